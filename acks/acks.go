@@ -0,0 +1,227 @@
+package acks
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// Ack is the decoded result of a channel acknowledgement, as written by ibc-go applications such as ICS-20 and ICS-27.
+type Ack struct {
+	// Result is the application-specific result of a successful acknowledgement.
+	Result []byte
+
+	// Error is set instead of Result for an error acknowledgement.
+	Error *Error
+}
+
+// Success returns true if the acknowledgement is not an error acknowledgement.
+func (a Ack) Success() bool {
+	return a.Error == nil
+}
+
+// Error is the error of an error acknowledgement.
+type Error struct {
+	// Codespace of the error.
+	// ibc-go does not write codespaces to acknowledgements,
+	// so Codespace is only set for expected errors from ErrorAck.
+	Codespace string
+
+	// Code is the ABCI code that ibc-go v4 and later write to error acknowledgements.
+	// It is zero for acknowledgements of earlier versions.
+	Code uint32
+
+	// Msg is the full error message before ibc-go v4,
+	// and the generic description that follows the ABCI code since.
+	Msg string
+}
+
+// String returns the error as written to the acknowledgement.
+func (e Error) String() string {
+	if e.Code == 0 {
+		return e.Msg
+	}
+	return fmt.Sprintf("ABCI code: %d: %s", e.Code, e.Msg)
+}
+
+// Codespaces of the registered errors matched by this package.
+const (
+	CodespaceSDK = "sdk"
+	// CodespaceIBC is the codespace of the errors of ibc-go's core errors package,
+	// which ibc-go v8 applications use instead of the SDK errors for their own failures.
+	CodespaceIBC = "ibc"
+)
+
+// Registered errors, which an error acknowledgement of ibc-go v4 and later identifies by ABCI code.
+var (
+	ErrSDKUnauthorized      = Error{Codespace: CodespaceSDK, Code: 4, Msg: "unauthorized"}
+	ErrSDKInsufficientFunds = Error{Codespace: CodespaceSDK, Code: 5, Msg: "insufficient funds"}
+	ErrSDKInvalidAddress    = Error{Codespace: CodespaceSDK, Code: 7, Msg: "invalid address"}
+
+	ErrIBCUnauthorized      = Error{Codespace: CodespaceIBC, Code: 2, Msg: "unauthorized"}
+	ErrIBCInsufficientFunds = Error{Codespace: CodespaceIBC, Code: 3, Msg: "insufficient funds"}
+	ErrIBCInvalidAddress    = Error{Codespace: CodespaceIBC, Code: 5, Msg: "invalid address"}
+)
+
+var registeredErrors = []Error{
+	ErrSDKUnauthorized, ErrSDKInsufficientFunds, ErrSDKInvalidAddress,
+	ErrIBCUnauthorized, ErrIBCInsufficientFunds, ErrIBCInvalidAddress,
+}
+
+// SuccessAck returns a successful acknowledgement with result.
+func SuccessAck(result []byte) Ack {
+	return Ack{Result: result}
+}
+
+// ErrorAck returns an error acknowledgement of the registered error with msg in codespace, such as "sdk" and "insufficient funds",
+// e.g. to compare with parsed acknowledgements through the matchers.
+// An unknown error has a zero code, like an acknowledgement before ibc-go v4 with the full error message msg.
+func ErrorAck(codespace, msg string) Ack {
+	for _, e := range registeredErrors {
+		if e.Codespace == codespace && e.Msg == msg {
+			e := e
+			return Ack{Error: &e}
+		}
+	}
+	return Ack{Error: &Error{Codespace: codespace, Msg: msg}}
+}
+
+// abciCodeRegexp matches the deterministic error of acknowledgements since ibc-go v4,
+// e.g. "ABCI code: 5: error handling packet: see events for details".
+var abciCodeRegexp = regexp.MustCompile(`^ABCI code: (\d+): (.*)$`)
+
+// Parse decodes the JSON of a channel acknowledgement, e.g. PacketAcknowledgement.Acknowledgement.
+func Parse(ack []byte) (Ack, error) {
+	var channelAck struct {
+		Result []byte  `json:"result"`
+		Error  *string `json:"error"`
+	}
+	if err := json.Unmarshal(ack, &channelAck); err != nil {
+		return Ack{}, fmt.Errorf("failed to decode acknowledgement %q: %w", ack, err)
+	}
+	if channelAck.Error == nil {
+		if channelAck.Result == nil {
+			return Ack{}, fmt.Errorf("acknowledgement %q has neither result nor error", ack)
+		}
+		return SuccessAck(channelAck.Result), nil
+	}
+
+	m := abciCodeRegexp.FindStringSubmatch(*channelAck.Error)
+	if m == nil {
+		return Ack{Error: &Error{Msg: *channelAck.Error}}, nil
+	}
+	code, err := strconv.ParseUint(m[1], 10, 32)
+	if err != nil {
+		return Ack{}, fmt.Errorf("invalid ABCI code of acknowledgement error %q: %w", *channelAck.Error, err)
+	}
+	return Ack{Error: &Error{Code: uint32(code), Msg: m[2]}}, nil
+}
+
+// ParseAckFromEvents decodes the acknowledgement of the first write_acknowledgement event,
+// such as from the events of a tx receiving a packet.
+func ParseAckFromEvents(events []abcitypes.Event) (Ack, error) {
+	ack, err := AckFromEvents(events)
+	if err != nil {
+		return Ack{}, err
+	}
+	return Parse(ack)
+}
+
+// AckFromEvents returns the encoded acknowledgement of the first write_acknowledgement event,
+// e.g. to relay it in a MsgAcknowledgement.
+func AckFromEvents(events []abcitypes.Event) ([]byte, error) {
+	for _, event := range events {
+		if event.Type != "write_acknowledgement" {
+			continue
+		}
+		attrs := make(map[string]string, len(event.Attributes))
+		for _, attr := range event.Attributes {
+			attrs[string(attr.Key)] = string(attr.Value)
+		}
+
+		if ackHex, ok := attrs["packet_ack_hex"]; ok {
+			ack, err := hex.DecodeString(ackHex)
+			if err != nil {
+				return nil, fmt.Errorf("invalid packet_ack_hex %q: %w", ackHex, err)
+			}
+			return ack, nil
+		}
+		if ack, ok := attrs["packet_ack"]; ok {
+			return []byte(ack), nil
+		}
+		return nil, errors.New("write_acknowledgement event has no acknowledgement")
+	}
+	return nil, errors.New("no write_acknowledgement event")
+}
+
+// errorClass is a kind of error that matchers recognize across ibc-go versions.
+type errorClass struct {
+	// Messages contained in the full error messages of acknowledgements before ibc-go v4.
+	legacyMsgs []string
+
+	sdk, ibc Error
+}
+
+var (
+	insufficientFunds = errorClass{legacyMsgs: []string{"insufficient funds"}, sdk: ErrSDKInsufficientFunds, ibc: ErrIBCInsufficientFunds}
+	unauthorized      = errorClass{legacyMsgs: []string{"unauthorized"}, sdk: ErrSDKUnauthorized, ibc: ErrIBCUnauthorized}
+	invalidAddress    = errorClass{legacyMsgs: []string{"invalid address", "decoding bech32 failed"}, sdk: ErrSDKInvalidAddress, ibc: ErrIBCInvalidAddress}
+)
+
+// matches reports whether a is an error acknowledgement of the class, written by a chain with the major version ibcGoMajor of ibc-go.
+func (c errorClass) matches(a Ack, ibcGoMajor int) bool {
+	if a.Error == nil {
+		return false
+	}
+	e := *a.Error
+
+	switch {
+	case e.Codespace != "":
+		// Expected errors from ErrorAck carry their codespace.
+		return e == c.sdk || e == c.ibc
+	case e.Code == 0:
+		for _, msg := range c.legacyMsgs {
+			if strings.Contains(e.Msg, msg) {
+				return true
+			}
+		}
+		return false
+	case e.Code == c.sdk.Code:
+		return true
+	default:
+		return ibcGoMajor >= 8 && e.Code == c.ibc.Code
+	}
+}
+
+// IsInsufficientFunds reports whether a is an error acknowledgement caused by insufficient funds,
+// written by a chain with the major version ibcGoMajor of ibc-go.
+//
+// Since acknowledgements of ibc-go v4 and later carry only an ABCI code without its codespace,
+// the codes of different errors may collide. In particular, from ibc-go v8 on,
+// ABCI code 5 is both the SDK's insufficient funds and ibc-go's invalid address error,
+// so IsInsufficientFunds and IsInvalidAddress both match it.
+func IsInsufficientFunds(a Ack, ibcGoMajor int) bool {
+	return insufficientFunds.matches(a, ibcGoMajor)
+}
+
+// IsUnauthorized reports whether a is an error acknowledgement caused by an unauthorized action,
+// such as an ICS-27 host rejecting a message type that is not allowed,
+// written by a chain with the major version ibcGoMajor of ibc-go.
+// See IsInsufficientFunds for the ambiguity of ABCI codes.
+func IsUnauthorized(a Ack, ibcGoMajor int) bool {
+	return unauthorized.matches(a, ibcGoMajor)
+}
+
+// IsInvalidAddress reports whether a is an error acknowledgement caused by an invalid address,
+// such as the receiver of an ICS-20 transfer,
+// written by a chain with the major version ibcGoMajor of ibc-go.
+// See IsInsufficientFunds for the ambiguity of ABCI codes.
+func IsInvalidAddress(a Ack, ibcGoMajor int) bool {
+	return invalidAddress.matches(a, ibcGoMajor)
+}
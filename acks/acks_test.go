@@ -0,0 +1,127 @@
+package acks
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// Error acknowledgements captured from chains of several ibc-go versions.
+var (
+	// ICS-20 transfer to an invalid receiver, on ibc-go v3.
+	v3InvalidReceiverAck = []byte(`{"error":"decoding bech32 failed: invalid checksum (expected 0dqqu5 got 2kqhcz)"}`)
+	// ICS-20 transfer of more vouchers than escrowed, on ibc-go v3.
+	v3InsufficientFundsAck = []byte(`{"error":"unable to unescrow tokens: 100uatom is smaller than 1000uatom: insufficient funds"}`)
+	// ICS-20 transfer of more vouchers than escrowed, on ibc-go v4.
+	v4InsufficientFundsAck = []byte(`{"error":"ABCI code: 5: error handling packet on destination chain: see events for details"}`)
+	// ICS-27 message type not allowed by the host, on ibc-go v6.
+	v6UnauthorizedAck = []byte(`{"error":"ABCI code: 4: error handling packet: see events for details"}`)
+	// ICS-20 transfer to an invalid receiver, on ibc-go v8.
+	v8InvalidReceiverAck = []byte(`{"error":"ABCI code: 5: error handling packet: see events for details"}`)
+	// ICS-27 message type not allowed by the host, on ibc-go v8.
+	v8UnauthorizedAck = []byte(`{"error":"ABCI code: 2: error handling packet: see events for details"}`)
+)
+
+func TestParse(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ack, err := Parse([]byte(`{"result":"AQ=="}`))
+		require.NoError(t, err)
+		require.True(t, ack.Success())
+		require.Equal(t, SuccessAck([]byte{1}), ack)
+	})
+
+	t.Run("legacy error", func(t *testing.T) {
+		ack, err := Parse(v3InvalidReceiverAck)
+		require.NoError(t, err)
+		require.False(t, ack.Success())
+		require.Zero(t, ack.Error.Code)
+		require.Equal(t, "decoding bech32 failed: invalid checksum (expected 0dqqu5 got 2kqhcz)", ack.Error.String())
+	})
+
+	t.Run("abci code error", func(t *testing.T) {
+		ack, err := Parse(v6UnauthorizedAck)
+		require.NoError(t, err)
+		require.Equal(t, &Error{Code: 4, Msg: "error handling packet: see events for details"}, ack.Error)
+		require.Equal(t, "ABCI code: 4: error handling packet: see events for details", ack.Error.String())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := Parse([]byte("not json"))
+		require.Error(t, err)
+
+		_, err = Parse([]byte("{}"))
+		require.ErrorContains(t, err, "neither result nor error")
+	})
+}
+
+func TestParseAckFromEvents(t *testing.T) {
+	event := func(attrs ...string) abcitypes.Event {
+		e := abcitypes.Event{Type: "write_acknowledgement"}
+		for i := 0; i < len(attrs); i += 2 {
+			e.Attributes = append(e.Attributes, abcitypes.EventAttribute{Key: []byte(attrs[i]), Value: []byte(attrs[i+1])})
+		}
+		return e
+	}
+
+	ack, err := ParseAckFromEvents([]abcitypes.Event{
+		{Type: "recv_packet"},
+		event("packet_ack", string(v4InsufficientFundsAck), "packet_ack_hex", hex.EncodeToString(v4InsufficientFundsAck)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint32(5), ack.Error.Code)
+
+	raw, err := AckFromEvents([]abcitypes.Event{event("packet_ack_hex", hex.EncodeToString(v4InsufficientFundsAck))})
+	require.NoError(t, err)
+	require.Equal(t, v4InsufficientFundsAck, raw)
+
+	ack, err = ParseAckFromEvents([]abcitypes.Event{event("packet_ack", `{"result":"AQ=="}`)})
+	require.NoError(t, err)
+	require.True(t, ack.Success())
+
+	_, err = ParseAckFromEvents([]abcitypes.Event{event()})
+	require.ErrorContains(t, err, "no acknowledgement")
+
+	_, err = ParseAckFromEvents(nil)
+	require.ErrorContains(t, err, "no write_acknowledgement event")
+}
+
+func TestMatchers(t *testing.T) {
+	parse := func(bz []byte) Ack {
+		ack, err := Parse(bz)
+		require.NoError(t, err)
+		return ack
+	}
+
+	for _, tt := range []struct {
+		name    string
+		ack     Ack
+		version int
+
+		insufficientFunds, unauthorized, invalidAddress bool
+	}{
+		{name: "success", ack: SuccessAck([]byte{1}), version: 6},
+		{name: "v3 invalid receiver", ack: parse(v3InvalidReceiverAck), version: 3, invalidAddress: true},
+		{name: "v3 insufficient funds", ack: parse(v3InsufficientFundsAck), version: 3, insufficientFunds: true},
+		{name: "v4 insufficient funds", ack: parse(v4InsufficientFundsAck), version: 4, insufficientFunds: true},
+		{name: "v6 unauthorized", ack: parse(v6UnauthorizedAck), version: 6, unauthorized: true},
+		// ABCI code 5 of ibc-go v8 is ambiguous without its codespace.
+		{name: "v8 invalid receiver", ack: parse(v8InvalidReceiverAck), version: 8, insufficientFunds: true, invalidAddress: true},
+		{name: "v8 unauthorized", ack: parse(v8UnauthorizedAck), version: 8, unauthorized: true},
+		// The IBC codespace is only used since ibc-go v8.
+		{name: "v6 code 2", ack: parse(v8UnauthorizedAck), version: 6},
+		{name: "expected sdk error", ack: ErrorAck(CodespaceSDK, "insufficient funds"), version: 8, insufficientFunds: true},
+		{name: "expected ibc error", ack: ErrorAck(CodespaceIBC, "invalid address"), version: 8, invalidAddress: true},
+		{name: "unknown error", ack: ErrorAck("transfer", "receive disabled"), version: 6},
+	} {
+		require.Equal(t, tt.insufficientFunds, IsInsufficientFunds(tt.ack, tt.version), tt.name)
+		require.Equal(t, tt.unauthorized, IsUnauthorized(tt.ack, tt.version), tt.name)
+		require.Equal(t, tt.invalidAddress, IsInvalidAddress(tt.ack, tt.version), tt.name)
+	}
+}
+
+func TestErrorAck(t *testing.T) {
+	require.Equal(t, &ErrSDKInsufficientFunds, ErrorAck(CodespaceSDK, "insufficient funds").Error)
+	require.Equal(t, &Error{Codespace: "transfer", Msg: "receive disabled"}, ErrorAck("transfer", "receive disabled").Error)
+}
@@ -0,0 +1,8 @@
+// Package acks provides typed channel acknowledgements and matchers for the errors of error acknowledgements.
+//
+// The error of an acknowledgement depends on the ibc-go version of the chain writing it:
+// before ibc-go v4, it is the full error message, e.g. "unable to unescrow tokens: ...: insufficient funds";
+// since ibc-go v4, it is a deterministic ABCI code without codespace, e.g. "ABCI code: 5: error handling packet: see events for details".
+// The matchers, such as IsInsufficientFunds, recognize errors in both forms,
+// so that tests need not compare raw strings that change between versions.
+package acks
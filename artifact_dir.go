@@ -0,0 +1,147 @@
+package interchaintest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+)
+
+// artifactRootDir is the directory under which TempArtifactDir creates per-test directories.
+// An empty value means a directory named interchaintest-artifacts inside os.TempDir().
+var artifactRootDir = os.Getenv("IBCTEST_ARTIFACT_DIR")
+
+// keepArtifactDirOnSuccess determines whether a directory created by TempArtifactDir
+// is retained following a successful test.
+var keepArtifactDirOnSuccess = os.Getenv("IBCTEST_KEEP_ARTIFACTS") != ""
+
+// SetArtifactRootDir sets the directory under which TempArtifactDir creates per-test directories.
+//
+// The value is initialized from the environment variable IBCTEST_ARTIFACT_DIR.
+// If empty, a directory named interchaintest-artifacts inside os.TempDir() is used.
+// Pointing CI at a known root allows archiving that single directory after a failed job.
+func SetArtifactRootDir(dir string) {
+	artifactRootDir = dir
+}
+
+// ArtifactRootDir reports the directory under which TempArtifactDir creates per-test directories.
+func ArtifactRootDir() string {
+	if artifactRootDir == "" {
+		return filepath.Join(os.TempDir(), "interchaintest-artifacts")
+	}
+	return artifactRootDir
+}
+
+// KeepArtifactDirOnSuccess sets whether a directory created by TempArtifactDir
+// is retained following a successful test.
+// Directories for failed tests are always retained.
+//
+// The value is false by default, but can be initialized to true by setting the
+// environment variable IBCTEST_KEEP_ARTIFACTS to a non-empty value.
+func KeepArtifactDirOnSuccess(b bool) {
+	keepArtifactDirOnSuccess = b
+}
+
+// KeepingArtifactDirOnSuccess reports the current value of KeepArtifactDirOnSuccess.
+// This function is only intended for tests.
+func KeepingArtifactDirOnSuccess() bool {
+	return keepArtifactDirOnSuccess
+}
+
+// artifactDirs tracks the artifact directory and reporter for each running test, keyed by test name.
+var artifactDirs = struct {
+	mu   sync.Mutex
+	dirs map[string]string
+	reps map[string]artifactReporter
+}{
+	dirs: make(map[string]string),
+	reps: make(map[string]artifactReporter),
+}
+
+type artifactReporter struct {
+	t   testreporter.T
+	rep *testreporter.Reporter
+}
+
+// TempArtifactDir returns the artifact directory for the test t,
+// creating it under ArtifactRootDir on the first call.
+// Every call from the same test returns the same directory,
+// so features that write artifacts (block databases, captured configuration, logs)
+// end up in exactly one directory per test.
+//
+// The directory is retained if the test fails, and removed when the test passes
+// unless KeepArtifactDirOnSuccess is set.
+// If ReportArtifactDir was called for t, the directory is tracked by that reporter.
+func TempArtifactDir(t TempDirTestingT) string {
+	t.Helper()
+
+	name := t.Name()
+
+	artifactDirs.mu.Lock()
+	defer artifactDirs.mu.Unlock()
+
+	if dir, ok := artifactDirs.dirs[name]; ok {
+		return dir
+	}
+
+	root := ArtifactRootDir()
+	if err := os.MkdirAll(root, 0755); err != nil {
+		// Panicking for the same reason as TempDir.
+		panic(fmt.Errorf("TempArtifactDir: %w", err))
+	}
+
+	dir, err := os.MkdirTemp(root, sanitizeTestName(name)+"-")
+	if err != nil {
+		panic(fmt.Errorf("TempArtifactDir: %w", err))
+	}
+	artifactDirs.dirs[name] = dir
+
+	if r, ok := artifactDirs.reps[name]; ok {
+		r.rep.TrackArtifactDir(r.t, dir)
+	}
+
+	t.Cleanup(func() {
+		artifactDirs.mu.Lock()
+		delete(artifactDirs.dirs, name)
+		artifactDirs.mu.Unlock()
+
+		if t.Failed() {
+			t.Logf("Keeping artifact directory for failed test at: %s", dir)
+			return
+		}
+		if keepArtifactDirOnSuccess {
+			t.Logf("Keeping artifact directory for test at: %s", dir)
+			return
+		}
+
+		if err := os.RemoveAll(dir); err != nil {
+			t.Errorf("TempArtifactDir RemoveAll cleanup: %v", err)
+		}
+	})
+
+	return dir
+}
+
+// ReportArtifactDir associates rep with t, so that the artifact directory
+// for t is tracked by rep, whether it has already been created or is created later
+// by any feature calling TempArtifactDir.
+func ReportArtifactDir(t testreporter.T, rep *testreporter.Reporter) {
+	name := t.Name()
+
+	artifactDirs.mu.Lock()
+	defer artifactDirs.mu.Unlock()
+
+	if dir, ok := artifactDirs.dirs[name]; ok {
+		rep.TrackArtifactDir(t, dir)
+		return
+	}
+
+	artifactDirs.reps[name] = artifactReporter{t: t, rep: rep}
+	t.Cleanup(func() {
+		artifactDirs.mu.Lock()
+		delete(artifactDirs.reps, name)
+		artifactDirs.mu.Unlock()
+	})
+}
@@ -0,0 +1,148 @@
+package interchaintest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/internal/mocktesting"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+// nopCloser wraps an io.Writer to provide a Close method that always returns nil.
+type nopCloser struct {
+	io.Writer
+}
+
+func (n nopCloser) Close() error {
+	return nil
+}
+
+func TestTempArtifactDir_Cleanup(t *testing.T) {
+	origKeep := interchaintest.KeepingArtifactDirOnSuccess()
+	origRoot := interchaintest.ArtifactRootDir()
+	defer func() {
+		interchaintest.KeepArtifactDirOnSuccess(origKeep)
+		interchaintest.SetArtifactRootDir(origRoot)
+	}()
+
+	root := t.TempDir()
+	interchaintest.SetArtifactRootDir(root)
+
+	for _, tc := range []struct {
+		name     string
+		keep     bool
+		failed   bool
+		wantKept bool
+	}{
+		{name: "passed", keep: false, failed: false, wantKept: false},
+		{name: "failed", keep: false, failed: true, wantKept: true},
+		{name: "passed with keep", keep: true, failed: false, wantKept: true},
+		{name: "failed with keep", keep: true, failed: true, wantKept: true},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			interchaintest.KeepArtifactDirOnSuccess(tc.keep)
+
+			mt := mocktesting.NewT(t.Name())
+
+			dir := interchaintest.TempArtifactDir(mt)
+			require.DirExists(t, dir)
+			require.Equal(t, root, filepath.Dir(dir))
+
+			if tc.failed {
+				mt.Fail()
+			}
+
+			mt.RunCleanups()
+
+			if !tc.wantKept {
+				require.NoDirExists(t, dir)
+				require.Empty(t, mt.Logs)
+				return
+			}
+
+			require.DirExists(t, dir)
+			require.NotEmpty(t, mt.Logs)
+			require.Contains(t, mt.Logs[len(mt.Logs)-1], dir)
+		})
+	}
+}
+
+func TestTempArtifactDir_SameDirPerTest(t *testing.T) {
+	origRoot := interchaintest.ArtifactRootDir()
+	defer interchaintest.SetArtifactRootDir(origRoot)
+	interchaintest.SetArtifactRootDir(t.TempDir())
+
+	mt := mocktesting.NewT("same_dir")
+	dir := interchaintest.TempArtifactDir(mt)
+	require.Equal(t, dir, interchaintest.TempArtifactDir(mt))
+
+	other := mocktesting.NewT("other_dir")
+	otherDir := interchaintest.TempArtifactDir(other)
+	require.NotEqual(t, dir, otherDir)
+
+	other.RunCleanups()
+	mt.RunCleanups()
+
+	// A new run of a test with the same name gets a fresh directory.
+	mt = mocktesting.NewT("same_dir")
+	newDir := interchaintest.TempArtifactDir(mt)
+	require.NotEqual(t, dir, newDir)
+	mt.RunCleanups()
+}
+
+func TestReportArtifactDir(t *testing.T) {
+	origRoot := interchaintest.ArtifactRootDir()
+	defer interchaintest.SetArtifactRootDir(origRoot)
+	interchaintest.SetArtifactRootDir(t.TempDir())
+
+	buf := new(bytes.Buffer)
+	rep := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	// Reporter registered before the directory is created.
+	before := mocktesting.NewT("report_before")
+	interchaintest.ReportArtifactDir(before, rep)
+	beforeDir := interchaintest.TempArtifactDir(before)
+
+	// Reporter registered after the directory is created.
+	after := mocktesting.NewT("report_after")
+	afterDir := interchaintest.TempArtifactDir(after)
+	interchaintest.ReportArtifactDir(after, rep)
+
+	before.Fail()
+	before.RunCleanups()
+	after.RunCleanups()
+	defer func() { _ = os.RemoveAll(beforeDir) }()
+
+	require.NoError(t, rep.Close())
+
+	var got []testreporter.ArtifactDirMessage
+	dec := json.NewDecoder(buf)
+	for {
+		var wm testreporter.WrappedMessage
+		err := dec.Decode(&wm)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if m, ok := wm.Message.(testreporter.ArtifactDirMessage); ok {
+			got = append(got, m)
+		}
+	}
+
+	require.Len(t, got, 2)
+	require.Equal(t, "report_before", got[0].Name)
+	require.Equal(t, beforeDir, got[0].Path)
+	require.Equal(t, "report_after", got[1].Name)
+	require.Equal(t, afterDir, got[1].Path)
+
+	// The failed test's directory is preserved, the passing one is removed.
+	require.DirExists(t, beforeDir)
+	require.NoDirExists(t, afterDir)
+}
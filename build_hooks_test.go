@@ -0,0 +1,51 @@
+package interchaintest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterchain_SetLinkChannelOpts(t *testing.T) {
+	a, b := chainIDChain{id: "a"}, chainIDChain{id: "b"}
+	r := &topologyRelayer{}
+	ic := NewInterchain().AddChain(a).AddChain(b).AddRelayer(r, "r").
+		AddLink(InterchainLink{Chain1: a, Chain2: b, Relayer: r, Path: "p"})
+
+	opts := ibc.CreateChannelOptions{SourcePortName: "wasm.a", DestPortName: "wasm.b", Order: ibc.Unordered, Version: "v1"}
+	require.NoError(t, ic.SetLinkChannelOpts(r, "p", opts))
+	require.Equal(t, opts, ic.links[relayerPath{Relayer: r, Path: "p"}].createChannelOpts)
+
+	require.EqualError(t, ic.SetLinkChannelOpts(r, "q", opts), `relayer "r" has no path named "q"`)
+
+	ic.linked = true
+	require.Error(t, ic.SetLinkChannelOpts(r, "p", opts))
+}
+
+func TestInterchain_BuildHooksOrder(t *testing.T) {
+	a, b := chainIDChain{id: "a"}, chainIDChain{id: "b"}
+	r1, r2 := &topologyRelayer{}, &topologyRelayer{}
+	ic := NewInterchain().AddChain(b).AddChain(a).AddRelayer(r2, "r2").AddRelayer(r1, "r1")
+
+	require.Equal(t, []ibc.Chain{a, b}, ic.sortedChains())
+
+	var relayers []ibc.Relayer
+	require.NoError(t, ic.afterRelayerConfigured(context.Background(), BuildHooks{
+		AfterRelayerConfigured: func(_ context.Context, rs []ibc.Relayer) error {
+			relayers = rs
+			return nil
+		},
+	}))
+	require.Equal(t, []ibc.Relayer{r1, r2}, relayers)
+
+	errHook := errors.New("hook failed")
+	err := ic.afterRelayerConfigured(context.Background(), BuildHooks{
+		AfterRelayerConfigured: func(context.Context, []ibc.Relayer) error { return errHook },
+	})
+	require.ErrorIs(t, err, errHook)
+
+	require.NoError(t, ic.afterRelayerConfigured(context.Background(), BuildHooks{}))
+}
@@ -0,0 +1,255 @@
+package interchaintest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/internal/mocktesting"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// buildEvents records the phases of Interchain.Build, which initializes and starts chains concurrently.
+type buildEvents struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (e *buildEvents) add(event string) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.events = append(e.events, event)
+}
+
+func (e *buildEvents) get() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.events...)
+}
+
+// lifecycleChain is an ibc.Chain that Interchain.Build can initialize and start without docker,
+// recording its initialization and start in events, if set.
+// Calling any other method panics.
+type lifecycleChain struct {
+	ibc.Chain
+	id     string
+	events *buildEvents
+}
+
+func (c lifecycleChain) Config() ibc.ChainConfig {
+	return ibc.ChainConfig{ChainID: c.id, Name: c.id, Denom: "stake", Bech32Prefix: "cosmos"}
+}
+
+func (c lifecycleChain) Initialize(context.Context, string, *client.Client, string) error {
+	c.events.add("initialize " + c.id)
+	return nil
+}
+
+func (c lifecycleChain) Start(string, context.Context, ...ibc.WalletAmount) error {
+	c.events.add("start " + c.id)
+	return nil
+}
+
+func (c lifecycleChain) BuildWallet(_ context.Context, keyName, mnemonic string) (ibc.Wallet, error) {
+	return cosmos.NewWallet(keyName, []byte(keyName), mnemonic, c.Config()), nil
+}
+
+func (c lifecycleChain) BuildRelayerWallet(ctx context.Context, keyName string) (ibc.Wallet, error) {
+	return c.BuildWallet(ctx, keyName, "mnemonic of "+keyName)
+}
+
+func (c lifecycleChain) GetRPCAddress() string  { return "http://" + c.id + ":26657" }
+func (c lifecycleChain) GetGRPCAddress() string { return c.id + ":9090" }
+
+// execRelayer is an ibc.Relayer that reports an exec for each chain it is configured for and each key it restores,
+// as docker relayers do, recording its configuration in events, if set. Calling any other method panics.
+type execRelayer struct {
+	ibc.Relayer
+	events *buildEvents
+}
+
+func (r *execRelayer) UseDockerNetwork() bool { return true }
+
+func (r *execRelayer) AddChainConfiguration(_ context.Context, rep ibc.RelayerExecReporter, cfg ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) error {
+	r.events.add("configure relayer for " + cfg.ChainID)
+	now := time.Now()
+	rep.TrackRelayerExec("relayer", []string{"rly", "chains", "add", cfg.ChainID}, "", "", 0, now, now, nil)
+	return nil
+}
+
+func (r *execRelayer) RestoreKey(_ context.Context, rep ibc.RelayerExecReporter, chainID, keyName, coinType, mnemonic string) error {
+	r.events.add("restore relayer key for " + chainID)
+	now := time.Now()
+	rep.TrackRelayerExec("relayer", []string{"rly", "keys", "restore", chainID, keyName}, "", "", 0, now, now, nil)
+	return nil
+}
+
+func TestInterchain_BuildRecordsRelayerExecs(t *testing.T) {
+	a, b := lifecycleChain{id: "a"}, lifecycleChain{id: "b"}
+	r := &execRelayer{}
+	ic := NewInterchain().AddChain(a).AddChain(b).AddRelayer(r, "r").
+		AddLink(InterchainLink{Chain1: a, Chain2: b, Relayer: r, Path: "p"})
+
+	mt := mocktesting.NewT(t.Name())
+	rec := testreporter.NewNopReporter().RelayerExecRecorder(mt)
+
+	require.NoError(t, ic.Build(context.Background(), rec.RelayerExecReporter, InterchainBuildOptions{
+		TestName:         t.Name(),
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	execs := rec.Execs()
+	require.Len(t, execs, 4)
+	commands := make(map[string]bool, len(execs))
+	for _, e := range execs {
+		require.Equal(t, "r", e.Relayer, "exec must be attributed to the relayer's name in the interchain")
+		require.Equal(t, t.Name(), e.Name)
+		commands[e.Command[1]+" "+e.Command[2]+" "+e.Command[3]] = true
+	}
+	require.Equal(t, map[string]bool{
+		"chains add a": true, "keys restore a": true,
+		"chains add b": true, "keys restore b": true,
+	}, commands)
+}
+
+func TestInterchain_CloseTracksQueryStats(t *testing.T) {
+	c := cosmos.NewCosmosChain(t.Name(), ibc.ChainConfig{ChainID: "a-1"}, 1, 0, zaptest.NewLogger(t))
+	ic := NewInterchain().AddChain(c)
+
+	// Set up what Build sets up for Close, which needs docker for a cosmos chain.
+	f, err := os.Create(filepath.Join(t.TempDir(), "report.json"))
+	require.NoError(t, err)
+	r := testreporter.NewReporter(f)
+	ic.rep = r.RelayerExecReporter(mocktesting.NewT("my_test"))
+	ic.cs = newChainSet(ic.log, nil)
+
+	require.NoError(t, ic.Close())
+	require.NoError(t, r.Close())
+
+	bz, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	metrics := make(map[string]float64)
+	for _, line := range bytes.Split(bytes.TrimSpace(bz), []byte("\n")) {
+		var m testreporter.WrappedMessage
+		require.NoError(t, json.Unmarshal(line, &m))
+		if mm, ok := m.Message.(testreporter.MetricMessage); ok {
+			require.Equal(t, "my_test", mm.Name)
+			require.Equal(t, "queries", mm.Unit)
+			metrics[mm.Metric] = mm.Value
+		}
+	}
+	require.Equal(t, map[string]float64{"a-1 throttled queries": 0, "a-1 retried queries": 0}, metrics)
+}
+
+func TestInterchain_BuildHooks(t *testing.T) {
+	errHook := errors.New("hook failed")
+
+	// build builds an interchain of two chains linked by a relayer with hooks,
+	// which record their calls in the returned events.
+	build := func(t *testing.T, hooks func(events *buildEvents) BuildHooks) ([]string, error) {
+		t.Helper()
+
+		events := new(buildEvents)
+		a, b := lifecycleChain{id: "a", events: events}, lifecycleChain{id: "b", events: events}
+		r := &execRelayer{events: events}
+		ic := NewInterchain().AddChain(a).AddChain(b).AddRelayer(r, "r").
+			AddLink(InterchainLink{Chain1: a, Chain2: b, Relayer: r, Path: "p"})
+
+		rep := testreporter.NewNopReporter().RelayerExecReporter(mocktesting.NewT(t.Name()))
+		err := ic.Build(context.Background(), rep, InterchainBuildOptions{
+			TestName:         t.Name(),
+			SkipPathCreation: true,
+			Hooks:            hooks(events),
+		})
+		t.Cleanup(func() {
+			_ = ic.Close()
+		})
+		return events.get(), err
+	}
+
+	t.Run("order", func(t *testing.T) {
+		events, err := build(t, func(events *buildEvents) BuildHooks {
+			return BuildHooks{
+				BeforeStart: func(_ context.Context, chains []ibc.Chain) error {
+					require.Len(t, chains, 2)
+					events.add("BeforeStart")
+					return nil
+				},
+				AfterChainStart: func(_ context.Context, chains []ibc.Chain) error {
+					require.Len(t, chains, 2)
+					events.add("AfterChainStart")
+					return nil
+				},
+				AfterRelayerConfigured: func(_ context.Context, relayers []ibc.Relayer) error {
+					require.Len(t, relayers, 1)
+					events.add("AfterRelayerConfigured")
+					return nil
+				},
+			}
+		})
+		require.NoError(t, err)
+
+		// The chains are initialized, started and configured on the relayer concurrently.
+		require.Len(t, events, 11)
+		require.ElementsMatch(t, []string{"initialize a", "initialize b"}, events[:2])
+		require.Equal(t, "BeforeStart", events[2])
+		require.ElementsMatch(t, []string{"start a", "start b"}, events[3:5])
+		require.Equal(t, "AfterChainStart", events[5])
+		require.ElementsMatch(t, []string{
+			"configure relayer for a", "restore relayer key for a",
+			"configure relayer for b", "restore relayer key for b",
+		}, events[6:10])
+		require.Equal(t, "AfterRelayerConfigured", events[10])
+	})
+
+	t.Run("BeforeStart error", func(t *testing.T) {
+		events, err := build(t, func(*buildEvents) BuildHooks {
+			return BuildHooks{
+				BeforeStart: func(context.Context, []ibc.Chain) error { return errHook },
+				AfterChainStart: func(context.Context, []ibc.Chain) error {
+					t.Error("AfterChainStart called after BeforeStart failed")
+					return nil
+				},
+			}
+		})
+		require.ErrorIs(t, err, errHook)
+		require.ErrorContains(t, err, "BeforeStart hook")
+		// The chains are not started.
+		require.ElementsMatch(t, []string{"initialize a", "initialize b"}, events)
+	})
+
+	t.Run("AfterChainStart error", func(t *testing.T) {
+		events, err := build(t, func(*buildEvents) BuildHooks {
+			return BuildHooks{
+				AfterChainStart: func(context.Context, []ibc.Chain) error { return errHook },
+				AfterRelayerConfigured: func(context.Context, []ibc.Relayer) error {
+					t.Error("AfterRelayerConfigured called after AfterChainStart failed")
+					return nil
+				},
+			}
+		})
+		require.ErrorIs(t, err, errHook)
+		require.ErrorContains(t, err, "AfterChainStart hook")
+		// The relayer is not configured.
+		require.Len(t, events, 4)
+		require.ElementsMatch(t, []string{"initialize a", "initialize b"}, events[:2])
+		require.ElementsMatch(t, []string{"start a", "start b"}, events[2:])
+	})
+}
@@ -0,0 +1,47 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+)
+
+// AddArchiveFullNode adds a fullnode that never prunes its state, peering with the existing nodes,
+// and blocks until it has caught up with the chain.
+// The returned node answers queries, e.g. ExecQueryAtHeight, for any height since genesis,
+// even when the other nodes prune, as configured in the chain's app.toml overrides.
+//
+// The node is configured with the chain's ConfigFileOverrides, except for the pruning settings.
+// It syncs by replaying blocks from its peers, so the other nodes must retain their blocks, i.e. keep min-retain-blocks at 0.
+// As with AddFullNodes, if the chain had no fullnodes, the archive node becomes the node used for the chain's queries.
+func (c *CosmosChain) AddArchiveFullNode(ctx context.Context) (*ChainNode, error) {
+	if err := c.AddFullNodes(ctx, archiveConfigFileOverrides(c.cfg.ConfigFileOverrides), 1); err != nil {
+		return nil, fmt.Errorf("failed to add archive node: %w", err)
+	}
+	n := c.FullNodes[len(c.FullNodes)-1]
+
+	if err := testutil.WaitForInSync(ctx, c, n); err != nil {
+		return nil, fmt.Errorf("archive node %s did not catch up: %w", n.Name(), err)
+	}
+	return n, nil
+}
+
+// archiveConfigFileOverrides returns a copy of overrides, with pruning disabled in app.toml.
+func archiveConfigFileOverrides(overrides map[string]any) map[string]any {
+	out := make(map[string]any, len(overrides)+1)
+	for file, o := range overrides {
+		out[file] = o
+	}
+
+	app := make(testutil.Toml)
+	if existing, ok := overrides["config/app.toml"].(testutil.Toml); ok {
+		for k, v := range existing {
+			app[k] = v
+		}
+	}
+	app["pruning"] = "nothing"
+	app["min-retain-blocks"] = 0
+	out["config/app.toml"] = app
+	return out
+}
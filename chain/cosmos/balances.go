@@ -0,0 +1,61 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// AllBalances returns the balances of address in every denom, e.g. to assert that nothing else changed
+// besides the denoms a test expects.
+func (c *CosmosChain) AllBalances(ctx context.Context, address string) (sdk.Coins, error) {
+	return c.queryBalances(ctx, func(queryClient bankTypes.QueryClient, page *query.PageRequest) (sdk.Coins, *query.PageResponse, error) {
+		res, err := queryClient.AllBalances(ctx, &bankTypes.QueryAllBalancesRequest{Address: address, Pagination: page})
+		if err != nil {
+			return nil, nil, fmt.Errorf("query all balances of %s: %w", address, err)
+		}
+		return res.Balances, res.Pagination, nil
+	})
+}
+
+// SpendableBalances returns the balances of address in every denom that are not locked, e.g. by vesting.
+func (c *CosmosChain) SpendableBalances(ctx context.Context, address string) (sdk.Coins, error) {
+	return c.queryBalances(ctx, func(queryClient bankTypes.QueryClient, page *query.PageRequest) (sdk.Coins, *query.PageResponse, error) {
+		res, err := queryClient.SpendableBalances(ctx, &bankTypes.QuerySpendableBalancesRequest{Address: address, Pagination: page})
+		if err != nil {
+			return nil, nil, fmt.Errorf("query spendable balances of %s: %w", address, err)
+		}
+		return res.Balances, res.Pagination, nil
+	})
+}
+
+// queryBalances collects the balances of all pages returned by queryPage.
+func (c *CosmosChain) queryBalances(
+	ctx context.Context,
+	queryPage func(bankTypes.QueryClient, *query.PageRequest) (sdk.Coins, *query.PageResponse, error),
+) (sdk.Coins, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	queryClient := bankTypes.NewQueryClient(conn)
+	var balances sdk.Coins
+	var nextKey []byte
+	for {
+		page, pageRes, err := queryPage(queryClient, &query.PageRequest{Key: nextKey})
+		if err != nil {
+			return nil, err
+		}
+		balances = balances.Add(page...)
+		if pageRes == nil || len(pageRes.NextKey) == 0 {
+			return balances, nil
+		}
+		nextKey = pageRes.NextKey
+	}
+}
@@ -0,0 +1,193 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v6/modules/core/02-client/types"
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	ibcexported "github.com/cosmos/ibc-go/v6/modules/core/exported"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// IBCTransfer is a single ICS-20 transfer of a batch sent with BatchSendIBCTransfer.
+type IBCTransfer struct {
+	// ChannelID is the transfer channel on the sending chain.
+	ChannelID string
+	Amount    ibc.WalletAmount
+}
+
+// BatchSendIBCTransfer sends a single transaction signed by user, with one MsgTransfer per transfer.
+// The transfers may use different channels.
+// Timeouts in options are relative, as with ibc.Chain's SendIBCTransfer;
+// without a timeout, packets time out ten minutes after they are sent.
+// The returned transactions are in the order of transfers and carry each message's packet,
+// so that sequences can be mapped back to their amounts.
+func BatchSendIBCTransfer(ctx context.Context, broadcaster *Broadcaster, user User, transfers []IBCTransfer, options ibc.TransferOptions) ([]ibc.Tx, error) {
+	if len(transfers) == 0 {
+		return nil, fmt.Errorf("no transfers to send")
+	}
+
+	c := broadcaster.chain
+	sender := user.FormattedAddressWithPrefix(c.Config().Bech32Prefix)
+
+	timeoutTimestamp := uint64(time.Now().Add(time.Duration(transfertypes.DefaultRelativePacketTimeoutTimestamp)).UnixNano())
+	if options.Timeout != nil && options.Timeout.NanoSeconds > 0 {
+		timeoutTimestamp = uint64(time.Now().UnixNano()) + options.Timeout.NanoSeconds
+	}
+
+	msgs := make([]sdk.Msg, len(transfers))
+	for i, transfer := range transfers {
+		var timeoutHeight clienttypes.Height
+		switch {
+		case options.Timeout == nil || options.Timeout.NanoSeconds > 0:
+		case !options.Timeout.AbsoluteHeight.IsZero():
+			timeoutHeight = options.Timeout.AbsoluteHeight.ClientHeight()
+		case options.Timeout.Height > 0:
+			latest, err := c.counterpartyLatestHeight(ctx, "transfer", transfer.ChannelID)
+			if err != nil {
+				return nil, err
+			}
+			timeoutHeight = ibc.HeightFromIBC(latest).Add(options.Timeout.Height).ClientHeight()
+		}
+
+		msgs[i] = transfertypes.NewMsgTransfer(
+			"transfer",
+			transfer.ChannelID,
+			sdk.NewInt64Coin(transfer.Amount.Denom, transfer.Amount.Amount),
+			sender,
+			transfer.Amount.Address,
+			timeoutHeight,
+			timeoutTimestamp,
+			options.Memo,
+		)
+	}
+
+	f, err := broadcaster.GetFactory(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	// The default gas limit is meant for a single message.
+	f = f.WithGas(flags.DefaultGasLimit * uint64(len(msgs)))
+
+	cc, err := broadcaster.GetClientContext(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.BroadcastTx(cc, f, msgs...); err != nil {
+		return nil, fmt.Errorf("broadcast ibc transfers: %w", err)
+	}
+
+	txBytes, err := broadcaster.GetTxResponseBytes(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := broadcaster.UnmarshalTxResponseBytes(ctx, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	txResp, err := c.getTransaction(resp.TxHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction %s: %w", resp.TxHash, err)
+	}
+	if txResp.Code != 0 {
+		return nil, fmt.Errorf("ibc transfer transaction %s failed with code %d: %s", resp.TxHash, txResp.Code, txResp.RawLog)
+	}
+
+	packets, err := sendPacketsFromEvents(txResp.Events)
+	if err != nil {
+		return nil, fmt.Errorf("transaction %s: %w", resp.TxHash, err)
+	}
+	if len(packets) != len(transfers) {
+		return nil, fmt.Errorf("transaction %s sent %d packets, expected %d", resp.TxHash, len(packets), len(transfers))
+	}
+
+	txs := make([]ibc.Tx, len(packets))
+	for i, packet := range packets {
+		txs[i] = ibc.Tx{
+			Height: uint64(txResp.Height),
+			TxHash: resp.TxHash,
+			// In cosmos, user is charged for entire gas requested, not the actual gas used.
+			GasSpent: txResp.GasWanted,
+			Packet:   packet,
+		}
+	}
+	return txs, nil
+}
+
+// counterpartyLatestHeight returns the latest height of the counterparty chain
+// known to the client of the channel with portID and channelID.
+func (c *CosmosChain) counterpartyLatestHeight(ctx context.Context, portID, channelID string) (ibcexported.Height, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	queryClient := chantypes.NewQueryClient(conn)
+	res, err := queryClient.ChannelClientState(ctx, &chantypes.QueryChannelClientStateRequest{PortId: portID, ChannelId: channelID})
+	if err != nil {
+		return nil, fmt.Errorf("query client state of channel %s/%s: %w", portID, channelID, err)
+	}
+
+	var clientState ibcexported.ClientState
+	if err := c.cfg.EncodingConfig.InterfaceRegistry.UnpackAny(res.IdentifiedClientState.ClientState, &clientState); err != nil {
+		return nil, fmt.Errorf("unpack client state of channel %s/%s: %w", portID, channelID, err)
+	}
+	return clientState.GetLatestHeight(), nil
+}
+
+// sendPacketsFromEvents returns the packets of all send_packet events, in order.
+func sendPacketsFromEvents(events []abcitypes.Event) ([]ibc.Packet, error) {
+	return packetsFromEvents(events, "send_packet")
+}
+
+// packetsFromEvents returns the packets of all events of eventType, in order.
+// Packets of events without packet_data, such as timeout_packet, have no data.
+func packetsFromEvents(events []abcitypes.Event, eventType string) ([]ibc.Packet, error) {
+	var packets []ibc.Packet
+	for _, event := range events {
+		if event.Type != eventType {
+			continue
+		}
+		attrs := make(map[string]string, len(event.Attributes))
+		for _, attr := range event.Attributes {
+			attrs[string(attr.Key)] = string(attr.Value)
+		}
+
+		seq, err := strconv.ParseUint(attrs["packet_sequence"], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid packet sequence from events %s: %w", attrs["packet_sequence"], err)
+		}
+		timeoutNano, err := strconv.ParseUint(attrs["packet_timeout_timestamp"], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid packet timestamp timeout %s: %w", attrs["packet_timeout_timestamp"], err)
+		}
+
+		packet := ibc.Packet{
+			Sequence:         seq,
+			SourcePort:       attrs["packet_src_port"],
+			SourceChannel:    attrs["packet_src_channel"],
+			DestPort:         attrs["packet_dst_port"],
+			DestChannel:      attrs["packet_dst_channel"],
+			TimeoutHeight:    attrs["packet_timeout_height"],
+			TimeoutTimestamp: ibc.Nanoseconds(timeoutNano),
+			Data:             []byte(attrs["packet_data"]),
+		}
+		if _, err := packet.ParsedTimeoutHeight(); err != nil {
+			return nil, fmt.Errorf("invalid packet timeout height from events: %w", err)
+		}
+		packets = append(packets, packet)
+	}
+	return packets, nil
+}
@@ -0,0 +1,194 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v6/internal/clicompat"
+	"go.uber.org/zap"
+	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v3"
+)
+
+// sdkModule is the module path of the Cosmos SDK among the build dependencies of a chain binary.
+const sdkModule = "github.com/cosmos/cosmos-sdk"
+
+// BinaryVersion is the version of a chain binary, as reported by its version --long command.
+type BinaryVersion struct {
+	Name       string // e.g. gaia
+	ServerName string // e.g. gaiad
+	Version    string // e.g. v7.1.0
+	Commit     string
+
+	// Version of the Cosmos SDK the binary is built with, e.g. v0.45.11,
+	// that of the replacement module if the SDK is replaced by a fork. Empty if unknown.
+	SDKVersion string
+}
+
+// ParseBinaryVersion parses the output of the version --long command of a chain binary,
+// be it JSON, as with --output json, or the default plain text, which older binaries print without --long details.
+func ParseBinaryVersion(out []byte) (BinaryVersion, error) {
+	out = bytes.TrimSpace(out)
+	if len(out) == 0 {
+		return BinaryVersion{}, errors.New("empty version output")
+	}
+
+	// JSON is valid YAML, so both outputs of version --long parse as YAML.
+	var info struct {
+		Name             string   `yaml:"name"`
+		ServerName       string   `yaml:"server_name"`
+		Version          string   `yaml:"version"`
+		Commit           string   `yaml:"commit"`
+		BuildDeps        []string `yaml:"build_deps"`
+		CosmosSDKVersion string   `yaml:"cosmos_sdk_version"`
+	}
+	if err := yaml.Unmarshal(out, &info); err != nil || info.Version == "" {
+		// The plain version only, as printed without --long.
+		if line := string(out); !strings.ContainsAny(line, " \t\n:{") {
+			return BinaryVersion{Version: line}, nil
+		}
+		return BinaryVersion{}, fmt.Errorf("unrecognized version output %q", out)
+	}
+
+	v := BinaryVersion{
+		Name:       info.Name,
+		ServerName: info.ServerName,
+		Version:    info.Version,
+		Commit:     info.Commit,
+		SDKVersion: info.CosmosSDKVersion,
+	}
+	if v.SDKVersion == "" {
+		v.SDKVersion = sdkDepVersion(info.BuildDeps)
+	}
+	return v, nil
+}
+
+// sdkDepVersion returns the version of the SDK among the build dependencies deps,
+// in the form module@version, or module@version => replacement@version for replaced modules.
+func sdkDepVersion(deps []string) string {
+	for _, dep := range deps {
+		mod, replacement, replaced := strings.Cut(dep, " => ")
+		path, version, _ := strings.Cut(strings.TrimSpace(mod), "@")
+		if path != sdkModule {
+			continue
+		}
+		if replaced {
+			if _, rv, ok := strings.Cut(strings.TrimSpace(replacement), "@"); ok {
+				return rv
+			}
+		}
+		return version
+	}
+	return ""
+}
+
+// SDKAtLeast reports whether the binary is built with the SDK version minVersion, e.g. v0.46.0, or newer.
+// It reports false if the SDK version is unknown.
+func (v BinaryVersion) SDKAtLeast(minVersion string) bool {
+	sdk := canonicalVersion(v.SDKVersion)
+	return sdk != "" && semver.Compare(sdk, canonicalVersion(minVersion)) >= 0
+}
+
+// Incompatibilities returns the known incompatibilities of the binary with interchaintest
+// or with the image tag imageVersion, e.g. the tag was bumped but the binary reports another version.
+func (v BinaryVersion) Incompatibilities(imageVersion string) []string {
+	var issues []string
+	if iv, bv := canonicalVersion(imageVersion), canonicalVersion(v.Version); iv != "" && bv != "" && iv != bv {
+		issues = append(issues, fmt.Sprintf("image version %s differs from binary version %s", imageVersion, v.Version))
+	}
+	if v.SDKVersion == "" {
+		return issues
+	}
+	if !v.SDKAtLeast("v0.46.0") {
+		issues = append(issues, fmt.Sprintf("SDK %s has no gov v1: gov v1 queries and ChainNode.SubmitProposal fail, SubmitProposal submits legacy proposals", v.SDKVersion))
+	}
+	return issues
+}
+
+// canonicalVersion returns the canonical semantic version of version, with or without the v prefix,
+// or an empty string if it is not a semantic version, e.g. an image tag such as main.
+func canonicalVersion(version string) string {
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	return semver.Canonical(version)
+}
+
+// BinaryVersion returns the version of the chain binary, probed in the chain image before Start,
+// and false if it is not probed yet or the binary did not report it.
+func (c *CosmosChain) BinaryVersion() (BinaryVersion, bool) {
+	if c.binaryVersion == nil {
+		return BinaryVersion{}, false
+	}
+	return *c.binaryVersion, true
+}
+
+// probeBinaryVersion runs the version command of the binary in the image of the first validator,
+// recording the reported version and logging the known incompatibilities it has.
+// A binary that reports no version is only logged, as the version is informational.
+func (c *CosmosChain) probeBinaryVersion(ctx context.Context) {
+	if len(c.Validators) == 0 {
+		return
+	}
+	tn := c.Validators[0]
+	stdout, stderr, err := tn.Exec(ctx, []string{c.cfg.Bin, "version", "--long"}, nil)
+	if err != nil {
+		c.log.Warn("Failed to probe chain binary version", zap.String("chain_id", c.cfg.ChainID), zap.Error(err))
+		return
+	}
+	// Older binaries print the version to stderr.
+	out := stdout
+	if len(bytes.TrimSpace(out)) == 0 {
+		out = stderr
+	}
+	v, err := ParseBinaryVersion(out)
+	if err != nil {
+		c.log.Warn("Failed to parse chain binary version", zap.String("chain_id", c.cfg.ChainID), zap.Error(err))
+		return
+	}
+	c.binaryVersion = &v
+
+	c.log.Info("Chain binary version",
+		zap.String("chain_id", c.cfg.ChainID),
+		zap.String("version", v.Version),
+		zap.String("sdk_version", v.SDKVersion),
+	)
+	for _, issue := range v.Incompatibilities(tn.Image.Version) {
+		c.log.Warn("Chain binary version incompatibility", zap.String("chain_id", c.cfg.ChainID), zap.String("issue", issue))
+	}
+}
+
+// probeGenesisCommand records whether the binary has the genesis command, under which SDK v0.47 moved
+// add-genesis-account, gentx and collect-gentxs. Binaries of SDK v0.47 may keep them at the top level too,
+// but those of SDK v0.50 only have them under the genesis command, so the command is probed rather than
+// derived from the SDK version.
+func (c *CosmosChain) probeGenesisCommand(ctx context.Context) {
+	if len(c.Validators) == 0 {
+		return
+	}
+	// Cobra fails with an unknown command error if the binary has no genesis command.
+	_, _, err := c.Validators[0].Exec(ctx, []string{c.cfg.Bin, "genesis", "--help"}, nil)
+	c.hasGenesisCommand = err == nil
+}
+
+// genesisCommand returns command, e.g. add-genesis-account, under the genesis command if the binary of tn has it.
+func (tn *ChainNode) genesisCommand(command ...string) []string {
+	if c, ok := tn.Chain.(*CosmosChain); ok && c.hasGenesisCommand {
+		return append([]string{"genesis"}, command...)
+	}
+	return command
+}
+
+// cliFormat returns the format of the JSON output of the CLI queries of the binary of tn,
+// by the SDK version of the binary, or detected from each output if the version is unknown.
+func (tn *ChainNode) cliFormat() clicompat.Format {
+	if c, ok := tn.Chain.(*CosmosChain); ok {
+		if v, ok := c.BinaryVersion(); ok {
+			return clicompat.ForSDKVersion(v.SDKVersion)
+		}
+	}
+	return clicompat.Detect
+}
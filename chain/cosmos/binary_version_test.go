@@ -0,0 +1,87 @@
+package cosmos_test
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBinaryVersion(t *testing.T) {
+	for _, tc := range []struct {
+		name, out string
+		want      cosmos.BinaryVersion
+	}{
+		{
+			name: "text",
+			out: `name: gaia
+server_name: gaiad
+version: v7.1.0
+commit: 7e5e4ebe8ba3dd9f3da2d5c11e0eac0c21fbb7d8
+build_tags: netgo,ledger
+go: go version go1.18.5 linux/amd64
+build_deps:
+- github.com/confio/ics23/go@v0.7.0
+- github.com/cosmos/cosmos-sdk@v0.45.6 => github.com/cosmos/cosmos-sdk@v0.45.11
+`,
+			want: cosmos.BinaryVersion{
+				Name:       "gaia",
+				ServerName: "gaiad",
+				Version:    "v7.1.0",
+				Commit:     "7e5e4ebe8ba3dd9f3da2d5c11e0eac0c21fbb7d8",
+				SDKVersion: "v0.45.11",
+			},
+		},
+		{
+			name: "json",
+			out:  `{"name":"osmosis","server_name":"osmosisd","version":"11.0.0","commit":"abc","build_deps":["github.com/cosmos/cosmos-sdk@v0.45.1"],"cosmos_sdk_version":"v0.45.1"}`,
+			want: cosmos.BinaryVersion{
+				Name:       "osmosis",
+				ServerName: "osmosisd",
+				Version:    "11.0.0",
+				Commit:     "abc",
+				SDKVersion: "v0.45.1",
+			},
+		},
+		{
+			name: "version only",
+			out:  "v0.46.2\n",
+			want: cosmos.BinaryVersion{Version: "v0.46.2"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := cosmos.ParseBinaryVersion([]byte(tc.out))
+			require.NoError(t, err)
+			require.Equal(t, tc.want, v)
+		})
+	}
+
+	_, err := cosmos.ParseBinaryVersion(nil)
+	require.ErrorContains(t, err, "empty version output")
+	_, err = cosmos.ParseBinaryVersion([]byte("Error: unknown command \"version\""))
+	require.ErrorContains(t, err, "unrecognized version output")
+}
+
+func TestBinaryVersion_Incompatibilities(t *testing.T) {
+	v := cosmos.BinaryVersion{Version: "v7.1.0", SDKVersion: "v0.45.11"}
+	require.True(t, v.SDKAtLeast("v0.45.0"))
+	require.False(t, v.SDKAtLeast("v0.46.0"))
+
+	issues := v.Incompatibilities("v7.1.0")
+	require.Len(t, issues, 1)
+	require.Contains(t, issues[0], "has no gov v1")
+
+	issues = v.Incompatibilities("v8.0.0")
+	require.Len(t, issues, 2)
+	require.Equal(t, "image version v8.0.0 differs from binary version v7.1.0", issues[0])
+
+	v = cosmos.BinaryVersion{Version: "v0.46.2", SDKVersion: "v0.46.2"}
+	require.Empty(t, v.Incompatibilities("local"))
+	require.Empty(t, cosmos.BinaryVersion{Version: "11.0.0"}.Incompatibilities("v11.0.0"))
+
+	v.SDKVersion = "v0.47.0-rc1"
+	require.False(t, v.SDKAtLeast("v0.47.0"), "pre-release is older than the release")
+
+	require.Empty(t, cosmos.BinaryVersion{Version: "v1.0.0"}.Incompatibilities("v1.0.0"))
+	require.False(t, cosmos.BinaryVersion{}.SDKAtLeast("v0.40.0"))
+}
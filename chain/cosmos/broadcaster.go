@@ -87,7 +87,14 @@ func (b *Broadcaster) GetFactory(ctx context.Context, user User) (tx.Factory, er
 		return tx.Factory{}, err
 	}
 
+	signMode, err := ParseSignMode(b.chain.Config().SignMode)
+	if err != nil {
+		return tx.Factory{}, err
+	}
+
 	f := b.defaultTxFactory(clientContext, accNumber.GetAccountNumber())
+	f = f.WithSignMode(signMode)
+	f = f.WithGasPrices(b.chain.getFullNode().txGasPrices(ctx, b.chain.Config().GasPrices))
 	for _, opt := range b.factoryOptions {
 		f = opt(f)
 	}
@@ -0,0 +1,154 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	clienttypes "github.com/cosmos/ibc-go/v6/modules/core/02-client/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v6/modules/core/23-commitment/types"
+	ibctm "github.com/cosmos/ibc-go/v6/modules/light-clients/07-tendermint"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
+	"golang.org/x/sync/errgroup"
+)
+
+// IBCUpgradeProposal submits an ibc-upgrade governance proposal to the chain, which halts the chain at prop.Height
+// and stores the client state of the upgraded chain, with chain ID prop.UpgradedChainID, for relayers to upgrade
+// the clients of the chain on counterparty chains with, see UpgradeChainID.
+func (c *CosmosChain) IBCUpgradeProposal(ctx context.Context, keyName string, prop IBCUpgradeProposal) (tx TxProposal, _ error) {
+	if prop.UnbondingPeriod == 0 {
+		unbonding, err := c.QueryUnbondingTime(ctx)
+		if err != nil {
+			return tx, err
+		}
+		prop.UnbondingPeriod = unbonding
+	}
+	txHash, err := c.getFullNode().IBCUpgradeProposal(ctx, keyName, prop)
+	if err != nil {
+		return tx, fmt.Errorf("failed to submit ibc upgrade proposal: %w", err)
+	}
+	return c.txProposal(txHash)
+}
+
+// UpgradeChainID restarts the chain, halted and with its nodes stopped, with chainID,
+// e.g. mychain-2 to bump the revision of mychain-1, as chains bump their revision in an upgrade:
+// the state of the chain is exported at its last block, e.g. at the height of a software upgrade plan,
+// and the nodes are started from a new genesis of that state with chainID, continuing at the next block height.
+// The nodes must be stopped before, see StopAllNodes.
+//
+// To upgrade the IBC clients of the chain on counterparty chains to the new revision, halt the chain with an ibc-upgrade
+// proposal, see IBCUpgradeProposal, and have the relayer upgrade the clients while the halted nodes still serve
+// the proofs of the upgraded client state, before stopping them. Relayers must be reconfigured with chainID,
+// and the addresses of the restarted nodes.
+func (c *CosmosChain) UpgradeChainID(ctx context.Context, chainID string) error {
+	if chainID == c.cfg.ChainID {
+		return fmt.Errorf("chain already has chain ID %s", chainID)
+	}
+
+	genbz, err := c.Validators[0].exportGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	g := make(map[string]any)
+	if err := json.Unmarshal(genbz, &g); err != nil {
+		return fmt.Errorf("failed to unmarshal exported genesis: %w", err)
+	}
+	g["chain_id"] = chainID
+	genbz, err = json.Marshal(g)
+	if err != nil {
+		return fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+	}
+
+	var eg errgroup.Group
+	for _, n := range c.Nodes() {
+		n := n
+		eg.Go(func() error {
+			if err := n.resetData(ctx); err != nil {
+				return err
+			}
+			return n.overwriteGenesisFile(ctx, genbz)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	// The host names of the nodes, by which they peer, are derived from the chain ID.
+	c.cfg.ChainID = chainID
+	peers := c.peerString(ctx, c.Nodes())
+	for _, n := range c.Nodes() {
+		if err := n.SetPeers(ctx, peers); err != nil {
+			return err
+		}
+	}
+	return c.StartAllNodes(ctx)
+}
+
+// IBCUpgradeProposal submits an ibc-upgrade governance proposal.
+func (tn *ChainNode) IBCUpgradeProposal(ctx context.Context, keyName string, prop IBCUpgradeProposal) (string, error) {
+	revision := ibc.ChainIDRevision(prop.UpgradedChainID)
+	if revision == 0 {
+		return "", fmt.Errorf("upgraded chain ID %s has no revision", prop.UpgradedChainID)
+	}
+	if prop.Height < 2 {
+		return "", fmt.Errorf("invalid upgrade height %d", prop.Height)
+	}
+
+	// The chain restarts from the state of the upgrade height, so the first block of the upgraded chain
+	// follows the latest height of its client state, as the relayer trusts the validators of that block.
+	clientState := ibctm.NewClientState(
+		prop.UpgradedChainID, ibctm.DefaultTrustLevel, 0, prop.UnbondingPeriod, 0,
+		clienttypes.NewHeight(revision, prop.Height-1), commitmenttypes.GetSDKSpecs(),
+		[]string{upgradetypes.StoreKey, upgradetypes.KeyUpgradedIBCState},
+	).ZeroCustomFields()
+	content, err := tn.Chain.Config().EncodingConfig.Codec.MarshalInterfaceJSON(clientState)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal upgraded client state: %w", err)
+	}
+
+	const file = "upgraded-client-state.json"
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	if err := fw.WriteFile(ctx, tn.VolumeName, file, content); err != nil {
+		return "", fmt.Errorf("writing upgraded client state file to docker volume: %w", err)
+	}
+
+	return tn.ExecTx(ctx, keyName,
+		"gov", "submit-proposal",
+		"ibc-upgrade", prop.Name, strconv.FormatUint(prop.Height, 10), path.Join(tn.HomeDir(), file),
+		"--title", prop.Title,
+		"--description", prop.Description,
+		"--deposit", prop.Deposit,
+	)
+}
+
+// exportGenesis returns the genesis of the state of the last block of the stopped node tn.
+func (tn *ChainNode) exportGenesis(ctx context.Context) ([]byte, error) {
+	tn.lock.Lock()
+	defer tn.lock.Unlock()
+
+	stdout, stderr, err := tn.ExecBin(ctx, "export")
+	if err != nil {
+		return nil, fmt.Errorf("export state of %s: %w", tn.Name(), err)
+	}
+	// Binaries of SDK v0.45 and older print the genesis to stderr.
+	if out := bytes.TrimSpace(stdout); len(out) > 0 && out[0] == '{' {
+		return out, nil
+	}
+	return bytes.TrimSpace(stderr), nil
+}
+
+// resetData deletes the blocks and state of the stopped node tn, and the last votes of its validator key,
+// so that it starts from genesis again.
+func (tn *ChainNode) resetData(ctx context.Context) error {
+	dataDir := path.Join(tn.HomeDir(), "data")
+	script := fmt.Sprintf(`rm -rf %[1]s && mkdir -p %[1]s && echo '{"height":"0","round":0,"step":0}' > %[1]s/priv_validator_state.json`, dataDir)
+	if _, _, err := tn.Exec(ctx, []string{"sh", "-c", script}, nil); err != nil {
+		return fmt.Errorf("reset data of %s: %w", tn.Name(), err)
+	}
+	return nil
+}
@@ -6,8 +6,10 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"hash/fnv"
+	"net"
 	"os"
 	"path"
 	"path/filepath"
@@ -20,6 +22,12 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govutils "github.com/cosmos/cosmos-sdk/x/gov/client/utils"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	dockertypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
@@ -52,15 +60,29 @@ type ChainNode struct {
 	Client       rpcclient.Client
 	TestName     string
 	Image        ibc.DockerImage
+	// Env holds environment variables of this node only, in the form KEY=VALUE.
+	// They are set after the chain's Env, overriding its variables of the same key.
+	// It is initialized from ibc.ChainConfig.ValidatorEnv or FullNodeEnv, which also apply before the chain starts.
+	// Changes apply to containers created afterwards, e.g. on StartAllNodes.
+	Env []string
+	// Sidecars are the sidecar processes of the validator, see ibc.SidecarConfig.ValidatorProcess.
+	Sidecars SidecarProcesses
 
 	lock sync.Mutex
 	log  *zap.Logger
 
+	valBroadcasterMu sync.Mutex // Guards valBroadcaster.
+	valBroadcaster   *SequencedBroadcaster
+
 	containerID string
 
+	pausedMu sync.Mutex // Guards paused.
+	paused   bool
+
 	// Ports set during StartContainer.
 	hostRPCPort  string
 	hostGRPCPort string
+	hostAPIPort  string
 }
 
 // ChainNodes is a collection of ChainNode
@@ -271,6 +293,9 @@ func (tn *ChainNode) SetPeers(ctx context.Context, peers string) error {
 }
 
 func (tn *ChainNode) Height(ctx context.Context) (uint64, error) {
+	if tn.isPaused() {
+		return 0, fmt.Errorf("height of %s: %w", tn.Name(), ErrNodePaused)
+	}
 	res, err := tn.Client.Status(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("tendermint rpc client status: %w", err)
@@ -379,38 +404,113 @@ func (tn *ChainNode) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx,
 // TxCommand is a helper to retrieve a full command for broadcasting a tx
 // with the chain node binary.
 func (tn *ChainNode) TxCommand(keyName string, command ...string) []string {
+	return tn.txCommand(tn.Chain.Config().GasPrices, keyName, command...)
+}
+
+func (tn *ChainNode) txCommand(gasPrices, keyName string, command ...string) []string {
 	command = append([]string{"tx"}, command...)
-	return tn.NodeCommand(append(command,
+	command = append(command,
 		"--from", keyName,
-		"--gas-prices", tn.Chain.Config().GasPrices,
+		"--gas-prices", gasPrices,
 		"--gas-adjustment", fmt.Sprint(tn.Chain.Config().GasAdjustment),
 		"--keyring-backend", keyring.BackendTest,
 		"--output", "json",
 		"-y",
-	)...)
+	)
+	return tn.NodeCommand(append(command, tn.signModeFlags()...)...)
+}
+
+// signModeFlags returns the flags signing transactions with the sign mode of the chain.
+// They are empty for the default sign mode, which is already the default of the chain binary.
+func (tn *ChainNode) signModeFlags() []string {
+	if mode := tn.Chain.Config().SignMode; mode != "" && mode != ibc.SignModeDirect {
+		return []string{"--sign-mode", mode}
+	}
+	return nil
 }
 
 // ExecTx executes a transaction, waits for 2 blocks if successful, then returns the tx hash.
 func (tn *ChainNode) ExecTx(ctx context.Context, keyName string, command ...string) (string, error) {
+	return tn.execTx(ctx, tn.Chain.Config().GasPrices, keyName, command...)
+}
+
+// ExecInternalTx executes a transaction for test plumbing, such as faucet funding or validator votes,
+// paying fees with the chain's InternalGasPrices.
+// If the node rejects the internal gas prices as insufficient, the transaction is retried with GasPrices.
+func (tn *ChainNode) ExecInternalTx(ctx context.Context, keyName string, command ...string) (string, error) {
+	cfg := tn.Chain.Config()
+	if cfg.InternalGasPrices == "" || cfg.InternalGasPrices == cfg.GasPrices {
+		return tn.execTx(ctx, cfg.GasPrices, keyName, command...)
+	}
+
+	txHash, err := tn.execTx(ctx, cfg.InternalGasPrices, keyName, command...)
+	if errors.Is(err, errInsufficientFee) {
+		tn.logger().Info(
+			"Internal gas prices rejected by node, retrying with gas prices",
+			zap.String("internal_gas_prices", cfg.InternalGasPrices),
+			zap.String("gas_prices", cfg.GasPrices),
+		)
+		return tn.execTx(ctx, cfg.GasPrices, keyName, command...)
+	}
+	return txHash, err
+}
+
+// errInsufficientFee is wrapped by execTx when the node rejects a transaction for insufficient fees.
+var errInsufficientFee = errors.New("insufficient fee")
+
+// errWrongSequence is wrapped by execTx when the node rejects a transaction for its account sequence,
+// typically because another transaction from the same account was broadcast concurrently.
+var errWrongSequence = errors.New("account sequence mismatch")
+
+func (tn *ChainNode) execTx(ctx context.Context, gasPrices, keyName string, command ...string) (string, error) {
 	tn.lock.Lock()
 	defer tn.lock.Unlock()
 
-	stdout, _, err := tn.Exec(ctx, tn.TxCommand(keyName, command...), nil)
+	txHash, err := tn.broadcastTxCommand(ctx, tn.txCommand(tn.txGasPrices(ctx, gasPrices), keyName, command...))
+	if err != nil {
+		return txHash, err
+	}
+	return tn.awaitTx(ctx, txHash)
+}
+
+// broadcastTxCommand executes the tx command and checks that the node accepted the transaction,
+// returning its hash. The caller must hold tn.lock.
+func (tn *ChainNode) broadcastTxCommand(ctx context.Context, cmd []string) (string, error) {
+	stdout, _, err := tn.Exec(ctx, cmd, nil)
 	if err != nil {
 		return "", err
 	}
-	output := CosmosTx{}
-	err = json.Unmarshal([]byte(stdout), &output)
+	res, err := tn.cliFormat().TxResponse(stdout)
 	if err != nil {
 		return "", err
 	}
+	output := CosmosTx{TxHash: res.TxHash, Code: int(res.Code), Codespace: res.Codespace, RawLog: res.RawLog}
+	if output.isInsufficientFee() {
+		return output.TxHash, fmt.Errorf("%w: transaction failed with code %d: %s", errInsufficientFee, output.Code, output.RawLog)
+	}
+	if output.isWrongSequence() {
+		return output.TxHash, fmt.Errorf("%w: transaction failed with code %d: %s", errWrongSequence, output.Code, output.RawLog)
+	}
 	if output.Code != 0 {
 		return output.TxHash, fmt.Errorf("transaction failed with code %d: %s", output.Code, output.RawLog)
 	}
-	if err := testutil.WaitForBlocks(ctx, 2, tn); err != nil {
+	return output.TxHash, nil
+}
+
+// awaitTx waits for an accepted transaction to be committed, and records its result if ctx has a TxRecorder.
+func (tn *ChainNode) awaitTx(ctx context.Context, txHash string) (string, error) {
+	// The chain produces the blocks if it only produces blocks on demand.
+	if err := testutil.WaitForBlocks(ctx, 2, tn.Chain); err != nil {
 		return "", err
 	}
-	return output.TxHash, nil
+	if r := txRecorderFromContext(ctx); r != nil {
+		res, err := tn.TxResult(txHash)
+		if err != nil {
+			return txHash, err
+		}
+		r.record(res)
+	}
+	return txHash, nil
 }
 
 // NodeCommand is a helper to retrieve a full command for a chain node binary.
@@ -421,7 +521,7 @@ func (tn *ChainNode) ExecTx(ctx context.Context, keyName string, command ...stri
 func (tn *ChainNode) NodeCommand(command ...string) []string {
 	command = tn.BinCommand(command...)
 	return append(command,
-		"--node", fmt.Sprintf("tcp://%s:26657", tn.HostName()),
+		"--node", "tcp://"+tn.rpcAddress(),
 		"--chain-id", tn.Chain.Config().ChainID,
 	)
 }
@@ -464,6 +564,12 @@ func (tn *ChainNode) ExecQuery(ctx context.Context, command ...string) ([]byte,
 	return tn.Exec(ctx, tn.QueryCommand(command...), nil)
 }
 
+// ExecQueryAtHeight executes a query command like ExecQuery, against the state at the given block height.
+// Nodes that pruned the state at height fail the query; see CosmosChain.AddArchiveFullNode.
+func (tn *ChainNode) ExecQueryAtHeight(ctx context.Context, height int64, command ...string) ([]byte, []byte, error) {
+	return tn.ExecQuery(ctx, append(command, "--height", fmt.Sprint(height))...)
+}
+
 // CondenseMoniker fits a moniker into the cosmos character limit for monikers.
 // If the moniker already fits, it is returned unmodified.
 // Otherwise, the middle is truncated, and a hash is appended to the end
@@ -548,7 +654,7 @@ func (tn *ChainNode) AddGenesisAccount(ctx context.Context, address string, gene
 	ctx, cancel := context.WithTimeout(ctx, time.Minute)
 	defer cancel()
 
-	_, _, err := tn.ExecBin(ctx, "add-genesis-account", address, amount)
+	_, _, err := tn.ExecBin(ctx, tn.genesisCommand("add-genesis-account", address, amount)...)
 	return err
 }
 
@@ -557,19 +663,32 @@ func (tn *ChainNode) Gentx(ctx context.Context, name string, genesisSelfDelegati
 	tn.lock.Lock()
 	defer tn.lock.Unlock()
 
-	_, _, err := tn.ExecBin(ctx,
+	chainCfg := tn.Chain.Config()
+	command := tn.genesisCommand(
 		"gentx", valKey, fmt.Sprintf("%d%s", genesisSelfDelegation.Amount.Int64(), genesisSelfDelegation.Denom),
 		"--keyring-backend", keyring.BackendTest,
-		"--chain-id", tn.Chain.Config().ChainID,
+		"--chain-id", chainCfg.ChainID,
 	)
+	if chainCfg.CommissionRate != "" {
+		command = append(command, "--commission-rate", chainCfg.CommissionRate)
+	}
+	if chainCfg.CommissionMaxRate != "" {
+		command = append(command, "--commission-max-rate", chainCfg.CommissionMaxRate)
+	}
+	if chainCfg.CommissionMaxChangeRate != "" {
+		command = append(command, "--commission-max-change-rate", chainCfg.CommissionMaxChangeRate)
+	}
+	if chainCfg.MinSelfDelegation > 0 {
+		command = append(command, "--min-self-delegation", strconv.FormatInt(chainCfg.MinSelfDelegation, 10))
+	}
+
+	_, _, err := tn.ExecBin(ctx, command...)
 	return err
 }
 
 // CollectGentxs runs collect gentxs on the node's home folders
 func (tn *ChainNode) CollectGentxs(ctx context.Context) error {
-	command := []string{tn.Chain.Config().Bin, "collect-gentxs",
-		"--home", tn.HomeDir(),
-	}
+	command := append([]string{tn.Chain.Config().Bin}, tn.genesisCommand("collect-gentxs", "--home", tn.HomeDir())...)
 
 	tn.lock.Lock()
 	defer tn.lock.Unlock()
@@ -579,9 +698,22 @@ func (tn *ChainNode) CollectGentxs(ctx context.Context) error {
 }
 
 type CosmosTx struct {
-	TxHash string `json:"txhash"`
-	Code   int    `json:"code"`
-	RawLog string `json:"raw_log"`
+	TxHash    string `json:"txhash"`
+	Code      int    `json:"code"`
+	Codespace string `json:"codespace"`
+	RawLog    string `json:"raw_log"`
+}
+
+// isInsufficientFee reports whether the transaction was rejected because its fees
+// did not meet the node's minimum gas prices.
+func (tx CosmosTx) isInsufficientFee() bool {
+	return tx.Codespace == sdkerrors.RootCodespace && uint32(tx.Code) == sdkerrors.ErrInsufficientFee.ABCICode()
+}
+
+// isWrongSequence reports whether the transaction was rejected because its account sequence
+// did not match the signer's next sequence.
+func (tx CosmosTx) isWrongSequence() bool {
+	return tx.Codespace == sdkerrors.RootCodespace && uint32(tx.Code) == sdkerrors.ErrWrongSequence.ABCICode()
 }
 
 func (tn *ChainNode) SendIBCTransfer(
@@ -595,19 +727,34 @@ func (tn *ChainNode) SendIBCTransfer(
 		"ibc-transfer", "transfer", "transfer", channelID,
 		amount.Address, fmt.Sprintf("%d%s", amount.Amount, amount.Denom),
 	}
-	if options.Timeout != nil {
-		if options.Timeout.NanoSeconds > 0 {
-			command = append(command, "--packet-timeout-timestamp", fmt.Sprint(options.Timeout.NanoSeconds))
-		} else if options.Timeout.Height > 0 {
-			command = append(command, "--packet-timeout-height", fmt.Sprintf("0-%d", options.Timeout.Height))
-		}
-	}
+	command = append(command, transferTimeoutFlags(options.Timeout)...)
 	if options.Memo != "" {
 		command = append(command, "--memo", options.Memo)
 	}
 	return tn.ExecTx(ctx, keyName, command...)
 }
 
+// transferTimeoutFlags returns the flags of the transfer commands setting timeout, if not nil.
+// Relative timeout heights are added by the CLI to the latest height of the client of the channel, keeping its revision.
+func transferTimeoutFlags(timeout *ibc.IBCTimeout) []string {
+	switch {
+	case timeout == nil:
+		return nil
+	case timeout.NanoSeconds > 0:
+		return []string{"--packet-timeout-timestamp", fmt.Sprint(timeout.NanoSeconds)}
+	case !timeout.AbsoluteHeight.IsZero():
+		// Absolute timeouts apply to both timeouts, so the default relative timeout timestamp is disabled.
+		return []string{
+			"--packet-timeout-height", timeout.AbsoluteHeight.String(),
+			"--packet-timeout-timestamp", "0",
+			"--absolute-timeouts",
+		}
+	case timeout.Height > 0:
+		return []string{"--packet-timeout-height", ibc.NewHeight(0, timeout.Height).String()}
+	}
+	return nil
+}
+
 func (tn *ChainNode) SendFunds(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
 	_, err := tn.ExecTx(ctx,
 		keyName, "bank", "send", keyName,
@@ -616,6 +763,23 @@ func (tn *ChainNode) SendFunds(ctx context.Context, keyName string, amount ibc.W
 	return err
 }
 
+// SendFundsInternal sends funds like SendFunds, but pays fees with the chain's InternalGasPrices.
+func (tn *ChainNode) SendFundsInternal(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
+	_, err := tn.ExecInternalTx(ctx,
+		keyName, "bank", "send", keyName,
+		amount.Address, fmt.Sprintf("%d%s", amount.Amount, amount.Denom),
+	)
+	return err
+}
+
+// StakingDelegate delegates amount, e.g. 1000uatom, from the account of keyName to the validator with the valoper address.
+func (tn *ChainNode) StakingDelegate(ctx context.Context, keyName, valoper, amount string) error {
+	_, err := tn.ExecTx(ctx,
+		keyName, "staking", "delegate", valoper, amount,
+	)
+	return err
+}
+
 type InstantiateContractAttribute struct {
 	Value string `json:"value"`
 }
@@ -644,7 +808,8 @@ type CodeInfosResponse struct {
 }
 
 // StoreContract takes a file path to smart contract and stores it on-chain. Returns the contracts code id.
-func (tn *ChainNode) StoreContract(ctx context.Context, keyName string, fileName string) (string, error) {
+// extraExecTxArgs are appended to the store transaction, e.g. "--gas", "5000000".
+func (tn *ChainNode) StoreContract(ctx context.Context, keyName string, fileName string, extraExecTxArgs ...string) (string, error) {
 	content, err := os.ReadFile(fileName)
 	if err != nil {
 		return "", err
@@ -656,7 +821,8 @@ func (tn *ChainNode) StoreContract(ctx context.Context, keyName string, fileName
 		return "", fmt.Errorf("writing contract file to docker volume: %w", err)
 	}
 
-	if _, err := tn.ExecTx(ctx, keyName, "wasm", "store", path.Join(tn.HomeDir(), file)); err != nil {
+	storeCmd := append([]string{"wasm", "store", path.Join(tn.HomeDir(), file)}, extraExecTxArgs...)
+	if _, err := tn.ExecTx(ctx, keyName, storeCmd...); err != nil {
 		return "", err
 	}
 
@@ -775,14 +941,53 @@ func (tn *ChainNode) QueryProposal(ctx context.Context, proposalID string) (*Pro
 	if err != nil {
 		return nil, err
 	}
-	var proposal ProposalResponse
-	err = json.Unmarshal(stdout, &proposal)
+	p, err := tn.cliFormat().Proposal(stdout)
 	if err != nil {
 		return nil, err
 	}
+	proposal := ProposalResponse{
+		ProposalID: p.ID,
+		Content: ProposalContent{
+			Type:        p.ContentType,
+			Title:       p.Title,
+			Description: p.Description,
+		},
+		Status: p.Status,
+		FinalTallyResult: ProposalFinalTallyResult{
+			Yes:        p.FinalTallyResult.Yes,
+			Abstain:    p.FinalTallyResult.Abstain,
+			No:         p.FinalTallyResult.No,
+			NoWithVeto: p.FinalTallyResult.NoWithVeto,
+		},
+		SubmitTime:      p.SubmitTime,
+		DepositEndTime:  p.DepositEndTime,
+		VotingStartTime: p.VotingStartTime,
+		VotingEndTime:   p.VotingEndTime,
+	}
+	for _, d := range p.TotalDeposit {
+		proposal.TotalDeposit = append(proposal.TotalDeposit, ProposalDeposit{Denom: d.Denom, Amount: d.Amount})
+	}
 	return &proposal, nil
 }
 
+// QueryProposalTally returns the current tally of votes on a governance proposal.
+func (tn *ChainNode) QueryProposalTally(ctx context.Context, proposalID string) (govv1.TallyResult, error) {
+	stdout, _, err := tn.ExecQuery(ctx, "gov", "tally", proposalID)
+	if err != nil {
+		return govv1.TallyResult{}, err
+	}
+	tally, err := tn.cliFormat().Tally(stdout)
+	if err != nil {
+		return govv1.TallyResult{}, err
+	}
+	return govv1.TallyResult{
+		YesCount:        tally.Yes,
+		AbstainCount:    tally.Abstain,
+		NoCount:         tally.No,
+		NoWithVetoCount: tally.NoWithVeto,
+	}, nil
+}
+
 // UpgradeProposal submits a software-upgrade governance proposal to the chain.
 func (tn *ChainNode) UpgradeProposal(ctx context.Context, keyName string, prop SoftwareUpgradeProposal) (string, error) {
 	command := []string{
@@ -816,6 +1021,34 @@ func (tn *ChainNode) TextProposal(ctx context.Context, keyName string, prop Text
 	return tn.ExecTx(ctx, keyName, command...)
 }
 
+// ParamChangeProposal submits a param-change governance proposal.
+func (tn *ChainNode) ParamChangeProposal(ctx context.Context, keyName string, prop ParamChangeProposal) (string, error) {
+	content, err := json.Marshal(struct {
+		Title       string        `json:"title"`
+		Description string        `json:"description"`
+		Changes     []ParamChange `json:"changes"`
+		Deposit     string        `json:"deposit"`
+	}{
+		Title:       prop.Title,
+		Description: prop.Description,
+		Changes:     prop.Changes,
+		Deposit:     prop.Deposit,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	const file = "param-change-proposal.json"
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	if err := fw.WriteFile(ctx, tn.VolumeName, file, content); err != nil {
+		return "", fmt.Errorf("writing param change proposal file to docker volume: %w", err)
+	}
+
+	return tn.ExecTx(ctx, keyName,
+		"gov", "submit-proposal", "param-change", path.Join(tn.HomeDir(), file),
+	)
+}
+
 // DumpContractState dumps the state of a contract at a block height.
 func (tn *ChainNode) DumpContractState(ctx context.Context, contractAddress string, height int64) (*DumpContractStateResponse, error) {
 	stdout, _, err := tn.ExecQuery(ctx,
@@ -855,15 +1088,20 @@ func (tn *ChainNode) UnsafeResetAll(ctx context.Context) error {
 
 func (tn *ChainNode) CreateNodeContainer(ctx context.Context) error {
 	chainCfg := tn.Chain.Config()
-	cmd := []string{chainCfg.Bin, "start", "--home", tn.HomeDir(), "--x-crisis-skip-assert-invariants"}
+	flags := append(tn.rollupFlags(), tn.consensusEngineFlags()...)
+	cmd := append([]string{chainCfg.Bin, "start", "--home", tn.HomeDir(), "--x-crisis-skip-assert-invariants"}, flags...)
 	if chainCfg.NoHostMount {
-		cmd = []string{"sh", "-c", fmt.Sprintf("cp -r %s %s_nomnt && %s start --home %s_nomnt --x-crisis-skip-assert-invariants", tn.HomeDir(), tn.HomeDir(), chainCfg.Bin, tn.HomeDir())}
+		cmd = []string{"sh", "-c", strings.TrimSpace(fmt.Sprintf("cp -r %s %s_nomnt && %s start --home %s_nomnt --x-crisis-skip-assert-invariants %s", tn.HomeDir(), tn.HomeDir(), chainCfg.Bin, tn.HomeDir(), strings.Join(flags, " ")))}
+	}
+	if offset := chainCfg.ClockOffset; offset != nil {
+		if err := tn.SetClockOffset(ctx, *offset); err != nil {
+			return err
+		}
 	}
 	imageRef := tn.Image.Ref()
 	tn.logger().
 		Info("Running command",
 			zap.String("command", strings.Join(cmd, " ")),
-			zap.String("container", tn.Name()),
 			zap.String("image", imageRef),
 		)
 
@@ -874,10 +1112,11 @@ func (tn *ChainNode) CreateNodeContainer(ctx context.Context) error {
 
 			Entrypoint: []string{},
 			Cmd:        cmd,
+			Env:        append(tn.env(), tn.fakeTimeEnv()...),
 
 			Hostname: tn.HostName(),
 
-			Labels: map[string]string{dockerutil.CleanupLabel: tn.TestName},
+			Labels: dockerutil.NodeResourceLabels(tn.TestName, tn.Name()),
 
 			ExposedPorts: sentryPorts,
 		},
@@ -889,7 +1128,7 @@ func (tn *ChainNode) CreateNodeContainer(ctx context.Context) error {
 		},
 		&network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
-				tn.NetworkID: {},
+				tn.NetworkID: {Aliases: tn.networkAliases()},
 			},
 		},
 		nil,
@@ -902,6 +1141,25 @@ func (tn *ChainNode) CreateNodeContainer(ctx context.Context) error {
 	return nil
 }
 
+// networkAliases returns the configured network aliases of the chain if tn is the node
+// the chain uses for queries and relayers, i.e. its first fullnode, or first validator without fullnodes.
+func (tn *ChainNode) networkAliases() []string {
+	c, ok := tn.Chain.(*CosmosChain)
+	if !ok || tn.Index != 0 || tn.Validator != (c.numFullNodes == 0) {
+		return nil
+	}
+	return c.cfg.NetworkAliases
+}
+
+// hostPort returns the published address of the container port, on the docker host of the chain.
+func (tn *ChainNode) hostPort(cont dockertypes.ContainerJSON, portID string) string {
+	hostPort := dockerutil.GetHostPort(cont, portID)
+	if c, ok := tn.Chain.(*CosmosChain); ok {
+		return dockerutil.HostPortOn(hostPort, c.hostAddress)
+	}
+	return hostPort
+}
+
 func (tn *ChainNode) StartContainer(ctx context.Context) error {
 	if err := dockerutil.StartContainer(ctx, tn.DockerClient, tn.containerID); err != nil {
 		return err
@@ -913,10 +1171,19 @@ func (tn *ChainNode) StartContainer(ctx context.Context) error {
 	}
 
 	// Set the host ports once since they will not change after the container has started.
-	tn.hostRPCPort = dockerutil.GetHostPort(c, rpcPort)
-	tn.hostGRPCPort = dockerutil.GetHostPort(c, grpcPort)
+	tn.hostRPCPort = tn.hostPort(c, rpcPort)
+	tn.hostGRPCPort = tn.hostPort(c, grpcPort)
+	tn.hostAPIPort = tn.hostPort(c, apiPort)
 
-	tn.logger().Info("Cosmos chain node started", zap.String("container", tn.Name()), zap.String("rpc_port", tn.hostRPCPort))
+	tn.logger().Info("Cosmos chain node started", zap.String("rpc_port", tn.hostRPCPort))
+
+	// The node serves no RPC without its consensus engine: that of CometMock serves the chain once started.
+	if tn.usesCometMock() {
+		if tn.Chain.(*CosmosChain).cometMockHostRPC == "" {
+			return nil
+		}
+		return tn.connectCometMock(ctx)
+	}
 
 	err = tn.NewClient("tcp://" + tn.hostRPCPort)
 	if err != nil {
@@ -940,10 +1207,19 @@ func (tn *ChainNode) StartContainer(ctx context.Context) error {
 
 func (tn *ChainNode) StopContainer(ctx context.Context) error {
 	timeout := 30 * time.Second
-	return tn.DockerClient.ContainerStop(ctx, tn.containerID, &timeout)
+	return dockerutil.StopContainer(ctx, tn.DockerClient, tn.containerID, timeout)
+}
+
+// RestartContainer stops and starts the node's container, keeping its data.
+func (tn *ChainNode) RestartContainer(ctx context.Context) error {
+	if err := tn.StopContainer(ctx); err != nil {
+		return err
+	}
+	return tn.StartContainer(ctx)
 }
 
 func (tn *ChainNode) RemoveContainer(ctx context.Context) error {
+	dockerutil.ExpectContainerStop(tn.containerID)
 	err := tn.DockerClient.ContainerRemove(ctx, tn.containerID, dockertypes.ContainerRemoveOptions{
 		Force:         true,
 		RemoveVolumes: true,
@@ -1031,16 +1307,14 @@ func (tn *ChainNode) AccountKeyBech32(ctx context.Context, name string) (string,
 func (nodes ChainNodes) PeerString(ctx context.Context) string {
 	addrs := make([]string, len(nodes))
 	for i, n := range nodes {
-		id, err := n.NodeID(ctx)
+		ps, err := n.PeerAddress(ctx)
 		if err != nil {
 			// TODO: would this be better to panic?
 			// When would NodeId return an error?
 			break
 		}
-		hostName := n.HostName()
-		ps := fmt.Sprintf("%s@%s:26656", id, hostName)
 		nodes.logger().Info("Peering",
-			zap.String("host_name", hostName),
+			zap.String("host_name", n.HostName()),
 			zap.String("peer", ps),
 			zap.String("container", n.Name()),
 		)
@@ -1049,6 +1323,56 @@ func (nodes ChainNodes) PeerString(ctx context.Context) string {
 	return strings.Join(addrs, ",")
 }
 
+// ValidatePeerAddress returns an error if peer is not a peer address
+// in the form <node-id>@<host>:<port>, as used in persistent_peers.
+func ValidatePeerAddress(peer string) error {
+	id, hostPort, ok := strings.Cut(peer, "@")
+	if !ok {
+		return fmt.Errorf("peer %q must be in the form <node-id>@<host>:<port>", peer)
+	}
+	if len(id) != 2*p2p.IDByteLength {
+		return fmt.Errorf("node id of peer %q must be %d hex characters, got %d", peer, 2*p2p.IDByteLength, len(id))
+	}
+	if _, err := hex.DecodeString(id); err != nil || strings.ToLower(id) != id {
+		return fmt.Errorf("node id of peer %q must be lowercase hex", peer)
+	}
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return fmt.Errorf("invalid address of peer %q: %w", peer, err)
+	}
+	if host == "" {
+		return fmt.Errorf("missing host of peer %q", peer)
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return fmt.Errorf("invalid port of peer %q: %w", peer, err)
+	}
+	return nil
+}
+
+// ParseSignMode returns the sign mode of the name of a ChainConfig.SignMode, SIGN_MODE_DIRECT if it is empty.
+func ParseSignMode(mode string) (signing.SignMode, error) {
+	switch mode {
+	case "", ibc.SignModeDirect:
+		return signing.SignMode_SIGN_MODE_DIRECT, nil
+	case ibc.SignModeAminoJSON:
+		return signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON, nil
+	default:
+		return signing.SignMode_SIGN_MODE_UNSPECIFIED, fmt.Errorf("unsupported sign mode %q, must be %s or %s", mode, ibc.SignModeDirect, ibc.SignModeAminoJSON)
+	}
+}
+
+// ValidateEnv returns an error if kv is not an environment variable in the form KEY=VALUE.
+func ValidateEnv(kv string) error {
+	key, _, ok := strings.Cut(kv, "=")
+	if !ok {
+		return fmt.Errorf("environment variable %q must be in the form KEY=VALUE", kv)
+	}
+	if key == "" || strings.ContainsAny(key, " \t\n") {
+		return fmt.Errorf("invalid name of environment variable %q", kv)
+	}
+	return nil
+}
+
 // LogGenesisHashes logs the genesis hashes for the various nodes
 func (nodes ChainNodes) LogGenesisHashes(ctx context.Context) error {
 	for _, n := range nodes {
@@ -1062,27 +1386,36 @@ func (nodes ChainNodes) LogGenesisHashes(ctx context.Context) error {
 	return nil
 }
 
+// logger returns the logger of the nodes' chain.
 func (nodes ChainNodes) logger() *zap.Logger {
 	if len(nodes) == 0 {
 		return zap.NewNop()
 	}
-	return nodes[0].logger()
+	return nodes[0].log
 }
 
 func (tn *ChainNode) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
 	job := dockerutil.NewImage(tn.logger(), tn.DockerClient, tn.NetworkID, tn.TestName, tn.Image.Repository, tn.Image.Version)
 	opts := dockerutil.ContainerOptions{
-		Env:   env,
+		Env:   append(tn.env(), env...),
 		Binds: tn.Bind(),
 	}
 	res := job.Run(ctx, cmd, opts)
 	return res.Stdout, res.Stderr, res.Err
 }
 
+// env returns the configured environment of the node: the chain's Env followed by the node's Env.
+func (tn *ChainNode) env() []string {
+	env := append([]string(nil), tn.Chain.Config().Env...)
+	return append(env, tn.Env...)
+}
+
+// logger returns the chain's logger with the fields identifying the node.
 func (tn *ChainNode) logger() *zap.Logger {
 	return tn.log.With(
-		zap.String("chain_id", tn.Chain.Config().ChainID),
-		zap.String("test", tn.TestName),
+		zap.Int("node_index", tn.Index),
+		zap.Bool("validator", tn.Validator),
+		zap.String("container", tn.Name()),
 	)
 }
 
@@ -1113,19 +1446,70 @@ func (tn *ChainNode) QueryICA(ctx context.Context, connectionID, address string)
 	return strings.TrimSpace(parts[1]), nil
 }
 
+// RegisterICAController will attempt to register an interchain account on the counterparty chain
+// using the native ICS-27 controller messages, rather than the intertx module.
+func (tn *ChainNode) RegisterICAController(ctx context.Context, keyName, connectionID string) (string, error) {
+	return tn.ExecTx(ctx, keyName,
+		"interchain-accounts", "controller", "register", connectionID,
+	)
+}
+
+// RegisterICAControllerWithVersion registers an interchain account as RegisterICAController,
+// with the ICS-27 channel version of the account's channel, e.g. to request an encoding, see ICAControllerVersion.
+func (tn *ChainNode) RegisterICAControllerWithVersion(ctx context.Context, keyName, connectionID, version string) (string, error) {
+	return tn.ExecTx(ctx, keyName,
+		"interchain-accounts", "controller", "register", connectionID,
+		"--version", version,
+	)
+}
+
+// SendICATx sends msgs to the interchain account of keyName on connectionID
+// with the native ICS-27 controller send-tx command, rather than the intertx module,
+// and returns the hash of the transaction.
+// The encoding must be that of the account's channel, see BuildICAPacketData.
+func (tn *ChainNode) SendICATx(ctx context.Context, keyName, connectionID string, msgs []types.Msg, encoding string) (string, error) {
+	content, err := BuildICAPacketData(msgs, encoding, "")
+	if err != nil {
+		return "", err
+	}
+
+	const file = "ica-packet-data.json"
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	if err := fw.WriteFile(ctx, tn.VolumeName, file, content); err != nil {
+		return "", fmt.Errorf("writing interchain account packet data file to docker volume: %w", err)
+	}
+
+	return tn.ExecTx(ctx, keyName,
+		"interchain-accounts", "controller", "send-tx", connectionID, path.Join(tn.HomeDir(), file),
+	)
+}
+
+// QueryICAController will query for an interchain account controlled by the specified address on the counterparty chain,
+// using the native ICS-27 controller queries, rather than the intertx module.
+func (tn *ChainNode) QueryICAController(ctx context.Context, connectionID, address string) (string, error) {
+	stdout, _, err := tn.ExecQuery(ctx,
+		"interchain-accounts", "controller", "interchain-account", address, connectionID,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	var res struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(stdout, &res); err != nil {
+		return "", fmt.Errorf("malformed stdout from command: %s: %w", stdout, err)
+	}
+	return res.Address, nil
+}
+
 // SendICABankTransfer builds a bank transfer message for a specified address and sends it to the specified
 // interchain account.
 func (tn *ChainNode) SendICABankTransfer(ctx context.Context, connectionID, fromAddr string, amount ibc.WalletAmount) error {
-	msg, err := json.Marshal(map[string]any{
-		"@type":        "/cosmos.bank.v1beta1.MsgSend",
-		"from_address": fromAddr,
-		"to_address":   amount.Address,
-		"amount": []map[string]any{
-			{
-				"denom":  amount.Denom,
-				"amount": amount.Amount,
-			},
-		},
+	msg, err := DefaultEncoding().Codec.MarshalInterfaceJSON(&banktypes.MsgSend{
+		FromAddress: fromAddr,
+		ToAddress:   amount.Address,
+		Amount:      types.NewCoins(types.NewInt64Coin(amount.Denom, amount.Amount)),
 	})
 	if err != nil {
 		return err
@@ -1137,3 +1521,31 @@ func (tn *ChainNode) SendICABankTransfer(ctx context.Context, connectionID, from
 	)
 	return err
 }
+
+// SendICAGovVote builds a governance vote of the interchain account icaAddr on the proposal of the host chain
+// with the given ID, e.g. ProposalVoteYes, and sends it to the interchain account, returning the hash of the transaction.
+// See PollForVote to wait for the vote on the host chain.
+func (tn *ChainNode) SendICAGovVote(ctx context.Context, connectionID, fromAddr, icaAddr, proposalID, vote string) (string, error) {
+	id, err := strconv.ParseUint(proposalID, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid proposal ID %q: %w", proposalID, err)
+	}
+	option, err := govv1beta1.VoteOptionFromString(govutils.NormalizeVoteOption(vote))
+	if err != nil {
+		return "", err
+	}
+	// The legacy vote is accepted by hosts with and without gov v1.
+	msg, err := DefaultEncoding().Codec.MarshalInterfaceJSON(&govv1beta1.MsgVote{
+		ProposalId: id,
+		Voter:      icaAddr,
+		Option:     option,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return tn.ExecTx(ctx, fromAddr,
+		"intertx", "submit", string(msg),
+		"--connection-id", connectionID,
+	)
+}
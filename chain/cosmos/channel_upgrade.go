@@ -0,0 +1,228 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	gogotypes "github.com/gogo/protobuf/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Channel upgrades were added in ibc-go v8.1, whose types this module does not depend on,
+// so the upgrade messages and queries are encoded as JSON here.
+// The encoding is tested against that of the ibc-go v8.1 types, see testdata/channelupgrade.
+
+// ChannelUpgradeFields are the parameters of a channel that a channel upgrade changes.
+type ChannelUpgradeFields struct {
+	Ordering       chantypes.Order
+	ConnectionHops []string
+	Version        string
+}
+
+// ChannelUpgrade is the upgrade of a channel whose upgrade handshake is in progress.
+type ChannelUpgrade struct {
+	Fields ChannelUpgradeFields
+
+	// TimeoutHeight and TimeoutTimestamp are when the counterparty chain stops accepting the upgrade.
+	TimeoutHeight    ibc.Height
+	TimeoutTimestamp uint64
+
+	// NextSequenceSend is the sequence of the next packet sent on the channel once the upgrade starts.
+	NextSequenceSend uint64
+}
+
+// channelUpgradeFieldsJSON is the proto JSON encoding of ibc.core.channel.v1.UpgradeFields.
+type channelUpgradeFieldsJSON struct {
+	Ordering       string   `json:"ordering"`
+	ConnectionHops []string `json:"connection_hops"`
+	Version        string   `json:"version"`
+}
+
+func (f channelUpgradeFieldsJSON) fields() ChannelUpgradeFields {
+	return ChannelUpgradeFields{
+		Ordering:       chantypes.Order(chantypes.Order_value[f.Ordering]),
+		ConnectionHops: f.ConnectionHops,
+		Version:        f.Version,
+	}
+}
+
+func channelUpgradeFieldsToJSON(f ChannelUpgradeFields) channelUpgradeFieldsJSON {
+	return channelUpgradeFieldsJSON{
+		Ordering:       f.Ordering.String(),
+		ConnectionHops: f.ConnectionHops,
+		Version:        f.Version,
+	}
+}
+
+// heightJSON is the proto JSON encoding of ibc.core.client.v1.Height.
+type heightJSON struct {
+	RevisionNumber uint64 `json:"revision_number,string"`
+	RevisionHeight uint64 `json:"revision_height,string"`
+}
+
+// channelUpgradeJSON is the proto JSON encoding of ibc.core.channel.v1.Upgrade.
+type channelUpgradeJSON struct {
+	Fields  channelUpgradeFieldsJSON `json:"fields"`
+	Timeout struct {
+		Height    heightJSON `json:"height"`
+		Timestamp uint64     `json:"timestamp,string"`
+	} `json:"timeout"`
+	NextSequenceSend uint64 `json:"next_sequence_send,string"`
+}
+
+func (u channelUpgradeJSON) upgrade() ChannelUpgrade {
+	return ChannelUpgrade{
+		Fields:           u.Fields.fields(),
+		TimeoutHeight:    ibc.NewHeight(u.Timeout.Height.RevisionNumber, u.Timeout.Height.RevisionHeight),
+		TimeoutTimestamp: u.Timeout.Timestamp,
+		NextSequenceSend: u.NextSequenceSend,
+	}
+}
+
+func channelUpgradeToJSON(u ChannelUpgrade) channelUpgradeJSON {
+	res := channelUpgradeJSON{
+		Fields:           channelUpgradeFieldsToJSON(u.Fields),
+		NextSequenceSend: u.NextSequenceSend,
+	}
+	res.Timeout.Height = heightJSON{RevisionNumber: u.TimeoutHeight.RevisionNumber, RevisionHeight: u.TimeoutHeight.RevisionHeight}
+	res.Timeout.Timestamp = u.TimeoutTimestamp
+	return res
+}
+
+// BuildChannelUpgradeProposal returns a gov v1 proposal initiating the upgrade of the channel with portID and channelID
+// to fields, with a deposit such as "10000000stake". Submit it with SubmitProposal.
+// Once the proposal passes, a relayer completes the upgrade handshake with the counterparty chain,
+// or RelayChannelUpgrade for relayers that do not support channel upgrades.
+//
+// Channel upgrades require chains with ibc-go v8.1 or later, whose gov module is the authority of channel upgrades.
+// Fields that do not change must be set to the current values of the channel, see QueryChannel.
+func (c *CosmosChain) BuildChannelUpgradeProposal(portID, channelID string, fields ChannelUpgradeFields, deposit, title, summary string) (ProposalV1, error) {
+	if fields.Ordering == chantypes.NONE {
+		return ProposalV1{}, errors.New("channel upgrade requires an ordering")
+	}
+	if len(fields.ConnectionHops) == 0 {
+		return ProposalV1{}, errors.New("channel upgrade requires connection hops")
+	}
+	if fields.Version == "" {
+		return ProposalV1{}, errors.New("channel upgrade requires a version")
+	}
+
+	authority, err := c.GovModuleAddress()
+	if err != nil {
+		return ProposalV1{}, err
+	}
+	msg, err := json.Marshal(struct {
+		Type      string                   `json:"@type"`
+		PortID    string                   `json:"port_id"`
+		ChannelID string                   `json:"channel_id"`
+		Fields    channelUpgradeFieldsJSON `json:"fields"`
+		Signer    string                   `json:"signer"`
+	}{
+		Type:      "/ibc.core.channel.v1.MsgChannelUpgradeInit",
+		PortID:    portID,
+		ChannelID: channelID,
+		Fields:    channelUpgradeFieldsToJSON(fields),
+		Signer:    authority,
+	})
+	if err != nil {
+		return ProposalV1{}, fmt.Errorf("failed to marshal channel upgrade message: %w", err)
+	}
+
+	return ProposalV1{
+		Messages: []json.RawMessage{msg},
+		Deposit:  deposit,
+		Title:    title,
+		Summary:  summary,
+	}, nil
+}
+
+// ChannelUpgradeProposal submits a gov v1 proposal initiating the upgrade of a channel,
+// built with BuildChannelUpgradeProposal.
+func (c *CosmosChain) ChannelUpgradeProposal(ctx context.Context, keyName string, prop ChannelUpgradeProposal) (TxProposal, error) {
+	p, err := c.BuildChannelUpgradeProposal(prop.PortID, prop.ChannelID, prop.Fields, prop.Deposit, prop.Title, prop.Summary)
+	if err != nil {
+		return TxProposal{}, err
+	}
+	return c.SubmitProposal(ctx, keyName, p)
+}
+
+// SupportsChannelUpgrades reports whether the chain serves the channel params query added with channel upgrades in ibc-go v8.1,
+// i.e. accepts channel upgrade proposals.
+func (c *CosmosChain) SupportsChannelUpgrades(ctx context.Context) (bool, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	// QueryChannelParamsRequest has no fields, and the response is discarded.
+	err = conn.Invoke(ctx, "/ibc.core.channel.v1.Query/ChannelParams", &gogotypes.Empty{}, &gogotypes.Empty{})
+	switch status.Code(err) {
+	case codes.OK:
+		return true, nil
+	case codes.Unimplemented:
+		return false, nil
+	}
+	return false, fmt.Errorf("query channel params: %w", err)
+}
+
+// QueryChannel returns the channel with portID and channelID, e.g. to assert its version after a channel upgrade.
+func (c *CosmosChain) QueryChannel(ctx context.Context, portID, channelID string) (*chantypes.Channel, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := chantypes.NewQueryClient(conn).Channel(ctx, &chantypes.QueryChannelRequest{PortId: portID, ChannelId: channelID})
+	if err != nil {
+		return nil, fmt.Errorf("query channel %s/%s: %w", portID, channelID, err)
+	}
+	return res.Channel, nil
+}
+
+// QueryChannelEnd returns the channel end with portID and channelID, including its UpgradeSequence,
+// which the ibc-go types of QueryChannel and QueryChannels do not know about.
+func (c *CosmosChain) QueryChannelEnd(ctx context.Context, portID, channelID string) (ibc.ChannelOutput, error) {
+	stdout, _, err := c.getFullNode().ExecQuery(ctx, "ibc", "channel", "end", portID, channelID, "--prove=false")
+	if err != nil {
+		return ibc.ChannelOutput{}, err
+	}
+	var res struct {
+		Channel ibc.ChannelOutput `json:"channel"`
+	}
+	if err := json.Unmarshal(stdout, &res); err != nil {
+		return ibc.ChannelOutput{}, fmt.Errorf("failed to unmarshal channel %s/%s: %w", portID, channelID, err)
+	}
+	res.Channel.PortID, res.Channel.ChannelID = portID, channelID
+	return res.Channel, nil
+}
+
+// QueryChannelUpgrade returns the upgrade of the channel with portID and channelID while its upgrade handshake is in progress.
+// The query fails once the upgrade completes or is cancelled, or if the channel is not being upgraded.
+func (c *CosmosChain) QueryChannelUpgrade(ctx context.Context, portID, channelID string) (*ChannelUpgrade, error) {
+	return c.getFullNode().QueryChannelUpgrade(ctx, portID, channelID)
+}
+
+// QueryChannelUpgrade returns the upgrade of the channel with portID and channelID while its upgrade handshake is in progress.
+func (tn *ChainNode) QueryChannelUpgrade(ctx context.Context, portID, channelID string) (*ChannelUpgrade, error) {
+	stdout, _, err := tn.ExecQuery(ctx, "ibc", "channel", "upgrade", portID, channelID)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Upgrade channelUpgradeJSON `json:"upgrade"`
+	}
+	if err := json.Unmarshal(stdout, &res); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal channel upgrade: %w", err)
+	}
+	upgrade := res.Upgrade.upgrade()
+	return &upgrade, nil
+}
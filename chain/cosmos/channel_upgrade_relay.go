@@ -0,0 +1,469 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+)
+
+const (
+	// channelUpgradeGas is the gas of the transactions of the channel upgrade handshake,
+	// which verify proofs of the counterparty channel end.
+	channelUpgradeGas = 1_000_000
+
+	// channelUpgradeFlushBlocks is the number of blocks RelayChannelUpgrade waits for the packets in flight to be flushed.
+	channelUpgradeFlushBlocks = 20
+)
+
+// ChannelUpgradeProof is a channel end being upgraded, with the proofs of its state
+// that the counterparty chain verifies in the upgrade handshake.
+type ChannelUpgradeProof struct {
+	Channel      ibc.ChannelOutput
+	ChannelProof []byte
+
+	// Upgrade is the upgrade in progress, proven by UpgradeProof.
+	// It is nil if the channel is not being upgraded, e.g. once it is open again.
+	Upgrade      *ChannelUpgrade
+	UpgradeProof []byte
+
+	// ProofHeight is the height of the consensus state of the counterparty client that the proofs are verified against.
+	ProofHeight ibc.Height
+}
+
+// TryMsg returns the MsgChannelUpgradeTry accepting the upgrade proven by p on the counterparty channel end
+// with portID, channelID and connectionHops, signed by signer.
+func (p ChannelUpgradeProof) TryMsg(portID, channelID string, connectionHops []string, signer string) (json.RawMessage, error) {
+	if p.Upgrade == nil {
+		return nil, errors.New("channel upgrade try requires the upgrade of the counterparty")
+	}
+	return json.Marshal(struct {
+		Type                          string                   `json:"@type"`
+		PortID                        string                   `json:"port_id"`
+		ChannelID                     string                   `json:"channel_id"`
+		ProposedUpgradeConnectionHops []string                 `json:"proposed_upgrade_connection_hops"`
+		CounterpartyUpgradeFields     channelUpgradeFieldsJSON `json:"counterparty_upgrade_fields"`
+		CounterpartyUpgradeSequence   uint64                   `json:"counterparty_upgrade_sequence,string"`
+		ProofChannel                  []byte                   `json:"proof_channel"`
+		ProofUpgrade                  []byte                   `json:"proof_upgrade"`
+		ProofHeight                   heightJSON               `json:"proof_height"`
+		Signer                        string                   `json:"signer"`
+	}{
+		Type:                          "/ibc.core.channel.v1.MsgChannelUpgradeTry",
+		PortID:                        portID,
+		ChannelID:                     channelID,
+		ProposedUpgradeConnectionHops: connectionHops,
+		CounterpartyUpgradeFields:     channelUpgradeFieldsToJSON(p.Upgrade.Fields),
+		CounterpartyUpgradeSequence:   p.Channel.UpgradeSequence,
+		ProofChannel:                  p.ChannelProof,
+		ProofUpgrade:                  p.UpgradeProof,
+		ProofHeight:                   p.proofHeight(),
+		Signer:                        signer,
+	})
+}
+
+// AckMsg returns the MsgChannelUpgradeAck acknowledging the upgrade proven by p on the counterparty channel end
+// with portID and channelID, signed by signer.
+func (p ChannelUpgradeProof) AckMsg(portID, channelID, signer string) (json.RawMessage, error) {
+	if p.Upgrade == nil {
+		return nil, errors.New("channel upgrade ack requires the upgrade of the counterparty")
+	}
+	return json.Marshal(struct {
+		Type                string             `json:"@type"`
+		PortID              string             `json:"port_id"`
+		ChannelID           string             `json:"channel_id"`
+		CounterpartyUpgrade channelUpgradeJSON `json:"counterparty_upgrade"`
+		ProofChannel        []byte             `json:"proof_channel"`
+		ProofUpgrade        []byte             `json:"proof_upgrade"`
+		ProofHeight         heightJSON         `json:"proof_height"`
+		Signer              string             `json:"signer"`
+	}{
+		Type:                "/ibc.core.channel.v1.MsgChannelUpgradeAck",
+		PortID:              portID,
+		ChannelID:           channelID,
+		CounterpartyUpgrade: channelUpgradeToJSON(*p.Upgrade),
+		ProofChannel:        p.ChannelProof,
+		ProofUpgrade:        p.UpgradeProof,
+		ProofHeight:         p.proofHeight(),
+		Signer:              signer,
+	})
+}
+
+// ConfirmMsg returns the MsgChannelUpgradeConfirm confirming the upgrade proven by p on the counterparty channel end
+// with portID and channelID, signed by signer.
+func (p ChannelUpgradeProof) ConfirmMsg(portID, channelID, signer string) (json.RawMessage, error) {
+	if p.Upgrade == nil {
+		return nil, errors.New("channel upgrade confirm requires the upgrade of the counterparty")
+	}
+	return json.Marshal(struct {
+		Type                     string             `json:"@type"`
+		PortID                   string             `json:"port_id"`
+		ChannelID                string             `json:"channel_id"`
+		CounterpartyChannelState ibc.ChannelState   `json:"counterparty_channel_state"`
+		CounterpartyUpgrade      channelUpgradeJSON `json:"counterparty_upgrade"`
+		ProofChannel             []byte             `json:"proof_channel"`
+		ProofUpgrade             []byte             `json:"proof_upgrade"`
+		ProofHeight              heightJSON         `json:"proof_height"`
+		Signer                   string             `json:"signer"`
+	}{
+		Type:                     "/ibc.core.channel.v1.MsgChannelUpgradeConfirm",
+		PortID:                   portID,
+		ChannelID:                channelID,
+		CounterpartyChannelState: p.Channel.State,
+		CounterpartyUpgrade:      channelUpgradeToJSON(*p.Upgrade),
+		ProofChannel:             p.ChannelProof,
+		ProofUpgrade:             p.UpgradeProof,
+		ProofHeight:              p.proofHeight(),
+		Signer:                   signer,
+	})
+}
+
+// OpenMsg returns the MsgChannelUpgradeOpen opening the counterparty channel end with portID and channelID
+// once the channel end proven by p completed flushing or is open, signed by signer.
+func (p ChannelUpgradeProof) OpenMsg(portID, channelID, signer string) (json.RawMessage, error) {
+	return json.Marshal(struct {
+		Type                        string           `json:"@type"`
+		PortID                      string           `json:"port_id"`
+		ChannelID                   string           `json:"channel_id"`
+		CounterpartyChannelState    ibc.ChannelState `json:"counterparty_channel_state"`
+		CounterpartyUpgradeSequence uint64           `json:"counterparty_upgrade_sequence,string"`
+		ProofChannel                []byte           `json:"proof_channel"`
+		ProofHeight                 heightJSON       `json:"proof_height"`
+		Signer                      string           `json:"signer"`
+	}{
+		Type:                        "/ibc.core.channel.v1.MsgChannelUpgradeOpen",
+		PortID:                      portID,
+		ChannelID:                   channelID,
+		CounterpartyChannelState:    p.Channel.State,
+		CounterpartyUpgradeSequence: p.Channel.UpgradeSequence,
+		ProofChannel:                p.ChannelProof,
+		ProofHeight:                 p.proofHeight(),
+		Signer:                      signer,
+	})
+}
+
+func (p ChannelUpgradeProof) proofHeight() heightJSON {
+	return heightJSON{RevisionNumber: p.ProofHeight.RevisionNumber, RevisionHeight: p.ProofHeight.RevisionHeight}
+}
+
+// proofResponse is the proof of a query with --prove.
+type proofResponse struct {
+	Proof       []byte     `json:"proof"`
+	ProofHeight heightJSON `json:"proof_height"`
+}
+
+func (r proofResponse) height() ibc.Height {
+	return ibc.NewHeight(r.ProofHeight.RevisionNumber, r.ProofHeight.RevisionHeight)
+}
+
+// QueryChannelUpgradeProof returns the channel end with portID and channelID and its upgrade in progress
+// proven at the given height, which must be the height of a consensus state of the client of the counterparty chain,
+// e.g. its latest height. The query fails if the channel is not being upgraded.
+// The proofs are of the state committed to by the block at height, i.e. of the state after the block before it.
+func (tn *ChainNode) QueryChannelUpgradeProof(ctx context.Context, portID, channelID string, height int64) (ChannelUpgradeProof, error) {
+	proof, err := tn.queryChannelProof(ctx, portID, channelID, height)
+	if err != nil {
+		return ChannelUpgradeProof{}, err
+	}
+	stdout, _, err := tn.ExecQueryAtHeight(ctx, height, "ibc", "channel", "upgrade", portID, channelID, "--prove")
+	if err != nil {
+		return ChannelUpgradeProof{}, err
+	}
+	var res struct {
+		Upgrade channelUpgradeJSON `json:"upgrade"`
+		proofResponse
+	}
+	if err := json.Unmarshal(stdout, &res); err != nil {
+		return ChannelUpgradeProof{}, fmt.Errorf("failed to unmarshal channel upgrade: %w", err)
+	}
+	if res.height() != proof.ProofHeight {
+		return ChannelUpgradeProof{}, fmt.Errorf("channel and upgrade proven at different heights %v and %v", proof.ProofHeight, res.height())
+	}
+	upgrade := res.Upgrade.upgrade()
+	proof.Upgrade = &upgrade
+	proof.UpgradeProof = res.Proof
+	return proof, nil
+}
+
+// queryChannelProof returns the channel end with portID and channelID proven at height, without its upgrade.
+func (tn *ChainNode) queryChannelProof(ctx context.Context, portID, channelID string, height int64) (ChannelUpgradeProof, error) {
+	stdout, _, err := tn.ExecQueryAtHeight(ctx, height, "ibc", "channel", "end", portID, channelID, "--prove")
+	if err != nil {
+		return ChannelUpgradeProof{}, err
+	}
+	var res struct {
+		Channel ibc.ChannelOutput `json:"channel"`
+		proofResponse
+	}
+	if err := json.Unmarshal(stdout, &res); err != nil {
+		return ChannelUpgradeProof{}, fmt.Errorf("failed to unmarshal channel %s/%s: %w", portID, channelID, err)
+	}
+	res.Channel.PortID, res.Channel.ChannelID = portID, channelID
+	return ChannelUpgradeProof{
+		Channel:      res.Channel,
+		ChannelProof: res.Proof,
+		ProofHeight:  res.height(),
+	}, nil
+}
+
+// sendJSONTxAndWait broadcasts the proto JSON encoded messages msgs in a transaction signed by keyName with exactly gas,
+// paying the chain's gas prices, and waits until the transaction is included in a block.
+// Unlike SendTxAndWait, the messages may be of types unknown to this module, e.g. of newer ibc-go versions.
+// It returns the height of the block including the transaction.
+func (tn *ChainNode) sendJSONTxAndWait(ctx context.Context, keyName string, gas uint64, msgs ...json.RawMessage) (uint64, error) {
+	res, err := tn.sendJSONTx(ctx, keyName, gas, msgs...)
+	if res == nil {
+		return 0, err
+	}
+	return uint64(res.Height), err
+}
+
+// sendJSONTx is sendJSONTxAndWait returning the result of the included transaction, e.g. for its events.
+// The result is nil unless the transaction was included.
+func (tn *ChainNode) sendJSONTx(ctx context.Context, keyName string, gas uint64, msgs ...json.RawMessage) (*coretypes.ResultTx, error) {
+	if len(msgs) == 0 {
+		return nil, errors.New("transaction has no messages")
+	}
+	fees, err := gasFees(tn.Chain.Config().GasPrices, gas)
+	if err != nil {
+		return nil, err
+	}
+
+	// Encode the transaction without messages as the SDK does, then add the messages to its body.
+	txConfig := tn.Chain.Config().EncodingConfig.TxConfig
+	b := txConfig.NewTxBuilder()
+	b.SetGasLimit(gas)
+	b.SetFeeAmount(fees)
+	bz, err := txConfig.TxJSONEncoder()(b.GetTx())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode unsigned transaction: %w", err)
+	}
+	var tx, body map[string]json.RawMessage
+	if err := json.Unmarshal(bz, &tx); err != nil {
+		return nil, fmt.Errorf("failed to decode unsigned transaction: %w", err)
+	}
+	if err := json.Unmarshal(tx["body"], &body); err != nil {
+		return nil, fmt.Errorf("failed to decode unsigned transaction body: %w", err)
+	}
+	if body["messages"], err = json.Marshal(msgs); err != nil {
+		return nil, err
+	}
+	if tx["body"], err = json.Marshal(body); err != nil {
+		return nil, err
+	}
+	unsigned, err := json.Marshal(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	txHash, err := tn.signAndBroadcastTx(ctx, keyName, unsigned)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := hex.DecodeString(txHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction hash %q: %w", txHash, err)
+	}
+
+	start, err := tn.Height(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The transaction is queried from the node, since the SDK cannot decode its messages.
+	poll := func(ctx context.Context, _ uint64) (*coretypes.ResultTx, error) {
+		return tn.Client.Tx(ctx, hash, false)
+	}
+	bp := testutil.BlockPoller[*coretypes.ResultTx]{CurrentHeight: tn.Height, PollFunc: poll}
+	res, err := bp.DoPoll(ctx, start, start+sendTxMaxBlocks)
+	if err != nil {
+		return nil, fmt.Errorf("transaction %s not included: %w", txHash, err)
+	}
+	if res.TxResult.Code != 0 {
+		return res, fmt.Errorf("transaction %s failed with code %d: %s", txHash, res.TxResult.Code, res.TxResult.Log)
+	}
+	return res, nil
+}
+
+// ChannelUpgradeEnd is a channel end of a channel upgrade relayed by RelayChannelUpgrade.
+type ChannelUpgradeEnd struct {
+	Chain     *CosmosChain
+	PortID    string
+	ChannelID string
+
+	// KeyName is the key in the keyring of Chain signing the handshake messages on it, e.g. of a test user.
+	KeyName string
+}
+
+// RelayChannelUpgrade completes the upgrade handshake of the channel between the ends a and b,
+// whose upgrade was initiated on a, e.g. by a proposal built with BuildChannelUpgradeProposal.
+// It relays the handshake for relayers without the relayer.ChannelUpgrade capability:
+// the handshake messages are signed by the keys of the ends, with proofs queried with the chain binaries,
+// while r updates the clients of pathName, and flushes the packets in flight when the upgrade requires it.
+//
+// It returns once both ends are open with the upgrade, or the error of the first step that failed,
+// e.g. because the counterparty chain rejected the upgrade.
+func RelayChannelUpgrade(ctx context.Context, r ibc.Relayer, rep ibc.RelayerExecReporter, pathName string, a, b ChannelUpgradeEnd) error {
+	// lastHeight is the height of the last change of each channel end, which the next proof of the end must include.
+	lastHeight := make(map[*CosmosChain]uint64)
+
+	// relay submits the message that msg builds on dst from the proof of the channel end src,
+	// with the upgrade of src unless the step is the open step, and returns the resulting state of dst.
+	relay := func(step string, src, dst ChannelUpgradeEnd, msg func(p ChannelUpgradeProof, signer string) (json.RawMessage, error)) (ibc.ChannelState, error) {
+		// The change of src must be committed to by the header the proofs are verified against,
+		// i.e. by a block after the one including it.
+		if last := lastHeight[src.Chain]; last > 0 {
+			h, err := src.Chain.Height(ctx)
+			if err != nil {
+				return "", err
+			}
+			if h <= last {
+				if err := testutil.WaitForBlocks(ctx, int(last-h+1), src.Chain); err != nil {
+					return "", err
+				}
+			}
+		}
+		if err := r.UpdateClients(ctx, rep, pathName); err != nil {
+			return "", fmt.Errorf("channel upgrade %s: failed to update clients: %w", step, err)
+		}
+		clientHeight, err := dst.Chain.counterpartyLatestHeight(ctx, dst.PortID, dst.ChannelID)
+		if err != nil {
+			return "", fmt.Errorf("channel upgrade %s: %w", step, err)
+		}
+		query := src.Chain.getFullNode().QueryChannelUpgradeProof
+		if step == "open" {
+			// The upgrade of src is gone once src is open.
+			query = src.Chain.getFullNode().queryChannelProof
+		}
+		p, err := query(ctx, src.PortID, src.ChannelID, int64(clientHeight.GetRevisionHeight()))
+		if err != nil {
+			return "", fmt.Errorf("channel upgrade %s: failed to prove channel %s/%s on %s: %w",
+				step, src.PortID, src.ChannelID, src.Chain.Config().ChainID, err)
+		}
+
+		signer, err := dst.Chain.getFullNode().AccountKeyBech32(ctx, dst.KeyName)
+		if err != nil {
+			return "", err
+		}
+		m, err := msg(p, signer)
+		if err != nil {
+			return "", fmt.Errorf("channel upgrade %s: %w", step, err)
+		}
+		height, err := dst.Chain.getFullNode().sendJSONTxAndWait(ctx, dst.KeyName, channelUpgradeGas, m)
+		if err != nil {
+			return "", fmt.Errorf("channel upgrade %s on %s: %w", step, dst.Chain.Config().ChainID, err)
+		}
+		lastHeight[dst.Chain] = height
+
+		ch, err := dst.Chain.QueryChannelEnd(ctx, dst.PortID, dst.ChannelID)
+		if err != nil {
+			return "", err
+		}
+		return ch.State, nil
+	}
+
+	// flush has r relay the packets in flight on the channel end until the end completes flushing.
+	flush := func(end ChannelUpgradeEnd) error {
+		if err := r.FlushPackets(ctx, rep, pathName, end.ChannelID); err != nil {
+			return fmt.Errorf("failed to flush packets: %w", err)
+		}
+		if err := r.FlushAcknowledgements(ctx, rep, pathName, end.ChannelID); err != nil {
+			return fmt.Errorf("failed to flush acknowledgements: %w", err)
+		}
+		bp := testutil.BlockPoller[uint64]{
+			CurrentHeight: end.Chain.Height,
+			PollFunc: func(ctx context.Context, height uint64) (uint64, error) {
+				ch, err := end.Chain.QueryChannelEnd(ctx, end.PortID, end.ChannelID)
+				if err != nil {
+					return 0, err
+				}
+				if ch.State != ibc.ChannelStateFlushComplete {
+					return 0, fmt.Errorf("channel %s/%s on %s is %s", end.PortID, end.ChannelID, end.Chain.Config().ChainID, ch.State)
+				}
+				return height, nil
+			},
+		}
+		start, err := end.Chain.Height(ctx)
+		if err != nil {
+			return err
+		}
+		height, err := bp.DoPoll(ctx, start, start+channelUpgradeFlushBlocks)
+		if err != nil {
+			return fmt.Errorf("channel upgrade not flushed: %w", err)
+		}
+		lastHeight[end.Chain] = height
+		return nil
+	}
+
+	open := func(src, dst ChannelUpgradeEnd) error {
+		state, err := relay("open", src, dst, func(p ChannelUpgradeProof, signer string) (json.RawMessage, error) {
+			return p.OpenMsg(dst.PortID, dst.ChannelID, signer)
+		})
+		if err != nil {
+			return err
+		}
+		if state != ibc.ChannelStateOpen {
+			return fmt.Errorf("channel upgrade open: channel %s/%s on %s is %s", dst.PortID, dst.ChannelID, dst.Chain.Config().ChainID, state)
+		}
+		return nil
+	}
+
+	b0, err := b.Chain.QueryChannelEnd(ctx, b.PortID, b.ChannelID)
+	if err != nil {
+		return err
+	}
+	state, err := relay("try", a, b, func(p ChannelUpgradeProof, signer string) (json.RawMessage, error) {
+		return p.TryMsg(b.PortID, b.ChannelID, b0.ConnectionHops, signer)
+	})
+	if err != nil {
+		return err
+	}
+	if state != ibc.ChannelStateFlushing {
+		// A rejected upgrade leaves the channel open, with an error receipt cancelling the upgrade on a.
+		return fmt.Errorf("channel upgrade try: channel %s/%s on %s is %s, the upgrade was rejected",
+			b.PortID, b.ChannelID, b.Chain.Config().ChainID, state)
+	}
+
+	state, err = relay("ack", b, a, func(p ChannelUpgradeProof, signer string) (json.RawMessage, error) {
+		return p.AckMsg(a.PortID, a.ChannelID, signer)
+	})
+	if err != nil {
+		return err
+	}
+	// a only completes flushing once the packets it sent before the upgrade are acknowledged or timed out.
+	switch state {
+	case ibc.ChannelStateFlushing:
+		if err := flush(a); err != nil {
+			return fmt.Errorf("channel upgrade ack: %w", err)
+		}
+	case ibc.ChannelStateFlushComplete:
+	default:
+		return fmt.Errorf("channel upgrade ack: channel %s/%s on %s is %s", a.PortID, a.ChannelID, a.Chain.Config().ChainID, state)
+	}
+
+	state, err = relay("confirm", a, b, func(p ChannelUpgradeProof, signer string) (json.RawMessage, error) {
+		return p.ConfirmMsg(b.PortID, b.ChannelID, signer)
+	})
+	if err != nil {
+		return err
+	}
+	// b opens right away if it has no packets in flight, otherwise it is opened once it completed flushing.
+	if state == ibc.ChannelStateFlushing {
+		if err := flush(b); err != nil {
+			return fmt.Errorf("channel upgrade confirm: %w", err)
+		}
+	}
+
+	if err := open(b, a); err != nil {
+		return err
+	}
+	if state != ibc.ChannelStateOpen {
+		return open(a, b)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package cosmos_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelUpgradeProofMsgs(t *testing.T) {
+	const signer = "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu"
+
+	proof := cosmos.ChannelUpgradeProof{
+		Channel: ibc.ChannelOutput{
+			State:           ibc.ChannelStateFlushComplete,
+			PortID:          "transfer",
+			ChannelID:       "channel-0",
+			ConnectionHops:  []string{"connection-0"},
+			UpgradeSequence: 1,
+		},
+		ChannelProof: []byte("proof of channel"),
+		Upgrade: &cosmos.ChannelUpgrade{
+			Fields: cosmos.ChannelUpgradeFields{
+				Ordering:       chantypes.UNORDERED,
+				ConnectionHops: []string{"connection-0"},
+				Version:        `{"fee_version":"ics29-1","app_version":"ics20-1"}`,
+			},
+			TimeoutHeight:    ibc.NewHeight(1, 500),
+			TimeoutTimestamp: 1_700_000_000_000_000_000,
+			NextSequenceSend: 3,
+		},
+		UpgradeProof: []byte("proof of upgrade"),
+		ProofHeight:  ibc.NewHeight(1, 42),
+	}
+
+	// The messages must be encoded as by the types of ibc-go v8.1, see testdata/channelupgrade/gen.
+	requireMsg := func(t *testing.T, golden string, msg json.RawMessage, err error) {
+		t.Helper()
+		require.NoError(t, err)
+		want, err := os.ReadFile(filepath.Join("testdata", "channelupgrade", golden))
+		require.NoError(t, err)
+		require.JSONEq(t, string(want), string(msg))
+	}
+
+	msg, err := proof.TryMsg("transfer", "channel-1", []string{"connection-1"}, signer)
+	requireMsg(t, "msg_channel_upgrade_try.json", msg, err)
+
+	msg, err = proof.AckMsg("transfer", "channel-1", signer)
+	requireMsg(t, "msg_channel_upgrade_ack.json", msg, err)
+
+	msg, err = proof.ConfirmMsg("transfer", "channel-1", signer)
+	requireMsg(t, "msg_channel_upgrade_confirm.json", msg, err)
+
+	open := proof
+	open.Channel.State = ibc.ChannelStateOpen
+	open.Upgrade, open.UpgradeProof = nil, nil
+	msg, err = open.OpenMsg("transfer", "channel-1", signer)
+	requireMsg(t, "msg_channel_upgrade_open.json", msg, err)
+
+	_, err = open.TryMsg("transfer", "channel-1", []string{"connection-1"}, signer)
+	require.ErrorContains(t, err, "requires the upgrade")
+	_, err = open.AckMsg("transfer", "channel-1", signer)
+	require.ErrorContains(t, err, "requires the upgrade")
+	_, err = open.ConfirmMsg("transfer", "channel-1", signer)
+	require.ErrorContains(t, err, "requires the upgrade")
+}
@@ -0,0 +1,126 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"golang.org/x/sync/errgroup"
+)
+
+// fakeTimeLibs are the paths of libfaketime in common chain base images, preloaded into nodes with a clock offset.
+// The dynamic linker skips the paths that do not exist in the image.
+var fakeTimeLibs = []string{
+	"/usr/lib/x86_64-linux-gnu/faketime/libfaketime.so.1",
+	"/usr/lib/aarch64-linux-gnu/faketime/libfaketime.so.1",
+	"/usr/lib/faketime/libfaketime.so.1",
+}
+
+// fakeTimeFile is the file in the node's home directory from which libfaketime reads the clock offset.
+const fakeTimeFile = "faketime"
+
+// clockOffsetTolerance is how far behind the offset time a new block may be, e.g. for the time taken to produce it.
+const clockOffsetTolerance = 10 * time.Second
+
+// SetClockOffset offsets the clocks of all nodes of the chain by offset, relative to the real time,
+// e.g. a positive offset puts the chain ahead of the other chains.
+// The new offset applies to the running nodes immediately.
+//
+// The chain must have been started with a clock offset, see ibc.ChainConfig.ClockOffset.
+// An error is returned if the offset does not apply, e.g. as the node binary does not read the time through libc.
+func (c *CosmosChain) SetClockOffset(ctx context.Context, offset time.Duration) error {
+	if c.cfg.ClockOffset == nil {
+		return fmt.Errorf("chain %s was not started with a clock offset", c.cfg.ChainID)
+	}
+
+	var eg errgroup.Group
+	for _, n := range c.Nodes() {
+		n := n
+		eg.Go(func() error {
+			return n.SetClockOffset(ctx, offset)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	// Nodes recreated later, e.g. after an upgrade, keep the offset.
+	c.cfg.ClockOffset = &offset
+	return c.verifyClockOffset(ctx, offset)
+}
+
+// verifyClockOffset returns an error if a new block of the chain is behind the real time offset by offset,
+// as the nodes run on the real time if libfaketime does not apply to their binary.
+// Block times only move forward, so an offset lower than the previous one is not verified beyond that.
+func (c *CosmosChain) verifyClockOffset(ctx context.Context, offset time.Duration) error {
+	// The time of a block is that of the votes for the previous block, which may predate the offset.
+	if err := testutil.WaitForBlocks(ctx, 2, c); err != nil {
+		return err
+	}
+	res, err := c.getFullNode().Client.Block(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to query latest block of %s: %w", c.cfg.ChainID, err)
+	}
+	want := time.Now().Add(offset)
+	if behind := want.Sub(res.Block.Time); behind > clockOffsetTolerance {
+		return fmt.Errorf(
+			"block %d of chain %s is at %s, %s behind the clock offset %s: the node binary must read the time through libc for libfaketime to apply",
+			res.Block.Height, c.cfg.ChainID, res.Block.Time.UTC().Format(time.RFC3339), behind, offset,
+		)
+	}
+	return nil
+}
+
+// SetClockOffset offsets the clock of the node by offset, relative to the real time.
+// The chain must have been started with a clock offset, see ibc.ChainConfig.ClockOffset.
+// An error is returned if the image of the node does not include libfaketime.
+func (tn *ChainNode) SetClockOffset(ctx context.Context, offset time.Duration) error {
+	if err := tn.requireFakeTime(ctx); err != nil {
+		return err
+	}
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	if err := fw.WriteFile(ctx, tn.VolumeName, fakeTimeFile, []byte(fakeTimeOffset(offset))); err != nil {
+		return fmt.Errorf("writing clock offset to docker volume: %w", err)
+	}
+	return nil
+}
+
+// requireFakeTime returns an error if none of fakeTimeLibs is in the image of the node.
+func (tn *ChainNode) requireFakeTime(ctx context.Context) error {
+	script := `for lib in "$@"; do [ -e "$lib" ] && exit 0; done; exit 1`
+	cmd := append([]string{"sh", "-c", script, "sh"}, fakeTimeLibs...)
+	if _, _, err := tn.Exec(ctx, cmd, nil); err != nil {
+		return fmt.Errorf("image %s has no libfaketime to offset the clock of %s, at any of %s: %w",
+			tn.Image.Ref(), tn.Name(), strings.Join(fakeTimeLibs, ", "), err)
+	}
+	return nil
+}
+
+// fakeTimeEnv returns the environment preloading libfaketime into the node,
+// or nil if the chain has no clock offset.
+func (tn *ChainNode) fakeTimeEnv() []string {
+	if tn.Chain.Config().ClockOffset == nil {
+		return nil
+	}
+	return []string{
+		"LD_PRELOAD=" + strings.Join(fakeTimeLibs, ":"),
+		"FAKETIME_TIMESTAMP_FILE=" + path.Join(tn.HomeDir(), fakeTimeFile),
+		// Read the file on every call, so that SetClockOffset applies to the running node.
+		"FAKETIME_NO_CACHE=1",
+		"DONT_FAKE_MONOTONIC=1",
+	}
+}
+
+// fakeTimeOffset formats offset as a relative libfaketime time, e.g. "+30" or "-1.5" seconds.
+func fakeTimeOffset(offset time.Duration) string {
+	s := strconv.FormatFloat(offset.Seconds(), 'f', -1, 64)
+	if offset >= 0 {
+		s = "+" + s
+	}
+	return s
+}
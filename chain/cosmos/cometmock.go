@@ -0,0 +1,181 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	dockerclient "github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	libclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+	"go.uber.org/zap"
+)
+
+// ErrNoMockConsensus is returned when controlling the blocks of a chain that runs its own consensus engine,
+// i.e. that has no ChainConfig.ConsensusEngine.
+var ErrNoMockConsensus = errors.New("chain does not run against a mock consensus engine, see ibc.ChainConfig.ConsensusEngine")
+
+// cometMockHomeDir is the home directory of the validator v in the container of CometMock.
+func cometMockHomeDir(v *ChainNode) string {
+	return path.Join("/cometmock", fmt.Sprintf("val-%d", v.Index))
+}
+
+// initializeCometMock creates the CometMock process of a chain with a consensus engine config, once.
+// CometMock mounts the volumes of all validators to sign blocks with their keys.
+func (c *CosmosChain) initializeCometMock(testName string, cli *dockerclient.Client, networkID string) error {
+	cfg := c.cfg.ConsensusEngine
+	if cfg == nil || c.cometMock != nil {
+		return nil
+	}
+	switch {
+	case len(c.Validators) == 0:
+		return errors.New("consensus engine requires at least one validator")
+	case c.numFullNodes > 0:
+		return fmt.Errorf("consensus engine does not support full nodes, got %d", c.numFullNodes)
+	case c.cfg.Rollup != nil:
+		return errors.New("consensus engine and rollup are mutually exclusive")
+	}
+
+	port := strings.TrimSuffix(ibc.CometMockABCIPort, "/tcp")
+	var appAddresses, homeDirs, binds []string
+	for _, v := range c.Validators {
+		appAddresses = append(appAddresses, v.HostName()+":"+port)
+		homeDirs = append(homeDirs, cometMockHomeDir(v))
+		binds = append(binds, v.VolumeName+":"+cometMockHomeDir(v))
+	}
+	genesisFile := path.Join(homeDirs[0], "config", "genesis.json")
+
+	c.cometMock = &SidecarProcess{
+		Config:       cfg.CometMockSidecar(appAddresses, genesisFile, homeDirs),
+		Chain:        c,
+		NetworkID:    networkID,
+		DockerClient: cli,
+		TestName:     testName,
+		log:          c.log,
+		extraBinds:   binds,
+	}
+	return nil
+}
+
+// CometMock returns the CometMock process of the chain, or nil if the chain runs its own consensus engine.
+func (c *CosmosChain) CometMock() *SidecarProcess {
+	return c.cometMock
+}
+
+// startCometMock starts CometMock once the apps of the validators are started,
+// and connects the RPC clients of the nodes to its RPC server, which serves the chain.
+func (c *CosmosChain) startCometMock(ctx context.Context) error {
+	if err := c.cometMock.CreateContainer(ctx); err != nil {
+		return err
+	}
+	if err := c.cometMock.StartContainer(ctx); err != nil {
+		return err
+	}
+	hostPorts, err := c.cometMock.GetHostPorts(ctx, ibc.CometMockPort)
+	if err != nil {
+		return err
+	}
+	c.cometMockHostRPC = hostPorts[0]
+	c.log.Info("CometMock started", zap.String("chain_id", c.cfg.ChainID), zap.String("rpc_port", c.cometMockHostRPC))
+
+	for _, n := range c.Nodes() {
+		if err := n.connectCometMock(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// connectCometMock points the RPC client of tn to CometMock and waits for it to serve the chain.
+func (tn *ChainNode) connectCometMock(ctx context.Context) error {
+	c := tn.Chain.(*CosmosChain)
+	tn.hostRPCPort = c.cometMockHostRPC
+	if err := tn.NewClient("tcp://" + tn.hostRPCPort); err != nil {
+		return err
+	}
+	return retry.Do(func() error {
+		_, err := tn.Client.Status(ctx)
+		return err
+	}, retry.Context(ctx), retry.Attempts(40), retry.Delay(time.Second), retry.DelayType(retry.FixedDelay))
+}
+
+// usesCometMock reports whether tn runs its app against CometMock instead of its own consensus engine.
+func (tn *ChainNode) usesCometMock() bool {
+	c, ok := tn.Chain.(*CosmosChain)
+	return ok && c.cometMock != nil
+}
+
+// consensusEngineFlags returns the flags of the start command of tn if its chain runs against CometMock.
+func (tn *ChainNode) consensusEngineFlags() []string {
+	if !tn.usesCometMock() {
+		return nil
+	}
+	return tn.Chain.Config().ConsensusEngine.NodeFlags()
+}
+
+// rpcAddress returns the address of the RPC server serving tn on the docker network, e.g. host:26657,
+// which is that of CometMock if the chain runs against it.
+func (tn *ChainNode) rpcAddress() string {
+	if tn.usesCometMock() {
+		c := tn.Chain.(*CosmosChain)
+		return c.cometMock.HostName() + ":" + strings.TrimSuffix(ibc.CometMockPort, "/tcp")
+	}
+	return tn.HostName() + ":" + strings.TrimSuffix(rpcPort, "/tcp")
+}
+
+// AdvanceBlocks makes CometMock produce n blocks at once.
+// It returns ErrNoMockConsensus if the chain runs its own consensus engine.
+func (c *CosmosChain) AdvanceBlocks(ctx context.Context, n int) error {
+	if n < 1 {
+		return fmt.Errorf("number of blocks must be positive, got %d", n)
+	}
+	if err := c.callCometMock(ctx, "advance_blocks", map[string]interface{}{"num_blocks": n}); err != nil {
+		return fmt.Errorf("advance %d blocks: %w", n, err)
+	}
+	return nil
+}
+
+// AdvanceBlock makes CometMock produce a block.
+// It returns ErrNoMockConsensus if the chain runs its own consensus engine.
+func (c *CosmosChain) AdvanceBlock(ctx context.Context) error {
+	return c.AdvanceBlocks(ctx, 1)
+}
+
+// AdvanceTime moves the block time of the chain forward by d, in whole seconds, from the next block on,
+// e.g. to expire timeouts or unbonding periods without waiting for them. Use AdvanceBlock to produce that block.
+// It returns ErrNoMockConsensus if the chain runs its own consensus engine.
+func (c *CosmosChain) AdvanceTime(ctx context.Context, d time.Duration) error {
+	if d < time.Second || d%time.Second != 0 {
+		return fmt.Errorf("duration must be a positive number of seconds, got %s", d)
+	}
+	secs := int64(d / time.Second)
+	if err := c.callCometMock(ctx, "advance_time", map[string]interface{}{"duration_in_seconds": secs}); err != nil {
+		return fmt.Errorf("advance time by %s: %w", d, err)
+	}
+	return nil
+}
+
+// callCometMock calls the JSON-RPC method of the RPC server of CometMock that controls the chain.
+func (c *CosmosChain) callCometMock(ctx context.Context, method string, params map[string]interface{}) error {
+	if c.cometMock == nil {
+		return ErrNoMockConsensus
+	}
+	cl, err := libclient.New("tcp://" + c.cometMockHostRPC)
+	if err != nil {
+		return err
+	}
+	var res struct{}
+	_, err = cl.Call(ctx, method, params, &res)
+	return err
+}
+
+// ProducesBlocksOnDemand reports whether the chain runs against CometMock without automatic block production,
+// i.e. only produces blocks on AdvanceBlocks and, by default, for each transaction.
+// testutil.WaitForBlocks then produces the blocks it waits for.
+func (c *CosmosChain) ProducesBlocksOnDemand() bool {
+	return c.cometMock != nil && c.cfg.ConsensusEngine.BlockInterval <= 0
+}
@@ -0,0 +1,25 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestBlockControlWithoutMockConsensus(t *testing.T) {
+	c := cosmos.NewCosmosChain(t.Name(), ibc.ChainConfig{ChainID: "test-1"}, 1, 0, zaptest.NewLogger(t))
+	ctx := context.Background()
+
+	require.Nil(t, c.CometMock())
+	require.ErrorIs(t, c.AdvanceBlock(ctx), cosmos.ErrNoMockConsensus)
+	require.ErrorIs(t, c.AdvanceBlocks(ctx, 5), cosmos.ErrNoMockConsensus)
+	require.ErrorIs(t, c.AdvanceTime(ctx, time.Hour), cosmos.ErrNoMockConsensus)
+
+	require.ErrorContains(t, c.AdvanceBlocks(ctx, 0), "must be positive")
+	require.ErrorContains(t, c.AdvanceTime(ctx, 1500*time.Millisecond), "number of seconds")
+}
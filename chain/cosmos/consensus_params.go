@@ -0,0 +1,264 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/icza/dyno"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// maxBlockBytes is the maximum block size CometBFT accepts.
+const maxBlockBytes = tmtypes.MaxBlockSizeBytes
+
+// ConsensusParams are the consensus params of a chain that tests can change,
+// in genesis with ModifyGenesisConsensusParams or by governance with BuildConsensusParamsProposal.
+type ConsensusParams struct {
+	Block     BlockParams
+	Evidence  EvidenceParams
+	Validator ValidatorParams
+}
+
+// BlockParams limit the size of the blocks of a chain.
+type BlockParams struct {
+	// MaxBytes is the maximum size of a block in bytes.
+	MaxBytes int64
+	// MaxGas is the maximum gas wanted by the transactions of a block, or -1 for no limit.
+	// Transactions wanting more gas than MaxGas are rejected from the mempool.
+	MaxGas int64
+}
+
+// EvidenceParams limit the misbehaviour evidence a chain accepts.
+// Evidence is too old once both MaxAgeNumBlocks blocks and MaxAgeDuration passed since the misbehaviour.
+type EvidenceParams struct {
+	MaxAgeNumBlocks int64
+	MaxAgeDuration  time.Duration
+	// MaxBytes is the maximum size of the evidence of a block in bytes, at most Block.MaxBytes.
+	MaxBytes int64
+}
+
+// ValidatorParams restrict the consensus keys of the validators of a chain.
+type ValidatorParams struct {
+	// PubKeyTypes are the accepted key types, e.g. ed25519 or secp256k1.
+	PubKeyTypes []string
+}
+
+// Validate returns an error if CometBFT does not accept p.
+func (p ConsensusParams) Validate() error {
+	if p.Block.MaxBytes <= 0 || p.Block.MaxBytes > maxBlockBytes {
+		return fmt.Errorf("block max bytes must be in (0, %d], got %d", maxBlockBytes, p.Block.MaxBytes)
+	}
+	if p.Block.MaxGas < -1 {
+		return fmt.Errorf("block max gas must be at least -1, got %d", p.Block.MaxGas)
+	}
+	if p.Evidence.MaxAgeNumBlocks <= 0 {
+		return fmt.Errorf("evidence max age must be positive, got %d blocks", p.Evidence.MaxAgeNumBlocks)
+	}
+	if p.Evidence.MaxAgeDuration <= 0 {
+		return fmt.Errorf("evidence max age must be positive, got %s", p.Evidence.MaxAgeDuration)
+	}
+	if p.Evidence.MaxBytes < 0 || p.Evidence.MaxBytes > p.Block.MaxBytes {
+		return fmt.Errorf("evidence max bytes must be in [0, %d], the block max bytes, got %d", p.Block.MaxBytes, p.Evidence.MaxBytes)
+	}
+	if len(p.Validator.PubKeyTypes) == 0 {
+		return errors.New("validator pub key types must not be empty")
+	}
+	for _, t := range p.Validator.PubKeyTypes {
+		if _, ok := tmtypes.ABCIPubKeyTypesToNames[t]; !ok {
+			return fmt.Errorf("unknown validator pub key type %q", t)
+		}
+	}
+	return nil
+}
+
+// consensusParamsJSON is the JSON encoding of ConsensusParams in genesis,
+// where integers are strings and durations are in nanoseconds.
+type consensusParamsJSON struct {
+	Block struct {
+		MaxBytes string `json:"max_bytes"`
+		MaxGas   string `json:"max_gas"`
+	} `json:"block"`
+	Evidence struct {
+		MaxAgeNumBlocks string `json:"max_age_num_blocks"`
+		MaxAgeDuration  string `json:"max_age_duration"`
+		MaxBytes        string `json:"max_bytes"`
+	} `json:"evidence"`
+	Validator struct {
+		PubKeyTypes []string `json:"pub_key_types"`
+	} `json:"validator"`
+}
+
+func (j consensusParamsJSON) params() (ConsensusParams, error) {
+	var p ConsensusParams
+	ints := []struct {
+		name string
+		s    string
+		v    *int64
+	}{
+		{"block max_bytes", j.Block.MaxBytes, &p.Block.MaxBytes},
+		{"block max_gas", j.Block.MaxGas, &p.Block.MaxGas},
+		{"evidence max_age_num_blocks", j.Evidence.MaxAgeNumBlocks, &p.Evidence.MaxAgeNumBlocks},
+		{"evidence max_bytes", j.Evidence.MaxBytes, &p.Evidence.MaxBytes},
+	}
+	for _, i := range ints {
+		v, err := strconv.ParseInt(i.s, 10, 64)
+		if err != nil {
+			return ConsensusParams{}, fmt.Errorf("invalid consensus param %s: %w", i.name, err)
+		}
+		*i.v = v
+	}
+	ns, err := strconv.ParseInt(j.Evidence.MaxAgeDuration, 10, 64)
+	if err != nil {
+		return ConsensusParams{}, fmt.Errorf("invalid consensus param evidence max_age_duration: %w", err)
+	}
+	p.Evidence.MaxAgeDuration = time.Duration(ns)
+	p.Validator.PubKeyTypes = j.Validator.PubKeyTypes
+	return p, nil
+}
+
+// genesisConsensusParamsPath returns the path of the consensus params in the genesis g,
+// which SDK v0.50 moved from consensus_params to consensus.params.
+func genesisConsensusParamsPath(g map[string]any) []any {
+	if _, err := dyno.Get(g, "consensus", "params"); err == nil {
+		return []any{"consensus", "params"}
+	}
+	return []any{"consensus_params"}
+}
+
+// ModifyGenesisConsensusParams returns a ChainConfig.ModifyGenesis function that changes the consensus params
+// of the chain with modify, e.g. to lower the block max gas so that large transactions are rejected,
+// or the evidence max age for misbehaviour tests. The modified params must be valid, see ConsensusParams.Validate.
+// Consensus params that ConsensusParams lacks are kept.
+func ModifyGenesisConsensusParams(modify func(p *ConsensusParams)) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(_ ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		g := make(map[string]any)
+		if err := json.Unmarshal(genbz, &g); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+		}
+		path := genesisConsensusParamsPath(g)
+		var j consensusParamsJSON
+		if err := genesisSection(g, &j, path...); err != nil {
+			return nil, err
+		}
+		p, err := j.params()
+		if err != nil {
+			return nil, err
+		}
+		modify(&p)
+		if err := p.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid consensus params: %w", err)
+		}
+
+		pubKeyTypes := make([]any, len(p.Validator.PubKeyTypes))
+		for i, t := range p.Validator.PubKeyTypes {
+			pubKeyTypes[i] = t
+		}
+		for _, kv := range []struct {
+			section, key string
+			v            any
+		}{
+			{"block", "max_bytes", strconv.FormatInt(p.Block.MaxBytes, 10)},
+			{"block", "max_gas", strconv.FormatInt(p.Block.MaxGas, 10)},
+			{"evidence", "max_age_num_blocks", strconv.FormatInt(p.Evidence.MaxAgeNumBlocks, 10)},
+			{"evidence", "max_age_duration", strconv.FormatInt(int64(p.Evidence.MaxAgeDuration), 10)},
+			{"evidence", "max_bytes", strconv.FormatInt(p.Evidence.MaxBytes, 10)},
+			{"validator", "pub_key_types", pubKeyTypes},
+		} {
+			if err := dyno.Set(g, kv.v, append(append([]any(nil), path...), kv.section, kv.key)...); err != nil {
+				return nil, fmt.Errorf("failed to set consensus param %s %s in genesis json: %w", kv.section, kv.key, err)
+			}
+		}
+		out, err := json.Marshal(g)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+		}
+		return out, nil
+	}
+}
+
+// consensusParamsFromProto converts the consensus params served by the RPC of a node.
+func consensusParamsFromProto(p tmproto.ConsensusParams) ConsensusParams {
+	return ConsensusParams{
+		Block: BlockParams{
+			MaxBytes: p.Block.MaxBytes,
+			MaxGas:   p.Block.MaxGas,
+		},
+		Evidence: EvidenceParams{
+			MaxAgeNumBlocks: p.Evidence.MaxAgeNumBlocks,
+			MaxAgeDuration:  p.Evidence.MaxAgeDuration,
+			MaxBytes:        p.Evidence.MaxBytes,
+		},
+		Validator: ValidatorParams{
+			PubKeyTypes: append([]string(nil), p.Validator.PubKeyTypes...),
+		},
+	}
+}
+
+// QueryConsensusParams returns the current consensus params of the chain.
+func (c *CosmosChain) QueryConsensusParams(ctx context.Context) (ConsensusParams, error) {
+	if err := c.throttleQuery(ctx); err != nil {
+		return ConsensusParams{}, err
+	}
+	res, err := c.getFullNode().Client.ConsensusParams(ctx, nil)
+	if err != nil {
+		return ConsensusParams{}, fmt.Errorf("query consensus params: %w", err)
+	}
+	return consensusParamsFromProto(res.ConsensusParams), nil
+}
+
+// BuildConsensusParamsProposal returns a gov v1 proposal updating the consensus params of the chain
+// to its current params changed by modify, with a MsgUpdateParams of the x/consensus module.
+// The consensus params are only updatable at runtime on chains of SDK v0.47 and later;
+// an error is returned for chains whose binary is known to be older, see BinaryVersion.
+func (c *CosmosChain) BuildConsensusParamsProposal(ctx context.Context, modify func(p *ConsensusParams), metadata, deposit, title, summary string) (ProposalV1, error) {
+	if v, ok := c.BinaryVersion(); ok && v.SDKVersion != "" && !v.SDKAtLeast("v0.47.0") {
+		return ProposalV1{}, fmt.Errorf("SDK %s has no x/consensus module to update consensus params at runtime", v.SDKVersion)
+	}
+	p, err := c.QueryConsensusParams(ctx)
+	if err != nil {
+		return ProposalV1{}, err
+	}
+	modify(&p)
+	if err := p.Validate(); err != nil {
+		return ProposalV1{}, fmt.Errorf("invalid consensus params: %w", err)
+	}
+	authority, err := c.GovModuleAddress()
+	if err != nil {
+		return ProposalV1{}, err
+	}
+
+	// The SDK of this module predates x/consensus, so the message is encoded as its JSON with its type URL.
+	msg, err := json.Marshal(map[string]any{
+		"@type":     "/cosmos.consensus.v1.MsgUpdateParams",
+		"authority": authority,
+		"block": map[string]any{
+			"max_bytes": strconv.FormatInt(p.Block.MaxBytes, 10),
+			"max_gas":   strconv.FormatInt(p.Block.MaxGas, 10),
+		},
+		"evidence": map[string]any{
+			"max_age_num_blocks": strconv.FormatInt(p.Evidence.MaxAgeNumBlocks, 10),
+			// Durations are encoded in seconds in JSON, e.g. 30s.
+			"max_age_duration": strconv.FormatFloat(p.Evidence.MaxAgeDuration.Seconds(), 'f', -1, 64) + "s",
+			"max_bytes":        strconv.FormatInt(p.Evidence.MaxBytes, 10),
+		},
+		"validator": map[string]any{
+			"pub_key_types": p.Validator.PubKeyTypes,
+		},
+	})
+	if err != nil {
+		return ProposalV1{}, fmt.Errorf("failed to marshal consensus params message: %w", err)
+	}
+	return ProposalV1{
+		Messages: []json.RawMessage{msg},
+		Metadata: metadata,
+		Deposit:  deposit,
+		Title:    title,
+		Summary:  summary,
+	}, nil
+}
@@ -3,9 +3,11 @@ package cosmos
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -18,9 +20,16 @@ import (
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	authTx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	clientTypes "github.com/cosmos/ibc-go/v6/modules/core/02-client/types"
 	chanTypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	ibcexported "github.com/cosmos/ibc-go/v6/modules/core/exported"
 	dockertypes "github.com/docker/docker/api/types"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
@@ -31,8 +40,6 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v6/testutil"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 // CosmosChain is a local docker testnet for a Cosmos SDK chain.
@@ -44,10 +51,63 @@ type CosmosChain struct {
 	numFullNodes  int
 	Validators    ChainNodes
 	FullNodes     ChainNodes
+	// Sidecars are the sidecar processes of the chain; those of the validators are in ChainNode.Sidecars.
+	Sidecars SidecarProcesses
 
 	log      *zap.Logger
 	keyring  keyring.Keyring
 	findTxMu sync.Mutex
+
+	faucetMu          sync.Mutex // Guards faucet and faucetBroadcaster.
+	faucet            ibc.Wallet
+	faucetBroadcaster *SequencedBroadcaster
+
+	feemarketMu        sync.Mutex // Guards noFeemarket and feemarketQueryPath.
+	noFeemarket        bool       // Set once the chain was found to lack a fee market module.
+	feemarketQueryPath string     // Set once the chain was found to serve the fee market query with this path.
+
+	// Rate limit and counts of the queries of the chain, see QueryStats.
+	queries queryThrottle
+
+	// Applied in order to the genesis file after the ModifyGenesis function of the config, see AddGenesisModifier.
+	genesisModifiers []GenesisModifier
+
+	// Address of the remote docker host of the chain, on which its ports are published, see SetHostAddress.
+	hostAddress string
+
+	// Run before the container of each sidecar process with the key as name is created, see AddSidecarSetup.
+	sidecarSetups map[string]SidecarSetup
+
+	// Version of the chain binary probed in Start, see BinaryVersion.
+	binaryVersion *BinaryVersion
+	// Whether the chain binary has the genesis command, probed in Start.
+	hasGenesisCommand bool
+
+	// CometMock process of a chain with a consensus engine config, and the host address of its RPC server once started.
+	cometMock        *SidecarProcess
+	cometMockHostRPC string
+
+	// Addresses of the wallets added to genesis by Start, see CompareGenesis.
+	genesisWallets map[string]bool
+}
+
+// GenesisModifier modifies the genesis file of a chain during Start, like ChainConfig.ModifyGenesis,
+// with the context of Start to wait on state from outside the chain, such as the genesis of another chain.
+type GenesisModifier func(ctx context.Context, cfg ibc.ChainConfig, genbz []byte) ([]byte, error)
+
+// AddGenesisModifier adds fn to the functions modifying the genesis file in Start,
+// after the ModifyGenesis function of the chain config.
+// It must be called before Start.
+func (c *CosmosChain) AddGenesisModifier(fn GenesisModifier) {
+	c.genesisModifiers = append(c.genesisModifiers, fn)
+}
+
+// SetHostAddress sets the address of the machine of the docker daemon the chain runs on,
+// e.g. 10.0.0.2, when it is not the local daemon.
+// The host addresses of the nodes and sidecars, such as GetHostRPCAddress, are then on that address.
+// It must be called before Start.
+func (c *CosmosChain) SetHostAddress(address string) {
+	c.hostAddress = address
 }
 
 func NewCosmosHeighlinerChainConfig(name string,
@@ -77,6 +137,14 @@ func NewCosmosHeighlinerChainConfig(name string,
 	}
 }
 
+// chainLogger scopes log to the chain, so that the logs of all chains of a test can be told apart by chain_id.
+func chainLogger(log *zap.Logger, chainID, testName string) *zap.Logger {
+	return log.With(
+		zap.String("chain_id", chainID),
+		zap.String("test", testName),
+	)
+}
+
 func NewCosmosChain(testName string, chainConfig ibc.ChainConfig, numValidators int, numFullNodes int, log *zap.Logger) *CosmosChain {
 	if chainConfig.EncodingConfig == nil {
 		cfg := DefaultEncoding()
@@ -93,7 +161,7 @@ func NewCosmosChain(testName string, chainConfig ibc.ChainConfig, numValidators
 		cfg:           chainConfig,
 		numValidators: numValidators,
 		numFullNodes:  numFullNodes,
-		log:           log,
+		log:           chainLogger(log, chainConfig.ChainID, testName),
 		keyring:       kr,
 	}
 }
@@ -103,10 +171,19 @@ func (c *CosmosChain) Nodes() ChainNodes {
 	return append(c.Validators, c.FullNodes...)
 }
 
+// peerString returns the persistent peers for connecting to nodes, including the configured additional peers.
+func (c *CosmosChain) peerString(ctx context.Context, nodes ChainNodes) string {
+	peers := nodes.PeerString(ctx)
+	if len(c.cfg.AdditionalPeers) == 0 {
+		return peers
+	}
+	return strings.Join(append([]string{peers}, c.cfg.AdditionalPeers...), ",")
+}
+
 // AddFullNodes adds new fullnodes to the network, peering with the existing nodes.
 func (c *CosmosChain) AddFullNodes(ctx context.Context, configFileOverrides map[string]any, inc int) error {
 	// Get peer string for existing nodes
-	peers := c.Nodes().PeerString(ctx)
+	peers := c.peerString(ctx, c.Nodes())
 
 	// Get genesis.json
 	genbz, err := c.Validators[0].genesisFileContent(ctx)
@@ -167,7 +244,14 @@ func (c *CosmosChain) Config() ibc.ChainConfig {
 
 // Implements Chain interface
 func (c *CosmosChain) Initialize(ctx context.Context, testName string, cli *client.Client, networkID string) error {
-	return c.initializeChainNodes(ctx, testName, cli, networkID)
+	if err := c.initializeChainNodes(ctx, testName, cli, networkID); err != nil {
+		return err
+	}
+	c.addMockDA()
+	if err := c.initializeCometMock(testName, cli, networkID); err != nil {
+		return err
+	}
+	return c.initializeSidecars(testName, cli, networkID)
 }
 
 func (c *CosmosChain) getFullNode() *ChainNode {
@@ -188,7 +272,7 @@ func (c *CosmosChain) Exec(ctx context.Context, cmd []string, env []string) (std
 
 // Implements Chain interface
 func (c *CosmosChain) GetRPCAddress() string {
-	return fmt.Sprintf("http://%s:26657", c.getFullNode().HostName())
+	return "http://" + c.getFullNode().rpcAddress()
 }
 
 // Implements Chain interface
@@ -237,6 +321,13 @@ func (c *CosmosChain) GetAddress(ctx context.Context, keyName string) ([]byte, e
 // If mnemonic != "", it will restore using that mnemonic
 // If mnemonic == "", it will create a new key
 func (c *CosmosChain) BuildWallet(ctx context.Context, keyName string, mnemonic string) (ibc.Wallet, error) {
+	if keyName == FaucetAccountKeyName && mnemonic == "" {
+		var err error
+		if mnemonic, err = newFaucetMnemonic(); err != nil {
+			return nil, err
+		}
+	}
+
 	if mnemonic != "" {
 		if err := c.RecoverKey(ctx, keyName, mnemonic); err != nil {
 			return nil, fmt.Errorf("failed to recover key with name %q on chain %s: %w", keyName, c.cfg.Name, err)
@@ -252,7 +343,11 @@ func (c *CosmosChain) BuildWallet(ctx context.Context, keyName string, mnemonic
 		return nil, fmt.Errorf("failed to get account address for key %q on chain %s: %w", keyName, c.cfg.Name, err)
 	}
 
-	return NewWallet(keyName, addrBytes, mnemonic, c.cfg), nil
+	wallet := NewWallet(keyName, addrBytes, mnemonic, c.cfg)
+	if keyName == FaucetAccountKeyName {
+		c.setFaucet(c.getFullNode(), wallet)
+	}
+	return wallet, nil
 }
 
 // BuildRelayerWallet will return a Cosmos wallet populated with the mnemonic so that the wallet can
@@ -285,9 +380,27 @@ func (c *CosmosChain) BuildRelayerWallet(ctx context.Context, keyName string) (i
 
 // Implements Chain interface
 func (c *CosmosChain) SendFunds(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
+	if b := c.FaucetBroadcaster(); b != nil && keyName == FaucetAccountKeyName {
+		return b.SendFunds(ctx, amount)
+	}
 	return c.getFullNode().SendFunds(ctx, keyName, amount)
 }
 
+// SendFundsInternal sends funds to a wallet from a user account,
+// paying fees with the chain's InternalGasPrices.
+// It is intended for test plumbing, such as funding users from the faucet.
+func (c *CosmosChain) SendFundsInternal(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
+	if b := c.FaucetBroadcaster(); b != nil && keyName == FaucetAccountKeyName {
+		return b.SendFundsInternal(ctx, amount)
+	}
+	return c.getFullNode().SendFundsInternal(ctx, keyName, amount)
+}
+
+// StakingDelegate delegates amount, e.g. 1000uatom, from the account of keyName to the validator with the valoper address.
+func (c *CosmosChain) StakingDelegate(ctx context.Context, keyName, valoper, amount string) error {
+	return c.getFullNode().StakingDelegate(ctx, keyName, valoper, amount)
+}
+
 // Implements Chain interface
 func (c *CosmosChain) SendIBCTransfer(
 	ctx context.Context,
@@ -304,6 +417,9 @@ func (c *CosmosChain) SendIBCTransfer(
 	if err != nil {
 		return tx, fmt.Errorf("failed to get transaction %s: %w", txHash, err)
 	}
+	if txResp.Code != 0 {
+		return tx, fmt.Errorf("ibc transfer transaction %s failed with code %d: %s", txHash, txResp.Code, txResp.RawLog)
+	}
 	tx.Height = uint64(txResp.Height)
 	tx.TxHash = txHash
 	// In cosmos, user is charged for entire gas requested, not the actual gas used.
@@ -328,6 +444,9 @@ func (c *CosmosChain) SendIBCTransfer(
 	tx.Packet.DestChannel = dstChan
 	tx.Packet.TimeoutHeight = timeoutHeight
 	tx.Packet.Data = []byte(data)
+	if _, err := tx.Packet.ParsedTimeoutHeight(); err != nil {
+		return tx, fmt.Errorf("invalid packet timeout height from events: %w", err)
+	}
 
 	seqNum, err := strconv.Atoi(seq)
 	if err != nil {
@@ -349,6 +468,32 @@ func (c *CosmosChain) QueryProposal(ctx context.Context, proposalID string) (*Pr
 	return c.getFullNode().QueryProposal(ctx, proposalID)
 }
 
+// QueryProposalTally returns the current tally of votes on a governance proposal,
+// which may be queried while the proposal is still in its voting period.
+func (c *CosmosChain) QueryProposalTally(ctx context.Context, proposalID string) (govv1.TallyResult, error) {
+	return c.getFullNode().QueryProposalTally(ctx, proposalID)
+}
+
+// QueryProposalVote returns the vote of voter on a governance proposal, e.g. that of an interchain account.
+func (c *CosmosChain) QueryProposalVote(ctx context.Context, proposalID, voter string) (govv1beta1.Vote, error) {
+	id, err := strconv.ParseUint(proposalID, 10, 64)
+	if err != nil {
+		return govv1beta1.Vote{}, fmt.Errorf("invalid proposal ID %q: %w", proposalID, err)
+	}
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return govv1beta1.Vote{}, err
+	}
+	defer conn.Close()
+
+	res, err := govv1beta1.NewQueryClient(conn).Vote(ctx, &govv1beta1.QueryVoteRequest{ProposalId: id, Voter: voter})
+	if err != nil {
+		return govv1beta1.Vote{}, fmt.Errorf("query vote of %s on proposal %s: %w", voter, proposalID, err)
+	}
+	return res.Vote, nil
+}
+
 // UpgradeProposal submits a software-upgrade governance proposal to the chain.
 func (c *CosmosChain) UpgradeProposal(ctx context.Context, keyName string, prop SoftwareUpgradeProposal) (tx TxProposal, _ error) {
 	txHash, err := c.getFullNode().UpgradeProposal(ctx, keyName, prop)
@@ -367,6 +512,46 @@ func (c *CosmosChain) TextProposal(ctx context.Context, keyName string, prop Tex
 	return c.txProposal(txHash)
 }
 
+// ParamChangeProposal submits a param-change governance proposal to the chain.
+func (c *CosmosChain) ParamChangeProposal(ctx context.Context, keyName string, prop ParamChangeProposal) (tx TxProposal, _ error) {
+	txHash, err := c.getFullNode().ParamChangeProposal(ctx, keyName, prop)
+	if err != nil {
+		return tx, fmt.Errorf("failed to submit param change proposal: %w", err)
+	}
+	return c.txProposal(txHash)
+}
+
+// TransferParamsProposal submits a param-change governance proposal setting whether
+// ICS-20 transfers may be sent from and received by the chain.
+func (c *CosmosChain) TransferParamsProposal(ctx context.Context, keyName, deposit string, sendEnabled, receiveEnabled bool) (TxProposal, error) {
+	return c.ParamChangeProposal(ctx, keyName, ParamChangeProposal{
+		Deposit:     deposit,
+		Title:       "Transfer params",
+		Description: fmt.Sprintf("Set transfer send enabled to %t and receive enabled to %t", sendEnabled, receiveEnabled),
+		Changes: []ParamChange{
+			{Subspace: transfertypes.ModuleName, Key: string(transfertypes.KeySendEnabled), Value: sendEnabled},
+			{Subspace: transfertypes.ModuleName, Key: string(transfertypes.KeyReceiveEnabled), Value: receiveEnabled},
+		},
+	})
+}
+
+// QueryTransferParams returns whether ICS-20 transfers may be sent from and received by the chain.
+func (c *CosmosChain) QueryTransferParams(ctx context.Context) (sendEnabled, receiveEnabled bool, err error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return false, false, err
+	}
+	defer conn.Close()
+
+	queryClient := transfertypes.NewQueryClient(conn)
+	res, err := queryClient.Params(ctx, &transfertypes.QueryParamsRequest{})
+	if err != nil {
+		return false, false, fmt.Errorf("query transfer params: %w", err)
+	}
+	return res.Params.SendEnabled, res.Params.ReceiveEnabled, nil
+}
+
 func (c *CosmosChain) txProposal(txHash string) (tx TxProposal, _ error) {
 	txResp, err := c.getTransaction(txHash)
 	if err != nil {
@@ -388,8 +573,9 @@ func (c *CosmosChain) txProposal(txHash string) (tx TxProposal, _ error) {
 }
 
 // StoreContract takes a file path to smart contract and stores it on-chain. Returns the contracts code id.
-func (c *CosmosChain) StoreContract(ctx context.Context, keyName string, fileName string) (string, error) {
-	return c.getFullNode().StoreContract(ctx, keyName, fileName)
+// extraExecTxArgs are appended to the store transaction, e.g. "--gas", "5000000".
+func (c *CosmosChain) StoreContract(ctx context.Context, keyName string, fileName string, extraExecTxArgs ...string) (string, error) {
+	return c.getFullNode().StoreContract(ctx, keyName, fileName, extraExecTxArgs...)
 }
 
 // InstantiateContract takes a code id for a smart contract and initialization message and returns the instantiated contract address.
@@ -433,7 +619,7 @@ func (c *CosmosChain) ExportState(ctx context.Context, height int64) (string, er
 func (c *CosmosChain) GetBalance(ctx context.Context, address string, denom string) (int64, error) {
 	params := &bankTypes.QueryBalanceRequest{Address: address, Denom: denom}
 	grpcAddress := c.getFullNode().hostGRPCPort
-	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := c.dialGRPC(grpcAddress)
 	if err != nil {
 		return 0, err
 	}
@@ -449,6 +635,157 @@ func (c *CosmosChain) GetBalance(ctx context.Context, address string, denom stri
 	return res.Balance.Amount.Int64(), nil
 }
 
+// GetSupply fetches the current total supply of denom, e.g. of an IBC voucher denom.
+func (c *CosmosChain) GetSupply(ctx context.Context, denom string) (int64, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	res, err := bankTypes.NewQueryClient(conn).SupplyOf(ctx, &bankTypes.QuerySupplyOfRequest{Denom: denom})
+	if err != nil {
+		return 0, fmt.Errorf("query supply of %s: %w", denom, err)
+	}
+	return res.Amount.Amount.Int64(), nil
+}
+
+// QueryUnbondingTime returns the unbonding time from the chain's staking params.
+func (c *CosmosChain) QueryUnbondingTime(ctx context.Context) (time.Duration, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	queryClient := stakingtypes.NewQueryClient(conn)
+	res, err := queryClient.Params(ctx, &stakingtypes.QueryParamsRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("query staking params: %w", err)
+	}
+	return res.Params.UnbondingTime, nil
+}
+
+// QueryClientState returns the state of the IBC light client with the given ID.
+func (c *CosmosChain) QueryClientState(ctx context.Context, clientID string) (ibcexported.ClientState, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	queryClient := clientTypes.NewQueryClient(conn)
+	res, err := queryClient.ClientState(ctx, &clientTypes.QueryClientStateRequest{ClientId: clientID})
+	if err != nil {
+		return nil, fmt.Errorf("query client state %s: %w", clientID, err)
+	}
+
+	var clientState ibcexported.ClientState
+	if err := c.cfg.EncodingConfig.InterfaceRegistry.UnpackAny(res.ClientState, &clientState); err != nil {
+		return nil, fmt.Errorf("unpack client state %s: %w", clientID, err)
+	}
+	return clientState, nil
+}
+
+// QueryClientLatestHeight returns the revision height of the latest consensus state
+// of the IBC light client with the given ID.
+func (c *CosmosChain) QueryClientLatestHeight(ctx context.Context, clientID string) (uint64, error) {
+	clientState, err := c.QueryClientState(ctx, clientID)
+	if err != nil {
+		return 0, err
+	}
+	return clientState.GetLatestHeight().GetRevisionHeight(), nil
+}
+
+// QueryClientHeight returns the height of the latest consensus state of the IBC light client with the given ID,
+// with its revision, which QueryClientLatestHeight leaves out.
+func (c *CosmosChain) QueryClientHeight(ctx context.Context, clientID string) (ibc.Height, error) {
+	clientState, err := c.QueryClientState(ctx, clientID)
+	if err != nil {
+		return ibc.Height{}, err
+	}
+	return ibc.HeightFromIBC(clientState.GetLatestHeight()), nil
+}
+
+// QueryClientConsensusTimestamp returns the timestamp of the latest consensus state
+// of the IBC light client with the given ID.
+func (c *CosmosChain) QueryClientConsensusTimestamp(ctx context.Context, clientID string) (time.Time, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer conn.Close()
+
+	queryClient := clientTypes.NewQueryClient(conn)
+	res, err := queryClient.ConsensusState(ctx, &clientTypes.QueryConsensusStateRequest{ClientId: clientID, LatestHeight: true})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("query consensus state of client %s: %w", clientID, err)
+	}
+
+	var consensusState ibcexported.ConsensusState
+	if err := c.cfg.EncodingConfig.InterfaceRegistry.UnpackAny(res.ConsensusState, &consensusState); err != nil {
+		return time.Time{}, fmt.Errorf("unpack consensus state of client %s: %w", clientID, err)
+	}
+	return time.Unix(0, int64(consensusState.GetTimestamp())), nil
+}
+
+// QueryClientConsensusHeights returns the heights of all consensus states stored for the IBC light client
+// with the given ID, in ascending order, e.g. to assert that the relayer updated the client
+// at the height of a packet's proof before relaying it.
+func (c *CosmosChain) QueryClientConsensusHeights(ctx context.Context, clientID string) ([]clientTypes.Height, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	queryClient := clientTypes.NewQueryClient(conn)
+	var (
+		heights []clientTypes.Height
+		nextKey []byte
+	)
+	for {
+		res, err := queryClient.ConsensusStateHeights(ctx, &clientTypes.QueryConsensusStateHeightsRequest{
+			ClientId:   clientID,
+			Pagination: &query.PageRequest{Key: nextKey},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("query consensus state heights of client %s: %w", clientID, err)
+		}
+		heights = append(heights, res.ConsensusStateHeights...)
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			break
+		}
+		nextKey = res.Pagination.NextKey
+	}
+
+	// Consensus states are stored by the string of their height, so they are not in numeric order.
+	sort.Slice(heights, func(i, j int) bool { return heights[i].LT(heights[j]) })
+	return heights, nil
+}
+
+// QueryClientStatus returns the status of the IBC light client with the given ID, e.g. Active or Expired.
+func (c *CosmosChain) QueryClientStatus(ctx context.Context, clientID string) (string, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	queryClient := clientTypes.NewQueryClient(conn)
+	res, err := queryClient.ClientStatus(ctx, &clientTypes.QueryClientStatusRequest{ClientId: clientID})
+	if err != nil {
+		return "", fmt.Errorf("query status of client %s: %w", clientID, err)
+	}
+	return res.Status, nil
+}
+
 func (c *CosmosChain) getTransaction(txHash string) (*types.TxResponse, error) {
 	// Retry because sometimes the tx is not committed to state yet.
 	var txResp *types.TxResponse
@@ -484,7 +821,11 @@ func (c *CosmosChain) UpgradeVersion(ctx context.Context, cli *client.Client, ve
 }
 
 func (c *CosmosChain) pullImages(ctx context.Context, cli *client.Client) {
-	for _, image := range c.Config().Images {
+	images := append([]ibc.DockerImage(nil), c.Config().Images...)
+	for _, sc := range c.Config().SidecarConfigs {
+		images = append(images, sc.Image)
+	}
+	for _, image := range images {
 		rc, err := cli.ImagePull(
 			ctx,
 			image.Repository+":"+image.Version,
@@ -527,11 +868,7 @@ func (c *CosmosChain) NewChainNode(
 	}
 
 	v, err := cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
-		Labels: map[string]string{
-			dockerutil.CleanupLabel: testName,
-
-			dockerutil.NodeOwnerLabel: tn.Name(),
-		},
+		Labels: dockerutil.NodeResourceLabels(testName, tn.Name()),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("creating volume for chain node: %w", err)
@@ -578,6 +915,9 @@ func (c *CosmosChain) initializeChainNodes(
 				return err
 			}
 			val.Index = i
+			if i < len(chainCfg.ValidatorEnv) {
+				val.Env = append([]string(nil), chainCfg.ValidatorEnv[i]...)
+			}
 			newVals[i] = val
 			return nil
 		})
@@ -590,6 +930,9 @@ func (c *CosmosChain) initializeChainNodes(
 				return err
 			}
 			fn.Index = i
+			if i < len(chainCfg.FullNodeEnv) {
+				fn.Env = append([]string(nil), chainCfg.FullNodeEnv[i]...)
+			}
 			newFullNodes[i] = fn
 			return nil
 		})
@@ -640,13 +983,76 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 
 	genesisAmounts := []types.Coin{genesisAmount}
 
+	for _, peer := range chainCfg.AdditionalPeers {
+		if err := ValidatePeerAddress(peer); err != nil {
+			return fmt.Errorf("invalid additional peer: %w", err)
+		}
+	}
+
+	if _, err := ParseSignMode(chainCfg.SignMode); err != nil {
+		return err
+	}
+
+	if chainCfg.BaseFeeMultiplier < 0 {
+		return fmt.Errorf("base fee multiplier must not be negative, got %v", chainCfg.BaseFeeMultiplier)
+	}
+
+	if n := len(chainCfg.ValidatorEnv); n > c.numValidators {
+		return fmt.Errorf("got %d validator environments for %d validators", n, c.numValidators)
+	}
+	if n := len(chainCfg.FullNodeEnv); n > c.numFullNodes {
+		return fmt.Errorf("got %d full node environments for %d full nodes", n, c.numFullNodes)
+	}
+
+	for _, n := range c.Nodes() {
+		for _, kv := range n.env() {
+			if err := ValidateEnv(kv); err != nil {
+				return fmt.Errorf("invalid environment of node %s: %w", n.Name(), err)
+			}
+		}
+	}
+
+	if chainCfg.Rollup != nil && len(c.Validators) != 1 {
+		return fmt.Errorf("rollup must have a single validator as sequencer, got %d validators", len(c.Validators))
+	}
+
+	if chainCfg.PoA != nil {
+		switch {
+		case chainCfg.Rollup != nil:
+			return errors.New("proof-of-authority genesis and rollup are mutually exclusive")
+		case len(chainCfg.ValidatorSelfDelegations) > 0:
+			return errors.New("proof-of-authority validators have no self-delegations, set their powers instead")
+		}
+		if _, err := chainCfg.PoA.Powers(len(c.Validators)); err != nil {
+			return fmt.Errorf("invalid proof-of-authority genesis: %w", err)
+		}
+	}
+
+	if n := len(chainCfg.ValidatorSelfDelegations); n > 0 && n != len(c.Validators) {
+		return fmt.Errorf("got %d validator self-delegations for %d validators", n, len(c.Validators))
+	}
+	// validatorGenesis returns the genesis balance and self-delegation of the i-th validator.
+	validatorGenesis := func(i int) ([]types.Coin, types.Coin) {
+		if len(chainCfg.ValidatorSelfDelegations) == 0 {
+			return genesisAmounts, genesisSelfDelegation
+		}
+		selfDelegation := types.NewInt64Coin(chainCfg.Denom, chainCfg.ValidatorSelfDelegations[i])
+		// Leave the validator the same spendable balance as with the default self-delegation.
+		balance := selfDelegation.Add(genesisAmount.Sub(genesisSelfDelegation))
+		return []types.Coin{balance}, selfDelegation
+	}
+
+	c.probeBinaryVersion(ctx)
+	c.probeGenesisCommand(ctx)
+
 	configFileOverrides := chainCfg.ConfigFileOverrides
 
 	eg := new(errgroup.Group)
 	// Initialize config and sign gentx for each validator.
-	for _, v := range c.Validators {
+	for i, v := range c.Validators {
 		v := v
 		v.Validator = true
+		amounts, selfDelegation := validatorGenesis(i)
 		eg.Go(func() error {
 			if err := v.InitFullNodeFiles(ctx); err != nil {
 				return err
@@ -668,7 +1074,13 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 					return err
 				}
 			}
-			return v.InitValidatorGenTx(ctx, &chainCfg, genesisAmounts, genesisSelfDelegation)
+			switch {
+			case chainCfg.Rollup != nil:
+				return v.InitSequencerAccount(ctx, amounts)
+			case chainCfg.PoA != nil:
+				return v.initValidatorAccount(ctx, amounts)
+			}
+			return v.InitValidatorGenTx(ctx, &chainCfg, amounts, selfDelegation)
 		})
 	}
 
@@ -717,23 +1129,47 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 			return err
 		}
 
-		if err := validator0.AddGenesisAccount(ctx, bech32, genesisAmounts); err != nil {
+		amounts, _ := validatorGenesis(i)
+		if err := validator0.AddGenesisAccount(ctx, bech32, amounts); err != nil {
 			return err
 		}
 
+		if chainCfg.PoA != nil {
+			continue
+		}
 		if err := validatorN.copyGentx(ctx, validator0); err != nil {
 			return err
 		}
 	}
 
+	// An address may be given several amounts, e.g. one per denom, which must be added as a single account.
+	var genesisAddresses []string
+	genesisCoins := make(map[string]types.Coins)
 	for _, wallet := range additionalGenesisWallets {
-		if err := validator0.AddGenesisAccount(ctx, wallet.Address, []types.Coin{{Denom: wallet.Denom, Amount: types.NewInt(wallet.Amount)}}); err != nil {
+		if _, err := types.GetFromBech32(wallet.Address, chainCfg.Bech32Prefix); err != nil {
+			return fmt.Errorf("invalid genesis wallet address %q for prefix %s: %w", wallet.Address, chainCfg.Bech32Prefix, err)
+		}
+		coin := types.Coin{Denom: wallet.Denom, Amount: types.NewInt(wallet.Amount)}
+		if err := coin.Validate(); err != nil {
+			return fmt.Errorf("invalid genesis wallet amount for %s: %w", wallet.Address, err)
+		}
+		if _, ok := genesisCoins[wallet.Address]; !ok {
+			genesisAddresses = append(genesisAddresses, wallet.Address)
+		}
+		genesisCoins[wallet.Address] = genesisCoins[wallet.Address].Add(coin)
+	}
+	c.genesisWallets = make(map[string]bool, len(genesisAddresses))
+	for _, addr := range genesisAddresses {
+		c.genesisWallets[addr] = true
+		if err := validator0.AddGenesisAccount(ctx, addr, genesisCoins[addr]); err != nil {
 			return err
 		}
 	}
 
-	if err := validator0.CollectGentxs(ctx); err != nil {
-		return err
+	if chainCfg.Rollup == nil && chainCfg.PoA == nil {
+		if err := validator0.CollectGentxs(ctx); err != nil {
+			return err
+		}
 	}
 
 	genbz, err := validator0.genesisFileContent(ctx)
@@ -741,6 +1177,16 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 		return err
 	}
 
+	switch {
+	case chainCfg.Rollup != nil:
+		genbz, err = validator0.setSequencerGenesisValidator(ctx, genbz)
+	case chainCfg.PoA != nil:
+		genbz, err = c.setPoAGenesisValidators(ctx, genbz)
+	}
+	if err != nil {
+		return err
+	}
+
 	genbz = bytes.ReplaceAll(genbz, []byte(`"stake"`), []byte(fmt.Sprintf(`"%s"`, chainCfg.Denom)))
 
 	if c.cfg.ModifyGenesis != nil {
@@ -749,6 +1195,12 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 			return err
 		}
 	}
+	for _, fn := range c.genesisModifiers {
+		genbz, err = fn(ctx, chainCfg, genbz)
+		if err != nil {
+			return err
+		}
+	}
 
 	// Provide EXPORT_GENESIS_FILE_PATH and EXPORT_GENESIS_CHAIN to help debug genesis file
 	exportGenesis := os.Getenv("EXPORT_GENESIS_FILE_PATH")
@@ -773,6 +1225,10 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 		return err
 	}
 
+	if err := c.startSidecars(ctx, true); err != nil {
+		return err
+	}
+
 	eg, egCtx := errgroup.WithContext(ctx)
 	for _, n := range chainNodes {
 		n := n
@@ -784,12 +1240,12 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 		return err
 	}
 
-	peers := chainNodes.PeerString(ctx)
+	peers := c.peerString(ctx, chainNodes)
 
 	eg, egCtx = errgroup.WithContext(ctx)
 	for _, n := range chainNodes {
 		n := n
-		c.log.Info("Starting container", zap.String("container", n.Name()))
+		n.logger().Info("Starting container")
 		eg.Go(func() error {
 			if err := n.SetPeers(egCtx, peers); err != nil {
 				return err
@@ -801,15 +1257,57 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 		return err
 	}
 
+	if c.cometMock != nil {
+		if err := c.startCometMock(ctx); err != nil {
+			return err
+		}
+	}
+
 	// Wait for 5 blocks before considering the chains "started"
-	return testutil.WaitForBlocks(ctx, 5, c.getFullNode())
+	if err := testutil.WaitForBlocks(ctx, 5, c); err != nil {
+		return err
+	}
+	if c.cfg.ClockOffset != nil {
+		if err := c.verifyClockOffset(ctx, *c.cfg.ClockOffset); err != nil {
+			return err
+		}
+	}
+	return c.startSidecars(ctx, false)
 }
 
 // Height implements ibc.Chain
 func (c *CosmosChain) Height(ctx context.Context) (uint64, error) {
+	if err := c.throttleQuery(ctx); err != nil {
+		return 0, err
+	}
 	return c.getFullNode().Height(ctx)
 }
 
+// IBCHeight returns the current height of the chain as IBC knows it, in the revision of its chain ID,
+// e.g. to set absolute timeout heights of packets sent to the chain, see ibc.IBCTimeout.
+func (c *CosmosChain) IBCHeight(ctx context.Context) (ibc.Height, error) {
+	h, err := c.Height(ctx)
+	if err != nil {
+		return ibc.Height{}, err
+	}
+	return ibc.HeightForChainID(c.cfg.ChainID, h), nil
+}
+
+// BlockTxStats implements testutil.ThroughputChain, returning the number of transactions in the block at height
+// and the total gas they used.
+func (c *CosmosChain) BlockTxStats(ctx context.Context, height uint64) (int, int64, error) {
+	h := int64(height)
+	res, err := c.getFullNode().Client.BlockResults(ctx, &h)
+	if err != nil {
+		return 0, 0, fmt.Errorf("block results at height %d: %w", height, err)
+	}
+	var gasUsed int64
+	for _, tx := range res.TxsResults {
+		gasUsed += tx.GasUsed
+	}
+	return len(res.TxsResults), gasUsed, nil
+}
+
 // Acknowledgements implements ibc.Chain, returning all acknowledgments in block at height
 func (c *CosmosChain) Acknowledgements(ctx context.Context, height uint64) ([]ibc.PacketAcknowledgement, error) {
 	var acks []*chanTypes.MsgAcknowledgement
@@ -875,6 +1373,25 @@ func (c *CosmosChain) Timeouts(ctx context.Context, height uint64) ([]ibc.Packet
 	return ibcTimeouts, nil
 }
 
+// TimeoutPacketEvents returns the packets of all timeout_packet events in the block at height,
+// i.e. the packets sent by the chain that were timed out, without their data.
+func (c *CosmosChain) TimeoutPacketEvents(ctx context.Context, height uint64) ([]ibc.Packet, error) {
+	h := int64(height)
+	res, err := c.getFullNode().Client.BlockResults(ctx, &h)
+	if err != nil {
+		return nil, fmt.Errorf("block results at height %d: %w", height, err)
+	}
+	var packets []ibc.Packet
+	for _, tx := range res.TxsResults {
+		found, err := packetsFromEvents(tx.Events, "timeout_packet")
+		if err != nil {
+			return nil, fmt.Errorf("find timeout packets at height %d: %w", height, err)
+		}
+		packets = append(packets, found...)
+	}
+	return packets, nil
+}
+
 // FindTxs implements blockdb.BlockSaver.
 func (c *CosmosChain) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx, error) {
 	fn := c.getFullNode()
@@ -917,13 +1434,23 @@ func (c *CosmosChain) StartAllNodes(ctx context.Context) error {
 	return eg.Wait()
 }
 
+// RestartFullNode restarts the node used for queries and by relayers, while the other nodes keep running.
+func (c *CosmosChain) RestartFullNode(ctx context.Context) error {
+	return c.getFullNode().RestartContainer(ctx)
+}
+
 func (c *CosmosChain) VoteOnProposalAllValidators(ctx context.Context, proposalID string, vote string) error {
 	var eg errgroup.Group
 	for _, n := range c.Nodes() {
 		if n.Validator {
 			n := n
 			eg.Go(func() error {
-				return n.VoteOnProposal(ctx, valKey, proposalID, vote)
+				b, err := n.validatorBroadcaster(ctx)
+				if err != nil {
+					return err
+				}
+				_, err = b.ExecInternalTx(ctx, "gov", "vote", proposalID, vote)
+				return err
 			})
 		}
 	}
@@ -0,0 +1,115 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+)
+
+// EpochInfo is the state of an epoch of a chain with an epochs module, such as osmosis.
+type EpochInfo struct {
+	Identifier string
+	Duration   time.Duration
+
+	// The number of the current epoch, starting at 1, or 0 before the first epoch started.
+	CurrentEpoch int64
+	// Block time and height at which the current epoch started.
+	CurrentEpochStartTime   time.Time
+	CurrentEpochStartHeight int64
+}
+
+// NextEpochStartTime returns the block time after which the next epoch starts.
+func (e EpochInfo) NextEpochStartTime() time.Time {
+	return e.CurrentEpochStartTime.Add(e.Duration)
+}
+
+// epochInfoJSON is the CLI representation of an epoch info.
+type epochInfoJSON struct {
+	Identifier              string    `json:"identifier"`
+	Duration                string    `json:"duration"`
+	CurrentEpoch            string    `json:"current_epoch"`
+	CurrentEpochStartTime   time.Time `json:"current_epoch_start_time"`
+	CurrentEpochStartHeight string    `json:"current_epoch_start_height"`
+}
+
+// QueryEpochInfo returns the state of the epoch with the given identifier, e.g. day or week.
+func (c *CosmosChain) QueryEpochInfo(ctx context.Context, identifier string) (EpochInfo, error) {
+	stdout, _, err := c.getFullNode().ExecQuery(ctx, "epochs", "epoch-infos")
+	if err != nil {
+		return EpochInfo{}, fmt.Errorf("query epoch infos: %w", err)
+	}
+
+	var res struct {
+		Epochs []epochInfoJSON `json:"epochs"`
+	}
+	if err := json.Unmarshal(stdout, &res); err != nil {
+		return EpochInfo{}, fmt.Errorf("failed to unmarshal epoch infos: %w", err)
+	}
+
+	for _, e := range res.Epochs {
+		if e.Identifier != identifier {
+			continue
+		}
+		d, err := time.ParseDuration(e.Duration)
+		if err != nil {
+			return EpochInfo{}, fmt.Errorf("invalid duration of epoch %s: %w", identifier, err)
+		}
+		current, err := strconv.ParseInt(e.CurrentEpoch, 10, 64)
+		if err != nil {
+			return EpochInfo{}, fmt.Errorf("invalid current epoch of epoch %s: %w", identifier, err)
+		}
+		startHeight, err := strconv.ParseInt(e.CurrentEpochStartHeight, 10, 64)
+		if err != nil {
+			return EpochInfo{}, fmt.Errorf("invalid current epoch start height of epoch %s: %w", identifier, err)
+		}
+		return EpochInfo{
+			Identifier:              e.Identifier,
+			Duration:                d,
+			CurrentEpoch:            current,
+			CurrentEpochStartTime:   e.CurrentEpochStartTime,
+			CurrentEpochStartHeight: startHeight,
+		}, nil
+	}
+	return EpochInfo{}, fmt.Errorf("epoch %s not found", identifier)
+}
+
+// WaitForEpoch blocks until the epoch with the given identifier and number began, and returns its info.
+// It returns immediately if a later epoch already began,
+// and returns an error if the epoch did not begin by the block at maxHeight.
+// Epochs start in the first block whose time is past the epoch's next start time,
+// so WaitForEpoch follows the chain's blocks rather than the wall clock.
+func (c *CosmosChain) WaitForEpoch(ctx context.Context, identifier string, number int64, maxHeight uint64) (EpochInfo, error) {
+	for {
+		info, err := c.QueryEpochInfo(ctx, identifier)
+		if err != nil {
+			return EpochInfo{}, err
+		}
+		if info.CurrentEpoch >= number {
+			return info, nil
+		}
+		h, err := c.Height(ctx)
+		if err != nil {
+			return EpochInfo{}, err
+		}
+		if h >= maxHeight {
+			return EpochInfo{}, fmt.Errorf("epoch %d of %s did not begin by height %d, current epoch is %d", number, identifier, maxHeight, info.CurrentEpoch)
+		}
+		if err := testutil.WaitForBlocks(ctx, 1, c); err != nil {
+			return EpochInfo{}, err
+		}
+	}
+}
+
+// WaitForEpochs blocks until n epochs with the given identifier started, and returns the info of the latest one.
+// It returns an error if they did not start by the block at maxHeight. See WaitForEpoch.
+func WaitForEpochs(ctx context.Context, chain *CosmosChain, identifier string, n int, maxHeight uint64) (EpochInfo, error) {
+	start, err := chain.QueryEpochInfo(ctx, identifier)
+	if err != nil {
+		return EpochInfo{}, err
+	}
+	return chain.WaitForEpoch(ctx, identifier, start.CurrentEpoch+int64(n), maxHeight)
+}
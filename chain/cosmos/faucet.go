@@ -0,0 +1,52 @@
+package cosmos
+
+import (
+	"fmt"
+
+	"github.com/cosmos/go-bip39"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// FaucetAccountKeyName is the key name of the faucet account from which test users are funded,
+// matching interchaintest.FaucetAccountKeyName.
+const FaucetAccountKeyName = "faucet"
+
+// FaucetWallet returns the wallet of the faucet account, funded in genesis as configured by
+// ibc.ChainConfig.FaucetGenesisBalance, or nil if the chain has no faucet.
+// Its key is in the keyring of the node signing the chain's transactions, and its mnemonic is set
+// for flows needing the key elsewhere.
+//
+// SendFunds and SendFundsInternal from the faucet key go through a SequencedBroadcaster,
+// so that concurrent funding does not cause account sequence mismatches.
+// Custom transactions from the faucet should use FaucetBroadcaster for the same reason.
+func (c *CosmosChain) FaucetWallet() ibc.Wallet {
+	c.faucetMu.Lock()
+	defer c.faucetMu.Unlock()
+	return c.faucet
+}
+
+// FaucetBroadcaster returns the broadcaster of transactions signed by the faucet,
+// or nil if the chain has no faucet.
+func (c *CosmosChain) FaucetBroadcaster() *SequencedBroadcaster {
+	c.faucetMu.Lock()
+	defer c.faucetMu.Unlock()
+	return c.faucetBroadcaster
+}
+
+// setFaucet records the faucet wallet, whose key is in the keyring of node.
+func (c *CosmosChain) setFaucet(node *ChainNode, wallet ibc.Wallet) {
+	c.faucetMu.Lock()
+	defer c.faucetMu.Unlock()
+	c.faucet = wallet
+	c.faucetBroadcaster = newSequencedBroadcaster(node, wallet)
+}
+
+// newFaucetMnemonic returns a new mnemonic for the faucet key,
+// so that the faucet wallet can be restored outside of the chain's keyring.
+func newFaucetMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate faucet entropy: %w", err)
+	}
+	return bip39.NewMnemonic(entropy)
+}
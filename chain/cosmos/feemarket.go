@@ -0,0 +1,287 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/gogo/protobuf/proto"
+	"go.uber.org/zap"
+)
+
+// ErrNoFeemarket is returned by QueryBaseFee and QueryGasPrice for chains without a fee market module.
+var ErrNoFeemarket = errors.New("chain has no feemarket module")
+
+// Paths of the gRPC methods of the fee market queries, which QueryGasPrice tries in order.
+const (
+	// The base fee query of the ethermint feemarket module.
+	baseFeeQueryPath = "/ethermint.feemarket.v1.Query/BaseFee"
+	// The gas price query of the Skip feemarket module.
+	skipGasPriceQueryPath = "/feemarket.feemarket.v1.Query/GasPrice"
+	// The EIP-1559 base fee query of the osmosis txfees module.
+	osmosisBaseFeeQueryPath = "/osmosis.txfees.v1beta1.Query/GetEipBaseFee"
+)
+
+// defaultBaseFeeMultiplier is multiplied with the base fee to get the minimum gas price of transactions,
+// unless the chain config sets BaseFeeMultiplier,
+// covering an increase of the base fee by up to 12.5% per block until the transaction is included.
+var defaultBaseFeeMultiplier = sdk.NewDecWithPrec(15, 1)
+
+// The following types mirror the query types of the fee market modules,
+// so that this module does not depend on them.
+
+// queryBaseFeeResponse mirrors ethermint.feemarket.v1.QueryBaseFeeResponse.
+type queryBaseFeeResponse struct {
+	BaseFee string `protobuf:"bytes,1,opt,name=base_fee,json=baseFee,proto3"`
+}
+
+func (m *queryBaseFeeResponse) Reset()         { *m = queryBaseFeeResponse{} }
+func (m *queryBaseFeeResponse) String() string { return proto.CompactTextString(m) }
+func (*queryBaseFeeResponse) ProtoMessage()    {}
+
+// gasPriceRequest mirrors feemarket.feemarket.v1.GasPriceRequest.
+type gasPriceRequest struct {
+	Denom string `protobuf:"bytes,1,opt,name=denom,proto3"`
+}
+
+func (m *gasPriceRequest) Reset()         { *m = gasPriceRequest{} }
+func (m *gasPriceRequest) String() string { return proto.CompactTextString(m) }
+func (*gasPriceRequest) ProtoMessage()    {}
+
+// gasPriceResponse mirrors feemarket.feemarket.v1.GasPriceResponse.
+type gasPriceResponse struct {
+	Price *sdk.DecCoin `protobuf:"bytes,1,opt,name=price,proto3"`
+}
+
+func (m *gasPriceResponse) Reset()         { *m = gasPriceResponse{} }
+func (m *gasPriceResponse) String() string { return proto.CompactTextString(m) }
+func (*gasPriceResponse) ProtoMessage()    {}
+
+// queryEipBaseFeeResponse mirrors osmosis.txfees.v1beta1.QueryEipBaseFeeResponse.
+// The base fee is a Dec in its wire format, the integer of the decimal at 18-digit precision.
+type queryEipBaseFeeResponse struct {
+	BaseFee string `protobuf:"bytes,1,opt,name=base_fee,json=baseFee,proto3"`
+}
+
+func (m *queryEipBaseFeeResponse) Reset()         { *m = queryEipBaseFeeResponse{} }
+func (m *queryEipBaseFeeResponse) String() string { return proto.CompactTextString(m) }
+func (*queryEipBaseFeeResponse) ProtoMessage()    {}
+
+// QueryBaseFee returns the current EIP-1559 base fee of a chain with the ethermint feemarket module,
+// in the chain's denom per gas, or zero if the base fee is disabled.
+// For chains without the feemarket module, the error wraps ErrNoFeemarket.
+// See QueryGasPrice for the minimum gas price of chains with other fee market modules.
+func (c *CosmosChain) QueryBaseFee(ctx context.Context) (sdk.Int, error) {
+	return c.getFullNode().QueryBaseFee(ctx)
+}
+
+// QueryBaseFee returns the current base fee of the chain as reported by the node, see CosmosChain.QueryBaseFee.
+func (tn *ChainNode) QueryBaseFee(ctx context.Context) (sdk.Int, error) {
+	value, err := tn.queryFeemarket(ctx, baseFeeQueryPath, nil)
+	if err != nil {
+		return sdk.Int{}, fmt.Errorf("query base fee: %w", err)
+	}
+
+	var resp queryBaseFeeResponse
+	if err := proto.Unmarshal(value, &resp); err != nil {
+		return sdk.Int{}, fmt.Errorf("decode base fee: %w", err)
+	}
+	if resp.BaseFee == "" {
+		return sdk.ZeroInt(), nil
+	}
+	baseFee, ok := sdk.NewIntFromString(resp.BaseFee)
+	if !ok {
+		return sdk.Int{}, fmt.Errorf("invalid base fee %q", resp.BaseFee)
+	}
+	return baseFee, nil
+}
+
+// QueryGasPrice returns the current dynamic gas price of a chain with a fee market module, in the chain's denom per gas:
+// the EIP-1559 base fee of the ethermint feemarket or the osmosis txfees module, or the gas price of the Skip feemarket module.
+// It is zero if the base fee is disabled. For chains without any of these modules, the error wraps ErrNoFeemarket.
+//
+// Transactions of CosmosChain and its nodes pay at least this gas price times ChainConfig.BaseFeeMultiplier on such chains,
+// regardless of the configured gas prices.
+func (c *CosmosChain) QueryGasPrice(ctx context.Context) (sdk.Dec, error) {
+	return c.getFullNode().QueryGasPrice(ctx)
+}
+
+// QueryGasPrice returns the current dynamic gas price of the chain as reported by the node, see CosmosChain.QueryGasPrice.
+func (tn *ChainNode) QueryGasPrice(ctx context.Context) (sdk.Dec, error) {
+	c, _ := tn.Chain.(*CosmosChain)
+	paths := []string{baseFeeQueryPath, skipGasPriceQueryPath, osmosisBaseFeeQueryPath}
+	if c != nil {
+		if p := c.feemarketPath(); p != "" {
+			paths = []string{p}
+		}
+	}
+
+	for _, p := range paths {
+		price, err := tn.queryGasPrice(ctx, p)
+		if errors.Is(err, ErrNoFeemarket) {
+			continue
+		}
+		if err != nil {
+			return sdk.Dec{}, fmt.Errorf("query gas price: %w", err)
+		}
+		if c != nil {
+			c.setFeemarketPath(p)
+		}
+		return price, nil
+	}
+	return sdk.Dec{}, fmt.Errorf("query gas price: %w", ErrNoFeemarket)
+}
+
+// queryGasPrice returns the gas price of the fee market query with the gRPC method path.
+func (tn *ChainNode) queryGasPrice(ctx context.Context, path string) (sdk.Dec, error) {
+	switch path {
+	case baseFeeQueryPath:
+		baseFee, err := tn.QueryBaseFee(ctx)
+		if err != nil {
+			return sdk.Dec{}, err
+		}
+		return sdk.NewDecFromInt(baseFee), nil
+
+	case skipGasPriceQueryPath:
+		denom := tn.Chain.Config().Denom
+		req, err := proto.Marshal(&gasPriceRequest{Denom: denom})
+		if err != nil {
+			return sdk.Dec{}, err
+		}
+		value, err := tn.queryFeemarket(ctx, path, req)
+		if err != nil {
+			return sdk.Dec{}, err
+		}
+		var resp gasPriceResponse
+		if err := proto.Unmarshal(value, &resp); err != nil {
+			return sdk.Dec{}, fmt.Errorf("decode gas price: %w", err)
+		}
+		if resp.Price == nil || resp.Price.Amount.IsNil() {
+			return sdk.ZeroDec(), nil
+		}
+		if resp.Price.Denom != denom {
+			return sdk.Dec{}, fmt.Errorf("gas price in denom %s, expected %s", resp.Price.Denom, denom)
+		}
+		return resp.Price.Amount, nil
+
+	case osmosisBaseFeeQueryPath:
+		value, err := tn.queryFeemarket(ctx, path, nil)
+		if err != nil {
+			return sdk.Dec{}, err
+		}
+		var resp queryEipBaseFeeResponse
+		if err := proto.Unmarshal(value, &resp); err != nil {
+			return sdk.Dec{}, fmt.Errorf("decode base fee: %w", err)
+		}
+		if resp.BaseFee == "" {
+			return sdk.ZeroDec(), nil
+		}
+		var baseFee sdk.Dec
+		if err := baseFee.Unmarshal([]byte(resp.BaseFee)); err != nil {
+			return sdk.Dec{}, fmt.Errorf("invalid base fee %q: %w", resp.BaseFee, err)
+		}
+		return baseFee, nil
+	}
+	return sdk.Dec{}, fmt.Errorf("unknown fee market query %s", path)
+}
+
+// queryFeemarket runs the ABCI query of a fee market module with the gRPC method path and the encoded request req,
+// returning the encoded response. The error wraps ErrNoFeemarket if the chain does not serve the query.
+func (tn *ChainNode) queryFeemarket(ctx context.Context, path string, req []byte) ([]byte, error) {
+	res, err := tn.Client.ABCIQuery(ctx, path, req)
+	if err != nil {
+		return nil, err
+	}
+	if r := res.Response; r.Code != 0 {
+		if r.Codespace == sdkerrors.ErrUnknownRequest.Codespace() && r.Code == sdkerrors.ErrUnknownRequest.ABCICode() {
+			return nil, fmt.Errorf("%w: %s", ErrNoFeemarket, r.Log)
+		}
+		return nil, fmt.Errorf("code %d: %s", r.Code, r.Log)
+	}
+	return res.Response.Value, nil
+}
+
+// txGasPrices returns gasPrices, raised to the current gas price of the fee market with margin if the chain has one,
+// so that transactions are not rejected once the base fee rises above the configured gas prices.
+func (tn *ChainNode) txGasPrices(ctx context.Context, gasPrices string) string {
+	c, ok := tn.Chain.(*CosmosChain)
+	if !ok || !c.mayHaveFeemarket() {
+		return gasPrices
+	}
+
+	price, err := tn.QueryGasPrice(ctx)
+	if errors.Is(err, ErrNoFeemarket) {
+		c.setNoFeemarket()
+		return gasPrices
+	}
+	if err != nil {
+		tn.logger().Info("Failed to query fee market gas price, using configured gas prices", zap.Error(err))
+		return gasPrices
+	}
+	return raiseGasPrices(gasPrices, c.cfg.Denom, price.Mul(c.baseFeeMultiplier()))
+}
+
+// baseFeeMultiplier returns the multiplier of the fee market gas price of the chain config, or the default one.
+func (c *CosmosChain) baseFeeMultiplier() sdk.Dec {
+	if c.cfg.BaseFeeMultiplier <= 0 {
+		return defaultBaseFeeMultiplier
+	}
+	m, err := sdk.NewDecFromStr(strconv.FormatFloat(c.cfg.BaseFeeMultiplier, 'f', -1, 64))
+	if err != nil {
+		return defaultBaseFeeMultiplier
+	}
+	return m
+}
+
+// raiseGasPrices returns gasPrices with the price of denom raised to minPrice, if it is lower.
+// Unparsable gas prices are returned unchanged, to be rejected by the node with a descriptive error.
+func raiseGasPrices(gasPrices, denom string, minPrice sdk.Dec) string {
+	if !minPrice.IsPositive() {
+		return gasPrices
+	}
+	prices, err := sdk.ParseDecCoins(gasPrices)
+	if err != nil {
+		return gasPrices
+	}
+
+	if prices.AmountOf(denom).GTE(minPrice) {
+		return gasPrices
+	}
+
+	raised := sdk.NewDecCoins(sdk.NewDecCoinFromDec(denom, minPrice))
+	for _, p := range prices {
+		if p.Denom != denom {
+			raised = raised.Add(p)
+		}
+	}
+	return raised.String()
+}
+
+// feemarketPath returns the path of the fee market query found to be served by the chain, if any.
+func (c *CosmosChain) feemarketPath() string {
+	c.feemarketMu.Lock()
+	defer c.feemarketMu.Unlock()
+	return c.feemarketQueryPath
+}
+
+func (c *CosmosChain) setFeemarketPath(path string) {
+	c.feemarketMu.Lock()
+	defer c.feemarketMu.Unlock()
+	c.feemarketQueryPath = path
+}
+
+// mayHaveFeemarket reports whether the chain was not found to lack a fee market module.
+func (c *CosmosChain) mayHaveFeemarket() bool {
+	c.feemarketMu.Lock()
+	defer c.feemarketMu.Unlock()
+	return !c.noFeemarket
+}
+
+func (c *CosmosChain) setNoFeemarket() {
+	c.feemarketMu.Lock()
+	defer c.feemarketMu.Unlock()
+	c.noFeemarket = true
+}
@@ -0,0 +1,127 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/gogo/protobuf/proto"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/bytes"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	"go.uber.org/zap/zaptest"
+)
+
+// feemarketClient is an rpcclient.Client serving the ABCI queries with the gRPC method paths of responses,
+// and failing the other queries as unknown requests, as the SDK does for unregistered query services.
+type feemarketClient struct {
+	rpcclient.Client
+	responses map[string][]byte
+	requests  map[string][]byte
+}
+
+func (c *feemarketClient) ABCIQuery(_ context.Context, path string, data bytes.HexBytes) (*coretypes.ResultABCIQuery, error) {
+	if c.requests == nil {
+		c.requests = make(map[string][]byte)
+	}
+	c.requests[path] = data
+
+	value, ok := c.responses[path]
+	if !ok {
+		return &coretypes.ResultABCIQuery{Response: abcitypes.ResponseQuery{
+			Codespace: sdkerrors.ErrUnknownRequest.Codespace(),
+			Code:      sdkerrors.ErrUnknownRequest.ABCICode(),
+			Log:       "unknown query path",
+		}}, nil
+	}
+	return &coretypes.ResultABCIQuery{Response: abcitypes.ResponseQuery{Value: value}}, nil
+}
+
+// bytesField returns the protobuf encoding of a message with the length-delimited field 1 set to value.
+func bytesField(t *testing.T, value []byte) []byte {
+	buf := proto.NewBuffer(nil)
+	require.NoError(t, buf.EncodeVarint(1<<3|proto.WireBytes))
+	require.NoError(t, buf.EncodeRawBytes(value))
+	return buf.Bytes()
+}
+
+func feemarketNode(t *testing.T, client *feemarketClient) *cosmos.ChainNode {
+	c := cosmos.NewCosmosChain(t.Name(), ibc.ChainConfig{ChainID: "test-1", Denom: "uosmo"}, 1, 0, zaptest.NewLogger(t))
+	return &cosmos.ChainNode{Chain: c, Client: client}
+}
+
+func TestQueryGasPrice_Skip(t *testing.T) {
+	ctx := context.Background()
+
+	price, err := (&sdk.DecCoin{Denom: "uosmo", Amount: sdk.MustNewDecFromStr("0.0025")}).Marshal()
+	require.NoError(t, err)
+	client := &feemarketClient{responses: map[string][]byte{
+		"/feemarket.feemarket.v1.Query/GasPrice": bytesField(t, price),
+	}}
+
+	got, err := feemarketNode(t, client).QueryGasPrice(ctx)
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("0.0025"), got)
+
+	// The request asks for the gas price in the chain's denom.
+	require.Equal(t, bytesField(t, []byte("uosmo")), client.requests["/feemarket.feemarket.v1.Query/GasPrice"])
+}
+
+func TestQueryGasPrice_SkipOtherDenom(t *testing.T) {
+	price, err := (&sdk.DecCoin{Denom: "uatom", Amount: sdk.MustNewDecFromStr("0.0025")}).Marshal()
+	require.NoError(t, err)
+	client := &feemarketClient{responses: map[string][]byte{
+		"/feemarket.feemarket.v1.Query/GasPrice": bytesField(t, price),
+	}}
+
+	_, err = feemarketNode(t, client).QueryGasPrice(context.Background())
+	require.ErrorContains(t, err, "gas price in denom uatom, expected uosmo")
+}
+
+func TestQueryGasPrice_Osmosis(t *testing.T) {
+	// The base fee is a Dec in its wire format, the integer of 0.0025 at 18-digit precision.
+	client := &feemarketClient{responses: map[string][]byte{
+		"/osmosis.txfees.v1beta1.Query/GetEipBaseFee": bytesField(t, []byte("2500000000000000")),
+	}}
+
+	got, err := feemarketNode(t, client).QueryGasPrice(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("0.0025"), got)
+}
+
+func TestQueryGasPrice_OsmosisInvalid(t *testing.T) {
+	client := &feemarketClient{responses: map[string][]byte{
+		"/osmosis.txfees.v1beta1.Query/GetEipBaseFee": bytesField(t, []byte("0.0025")),
+	}}
+
+	_, err := feemarketNode(t, client).QueryGasPrice(context.Background())
+	require.ErrorContains(t, err, `invalid base fee "0.0025"`)
+}
+
+func TestQueryGasPrice_Disabled(t *testing.T) {
+	// An empty response leaves the price unset, which is the case of a disabled base fee.
+	client := &feemarketClient{responses: map[string][]byte{
+		"/feemarket.feemarket.v1.Query/GasPrice": nil,
+	}}
+
+	got, err := feemarketNode(t, client).QueryGasPrice(context.Background())
+	require.NoError(t, err)
+	require.True(t, got.IsZero())
+}
+
+func TestQueryGasPrice_NoFeemarket(t *testing.T) {
+	_, err := feemarketNode(t, &feemarketClient{}).QueryGasPrice(context.Background())
+	require.ErrorIs(t, err, cosmos.ErrNoFeemarket)
+}
+
+func TestStart_NegativeBaseFeeMultiplier(t *testing.T) {
+	c := cosmos.NewCosmosChain(t.Name(), ibc.ChainConfig{ChainID: "test-1", Denom: "uosmo", BaseFeeMultiplier: -1}, 1, 0, zaptest.NewLogger(t))
+
+	err := c.Start(t.Name(), context.Background())
+	require.ErrorContains(t, err, "base fee multiplier must not be negative")
+}
@@ -0,0 +1,305 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/icza/dyno"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// ModifyGenesisRemoveModules returns a ChainConfig.ModifyGenesis function that deletes
+// the genesis state of the given modules, e.g. "transfer" or "interchainaccounts".
+//
+// A module without genesis state is skipped during InitGenesis, so an IBC application module
+// never binds its port and channel handshakes for it fail on this chain.
+// This is useful for negative tests against a counterparty lacking a module.
+func ModifyGenesisRemoveModules(modules ...string) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(_ ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		g := make(map[string]any)
+		if err := json.Unmarshal(genbz, &g); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+		}
+		appState, ok := g["app_state"].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("genesis file has no app_state")
+		}
+		for _, m := range modules {
+			if _, ok := appState[m]; !ok {
+				return nil, fmt.Errorf("module %s not found in genesis app_state", m)
+			}
+			delete(appState, m)
+		}
+		out, err := json.Marshal(g)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+		}
+		return out, nil
+	}
+}
+
+// ModifyGenesisDisableTransfer returns a ChainConfig.ModifyGenesis function that disables
+// sending and receiving ICS-20 fungible token transfers through the transfer module params.
+// Unlike removing the module, channels can still be opened but transfers fail.
+func ModifyGenesisDisableTransfer() func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(_ ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		g := make(map[string]any)
+		if err := json.Unmarshal(genbz, &g); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+		}
+		if err := dyno.Set(g, false, "app_state", "transfer", "params", "send_enabled"); err != nil {
+			return nil, fmt.Errorf("failed to set transfer send_enabled in genesis json: %w", err)
+		}
+		if err := dyno.Set(g, false, "app_state", "transfer", "params", "receive_enabled"); err != nil {
+			return nil, fmt.Errorf("failed to set transfer receive_enabled in genesis json: %w", err)
+		}
+		out, err := json.Marshal(g)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+		}
+		return out, nil
+	}
+}
+
+// ModifyGenesisEpochDuration returns a ChainConfig.ModifyGenesis function that sets the duration
+// of the epoch with the given identifier, e.g. to shorten the day epoch of osmosis from 24 hours to seconds.
+// See QueryEpochInfo and WaitForEpochs.
+func ModifyGenesisEpochDuration(identifier string, d time.Duration) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(_ ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		g := make(map[string]any)
+		if err := json.Unmarshal(genbz, &g); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+		}
+		epochs, err := dyno.GetSlice(g, "app_state", "epochs", "epochs")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get epochs from genesis json: %w", err)
+		}
+		found := false
+		for _, e := range epochs {
+			if id, _ := dyno.GetString(e, "identifier"); id != identifier {
+				continue
+			}
+			// Durations are encoded in seconds in JSON, e.g. 30s.
+			if err := dyno.Set(e, strconv.FormatFloat(d.Seconds(), 'f', -1, 64)+"s", "duration"); err != nil {
+				return nil, fmt.Errorf("failed to set epoch %s duration in genesis json: %w", identifier, err)
+			}
+			found = true
+		}
+		if !found {
+			return nil, fmt.Errorf("epoch %s not found in genesis json", identifier)
+		}
+		out, err := json.Marshal(g)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+		}
+		return out, nil
+	}
+}
+
+// ModifyGenesisVotingPeriod returns a ChainConfig.ModifyGenesis function that sets the voting period of gov proposals,
+// e.g. to pass proposals within a test instead of after the default of two days.
+// Both the gov params of SDK v0.47 and later and the voting params of earlier versions are supported.
+func ModifyGenesisVotingPeriod(d time.Duration) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(_ ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		g := make(map[string]any)
+		if err := json.Unmarshal(genbz, &g); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+		}
+		// Durations are encoded in seconds in JSON, e.g. 30s.
+		period := strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+		found := false
+		for _, params := range []string{"params", "voting_params"} {
+			if _, err := dyno.Get(g, "app_state", "gov", params, "voting_period"); err != nil {
+				continue
+			}
+			if err := dyno.Set(g, period, "app_state", "gov", params, "voting_period"); err != nil {
+				return nil, fmt.Errorf("failed to set gov voting period in genesis json: %w", err)
+			}
+			found = true
+		}
+		if !found {
+			return nil, fmt.Errorf("gov voting period not found in genesis json")
+		}
+		out, err := json.Marshal(g)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+		}
+		return out, nil
+	}
+}
+
+// ModifyGenesisOracleVotePeriod returns a ChainConfig.ModifyGenesis function that sets the vote period
+// and the slash window, in blocks, of an x/oracle-style module such as that of umee,
+// e.g. to get exchange rates and miss counters within a few blocks. See AddPriceFeeders.
+func ModifyGenesisOracleVotePeriod(votePeriod, slashWindow uint64) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(_ ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		if votePeriod == 0 || slashWindow < votePeriod {
+			return nil, fmt.Errorf("oracle slash window %d must be at least the vote period %d, which must be positive", slashWindow, votePeriod)
+		}
+		g := make(map[string]any)
+		if err := json.Unmarshal(genbz, &g); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+		}
+		if _, err := dyno.Get(g, "app_state", "oracle", "params"); err != nil {
+			return nil, fmt.Errorf("oracle params not found in genesis json: %w", err)
+		}
+		// Block counts are uint64, encoded as strings in JSON.
+		if err := dyno.Set(g, strconv.FormatUint(votePeriod, 10), "app_state", "oracle", "params", "vote_period"); err != nil {
+			return nil, fmt.Errorf("failed to set oracle vote period in genesis json: %w", err)
+		}
+		if err := dyno.Set(g, strconv.FormatUint(slashWindow, 10), "app_state", "oracle", "params", "slash_window"); err != nil {
+			return nil, fmt.Errorf("failed to set oracle slash window in genesis json: %w", err)
+		}
+		out, err := json.Marshal(g)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+		}
+		return out, nil
+	}
+}
+
+// OracleDenom is a denom of the accept list of an x/oracle-style module, see ModifyGenesisOracleAcceptList.
+type OracleDenom struct {
+	BaseDenom   string `json:"base_denom"`
+	SymbolDenom string `json:"symbol_denom"` // e.g. ATOM
+	Exponent    uint32 `json:"exponent"`
+}
+
+// ModifyGenesisOracleAcceptList returns a ChainConfig.ModifyGenesis function that sets the accept list
+// of an x/oracle-style module such as that of umee to denoms, the denoms whose exchange rates validators must vote.
+// A validator whose price feeder does not vote every denom of the accept list misses the vote period.
+func ModifyGenesisOracleAcceptList(denoms ...OracleDenom) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(_ ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		if len(denoms) == 0 {
+			return nil, fmt.Errorf("oracle accept list must have at least one denom")
+		}
+		g := make(map[string]any)
+		if err := json.Unmarshal(genbz, &g); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+		}
+		if _, err := dyno.Get(g, "app_state", "oracle", "params"); err != nil {
+			return nil, fmt.Errorf("oracle params not found in genesis json: %w", err)
+		}
+		if err := dyno.Set(g, denoms, "app_state", "oracle", "params", "accept_list"); err != nil {
+			return nil, fmt.Errorf("failed to set oracle accept list in genesis json: %w", err)
+		}
+		out, err := json.Marshal(g)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+		}
+		return out, nil
+	}
+}
+
+// ModifyGenesisAddAccounts returns a ChainConfig.ModifyGenesis function that funds the given wallets
+// directly in genesis, creating their accounts and increasing the total supply.
+// Unlike funding through GetAndFundTestUsers, the accounts exist from the first block without any transaction.
+//
+// An error is returned if an address is not a valid bech32 address for the chain's Bech32Prefix.
+func ModifyGenesisAddAccounts(wallets ...ibc.WalletAmount) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(cfg ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		g := make(map[string]any)
+		if err := json.Unmarshal(genbz, &g); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+		}
+
+		var bank struct {
+			Balances []genesisBalance `json:"balances"`
+			Supply   types.Coins      `json:"supply"`
+		}
+		if err := genesisSection(g, &bank, "app_state", "bank"); err != nil {
+			return nil, err
+		}
+		accounts, err := dyno.GetSlice(g, "app_state", "auth", "accounts")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get auth accounts from genesis json: %w", err)
+		}
+
+		for _, w := range wallets {
+			if _, err := types.GetFromBech32(w.Address, cfg.Bech32Prefix); err != nil {
+				return nil, fmt.Errorf("invalid genesis account address %q for prefix %s: %w", w.Address, cfg.Bech32Prefix, err)
+			}
+			if err := types.ValidateDenom(w.Denom); err != nil {
+				return nil, fmt.Errorf("invalid genesis account denom for %s: %w", w.Address, err)
+			}
+			if w.Amount <= 0 {
+				return nil, fmt.Errorf("invalid genesis account amount %d for %s: must be positive", w.Amount, w.Address)
+			}
+			coin := types.NewInt64Coin(w.Denom, w.Amount)
+
+			funded := false
+			for i := range bank.Balances {
+				if bank.Balances[i].Address == w.Address {
+					bank.Balances[i].Coins = bank.Balances[i].Coins.Add(coin)
+					funded = true
+					break
+				}
+			}
+			if !funded {
+				bank.Balances = append(bank.Balances, genesisBalance{Address: w.Address, Coins: types.NewCoins(coin)})
+			}
+			bank.Supply = bank.Supply.Add(coin)
+
+			if !hasGenesisAccount(accounts, w.Address) {
+				accounts = append(accounts, map[string]any{
+					"@type":          "/cosmos.auth.v1beta1.BaseAccount",
+					"address":        w.Address,
+					"pub_key":        nil,
+					"account_number": "0",
+					"sequence":       "0",
+				})
+			}
+		}
+
+		if err := dyno.Set(g, accounts, "app_state", "auth", "accounts"); err != nil {
+			return nil, fmt.Errorf("failed to set auth accounts in genesis json: %w", err)
+		}
+		if err := dyno.Set(g, bank.Balances, "app_state", "bank", "balances"); err != nil {
+			return nil, fmt.Errorf("failed to set bank balances in genesis json: %w", err)
+		}
+		if err := dyno.Set(g, bank.Supply, "app_state", "bank", "supply"); err != nil {
+			return nil, fmt.Errorf("failed to set bank supply in genesis json: %w", err)
+		}
+
+		out, err := json.Marshal(g)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+		}
+		return out, nil
+	}
+}
+
+// genesisBalance is the genesis representation of an account's bank balance.
+type genesisBalance struct {
+	Address string      `json:"address"`
+	Coins   types.Coins `json:"coins"`
+}
+
+// genesisSection decodes the genesis value at path into v.
+func genesisSection(g map[string]any, v any, path ...any) error {
+	section, err := dyno.Get(g, path...)
+	if err != nil {
+		return fmt.Errorf("failed to get %v from genesis json: %w", path, err)
+	}
+	bz, err := json.Marshal(section)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %v genesis json: %w", path, err)
+	}
+	if err := json.Unmarshal(bz, v); err != nil {
+		return fmt.Errorf("failed to unmarshal %v genesis json: %w", path, err)
+	}
+	return nil
+}
+
+// hasGenesisAccount reports whether accounts, from the auth genesis state, contains address.
+func hasGenesisAccount(accounts []any, address string) bool {
+	for _, a := range accounts {
+		if m, ok := a.(map[string]any); ok && m["address"] == address {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/icza/dyno"
+)
+
+// CompareGenesis diffs the app_state of the genesis files of chainA and chainB,
+// typically two chains of the same binary, such as a chain and its clone for a fork test.
+// Validator-specific fields, i.e. the gentxs and the accounts and balances of the validators that signed them,
+// are ignored, as every chain generates its own validator keys.
+// So are the accounts and balances of the wallets that Interchain.Build adds to the genesis of each chain,
+// i.e. the faucet, the relayer wallets and the Interchain's AdditionalGenesisWallets.
+//
+// The returned diff is empty if both app states are equal.
+// Otherwise it has one sorted line per differing value, as described in DiffGenesis.
+func CompareGenesis(ctx context.Context, chainA, chainB *CosmosChain) (diff string, err error) {
+	a, err := chainA.getFullNode().genesisFileContent(ctx)
+	if err != nil {
+		return "", fmt.Errorf("chain %s: %w", chainA.Config().ChainID, err)
+	}
+	b, err := chainB.getFullNode().genesisFileContent(ctx)
+	if err != nil {
+		return "", fmt.Errorf("chain %s: %w", chainB.Config().ChainID, err)
+	}
+	return diffGenesis(a, b, chainA.genesisWallets, chainB.genesisWallets)
+}
+
+// DiffGenesis diffs the app_state of the genesis documents a and b, ignoring validator-specific fields.
+// See CompareGenesis.
+//
+// Each line of the diff has the form "<path>: <value in a> != <value in b>",
+// where path is the JSON path of the value, e.g. app_state.bank.params.send_enabled[0].enabled,
+// and values are compact JSON, or <missing> if the path does not exist in one of the documents.
+func DiffGenesis(a, b []byte) (string, error) {
+	return diffGenesis(a, b, nil, nil)
+}
+
+// diffGenesis is DiffGenesis, also ignoring the accounts and balances of the addresses of ignoreA and ignoreB
+// in a and b respectively.
+func diffGenesis(a, b []byte, ignoreA, ignoreB map[string]bool) (string, error) {
+	stateA, err := comparableAppState(a, ignoreA)
+	if err != nil {
+		return "", fmt.Errorf("first genesis: %w", err)
+	}
+	stateB, err := comparableAppState(b, ignoreB)
+	if err != nil {
+		return "", fmt.Errorf("second genesis: %w", err)
+	}
+
+	var lines []string
+	diffJSON(&lines, "app_state", stateA, stateB)
+	sort.Strings(lines)
+	return strings.Join(lines, "\n"), nil
+}
+
+// comparableAppState decodes the app_state of genesis, without its validator-specific fields
+// and the accounts and balances of the addresses of ignore.
+func comparableAppState(genesis []byte, ignore map[string]bool) (map[string]any, error) {
+	var g map[string]any
+	dec := json.NewDecoder(bytes.NewReader(genesis))
+	// Compare numbers by their literal, so that large amounts do not lose precision.
+	dec.UseNumber()
+	if err := dec.Decode(&g); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis json: %w", err)
+	}
+	appState, ok := g["app_state"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("genesis json has no app_state")
+	}
+
+	ignored := make(map[any]bool, len(ignore))
+	for addr := range ignore {
+		ignored[addr] = true
+	}
+	if txs, err := dyno.GetSlice(appState, "genutil", "gen_txs"); err == nil {
+		for _, tx := range txs {
+			msgs, err := dyno.GetSlice(tx, "body", "messages")
+			if err != nil {
+				continue
+			}
+			for _, msg := range msgs {
+				if addr, err := dyno.GetString(msg, "delegator_address"); err == nil {
+					ignored[addr] = true
+				}
+			}
+		}
+		_ = dyno.Delete(appState, "gen_txs", "genutil")
+	}
+
+	for _, path := range [][]any{{"auth", "accounts"}, {"bank", "balances"}} {
+		entries, err := dyno.GetSlice(appState, path...)
+		if err != nil {
+			continue
+		}
+		kept := make([]any, 0, len(entries))
+		for _, e := range entries {
+			if m, ok := e.(map[string]any); ok && ignored[m["address"]] {
+				continue
+			}
+			kept = append(kept, e)
+		}
+		if err := dyno.Set(appState, kept, path...); err != nil {
+			return nil, fmt.Errorf("failed to filter accounts from %v: %w", path, err)
+		}
+	}
+
+	return appState, nil
+}
+
+// diffJSON appends to lines a line for every value that differs between the decoded JSON values a and b.
+func diffJSON(lines *[]string, path string, a, b any) {
+	switch a := a.(type) {
+	case map[string]any:
+		if b, ok := b.(map[string]any); ok {
+			keys := make(map[string]bool, len(a)+len(b))
+			for k := range a {
+				keys[k] = true
+			}
+			for k := range b {
+				keys[k] = true
+			}
+			for k := range keys {
+				va, okA := a[k]
+				vb, okB := b[k]
+				switch {
+				case !okA:
+					*lines = append(*lines, fmt.Sprintf("%s.%s: <missing> != %s", path, k, compactJSON(vb)))
+				case !okB:
+					*lines = append(*lines, fmt.Sprintf("%s.%s: %s != <missing>", path, k, compactJSON(va)))
+				default:
+					diffJSON(lines, path+"."+k, va, vb)
+				}
+			}
+			return
+		}
+	case []any:
+		if b, ok := b.([]any); ok {
+			for i := 0; i < len(a) || i < len(b); i++ {
+				p := fmt.Sprintf("%s[%d]", path, i)
+				switch {
+				case i >= len(a):
+					*lines = append(*lines, fmt.Sprintf("%s: <missing> != %s", p, compactJSON(b[i])))
+				case i >= len(b):
+					*lines = append(*lines, fmt.Sprintf("%s: %s != <missing>", p, compactJSON(a[i])))
+				default:
+					diffJSON(lines, p, a[i], b[i])
+				}
+			}
+			return
+		}
+	}
+
+	// Scalars, or values of different types.
+	if ca, cb := compactJSON(a), compactJSON(b); ca != cb {
+		*lines = append(*lines, fmt.Sprintf("%s: %s != %s", path, ca, cb))
+	}
+}
+
+func compactJSON(v any) string {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(bz)
+}
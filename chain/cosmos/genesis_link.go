@@ -0,0 +1,280 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	simappparams "github.com/cosmos/cosmos-sdk/simapp/params"
+	"github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v6/modules/core/02-client/types"
+	conntypes "github.com/cosmos/ibc-go/v6/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v6/modules/core/23-commitment/types"
+	host "github.com/cosmos/ibc-go/v6/modules/core/24-host"
+	ibctm "github.com/cosmos/ibc-go/v6/modules/light-clients/07-tendermint"
+	"github.com/gogo/protobuf/proto"
+	"github.com/icza/dyno"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// The IDs of the client, connection, and transfer channel that ModifyGenesisLink creates on a chain.
+// They are the first IDs of their kind, as the chain has no other IBC state at genesis.
+const (
+	GenesisLinkClientID     = "07-tendermint-0"
+	GenesisLinkConnectionID = "connection-0"
+	GenesisLinkChannelID    = "channel-0"
+)
+
+// genesisLinkMaxClockDrift is the max clock drift of the clients created by ModifyGenesisLink,
+// the default of the relayers.
+const genesisLinkMaxClockDrift = 10 * time.Minute
+
+// GenesisLinkEnd is the state of a chain at genesis that its counterparty's light client
+// is built from by ModifyGenesisLink. Build it with GenesisLinkEndFromGenesis.
+type GenesisLinkEnd struct {
+	ChainID         string
+	GenesisTime     time.Time
+	UnbondingPeriod time.Duration
+
+	// ValidatorsHash is the hash of the validator set created from the gentxs of the genesis,
+	// which signs the first blocks of the chain.
+	ValidatorsHash []byte
+}
+
+// GenesisLinkEndFromGenesis returns the GenesisLinkEnd of the chain with the genesis file genbz,
+// after its gentxs were collected.
+//
+// The voting power of each validator is derived from its gentx with the default power reduction of the SDK,
+// so the validators hash does not match chains that use a different power reduction, such as evmos.
+func GenesisLinkEndFromGenesis(cfg ibc.ChainConfig, genbz []byte) (GenesisLinkEnd, error) {
+	var g struct {
+		ChainID     string    `json:"chain_id"`
+		GenesisTime time.Time `json:"genesis_time"`
+		AppState    struct {
+			Genutil struct {
+				GenTxs []json.RawMessage `json:"gen_txs"`
+			} `json:"genutil"`
+			Staking struct {
+				Params struct {
+					UnbondingTime string `json:"unbonding_time"`
+				} `json:"params"`
+			} `json:"staking"`
+		} `json:"app_state"`
+	}
+	if err := json.Unmarshal(genbz, &g); err != nil {
+		return GenesisLinkEnd{}, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+	}
+	unbonding, err := time.ParseDuration(g.AppState.Staking.Params.UnbondingTime)
+	if err != nil {
+		return GenesisLinkEnd{}, fmt.Errorf("failed to parse staking unbonding time of genesis: %w", err)
+	}
+
+	txConfig := genesisEncoding(cfg).TxConfig
+	var validators []*tmtypes.Validator
+	for i, bz := range g.AppState.Genutil.GenTxs {
+		tx, err := txConfig.TxJSONDecoder()(bz)
+		if err != nil {
+			return GenesisLinkEnd{}, fmt.Errorf("failed to decode gentx %d: %w", i, err)
+		}
+		for _, msg := range tx.GetMsgs() {
+			createValidator, ok := msg.(*stakingtypes.MsgCreateValidator)
+			if !ok {
+				continue
+			}
+			pubKey, ok := createValidator.Pubkey.GetCachedValue().(cryptotypes.PubKey)
+			if !ok {
+				return GenesisLinkEnd{}, fmt.Errorf("gentx %d has no validator public key", i)
+			}
+			tmPubKey, err := cryptocodec.ToTmPubKeyInterface(pubKey)
+			if err != nil {
+				return GenesisLinkEnd{}, fmt.Errorf("failed to convert validator public key of gentx %d: %w", i, err)
+			}
+			power := types.TokensToConsensusPower(createValidator.Value.Amount, types.DefaultPowerReduction)
+			validators = append(validators, tmtypes.NewValidator(tmPubKey, power))
+		}
+	}
+	if len(validators) == 0 {
+		return GenesisLinkEnd{}, fmt.Errorf("genesis of %s has no gentxs creating validators", g.ChainID)
+	}
+
+	return GenesisLinkEnd{
+		ChainID:         g.ChainID,
+		GenesisTime:     g.GenesisTime,
+		UnbondingPeriod: unbonding,
+		ValidatorsHash:  tmtypes.NewValidatorSet(validators).Hash(),
+	}, nil
+}
+
+// ModifyGenesisLink returns a ChainConfig.ModifyGenesis function that creates an open ICS-20 transfer channel
+// to counterparty in genesis, along with the client and open connection it uses,
+// all with the GenesisLink IDs on both chains.
+// The counterparty must create the same state for this chain in its own genesis.
+//
+// The client trusts the first block of the counterparty, built from its genesis time and validators.
+// Its consensus state has a stand-in commitment root, as the app hash of the block is unknown before the
+// counterparty starts, so packets can only be relayed after the client is updated to a later height,
+// which relayers do before relaying the first packet.
+func ModifyGenesisLink(counterparty GenesisLinkEnd, trustingPeriod time.Duration) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(cfg ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		g := make(map[string]any)
+		if err := json.Unmarshal(genbz, &g); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+		}
+		cdc := genesisEncoding(cfg).Codec
+
+		if clients, err := dyno.GetSlice(g, "app_state", "ibc", "client_genesis", "clients"); err == nil && len(clients) > 0 {
+			return nil, fmt.Errorf("genesis already has %d IBC clients", len(clients))
+		}
+
+		height := clienttypes.NewHeight(clienttypes.ParseChainID(counterparty.ChainID), 1)
+		clientState := ibctm.NewClientState(
+			counterparty.ChainID, ibctm.DefaultTrustLevel,
+			trustingPeriod, counterparty.UnbondingPeriod, genesisLinkMaxClockDrift,
+			height, commitmenttypes.GetSDKSpecs(), []string{"upgrade", "upgradedIBCState"},
+		)
+		consensusState := ibctm.NewConsensusState(
+			counterparty.GenesisTime,
+			commitmenttypes.NewMerkleRoot([]byte(ibctm.SentinelRoot)),
+			counterparty.ValidatorsHash,
+		)
+
+		prefix := commitmenttypes.NewMerklePrefix([]byte(host.StoreKey))
+		connection := conntypes.NewConnectionEnd(
+			conntypes.OPEN, GenesisLinkClientID,
+			conntypes.NewCounterparty(GenesisLinkClientID, GenesisLinkConnectionID, prefix),
+			conntypes.ExportedVersionsToProto(conntypes.GetCompatibleVersions()), 0,
+		)
+
+		port := transfertypes.PortID
+		channel := chantypes.NewChannel(
+			chantypes.OPEN, chantypes.UNORDERED,
+			chantypes.NewCounterparty(port, GenesisLinkChannelID),
+			[]string{GenesisLinkConnectionID}, transfertypes.Version,
+		)
+
+		clients := clienttypes.NewIdentifiedClientState(GenesisLinkClientID, clientState)
+		consensusStates := clienttypes.NewClientConsensusStates(GenesisLinkClientID, []clienttypes.ConsensusStateWithHeight{
+			clienttypes.NewConsensusStateWithHeight(height, consensusState),
+		})
+		connections := conntypes.NewIdentifiedConnection(GenesisLinkConnectionID, connection)
+		connectionPaths := conntypes.NewConnectionPaths(GenesisLinkClientID, []string{GenesisLinkConnectionID})
+		channels := chantypes.NewIdentifiedChannel(port, GenesisLinkChannelID, channel)
+		sequence := chantypes.NewPacketSequence(port, GenesisLinkChannelID, 1)
+
+		// Each entry is set as the only element of the list at its path in the ibc genesis state.
+		ibcState := []struct {
+			path []any
+			msg  proto.Message
+		}{
+			{[]any{"client_genesis", "clients"}, &clients},
+			{[]any{"client_genesis", "clients_consensus"}, &consensusStates},
+			{[]any{"connection_genesis", "connections"}, &connections},
+			{[]any{"connection_genesis", "client_connection_paths"}, &connectionPaths},
+			{[]any{"channel_genesis", "channels"}, &channels},
+			{[]any{"channel_genesis", "send_sequences"}, &sequence},
+			{[]any{"channel_genesis", "recv_sequences"}, &sequence},
+			{[]any{"channel_genesis", "ack_sequences"}, &sequence},
+		}
+		for _, s := range ibcState {
+			v, err := protoJSONValue(cdc, s.msg)
+			if err != nil {
+				return nil, err
+			}
+			path := append([]any{"app_state", "ibc"}, s.path...)
+			if err := dyno.Set(g, []any{v}, path...); err != nil {
+				return nil, fmt.Errorf("failed to set %v in genesis json: %w", path, err)
+			}
+		}
+
+		// The next IDs follow the IDs of the created client, connection, and channel.
+		for _, path := range [][]any{
+			{"client_genesis", "next_client_sequence"},
+			{"connection_genesis", "next_connection_sequence"},
+			{"channel_genesis", "next_channel_sequence"},
+		} {
+			path = append([]any{"app_state", "ibc"}, path...)
+			if err := dyno.Set(g, "1", path...); err != nil {
+				return nil, fmt.Errorf("failed to set %v in genesis json: %w", path, err)
+			}
+		}
+
+		if err := addGenesisChannelCapabilities(g, port, GenesisLinkChannelID); err != nil {
+			return nil, err
+		}
+
+		out, err := json.Marshal(g)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+		}
+		return out, nil
+	}
+}
+
+// addGenesisChannelCapabilities adds the capabilities of the port and of the channel with channelID
+// to the capability genesis state, owned by the ibc module and the application module bound to the port,
+// as if the port had been bound and the channel handshake had run.
+// The application module then skips binding its port during InitGenesis, as it already owns it.
+func addGenesisChannelCapabilities(g map[string]any, portID, channelID string) error {
+	var capability struct {
+		Index  uint64 `json:"index,string"`
+		Owners []any  `json:"owners"`
+	}
+	if err := genesisSection(g, &capability, "app_state", "capability"); err != nil {
+		return err
+	}
+	if capability.Index == 0 {
+		// The first capability index is 1.
+		capability.Index = 1
+	}
+
+	for _, name := range []string{host.PortPath(portID), host.ChannelCapabilityPath(portID, channelID)} {
+		capability.Owners = append(capability.Owners, map[string]any{
+			"index": fmt.Sprint(capability.Index),
+			"index_owners": map[string]any{
+				// Owners are sorted by module name.
+				"owners": []any{
+					map[string]any{"module": host.ModuleName, "name": name},
+					map[string]any{"module": portID, "name": name},
+				},
+			},
+		})
+		capability.Index++
+	}
+
+	if err := dyno.Set(g, fmt.Sprint(capability.Index), "app_state", "capability", "index"); err != nil {
+		return fmt.Errorf("failed to set capability index in genesis json: %w", err)
+	}
+	if err := dyno.Set(g, capability.Owners, "app_state", "capability", "owners"); err != nil {
+		return fmt.Errorf("failed to set capability owners in genesis json: %w", err)
+	}
+	return nil
+}
+
+// genesisEncoding returns the encoding config of the chain, or DefaultEncoding if the config has none.
+func genesisEncoding(cfg ibc.ChainConfig) *simappparams.EncodingConfig {
+	if cfg.EncodingConfig != nil {
+		return cfg.EncodingConfig
+	}
+	enc := DefaultEncoding()
+	return &enc
+}
+
+// protoJSONValue returns msg encoded as JSON with cdc, decoded into a value for a genesis JSON map.
+func protoJSONValue(cdc codec.JSONCodec, msg proto.Message) (any, error) {
+	bz, err := cdc.MarshalJSON(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %T to json: %w", msg, err)
+	}
+	var v any
+	if err := json.Unmarshal(bz, &v); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %T json: %w", msg, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,99 @@
+package cosmos_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	ibctypes "github.com/cosmos/ibc-go/v6/modules/core/types"
+	ibctm "github.com/cosmos/ibc-go/v6/modules/light-clients/07-tendermint"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	tmed25519 "github.com/tendermint/tendermint/crypto/ed25519"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func TestModifyGenesisLink(t *testing.T) {
+	enc := cosmos.DefaultEncoding()
+	cfg := ibc.ChainConfig{EncodingConfig: &enc}
+
+	// A gentx of a validator with a self-delegation of 5 consensus power.
+	tmKey := tmed25519.GenPrivKey()
+	pubKey := &ed25519.PubKey{Key: tmKey.PubKey().Bytes()}
+	msg, err := stakingtypes.NewMsgCreateValidator(
+		sdk.ValAddress(pubKey.Address()), pubKey, sdk.NewInt64Coin("stake", 5_000_000),
+		stakingtypes.Description{Moniker: "validator"}, stakingtypes.NewCommissionRates(sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()), sdk.OneInt(),
+	)
+	require.NoError(t, err)
+	txBuilder := enc.TxConfig.NewTxBuilder()
+	require.NoError(t, txBuilder.SetMsgs(msg))
+	gentx, err := enc.TxConfig.TxJSONEncoder()(txBuilder.GetTx())
+	require.NoError(t, err)
+
+	ibcGenesis, err := enc.Codec.MarshalJSON(ibctypes.DefaultGenesisState())
+	require.NoError(t, err)
+	capabilityGenesis, err := enc.Codec.MarshalJSON(capabilitytypes.DefaultGenesis())
+	require.NoError(t, err)
+
+	genesis := `{
+  "chain_id": "gaia-2",
+  "genesis_time": "2022-11-01T12:00:00Z",
+  "app_state": {
+    "genutil": {"gen_txs": [` + string(gentx) + `]},
+    "staking": {"params": {"unbonding_time": "1814400s"}},
+    "ibc": ` + string(ibcGenesis) + `,
+    "capability": ` + string(capabilityGenesis) + `
+  }
+}`
+
+	end, err := cosmos.GenesisLinkEndFromGenesis(cfg, []byte(genesis))
+	require.NoError(t, err)
+	require.Equal(t, "gaia-2", end.ChainID)
+	require.Equal(t, time.Date(2022, 11, 1, 12, 0, 0, 0, time.UTC), end.GenesisTime)
+	require.Equal(t, 21*24*time.Hour, end.UnbondingPeriod)
+	wantHash := tmtypes.NewValidatorSet([]*tmtypes.Validator{tmtypes.NewValidator(tmKey.PubKey(), 5)}).Hash()
+	require.Equal(t, wantHash, end.ValidatorsHash)
+
+	// Both chains of a link are configured the same way, so link this genesis to itself.
+	out, err := cosmos.ModifyGenesisLink(end, 14*24*time.Hour)(cfg, []byte(genesis))
+	require.NoError(t, err)
+
+	var g struct {
+		AppState struct {
+			IBC        json.RawMessage `json:"ibc"`
+			Capability json.RawMessage `json:"capability"`
+		} `json:"app_state"`
+	}
+	require.NoError(t, json.Unmarshal(out, &g))
+
+	var ibcState ibctypes.GenesisState
+	require.NoError(t, enc.Codec.UnmarshalJSON(g.AppState.IBC, &ibcState))
+	require.NoError(t, ibcState.Validate())
+
+	require.Len(t, ibcState.ClientGenesis.Clients, 1)
+	clientState, ok := ibcState.ClientGenesis.Clients[0].ClientState.GetCachedValue().(*ibctm.ClientState)
+	require.True(t, ok)
+	require.Equal(t, "gaia-2", clientState.ChainId)
+	require.Equal(t, uint64(2), clientState.LatestHeight.RevisionNumber)
+	require.Equal(t, 14*24*time.Hour, clientState.TrustingPeriod)
+
+	require.Len(t, ibcState.ChannelGenesis.Channels, 1)
+	channel := ibcState.ChannelGenesis.Channels[0]
+	require.Equal(t, cosmos.GenesisLinkChannelID, channel.ChannelId)
+	require.Equal(t, cosmos.GenesisLinkChannelID, channel.Counterparty.ChannelId)
+	require.Equal(t, []string{cosmos.GenesisLinkConnectionID}, channel.ConnectionHops)
+
+	var capabilityState capabilitytypes.GenesisState
+	require.NoError(t, enc.Codec.UnmarshalJSON(g.AppState.Capability, &capabilityState))
+	require.NoError(t, capabilityState.Validate())
+	require.Equal(t, uint64(3), capabilityState.Index)
+	require.Len(t, capabilityState.Owners, 2)
+
+	_, err = cosmos.ModifyGenesisLink(end, time.Hour)(cfg, out)
+	require.ErrorContains(t, err, "genesis already has 1 IBC clients")
+}
@@ -0,0 +1,394 @@
+package cosmos_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+const testGenesis = `{
+  "chain_id": "test-1",
+  "app_state": {
+    "bank": {"balances": []},
+    "transfer": {"port_id": "transfer", "params": {"send_enabled": true, "receive_enabled": true}},
+    "interchainaccounts": {}
+  }
+}`
+
+func TestModifyGenesisRemoveModules(t *testing.T) {
+	out, err := cosmos.ModifyGenesisRemoveModules("transfer", "interchainaccounts")(ibc.ChainConfig{}, []byte(testGenesis))
+	require.NoError(t, err)
+
+	var g struct {
+		ChainID  string                     `json:"chain_id"`
+		AppState map[string]json.RawMessage `json:"app_state"`
+	}
+	require.NoError(t, json.Unmarshal(out, &g))
+	require.Equal(t, "test-1", g.ChainID)
+	require.Contains(t, g.AppState, "bank")
+	require.NotContains(t, g.AppState, "transfer")
+	require.NotContains(t, g.AppState, "interchainaccounts")
+
+	_, err = cosmos.ModifyGenesisRemoveModules("wasm")(ibc.ChainConfig{}, []byte(testGenesis))
+	require.ErrorContains(t, err, "module wasm not found")
+}
+
+func TestModifyGenesisDisableTransfer(t *testing.T) {
+	out, err := cosmos.ModifyGenesisDisableTransfer()(ibc.ChainConfig{}, []byte(testGenesis))
+	require.NoError(t, err)
+
+	var g struct {
+		AppState struct {
+			Transfer struct {
+				PortID string `json:"port_id"`
+				Params struct {
+					SendEnabled    bool `json:"send_enabled"`
+					ReceiveEnabled bool `json:"receive_enabled"`
+				} `json:"params"`
+			} `json:"transfer"`
+		} `json:"app_state"`
+	}
+	require.NoError(t, json.Unmarshal(out, &g))
+	require.Equal(t, "transfer", g.AppState.Transfer.PortID)
+	require.False(t, g.AppState.Transfer.Params.SendEnabled)
+	require.False(t, g.AppState.Transfer.Params.ReceiveEnabled)
+}
+
+func TestModifyGenesisAddAccounts(t *testing.T) {
+	const genesis = `{
+  "app_state": {
+    "auth": {"accounts": [{"@type": "/cosmos.auth.v1beta1.BaseAccount", "address": "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu", "pub_key": null, "account_number": "0", "sequence": "0"}]},
+    "bank": {
+      "balances": [{"address": "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu", "coins": [{"denom": "uatom", "amount": "100"}]}],
+      "supply": [{"denom": "uatom", "amount": "100"}]
+    }
+  }
+}`
+	cfg := ibc.ChainConfig{Bech32Prefix: "cosmos"}
+
+	existing := "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu"
+	fresh, err := types.Bech32ifyAddressBytes("cosmos", make([]byte, 20))
+	require.NoError(t, err)
+
+	out, err := cosmos.ModifyGenesisAddAccounts(
+		ibc.WalletAmount{Address: existing, Denom: "uatom", Amount: 50},
+		ibc.WalletAmount{Address: fresh, Denom: "uatom", Amount: 10},
+		ibc.WalletAmount{Address: fresh, Denom: "ufoo", Amount: 5},
+	)(cfg, []byte(genesis))
+	require.NoError(t, err)
+
+	var g struct {
+		AppState struct {
+			Auth struct {
+				Accounts []struct {
+					Address string `json:"address"`
+				} `json:"accounts"`
+			} `json:"auth"`
+			Bank struct {
+				Balances []struct {
+					Address string      `json:"address"`
+					Coins   types.Coins `json:"coins"`
+				} `json:"balances"`
+				Supply types.Coins `json:"supply"`
+			} `json:"bank"`
+		} `json:"app_state"`
+	}
+	require.NoError(t, json.Unmarshal(out, &g))
+
+	require.Len(t, g.AppState.Auth.Accounts, 2)
+	require.Equal(t, existing, g.AppState.Auth.Accounts[0].Address)
+	require.Equal(t, fresh, g.AppState.Auth.Accounts[1].Address)
+
+	require.Len(t, g.AppState.Bank.Balances, 2)
+	require.Equal(t, "150uatom", g.AppState.Bank.Balances[0].Coins.String())
+	require.Equal(t, "10uatom,5ufoo", g.AppState.Bank.Balances[1].Coins.String())
+	require.Equal(t, "160uatom,5ufoo", g.AppState.Bank.Supply.String())
+
+	_, err = cosmos.ModifyGenesisAddAccounts(
+		ibc.WalletAmount{Address: "osmo1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5helwsw", Denom: "uatom", Amount: 1},
+	)(cfg, []byte(genesis))
+	require.ErrorContains(t, err, "invalid genesis account address")
+
+	_, err = cosmos.ModifyGenesisAddAccounts(
+		ibc.WalletAmount{Address: fresh, Denom: "uatom", Amount: 0},
+	)(cfg, []byte(genesis))
+	require.ErrorContains(t, err, "must be positive")
+}
+
+func TestDiffGenesis(t *testing.T) {
+	genesis := func(validator, sendEnabled, amount string) []byte {
+		return []byte(`{
+  "chain_id": "` + validator + `-chain",
+  "app_state": {
+    "auth": {"accounts": [{"address": "` + validator + `"}, {"address": "cosmos1user"}]},
+    "bank": {
+      "params": {"default_send_enabled": ` + sendEnabled + `},
+      "balances": [
+        {"address": "` + validator + `", "coins": [{"denom": "uatom", "amount": "10"}]},
+        {"address": "cosmos1user", "coins": [{"denom": "uatom", "amount": "` + amount + `"}]}
+      ]
+    },
+    "genutil": {"gen_txs": [{"body": {"messages": [{"delegator_address": "` + validator + `"}]}}]}
+  }
+}`)
+	}
+
+	// Validator-specific fields, and fields outside of app_state, are ignored.
+	diff, err := cosmos.DiffGenesis(genesis("cosmos1vala", "true", "100"), genesis("cosmos1valb", "true", "100"))
+	require.NoError(t, err)
+	require.Empty(t, diff)
+
+	diff, err = cosmos.DiffGenesis(genesis("cosmos1vala", "true", "100"), genesis("cosmos1valb", "false", "100000000000000000001"))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`app_state.bank.balances[0].coins[0].amount: "100" != "100000000000000000001"`,
+		`app_state.bank.params.default_send_enabled: true != false`,
+	}, strings.Split(diff, "\n"))
+
+	diff, err = cosmos.DiffGenesis(
+		[]byte(`{"app_state": {"mint": {"minter": {"inflation": 0.13}}}}`),
+		[]byte(`{"app_state": {"mint": {}, "wasm": {"codes": []}}}`),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`app_state.mint.minter: {"inflation":0.13} != <missing>`,
+		`app_state.wasm: <missing> != {"codes":[]}`,
+	}, strings.Split(diff, "\n"))
+
+	_, err = cosmos.DiffGenesis([]byte(`{}`), []byte(`{"app_state": {}}`))
+	require.ErrorContains(t, err, "first genesis: genesis json has no app_state")
+}
+
+func TestModifyGenesisEpochDuration(t *testing.T) {
+	const genesis = `{"app_state": {"epochs": {"epochs": [
+  {"identifier": "day", "duration": "86400s", "current_epoch": "0"},
+  {"identifier": "week", "duration": "604800s", "current_epoch": "0"}
+]}}}`
+
+	out, err := cosmos.ModifyGenesisEpochDuration("day", 30*time.Second)(ibc.ChainConfig{}, []byte(genesis))
+	require.NoError(t, err)
+
+	var g struct {
+		AppState struct {
+			Epochs struct {
+				Epochs []struct {
+					Identifier string `json:"identifier"`
+					Duration   string `json:"duration"`
+				} `json:"epochs"`
+			} `json:"epochs"`
+		} `json:"app_state"`
+	}
+	require.NoError(t, json.Unmarshal(out, &g))
+	epochs := g.AppState.Epochs.Epochs
+	require.Len(t, epochs, 2)
+	require.Equal(t, "30s", epochs[0].Duration)
+	require.Equal(t, "604800s", epochs[1].Duration)
+
+	out, err = cosmos.ModifyGenesisEpochDuration("week", 1500*time.Millisecond)(ibc.ChainConfig{}, []byte(genesis))
+	require.NoError(t, err)
+	require.Contains(t, string(out), `"duration":"1.5s"`)
+
+	_, err = cosmos.ModifyGenesisEpochDuration("hour", time.Minute)(ibc.ChainConfig{}, []byte(genesis))
+	require.ErrorContains(t, err, "epoch hour not found")
+}
+
+func TestModifyGenesisVotingPeriod(t *testing.T) {
+	for _, genesis := range []string{
+		// SDK v0.46 and earlier.
+		`{"app_state": {"gov": {"voting_params": {"voting_period": "172800s"}}}}`,
+		// SDK v0.47 and later.
+		`{"app_state": {"gov": {"params": {"voting_period": "172800s", "min_deposit": []}}}}`,
+	} {
+		out, err := cosmos.ModifyGenesisVotingPeriod(20*time.Second)(ibc.ChainConfig{}, []byte(genesis))
+		require.NoError(t, err)
+		require.Contains(t, string(out), `"voting_period":"20s"`)
+		require.NotContains(t, string(out), "172800s")
+	}
+
+	_, err := cosmos.ModifyGenesisVotingPeriod(time.Minute)(ibc.ChainConfig{}, []byte(testGenesis))
+	require.ErrorContains(t, err, "voting period not found")
+}
+
+func TestModifyGenesisOracleVotePeriod(t *testing.T) {
+	const genesis = `{"app_state": {"oracle": {"params": {"vote_period": "5", "slash_window": "100800", "vote_threshold": "0.5"}}}}`
+	out, err := cosmos.ModifyGenesisOracleVotePeriod(2, 10)(ibc.ChainConfig{}, []byte(genesis))
+	require.NoError(t, err)
+	require.Contains(t, string(out), `"vote_period":"2"`)
+	require.Contains(t, string(out), `"slash_window":"10"`)
+	require.Contains(t, string(out), `"vote_threshold":"0.5"`)
+
+	_, err = cosmos.ModifyGenesisOracleVotePeriod(2, 10)(ibc.ChainConfig{}, []byte(testGenesis))
+	require.ErrorContains(t, err, "oracle params not found")
+
+	_, err = cosmos.ModifyGenesisOracleVotePeriod(5, 4)(ibc.ChainConfig{}, []byte(genesis))
+	require.ErrorContains(t, err, "must be at least the vote period")
+}
+
+func TestModifyGenesisOracleAcceptList(t *testing.T) {
+	const genesis = `{"app_state": {"oracle": {"params": {"vote_period": "5", "accept_list": [
+  {"base_denom": "uumee", "symbol_denom": "UMEE", "exponent": 6},
+  {"base_denom": "ibc/atom", "symbol_denom": "ATOM", "exponent": 6}
+]}}}}`
+	out, err := cosmos.ModifyGenesisOracleAcceptList(cosmos.OracleDenom{BaseDenom: "ibc/atom", SymbolDenom: "ATOM", Exponent: 6})(ibc.ChainConfig{}, []byte(genesis))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"app_state": {"oracle": {"params": {"vote_period": "5", "accept_list": [
+  {"base_denom": "ibc/atom", "symbol_denom": "ATOM", "exponent": 6}
+]}}}}`, string(out))
+
+	_, err = cosmos.ModifyGenesisOracleAcceptList(cosmos.OracleDenom{SymbolDenom: "ATOM"})(ibc.ChainConfig{}, []byte(testGenesis))
+	require.ErrorContains(t, err, "oracle params not found")
+
+	_, err = cosmos.ModifyGenesisOracleAcceptList()(ibc.ChainConfig{}, []byte(genesis))
+	require.ErrorContains(t, err, "at least one denom")
+}
+
+func TestModifyGenesisConsensusParams(t *testing.T) {
+	const consensusParams = `{
+    "block": {"max_bytes": "22020096", "max_gas": "-1", "time_iota_ms": "1000"},
+    "evidence": {"max_age_num_blocks": "100000", "max_age_duration": "172800000000000", "max_bytes": "1048576"},
+    "validator": {"pub_key_types": ["ed25519"]},
+    "version": {}
+  }`
+
+	for _, tt := range []struct {
+		name, genesis string
+		path          []string
+	}{
+		{name: "consensus_params", genesis: `{"consensus_params": ` + consensusParams + `}`, path: []string{"consensus_params"}},
+		{name: "SDK v0.50", genesis: `{"consensus": {"params": ` + consensusParams + `}}`, path: []string{"consensus", "params"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := cosmos.ModifyGenesisConsensusParams(func(p *cosmos.ConsensusParams) {
+				require.Equal(t, int64(-1), p.Block.MaxGas)
+				require.Equal(t, 48*time.Hour, p.Evidence.MaxAgeDuration)
+				p.Block.MaxGas = 1_000_000
+				p.Evidence.MaxAgeNumBlocks = 10
+				p.Evidence.MaxAgeDuration = time.Minute
+				p.Validator.PubKeyTypes = []string{"ed25519", "secp256k1"}
+			})(ibc.ChainConfig{}, []byte(tt.genesis))
+			require.NoError(t, err)
+
+			var g map[string]any
+			require.NoError(t, json.Unmarshal(out, &g))
+			var params any = g
+			for _, k := range tt.path {
+				params = params.(map[string]any)[k]
+			}
+			bz, err := json.Marshal(params)
+			require.NoError(t, err)
+			require.JSONEq(t, `{
+    "block": {"max_bytes": "22020096", "max_gas": "1000000", "time_iota_ms": "1000"},
+    "evidence": {"max_age_num_blocks": "10", "max_age_duration": "60000000000", "max_bytes": "1048576"},
+    "validator": {"pub_key_types": ["ed25519", "secp256k1"]},
+    "version": {}
+  }`, string(bz))
+		})
+	}
+
+	genesis := []byte(`{"consensus_params": ` + consensusParams + `}`)
+	for _, tt := range []struct {
+		name   string
+		modify func(p *cosmos.ConsensusParams)
+		err    string
+	}{
+		{"block max bytes", func(p *cosmos.ConsensusParams) { p.Block.MaxBytes = 0 }, "block max bytes"},
+		{"block max gas", func(p *cosmos.ConsensusParams) { p.Block.MaxGas = -2 }, "block max gas"},
+		{"evidence max age", func(p *cosmos.ConsensusParams) { p.Evidence.MaxAgeDuration = 0 }, "evidence max age"},
+		{"evidence max bytes", func(p *cosmos.ConsensusParams) { p.Evidence.MaxBytes = p.Block.MaxBytes + 1 }, "evidence max bytes"},
+		{"pub key type", func(p *cosmos.ConsensusParams) { p.Validator.PubKeyTypes = []string{"rsa"} }, `unknown validator pub key type "rsa"`},
+	} {
+		t.Run("invalid "+tt.name, func(t *testing.T) {
+			_, err := cosmos.ModifyGenesisConsensusParams(tt.modify)(ibc.ChainConfig{}, genesis)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestPoAStakingGenesis(t *testing.T) {
+	const genesis = `{"app_state": {
+  "bank": {"balances": [], "supply": [{"denom": "stake", "amount": "100"}]},
+  "staking": {"validators": [], "delegations": []}
+}}`
+	validators := []cosmos.PoAGenesisValidator{
+		{
+			AccountAddress:  "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu",
+			OperatorAddress: "cosmosvaloper1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc56kct20",
+			ConsensusPubKey: "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=",
+			Moniker:         "val-0",
+			Power:           10,
+		},
+		{
+			AccountAddress:  "cosmos1qgpqyqszqgpqyqszqgpqyqszqgpqyqszrh8mx2",
+			OperatorAddress: "cosmosvaloper1qgpqyqszqgpqyqszqgpqyqszqgpqyqszxrnw2e",
+			ConsensusPubKey: "AgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgI=",
+			Moniker:         "val-1",
+			Power:           5,
+		},
+	}
+
+	out, err := cosmos.PoAStakingGenesis([]byte(genesis), "stake", validators)
+	require.NoError(t, err)
+
+	var g struct {
+		AppState struct {
+			Bank struct {
+				Balances []struct {
+					Address string      `json:"address"`
+					Coins   types.Coins `json:"coins"`
+				} `json:"balances"`
+				Supply types.Coins `json:"supply"`
+			} `json:"bank"`
+			Staking struct {
+				Validators []struct {
+					OperatorAddress string `json:"operator_address"`
+					ConsensusPubkey struct {
+						Type string `json:"@type"`
+						Key  string `json:"key"`
+					} `json:"consensus_pubkey"`
+					Status string `json:"status"`
+					Tokens string `json:"tokens"`
+				} `json:"validators"`
+				Delegations []struct {
+					DelegatorAddress string `json:"delegator_address"`
+					ValidatorAddress string `json:"validator_address"`
+					Shares           string `json:"shares"`
+				} `json:"delegations"`
+			} `json:"staking"`
+		} `json:"app_state"`
+	}
+	require.NoError(t, json.Unmarshal(out, &g))
+
+	require.Len(t, g.AppState.Staking.Validators, 2)
+	require.Len(t, g.AppState.Staking.Delegations, 2)
+	for i, v := range g.AppState.Staking.Validators {
+		require.Equal(t, validators[i].OperatorAddress, v.OperatorAddress)
+		require.Equal(t, "/cosmos.crypto.ed25519.PubKey", v.ConsensusPubkey.Type)
+		require.Equal(t, validators[i].ConsensusPubKey, v.ConsensusPubkey.Key)
+		require.Equal(t, "BOND_STATUS_UNBONDED", v.Status)
+
+		d := g.AppState.Staking.Delegations[i]
+		require.Equal(t, validators[i].AccountAddress, d.DelegatorAddress)
+		require.Equal(t, validators[i].OperatorAddress, d.ValidatorAddress)
+	}
+	require.Equal(t, "10000000", g.AppState.Staking.Validators[0].Tokens)
+	require.Equal(t, "5000000.000000000000000000", g.AppState.Staking.Delegations[1].Shares)
+
+	// The tokens are in the not bonded pool, and the supply includes them.
+	require.Len(t, g.AppState.Bank.Balances, 1)
+	require.Equal(t, "cosmos1tygms3xhhs3yv487phx3dw4a95jn7t7lpm470r", g.AppState.Bank.Balances[0].Address)
+	require.Equal(t, types.NewCoins(types.NewInt64Coin("stake", 15_000_000)), g.AppState.Bank.Balances[0].Coins)
+	require.Equal(t, types.NewCoins(types.NewInt64Coin("stake", 15_000_100)), g.AppState.Bank.Supply)
+
+	_, err = cosmos.PoAStakingGenesis([]byte(genesis), "stake", nil)
+	require.ErrorContains(t, err, "at least one validator")
+
+	validators[1].Power = 0
+	_, err = cosmos.PoAStakingGenesis([]byte(genesis), "stake", validators)
+	require.ErrorContains(t, err, "must be positive")
+}
@@ -0,0 +1,146 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ProposalV1 is the proposal file of a gov v1 proposal, which executes its messages once it passes.
+// Build it with BuildProposal, and submit it with SubmitProposal.
+type ProposalV1 struct {
+	// Messages encoded as JSON with their type URLs.
+	Messages []json.RawMessage `json:"messages"`
+	Metadata string            `json:"metadata"`
+	Deposit  string            `json:"deposit"`
+
+	// Title and Summary are only read by chains of SDK v0.47 and later.
+	Title   string `json:"title"`
+	Summary string `json:"summary"`
+
+	// msgs are the decoded messages, to submit the proposal to chains without gov v1.
+	msgs []sdk.Msg
+}
+
+// BuildProposal returns a gov v1 proposal executing messages, with a deposit such as "10000000stake".
+// Messages that require an authority, such as *upgradetypes.MsgSoftwareUpgrade,
+// must set it to the gov module account, see GovModuleAddress.
+func (c *CosmosChain) BuildProposal(messages []sdk.Msg, metadata, deposit, title, summary string) (ProposalV1, error) {
+	prop := ProposalV1{
+		Metadata: metadata,
+		Deposit:  deposit,
+		Title:    title,
+		Summary:  summary,
+		msgs:     messages,
+	}
+	for _, msg := range messages {
+		bz, err := c.cfg.EncodingConfig.Codec.MarshalInterfaceJSON(msg)
+		if err != nil {
+			return ProposalV1{}, fmt.Errorf("failed to marshal proposal message %T: %w", msg, err)
+		}
+		prop.Messages = append(prop.Messages, bz)
+	}
+	return prop, nil
+}
+
+// GovModuleAddress returns the address of the gov module account,
+// the authority of messages executed by gov v1 proposals.
+func (c *CosmosChain) GovModuleAddress() (string, error) {
+	return sdk.Bech32ifyAddressBytes(c.cfg.Bech32Prefix, authtypes.NewModuleAddress(govtypes.ModuleName))
+}
+
+// SubmitProposal submits the gov v1 proposal prop, returning its proposal ID from the events of the transaction.
+//
+// Chains without gov v1, i.e. of SDK v0.45 and earlier, only accept legacy v1beta1 proposals.
+// A proposal built with BuildProposal is submitted to them as the equivalent legacy proposal,
+// which exists for a single *upgradetypes.MsgSoftwareUpgrade, and for a proposal without messages as a text proposal.
+func (c *CosmosChain) SubmitProposal(ctx context.Context, keyName string, prop ProposalV1) (TxProposal, error) {
+	v1, err := c.supportsGovV1(ctx)
+	if err != nil {
+		return TxProposal{}, err
+	}
+	if !v1 {
+		return c.submitLegacyProposal(ctx, keyName, prop)
+	}
+
+	txHash, err := c.getFullNode().SubmitProposal(ctx, keyName, prop)
+	if err != nil {
+		return TxProposal{}, fmt.Errorf("failed to submit gov v1 proposal: %w", err)
+	}
+	return c.txProposal(txHash)
+}
+
+// supportsGovV1 reports whether the chain serves the gov v1 queries, i.e. accepts gov v1 proposals.
+func (c *CosmosChain) supportsGovV1(ctx context.Context) (bool, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	_, err = govv1.NewQueryClient(conn).Params(ctx, &govv1.QueryParamsRequest{ParamsType: govv1.ParamDeposit})
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("query gov v1 params: %w", err)
+	}
+	return true, nil
+}
+
+// submitLegacyProposal submits prop as the equivalent legacy v1beta1 proposal.
+func (c *CosmosChain) submitLegacyProposal(ctx context.Context, keyName string, prop ProposalV1) (TxProposal, error) {
+	if len(prop.msgs) != len(prop.Messages) {
+		return TxProposal{}, fmt.Errorf("chain %s does not support gov v1 proposals, and proposal was not built with BuildProposal", c.cfg.ChainID)
+	}
+
+	switch len(prop.msgs) {
+	case 0:
+		return c.TextProposal(ctx, keyName, TextProposal{
+			Deposit:     prop.Deposit,
+			Title:       prop.Title,
+			Description: prop.Summary,
+		})
+	case 1:
+		if msg, ok := prop.msgs[0].(*upgradetypes.MsgSoftwareUpgrade); ok {
+			return c.UpgradeProposal(ctx, keyName, SoftwareUpgradeProposal{
+				Deposit:     prop.Deposit,
+				Title:       prop.Title,
+				Name:        msg.Plan.Name,
+				Description: prop.Summary,
+				Height:      uint64(msg.Plan.Height),
+				Info:        msg.Plan.Info,
+			})
+		}
+	}
+	return TxProposal{}, fmt.Errorf("chain %s does not support gov v1 proposals, and its messages have no legacy proposal equivalent", c.cfg.ChainID)
+}
+
+// SubmitProposal submits the gov v1 proposal prop as a proposal file.
+func (tn *ChainNode) SubmitProposal(ctx context.Context, keyName string, prop ProposalV1) (string, error) {
+	content, err := json.Marshal(prop)
+	if err != nil {
+		return "", err
+	}
+
+	const file = "proposal.json"
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	if err := fw.WriteFile(ctx, tn.VolumeName, file, content); err != nil {
+		return "", fmt.Errorf("writing proposal file to docker volume: %w", err)
+	}
+
+	return tn.ExecTx(ctx, keyName,
+		"gov", "submit-proposal", path.Join(tn.HomeDir(), file),
+	)
+}
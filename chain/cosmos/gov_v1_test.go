@@ -0,0 +1,77 @@
+package cosmos_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBuildProposal(t *testing.T) {
+	chain := cosmos.NewCosmosChain(t.Name(), ibc.ChainConfig{Bech32Prefix: "cosmos"}, 1, 0, zap.NewNop())
+
+	authority, err := chain.GovModuleAddress()
+	require.NoError(t, err)
+	require.Equal(t, "cosmos10d07y265gmmuvt4z0w9aw880jnsr700j6zn9kn", authority)
+
+	msg := &upgradetypes.MsgSoftwareUpgrade{
+		Authority: authority,
+		Plan:      upgradetypes.Plan{Name: "v2", Height: 100},
+	}
+	prop, err := chain.BuildProposal([]sdk.Msg{msg}, "ipfs://meta", "10000000uatom", "Upgrade", "Upgrade to v2")
+	require.NoError(t, err)
+
+	bz, err := json.Marshal(prop)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"messages": [{
+			"@type": "/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade",
+			"authority": "cosmos10d07y265gmmuvt4z0w9aw880jnsr700j6zn9kn",
+			"plan": {"name": "v2", "time": "0001-01-01T00:00:00Z", "height": "100", "info": "", "upgraded_client_state": null}
+		}],
+		"metadata": "ipfs://meta",
+		"deposit": "10000000uatom",
+		"title": "Upgrade",
+		"summary": "Upgrade to v2"
+	}`, string(bz))
+}
+
+func TestBuildChannelUpgradeProposal(t *testing.T) {
+	chain := cosmos.NewCosmosChain(t.Name(), ibc.ChainConfig{Bech32Prefix: "cosmos"}, 1, 0, zap.NewNop())
+
+	fields := cosmos.ChannelUpgradeFields{
+		Ordering:       chantypes.UNORDERED,
+		ConnectionHops: []string{"connection-0"},
+		Version:        `{"fee_version":"ics29-1","app_version":"ics20-1"}`,
+	}
+	prop, err := chain.BuildChannelUpgradeProposal("transfer", "channel-0", fields, "10000000uatom", "Fee", "Add fees to channel-0")
+	require.NoError(t, err)
+
+	// The message must be encoded as by the types of ibc-go v8.1, see testdata/channelupgrade/gen.
+	want, err := os.ReadFile(filepath.Join("testdata", "channelupgrade", "msg_channel_upgrade_init.json"))
+	require.NoError(t, err)
+	require.Len(t, prop.Messages, 1)
+	require.JSONEq(t, string(want), string(prop.Messages[0]))
+
+	bz, err := json.Marshal(prop)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"messages": [`+string(want)+`],
+		"metadata": "",
+		"deposit": "10000000uatom",
+		"title": "Fee",
+		"summary": "Add fees to channel-0"
+	}`, string(bz))
+
+	fields.Version = ""
+	_, err = chain.BuildChannelUpgradeProposal("transfer", "channel-0", fields, "10000000uatom", "Fee", "")
+	require.ErrorContains(t, err, "requires a version")
+}
@@ -0,0 +1,44 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// ibcHookSenderPrefix is the address derivation prefix of the ibc-hooks intermediary senders.
+const ibcHookSenderPrefix = "ibc-wasm-hook-intermediary"
+
+// SendIBCHookTransfer sends an ICS-20 transfer to the contract amount.Address on a chain with the ibc-hooks middleware,
+// with a memo that executes msg on the contract with the transferred funds.
+// The memo is validated before sending, and replaces options.Memo.
+//
+// If the contract call fails, the receiving chain acknowledges the packet with an error and the sender is refunded.
+// The contract sees the transfer coming from IBCHookSender rather than from the original sender.
+func (c *CosmosChain) SendIBCHookTransfer(
+	ctx context.Context,
+	channelID string,
+	keyName string,
+	amount ibc.WalletAmount,
+	msg any,
+	options ibc.TransferOptions,
+) (ibc.Tx, error) {
+	memo, err := ibc.WasmHookMemo(amount.Address, msg)
+	if err != nil {
+		return ibc.Tx{}, err
+	}
+	options.Memo = memo
+	return c.SendIBCTransfer(ctx, channelID, keyName, amount, options)
+}
+
+// IBCHookSender returns the address that ibc-hooks executes wasm hooks from, on behalf of originalSender on the
+// counterparty chain. channelID is the channel on the receiving chain, i.e. the destination channel of the packet,
+// and bech32Prefix the receiving chain's prefix.
+// Contracts track funds and state of hook calls, e.g. for queries by sender, under this address.
+func IBCHookSender(channelID, originalSender, bech32Prefix string) (string, error) {
+	h := address.Hash(ibcHookSenderPrefix, []byte(fmt.Sprintf("%s/%s", channelID, originalSender)))
+	return sdk.Bech32ifyAddressBytes(bech32Prefix, sdk.AccAddress(h))
+}
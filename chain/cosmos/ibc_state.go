@@ -0,0 +1,85 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	conntypes "github.com/cosmos/ibc-go/v6/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// QueryChannels returns all channel ends of the chain, as the relayers' GetChannels does,
+// but directly from the chain state and with the height at which they were queried.
+func (c *CosmosChain) QueryChannels(ctx context.Context) ([]ibc.ChannelOutput, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	queryClient := chantypes.NewQueryClient(conn)
+	var (
+		channels []ibc.ChannelOutput
+		nextKey  []byte
+	)
+	for {
+		res, err := queryClient.Channels(ctx, &chantypes.QueryChannelsRequest{
+			Pagination: &query.PageRequest{Key: nextKey},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("query channels: %w", err)
+		}
+		for _, ch := range res.Channels {
+			out, err := ibc.ChannelOutputFromProto(*ch, res.Height.RevisionHeight)
+			if err != nil {
+				return nil, fmt.Errorf("channel %s/%s: %w", ch.PortId, ch.ChannelId, err)
+			}
+			channels = append(channels, out)
+		}
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			break
+		}
+		nextKey = res.Pagination.NextKey
+	}
+	return channels, nil
+}
+
+// QueryConnections returns all connection ends of the chain, as the relayers' GetConnections does,
+// but directly from the chain state and with the height at which they were queried.
+func (c *CosmosChain) QueryConnections(ctx context.Context) (ibc.ConnectionOutputs, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	queryClient := conntypes.NewQueryClient(conn)
+	var (
+		conns   ibc.ConnectionOutputs
+		nextKey []byte
+	)
+	for {
+		res, err := queryClient.Connections(ctx, &conntypes.QueryConnectionsRequest{
+			Pagination: &query.PageRequest{Key: nextKey},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("query connections: %w", err)
+		}
+		for _, c := range res.Connections {
+			out, err := ibc.ConnectionOutputFromProto(*c, res.Height.RevisionHeight)
+			if err != nil {
+				return nil, fmt.Errorf("connection %s: %w", c.Id, err)
+			}
+			conns = append(conns, out)
+		}
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			break
+		}
+		nextKey = res.Pagination.NextKey
+	}
+	return conns, nil
+}
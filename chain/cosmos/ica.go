@@ -0,0 +1,213 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	icahosttypes "github.com/cosmos/ibc-go/v6/modules/apps/27-interchain-accounts/host/types"
+	icatypes "github.com/cosmos/ibc-go/v6/modules/apps/27-interchain-accounts/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+)
+
+const (
+	// icaChannelOpenMaxBlocks is how many blocks ReopenICAChannel waits for the channel handshake to complete.
+	icaChannelOpenMaxBlocks = 30
+
+	// ICAEncodingProtobuf is the ICS-27 channel version encoding for protobuf encoded transactions.
+	ICAEncodingProtobuf = ibc.ICAEncodingProtobuf
+	// ICAEncodingProto3JSON is the ICS-27 channel version encoding for proto3 JSON encoded transactions.
+	ICAEncodingProto3JSON = ibc.ICAEncodingProto3JSON
+)
+
+// BuildICATx serializes msgs into the ICS-27 CosmosTx payload carried in the data
+// of an interchain account packet, i.e. the payload of a controller MsgSendTx.
+// The encoding must match the one negotiated in the channel version, either
+// ICAEncodingProtobuf or ICAEncodingProto3JSON.
+// For proto3json, the message types must be registered with DefaultEncoding.
+func BuildICATx(msgs []sdk.Msg, encoding string) ([]byte, error) {
+	cdc := codec.NewProtoCodec(DefaultEncoding().InterfaceRegistry)
+
+	switch encoding {
+	case ICAEncodingProtobuf:
+		protoMsgs := make([]proto.Message, len(msgs))
+		for i, msg := range msgs {
+			protoMsgs[i] = msg
+		}
+		bz, err := icatypes.SerializeCosmosTx(cdc, protoMsgs)
+		if err != nil {
+			return nil, fmt.Errorf("serialize cosmos tx: %w", err)
+		}
+		return bz, nil
+	case ICAEncodingProto3JSON:
+		anys := make([]*codectypes.Any, len(msgs))
+		for i, msg := range msgs {
+			a, err := codectypes.NewAnyWithValue(msg)
+			if err != nil {
+				return nil, fmt.Errorf("pack message %d: %w", i, err)
+			}
+			anys[i] = a
+		}
+		bz, err := cdc.MarshalJSON(&icatypes.CosmosTx{Messages: anys})
+		if err != nil {
+			return nil, fmt.Errorf("marshal cosmos tx json: %w", err)
+		}
+		return bz, nil
+	default:
+		return nil, fmt.Errorf("unsupported interchain account encoding %q", encoding)
+	}
+}
+
+// BuildICAPacketData returns the JSON of the ICS-27 packet data executing msgs on the host chain,
+// as submitted with the send-tx command of the native ICS-27 controller, see (*ChainNode).SendICATx.
+// The messages are serialized with BuildICATx in the given encoding.
+func BuildICAPacketData(msgs []sdk.Msg, encoding, memo string) ([]byte, error) {
+	data, err := BuildICATx(msgs, encoding)
+	if err != nil {
+		return nil, err
+	}
+	bz, err := icatypes.ModuleCdc.MarshalJSON(&icatypes.InterchainAccountPacketData{
+		Type: icatypes.EXECUTE_TX,
+		Data: data,
+		Memo: memo,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal interchain account packet data: %w", err)
+	}
+	return bz, nil
+}
+
+// ICAControllerVersion returns the ICS-27 channel version, requesting the encoding of transactions,
+// with which an interchain account is registered on the controller connection controllerConnectionID,
+// whose counterparty on the host chain is hostConnectionID.
+// See (*ChainNode).RegisterICAControllerWithVersion.
+func ICAControllerVersion(controllerConnectionID, hostConnectionID, encoding string) (string, error) {
+	metadata := icatypes.NewMetadata(icatypes.Version, controllerConnectionID, hostConnectionID, "", encoding, icatypes.TxTypeSDKMultiMsg)
+	bz, err := icatypes.ModuleCdc.MarshalJSON(&metadata)
+	if err != nil {
+		return "", fmt.Errorf("marshal interchain account metadata: %w", err)
+	}
+	return string(bz), nil
+}
+
+// ReopenICAChannel opens a new channel for the interchain account owned by keyName on connectionID,
+// e.g. after its ordered channel was closed by a packet timeout, and returns the new controller channel ID.
+// It re-sends the account registration, through the intertx module if the chain has it and
+// through the native ICS-27 controller messages otherwise, then polls the relayer's view of the
+// channels until the new channel is open.
+// The relayer must be running on pathName so that it completes the channel handshake.
+// Reopening cannot be driven by the relayer alone, because the ICS-27 controller only accepts
+// channel handshakes initiated by its own account registration.
+// An error is returned if the interchain account address changed.
+func ReopenICAChannel(ctx context.Context, c *CosmosChain, r ibc.Relayer, rep ibc.RelayerExecReporter, pathName, connectionID, keyName string) (string, error) {
+	tn := c.getFullNode()
+
+	ownerBytes, err := c.GetAddress(ctx, keyName)
+	if err != nil {
+		return "", fmt.Errorf("get address of %s: %w", keyName, err)
+	}
+	owner, err := sdk.Bech32ifyAddressBytes(c.Config().Bech32Prefix, ownerBytes)
+	if err != nil {
+		return "", err
+	}
+
+	register, query := tn.RegisterICA, tn.QueryICA
+	icaAddr, err := query(ctx, connectionID, owner)
+	if err != nil {
+		register, query = tn.RegisterICAController, tn.QueryICAController
+		icaAddr, err = query(ctx, connectionID, owner)
+	}
+	if err != nil {
+		return "", fmt.Errorf("query interchain account of %s on %s: %w", owner, connectionID, err)
+	}
+
+	portID, err := icatypes.NewControllerPortID(owner)
+	if err != nil {
+		return "", err
+	}
+
+	chainID := c.Config().ChainID
+	channels, err := r.GetChannels(ctx, rep, chainID)
+	if err != nil {
+		return "", fmt.Errorf("get channels on %s: %w", chainID, err)
+	}
+	existing := make(map[string]bool)
+	for _, ch := range channels {
+		if ch.PortID == portID {
+			existing[ch.ChannelID] = true
+		}
+	}
+
+	if _, err := register(ctx, keyName, connectionID); err != nil {
+		return "", fmt.Errorf("register interchain account of %s on %s: %w", owner, connectionID, err)
+	}
+
+	var channelID string
+	for i := 0; i < icaChannelOpenMaxBlocks && channelID == ""; i++ {
+		if err := testutil.WaitForBlocks(ctx, 1, c); err != nil {
+			return "", err
+		}
+		channels, err := r.GetChannels(ctx, rep, chainID)
+		if err != nil {
+			return "", fmt.Errorf("get channels on %s: %w", chainID, err)
+		}
+		for _, ch := range channels {
+			if ch.PortID == portID && !existing[ch.ChannelID] && ch.State == ibc.ChannelStateOpen {
+				channelID = ch.ChannelID
+				break
+			}
+		}
+	}
+	if channelID == "" {
+		return "", fmt.Errorf("no new open channel on %s port %s after %d blocks, is the relayer running on path %s?", chainID, portID, icaChannelOpenMaxBlocks, pathName)
+	}
+
+	newICAAddr, err := query(ctx, connectionID, owner)
+	if err != nil {
+		return "", fmt.Errorf("query interchain account of %s on %s: %w", owner, connectionID, err)
+	}
+	if newICAAddr != icaAddr {
+		return "", fmt.Errorf("interchain account of %s changed from %s to %s after reopening channel", owner, icaAddr, newICAAddr)
+	}
+
+	return channelID, nil
+}
+
+// QueryICAHostParams returns the allow-list of the interchain accounts host module of the chain,
+// the type URLs of the messages interchain accounts may execute, e.g. /cosmos.bank.v1beta1.MsgSend, or * for all.
+// The host module rejects other messages with an error acknowledgement.
+func (c *CosmosChain) QueryICAHostParams(ctx context.Context) ([]string, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := icahosttypes.NewQueryClient(conn).Params(ctx, &icahosttypes.QueryParamsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("query interchain accounts host params: %w", err)
+	}
+	return res.Params.AllowMessages, nil
+}
+
+// ICAHostAllowListProposal submits a param-change governance proposal setting the allow-list
+// of the interchain accounts host module to allowMessages, see QueryICAHostParams.
+// The host params must be managed by the params module, as up to ibc-go v7.
+func (c *CosmosChain) ICAHostAllowListProposal(ctx context.Context, keyName, deposit string, allowMessages ...string) (TxProposal, error) {
+	if allowMessages == nil {
+		allowMessages = []string{}
+	}
+	return c.ParamChangeProposal(ctx, keyName, ParamChangeProposal{
+		Deposit:     deposit,
+		Title:       "Interchain accounts host allow-list",
+		Description: fmt.Sprintf("Allow interchain accounts to execute %v", allowMessages),
+		Changes: []ParamChange{
+			{Subspace: icahosttypes.SubModuleName, Key: string(icahosttypes.KeyAllowMessages), Value: allowMessages},
+		},
+	})
+}
@@ -0,0 +1,89 @@
+package cosmos_test
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	icatypes "github.com/cosmos/ibc-go/v6/modules/apps/27-interchain-accounts/types"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildICATx(t *testing.T) {
+	msgs := []sdk.Msg{
+		&banktypes.MsgSend{
+			FromAddress: "cosmos1from",
+			ToAddress:   "cosmos1to",
+			Amount:      sdk.NewCoins(sdk.NewInt64Coin("uatom", 100)),
+		},
+		&banktypes.MsgSend{
+			FromAddress: "cosmos1from",
+			ToAddress:   "cosmos1other",
+			Amount:      sdk.NewCoins(sdk.NewInt64Coin("uatom", 5)),
+		},
+	}
+	cdc := codec.NewProtoCodec(cosmos.DefaultEncoding().InterfaceRegistry)
+
+	t.Run("proto3", func(t *testing.T) {
+		bz, err := cosmos.BuildICATx(msgs, cosmos.ICAEncodingProtobuf)
+		require.NoError(t, err)
+
+		got, err := icatypes.DeserializeCosmosTx(cdc, bz)
+		require.NoError(t, err)
+		require.Equal(t, msgs, got)
+	})
+
+	t.Run("proto3json", func(t *testing.T) {
+		bz, err := cosmos.BuildICATx(msgs, cosmos.ICAEncodingProto3JSON)
+		require.NoError(t, err)
+		require.Contains(t, string(bz), `"@type":"/cosmos.bank.v1beta1.MsgSend"`)
+
+		var tx icatypes.CosmosTx
+		require.NoError(t, cdc.UnmarshalJSON(bz, &tx))
+		require.Len(t, tx.Messages, 2)
+		for i, a := range tx.Messages {
+			var msg sdk.Msg
+			require.NoError(t, cdc.UnpackAny(a, &msg))
+			require.Equal(t, msgs[i], msg)
+		}
+	})
+
+	t.Run("unsupported encoding", func(t *testing.T) {
+		_, err := cosmos.BuildICATx(msgs, "amino")
+		require.Error(t, err)
+	})
+}
+
+func TestBuildICAPacketData(t *testing.T) {
+	msgs := []sdk.Msg{&banktypes.MsgSend{
+		FromAddress: "cosmos1from",
+		ToAddress:   "cosmos1to",
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin("uatom", 100)),
+	}}
+
+	for _, encoding := range []string{cosmos.ICAEncodingProtobuf, cosmos.ICAEncodingProto3JSON} {
+		bz, err := cosmos.BuildICAPacketData(msgs, encoding, "memo")
+		require.NoError(t, err, encoding)
+
+		got, err := ibc.DecodeICAPacket(bz)
+		require.NoError(t, err, encoding)
+		require.Equal(t, ibc.ICAPacketData{
+			Type:     icatypes.EXECUTE_TX,
+			Encoding: encoding,
+			Msgs:     msgs,
+			Memo:     "memo",
+		}, got)
+	}
+}
+
+func TestICAControllerVersion(t *testing.T) {
+	version, err := cosmos.ICAControllerVersion("connection-0", "connection-1", cosmos.ICAEncodingProto3JSON)
+	require.NoError(t, err)
+
+	var metadata icatypes.Metadata
+	require.NoError(t, icatypes.ModuleCdc.UnmarshalJSON([]byte(version), &metadata))
+	require.Equal(t, icatypes.NewMetadata(icatypes.Version, "connection-0", "connection-1", "", cosmos.ICAEncodingProto3JSON, icatypes.TxTypeSDKMultiMsg), metadata)
+}
@@ -0,0 +1,217 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/v6/modules/core/02-client/types"
+	ibctm "github.com/cosmos/ibc-go/v6/modules/light-clients/07-tendermint"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// submitMisbehaviourGas is the gas limit of the transaction of SubmitMisbehaviour,
+// which verifies the commits of both headers.
+const submitMisbehaviourGas = 1_000_000
+
+// consensusPrivKey returns the consensus private key of the validator tn, with which it signs blocks.
+func (tn *ChainNode) consensusPrivKey(ctx context.Context) (crypto.PrivKey, error) {
+	fr := dockerutil.NewFileRetriever(tn.logger(), tn.DockerClient, tn.TestName)
+	keybz, err := fr.SingleFileContent(ctx, tn.VolumeName, "config/priv_validator_key.json")
+	if err != nil {
+		return nil, fmt.Errorf("getting priv_validator_key.json content: %w", err)
+	}
+	var key struct {
+		PrivKey crypto.PrivKey `json:"priv_key"`
+	}
+	if err := tmjson.Unmarshal(keybz, &key); err != nil {
+		return nil, fmt.Errorf("unmarshaling priv_validator_key.json: %w", err)
+	}
+	return key.PrivKey, nil
+}
+
+// validatorSet returns the validator set of the chain at height.
+func (c *CosmosChain) validatorSet(ctx context.Context, height int64) (*tmtypes.ValidatorSet, error) {
+	var (
+		vals    []*tmtypes.Validator
+		page    = 1
+		perPage = 100
+	)
+	for {
+		res, err := c.getFullNode().Client.Validators(ctx, &height, &page, &perPage)
+		if err != nil {
+			return nil, fmt.Errorf("query validators at height %d: %w", height, err)
+		}
+		vals = append(vals, res.Validators...)
+		if len(res.Validators) == 0 || len(vals) >= res.Total {
+			break
+		}
+		page++
+	}
+	return tmtypes.NewValidatorSet(vals), nil
+}
+
+// header returns the IBC header of the block of the chain at height,
+// as a light client with a consensus state at trustedHeight verifies it.
+func (c *CosmosChain) header(ctx context.Context, height int64, trustedHeight clienttypes.Height) (*ibctm.Header, error) {
+	res, err := c.getFullNode().Client.Commit(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("query commit at height %d: %w", height, err)
+	}
+	valSet, err := c.validatorSet(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	// The consensus state at trustedHeight commits to the validators of the next block.
+	trustedVals, err := c.validatorSet(ctx, int64(trustedHeight.RevisionHeight)+1)
+	if err != nil {
+		return nil, err
+	}
+
+	valSetProto, err := valSet.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	trustedValsProto, err := trustedVals.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return &ibctm.Header{
+		SignedHeader:      res.SignedHeader.ToProto(),
+		ValidatorSet:      valSetProto,
+		TrustedHeight:     trustedHeight,
+		TrustedValidators: trustedValsProto,
+	}, nil
+}
+
+// ForkMisbehaviour makes the validators of the chain double-sign a header conflicting with that of a committed block,
+// as if the chain forked, and returns the resulting misbehaviour of the chain for the light client with clientID
+// of another chain, whose consensus state at trustedHeight is trusted to verify it. See SubmitMisbehaviour.
+//
+// The conflicting header differs by its app hash from that of the latest block after trustedHeight.
+// It is signed with the consensus keys of the validators of the chain, which must hold more than 2/3 of the voting power.
+func (c *CosmosChain) ForkMisbehaviour(ctx context.Context, clientID string, trustedHeight clienttypes.Height) (*ibctm.Misbehaviour, error) {
+	latest, err := c.Height(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The commit of the latest block may not be final yet.
+	height := int64(latest) - 1
+	if height <= int64(trustedHeight.RevisionHeight) {
+		return nil, fmt.Errorf("chain height %d is not past trusted height %s yet", height, trustedHeight)
+	}
+
+	header1, err := c.header(ctx, height, trustedHeight)
+	if err != nil {
+		return nil, err
+	}
+	header2, err := c.header(ctx, height, trustedHeight)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.forkHeader(ctx, header2); err != nil {
+		return nil, err
+	}
+	return ibctm.NewMisbehaviour(clientID, header1, header2), nil
+}
+
+// forkHeader changes the app hash of header and replaces its commit by one signed by the validators of the chain.
+func (c *CosmosChain) forkHeader(ctx context.Context, header *ibctm.Header) error {
+	tmHeader, err := tmtypes.HeaderFromProto(header.Header)
+	if err != nil {
+		return err
+	}
+	tmHeader.AppHash = tmhash.Sum(append([]byte("fork"), tmHeader.AppHash...))
+
+	valSet, err := tmtypes.ValidatorSetFromProto(header.ValidatorSet)
+	if err != nil {
+		return err
+	}
+	keys := make(map[string]crypto.PrivKey)
+	for _, v := range c.Validators {
+		key, err := v.consensusPrivKey(ctx)
+		if err != nil {
+			return fmt.Errorf("consensus key of %s: %w", v.Name(), err)
+		}
+		keys[key.PubKey().Address().String()] = key
+	}
+
+	commit := header.Commit
+	blockID := tmtypes.BlockID{
+		Hash: tmHeader.Hash(),
+		PartSetHeader: tmtypes.PartSetHeader{
+			Total: commit.BlockID.PartSetHeader.Total,
+			Hash:  commit.BlockID.PartSetHeader.Hash,
+		},
+	}
+	sigs := make([]tmtypes.CommitSig, len(valSet.Validators))
+	for i, val := range valSet.Validators {
+		key, ok := keys[val.Address.String()]
+		if !ok {
+			sigs[i] = tmtypes.NewCommitSigAbsent()
+			continue
+		}
+		vote := &tmtypes.Vote{
+			Type:             tmproto.PrecommitType,
+			Height:           tmHeader.Height,
+			Round:            commit.Round,
+			BlockID:          blockID,
+			Timestamp:        tmHeader.Time,
+			ValidatorAddress: val.Address,
+			ValidatorIndex:   int32(i),
+		}
+		sig, err := key.Sign(tmtypes.VoteSignBytes(tmHeader.ChainID, vote.ToProto()))
+		if err != nil {
+			return fmt.Errorf("sign conflicting vote of validator %s: %w", val.Address, err)
+		}
+		sigs[i] = tmtypes.NewCommitSigForBlock(sig, val.Address, vote.Timestamp)
+	}
+	forked := tmtypes.NewCommit(tmHeader.Height, commit.Round, blockID, sigs)
+	if err := valSet.VerifyCommit(tmHeader.ChainID, blockID, tmHeader.Height, forked); err != nil {
+		return fmt.Errorf("validators of the chain cannot sign a conflicting header: %w", err)
+	}
+
+	header.Header = tmHeader.ToProto()
+	header.Commit = forked.ToProto()
+	return nil
+}
+
+// SubmitMisbehaviour submits misbehaviour of the chain tracked by its light client, signed by wallet,
+// which freezes the client, e.g. misbehaviour of the counterparty chain from its ForkMisbehaviour.
+func (c *CosmosChain) SubmitMisbehaviour(ctx context.Context, wallet ibc.Wallet, misbehaviour *ibctm.Misbehaviour) error {
+	if misbehaviour == nil {
+		return errors.New("nil misbehaviour")
+	}
+	msg, err := clienttypes.NewMsgSubmitMisbehaviour(misbehaviour.ClientId, misbehaviour, wallet.FormattedAddress())
+	if err != nil {
+		return fmt.Errorf("failed to build misbehaviour message: %w", err)
+	}
+	fees, err := gasFees(c.cfg.GasPrices, submitMisbehaviourGas)
+	if err != nil {
+		return err
+	}
+	if _, err := c.SendTxAndWait(ctx, wallet, submitMisbehaviourGas, fees, msg); err != nil {
+		return fmt.Errorf("submit misbehaviour of client %s: %w", misbehaviour.ClientId, err)
+	}
+	return nil
+}
+
+// gasFees returns the fees paying gas at gasPrices, e.g. 0.01uatom, rounded up.
+func gasFees(gasPrices string, gas uint64) (sdk.Coins, error) {
+	prices, err := sdk.ParseDecCoins(gasPrices)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas prices %q: %w", gasPrices, err)
+	}
+	fees := sdk.NewCoins()
+	for _, p := range prices {
+		fees = fees.Add(sdk.NewCoin(p.Denom, p.Amount.MulInt64(int64(gas)).Ceil().TruncateInt()))
+	}
+	return fees, nil
+}
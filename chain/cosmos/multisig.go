@@ -0,0 +1,193 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
+)
+
+// multisigKeyName returns the name of the keyring entry of the multisig of keyNames with threshold.
+func multisigKeyName(keyNames []string, threshold int) string {
+	return fmt.Sprintf("multisig-%d-of-%s", threshold, strings.Join(keyNames, "-"))
+}
+
+// CreateMultisig adds a multisig key of the keys keyNames requiring threshold signatures to the node's keyring,
+// and returns the multisig's bech32 account address.
+// The address only exists on chain once it received funds.
+func (tn *ChainNode) CreateMultisig(ctx context.Context, keyNames []string, threshold int) (string, error) {
+	if threshold < 1 || threshold > len(keyNames) {
+		return "", fmt.Errorf("invalid multisig threshold %d for %d keys", threshold, len(keyNames))
+	}
+	name := multisigKeyName(keyNames, threshold)
+
+	tn.lock.Lock()
+	_, _, err := tn.ExecBin(ctx,
+		"keys", "add", name,
+		"--multisig", strings.Join(keyNames, ","),
+		"--multisig-threshold", fmt.Sprint(threshold),
+		"--keyring-backend", keyring.BackendTest,
+	)
+	tn.lock.Unlock()
+	if err != nil {
+		return "", fmt.Errorf("failed to add multisig key %s: %w", name, err)
+	}
+
+	return tn.AccountKeyBech32(ctx, name)
+}
+
+// ExecMultisigTx executes a transaction from the multisig at multisigAddress, signed by the keys signers,
+// waits for 2 blocks if successful, then returns the tx hash.
+// The multisig and signer keys must be in the node's keyring, e.g. a multisig from CreateMultisig.
+//
+// The transaction is generated, signed by each signer, combined into the multisig signature and broadcast,
+// like with the generate-only, sign, multisign and broadcast commands.
+// The combined signature is checked to meet the multisig's threshold before broadcasting.
+func (tn *ChainNode) ExecMultisigTx(ctx context.Context, multisigAddress string, signers []string, command ...string) (string, error) {
+	name, err := tn.keyNameByAddress(ctx, multisigAddress)
+	if err != nil {
+		return "", err
+	}
+
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	prefix := "multisig-" + dockerutil.RandLowerCaseLetterString(8)
+	writeFile := func(suffix string, content []byte) (string, error) {
+		file := prefix + "-" + suffix + ".json"
+		if err := fw.WriteFile(ctx, tn.VolumeName, file, content); err != nil {
+			return "", fmt.Errorf("writing %s to docker volume: %w", file, err)
+		}
+		return path.Join(tn.HomeDir(), file), nil
+	}
+
+	unsigned, _, err := tn.Exec(ctx, append(tn.TxCommand(multisigAddress, command...), "--generate-only"), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate multisig tx: %w", err)
+	}
+	unsignedPath, err := writeFile("unsigned", unsigned)
+	if err != nil {
+		return "", err
+	}
+
+	sigPaths := make([]string, len(signers))
+	for i, signer := range signers {
+		sig, _, err := tn.Exec(ctx, tn.NodeCommand(
+			"tx", "sign", unsignedPath,
+			"--multisig", multisigAddress,
+			"--from", signer,
+			"--sign-mode", "amino-json",
+			"--keyring-backend", keyring.BackendTest,
+		), nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to sign multisig tx by %s: %w", signer, err)
+		}
+		if sigPaths[i], err = writeFile(signer, sig); err != nil {
+			return "", err
+		}
+	}
+
+	signed, _, err := tn.Exec(ctx, tn.NodeCommand(append(
+		[]string{"tx", "multisign", unsignedPath, name},
+		append(sigPaths, "--keyring-backend", keyring.BackendTest)...,
+	)...), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to combine multisig signatures: %w", err)
+	}
+	if err := checkMultisigThreshold(signed); err != nil {
+		return "", err
+	}
+	signedPath, err := writeFile("signed", signed)
+	if err != nil {
+		return "", err
+	}
+
+	tn.lock.Lock()
+	txHash, err := tn.broadcastTxCommand(ctx, tn.NodeCommand("tx", "broadcast", signedPath, "--output", "json"))
+	tn.lock.Unlock()
+	if err != nil {
+		return txHash, err
+	}
+	return tn.awaitTx(ctx, txHash)
+}
+
+// keyNameByAddress returns the name of the key with the bech32 account address in the node's keyring.
+func (tn *ChainNode) keyNameByAddress(ctx context.Context, address string) (string, error) {
+	stdout, _, err := tn.ExecBin(ctx, "keys", "show", address, "--keyring-backend", keyring.BackendTest, "--output", "json")
+	if err != nil {
+		return "", fmt.Errorf("failed to show key of %s: %w", address, err)
+	}
+	var key struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(stdout, &key); err != nil {
+		return "", fmt.Errorf("failed to unmarshal key of %s: %w", address, err)
+	}
+	return key.Name, nil
+}
+
+// multisigTx is the part of a signed tx JSON describing a multisig signer's signature.
+type multisigTx struct {
+	AuthInfo struct {
+		SignerInfos []struct {
+			PublicKey struct {
+				Type      string `json:"@type"`
+				Threshold int    `json:"threshold"`
+			} `json:"public_key"`
+			ModeInfo struct {
+				Multi *struct {
+					ModeInfos []json.RawMessage `json:"mode_infos"`
+				} `json:"multi"`
+			} `json:"mode_info"`
+		} `json:"signer_infos"`
+	} `json:"auth_info"`
+	Signatures []string `json:"signatures"`
+}
+
+// checkMultisigThreshold parses the combined signature of a signed multisig tx JSON
+// and checks that it has at least the multisig's threshold of signatures.
+func checkMultisigThreshold(signedTx []byte) error {
+	var tx multisigTx
+	if err := json.Unmarshal(signedTx, &tx); err != nil {
+		return fmt.Errorf("failed to unmarshal signed multisig tx: %w", err)
+	}
+	if len(tx.AuthInfo.SignerInfos) != 1 || len(tx.Signatures) != 1 {
+		return fmt.Errorf("signed multisig tx has %d signers and %d signatures, expected 1", len(tx.AuthInfo.SignerInfos), len(tx.Signatures))
+	}
+	info := tx.AuthInfo.SignerInfos[0]
+	if info.ModeInfo.Multi == nil {
+		return fmt.Errorf("signer of multisig tx is not a multisig but %s", info.PublicKey.Type)
+	}
+
+	bz, err := base64.StdEncoding.DecodeString(tx.Signatures[0])
+	if err != nil {
+		return fmt.Errorf("invalid multisig signature encoding: %w", err)
+	}
+	var sig cryptotypes.MultiSignature
+	if err := sig.Unmarshal(bz); err != nil {
+		return fmt.Errorf("failed to decode multisig signature: %w", err)
+	}
+	if len(sig.Signatures) != len(info.ModeInfo.Multi.ModeInfos) {
+		return fmt.Errorf("multisig signature has %d signatures for %d sign modes", len(sig.Signatures), len(info.ModeInfo.Multi.ModeInfos))
+	}
+	if len(sig.Signatures) < info.PublicKey.Threshold {
+		return fmt.Errorf("multisig signature has %d signatures, below threshold %d", len(sig.Signatures), info.PublicKey.Threshold)
+	}
+	return nil
+}
+
+// CreateMultisig adds a multisig key of the keys keyNames requiring threshold signatures,
+// and returns the multisig's bech32 account address. See ChainNode.CreateMultisig.
+func (c *CosmosChain) CreateMultisig(ctx context.Context, keyNames []string, threshold int) (string, error) {
+	return c.getFullNode().CreateMultisig(ctx, keyNames, threshold)
+}
+
+// ExecMultisigTx executes a transaction from the multisig at multisigAddress, signed by the keys signers,
+// then returns the tx hash. See ChainNode.ExecMultisigTx.
+func (c *CosmosChain) ExecMultisigTx(ctx context.Context, multisigAddress string, signers []string, command ...string) (string, error) {
+	return c.getFullNode().ExecMultisigTx(ctx, multisigAddress, signers, command...)
+}
@@ -0,0 +1,150 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/x/nft"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// NFTTransferPort is the port bound by the ICS-721 nft-transfer module.
+const NFTTransferPort = "nft-transfer"
+
+// NFT is a non-fungible token as returned by the nft module queries.
+type NFT struct {
+	ClassID string
+	ID      string
+	URI     string
+}
+
+// IssueNFTClass creates the NFT class classID, owned by keyName, in which tokens can then be minted.
+// Minting is not restricted to the owner.
+func (tn *ChainNode) IssueNFTClass(ctx context.Context, keyName, classID, name string) error {
+	_, err := tn.ExecTx(ctx, keyName,
+		"nft", "issue", classID,
+		"--name", name,
+		"--symbol", classID,
+		"--mint-restricted=false",
+		"--update-restricted=false",
+	)
+	return err
+}
+
+// MintNFT mints the token tokenID in the class classID, owned by recipient.
+func (tn *ChainNode) MintNFT(ctx context.Context, keyName, classID, tokenID, uri, recipient string) error {
+	_, err := tn.ExecTx(ctx, keyName,
+		"nft", "mint", classID, tokenID,
+		"--uri", uri,
+		"--recipient", recipient,
+	)
+	return err
+}
+
+// SendNFTTransfer sends the token tokenID of class classID to receiver over the ICS-721 channel channelID,
+// returning the transaction hash.
+func (tn *ChainNode) SendNFTTransfer(
+	ctx context.Context,
+	channelID string,
+	keyName string,
+	classID, tokenID string,
+	receiver string,
+	options ibc.TransferOptions,
+) (string, error) {
+	command := []string{
+		"nft-transfer", "transfer", NFTTransferPort, channelID,
+		receiver, classID, tokenID,
+	}
+	command = append(command, transferTimeoutFlags(options.Timeout)...)
+	if options.Memo != "" {
+		command = append(command, "--memo", options.Memo)
+	}
+	return tn.ExecTx(ctx, keyName, command...)
+}
+
+// IssueNFTClass creates the NFT class classID, owned by keyName.
+func (c *CosmosChain) IssueNFTClass(ctx context.Context, keyName, classID, name string) error {
+	return c.getFullNode().IssueNFTClass(ctx, keyName, classID, name)
+}
+
+// MintNFT mints the token tokenID in the class classID, owned by recipient.
+func (c *CosmosChain) MintNFT(ctx context.Context, keyName, classID, tokenID, uri, recipient string) error {
+	return c.getFullNode().MintNFT(ctx, keyName, classID, tokenID, uri, recipient)
+}
+
+// SendNFTTransfer sends the token tokenID of class classID to receiver over the ICS-721 channel channelID.
+// The returned transaction carries the sent packet, as with SendIBCTransfer.
+func (c *CosmosChain) SendNFTTransfer(
+	ctx context.Context,
+	channelID string,
+	keyName string,
+	classID, tokenID string,
+	receiver string,
+	options ibc.TransferOptions,
+) (tx ibc.Tx, _ error) {
+	txHash, err := c.getFullNode().SendNFTTransfer(ctx, channelID, keyName, classID, tokenID, receiver, options)
+	if err != nil {
+		return tx, fmt.Errorf("send nft transfer: %w", err)
+	}
+	txResp, err := c.getTransaction(txHash)
+	if err != nil {
+		return tx, fmt.Errorf("failed to get transaction %s: %w", txHash, err)
+	}
+	if txResp.Code != 0 {
+		return tx, fmt.Errorf("nft transfer transaction %s failed with code %d: %s", txHash, txResp.Code, txResp.RawLog)
+	}
+	tx.Height = uint64(txResp.Height)
+	tx.TxHash = txHash
+	// In cosmos, user is charged for entire gas requested, not the actual gas used.
+	tx.GasSpent = txResp.GasWanted
+
+	packets, err := sendPacketsFromEvents(txResp.Events)
+	if err != nil {
+		return tx, err
+	}
+	if len(packets) != 1 {
+		return tx, fmt.Errorf("nft transfer transaction %s sent %d packets, expected 1", txHash, len(packets))
+	}
+	tx.Packet = packets[0]
+
+	return tx, nil
+}
+
+// QueryNFTOwner returns the address owning the token tokenID of class classID.
+// For a token received over ICS-721, classID is the voucher class, see ibc.NFTVoucherClassID.
+func (c *CosmosChain) QueryNFTOwner(ctx context.Context, classID, tokenID string) (string, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	res, err := nft.NewQueryClient(conn).Owner(ctx, &nft.QueryOwnerRequest{ClassId: classID, Id: tokenID})
+	if err != nil {
+		return "", fmt.Errorf("query nft owner: %w", err)
+	}
+	return res.Owner, nil
+}
+
+// QueryNFTs returns the tokens owned by owner.
+// If classID is not empty, only tokens of that class are returned.
+func (c *CosmosChain) QueryNFTs(ctx context.Context, owner, classID string) ([]NFT, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := nft.NewQueryClient(conn).NFTs(ctx, &nft.QueryNFTsRequest{ClassId: classID, Owner: owner})
+	if err != nil {
+		return nil, fmt.Errorf("query nfts: %w", err)
+	}
+
+	out := make([]NFT, len(res.Nfts))
+	for i, n := range res.Nfts {
+		out[i] = NFT{ClassID: n.ClassId, ID: n.Id, URI: n.Uri}
+	}
+	return out, nil
+}
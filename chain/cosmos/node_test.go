@@ -1,12 +1,16 @@
 package cosmos_test
 
 import (
+	"context"
 	"strings"
 	"testing"
 
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
 )
 
 func TestCondenseMoniker_MiddleDetail(t *testing.T) {
@@ -27,3 +31,62 @@ func TestCondenseMoniker_Short(t *testing.T) {
 	const m = "my_moniker"
 	require.Equal(t, m, cosmos.CondenseMoniker(m))
 }
+
+func TestValidatePeerAddress(t *testing.T) {
+	const id = "0123456789abcdef0123456789abcdef01234567"
+
+	for _, peer := range []string{
+		id + "@external-node:26656",
+		id + "@192.168.1.10:26656",
+		id + "@[::1]:26656",
+	} {
+		require.NoError(t, cosmos.ValidatePeerAddress(peer), peer)
+	}
+
+	for _, tc := range []struct {
+		peer, errContains string
+	}{
+		{peer: "external-node:26656", errContains: "must be in the form"},
+		{peer: "0123@external-node:26656", errContains: "40 hex characters"},
+		{peer: strings.ToUpper(id) + "@external-node:26656", errContains: "lowercase hex"},
+		{peer: strings.Repeat("z", 40) + "@external-node:26656", errContains: "lowercase hex"},
+		{peer: id + "@external-node", errContains: "invalid address"},
+		{peer: id + "@:26656", errContains: "missing host"},
+		{peer: id + "@external-node:p2p", errContains: "invalid port"},
+	} {
+		require.ErrorContains(t, cosmos.ValidatePeerAddress(tc.peer), tc.errContains, tc.peer)
+	}
+}
+
+func TestValidateEnv(t *testing.T) {
+	for _, kv := range []string{"LOG_FORMAT=json", "EMPTY=", "OPTS=a=b"} {
+		require.NoError(t, cosmos.ValidateEnv(kv), kv)
+	}
+
+	require.ErrorContains(t, cosmos.ValidateEnv("LOG_FORMAT"), "must be in the form")
+	require.ErrorContains(t, cosmos.ValidateEnv("=json"), "invalid name")
+	require.ErrorContains(t, cosmos.ValidateEnv("LOG FORMAT=json"), "invalid name")
+}
+
+func TestParseSignMode(t *testing.T) {
+	for mode, want := range map[string]signing.SignMode{
+		"":                    signing.SignMode_SIGN_MODE_DIRECT,
+		ibc.SignModeDirect:    signing.SignMode_SIGN_MODE_DIRECT,
+		ibc.SignModeAminoJSON: signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON,
+	} {
+		got, err := cosmos.ParseSignMode(mode)
+		require.NoError(t, err, mode)
+		require.Equal(t, want, got, mode)
+	}
+
+	_, err := cosmos.ParseSignMode("textual")
+	require.ErrorContains(t, err, `unsupported sign mode "textual"`)
+}
+
+func TestStart_TooManyNodeEnvs(t *testing.T) {
+	c := cosmos.NewCosmosChain(t.Name(), ibc.ChainConfig{ChainID: "test-1", ValidatorEnv: [][]string{nil, nil, {"LOG_FORMAT=json"}}}, 2, 1, zaptest.NewLogger(t))
+	require.ErrorContains(t, c.Start(t.Name(), context.Background()), "got 3 validator environments for 2 validators")
+
+	c = cosmos.NewCosmosChain(t.Name(), ibc.ChainConfig{ChainID: "test-1", FullNodeEnv: [][]string{nil, nil}}, 2, 1, zaptest.NewLogger(t))
+	require.ErrorContains(t, c.Start(t.Name(), context.Background()), "got 2 full node environments for 1 full nodes")
+}
@@ -0,0 +1,179 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+)
+
+// PriceFeederProcessName is the process name of the validator sidecars added by AddPriceFeeders.
+const PriceFeederProcessName = "price-feeder"
+
+// priceFeederConfigFile is the path of the price feeder config file, relative to the validator's home directory.
+const priceFeederConfigFile = "price-feeder.toml"
+
+// PriceFeederConfig configures the price feeders of a chain with an x/oracle-style module, such as umee or ojo,
+// where validators vote on exchange rates every vote period.
+type PriceFeederConfig struct {
+	Image ibc.DockerImage
+
+	// Bin is the price feeder binary, started with the path of its config file as only argument.
+	// Defaults to price-feeder.
+	Bin string
+
+	// Env is the environment of the price feeders, e.g. the password of the keyring if the feeder requires one.
+	Env []string
+
+	// ConfigFile returns the content of the config file of the price feeder of a validator.
+	ConfigFile func(v PriceFeederValidator) ([]byte, error)
+}
+
+// PriceFeederValidator is the validator a price feeder votes for, as needed in the config file of the feeder.
+type PriceFeederValidator struct {
+	Node *ChainNode
+
+	ChainID string
+
+	// KeyName is the name of the validator key in the test keyring in KeyringDir,
+	// which the feeder shares with the validator.
+	KeyName    string
+	KeyringDir string
+
+	AccountAddress   string
+	ValidatorAddress string
+
+	// Addresses of the validator on the docker network.
+	GRPCAddress string // e.g. host:9090
+	RPCAddress  string // e.g. tcp://host:26657
+}
+
+// AddPriceFeeders runs a price feeder as a sidecar of each validator, signing votes with the validator key.
+// The feeders start after the chain, once the config file of each one is written to its validator's volume.
+// Use WaitForExchangeRates to wait for the first vote period with their votes to complete.
+// It must be called before the chain is initialized, i.e. before the interchain is built.
+func (c *CosmosChain) AddPriceFeeders(cfg PriceFeederConfig) error {
+	if cfg.ConfigFile == nil {
+		return errors.New("price feeder config file function must be set")
+	}
+	bin := cfg.Bin
+	if bin == "" {
+		bin = "price-feeder"
+	}
+	for _, s := range c.cfg.SidecarConfigs {
+		if s.ProcessName == PriceFeederProcessName {
+			return fmt.Errorf("chain %s already has price feeders", c.cfg.ChainID)
+		}
+	}
+
+	c.cfg.SidecarConfigs = append(c.cfg.SidecarConfigs, ibc.SidecarConfig{
+		ProcessName:      PriceFeederProcessName,
+		Image:            cfg.Image,
+		StartCmd:         []string{bin, path.Join(c.HomeDir(), priceFeederConfigFile)},
+		Env:              append([]string(nil), cfg.Env...),
+		ValidatorProcess: true,
+		ShareNodeVolume:  true,
+	})
+
+	c.AddSidecarSetup(PriceFeederProcessName, func(ctx context.Context, s *SidecarProcess) error {
+		v, err := priceFeederValidator(ctx, s.Node)
+		if err != nil {
+			return err
+		}
+		content, err := cfg.ConfigFile(v)
+		if err != nil {
+			return fmt.Errorf("price feeder config file: %w", err)
+		}
+		fw := dockerutil.NewFileWriter(s.logger(), s.DockerClient, s.TestName)
+		if err := fw.WriteFile(ctx, s.Node.VolumeName, priceFeederConfigFile, content); err != nil {
+			return fmt.Errorf("writing price feeder config file to docker volume: %w", err)
+		}
+		return nil
+	})
+	return nil
+}
+
+func priceFeederValidator(ctx context.Context, tn *ChainNode) (PriceFeederValidator, error) {
+	acc, err := tn.AccountKeyBech32(ctx, valKey)
+	if err != nil {
+		return PriceFeederValidator{}, err
+	}
+	val, err := tn.KeyBech32(ctx, valKey, "val")
+	if err != nil {
+		return PriceFeederValidator{}, err
+	}
+	return PriceFeederValidator{
+		Node:             tn,
+		ChainID:          tn.Chain.Config().ChainID,
+		KeyName:          valKey,
+		KeyringDir:       tn.HomeDir(),
+		AccountAddress:   acc,
+		ValidatorAddress: val,
+		GRPCAddress:      tn.HostName() + ":9090",
+		RPCAddress:       "tcp://" + tn.rpcAddress(),
+	}, nil
+}
+
+// QueryExchangeRates returns the exchange rates of the oracle module, set from the votes of the last vote period.
+func (c *CosmosChain) QueryExchangeRates(ctx context.Context) (sdk.DecCoins, error) {
+	stdout, _, err := c.getFullNode().ExecQuery(ctx, "oracle", "exchange-rates")
+	if err != nil {
+		return nil, fmt.Errorf("query exchange rates: %w", err)
+	}
+
+	var res struct {
+		ExchangeRates sdk.DecCoins `json:"exchange_rates"`
+	}
+	if err := json.Unmarshal(stdout, &res); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal exchange rates: %w", err)
+	}
+	return res.ExchangeRates, nil
+}
+
+// QueryOracleMissCounter returns the number of vote periods of the current slash window
+// in which the validator with the given valoper address did not vote valid exchange rates.
+func (c *CosmosChain) QueryOracleMissCounter(ctx context.Context, valoper string) (uint64, error) {
+	stdout, _, err := c.getFullNode().ExecQuery(ctx, "oracle", "miss-counter", valoper)
+	if err != nil {
+		return 0, fmt.Errorf("query miss counter: %w", err)
+	}
+
+	var res struct {
+		MissCounter string `json:"miss_counter"`
+	}
+	if err := json.Unmarshal(stdout, &res); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal miss counter: %w", err)
+	}
+	n, err := strconv.ParseUint(res.MissCounter, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid miss counter of validator %s: %w", valoper, err)
+	}
+	return n, nil
+}
+
+// WaitForExchangeRates blocks until the oracle module has exchange rates, i.e. until a vote period
+// with enough votes completed, and returns them. It returns an error if there are none after maxBlocks blocks.
+func (c *CosmosChain) WaitForExchangeRates(ctx context.Context, maxBlocks int) (sdk.DecCoins, error) {
+	for i := 0; ; i++ {
+		rates, err := c.QueryExchangeRates(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !rates.Empty() {
+			return rates, nil
+		}
+		if i >= maxBlocks {
+			return nil, fmt.Errorf("no exchange rates after %d blocks", maxBlocks)
+		}
+		if err := testutil.WaitForBlocks(ctx, 1, c); err != nil {
+			return nil, err
+		}
+	}
+}
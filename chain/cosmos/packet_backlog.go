@@ -0,0 +1,51 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+)
+
+// QueryPacketBacklog returns the number of packets sent from the channel with portID and channelID
+// whose commitments are still outstanding on the chain, split by their progress on the counterparty chain:
+// pendingSend packets have not been received by counterparty yet,
+// and the remaining pendingAck packets were received, but their acknowledgements or timeouts were not relayed back yet.
+//
+// Once all packets of the channel are relayed, both counts drain to zero.
+// The counterparty chain is required because the sending chain alone cannot tell received packets from unreceived ones.
+func (c *CosmosChain) QueryPacketBacklog(ctx context.Context, counterparty *CosmosChain, portID, channelID string) (pendingSend, pendingAck int, err error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer conn.Close()
+
+	src := packetQuerier{chantypes.NewQueryClient(conn), portID, channelID}
+	channel, err := src.Channel(ctx, &chantypes.QueryChannelRequest{PortId: portID, ChannelId: channelID})
+	if err != nil {
+		return 0, 0, fmt.Errorf("query channel %s/%s: %w", portID, channelID, err)
+	}
+
+	sequences, err := src.commitments(ctx)
+	if err != nil || len(sequences) == 0 {
+		return 0, 0, err
+	}
+
+	cpConn, err := counterparty.dialGRPC(counterparty.getFullNode().hostGRPCPort)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer cpConn.Close()
+
+	cp := channel.Channel.Counterparty
+	dst := packetQuerier{chantypes.NewQueryClient(cpConn), cp.PortId, cp.ChannelId}
+	unreceived, err := unrelayedPackets(ctx, src, dst, channel.Channel.Ordering)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w on %s", err, counterparty.Config().ChainID)
+	}
+
+	pendingSend = len(unreceived)
+	return pendingSend, len(sequences) - pendingSend, nil
+}
@@ -0,0 +1,237 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+
+	"github.com/strangelove-ventures/interchaintest/v6/acks"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+)
+
+// packetRelayGas is the gas of the MsgRecvPacket and MsgAcknowledgement transactions of RelayPacket,
+// which verify a proof of the counterparty and run the application callback.
+const packetRelayGas = 500_000
+
+// packetJSON is the proto JSON of a channel packet.
+type packetJSON struct {
+	Sequence           uint64     `json:"sequence,string"`
+	SourcePort         string     `json:"source_port"`
+	SourceChannel      string     `json:"source_channel"`
+	DestinationPort    string     `json:"destination_port"`
+	DestinationChannel string     `json:"destination_channel"`
+	Data               []byte     `json:"data"`
+	TimeoutHeight      heightJSON `json:"timeout_height"`
+	TimeoutTimestamp   uint64     `json:"timeout_timestamp,string"`
+}
+
+func packetToJSON(p ibc.Packet) (packetJSON, error) {
+	timeoutHeight, err := p.ParsedTimeoutHeight()
+	if err != nil {
+		return packetJSON{}, err
+	}
+	return packetJSON{
+		Sequence:           p.Sequence,
+		SourcePort:         p.SourcePort,
+		SourceChannel:      p.SourceChannel,
+		DestinationPort:    p.DestPort,
+		DestinationChannel: p.DestChannel,
+		Data:               p.Data,
+		TimeoutHeight:      heightJSON{RevisionNumber: timeoutHeight.RevisionNumber, RevisionHeight: timeoutHeight.RevisionHeight},
+		TimeoutTimestamp:   uint64(p.TimeoutTimestamp),
+	}, nil
+}
+
+// PacketProof is the proof of the commitment or the acknowledgement of a packet on a chain,
+// that the counterparty chain verifies when the packet or its acknowledgement is relayed.
+type PacketProof struct {
+	Proof []byte
+
+	// ProofHeight is the height of the consensus state of the counterparty client that the proof is verified against.
+	ProofHeight ibc.Height
+}
+
+func (p PacketProof) proofHeight() heightJSON {
+	return heightJSON{RevisionNumber: p.ProofHeight.RevisionNumber, RevisionHeight: p.ProofHeight.RevisionHeight}
+}
+
+// RecvMsg returns the MsgRecvPacket delivering packet, whose commitment on the sending chain p proves, signed by signer.
+func (p PacketProof) RecvMsg(packet ibc.Packet, signer string) (json.RawMessage, error) {
+	pj, err := packetToJSON(packet)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Type            string     `json:"@type"`
+		Packet          packetJSON `json:"packet"`
+		ProofCommitment []byte     `json:"proof_commitment"`
+		ProofHeight     heightJSON `json:"proof_height"`
+		Signer          string     `json:"signer"`
+	}{
+		Type:            "/ibc.core.channel.v1.MsgRecvPacket",
+		Packet:          pj,
+		ProofCommitment: p.Proof,
+		ProofHeight:     p.proofHeight(),
+		Signer:          signer,
+	})
+}
+
+// AckMsg returns the MsgAcknowledgement delivering the acknowledgement ack of packet,
+// which p proves on the receiving chain, signed by signer.
+func (p PacketProof) AckMsg(packet ibc.Packet, ack []byte, signer string) (json.RawMessage, error) {
+	pj, err := packetToJSON(packet)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Type            string     `json:"@type"`
+		Packet          packetJSON `json:"packet"`
+		Acknowledgement []byte     `json:"acknowledgement"`
+		ProofAcked      []byte     `json:"proof_acked"`
+		ProofHeight     heightJSON `json:"proof_height"`
+		Signer          string     `json:"signer"`
+	}{
+		Type:            "/ibc.core.channel.v1.MsgAcknowledgement",
+		Packet:          pj,
+		Acknowledgement: ack,
+		ProofAcked:      p.Proof,
+		ProofHeight:     p.proofHeight(),
+		Signer:          signer,
+	})
+}
+
+// queryPacketProof returns the proof at height of the packet state of kind, "packet-commitment" or "packet-ack",
+// of the packet with sequence on the channel end with portID and channelID.
+// See QueryChannelUpgradeProof for the choice of height.
+func (tn *ChainNode) queryPacketProof(ctx context.Context, kind, portID, channelID string, sequence uint64, height int64) (PacketProof, error) {
+	stdout, _, err := tn.ExecQueryAtHeight(ctx, height, "ibc", "channel", kind, portID, channelID, strconv.FormatUint(sequence, 10), "--prove")
+	if err != nil {
+		return PacketProof{}, err
+	}
+	var res proofResponse
+	if err := json.Unmarshal(stdout, &res); err != nil {
+		return PacketProof{}, fmt.Errorf("failed to unmarshal %s proof of packet %d on %s/%s: %w", kind, sequence, portID, channelID, err)
+	}
+	if len(res.Proof) == 0 {
+		return PacketProof{}, fmt.Errorf("no %s of packet %d on %s/%s at height %d", kind, sequence, portID, channelID, height)
+	}
+	return PacketProof{Proof: res.Proof, ProofHeight: res.height()}, nil
+}
+
+// QueryPacketAcknowledgementCommitment returns the commitment of the acknowledgement written for the packet with sequence
+// received on the channel end with portID and channelID, i.e. the hash of the acknowledgement as by chantypes.CommitAcknowledgement.
+func (c *CosmosChain) QueryPacketAcknowledgementCommitment(ctx context.Context, portID, channelID string, sequence uint64) ([]byte, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := chantypes.NewQueryClient(conn).PacketAcknowledgement(ctx, &chantypes.QueryPacketAcknowledgementRequest{
+		PortId:    portID,
+		ChannelId: channelID,
+		Sequence:  sequence,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query acknowledgement of packet %d on %s/%s: %w", sequence, portID, channelID, err)
+	}
+	return res.Acknowledgement, nil
+}
+
+// PacketRelayEnd is an end of a packet relayed by RelayPacket.
+type PacketRelayEnd struct {
+	Chain *CosmosChain
+
+	// KeyName is the key in the keyring of Chain signing the relay message on it, e.g. of a test user.
+	KeyName string
+}
+
+// RelayPacket relays the packet sent by tx on src to dst with a MsgRecvPacket,
+// then relays the acknowledgement written by dst back to src with a MsgAcknowledgement, and returns the acknowledgement.
+// The messages are signed by the keys of the ends, with proofs queried with the chain binaries,
+// while r updates the clients of pathName. The relayer must not be running, or it may relay the packet first.
+// This relays the packets of any application, e.g. ICS-20 packets delivered to the ibc-go mock module.
+func RelayPacket(ctx context.Context, r ibc.Relayer, rep ibc.RelayerExecReporter, pathName string, src, dst PacketRelayEnd, tx ibc.Tx) ([]byte, error) {
+	packet := tx.Packet
+
+	// relay submits the message that msg builds on to, from the state of from changed at height last,
+	// proven with query at the height of the client of to. It returns the height of the transaction,
+	// and the acknowledgement that it wrote for the recv step.
+	relay := func(step string, from, to PacketRelayEnd, last uint64, toPort, toChannel string,
+		query func(tn *ChainNode, height int64) (PacketProof, error),
+		msg func(p PacketProof, signer string) (json.RawMessage, error),
+	) (uint64, []byte, error) {
+		// The change must be committed to by the header the proof is verified against, i.e. by a block after the one including it.
+		h, err := from.Chain.Height(ctx)
+		if err != nil {
+			return 0, nil, err
+		}
+		if h <= last {
+			if err := testutil.WaitForBlocks(ctx, int(last-h+1), from.Chain); err != nil {
+				return 0, nil, err
+			}
+		}
+		if err := r.UpdateClients(ctx, rep, pathName); err != nil {
+			return 0, nil, fmt.Errorf("%s: failed to update clients: %w", step, err)
+		}
+		clientHeight, err := to.Chain.counterpartyLatestHeight(ctx, toPort, toChannel)
+		if err != nil {
+			return 0, nil, fmt.Errorf("%s: %w", step, err)
+		}
+		p, err := query(from.Chain.getFullNode(), int64(clientHeight.GetRevisionHeight()))
+		if err != nil {
+			return 0, nil, fmt.Errorf("%s: failed to prove packet %d on %s: %w", step, packet.Sequence, from.Chain.Config().ChainID, err)
+		}
+
+		signer, err := to.Chain.getFullNode().AccountKeyBech32(ctx, to.KeyName)
+		if err != nil {
+			return 0, nil, err
+		}
+		m, err := msg(p, signer)
+		if err != nil {
+			return 0, nil, fmt.Errorf("%s: %w", step, err)
+		}
+		res, err := to.Chain.getFullNode().sendJSONTx(ctx, to.KeyName, packetRelayGas, m)
+		if err != nil {
+			return 0, nil, fmt.Errorf("%s on %s: %w", step, to.Chain.Config().ChainID, err)
+		}
+		if step != "recv" {
+			return uint64(res.Height), nil, nil
+		}
+		ack, err := acks.AckFromEvents(res.TxResult.Events)
+		if err != nil {
+			return 0, nil, fmt.Errorf("%s on %s: %w", step, to.Chain.Config().ChainID, err)
+		}
+		return uint64(res.Height), ack, nil
+	}
+
+	recvHeight, ack, err := relay("recv", src, dst, uint64(tx.Height), packet.DestPort, packet.DestChannel,
+		func(tn *ChainNode, height int64) (PacketProof, error) {
+			return tn.queryPacketProof(ctx, "packet-commitment", packet.SourcePort, packet.SourceChannel, packet.Sequence, height)
+		},
+		func(p PacketProof, signer string) (json.RawMessage, error) {
+			return p.RecvMsg(packet, signer)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, err = relay("acknowledgement", dst, src, recvHeight, packet.SourcePort, packet.SourceChannel,
+		func(tn *ChainNode, height int64) (PacketProof, error) {
+			return tn.queryPacketProof(ctx, "packet-ack", packet.DestPort, packet.DestChannel, packet.Sequence, height)
+		},
+		func(p PacketProof, signer string) (json.RawMessage, error) {
+			return p.AckMsg(packet, ack, signer)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ack, nil
+}
@@ -0,0 +1,62 @@
+package cosmos_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/v6/modules/core/02-client/types"
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacketProofMsgs(t *testing.T) {
+	const signer = "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu"
+
+	packet := ibc.Packet{
+		Sequence:         3,
+		SourcePort:       "transfer",
+		SourceChannel:    "channel-1",
+		DestPort:         ibc.MockPort,
+		DestChannel:      "channel-0",
+		Data:             []byte(`{"amount":"100","denom":"stake"}`),
+		TimeoutHeight:    "1-500",
+		TimeoutTimestamp: 1_700_000_000_000_000_000,
+	}
+	wantPacket := chantypes.NewPacket(packet.Data, 3, "transfer", "channel-1", ibc.MockPort, "channel-0",
+		clienttypes.NewHeight(1, 500), 1_700_000_000_000_000_000)
+	proof := cosmos.PacketProof{Proof: []byte("proof of packet"), ProofHeight: ibc.NewHeight(1, 42)}
+
+	// The messages must decode as the ibc-go messages.
+	decode := func(t *testing.T, msg json.RawMessage, err error) sdk.Msg {
+		t.Helper()
+		require.NoError(t, err)
+		var m sdk.Msg
+		require.NoError(t, cosmos.DefaultEncoding().Codec.UnmarshalInterfaceJSON(msg, &m))
+		return m
+	}
+
+	msg, err := proof.RecvMsg(packet, signer)
+	require.Equal(t, &chantypes.MsgRecvPacket{
+		Packet:          wantPacket,
+		ProofCommitment: []byte("proof of packet"),
+		ProofHeight:     clienttypes.NewHeight(1, 42),
+		Signer:          signer,
+	}, decode(t, msg, err))
+
+	ack := []byte(`{"error":"ABCI code: 1: error handling packet: see events for details"}`)
+	msg, err = proof.AckMsg(packet, ack, signer)
+	require.Equal(t, &chantypes.MsgAcknowledgement{
+		Packet:          wantPacket,
+		Acknowledgement: ack,
+		ProofAcked:      []byte("proof of packet"),
+		ProofHeight:     clienttypes.NewHeight(1, 42),
+		Signer:          signer,
+	}, decode(t, msg, err))
+
+	packet.TimeoutHeight = "invalid"
+	_, err = proof.RecvMsg(packet, signer)
+	require.Error(t, err)
+}
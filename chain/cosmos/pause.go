@@ -0,0 +1,74 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ErrNodePaused is returned by Height for a node paused with PauseContainer,
+// rather than waiting for the frozen node to answer.
+var ErrNodePaused = errors.New("node is paused")
+
+// PauseAllNodes freezes every node of the chain with docker pause, so that the chain stops producing blocks
+// while its nodes keep their state and peers, e.g. to simulate a halt for relayer tests.
+// Unlike StopAllNodes, the nodes continue exactly where they left off once resumed with ResumeAllNodes.
+//
+// The RPC and gRPC endpoints of paused nodes accept connections but do not answer until the nodes resume,
+// so queries against a paused chain block until their context is done.
+// Height returns an error wrapping ErrNodePaused instead.
+func (c *CosmosChain) PauseAllNodes(ctx context.Context) error {
+	var eg errgroup.Group
+	for _, n := range c.Nodes() {
+		n := n
+		eg.Go(func() error {
+			return n.PauseContainer(ctx)
+		})
+	}
+	return eg.Wait()
+}
+
+// ResumeAllNodes unfreezes the nodes of the chain paused with PauseAllNodes.
+func (c *CosmosChain) ResumeAllNodes(ctx context.Context) error {
+	var eg errgroup.Group
+	for _, n := range c.Nodes() {
+		n := n
+		eg.Go(func() error {
+			return n.ResumeContainer(ctx)
+		})
+	}
+	return eg.Wait()
+}
+
+// PauseContainer freezes the node's container with docker pause.
+func (tn *ChainNode) PauseContainer(ctx context.Context) error {
+	if err := tn.DockerClient.ContainerPause(ctx, tn.containerID); err != nil {
+		return fmt.Errorf("pause container %s: %w", tn.Name(), err)
+	}
+	tn.setPaused(true)
+	return nil
+}
+
+// ResumeContainer unfreezes the node's container paused with PauseContainer.
+func (tn *ChainNode) ResumeContainer(ctx context.Context) error {
+	if err := tn.DockerClient.ContainerUnpause(ctx, tn.containerID); err != nil {
+		return fmt.Errorf("unpause container %s: %w", tn.Name(), err)
+	}
+	tn.setPaused(false)
+	return nil
+}
+
+func (tn *ChainNode) setPaused(paused bool) {
+	tn.pausedMu.Lock()
+	defer tn.pausedMu.Unlock()
+	tn.paused = paused
+}
+
+// isPaused reports whether the node's container was paused with PauseContainer.
+func (tn *ChainNode) isPaused() bool {
+	tn.pausedMu.Lock()
+	defer tn.pausedMu.Unlock()
+	return tn.paused
+}
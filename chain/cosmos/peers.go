@@ -0,0 +1,142 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"golang.org/x/sync/errgroup"
+)
+
+// P2PAddress returns the address on which the node listens for peers on the docker network, e.g. host:26656.
+func (tn *ChainNode) P2PAddress() string {
+	return tn.HostName() + ":" + strings.TrimSuffix(p2pPort, "/tcp")
+}
+
+// PeerAddress returns the address of the node as a persistent peer of other nodes,
+// in the form <node-id>@<host>:<port>, see ValidatePeerAddress.
+func (tn *ChainNode) PeerAddress(ctx context.Context) (string, error) {
+	id, err := tn.NodeID(ctx)
+	if err != nil {
+		return "", err
+	}
+	return id + "@" + tn.P2PAddress(), nil
+}
+
+// PeerTopology maps nodes of a chain to the only nodes they peer with.
+type PeerTopology map[*ChainNode]ChainNodes
+
+// LineTopology returns the topology in which each of nodes peers with the nodes before and after it,
+// so that blocks of the first node propagate through all the others to the last.
+func LineTopology(nodes ChainNodes) PeerTopology {
+	t := make(PeerTopology, len(nodes))
+	for i, n := range nodes {
+		var peers ChainNodes
+		if i > 0 {
+			peers = append(peers, nodes[i-1])
+		}
+		if i < len(nodes)-1 {
+			peers = append(peers, nodes[i+1])
+		}
+		t[n] = peers
+	}
+	return t
+}
+
+// RingTopology returns the line topology of nodes with the last node peering with the first.
+func RingTopology(nodes ChainNodes) PeerTopology {
+	t := LineTopology(nodes)
+	if len(nodes) > 2 {
+		first, last := nodes[0], nodes[len(nodes)-1]
+		t[first] = append(t[first], last)
+		t[last] = append(t[last], first)
+	}
+	return t
+}
+
+// StarTopology returns the topology in which the nodes peer only with hub, which peers with them all.
+func StarTopology(hub *ChainNode, nodes ChainNodes) PeerTopology {
+	t := PeerTopology{hub: nil}
+	for _, n := range nodes {
+		if n == hub {
+			continue
+		}
+		t[hub] = append(t[hub], n)
+		t[n] = ChainNodes{hub}
+	}
+	return t
+}
+
+// setPersistentPeers configures tn to peer only with peers: peer exchange is disabled
+// so that tn does not connect to the other nodes it learns of. The node must be restarted to apply it.
+func (tn *ChainNode) setPersistentPeers(ctx context.Context, peers ChainNodes) error {
+	addrs := make([]string, len(peers))
+	for i, p := range peers {
+		addr, err := p.PeerAddress(ctx)
+		if err != nil {
+			return fmt.Errorf("peer address of %s: %w", p.Name(), err)
+		}
+		addrs[i] = addr
+	}
+
+	c := make(testutil.Toml)
+	p2p := make(testutil.Toml)
+	p2p["persistent_peers"] = strings.Join(addrs, ",")
+	p2p["pex"] = false
+	c["p2p"] = p2p
+
+	return testutil.ModifyTomlConfigFile(
+		ctx,
+		tn.logger(),
+		tn.DockerClient,
+		tn.TestName,
+		tn.VolumeName,
+		"config/config.toml",
+		c,
+	)
+}
+
+// SetPersistentPeers restarts node so that it peers only with peers.
+// Other nodes keep their peers, and may still connect to node if they have it as persistent peer.
+func (c *CosmosChain) SetPersistentPeers(ctx context.Context, node *ChainNode, peers ChainNodes) error {
+	if err := node.setPersistentPeers(ctx, peers); err != nil {
+		return err
+	}
+	return node.RestartContainer(ctx)
+}
+
+// SetPeerTopology restarts the nodes of topology at once so that each peers only with its peers in topology,
+// e.g. a LineTopology, RingTopology or StarTopology of the nodes of the chain. Other nodes keep their peers.
+func (c *CosmosChain) SetPeerTopology(ctx context.Context, topology PeerTopology) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	for n, peers := range topology {
+		n, peers := n, peers
+		eg.Go(func() error {
+			return n.setPersistentPeers(egCtx, peers)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	// Stop all nodes before starting any, so that no connection to a former peer survives the restarts.
+	eg, egCtx = errgroup.WithContext(ctx)
+	for n := range topology {
+		n := n
+		eg.Go(func() error {
+			return n.StopContainer(egCtx)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+	eg, egCtx = errgroup.WithContext(ctx)
+	for n := range topology {
+		n := n
+		eg.Go(func() error {
+			return n.StartContainer(egCtx)
+		})
+	}
+	return eg.Wait()
+}
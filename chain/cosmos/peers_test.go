@@ -0,0 +1,56 @@
+package cosmos_test
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/stretchr/testify/require"
+)
+
+func testNodes(n int) cosmos.ChainNodes {
+	nodes := make(cosmos.ChainNodes, n)
+	for i := range nodes {
+		nodes[i] = &cosmos.ChainNode{Index: i}
+	}
+	return nodes
+}
+
+func TestLineTopology(t *testing.T) {
+	nodes := testNodes(4)
+	topology := cosmos.LineTopology(nodes)
+
+	require.Len(t, topology, 4)
+	require.Equal(t, cosmos.ChainNodes{nodes[1]}, topology[nodes[0]])
+	require.Equal(t, cosmos.ChainNodes{nodes[0], nodes[2]}, topology[nodes[1]])
+	require.Equal(t, cosmos.ChainNodes{nodes[1], nodes[3]}, topology[nodes[2]])
+	require.Equal(t, cosmos.ChainNodes{nodes[2]}, topology[nodes[3]])
+
+	single := testNodes(1)
+	require.Empty(t, cosmos.LineTopology(single)[single[0]])
+}
+
+func TestRingTopology(t *testing.T) {
+	nodes := testNodes(3)
+	topology := cosmos.RingTopology(nodes)
+
+	require.Len(t, topology, 3)
+	require.ElementsMatch(t, cosmos.ChainNodes{nodes[1], nodes[2]}, topology[nodes[0]])
+	require.ElementsMatch(t, cosmos.ChainNodes{nodes[0], nodes[2]}, topology[nodes[1]])
+	require.ElementsMatch(t, cosmos.ChainNodes{nodes[0], nodes[1]}, topology[nodes[2]])
+
+	// Two nodes already peer with each other in a line.
+	pair := testNodes(2)
+	require.Equal(t, cosmos.ChainNodes{pair[1]}, cosmos.RingTopology(pair)[pair[0]])
+}
+
+func TestStarTopology(t *testing.T) {
+	nodes := testNodes(4)
+	hub := nodes[1]
+	topology := cosmos.StarTopology(hub, nodes)
+
+	require.Len(t, topology, 4)
+	require.Equal(t, cosmos.ChainNodes{nodes[0], nodes[2], nodes[3]}, topology[hub])
+	for _, n := range []*cosmos.ChainNode{nodes[0], nodes[2], nodes[3]} {
+		require.Equal(t, cosmos.ChainNodes{hub}, topology[n])
+	}
+}
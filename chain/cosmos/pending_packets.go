@@ -0,0 +1,177 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// QueryPendingPackets returns the packets and acknowledgements on the channel with portID and channelID
+// that have not been relayed between the chain, as source, and counterparty, which must be a CosmosChain.
+// It answers the relayers' GetPendingPackets from the packet commitment and acknowledgement queries of the chains,
+// e.g. when the relayer cannot. Channels in the CLOSED state are reported like any other channel.
+func (c *CosmosChain) QueryPendingPackets(ctx context.Context, counterparty ibc.Chain, portID, channelID string) (ibc.PendingPackets, error) {
+	var pending ibc.PendingPackets
+
+	cp, ok := counterparty.(*CosmosChain)
+	if !ok {
+		return pending, fmt.Errorf("counterparty %s is not a cosmos chain", counterparty.Config().ChainID)
+	}
+
+	srcConn, err := c.dialGRPC(c.getFullNode().hostGRPCPort)
+	if err != nil {
+		return pending, err
+	}
+	defer srcConn.Close()
+	dstConn, err := cp.dialGRPC(cp.getFullNode().hostGRPCPort)
+	if err != nil {
+		return pending, err
+	}
+	defer dstConn.Close()
+
+	src := packetQuerier{chantypes.NewQueryClient(srcConn), portID, channelID}
+	ch, err := src.Channel(ctx, &chantypes.QueryChannelRequest{PortId: portID, ChannelId: channelID})
+	if err != nil {
+		return pending, fmt.Errorf("query channel %s/%s: %w", portID, channelID, err)
+	}
+	dst := packetQuerier{chantypes.NewQueryClient(dstConn), ch.Channel.Counterparty.PortId, ch.Channel.Counterparty.ChannelId}
+
+	if pending.SrcPackets, err = unrelayedPackets(ctx, src, dst, ch.Channel.Ordering); err != nil {
+		return pending, err
+	}
+	if pending.DstPackets, err = unrelayedPackets(ctx, dst, src, ch.Channel.Ordering); err != nil {
+		return pending, err
+	}
+	if pending.SrcAcks, err = unrelayedAcks(ctx, src, dst); err != nil {
+		return pending, err
+	}
+	if pending.DstAcks, err = unrelayedAcks(ctx, dst, src); err != nil {
+		return pending, err
+	}
+	return pending, nil
+}
+
+// packetQuerier queries the packets of a channel end.
+type packetQuerier struct {
+	chantypes.QueryClient
+	portID, channelID string
+}
+
+func (q packetQuerier) String() string {
+	return q.portID + "/" + q.channelID
+}
+
+// commitments returns the sequences of the packets sent on the channel end whose commitments still exist.
+func (q packetQuerier) commitments(ctx context.Context) ([]uint64, error) {
+	var (
+		seqs    []uint64
+		nextKey []byte
+	)
+	for {
+		res, err := q.PacketCommitments(ctx, &chantypes.QueryPacketCommitmentsRequest{
+			PortId:     q.portID,
+			ChannelId:  q.channelID,
+			Pagination: &query.PageRequest{Key: nextKey},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("query packet commitments of %s: %w", q, err)
+		}
+		for _, c := range res.Commitments {
+			seqs = append(seqs, c.Sequence)
+		}
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return seqs, nil
+		}
+		nextKey = res.Pagination.NextKey
+	}
+}
+
+// acknowledgements returns the sequences of the packets acknowledged on the channel end.
+func (q packetQuerier) acknowledgements(ctx context.Context) ([]uint64, error) {
+	var (
+		seqs    []uint64
+		nextKey []byte
+	)
+	for {
+		res, err := q.PacketAcknowledgements(ctx, &chantypes.QueryPacketAcknowledgementsRequest{
+			PortId:     q.portID,
+			ChannelId:  q.channelID,
+			Pagination: &query.PageRequest{Key: nextKey},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("query packet acknowledgements of %s: %w", q, err)
+		}
+		for _, a := range res.Acknowledgements {
+			seqs = append(seqs, a.Sequence)
+		}
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return seqs, nil
+		}
+		nextKey = res.Pagination.NextKey
+	}
+}
+
+// unrelayedPackets returns the sequences of the packets committed on sender that receiver has not received.
+func unrelayedPackets(ctx context.Context, sender, receiver packetQuerier, ordering chantypes.Order) ([]uint64, error) {
+	seqs, err := sender.commitments(ctx)
+	if err != nil || len(seqs) == 0 {
+		return nil, err
+	}
+
+	// Ordered channels do not write packet receipts, the next sequence to receive tells what was received.
+	if ordering == chantypes.ORDERED {
+		res, err := receiver.NextSequenceReceive(ctx, &chantypes.QueryNextSequenceReceiveRequest{
+			PortId:    receiver.portID,
+			ChannelId: receiver.channelID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("query next sequence receive of %s: %w", receiver, err)
+		}
+		var unreceived []uint64
+		for _, seq := range seqs {
+			if seq >= res.NextSequenceReceive {
+				unreceived = append(unreceived, seq)
+			}
+		}
+		return unreceived, nil
+	}
+
+	res, err := receiver.UnreceivedPackets(ctx, &chantypes.QueryUnreceivedPacketsRequest{
+		PortId:                    receiver.portID,
+		ChannelId:                 receiver.channelID,
+		PacketCommitmentSequences: seqs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query unreceived packets of %s: %w", receiver, err)
+	}
+	return nilIfEmpty(res.Sequences), nil
+}
+
+// unrelayedAcks returns the sequences of the packets acknowledged on receiver whose acknowledgements sender has not received.
+func unrelayedAcks(ctx context.Context, receiver, sender packetQuerier) ([]uint64, error) {
+	seqs, err := receiver.acknowledgements(ctx)
+	if err != nil || len(seqs) == 0 {
+		return nil, err
+	}
+
+	res, err := sender.UnreceivedAcks(ctx, &chantypes.QueryUnreceivedAcksRequest{
+		PortId:             sender.portID,
+		ChannelId:          sender.channelID,
+		PacketAckSequences: seqs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query unreceived acknowledgements of %s: %w", sender, err)
+	}
+	return nilIfEmpty(res.Sequences), nil
+}
+
+// nilIfEmpty returns nil for an empty seqs, as the relayers report no pending sequences.
+func nilIfEmpty(seqs []uint64) []uint64 {
+	if len(seqs) == 0 {
+		return nil
+	}
+	return seqs
+}
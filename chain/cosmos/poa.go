@@ -0,0 +1,174 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/icza/dyno"
+)
+
+// PoAGenesisValidator is a validator of a proof-of-authority genesis, see PoAStakingGenesis.
+type PoAGenesisValidator struct {
+	// Bech32 address of the account operating the validator, which self-delegates its tokens.
+	AccountAddress string
+	// Bech32 operator address of the validator, e.g. cosmosvaloper1...
+	OperatorAddress string
+	// Base64 ed25519 consensus public key of the validator, as in the priv_validator_key.json of its node.
+	ConsensusPubKey string
+	Moniker         string
+	// Voting power of the validator, which must be positive.
+	Power int64
+}
+
+// PoAStakingGenesis adds validators to the staking genesis of genbz, each self-delegating the tokens of its power
+// in denom, so that the staking module bonds them in InitGenesis with the powers of the genesis validators.
+// SDK chains, including x/poa chains, which build on x/staking, require the genesis validators to match
+// the validator set returned by InitGenesis.
+//
+// The validators are added unbonded, with their tokens in the not bonded pool: bonding them in InitGenesis
+// runs the staking hooks that set up their distribution and slashing state, as gentxs would.
+func PoAStakingGenesis(genbz []byte, denom string, validators []PoAGenesisValidator) ([]byte, error) {
+	g := make(map[string]any)
+	if err := json.Unmarshal(genbz, &g); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+	}
+	prefix, err := bech32Prefix(validators)
+	if err != nil {
+		return nil, err
+	}
+
+	total := types.ZeroInt()
+	for _, v := range validators {
+		if v.Power <= 0 {
+			return nil, fmt.Errorf("power of validator %s must be positive, got %d", v.OperatorAddress, v.Power)
+		}
+		tokens := types.TokensFromConsensusPower(v.Power, types.DefaultPowerReduction)
+		total = total.Add(tokens)
+
+		validator := map[string]any{
+			"operator_address": v.OperatorAddress,
+			"consensus_pubkey": map[string]any{"@type": "/cosmos.crypto.ed25519.PubKey", "key": v.ConsensusPubKey},
+			"jailed":           false,
+			"status":           stakingtypes.Unbonded.String(),
+			"tokens":           tokens.String(),
+			"delegator_shares": types.NewDecFromInt(tokens).String(),
+			"description":      map[string]any{"moniker": v.Moniker},
+			"unbonding_height": "0",
+			"unbonding_time":   "1970-01-01T00:00:00Z",
+			"commission": map[string]any{
+				"commission_rates": map[string]any{"rate": "0.1", "max_rate": "0.2", "max_change_rate": "0.01"},
+				"update_time":      "1970-01-01T00:00:00Z",
+			},
+			"min_self_delegation": "1",
+		}
+		if err := dyno.Append(g, validator, "app_state", "staking", "validators"); err != nil {
+			return nil, fmt.Errorf("failed to add staking validator %s: %w", v.OperatorAddress, err)
+		}
+		delegation := map[string]any{
+			"delegator_address": v.AccountAddress,
+			"validator_address": v.OperatorAddress,
+			"shares":            types.NewDecFromInt(tokens).String(),
+		}
+		if err := dyno.Append(g, delegation, "app_state", "staking", "delegations"); err != nil {
+			return nil, fmt.Errorf("failed to add self-delegation of %s: %w", v.OperatorAddress, err)
+		}
+	}
+
+	pool, err := types.Bech32ifyAddressBytes(prefix, authtypes.NewModuleAddress(stakingtypes.NotBondedPoolName))
+	if err != nil {
+		return nil, err
+	}
+	coins := types.NewCoins(types.NewCoin(denom, total))
+	if err := dyno.Append(g, map[string]any{"address": pool, "coins": coins}, "app_state", "bank", "balances"); err != nil {
+		return nil, fmt.Errorf("failed to add not bonded pool balance: %w", err)
+	}
+
+	// The supply is computed from the balances if empty, otherwise it must include the pool.
+	if supply, err := dyno.GetSlice(g, "app_state", "bank", "supply"); err == nil && len(supply) > 0 {
+		bz, err := json.Marshal(supply)
+		if err != nil {
+			return nil, err
+		}
+		var s types.Coins
+		if err := json.Unmarshal(bz, &s); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal bank supply: %w", err)
+		}
+		if err := dyno.Set(g, s.Add(coins...), "app_state", "bank", "supply"); err != nil {
+			return nil, fmt.Errorf("failed to set bank supply: %w", err)
+		}
+	}
+
+	out, err := json.Marshal(g)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+	}
+	return out, nil
+}
+
+// bech32Prefix returns the account address prefix of validators, which must have at least one validator.
+func bech32Prefix(validators []PoAGenesisValidator) (string, error) {
+	if len(validators) == 0 {
+		return "", fmt.Errorf("proof-of-authority genesis must have at least one validator")
+	}
+	prefix, _, err := bech32.DecodeAndConvert(validators[0].AccountAddress)
+	if err != nil {
+		return "", fmt.Errorf("invalid account address of validator %s: %w", validators[0].OperatorAddress, err)
+	}
+	return prefix, nil
+}
+
+// setPoAGenesisValidators sets the validators of a proof-of-authority chain with power as its genesis validators,
+// in place of the validator set built from gentxs, and bonds them in the staking genesis, see PoAStakingGenesis.
+// Validators without power are left out, as CometBFT rejects them.
+func (c *CosmosChain) setPoAGenesisValidators(ctx context.Context, genbz []byte) ([]byte, error) {
+	powers, err := c.cfg.PoA.Powers(len(c.Validators))
+	if err != nil {
+		return nil, err
+	}
+	var (
+		validators        []map[string]any
+		stakingValidators []PoAGenesisValidator
+	)
+	for i, v := range c.Validators {
+		if powers[i] == 0 {
+			continue
+		}
+		gv, err := v.genesisValidator(ctx, v.Name(), powers[i])
+		if err != nil {
+			return nil, fmt.Errorf("genesis validator %s: %w", v.Name(), err)
+		}
+		validators = append(validators, gv)
+
+		var pubKey struct {
+			Value string `json:"value"`
+		}
+		if err := json.Unmarshal(gv["pub_key"].(json.RawMessage), &pubKey); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal consensus key of %s: %w", v.Name(), err)
+		}
+		account, err := v.AccountKeyBech32(ctx, valKey)
+		if err != nil {
+			return nil, err
+		}
+		operator, err := v.ValidatorOperatorAddress(ctx)
+		if err != nil {
+			return nil, err
+		}
+		stakingValidators = append(stakingValidators, PoAGenesisValidator{
+			AccountAddress:  account,
+			OperatorAddress: operator,
+			ConsensusPubKey: pubKey.Value,
+			Moniker:         v.Name(),
+			Power:           powers[i],
+		})
+	}
+	genbz, err = PoAStakingGenesis(genbz, c.cfg.Denom, stakingValidators)
+	if err != nil {
+		return nil, err
+	}
+	return setGenesisValidators(genbz, validators)
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	govutils "github.com/cosmos/cosmos-sdk/x/gov/client/utils"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
 	"github.com/strangelove-ventures/interchaintest/v6/testutil"
 )
@@ -27,6 +29,27 @@ func PollForProposalStatus(ctx context.Context, chain *CosmosChain, startHeight,
 	return bp.DoPoll(ctx, startHeight, maxHeight)
 }
 
+// PollForVote attempts to find the vote of voter with the given option, e.g. ProposalVoteYes, on a proposal,
+// such as the vote of an interchain account sent with ChainNode.SendICAGovVote once its packet is relayed.
+func PollForVote(ctx context.Context, chain *CosmosChain, startHeight, maxHeight uint64, proposalID, voter, vote string) (govv1beta1.Vote, error) {
+	option, err := govv1beta1.VoteOptionFromString(govutils.NormalizeVoteOption(vote))
+	if err != nil {
+		return govv1beta1.Vote{}, err
+	}
+	doPoll := func(ctx context.Context, height uint64) (govv1beta1.Vote, error) {
+		v, err := chain.QueryProposalVote(ctx, proposalID, voter)
+		if err != nil {
+			return govv1beta1.Vote{}, err
+		}
+		if len(v.Options) != 1 || v.Options[0].Option != option {
+			return govv1beta1.Vote{}, fmt.Errorf("vote of %s (%v) does not match expected: (%s)", voter, v.Options, option)
+		}
+		return v, nil
+	}
+	bp := testutil.BlockPoller[govv1beta1.Vote]{CurrentHeight: chain.Height, PollFunc: doPoll}
+	return bp.DoPoll(ctx, startHeight, maxHeight)
+}
+
 // PollForMessage searches every transaction for a message. Must pass a coded registry capable of decoding the cosmos transaction.
 // fn is optional. Return true from the fn to stop polling and return the found message. If fn is nil, returns the first message to match type T.
 func PollForMessage[T any](ctx context.Context, chain *CosmosChain, registry codectypes.InterfaceRegistry, startHeight, maxHeight uint64, fn func(found T) bool) (T, error) {
@@ -0,0 +1,123 @@
+package cosmos
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// Retries of a query the node rejects as overloaded or unreachable, with jittered exponential backoff from queryRetryDelay.
+const queryRetries = 5
+
+var queryRetryDelay = 100 * time.Millisecond
+
+// QueryStats counts the queries of the query helpers of a chain delayed by its query rate limit,
+// see ibc.ChainConfig.QueryRateLimit, and those retried after the node was overloaded or unreachable.
+type QueryStats struct {
+	Throttled uint64
+	Retried   uint64
+}
+
+// queryThrottle limits the rate of the queries of a chain and counts them for QueryStats.
+type queryThrottle struct {
+	once    sync.Once
+	limiter *rate.Limiter // Nil if queries are not throttled.
+
+	throttled, retried uint64
+}
+
+func (c *CosmosChain) queryThrottle() *queryThrottle {
+	q := &c.queries
+	q.once.Do(func() {
+		limit := c.cfg.QueryRateLimit
+		if limit > 0 {
+			burst := int(limit)
+			if burst < 1 {
+				burst = 1
+			}
+			q.limiter = rate.NewLimiter(rate.Limit(limit), burst)
+		}
+	})
+	return q
+}
+
+// QueryStats returns the counts of throttled and retried queries of the chain so far.
+func (c *CosmosChain) QueryStats() QueryStats {
+	q := c.queryThrottle()
+	return QueryStats{
+		Throttled: atomic.LoadUint64(&q.throttled),
+		Retried:   atomic.LoadUint64(&q.retried),
+	}
+}
+
+// TrackQueryStats records the counts of throttled and retried queries of the chain as metrics of the test of rep.
+// Interchain.Close records them for the chains of the interchain built with a reporter.
+func (c *CosmosChain) TrackQueryStats(rep *testreporter.RelayerExecReporter) {
+	stats := c.QueryStats()
+	rep.TrackMetric(c.cfg.ChainID+" throttled queries", float64(stats.Throttled), "queries")
+	rep.TrackMetric(c.cfg.ChainID+" retried queries", float64(stats.Retried), "queries")
+}
+
+// throttleQuery blocks until the query rate limit of the chain allows another query.
+func (c *CosmosChain) throttleQuery(ctx context.Context) error {
+	q := c.queryThrottle()
+	if q.limiter == nil {
+		return nil
+	}
+	if q.limiter.Allow() {
+		return nil
+	}
+	atomic.AddUint64(&q.throttled, 1)
+	return q.limiter.Wait(ctx)
+}
+
+// dialGRPC returns a connection to the gRPC server at addr whose queries are throttled
+// and retried as the query helpers of the chain are.
+func (c *CosmosChain) dialGRPC(addr string) (*grpc.ClientConn, error) {
+	return grpc.Dial(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(c.throttleInterceptor),
+	)
+}
+
+func (c *CosmosChain) throttleInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	delay := queryRetryDelay
+	for i := 0; ; i++ {
+		if err := c.throttleQuery(ctx); err != nil {
+			return err
+		}
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil || i == queryRetries || !retryableQueryError(err) {
+			return err
+		}
+		atomic.AddUint64(&c.queryThrottle().retried, 1)
+
+		// Jitter spreads the retries of parallel queries.
+		jittered := delay/2 + time.Duration(rand.Int63n(int64(delay)))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(jittered):
+		}
+		delay *= 2
+	}
+}
+
+// retryableQueryError reports whether err is returned by an overloaded or unreachable node,
+// e.g. one that refused the connection.
+func retryableQueryError(err error) bool {
+	switch status.Code(err) {
+	case codes.ResourceExhausted, codes.Unavailable:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,249 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	"github.com/strangelove-ventures/interchaintest/v6/acks"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+)
+
+const (
+	// rateLimitProposalMaxBlocks is how many blocks ContractRateLimiter waits for its governance proposal to pass.
+	rateLimitProposalMaxBlocks = 30
+
+	// rateLimitAckMaxBlocks is how many blocks AssertRecvRateLimit waits for the acknowledgement of a transfer.
+	rateLimitAckMaxBlocks = 30
+)
+
+// RateLimiter configures the IBC rate limit middleware of a chain,
+// such as the ibc-rate-limit contract of osmosis, see ContractRateLimiter.
+type RateLimiter interface {
+	// SetRecvQuota limits the amount of denom received over channelID within a window
+	// to maxPercent of the denom's supply on the chain at the start of the window.
+	SetRecvQuota(ctx context.Context, channelID, denom string, maxPercent int) error
+
+	// WaitForReset blocks until the current quota window ended, advancing with the chain's blocks
+	// rather than the wall clock.
+	WaitForReset(ctx context.Context) error
+
+	// QuotaExceededAck returns a substring of the acknowledgement error of packets exceeding a quota,
+	// or an empty string if any error acknowledgement is accepted.
+	QuotaExceededAck() string
+}
+
+// ContractRateLimiter is a RateLimiter for chains with the ibc-rate-limit middleware of osmosis,
+// which tracks quotas in a contract.
+// SetRecvQuota instantiates the contract and registers it by governance proposal,
+// so the chain's voting period must be short.
+//
+// Quota windows are measured in block time, so fast blocks keep tests with short windows fast.
+type ContractRateLimiter struct {
+	Chain *CosmosChain
+
+	// ContractFile is the path to the ibc-rate-limit contract, e.g. rate_limiter.wasm.
+	ContractFile string
+
+	// ProposerKeyName is the key storing and instantiating the contract, and submitting the proposal.
+	ProposerKeyName string
+	Deposit         string
+
+	// Window is the length of the quota window.
+	Window time.Duration
+
+	// ExceededAck is returned by QuotaExceededAck.
+	ExceededAck string
+}
+
+var _ RateLimiter = (*ContractRateLimiter)(nil)
+
+// SetRecvQuota implements RateLimiter by instantiating the rate limit contract with the quota
+// and passing a proposal setting it as the middleware's contract.
+func (l *ContractRateLimiter) SetRecvQuota(ctx context.Context, channelID, denom string, maxPercent int) error {
+	if l.Window < time.Second {
+		return fmt.Errorf("rate limit window %s is shorter than the contract's resolution of a second", l.Window)
+	}
+	gov, err := l.Chain.govModuleAddress()
+	if err != nil {
+		return err
+	}
+
+	initMsg, err := json.Marshal(map[string]any{
+		"gov_module": gov,
+		"ibc_module": gov,
+		"paths": []map[string]any{{
+			"channel_id": channelID,
+			"denom":      denom,
+			"quotas": []map[string]any{{
+				"name":      "recv",
+				"duration":  uint64(l.Window.Seconds()),
+				"send_recv": []int{100, maxPercent},
+			}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	codeID, err := l.Chain.StoreContract(ctx, l.ProposerKeyName, l.ContractFile)
+	if err != nil {
+		return fmt.Errorf("failed to store rate limit contract: %w", err)
+	}
+	contract, err := l.Chain.InstantiateContract(ctx, l.ProposerKeyName, codeID, string(initMsg), true)
+	if err != nil {
+		return fmt.Errorf("failed to instantiate rate limit contract: %w", err)
+	}
+
+	prop, err := l.Chain.ParamChangeProposal(ctx, l.ProposerKeyName, ParamChangeProposal{
+		Title:       "Set rate limit contract",
+		Description: fmt.Sprintf("Limit %s received over %s to %d%%", denom, channelID, maxPercent),
+		Deposit:     l.Deposit,
+		Changes:     []ParamChange{{Subspace: "rate-limited-ibc", Key: "contract", Value: contract}},
+	})
+	if err != nil {
+		return err
+	}
+	return passProposal(ctx, l.Chain, prop)
+}
+
+// WaitForReset implements RateLimiter by waiting for a window of block time.
+func (l *ContractRateLimiter) WaitForReset(ctx context.Context) error {
+	stat, err := l.Chain.getFullNode().Client.Status(ctx)
+	if err != nil {
+		return err
+	}
+	end := stat.SyncInfo.LatestBlockTime.Add(l.Window)
+	for {
+		if err := testutil.WaitForBlocks(ctx, 1, l.Chain); err != nil {
+			return err
+		}
+		stat, err := l.Chain.getFullNode().Client.Status(ctx)
+		if err != nil {
+			return err
+		}
+		if stat.SyncInfo.LatestBlockTime.After(end) {
+			return nil
+		}
+	}
+}
+
+// QuotaExceededAck implements RateLimiter.
+func (l *ContractRateLimiter) QuotaExceededAck() string {
+	return l.ExceededAck
+}
+
+// govModuleAddress returns the bech32 address of the chain's governance module account.
+func (c *CosmosChain) govModuleAddress() (string, error) {
+	return sdk.Bech32ifyAddressBytes(c.Config().Bech32Prefix, authtypes.NewModuleAddress(govtypes.ModuleName))
+}
+
+// passProposal votes yes on the proposal with all validators and waits for it to pass.
+func passProposal(ctx context.Context, c *CosmosChain, prop TxProposal) error {
+	if err := c.VoteOnProposalAllValidators(ctx, prop.ProposalID, ProposalVoteYes); err != nil {
+		return fmt.Errorf("failed to vote on proposal %s: %w", prop.ProposalID, err)
+	}
+	if _, err := PollForProposalStatus(ctx, c, prop.Height, prop.Height+rateLimitProposalMaxBlocks, prop.ProposalID, ProposalStatusPassed); err != nil {
+		return fmt.Errorf("proposal %s did not pass: %w", prop.ProposalID, err)
+	}
+	return nil
+}
+
+// RateLimitTransfer describes the transfers AssertRecvRateLimit sends to the rate limited chain.
+type RateLimitTransfer struct {
+	// Src is the chain sending the transfers over SrcChannel, a channel to the rate limited chain.
+	Src        ibc.Chain
+	SrcChannel ibc.ChannelOutput
+
+	SenderKeyName string
+	// Receiver is the address on the rate limited chain.
+	Receiver string
+	// Denom is the denom transferred from Src.
+	Denom string
+
+	// Supply is the amount transferred before the quota is set,
+	// making up the supply of the denom on the rate limited chain that the quota is relative to.
+	Supply int64
+}
+
+// AssertRecvRateLimit asserts that the rate limiter rejects the transfers exceeding a receive quota of maxPercent.
+// The relayer must be relaying the channel.
+//
+// It first transfers t.Supply, which the quota is relative to, then sets the quota.
+// A transfer of half the quota is accepted, while a subsequent transfer of the full quota
+// is rejected with an error acknowledgement matching the limiter's QuotaExceededAck.
+// After the window reset, a transfer of the full quota is accepted,
+// which would exceed the quota together with the first transfer had the window not reset.
+func AssertRecvRateLimit(ctx context.Context, limiter RateLimiter, t RateLimitTransfer, maxPercent int) error {
+	quota := t.Supply * int64(maxPercent) / 100
+	if quota < 2 {
+		return fmt.Errorf("quota of %d%% of supply %d is too small to split", maxPercent, t.Supply)
+	}
+	dstDenom := transfertypes.ParseDenomTrace(
+		transfertypes.GetPrefixedDenom(t.SrcChannel.Counterparty.PortID, t.SrcChannel.Counterparty.ChannelID, t.Denom),
+	).IBCDenom()
+
+	if err := sendRateLimitedTransfer(ctx, t, t.Supply, false, ""); err != nil {
+		return fmt.Errorf("initial transfer: %w", err)
+	}
+	if err := limiter.SetRecvQuota(ctx, t.SrcChannel.Counterparty.ChannelID, dstDenom, maxPercent); err != nil {
+		return fmt.Errorf("failed to set quota: %w", err)
+	}
+
+	if err := sendRateLimitedTransfer(ctx, t, quota/2, false, ""); err != nil {
+		return fmt.Errorf("transfer under quota: %w", err)
+	}
+	if err := sendRateLimitedTransfer(ctx, t, quota, true, limiter.QuotaExceededAck()); err != nil {
+		return fmt.Errorf("transfer exceeding quota: %w", err)
+	}
+
+	if err := limiter.WaitForReset(ctx); err != nil {
+		return fmt.Errorf("failed to wait for quota reset: %w", err)
+	}
+	if err := sendRateLimitedTransfer(ctx, t, quota, false, ""); err != nil {
+		return fmt.Errorf("transfer after quota reset: %w", err)
+	}
+	return nil
+}
+
+// sendRateLimitedTransfer sends amount and checks its acknowledgement,
+// which must be an error containing ackErr if wantErr is true, or successful otherwise.
+func sendRateLimitedTransfer(ctx context.Context, t RateLimitTransfer, amount int64, wantErr bool, ackErr string) error {
+	tx, err := t.Src.SendIBCTransfer(ctx, t.SrcChannel.ChannelID, t.SenderKeyName, ibc.WalletAmount{
+		Address: t.Receiver,
+		Denom:   t.Denom,
+		Amount:  amount,
+	}, ibc.TransferOptions{})
+	if err != nil {
+		return err
+	}
+	if err := tx.Validate(); err != nil {
+		return err
+	}
+
+	ack, err := testutil.PollForAck(ctx, t.Src, tx.Height, tx.Height+rateLimitAckMaxBlocks, tx.Packet)
+	if err != nil {
+		return fmt.Errorf("no acknowledgement of packet %d: %w", tx.Packet.Sequence, err)
+	}
+	channelAck, err := acks.Parse(ack.Acknowledgement)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !wantErr && !channelAck.Success():
+		return fmt.Errorf("transfer of %d was rejected: %s", amount, channelAck.Error)
+	case wantErr && channelAck.Success():
+		return fmt.Errorf("transfer of %d was acknowledged successfully, expected an error acknowledgement", amount)
+	case wantErr && !strings.Contains(channelAck.Error.Msg, ackErr):
+		return fmt.Errorf("acknowledgement error %q of transfer of %d does not contain %q", channelAck.Error, amount, ackErr)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package cosmos_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRateLimiter enforces a receive quota on the transfers of a rateLimitedChain.
+type mockRateLimiter struct {
+	// NoReset keeps the quota used across WaitForReset.
+	NoReset bool
+	// NoLimit accepts all transfers.
+	NoLimit bool
+
+	supply, quota, used int64
+
+	GotChannelID, GotDenom string
+}
+
+func (l *mockRateLimiter) SetRecvQuota(_ context.Context, channelID, denom string, maxPercent int) error {
+	l.GotChannelID, l.GotDenom = channelID, denom
+	l.quota = l.supply * int64(maxPercent) / 100
+	return nil
+}
+
+func (l *mockRateLimiter) WaitForReset(context.Context) error {
+	if !l.NoReset {
+		l.used = 0
+	}
+	return nil
+}
+
+func (l *mockRateLimiter) QuotaExceededAck() string { return "rate limit exceeded" }
+
+// recv returns the acknowledgement of receiving amount.
+func (l *mockRateLimiter) recv(amount int64) []byte {
+	if l.quota == 0 {
+		l.supply += amount
+		return []byte(`{"result":"AQ=="}`)
+	}
+	if !l.NoLimit && l.used+amount > l.quota {
+		return []byte(`{"error":"rate limit exceeded: channel-1/ibc/C4CFF46FD6DE35CA4CF4CE031E643C8FDC9BA4B99AE598E9B0ED98FE3A2319F9"}`)
+	}
+	l.used += amount
+	l.supply += amount
+	return []byte(`{"result":"AQ=="}`)
+}
+
+// rateLimitedChain is the sending chain of the transfers, acknowledged by its limiter as they are sent.
+// Calling any other method panics.
+type rateLimitedChain struct {
+	ibc.Chain
+
+	limiter *mockRateLimiter
+	height  uint64
+	acks    map[uint64][]ibc.PacketAcknowledgement
+}
+
+func (c *rateLimitedChain) SendIBCTransfer(_ context.Context, channelID, _ string, amount ibc.WalletAmount, _ ibc.TransferOptions) (ibc.Tx, error) {
+	c.height++
+	packet := ibc.Packet{
+		Sequence:         c.height,
+		SourcePort:       "transfer",
+		SourceChannel:    channelID,
+		DestPort:         "transfer",
+		DestChannel:      "channel-1",
+		TimeoutTimestamp: 1,
+		Data:             []byte(fmt.Sprintf(`{"amount":"%d","denom":"%s"}`, amount.Amount, amount.Denom)),
+	}
+	if c.acks == nil {
+		c.acks = make(map[uint64][]ibc.PacketAcknowledgement)
+	}
+	c.acks[c.height+1] = []ibc.PacketAcknowledgement{{Packet: packet, Acknowledgement: c.limiter.recv(amount.Amount)}}
+	return ibc.Tx{Height: c.height, TxHash: fmt.Sprint(c.height), GasSpent: 1, Packet: packet}, nil
+}
+
+func (c *rateLimitedChain) Height(context.Context) (uint64, error) {
+	return c.height + 1, nil
+}
+
+func (c *rateLimitedChain) Acknowledgements(_ context.Context, height uint64) ([]ibc.PacketAcknowledgement, error) {
+	return c.acks[height], nil
+}
+
+func TestAssertRecvRateLimit(t *testing.T) {
+	ctx := context.Background()
+
+	transfer := func(limiter *mockRateLimiter) cosmos.RateLimitTransfer {
+		return cosmos.RateLimitTransfer{
+			Src: &rateLimitedChain{limiter: limiter},
+			SrcChannel: ibc.ChannelOutput{
+				ChannelID:    "channel-0",
+				PortID:       "transfer",
+				Counterparty: ibc.ChannelCounterparty{PortID: "transfer", ChannelID: "channel-1"},
+			},
+			SenderKeyName: "sender",
+			Receiver:      "osmo1receiver",
+			Denom:         "uatom",
+			Supply:        1000,
+		}
+	}
+
+	t.Run("quota hit and reset", func(t *testing.T) {
+		var limiter mockRateLimiter
+		require.NoError(t, cosmos.AssertRecvRateLimit(ctx, &limiter, transfer(&limiter), 10))
+		require.Equal(t, "channel-1", limiter.GotChannelID)
+		require.Equal(t, "ibc/C4CFF46FD6DE35CA4CF4CE031E643C8FDC9BA4B99AE598E9B0ED98FE3A2319F9", limiter.GotDenom)
+		// The transfers under the quota before and after the reset are received.
+		require.Equal(t, int64(1000+50+100), limiter.supply)
+	})
+
+	t.Run("quota not enforced", func(t *testing.T) {
+		limiter := mockRateLimiter{NoLimit: true}
+		err := cosmos.AssertRecvRateLimit(ctx, &limiter, transfer(&limiter), 10)
+		require.ErrorContains(t, err, "transfer exceeding quota")
+	})
+
+	t.Run("quota not reset", func(t *testing.T) {
+		limiter := mockRateLimiter{NoReset: true}
+		err := cosmos.AssertRecvRateLimit(ctx, &limiter, transfer(&limiter), 10)
+		require.ErrorContains(t, err, "transfer after quota reset")
+	})
+
+	t.Run("quota too small", func(t *testing.T) {
+		var limiter mockRateLimiter
+		err := cosmos.AssertRecvRateLimit(ctx, &limiter, transfer(&limiter), 0)
+		require.ErrorContains(t, err, "too small")
+	})
+}
@@ -0,0 +1,123 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"golang.org/x/sync/errgroup"
+)
+
+// readyHeight is the minimum height a node must report to be considered ready.
+const readyHeight = 2
+
+// readinessProbe checks once whether the node is ready for use by tests.
+// The returned error names the probe that failed.
+func (tn *ChainNode) readinessProbe(ctx context.Context, probeAPI bool) error {
+	h, err := tn.Height(ctx)
+	if err != nil {
+		return fmt.Errorf("rpc probe: %w", err)
+	}
+	if h < readyHeight {
+		return fmt.Errorf("height probe: height %d is below %d", h, readyHeight)
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", tn.hostGRPCPort)
+	if err != nil {
+		return fmt.Errorf("grpc probe: %w", err)
+	}
+	_ = conn.Close()
+
+	if probeAPI {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+tn.hostAPIPort+"/cosmos/base/tendermint/v1beta1/node_info", nil)
+		if err != nil {
+			return fmt.Errorf("api probe: %w", err)
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("api probe: %w", err)
+		}
+		_ = res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			return fmt.Errorf("api probe: unexpected status %s", res.Status)
+		}
+	}
+
+	return nil
+}
+
+// ReadLogs returns the last lines of the node container's output, stdout and stderr combined.
+func (tn *ChainNode) ReadLogs(ctx context.Context, lines int) (string, error) {
+	rc, err := tn.DockerClient.ContainerLogs(ctx, tn.containerID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       fmt.Sprint(lines),
+	})
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = rc.Close() }()
+
+	// Logs are multiplexed into one stream; see docs for ContainerLogs.
+	buf := new(bytes.Buffer)
+	if _, err := stdcopy.StdCopy(buf, buf, rc); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// apiEnabled reports whether the chain's app.toml overrides enable the API server.
+func (c *CosmosChain) apiEnabled() bool {
+	app, ok := c.cfg.ConfigFileOverrides["config/app.toml"].(testutil.Toml)
+	if !ok {
+		return false
+	}
+	api, ok := app["api"].(testutil.Toml)
+	if !ok {
+		return false
+	}
+	enabled, _ := api["enable"].(bool)
+	return enabled
+}
+
+// WaitReady blocks until every node of the chain reports a height of at least 2,
+// accepts RPC and gRPC connections, and, if enabled, serves the API.
+// If a node is not ready within timeout, the returned error identifies the node and the failing probe,
+// and includes the node's recent logs.
+func (c *CosmosChain) WaitReady(ctx context.Context, timeout time.Duration) error {
+	probeAPI := c.apiEnabled()
+
+	var eg errgroup.Group
+	for _, n := range c.Nodes() {
+		n := n
+		eg.Go(func() error {
+			probeCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			for {
+				err := n.readinessProbe(probeCtx, probeAPI)
+				if err == nil {
+					return nil
+				}
+
+				select {
+				case <-probeCtx.Done():
+					logs, logErr := n.ReadLogs(ctx, 50)
+					if logErr != nil {
+						logs = fmt.Sprintf("(failed to retrieve logs: %v)", logErr)
+					}
+					return fmt.Errorf("node %s not ready after %s: %w\nrecent logs:\n%s", n.Name(), timeout, err, logs)
+				case <-time.After(500 * time.Millisecond):
+				}
+			}
+		})
+	}
+	return eg.Wait()
+}
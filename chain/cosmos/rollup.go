@@ -0,0 +1,122 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
+)
+
+// addMockDA adds the mock DA layer to the sidecars of a rollup without a DA address, once.
+func (c *CosmosChain) addMockDA() {
+	if c.cfg.Rollup == nil || c.cfg.Rollup.DAAddress != "" {
+		return
+	}
+	for _, s := range c.cfg.SidecarConfigs {
+		if s.ProcessName == ibc.MockDAProcessName {
+			return
+		}
+	}
+	c.cfg.SidecarConfigs = append(c.cfg.SidecarConfigs, c.cfg.Rollup.MockDASidecar())
+}
+
+// DAAddress returns the address of the DA layer of a rollup on the docker network,
+// either the configured one or that of the mock DA layer. It is empty if the chain is not a rollup.
+func (c *CosmosChain) DAAddress() string {
+	if c.cfg.Rollup == nil {
+		return ""
+	}
+	if c.cfg.Rollup.DAAddress != "" {
+		return c.cfg.Rollup.DAAddress
+	}
+	mock := c.Sidecars.ByName(ibc.MockDAProcessName)
+	if mock == nil {
+		return ""
+	}
+	port := strings.TrimSuffix(ibc.MockDAPort, "/tcp")
+	return fmt.Sprintf("http://%s:%s", mock.HostName(), port)
+}
+
+// rollupFlags returns the flags of the start command of tn if its chain is a rollup.
+// The sequencer is the only validator.
+func (tn *ChainNode) rollupFlags() []string {
+	c, ok := tn.Chain.(*CosmosChain)
+	if !ok || c.cfg.Rollup == nil {
+		return nil
+	}
+	return c.cfg.Rollup.NodeFlags(c.DAAddress(), tn.Validator)
+}
+
+// InitSequencerAccount creates the key of the sequencer of a rollup and funds it in genesis.
+// Unlike InitValidatorGenTx, no gentx is signed since the rollup has no staking validator set.
+func (tn *ChainNode) InitSequencerAccount(ctx context.Context, genesisAmounts []types.Coin) error {
+	return tn.initValidatorAccount(ctx, genesisAmounts)
+}
+
+// initValidatorAccount creates the key of the validator tn and funds it in genesis, without a gentx.
+func (tn *ChainNode) initValidatorAccount(ctx context.Context, genesisAmounts []types.Coin) error {
+	if err := tn.CreateKey(ctx, valKey); err != nil {
+		return err
+	}
+	bech32, err := tn.AccountKeyBech32(ctx, valKey)
+	if err != nil {
+		return err
+	}
+	return tn.AddGenesisAccount(ctx, bech32, genesisAmounts)
+}
+
+// setSequencerGenesisValidator sets the consensus key of the sequencer tn as the only genesis validator,
+// which rollkit requires in place of the validator set built from gentxs.
+func (tn *ChainNode) setSequencerGenesisValidator(ctx context.Context, genbz []byte) ([]byte, error) {
+	v, err := tn.genesisValidator(ctx, "sequencer", 1)
+	if err != nil {
+		return nil, err
+	}
+	return setGenesisValidators(genbz, []map[string]any{v})
+}
+
+// genesisValidator returns the genesis validator entry of the consensus key of tn, with name and power.
+func (tn *ChainNode) genesisValidator(ctx context.Context, name string, power int64) (map[string]any, error) {
+	fr := dockerutil.NewFileRetriever(tn.logger(), tn.DockerClient, tn.TestName)
+	keybz, err := fr.SingleFileContent(ctx, tn.VolumeName, "config/priv_validator_key.json")
+	if err != nil {
+		return nil, fmt.Errorf("getting priv_validator_key.json content: %w", err)
+	}
+	var key struct {
+		Address string          `json:"address"`
+		PubKey  json.RawMessage `json:"pub_key"`
+	}
+	if err := json.Unmarshal(keybz, &key); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal consensus key of %s: %w", tn.Name(), err)
+	}
+	return map[string]any{
+		"address": key.Address,
+		"pub_key": key.PubKey,
+		"power":   strconv.FormatInt(power, 10),
+		"name":    name,
+	}, nil
+}
+
+// setGenesisValidators replaces the genesis validators of genbz, otherwise built from gentxs, with validators.
+func setGenesisValidators(genbz []byte, validators []map[string]any) ([]byte, error) {
+	g := make(map[string]any)
+	if err := json.Unmarshal(genbz, &g); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+	}
+	// The genesis validators are in the consensus object since SDK v0.50.
+	if consensus, ok := g["consensus"].(map[string]any); ok {
+		consensus["validators"] = validators
+	} else {
+		g["validators"] = validators
+	}
+	out, err := json.Marshal(g)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+	}
+	return out, nil
+}
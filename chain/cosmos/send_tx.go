@@ -0,0 +1,111 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authTx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+)
+
+// sendTxMaxBlocks is the number of blocks SendTxAndWait waits for a broadcast transaction to be included.
+const sendTxMaxBlocks = 10
+
+// SendTxAndWait broadcasts the messages msgs in a transaction signed by wallet with exactly gas and fees,
+// without simulating it, and waits until the transaction is included in a block.
+// It returns the response of the committed transaction, with the gas it used.
+//
+// A transaction included with a non-zero code, e.g. after running out of gas, returns its response along with an error.
+func (c *CosmosChain) SendTxAndWait(ctx context.Context, wallet ibc.Wallet, gas uint64, fees sdk.Coins, msgs ...sdk.Msg) (*sdk.TxResponse, error) {
+	return c.getFullNode().SendTxAndWait(ctx, wallet, gas, fees, msgs...)
+}
+
+// SendTxAndWait broadcasts the messages msgs in a transaction signed by wallet with exactly gas and fees,
+// and waits until the transaction is included in a block. The key of wallet must be in the node's keyring.
+func (tn *ChainNode) SendTxAndWait(ctx context.Context, wallet ibc.Wallet, gas uint64, fees sdk.Coins, msgs ...sdk.Msg) (*sdk.TxResponse, error) {
+	if len(msgs) == 0 {
+		return nil, errors.New("transaction has no messages")
+	}
+	txConfig := tn.Chain.Config().EncodingConfig.TxConfig
+
+	b := txConfig.NewTxBuilder()
+	if err := b.SetMsgs(msgs...); err != nil {
+		return nil, fmt.Errorf("failed to set transaction messages: %w", err)
+	}
+	b.SetGasLimit(gas)
+	b.SetFeeAmount(fees)
+	unsigned, err := txConfig.TxJSONEncoder()(b.GetTx())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode unsigned transaction: %w", err)
+	}
+	txHash, err := tn.signAndBroadcastTx(ctx, wallet.KeyName(), unsigned)
+	if err != nil {
+		return nil, err
+	}
+
+	start, err := tn.Height(ctx)
+	if err != nil {
+		return nil, err
+	}
+	poll := func(ctx context.Context, _ uint64) (*sdk.TxResponse, error) {
+		return authTx.QueryTx(tn.CliContext(), txHash)
+	}
+	bp := testutil.BlockPoller[*sdk.TxResponse]{CurrentHeight: tn.Height, PollFunc: poll}
+	txResp, err := bp.DoPoll(ctx, start, start+sendTxMaxBlocks)
+	if err != nil {
+		return nil, fmt.Errorf("transaction %s not included: %w", txHash, err)
+	}
+
+	if txResp.Code != 0 {
+		return txResp, fmt.Errorf("transaction %s failed with code %d: %s", txHash, txResp.Code, txResp.RawLog)
+	}
+	if r := txRecorderFromContext(ctx); r != nil {
+		r.record(newTxResult(txResp))
+	}
+	return txResp, nil
+}
+
+// signAndBroadcastTx signs the JSON encoded transaction unsigned with the key keyName in the node's keyring,
+// and broadcasts it without waiting for it to be included. It returns the hash of the transaction.
+func (tn *ChainNode) signAndBroadcastTx(ctx context.Context, keyName string, unsigned []byte) (string, error) {
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	prefix := "tx-" + dockerutil.RandLowerCaseLetterString(8)
+	writeFile := func(suffix string, content []byte) (string, error) {
+		file := prefix + "-" + suffix + ".json"
+		if err := fw.WriteFile(ctx, tn.VolumeName, file, content); err != nil {
+			return "", fmt.Errorf("writing %s to docker volume: %w", file, err)
+		}
+		return path.Join(tn.HomeDir(), file), nil
+	}
+	unsignedPath, err := writeFile("unsigned", unsigned)
+	if err != nil {
+		return "", err
+	}
+
+	// Hold the lock from signing to broadcasting, so the transaction keeps the account sequence it was signed with.
+	tn.lock.Lock()
+	defer tn.lock.Unlock()
+	signed, _, err := tn.Exec(ctx, tn.NodeCommand(append([]string{
+		"tx", "sign", unsignedPath,
+		"--from", keyName,
+		"--keyring-backend", keyring.BackendTest,
+	}, tn.signModeFlags()...)...), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	signedPath, err := writeFile("signed", signed)
+	if err != nil {
+		return "", err
+	}
+	return tn.broadcastTxCommand(ctx, tn.NodeCommand(
+		"tx", "broadcast", signedPath,
+		"--broadcast-mode", "sync",
+		"--output", "json",
+	))
+}
@@ -0,0 +1,177 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"go.uber.org/zap"
+)
+
+// SequencedBroadcaster broadcasts transactions signed by a single wallet, such that concurrent callers
+// sharing the wallet do not fail with account sequence mismatches.
+//
+// Signing and broadcasting are serialized, and every transaction is signed with the wallet's sequence tracked locally,
+// so that it is accepted while earlier transactions are still waiting for inclusion.
+// If the node rejects a sequence, e.g. because the wallet also signed transactions elsewhere,
+// the account is fetched again and the transaction is retried once.
+type SequencedBroadcaster struct {
+	node   *ChainNode
+	wallet ibc.Wallet
+
+	mu            sync.Mutex
+	haveAccount   bool
+	accountNumber uint64
+	sequence      uint64
+}
+
+// NewSequencedBroadcaster returns a SequencedBroadcaster for transactions signed by wallet on chain.
+// The wallet's key must be in the keyring of the chain's nodes, e.g. a wallet from BuildWallet.
+func NewSequencedBroadcaster(chain *CosmosChain, wallet ibc.Wallet) *SequencedBroadcaster {
+	return newSequencedBroadcaster(chain.getFullNode(), wallet)
+}
+
+func newSequencedBroadcaster(node *ChainNode, wallet ibc.Wallet) *SequencedBroadcaster {
+	return &SequencedBroadcaster{node: node, wallet: wallet}
+}
+
+// Wallet returns the wallet signing the broadcast transactions.
+func (b *SequencedBroadcaster) Wallet() ibc.Wallet {
+	return b.wallet
+}
+
+// Sequence returns the account sequence of the next transaction,
+// or 0 if the account has not been fetched yet, i.e. before the first transaction.
+func (b *SequencedBroadcaster) Sequence() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.sequence
+}
+
+// ExecTx executes a transaction like ChainNode.ExecTx, signed by the broadcaster's wallet,
+// waits for 2 blocks if successful, then returns the tx hash.
+// Unlike ChainNode.ExecTx, other transactions can be broadcast while ExecTx waits for blocks.
+func (b *SequencedBroadcaster) ExecTx(ctx context.Context, command ...string) (string, error) {
+	return b.execTx(ctx, b.node.Chain.Config().GasPrices, command...)
+}
+
+// ExecInternalTx executes a transaction like ChainNode.ExecInternalTx, signed by the broadcaster's wallet.
+func (b *SequencedBroadcaster) ExecInternalTx(ctx context.Context, command ...string) (string, error) {
+	cfg := b.node.Chain.Config()
+	if cfg.InternalGasPrices == "" || cfg.InternalGasPrices == cfg.GasPrices {
+		return b.execTx(ctx, cfg.GasPrices, command...)
+	}
+
+	txHash, err := b.execTx(ctx, cfg.InternalGasPrices, command...)
+	if errors.Is(err, errInsufficientFee) {
+		b.node.logger().Info(
+			"Internal gas prices rejected by node, retrying with gas prices",
+			zap.String("internal_gas_prices", cfg.InternalGasPrices),
+			zap.String("gas_prices", cfg.GasPrices),
+		)
+		return b.execTx(ctx, cfg.GasPrices, command...)
+	}
+	return txHash, err
+}
+
+// SendFunds sends funds from the broadcaster's wallet, like CosmosChain.SendFunds.
+func (b *SequencedBroadcaster) SendFunds(ctx context.Context, amount ibc.WalletAmount) error {
+	_, err := b.ExecTx(ctx, b.sendCommand(amount)...)
+	return err
+}
+
+// SendFundsInternal sends funds from the broadcaster's wallet, like CosmosChain.SendFundsInternal.
+func (b *SequencedBroadcaster) SendFundsInternal(ctx context.Context, amount ibc.WalletAmount) error {
+	_, err := b.ExecInternalTx(ctx, b.sendCommand(amount)...)
+	return err
+}
+
+func (b *SequencedBroadcaster) sendCommand(amount ibc.WalletAmount) []string {
+	return []string{
+		"bank", "send", b.wallet.KeyName(),
+		amount.Address, fmt.Sprintf("%d%s", amount.Amount, amount.Denom),
+	}
+}
+
+func (b *SequencedBroadcaster) execTx(ctx context.Context, gasPrices string, command ...string) (string, error) {
+	b.mu.Lock()
+	txHash, err := b.broadcast(ctx, gasPrices, command)
+	if errors.Is(err, errWrongSequence) {
+		b.node.logger().Info(
+			"Account sequence mismatch, retrying with fetched account",
+			zap.String("key_name", b.wallet.KeyName()),
+			zap.Uint64("sequence", b.sequence),
+		)
+		b.haveAccount = false
+		txHash, err = b.broadcast(ctx, gasPrices, command)
+	}
+	b.mu.Unlock()
+	if err != nil {
+		return txHash, err
+	}
+
+	// Wait outside of the lock, so that transactions from the wallet can be included in the same blocks.
+	return b.node.awaitTx(ctx, txHash)
+}
+
+// broadcast signs and broadcasts the transaction with the tracked sequence.
+// The caller must hold b.mu.
+func (b *SequencedBroadcaster) broadcast(ctx context.Context, gasPrices string, command []string) (string, error) {
+	if !b.haveAccount {
+		if err := b.fetchAccount(); err != nil {
+			return "", err
+		}
+	}
+
+	cmd := append(b.node.txCommand(b.node.txGasPrices(ctx, gasPrices), b.wallet.KeyName(), command...),
+		"--account-number", fmt.Sprint(b.accountNumber),
+		"--sequence", fmt.Sprint(b.sequence),
+	)
+
+	b.node.lock.Lock()
+	txHash, err := b.node.broadcastTxCommand(ctx, cmd)
+	b.node.lock.Unlock()
+	if err != nil {
+		return txHash, err
+	}
+
+	// The node accepted the transaction, which consumes the sequence even if its messages fail.
+	b.sequence++
+	return txHash, nil
+}
+
+// fetchAccount sets the tracked account number and sequence from the committed state.
+// The caller must hold b.mu.
+func (b *SequencedBroadcaster) fetchAccount() error {
+	num, seq, err := authtypes.AccountRetriever{}.GetAccountNumberSequence(b.node.CliContext(), sdk.AccAddress(b.wallet.Address()))
+	if err != nil {
+		return fmt.Errorf("failed to get account of %s: %w", b.wallet.FormattedAddress(), err)
+	}
+	b.accountNumber, b.sequence, b.haveAccount = num, seq, true
+	return nil
+}
+
+// validatorBroadcaster returns the broadcaster of transactions signed by the node's validator key,
+// e.g. for governance votes.
+func (tn *ChainNode) validatorBroadcaster(ctx context.Context) (*SequencedBroadcaster, error) {
+	tn.valBroadcasterMu.Lock()
+	defer tn.valBroadcasterMu.Unlock()
+	if tn.valBroadcaster != nil {
+		return tn.valBroadcaster, nil
+	}
+
+	bech32, err := tn.AccountKeyBech32(ctx, valKey)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := sdk.GetFromBech32(bech32, tn.Chain.Config().Bech32Prefix)
+	if err != nil {
+		return nil, err
+	}
+	tn.valBroadcaster = newSequencedBroadcaster(tn, NewWallet(valKey, addr, "", tn.Chain.Config()))
+	return tn.valBroadcaster, nil
+}
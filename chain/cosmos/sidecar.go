@@ -0,0 +1,296 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	dockertypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	dockerclient "github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// SidecarProcess is an auxiliary process of a chain or of one of its validators,
+// run in its own container as configured by an ibc.SidecarConfig.
+//
+// Like chain node containers, sidecar containers are watched by the container watchdog,
+// see interchaintest.InterchainBuildOptions.OnContainerExit.
+type SidecarProcess struct {
+	Config       ibc.SidecarConfig
+	Chain        ibc.Chain
+	NetworkID    string
+	DockerClient *dockerclient.Client
+	TestName     string
+
+	// Node is the validator the process runs for, or nil if the process runs for the chain.
+	Node *ChainNode
+
+	log *zap.Logger
+
+	// Binds of the container in addition to the validator's volume, such as all validator volumes for CometMock.
+	extraBinds []string
+
+	containerID string
+}
+
+// SidecarSetup prepares a sidecar process before its container is created in Start,
+// e.g. to write a config file that depends on the validator of the process to the validator's volume.
+type SidecarSetup func(ctx context.Context, s *SidecarProcess) error
+
+// AddSidecarSetup sets fn to prepare each sidecar process with the given process name
+// before its container is created in Start.
+// It must be called before Start.
+func (c *CosmosChain) AddSidecarSetup(processName string, fn SidecarSetup) {
+	if c.sidecarSetups == nil {
+		c.sidecarSetups = make(map[string]SidecarSetup)
+	}
+	c.sidecarSetups[processName] = fn
+}
+
+// SidecarProcesses is a collection of SidecarProcess.
+type SidecarProcesses []*SidecarProcess
+
+// ByName returns the sidecar process with the given process name, or nil if there is none.
+func (s SidecarProcesses) ByName(processName string) *SidecarProcess {
+	for _, p := range s {
+		if p.Config.ProcessName == processName {
+			return p
+		}
+	}
+	return nil
+}
+
+// Name of the sidecar container.
+func (s *SidecarProcess) Name() string {
+	owner := s.Chain.Config().ChainID
+	if s.Node != nil {
+		owner = fmt.Sprintf("%s-val-%d", owner, s.Node.Index)
+	}
+	return fmt.Sprintf("%s-%s-%s", owner, s.Config.ProcessName, dockerutil.SanitizeContainerName(s.TestName))
+}
+
+// HostName of the sidecar container on the docker network.
+func (s *SidecarProcess) HostName() string {
+	return dockerutil.CondenseHostName(s.Name())
+}
+
+// Bind returns the home folder bind of the validator if the process shares its volume,
+// followed by the other volumes the process mounts.
+func (s *SidecarProcess) Bind() []string {
+	var binds []string
+	if s.Node != nil && s.Config.ShareNodeVolume {
+		binds = s.Node.Bind()
+	}
+	return append(binds, s.extraBinds...)
+}
+
+func (s *SidecarProcess) logger() *zap.Logger {
+	return s.log.With(
+		zap.String("process", s.Config.ProcessName),
+		zap.String("container", s.Name()),
+	)
+}
+
+// CreateContainer creates the container of the process, removing any previous one.
+func (s *SidecarProcess) CreateContainer(ctx context.Context) error {
+	if err := s.RemoveContainer(ctx); err != nil {
+		return err
+	}
+
+	ports := make(nat.PortSet, len(s.Config.Ports))
+	for _, p := range s.Config.Ports {
+		ports[nat.Port(p)] = struct{}{}
+	}
+
+	imageRef := s.Config.Image.Ref()
+	s.logger().Info("Running command",
+		zap.String("command", strings.Join(s.Config.StartCmd, " ")),
+		zap.String("image", imageRef),
+	)
+
+	cc, err := s.DockerClient.ContainerCreate(
+		ctx,
+		&container.Config{
+			Image: imageRef,
+
+			Entrypoint: []string{},
+			Cmd:        s.Config.StartCmd,
+			Env:        s.Config.Env,
+
+			Hostname: s.HostName(),
+			User:     s.Config.Image.UidGid,
+
+			Labels: dockerutil.NodeResourceLabels(s.TestName, s.Name()),
+
+			ExposedPorts: ports,
+		},
+		&container.HostConfig{
+			Binds:           s.Bind(),
+			PublishAllPorts: true,
+			AutoRemove:      false,
+			DNS:             []string{},
+		},
+		&network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				s.NetworkID: {},
+			},
+		},
+		nil,
+		s.Name(),
+	)
+	if err != nil {
+		return fmt.Errorf("create sidecar container %s: %w", s.Name(), err)
+	}
+	s.containerID = cc.ID
+	return nil
+}
+
+// StartContainer starts the container after it is created by CreateContainer.
+func (s *SidecarProcess) StartContainer(ctx context.Context) error {
+	if err := dockerutil.StartContainer(ctx, s.DockerClient, s.containerID); err != nil {
+		return fmt.Errorf("start sidecar container %s: %w", s.Name(), err)
+	}
+	s.logger().Info("Sidecar process started")
+	return nil
+}
+
+// StopContainer stops the container of the process. The exit is not reported by the container watchdog.
+func (s *SidecarProcess) StopContainer(ctx context.Context) error {
+	timeout := 30 * time.Second
+	return dockerutil.StopContainer(ctx, s.DockerClient, s.containerID, timeout)
+}
+
+// RestartContainer stops and starts the container of the process.
+// Its host ports may change, so they must be retrieved again with GetHostPorts.
+func (s *SidecarProcess) RestartContainer(ctx context.Context) error {
+	if err := s.StopContainer(ctx); err != nil {
+		return err
+	}
+	return s.StartContainer(ctx)
+}
+
+// RemoveContainer removes the container of the process, if it exists.
+func (s *SidecarProcess) RemoveContainer(ctx context.Context) error {
+	if s.containerID == "" {
+		return nil
+	}
+	dockerutil.ExpectContainerStop(s.containerID)
+	err := s.DockerClient.ContainerRemove(ctx, s.containerID, dockertypes.ContainerRemoveOptions{
+		Force: true,
+	})
+	if err != nil && !errdefs.IsNotFound(err) {
+		return fmt.Errorf("remove sidecar container %s: %w", s.Name(), err)
+	}
+	s.containerID = ""
+	return nil
+}
+
+// GetHostPorts returns the host addresses, e.g. 127.0.0.1:32768, of the given container ports of the running process.
+// The address of a port not published by the container is empty.
+func (s *SidecarProcess) GetHostPorts(ctx context.Context, portIDs ...string) ([]string, error) {
+	c, err := s.DockerClient.ContainerInspect(ctx, s.containerID)
+	if err != nil {
+		return nil, fmt.Errorf("inspect sidecar container %s: %w", s.Name(), err)
+	}
+	hostPorts := make([]string, len(portIDs))
+	for i, p := range portIDs {
+		hostPorts[i] = dockerutil.GetHostPort(c, p)
+		if chain, ok := s.Chain.(*CosmosChain); ok {
+			hostPorts[i] = dockerutil.HostPortOn(hostPorts[i], chain.hostAddress)
+		}
+	}
+	return hostPorts, nil
+}
+
+// Exec runs cmd in a new container of the process image, with the process environment followed by env,
+// and the validator's volume if the process shares it.
+func (s *SidecarProcess) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
+	job := dockerutil.NewImage(s.logger(), s.DockerClient, s.NetworkID, s.TestName, s.Config.Image.Repository, s.Config.Image.Version)
+	opts := dockerutil.ContainerOptions{
+		Env:   append(append([]string(nil), s.Config.Env...), env...),
+		Binds: s.Bind(),
+		User:  s.Config.Image.UidGid,
+	}
+	res := job.Run(ctx, cmd, opts)
+	return res.Stdout, res.Stderr, res.Err
+}
+
+// initializeSidecars creates the sidecar processes of the chain and of its validators, once.
+func (c *CosmosChain) initializeSidecars(testName string, cli *dockerclient.Client, networkID string) error {
+	names := make(map[string]bool)
+	for _, cfg := range c.cfg.SidecarConfigs {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if names[cfg.ProcessName] {
+			return fmt.Errorf("duplicate sidecar process name %s", cfg.ProcessName)
+		}
+		names[cfg.ProcessName] = true
+	}
+
+	newSidecar := func(cfg ibc.SidecarConfig, node *ChainNode) *SidecarProcess {
+		return &SidecarProcess{
+			Config:       cfg.Clone(),
+			Chain:        c,
+			NetworkID:    networkID,
+			DockerClient: cli,
+			TestName:     testName,
+			Node:         node,
+			log:          c.log,
+		}
+	}
+	for _, cfg := range c.cfg.SidecarConfigs {
+		if !cfg.ValidatorProcess {
+			if c.Sidecars.ByName(cfg.ProcessName) == nil {
+				c.Sidecars = append(c.Sidecars, newSidecar(cfg, nil))
+			}
+			continue
+		}
+		for _, v := range c.Validators {
+			if v.Sidecars.ByName(cfg.ProcessName) == nil {
+				v.Sidecars = append(v.Sidecars, newSidecar(cfg, v))
+			}
+		}
+	}
+	return nil
+}
+
+// allSidecars returns the sidecar processes of the chain followed by those of its validators.
+func (c *CosmosChain) allSidecars() SidecarProcesses {
+	sidecars := append(SidecarProcesses(nil), c.Sidecars...)
+	for _, v := range c.Validators {
+		sidecars = append(sidecars, v.Sidecars...)
+	}
+	return sidecars
+}
+
+// startSidecars creates and starts the containers of the sidecar processes whose PreStart is preStart.
+func (c *CosmosChain) startSidecars(ctx context.Context, preStart bool) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, s := range c.allSidecars() {
+		s := s
+		if s.Config.PreStart != preStart {
+			continue
+		}
+		eg.Go(func() error {
+			if setup, ok := c.sidecarSetups[s.Config.ProcessName]; ok {
+				if err := setup(egCtx, s); err != nil {
+					return fmt.Errorf("set up sidecar %s: %w", s.Name(), err)
+				}
+			}
+			if err := s.CreateContainer(egCtx); err != nil {
+				return err
+			}
+			return s.StartContainer(egCtx)
+		})
+	}
+	return eg.Wait()
+}
@@ -0,0 +1,154 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+)
+
+// ValidatorConsAddress returns the bech32 consensus address of the node's validator, e.g. cosmosvalcons1...
+func (tn *ChainNode) ValidatorConsAddress(ctx context.Context) (string, error) {
+	stat, err := tn.Client.Status(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get status of node %s: %w", tn.Name(), err)
+	}
+	return types.Bech32ifyAddressBytes(tn.Chain.Config().Bech32Prefix+types.PrefixValidator+types.PrefixConsensus, stat.ValidatorInfo.Address)
+}
+
+// ValidatorOperatorAddress returns the bech32 operator address of the node's validator, e.g. cosmosvaloper1...
+func (tn *ChainNode) ValidatorOperatorAddress(ctx context.Context) (string, error) {
+	return tn.KeyBech32(ctx, valKey, "val")
+}
+
+// ValidatorWallet returns the wallet of the node's validator key, which operates the validator.
+func (tn *ChainNode) ValidatorWallet(ctx context.Context) (ibc.Wallet, error) {
+	b, err := tn.validatorBroadcaster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return b.Wallet(), nil
+}
+
+// JailValidator stops the validator at index valIndex in Validators until it is jailed for downtime,
+// then starts it again, so that it can be unjailed with Unjail once the downtime jail duration has passed.
+// The chain must keep producing blocks without the validator,
+// i.e. the other validators must have more than 2/3 of the voting power.
+//
+// Jailing takes about one signed-blocks window of the slashing params,
+// so tests typically shorten the window through a genesis modification.
+func (c *CosmosChain) JailValidator(ctx context.Context, valIndex int) error {
+	if valIndex < 0 || valIndex >= len(c.Validators) {
+		return fmt.Errorf("validator index %d out of range for %d validators", valIndex, len(c.Validators))
+	}
+	v := c.Validators[valIndex]
+
+	valoper, err := v.ValidatorOperatorAddress(ctx)
+	if err != nil {
+		return err
+	}
+	params, err := c.QuerySlashingParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := v.StopContainer(ctx); err != nil {
+		return fmt.Errorf("failed to stop validator %s: %w", v.Name(), err)
+	}
+
+	// The validator is jailed once it misses more than (1 - min signed per window) of the window,
+	// so waiting for a window and some margin is enough.
+	maxBlocks := params.SignedBlocksWindow + 10
+	for i := int64(0); ; i++ {
+		val, err := c.QueryValidator(ctx, valoper)
+		if err != nil {
+			return err
+		}
+		if val.Jailed {
+			break
+		}
+		if i == maxBlocks {
+			return fmt.Errorf("validator %s not jailed after %d blocks", valoper, maxBlocks)
+		}
+		if err := testutil.WaitForBlocks(ctx, 1, c); err != nil {
+			return err
+		}
+	}
+
+	if err := v.StartContainer(ctx); err != nil {
+		return fmt.Errorf("failed to restart validator %s: %w", v.Name(), err)
+	}
+	return nil
+}
+
+// Unjail unjails the validator operated by wallet.
+// The wallet is either a validator's wallet from ChainNode.ValidatorWallet,
+// or a wallet whose key is in the keyring of the node signing the chain's transactions.
+func (c *CosmosChain) Unjail(ctx context.Context, wallet ibc.Wallet) error {
+	for _, v := range c.Validators {
+		b, err := v.validatorBroadcaster(ctx)
+		if err != nil {
+			return err
+		}
+		if b.Wallet().FormattedAddress() == wallet.FormattedAddress() {
+			_, err := b.ExecTx(ctx, "slashing", "unjail")
+			return err
+		}
+	}
+	_, err := c.getFullNode().ExecTx(ctx, wallet.KeyName(), "slashing", "unjail")
+	return err
+}
+
+// QueryValidator returns the staking state of the validator with the given operator address,
+// including whether it is jailed and its bonded tokens.
+func (c *CosmosChain) QueryValidator(ctx context.Context, valoper string) (stakingtypes.Validator, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return stakingtypes.Validator{}, err
+	}
+	defer conn.Close()
+
+	res, err := stakingtypes.NewQueryClient(conn).Validator(ctx, &stakingtypes.QueryValidatorRequest{ValidatorAddr: valoper})
+	if err != nil {
+		return stakingtypes.Validator{}, fmt.Errorf("query validator: %w", err)
+	}
+	return res.Validator, nil
+}
+
+// QueryValidatorSigningInfo returns the slashing signing info of the validator with the given consensus address,
+// e.g. from ChainNode.ValidatorConsAddress, including until when it is jailed.
+func (c *CosmosChain) QueryValidatorSigningInfo(ctx context.Context, valcons string) (slashingtypes.ValidatorSigningInfo, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return slashingtypes.ValidatorSigningInfo{}, err
+	}
+	defer conn.Close()
+
+	res, err := slashingtypes.NewQueryClient(conn).SigningInfo(ctx, &slashingtypes.QuerySigningInfoRequest{ConsAddress: valcons})
+	if err != nil {
+		return slashingtypes.ValidatorSigningInfo{}, fmt.Errorf("query signing info: %w", err)
+	}
+	return res.ValSigningInfo, nil
+}
+
+// QuerySlashingParams returns the chain's slashing params, such as the downtime window and slash fraction.
+func (c *CosmosChain) QuerySlashingParams(ctx context.Context) (slashingtypes.Params, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return slashingtypes.Params{}, err
+	}
+	defer conn.Close()
+
+	res, err := slashingtypes.NewQueryClient(conn).Params(ctx, &slashingtypes.QueryParamsRequest{})
+	if err != nil {
+		return slashingtypes.Params{}, fmt.Errorf("query slashing params: %w", err)
+	}
+	return res.Params, nil
+}
@@ -0,0 +1,49 @@
+// Command gen writes the channel upgrade messages of testdata/channelupgrade, encoded with the types of ibc-go v8.1,
+// which the interchaintest module cannot depend on. Run it with go mod tidy && go run . from this directory.
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+)
+
+func main() {
+	reg := codectypes.NewInterfaceRegistry()
+	channeltypes.RegisterInterfaces(reg)
+	cdc := codec.NewProtoCodec(reg)
+
+	fields := channeltypes.NewUpgradeFields(channeltypes.UNORDERED, []string{"connection-0"}, `{"fee_version":"ics29-1","app_version":"ics20-1"}`)
+	upgrade := channeltypes.NewUpgrade(fields, channeltypes.NewTimeout(clienttypes.NewHeight(1, 500), 1_700_000_000_000_000_000), 3)
+	proofHeight := clienttypes.NewHeight(1, 42)
+	// The signer of the upgrade init is the gov module account of chains with the cosmos bech32 prefix,
+	// that of the other messages is any account.
+	const signer = "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu"
+
+	msgs := map[string]sdk.Msg{
+		"msg_channel_upgrade_init.json": channeltypes.NewMsgChannelUpgradeInit("transfer", "channel-0", fields,
+			"cosmos10d07y265gmmuvt4z0w9aw880jnsr700j6zn9kn"),
+		"msg_channel_upgrade_try.json": channeltypes.NewMsgChannelUpgradeTry("transfer", "channel-1", []string{"connection-1"},
+			fields, 1, []byte("proof of channel"), []byte("proof of upgrade"), proofHeight, signer),
+		"msg_channel_upgrade_ack.json": channeltypes.NewMsgChannelUpgradeAck("transfer", "channel-1",
+			upgrade, []byte("proof of channel"), []byte("proof of upgrade"), proofHeight, signer),
+		"msg_channel_upgrade_confirm.json": channeltypes.NewMsgChannelUpgradeConfirm("transfer", "channel-1", channeltypes.FLUSHCOMPLETE,
+			upgrade, []byte("proof of channel"), []byte("proof of upgrade"), proofHeight, signer),
+		"msg_channel_upgrade_open.json": channeltypes.NewMsgChannelUpgradeOpen("transfer", "channel-1", channeltypes.OPEN,
+			1, []byte("proof of channel"), proofHeight, signer),
+	}
+	for name, msg := range msgs {
+		bz, err := cdc.MarshalInterfaceJSON(msg)
+		if err != nil {
+			panic(err)
+		}
+		if err := os.WriteFile(filepath.Join("..", name), append(bz, '\n'), 0o644); err != nil {
+			panic(err)
+		}
+	}
+}
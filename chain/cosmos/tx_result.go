@@ -0,0 +1,121 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	authTx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+)
+
+// TxResult is the outcome of a committed transaction, as reported by the chain.
+type TxResult struct {
+	// The transaction hash.
+	TxHash string
+	// The block height.
+	Height int64
+	// Gas requested by the transaction.
+	GasWanted int64
+	// Gas actually consumed by the transaction.
+	GasUsed int64
+	// Fees paid for the transaction.
+	FeePaid types.Coins
+}
+
+// Gas returns the gas of the transaction, e.g. for testutil.AssertGasWithin.
+func (r TxResult) Gas() testutil.TxGas {
+	return testutil.TxGas{GasWanted: r.GasWanted, GasUsed: r.GasUsed}
+}
+
+// MeasureGas runs op, which must broadcast exactly one transaction with the context it is given,
+// e.g. a transfer or a delegation with the chain's tx helpers, and returns the result of that transaction.
+func MeasureGas(ctx context.Context, op func(ctx context.Context) error) (TxResult, error) {
+	var r TxRecorder
+	if err := op(WithTxRecorder(ctx, &r)); err != nil {
+		return TxResult{}, err
+	}
+	results := r.Results()
+	if len(results) != 1 {
+		return TxResult{}, fmt.Errorf("measured operation broadcast %d transactions, expected 1", len(results))
+	}
+	return results[0], nil
+}
+
+// TxRecorder collects the results of the transactions broadcast by the chain's tx helpers,
+// such as SendFunds, SendIBCTransfer, governance, staking, or contract transactions.
+// Attach it to a context with WithTxRecorder.
+type TxRecorder struct {
+	mu      sync.Mutex
+	results []TxResult
+}
+
+// Results returns the results recorded so far, in broadcast order,
+// except for concurrent transactions, which are recorded as they are committed.
+func (r *TxRecorder) Results() []TxResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]TxResult(nil), r.results...)
+}
+
+// Last returns the most recently recorded result.
+// It returns false if no transaction has been recorded.
+func (r *TxRecorder) Last() (TxResult, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.results) == 0 {
+		return TxResult{}, false
+	}
+	return r.results[len(r.results)-1], true
+}
+
+func (r *TxRecorder) record(res TxResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results = append(r.results, res)
+}
+
+type txRecorderKey struct{}
+
+// WithTxRecorder returns a context that records the result of every successful transaction
+// broadcast with it into r.
+func WithTxRecorder(ctx context.Context, r *TxRecorder) context.Context {
+	return context.WithValue(ctx, txRecorderKey{}, r)
+}
+
+func txRecorderFromContext(ctx context.Context) *TxRecorder {
+	r, _ := ctx.Value(txRecorderKey{}).(*TxRecorder)
+	return r
+}
+
+// TxResult queries the committed transaction with the given hash.
+func (tn *ChainNode) TxResult(txHash string) (TxResult, error) {
+	txResp, err := authTx.QueryTx(tn.CliContext(), txHash)
+	if err != nil {
+		return TxResult{}, fmt.Errorf("query tx %s: %w", txHash, err)
+	}
+	return newTxResult(txResp), nil
+}
+
+func newTxResult(txResp *types.TxResponse) TxResult {
+	res := TxResult{
+		TxHash:    txResp.TxHash,
+		Height:    txResp.Height,
+		GasWanted: txResp.GasWanted,
+		GasUsed:   txResp.GasUsed,
+	}
+	if feeTx, ok := txResp.GetTx().(types.FeeTx); ok {
+		res.FeePaid = feeTx.GetFee()
+	}
+	return res
+}
+
+// GetTxResult returns the gas, fees, and height of the committed transaction with the given hash.
+func (c *CosmosChain) GetTxResult(txHash string) (TxResult, error) {
+	txResp, err := c.getTransaction(txHash)
+	if err != nil {
+		return TxResult{}, fmt.Errorf("failed to get transaction %s: %w", txHash, err)
+	}
+	return newTxResult(txResp), nil
+}
@@ -0,0 +1,171 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// txSearchPerPage is the page size of TxSearch when the caller does not set one, the maximum of the RPC.
+const txSearchPerPage = 100
+
+// TxSearchResult is a transaction found by TxSearch.
+type TxSearchResult struct {
+	// The block height.
+	Height int64
+	// The transaction hash.
+	TxHash string
+	// The result code, 0 on success.
+	Code uint32
+	// Events emitted by the transaction.
+	Events []abcitypes.Event
+}
+
+// TxSearch returns the transactions matching the tendermint event query, such as "message.sender='cosmos1...'",
+// in ascending order of height, paginated by perPage transactions per page.
+// A page of 0 returns the transactions of all pages, and a perPage of 0 defaults to 100.
+func (c *CosmosChain) TxSearch(ctx context.Context, query string, page, perPage int) ([]TxSearchResult, error) {
+	return c.getFullNode().TxSearch(ctx, query, page, perPage)
+}
+
+// TxSearch returns the transactions matching the event query as indexed by the node, see CosmosChain.TxSearch.
+func (tn *ChainNode) TxSearch(ctx context.Context, query string, page, perPage int) ([]TxSearchResult, error) {
+	if perPage == 0 {
+		perPage = txSearchPerPage
+	}
+	if page != 0 {
+		results, _, err := tn.txSearchPage(ctx, query, page, perPage)
+		return results, err
+	}
+
+	var all []TxSearchResult
+	for page := 1; ; page++ {
+		results, total, err := tn.txSearchPage(ctx, query, page, perPage)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, results...)
+		if len(results) == 0 || len(all) >= total {
+			return all, nil
+		}
+	}
+}
+
+// txSearchPage returns a single page of TxSearch, and the total number of matching transactions.
+func (tn *ChainNode) txSearchPage(ctx context.Context, query string, page, perPage int) ([]TxSearchResult, int, error) {
+	res, err := tn.Client.TxSearch(ctx, query, false, &page, &perPage, "asc")
+	if err != nil {
+		return nil, 0, fmt.Errorf("tx search %q page %d: %w", query, page, err)
+	}
+	results := make([]TxSearchResult, len(res.Txs))
+	for i, tx := range res.Txs {
+		results[i] = TxSearchResult{
+			Height: tx.Height,
+			TxHash: tx.Hash.String(),
+			Code:   tx.TxResult.Code,
+			Events: tx.TxResult.Events,
+		}
+	}
+	return results, res.TotalCount, nil
+}
+
+// TxSearchRange returns the transactions matching the tendermint event query, such as "send_packet.packet_src_channel='channel-0'",
+// in blocks from minHeight to maxHeight inclusive, in ascending order of height, e.g. to count the IBC events
+// emitted over a phase of a test. A maxHeight of 0 has no upper bound.
+func (c *CosmosChain) TxSearchRange(ctx context.Context, query string, minHeight, maxHeight int64) ([]TxSearchResult, error) {
+	if minHeight < 1 {
+		return nil, fmt.Errorf("min height must be positive, got %d", minHeight)
+	}
+	if maxHeight != 0 && maxHeight < minHeight {
+		return nil, fmt.Errorf("max height %d is below min height %d", maxHeight, minHeight)
+	}
+	query = fmt.Sprintf("%s AND tx.height>=%d", query, minHeight)
+	if maxHeight != 0 {
+		query = fmt.Sprintf("%s AND tx.height<=%d", query, maxHeight)
+	}
+	return c.TxSearch(ctx, query, 0, 0)
+}
+
+// CountEvents returns the number of events of the type eventType, such as send_packet, emitted by the transactions txs.
+func CountEvents(txs []TxSearchResult, eventType string) int {
+	n := 0
+	for _, tx := range txs {
+		for _, e := range tx.Events {
+			if e.Type == eventType {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// FindTxsBySender returns all transactions with a message signed by the sender address, in ascending order of height.
+func (c *CosmosChain) FindTxsBySender(ctx context.Context, sender string) ([]TxSearchResult, error) {
+	return c.TxSearch(ctx, fmt.Sprintf("message.sender='%s'", sender), 0, 0)
+}
+
+// FindPacketsByMemo returns all ICS-20 transfer packets sent by the chain with the given memo, in order,
+// e.g. to find the transfers of a subtest tagging its transfers with a unique memo.
+// The memo must not contain single quotes, which the event query cannot express,
+// and memos with characters escaped in JSON, such as double quotes, are not found.
+func (c *CosmosChain) FindPacketsByMemo(ctx context.Context, memo string) ([]ibc.Packet, error) {
+	if strings.Contains(memo, "'") {
+		return nil, fmt.Errorf("memo %q must not contain single quotes", memo)
+	}
+
+	// The query matches the memo anywhere in the packet data, so compare it exactly below.
+	results, err := c.TxSearch(ctx, fmt.Sprintf("send_packet.packet_data CONTAINS '%s'", memo), 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var packets []ibc.Packet
+	for _, res := range results {
+		sent, err := sendPacketsFromEvents(res.Events)
+		if err != nil {
+			return nil, fmt.Errorf("tx %s: %w", res.TxHash, err)
+		}
+		for _, p := range sent {
+			var data struct {
+				Memo string `json:"memo"`
+			}
+			if err := json.Unmarshal(p.Data, &data); err != nil || data.Memo != memo {
+				continue
+			}
+			packets = append(packets, p)
+		}
+	}
+	return packets, nil
+}
+
+// ReceivedPackets returns the packets received by the chain on its channel end dstChannelID, in the order
+// the chain received them, from the recv_packet events of successful transactions, e.g. for testutil.AssertOrderedDelivery.
+// Packets that were already received, e.g. relayed again by a second relayer, emit no events and are not returned.
+func (c *CosmosChain) ReceivedPackets(ctx context.Context, dstChannelID string) ([]ibc.Packet, error) {
+	results, err := c.TxSearch(ctx, fmt.Sprintf("recv_packet.packet_dst_channel='%s'", dstChannelID), 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var packets []ibc.Packet
+	for _, res := range results {
+		if res.Code != 0 {
+			continue
+		}
+		received, err := packetsFromEvents(res.Events, "recv_packet")
+		if err != nil {
+			return nil, fmt.Errorf("tx %s: %w", res.TxHash, err)
+		}
+		// A transaction may receive packets on other channels as well.
+		for _, p := range received {
+			if p.DestChannel == dstChannelID {
+				packets = append(packets, p)
+			}
+		}
+	}
+	return packets, nil
+}
@@ -0,0 +1,34 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestTxSearchRange_InvalidRange(t *testing.T) {
+	c := cosmos.NewCosmosChain(t.Name(), ibc.ChainConfig{ChainID: "test-1"}, 1, 0, zaptest.NewLogger(t))
+	ctx := context.Background()
+
+	_, err := c.TxSearchRange(ctx, "tx.height>0", 0, 10)
+	require.ErrorContains(t, err, "min height must be positive")
+	_, err = c.TxSearchRange(ctx, "tx.height>0", 10, 9)
+	require.ErrorContains(t, err, "below min height")
+}
+
+func TestCountEvents(t *testing.T) {
+	txs := []cosmos.TxSearchResult{
+		{Events: []abcitypes.Event{{Type: "send_packet"}, {Type: "message"}, {Type: "send_packet"}}},
+		{Events: []abcitypes.Event{{Type: "message"}}},
+		{Events: []abcitypes.Event{{Type: "send_packet"}}},
+	}
+	require.Equal(t, 3, cosmos.CountEvents(txs, "send_packet"))
+	require.Equal(t, 2, cosmos.CountEvents(txs, "message"))
+	require.Zero(t, cosmos.CountEvents(txs, "recv_packet"))
+	require.Zero(t, cosmos.CountEvents(nil, "send_packet"))
+}
@@ -1,5 +1,7 @@
 package cosmos
 
+import "time"
+
 const (
 	ProposalVoteYes        = "yes"
 	ProposalVoteNo         = "no"
@@ -49,6 +51,46 @@ type SoftwareUpgradeProposal struct {
 	Info        string // optional
 }
 
+// IBCUpgradeProposal defines the required parameters for submitting an ibc-upgrade proposal,
+// a software upgrade that also schedules the upgrade of the IBC clients of the chain on counterparty chains.
+type IBCUpgradeProposal struct {
+	Deposit     string
+	Title       string
+	Name        string
+	Description string
+	Height      uint64
+	// Chain ID the chain restarts with after the upgrade, e.g. mychain-2 to bump the revision of mychain-1.
+	UpgradedChainID string
+	// Unbonding period of the upgraded chain, defaults to that of the chain.
+	UnbondingPeriod time.Duration
+}
+
+// ParamChangeProposal defines the required parameters for submitting a param-change proposal.
+type ParamChangeProposal struct {
+	Deposit     string
+	Title       string
+	Description string
+	Changes     []ParamChange
+}
+
+// ChannelUpgradeProposal defines the required parameters for submitting a proposal that initiates a channel upgrade.
+type ChannelUpgradeProposal struct {
+	Deposit   string
+	Title     string
+	Summary   string
+	PortID    string
+	ChannelID string
+	Fields    ChannelUpgradeFields
+}
+
+// ParamChange is a single change of a module parameter in a ParamChangeProposal.
+type ParamChange struct {
+	Subspace string `json:"subspace"`
+	Key      string `json:"key"`
+	// Value is marshaled to the JSON value of the parameter.
+	Value any `json:"value"`
+}
+
 // ProposalResponse is the proposal query response.
 type ProposalResponse struct {
 	ProposalID       string                   `json:"proposal_id"`
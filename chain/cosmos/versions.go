@@ -0,0 +1,40 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+)
+
+// QueryModuleVersions returns the consensus version of each module of the chain, keyed by module name,
+// as recorded by the upgrade module.
+// A module's version is bumped by the migrations of an upgrade, so comparing the versions before and after
+// an upgrade confirms that its migrations ran.
+func (c *CosmosChain) QueryModuleVersions(ctx context.Context) (map[string]uint64, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := upgradetypes.NewQueryClient(conn).ModuleVersions(ctx, &upgradetypes.QueryModuleVersionsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("query module versions: %w", err)
+	}
+	versions := make(map[string]uint64, len(res.ModuleVersions))
+	for _, v := range res.ModuleVersions {
+		versions[v.Name] = v.Version
+	}
+	return versions, nil
+}
+
+// AppVersion returns the software version that the running app reports over ABCI, e.g. "v8.0.0".
+func (c *CosmosChain) AppVersion(ctx context.Context) (string, error) {
+	res, err := c.getFullNode().Client.ABCIInfo(ctx)
+	if err != nil {
+		return "", fmt.Errorf("abci info: %w", err)
+	}
+	return res.Response.Version, nil
+}
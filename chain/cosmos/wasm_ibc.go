@@ -0,0 +1,100 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+)
+
+// WasmPortID returns the IBC port bound by wasmd to a contract that implements the IBC entry points.
+func WasmPortID(contractAddress string) string {
+	return "wasm." + contractAddress
+}
+
+// CreateWasmChannel opens a channel between the IBC enabled contracts contractA on chainA and contractB on chainB
+// with the given version and order, and returns the channel ends on chainA and chainB.
+//
+// The relayer must have created the clients and connection of pathName,
+// and chainA must be the source chain of the path, i.e. Chain1 of its InterchainLink.
+// The handshake is executed by the relayer, so the relayer does not need to be running.
+// The contracts' channel open callbacks may reject the version, in which case the channel is not opened.
+func CreateWasmChannel(
+	ctx context.Context,
+	r ibc.Relayer,
+	rep ibc.RelayerExecReporter,
+	pathName string,
+	chainA, chainB ibc.Chain,
+	contractA, contractB string,
+	version string,
+	order ibc.Order,
+) (ibc.ChannelOutput, ibc.ChannelOutput, error) {
+	portA, portB := WasmPortID(contractA), WasmPortID(contractB)
+	chainAID, chainBID := chainA.Config().ChainID, chainB.Config().ChainID
+
+	channels, err := r.GetChannels(ctx, rep, chainAID)
+	if err != nil {
+		return ibc.ChannelOutput{}, ibc.ChannelOutput{}, fmt.Errorf("get channels on %s: %w", chainAID, err)
+	}
+	existing := make(map[string]bool)
+	for _, ch := range channels {
+		if ch.PortID == portA {
+			existing[ch.ChannelID] = true
+		}
+	}
+
+	if err := r.CreateChannel(ctx, rep, pathName, ibc.CreateChannelOptions{
+		SourcePortName: portA,
+		DestPortName:   portB,
+		Order:          order,
+		Version:        version,
+	}); err != nil {
+		return ibc.ChannelOutput{}, ibc.ChannelOutput{}, fmt.Errorf("create channel between %s and %s on path %s: %w", portA, portB, pathName, err)
+	}
+
+	channels, err = r.GetChannels(ctx, rep, chainAID)
+	if err != nil {
+		return ibc.ChannelOutput{}, ibc.ChannelOutput{}, fmt.Errorf("get channels on %s: %w", chainAID, err)
+	}
+	var chA *ibc.ChannelOutput
+	for i, ch := range channels {
+		if ch.PortID == portA && ch.Counterparty.PortID == portB && !existing[ch.ChannelID] && ch.State == ibc.ChannelStateOpen {
+			chA = &channels[i]
+			break
+		}
+	}
+	if chA == nil {
+		return ibc.ChannelOutput{}, ibc.ChannelOutput{}, fmt.Errorf("no new open channel on %s port %s with counterparty port %s", chainAID, portA, portB)
+	}
+
+	channels, err = r.GetChannels(ctx, rep, chainBID)
+	if err != nil {
+		return ibc.ChannelOutput{}, ibc.ChannelOutput{}, fmt.Errorf("get channels on %s: %w", chainBID, err)
+	}
+	for _, ch := range channels {
+		if ch.PortID == portB && ch.ChannelID == chA.Counterparty.ChannelID {
+			return *chA, ch, nil
+		}
+	}
+	return ibc.ChannelOutput{}, ibc.ChannelOutput{}, fmt.Errorf("counterparty channel %s of %s/%s not found on %s", chA.Counterparty.ChannelID, portA, chA.ChannelID, chainBID)
+}
+
+// PollForContractState polls the smart query of a contract until fn returns true for the response,
+// e.g. to wait for a contract's IBC callbacks having updated its state.
+// Failed queries are retried, since contracts commonly return errors for state that is not set yet.
+func PollForContractState[T any](ctx context.Context, chain *CosmosChain, startHeight, maxHeight uint64, contractAddress string, queryMsg any, fn func(T) bool) (T, error) {
+	var zero T
+	doPoll := func(ctx context.Context, height uint64) (T, error) {
+		var res T
+		if err := chain.QueryContract(ctx, contractAddress, queryMsg, &res); err != nil {
+			return zero, err
+		}
+		if !fn(res) {
+			return zero, fmt.Errorf("state of contract %s does not match expected: %+v", contractAddress, res)
+		}
+		return res, nil
+	}
+	bp := testutil.BlockPoller[T]{CurrentHeight: chain.Height, PollFunc: doPoll}
+	return bp.DoPoll(ctx, startHeight, maxHeight)
+}
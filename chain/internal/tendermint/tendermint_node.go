@@ -230,7 +230,7 @@ func (tn *TendermintNode) CreateNodeContainer(ctx context.Context, additionalFla
 
 			Hostname: tn.HostName(),
 
-			Labels: map[string]string{dockerutil.CleanupLabel: tn.TestName},
+			Labels: dockerutil.NodeResourceLabels(tn.TestName, tn.Name()),
 
 			ExposedPorts: sentryPorts,
 		},
@@ -257,7 +257,7 @@ func (tn *TendermintNode) CreateNodeContainer(ctx context.Context, additionalFla
 
 func (tn *TendermintNode) StopContainer(ctx context.Context) error {
 	timeout := 30 * time.Second
-	return tn.DockerClient.ContainerStop(ctx, tn.containerID, &timeout)
+	return dockerutil.StopContainer(ctx, tn.DockerClient, tn.containerID, timeout)
 }
 
 func (tn *TendermintNode) StartContainer(ctx context.Context) error {
@@ -370,5 +370,7 @@ func (tn *TendermintNode) logger() *zap.Logger {
 	return tn.Log.With(
 		zap.String("chain_id", tn.Chain.Config().ChainID),
 		zap.String("test", tn.TestName),
+		zap.Int("node_index", tn.Index),
+		zap.String("container", tn.Name()),
 	)
 }
@@ -52,6 +52,15 @@ func (p *PenumbraAppNode) Name() string {
 	return fmt.Sprintf("pd-%d-%s-%s", p.Index, p.Chain.Config().ChainID, p.TestName)
 }
 
+func (p *PenumbraAppNode) logger() *zap.Logger {
+	return p.log.With(
+		zap.String("chain_id", p.Chain.Config().ChainID),
+		zap.String("test", p.TestName),
+		zap.Int("node_index", p.Index),
+		zap.String("container", p.Name()),
+	)
+}
+
 // the hostname of the test node container
 func (p *PenumbraAppNode) HostName() string {
 	return dockerutil.CondenseHostName(p.Name())
@@ -116,7 +125,7 @@ func (p *PenumbraAppNode) AllocationsInputFileContainer() string {
 }
 
 func (p *PenumbraAppNode) genesisFileContent(ctx context.Context) ([]byte, error) {
-	fr := dockerutil.NewFileRetriever(p.log, p.DockerClient, p.TestName)
+	fr := dockerutil.NewFileRetriever(p.logger(), p.DockerClient, p.TestName)
 	gen, err := fr.SingleFileContent(ctx, p.VolumeName, ".penumbra/testnet_data/node0/tendermint/config/genesis.json")
 	if err != nil {
 		return nil, fmt.Errorf("error getting genesis.json content: %w", err)
@@ -135,7 +144,7 @@ func (p *PenumbraAppNode) GenerateGenesisFile(
 	if err != nil {
 		return fmt.Errorf("error marshalling validators to json: %w", err)
 	}
-	fw := dockerutil.NewFileWriter(p.log, p.DockerClient, p.TestName)
+	fw := dockerutil.NewFileWriter(p.logger(), p.DockerClient, p.TestName)
 	if err := fw.WriteFile(ctx, p.VolumeName, "validators.json", validatorsJson); err != nil {
 		return fmt.Errorf("error writing validators to file: %w", err)
 	}
@@ -227,7 +236,7 @@ func (p *PenumbraAppNode) CreateNodeContainer(ctx context.Context) error {
 			Hostname: p.HostName(),
 			User:     p.Image.UidGid,
 
-			Labels: map[string]string{dockerutil.CleanupLabel: p.TestName},
+			Labels: dockerutil.NodeResourceLabels(p.TestName, p.Name()),
 
 			ExposedPorts: exposedPorts,
 		},
@@ -254,7 +263,7 @@ func (p *PenumbraAppNode) CreateNodeContainer(ctx context.Context) error {
 
 func (p *PenumbraAppNode) StopContainer(ctx context.Context) error {
 	timeout := 30 * time.Second
-	return p.DockerClient.ContainerStop(ctx, p.containerID, &timeout)
+	return dockerutil.StopContainer(ctx, p.DockerClient, p.containerID, timeout)
 }
 
 func (p *PenumbraAppNode) StartContainer(ctx context.Context) error {
@@ -275,7 +284,7 @@ func (p *PenumbraAppNode) StartContainer(ctx context.Context) error {
 
 // Exec run a container for a specific job and block until the container exits
 func (p *PenumbraAppNode) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
-	job := dockerutil.NewImage(p.log, p.DockerClient, p.NetworkID, p.TestName, p.Image.Repository, p.Image.Version)
+	job := dockerutil.NewImage(p.logger(), p.DockerClient, p.NetworkID, p.TestName, p.Image.Repository, p.Image.Version)
 	opts := dockerutil.ContainerOptions{
 		Binds: p.Bind(),
 		Env:   env,
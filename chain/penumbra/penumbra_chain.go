@@ -92,6 +92,13 @@ func (c *PenumbraChain) Config() ibc.ChainConfig {
 	return c.cfg
 }
 
+func (c *PenumbraChain) logger() *zap.Logger {
+	return c.log.With(
+		zap.String("chain_id", c.cfg.ChainID),
+		zap.String("test", c.testName),
+	)
+}
+
 // Implements Chain interface
 func (c *PenumbraChain) Initialize(ctx context.Context, testName string, cli *client.Client, networkID string) error {
 	return c.initializeChainNodes(ctx, testName, cli, networkID)
@@ -255,7 +262,7 @@ func (c *PenumbraChain) initializeChainNodes(
 			types.ImagePullOptions{},
 		)
 		if err != nil {
-			c.log.Error("Failed to pull image",
+			c.logger().Error("Failed to pull image",
 				zap.Error(err),
 				zap.String("repository", image.Repository),
 				zap.String("tag", image.Version),
@@ -270,18 +277,14 @@ func (c *PenumbraChain) initializeChainNodes(
 			DockerClient: cli, NetworkID: networkID, TestName: testName, Image: chainCfg.Images[0]}
 
 		tv, err := cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
-			Labels: map[string]string{
-				dockerutil.CleanupLabel: testName,
-
-				dockerutil.NodeOwnerLabel: tn.Name(),
-			},
+			Labels: dockerutil.NodeResourceLabels(testName, tn.Name()),
 		})
 		if err != nil {
 			return fmt.Errorf("creating tendermint volume: %w", err)
 		}
 		tn.VolumeName = tv.Name
 		if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
-			Log: c.log,
+			Log: c.logger(),
 
 			Client: cli,
 
@@ -296,18 +299,14 @@ func (c *PenumbraChain) initializeChainNodes(
 		pn := &PenumbraAppNode{log: c.log, Index: i, Chain: c,
 			DockerClient: cli, NetworkID: networkID, TestName: testName, Image: chainCfg.Images[1]}
 		pv, err := cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
-			Labels: map[string]string{
-				dockerutil.CleanupLabel: testName,
-
-				dockerutil.NodeOwnerLabel: pn.Name(),
-			},
+			Labels: dockerutil.NodeResourceLabels(testName, pn.Name()),
 		})
 		if err != nil {
 			return fmt.Errorf("creating penumbra volume: %w", err)
 		}
 		pn.VolumeName = pv.Name
 		if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
-			Log: c.log,
+			Log: c.logger(),
 
 			Client: cli,
 
@@ -363,7 +362,7 @@ func (c *PenumbraChain) Start(testName string, ctx context.Context, additionalGe
 			if err := v.TendermintNode.InitValidatorFiles(egCtx); err != nil {
 				return fmt.Errorf("error initializing validator files: %v", err)
 			}
-			fr := dockerutil.NewFileRetriever(c.log, v.TendermintNode.DockerClient, v.TendermintNode.TestName)
+			fr := dockerutil.NewFileRetriever(c.logger(), v.TendermintNode.DockerClient, v.TendermintNode.TestName)
 			privValKeyBytes, err := fr.SingleFileContent(egCtx, v.TendermintNode.VolumeName, "config/priv_validator_key.json")
 			if err != nil {
 				return fmt.Errorf("error reading tendermint privval key file: %v", err)
@@ -381,7 +380,7 @@ func (c *PenumbraChain) Start(testName string, ctx context.Context, additionalGe
 
 			// In all likelihood, the PenumbraAppNode and TendermintNode have the same DockerClient and TestName,
 			// but instantiate a new FileRetriever to be defensive.
-			fr = dockerutil.NewFileRetriever(c.log, v.PenumbraAppNode.DockerClient, v.PenumbraAppNode.TestName)
+			fr = dockerutil.NewFileRetriever(c.logger(), v.PenumbraAppNode.DockerClient, v.PenumbraAppNode.TestName)
 			validatorTemplateDefinitionFileBytes, err := fr.SingleFileContent(egCtx, v.PenumbraAppNode.VolumeName, "validator.json")
 			if err != nil {
 				return fmt.Errorf("error reading validator definition template file: %v", err)
@@ -446,13 +445,13 @@ func (c *PenumbraChain) Start(testName string, ctx context.Context, additionalGe
 		eg.Go(func() error {
 			firstValPrivKeyRelPath := fmt.Sprintf(".penumbra/testnet_data/node%d/tendermint/config/priv_validator_key.json", i)
 
-			fr := dockerutil.NewFileRetriever(c.log, firstVal.PenumbraAppNode.DockerClient, firstVal.PenumbraAppNode.TestName)
+			fr := dockerutil.NewFileRetriever(c.logger(), firstVal.PenumbraAppNode.DockerClient, firstVal.PenumbraAppNode.TestName)
 			pk, err := fr.SingleFileContent(egCtx, firstVal.PenumbraAppNode.VolumeName, firstValPrivKeyRelPath)
 			if err != nil {
 				return fmt.Errorf("error getting validator private key content: %w", err)
 			}
 
-			fw := dockerutil.NewFileWriter(c.log, val.PenumbraAppNode.DockerClient, val.PenumbraAppNode.TestName)
+			fw := dockerutil.NewFileWriter(c.logger(), val.PenumbraAppNode.DockerClient, val.PenumbraAppNode.TestName)
 			if err := fw.WriteFile(egCtx, val.TendermintNode.VolumeName, "config/priv_validator_key.json", pk); err != nil {
 				return fmt.Errorf("overwriting priv_validator_key.json: %w", err)
 			}
@@ -515,7 +514,7 @@ func (c *PenumbraChain) start(ctx context.Context) error {
 	eg, egCtx = errgroup.WithContext(ctx)
 	for _, n := range c.PenumbraNodes {
 		n := n
-		c.log.Info("Starting tendermint container", zap.String("container", n.TendermintNode.Name()))
+		c.logger().Info("Starting tendermint container", zap.String("container", n.TendermintNode.Name()))
 		eg.Go(func() error {
 			peers := tmNodes.PeerString(egCtx, n.TendermintNode)
 			if err := n.TendermintNode.SetConfigAndPeers(egCtx, peers); err != nil {
@@ -523,7 +522,7 @@ func (c *PenumbraChain) start(ctx context.Context) error {
 			}
 			return n.TendermintNode.StartContainer(egCtx)
 		})
-		c.log.Info("Starting penumbra container", zap.String("container", n.PenumbraAppNode.Name()))
+		c.logger().Info("Starting penumbra container", zap.String("container", n.PenumbraAppNode.Name()))
 		eg.Go(func() error {
 			return n.PenumbraAppNode.StartContainer(egCtx)
 		})
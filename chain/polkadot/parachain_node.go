@@ -214,6 +214,8 @@ func (pn *ParachainNode) logger() *zap.Logger {
 	return pn.log.With(
 		zap.String("chain_id", pn.ChainID),
 		zap.String("test", pn.TestName),
+		zap.Int("node_index", pn.Index),
+		zap.String("container", pn.Name()),
 	)
 }
 
@@ -263,7 +265,7 @@ func (pn *ParachainNode) CreateNodeContainer(ctx context.Context) error {
 			Hostname: pn.HostName(),
 			User:     pn.Image.UidGid,
 
-			Labels: map[string]string{dockerutil.CleanupLabel: pn.TestName},
+			Labels: dockerutil.NodeResourceLabels(pn.TestName, pn.Name()),
 
 			ExposedPorts: exposedPorts,
 		},
@@ -291,7 +293,7 @@ func (pn *ParachainNode) CreateNodeContainer(ctx context.Context) error {
 // StopContainer stops the relay chain node container, waiting at most 30 seconds.
 func (pn *ParachainNode) StopContainer(ctx context.Context) error {
 	timeout := 30 * time.Second
-	return pn.DockerClient.ContainerStop(ctx, pn.containerID, &timeout)
+	return dockerutil.StopContainer(ctx, pn.DockerClient, pn.containerID, timeout)
 }
 
 // StartContainer starts the container after it is built by CreateNodeContainer.
@@ -311,7 +313,7 @@ func (pn *ParachainNode) StartContainer(ctx context.Context) error {
 
 	explorerUrl := fmt.Sprintf("\033[4;34mhttps://polkadot.js.org/apps?rpc=ws://%s#/explorer\033[0m",
 		strings.Replace(pn.hostWsPort, "localhost", "127.0.0.1", 1))
-	pn.log.Info(explorerUrl, zap.String("container", pn.Name()))
+	pn.logger().Info(explorerUrl)
 	var api *gsrpc.SubstrateAPI
 	if err = retry.Do(func() error {
 		var err error
@@ -327,7 +329,7 @@ func (pn *ParachainNode) StartContainer(ctx context.Context) error {
 
 // Exec run a container for a specific job and block until the container exits.
 func (pn *ParachainNode) Exec(ctx context.Context, cmd []string, env []string) dockerutil.ContainerExecResult {
-	job := dockerutil.NewImage(pn.log, pn.DockerClient, pn.NetworkID, pn.TestName, pn.Image.Repository, pn.Image.Version)
+	job := dockerutil.NewImage(pn.logger(), pn.DockerClient, pn.NetworkID, pn.TestName, pn.Image.Repository, pn.Image.Version)
 	opts := dockerutil.ContainerOptions{
 		Binds: pn.Bind(),
 		Env:   env,
@@ -363,6 +365,6 @@ func (pn *ParachainNode) SendFunds(ctx context.Context, keyName string, amount i
 		return err
 	}
 
-	pn.log.Info("Transfer sent", zap.String("hash", fmt.Sprintf("%#x", hash)), zap.String("container", pn.Name()))
+	pn.logger().Info("Transfer sent", zap.String("hash", fmt.Sprintf("%#x", hash)))
 	return nil
 }
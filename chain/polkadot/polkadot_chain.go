@@ -105,7 +105,7 @@ func (c *PolkadotChain) Initialize(ctx context.Context, testName string, cli *cl
 			types.ImagePullOptions{},
 		)
 		if err != nil {
-			c.log.Error("Failed to pull image",
+			c.logger().Error("Failed to pull image",
 				zap.Error(err),
 				zap.String("repository", image.Repository),
 				zap.String("tag", image.Version),
@@ -164,11 +164,7 @@ func (c *PolkadotChain) Initialize(ctx context.Context, testName string, cli *cl
 		}
 
 		v, err := cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
-			Labels: map[string]string{
-				dockerutil.CleanupLabel: testName,
-
-				dockerutil.NodeOwnerLabel: pn.Name(),
-			},
+			Labels: dockerutil.NodeResourceLabels(testName, pn.Name()),
 		})
 		if err != nil {
 			return fmt.Errorf("creating volume for chain node: %w", err)
@@ -176,7 +172,7 @@ func (c *PolkadotChain) Initialize(ctx context.Context, testName string, cli *cl
 		pn.VolumeName = v.Name
 
 		if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
-			Log:        c.log,
+			Log:        c.logger(),
 			Client:     cli,
 			VolumeName: v.Name,
 			ImageRef:   chainCfg.Images[0].Ref(),
@@ -211,11 +207,7 @@ func (c *PolkadotChain) Initialize(ctx context.Context, testName string, cli *cl
 				RelayChainFlags: parachainConfig.RelayChainFlags,
 			}
 			v, err := cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
-				Labels: map[string]string{
-					dockerutil.CleanupLabel: testName,
-
-					dockerutil.NodeOwnerLabel: pn.Name(),
-				},
+				Labels: dockerutil.NodeResourceLabels(testName, pn.Name()),
 			})
 			if err != nil {
 				return fmt.Errorf("creating volume for chain node: %w", err)
@@ -223,7 +215,7 @@ func (c *PolkadotChain) Initialize(ctx context.Context, testName string, cli *cl
 			pn.VolumeName = v.Name
 
 			if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
-				Log:        c.log,
+				Log:        c.logger(),
 				Client:     cli,
 				VolumeName: v.Name,
 				ImageRef:   parachainConfig.Image.Ref(),
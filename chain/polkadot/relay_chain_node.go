@@ -136,6 +136,8 @@ func (c *RelayChainNode) logger() *zap.Logger {
 	return c.log.With(
 		zap.String("chain_id", c.Chain.Config().ChainID),
 		zap.String("test", c.TestName),
+		zap.Int("node_index", c.Index),
+		zap.String("container", c.Name()),
 	)
 }
 
@@ -239,7 +241,7 @@ func (p *RelayChainNode) CreateNodeContainer(ctx context.Context) error {
 			Hostname: p.HostName(),
 			User:     p.Image.UidGid,
 
-			Labels: map[string]string{dockerutil.CleanupLabel: p.TestName},
+			Labels: dockerutil.NodeResourceLabels(p.TestName, p.Name()),
 
 			ExposedPorts: exposedPorts,
 		},
@@ -267,7 +269,7 @@ func (p *RelayChainNode) CreateNodeContainer(ctx context.Context) error {
 // StopContainer stops the relay chain node container, waiting at most 30 seconds.
 func (p *RelayChainNode) StopContainer(ctx context.Context) error {
 	timeout := 30 * time.Second
-	return p.DockerClient.ContainerStop(ctx, p.containerID, &timeout)
+	return dockerutil.StopContainer(ctx, p.DockerClient, p.containerID, timeout)
 }
 
 // StartContainer starts the container after it is built by CreateNodeContainer.
@@ -288,7 +290,7 @@ func (p *RelayChainNode) StartContainer(ctx context.Context) error {
 	p.logger().Info("Waiting for RPC endpoint to be available", zap.String("container", p.Name()))
 	explorerUrl := fmt.Sprintf("\033[4;34mhttps://polkadot.js.org/apps?rpc=ws://%s#/explorer\033[0m",
 		strings.Replace(p.hostWsPort, "localhost", "127.0.0.1", 1))
-	p.log.Info(explorerUrl, zap.String("container", p.Name()))
+	p.logger().Info(explorerUrl)
 	var api *gsrpc.SubstrateAPI
 	if err = retry.Do(func() error {
 		var err error
@@ -306,7 +308,7 @@ func (p *RelayChainNode) StartContainer(ctx context.Context) error {
 
 // Exec runs a container for a specific job and blocks until the container exits.
 func (p *RelayChainNode) Exec(ctx context.Context, cmd []string, env []string) dockerutil.ContainerExecResult {
-	job := dockerutil.NewImage(p.log, p.DockerClient, p.NetworkID, p.TestName, p.Image.Repository, p.Image.Version)
+	job := dockerutil.NewImage(p.logger(), p.DockerClient, p.NetworkID, p.TestName, p.Image.Repository, p.Image.Version)
 	opts := dockerutil.ContainerOptions{
 		Binds: p.Bind(),
 		Env:   env,
@@ -328,7 +330,7 @@ func (p *RelayChainNode) SendFunds(ctx context.Context, keyName string, amount i
 		return err
 	}
 
-	p.log.Info("Transfer sent", zap.String("hash", fmt.Sprintf("%#x", hash)), zap.String("container", p.Name()))
+	p.logger().Info("Transfer sent", zap.String("hash", fmt.Sprintf("%#x", hash)))
 	return nil
 }
 
@@ -0,0 +1,58 @@
+package interchaintest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LogChainsToFiles additionally writes the logs of each chain built from specs
+// to its own JSON file named after the chain ID, in the logs directory under TempArtifactDir(t).
+// Each spec's Logger is replaced by a logger writing to both the file and the spec's
+// existing Logger, or log if unset.
+//
+// Call LogChainsToFiles before passing specs to NewBuiltinChainFactory.
+// The files are closed when t completes, and kept with the artifact directory when t fails.
+func LogChainsToFiles(t TempDirTestingT, log *zap.Logger, specs ...*ChainSpec) error {
+	t.Helper()
+
+	dir := filepath.Join(TempArtifactDir(t), "logs")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("mkdirall: %w", err)
+	}
+
+	for i, s := range specs {
+		cfg, err := s.Config(log)
+		if err != nil {
+			return fmt.Errorf("failed to build chain config at index %d: %w", i, err)
+		}
+
+		f, err := os.Create(filepath.Join(dir, cfg.ChainID+".log"))
+		if err != nil {
+			return fmt.Errorf("create log file for chain %s: %w", cfg.ChainID, err)
+		}
+		t.Cleanup(func() {
+			if err := f.Close(); err != nil {
+				t.Errorf("LogChainsToFiles close %s: %v", f.Name(), err)
+			}
+		})
+
+		base := log
+		if s.Logger != nil {
+			base = s.Logger
+		}
+		fileCore := zapcore.NewCore(
+			zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+			zapcore.Lock(f),
+			zapcore.DebugLevel,
+		)
+		s.Logger = base.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(c, fileCore)
+		}))
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package interchaintest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/internal/mocktesting"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestLogChainsToFiles(t *testing.T) {
+	origRoot := interchaintest.ArtifactRootDir()
+	defer interchaintest.SetArtifactRootDir(origRoot)
+	interchaintest.SetArtifactRootDir(t.TempDir())
+
+	mt := mocktesting.NewT("log_chains")
+	defer mt.RunCleanups()
+
+	gaia := &interchaintest.ChainSpec{Name: "gaia", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "gaia-1"}}
+	osmosis := &interchaintest.ChainSpec{Name: "osmosis", Version: "v11.0.1", ChainConfig: ibc.ChainConfig{ChainID: "osmosis-1"}}
+	require.NoError(t, interchaintest.LogChainsToFiles(mt, zaptest.NewLogger(t), gaia, osmosis))
+
+	gaia.Logger.Info("gaia message", zap.String("chain_id", "gaia-1"))
+	osmosis.Logger.Debug("osmosis message", zap.String("chain_id", "osmosis-1"))
+
+	dir := filepath.Join(interchaintest.TempArtifactDir(mt), "logs")
+
+	gaiaLogs, err := os.ReadFile(filepath.Join(dir, "gaia-1.log"))
+	require.NoError(t, err)
+	require.Contains(t, string(gaiaLogs), `"msg":"gaia message"`)
+	require.Contains(t, string(gaiaLogs), `"chain_id":"gaia-1"`)
+	require.NotContains(t, string(gaiaLogs), "osmosis")
+
+	osmosisLogs, err := os.ReadFile(filepath.Join(dir, "osmosis-1.log"))
+	require.NoError(t, err)
+	require.Contains(t, string(osmosisLogs), `"msg":"osmosis message"`)
+	require.NotContains(t, string(osmosisLogs), "gaia")
+}
+
+func TestBuiltinChainFactory_LogLevel(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+			{Name: "gaia", Version: "v7.0.1", LogLevel: "warn"},
+		})
+		_, err := cf.Chains(t.Name())
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+			{Name: "gaia", Version: "v7.0.1", LogLevel: "loud"},
+		})
+		_, err := cf.Chains(t.Name())
+		require.ErrorContains(t, err, `invalid log level "loud"`)
+	})
+}
@@ -109,7 +109,12 @@ func (f *BuiltinChainFactory) Chains(testName string) ([]ibc.Chain, error) {
 			return nil, fmt.Errorf("failed to build chain config at index %d: %w", i, err)
 		}
 
-		chain, err := buildChain(f.log, testName, *cfg, s.NumValidators, s.NumFullNodes)
+		log, err := s.logger(f.log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build logger for chain %s: %w", cfg.ChainID, err)
+		}
+
+		chain, err := buildChain(log, testName, *cfg, s.NumValidators, s.NumFullNodes)
 		if err != nil {
 			return nil, err
 		}
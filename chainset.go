@@ -48,11 +48,16 @@ func newChainSet(log *zap.Logger, chains []ibc.Chain) *chainSet {
 // Initialize concurrently calls Initialize against each chain in the set.
 // Each chain may run a docker pull command,
 // so with a cold image cache, running concurrently may save some time.
-func (cs *chainSet) Initialize(ctx context.Context, testName string, cli *client.Client, networkID string) error {
+// Chains in hosts are initialized with the client and network of their docker host.
+func (cs *chainSet) Initialize(ctx context.Context, testName string, cli *client.Client, networkID string, hosts map[ibc.Chain]DockerHost) error {
 	var eg errgroup.Group
 
 	for c := range cs.chains {
 		c := c
+		cli, networkID := cli, networkID
+		if h, ok := hosts[c]; ok {
+			cli, networkID = h.Client, h.NetworkID
+		}
 		eg.Go(func() error {
 			if err := c.Initialize(ctx, testName, cli, networkID); err != nil {
 				return fmt.Errorf("failed to initialize chain %s: %w", c.Config().Name, err)
@@ -118,6 +123,32 @@ func (cs *chainSet) Start(ctx context.Context, testName string, additionalGenesi
 	return eg.Wait()
 }
 
+// readyWaiter is implemented by chains that can probe whether all of their nodes are ready.
+type readyWaiter interface {
+	WaitReady(ctx context.Context, timeout time.Duration) error
+}
+
+// WaitReady concurrently waits for each chain in the set that supports readiness probes to become ready.
+func (cs *chainSet) WaitReady(ctx context.Context, timeout time.Duration) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+
+	for c := range cs.chains {
+		c := c
+		rw, ok := c.(readyWaiter)
+		if !ok {
+			continue
+		}
+		eg.Go(func() error {
+			if err := rw.WaitReady(egCtx, timeout); err != nil {
+				return fmt.Errorf("chain %s: %w", c.Config().Name, err)
+			}
+			return nil
+		})
+	}
+
+	return eg.Wait()
+}
+
 // TrackBlocks initializes database tables and polls for transactions to be saved in the database.
 // This method is a nop if dbPath is blank.
 // The gitSha is used to pin a git commit to a test invocation. Thus, when a user is looking at historical
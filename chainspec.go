@@ -11,6 +11,7 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
 	"github.com/strangelove-ventures/interchaintest/v6/label"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 // ChainSpec is a wrapper around an ibc.ChainConfig
@@ -41,11 +42,35 @@ type ChainSpec struct {
 	// If unspecified, NumValidators defaults to 2 and NumFullNodes defaults to 1.
 	NumValidators, NumFullNodes *int
 
+	// Logger, if set, is used for this chain and its nodes instead of the chain factory's logger.
+	Logger *zap.Logger `json:"-" yaml:"-"`
+
+	// LogLevel, if set, raises the minimum level of this chain's logs, e.g. "info" or "warn".
+	// Log levels can only be raised, not lowered, past the level of the underlying logger.
+	LogLevel string `json:"-" yaml:"-"`
+
 	// Generate the automatic suffix on demand when needed.
 	autoSuffixOnce sync.Once
 	autoSuffix     string
 }
 
+// logger returns the logger for the chain of this spec,
+// i.e. Logger or else base, with LogLevel applied.
+func (s *ChainSpec) logger(base *zap.Logger) (*zap.Logger, error) {
+	log := base
+	if s.Logger != nil {
+		log = s.Logger
+	}
+	if s.LogLevel == "" {
+		return log, nil
+	}
+	lvl, err := zapcore.ParseLevel(s.LogLevel)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level %q: %w", s.LogLevel, err)
+	}
+	return log.WithOptions(zap.IncreaseLevel(lvl)), nil
+}
+
 // Config returns the underlying ChainConfig,
 // with any overrides applied.
 func (s *ChainSpec) Config(log *zap.Logger) (*ibc.ChainConfig, error) {
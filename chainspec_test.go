@@ -3,6 +3,7 @@ package interchaintest_test
 import (
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
@@ -133,6 +134,329 @@ func TestChainSpec_Config(t *testing.T) {
 
 			require.Equal(t, m, cfg.NoHostMount)
 		})
+
+		t.Run("ValidatorSelfDelegations", func(t *testing.T) {
+			require.Empty(t, baseCfg.ValidatorSelfDelegations)
+
+			s := &interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+
+				ChainConfig: ibc.ChainConfig{
+					ValidatorSelfDelegations: []int64{67_000_000, 33_000_000},
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.Equal(t, []int64{67_000_000, 33_000_000}, cfg.ValidatorSelfDelegations)
+		})
+
+		t.Run("ValidatorCommission", func(t *testing.T) {
+			require.Empty(t, baseCfg.CommissionRate)
+			require.Empty(t, baseCfg.CommissionMaxRate)
+			require.Empty(t, baseCfg.CommissionMaxChangeRate)
+			require.Zero(t, baseCfg.MinSelfDelegation)
+
+			s := &interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+
+				ChainConfig: ibc.ChainConfig{
+					CommissionRate:          "0.05",
+					CommissionMaxRate:       "0.10",
+					CommissionMaxChangeRate: "0.01",
+					MinSelfDelegation:       1_000_000,
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.Equal(t, "0.05", cfg.CommissionRate)
+			require.Equal(t, "0.10", cfg.CommissionMaxRate)
+			require.Equal(t, "0.01", cfg.CommissionMaxChangeRate)
+			require.Equal(t, int64(1_000_000), cfg.MinSelfDelegation)
+		})
+
+		t.Run("AdditionalPeers", func(t *testing.T) {
+			require.Empty(t, baseCfg.AdditionalPeers)
+
+			const peer = "0123456789abcdef0123456789abcdef01234567@external-node:26656"
+			s := &interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+
+				ChainConfig: ibc.ChainConfig{
+					AdditionalPeers: []string{peer},
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.Equal(t, []string{peer}, cfg.AdditionalPeers)
+		})
+
+		t.Run("NetworkAliases", func(t *testing.T) {
+			require.Empty(t, baseCfg.NetworkAliases)
+
+			s := &interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+
+				ChainConfig: ibc.ChainConfig{
+					NetworkAliases: []string{"chain-a-rpc"},
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.Equal(t, []string{"chain-a-rpc"}, cfg.NetworkAliases)
+		})
+
+		t.Run("Env", func(t *testing.T) {
+			require.Empty(t, baseCfg.Env)
+
+			s := &interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+
+				ChainConfig: ibc.ChainConfig{
+					Env: []string{"LOG_FORMAT=json"},
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.Equal(t, []string{"LOG_FORMAT=json"}, cfg.Env)
+		})
+
+		t.Run("ValidatorEnv and FullNodeEnv", func(t *testing.T) {
+			require.Empty(t, baseCfg.ValidatorEnv)
+			require.Empty(t, baseCfg.FullNodeEnv)
+
+			validatorEnv := [][]string{nil, {"LOG_FORMAT=plain"}}
+			s := &interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+
+				ChainConfig: ibc.ChainConfig{
+					ValidatorEnv: validatorEnv,
+					FullNodeEnv:  [][]string{{"LOG_LEVEL=debug"}},
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.Equal(t, validatorEnv, cfg.ValidatorEnv)
+			require.Equal(t, [][]string{{"LOG_LEVEL=debug"}}, cfg.FullNodeEnv)
+
+			// The config does not share the environments of the spec.
+			cfg.ValidatorEnv[1][0] = "LOG_FORMAT=json"
+			require.Equal(t, "LOG_FORMAT=plain", validatorEnv[1][0])
+		})
+
+		t.Run("BaseFeeMultiplier", func(t *testing.T) {
+			require.Zero(t, baseCfg.BaseFeeMultiplier)
+
+			s := &interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+
+				ChainConfig: ibc.ChainConfig{
+					BaseFeeMultiplier: 2.5,
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.Equal(t, 2.5, cfg.BaseFeeMultiplier)
+
+			// A negative multiplier is kept, to be rejected when the chain starts.
+			s.ChainConfig.BaseFeeMultiplier = -1
+			cfg, err = s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.Equal(t, -1.0, cfg.BaseFeeMultiplier)
+		})
+
+		t.Run("QueryRateLimit", func(t *testing.T) {
+			require.Zero(t, baseCfg.QueryRateLimit)
+
+			s := &interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+
+				ChainConfig: ibc.ChainConfig{
+					// Negative disables the throttle, so it must be merged too.
+					QueryRateLimit: -1,
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.Equal(t, float64(-1), cfg.QueryRateLimit)
+		})
+
+		t.Run("SignMode", func(t *testing.T) {
+			require.Empty(t, baseCfg.SignMode)
+
+			s := &interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+
+				ChainConfig: ibc.ChainConfig{
+					SignMode: ibc.SignModeAminoJSON,
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.Equal(t, ibc.SignModeAminoJSON, cfg.SignMode)
+		})
+
+		t.Run("SidecarConfigs", func(t *testing.T) {
+			require.Empty(t, baseCfg.SidecarConfigs)
+
+			sidecar := ibc.SidecarConfig{
+				ProcessName: "price-feeder",
+				Image:       ibc.DockerImage{Repository: "price-feeder", Version: "v1.0.0"},
+				StartCmd:    []string{"price-feeder", "start"},
+			}
+			s := &interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+
+				ChainConfig: ibc.ChainConfig{
+					SidecarConfigs: []ibc.SidecarConfig{sidecar},
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.Equal(t, []ibc.SidecarConfig{sidecar}, cfg.SidecarConfigs)
+		})
+
+		t.Run("Rollup", func(t *testing.T) {
+			require.Nil(t, baseCfg.Rollup)
+
+			rollup := &ibc.RollupConfig{StartFlags: []string{"--rollkit.block_time=1s"}}
+			s := &interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+
+				ChainConfig: ibc.ChainConfig{
+					Rollup: rollup,
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.Equal(t, rollup, cfg.Rollup)
+			// The merged config does not share the flags.
+			cfg.Rollup.StartFlags[0] = "changed"
+			require.Equal(t, "--rollkit.block_time=1s", rollup.StartFlags[0])
+		})
+
+		t.Run("PoA", func(t *testing.T) {
+			require.Nil(t, baseCfg.PoA)
+
+			poa := &ibc.PoAConfig{ValidatorPowers: []int64{10, 5}}
+			s := &interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+
+				ChainConfig: ibc.ChainConfig{
+					PoA: poa,
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.Equal(t, poa, cfg.PoA)
+			// The merged config does not share the powers.
+			cfg.PoA.ValidatorPowers[0] = 1
+			require.Equal(t, int64(10), poa.ValidatorPowers[0])
+		})
+
+		t.Run("ConsensusEngine", func(t *testing.T) {
+			require.Nil(t, baseCfg.ConsensusEngine)
+
+			engine := &ibc.ConsensusEngineConfig{ExtraFlags: []string{"--auto-tx=false"}}
+			s := &interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+
+				ChainConfig: ibc.ChainConfig{
+					ConsensusEngine: engine,
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.Equal(t, engine, cfg.ConsensusEngine)
+			// The merged config does not share the flags.
+			cfg.ConsensusEngine.ExtraFlags[0] = "changed"
+			require.Equal(t, "--auto-tx=false", engine.ExtraFlags[0])
+		})
+
+		t.Run("ClockOffset", func(t *testing.T) {
+			require.Nil(t, baseCfg.ClockOffset)
+
+			offset := 30 * time.Second
+			s := &interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+
+				ChainConfig: ibc.ChainConfig{
+					ClockOffset: &offset,
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.Equal(t, 30*time.Second, *cfg.ClockOffset)
+
+			// The config owns its copy of the offset.
+			offset = time.Second
+			require.Equal(t, 30*time.Second, *cfg.ClockOffset)
+		})
+
+		t.Run("FaucetGenesisBalance", func(t *testing.T) {
+			require.Empty(t, baseCfg.FaucetGenesisBalance)
+
+			balance := map[string]int64{"uatom": 1_000_000_000_000_000, "ufoo": 1_000}
+			s := &interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+
+				ChainConfig: ibc.ChainConfig{
+					FaucetGenesisBalance: balance,
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.Equal(t, balance, cfg.FaucetGenesisBalance)
+
+			// The config owns its copy of the balance.
+			balance["uatom"] = 1
+			require.Equal(t, int64(1_000_000_000_000_000), cfg.FaucetGenesisBalance["uatom"])
+		})
 	})
 
 	t.Run("error cases", func(t *testing.T) {
@@ -19,6 +19,8 @@ type mainFlags struct {
 	MatrixFile        string
 	ReportFile        string
 	BlockDatabaseFile string
+	SkipStress        bool
+	StressTransfers   int
 }
 
 func (f mainFlags) Logger() (lc LoggerCloser, _ error) {
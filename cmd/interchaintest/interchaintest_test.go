@@ -172,7 +172,7 @@ func configureTestReporter() error {
 func getRelayerFactory(name string, logger *zap.Logger) (interchaintest.RelayerFactory, error) {
 	switch name {
 	case "rly", "cosmos/relayer":
-		return interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, logger, relayer.StartupFlags("-b", "100")), nil
+		return interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, logger, relayer.ProcessorEvents(100)), nil
 	case "hermes":
 		return interchaintest.NewBuiltinRelayerFactory(ibc.Hermes, logger), nil
 	default:
@@ -233,7 +233,10 @@ func TestConformance(t *testing.T) {
 	}
 
 	// Begin test execution, which will spawn many parallel subtests.
-	conformance.Test(t, ctx, chainFactories, relayerFactories, reporter)
+	conformance.TestWithOptions(t, ctx, chainFactories, relayerFactories, reporter, conformance.TestOptions{
+		SkipStress: extraFlags.SkipStress,
+		Stress:     conformance.StressOptions{Transfers: extraFlags.StressTransfers},
+	})
 }
 
 // addFlags configures additional flags beyond the default testing flags.
@@ -247,6 +250,8 @@ func addFlags() {
 	flag.StringVar(&extraFlags.LogFormat, "log-format", "console", "Chain and relayer log format: console|json")
 	flag.StringVar(&extraFlags.LogLevel, "log-level", "info", "Chain and relayer log level: debug|info|error")
 	flag.StringVar(&extraFlags.ReportFile, "report-file", "", "Path where test report will be stored. Defaults to $HOME/.interchaintest/reports/$TIMESTAMP.json")
+	flag.BoolVar(&extraFlags.SkipStress, "skip-stress", false, "Skip the relayer stress case, which sends hundreds of transfers per relayer")
+	flag.IntVar(&extraFlags.StressTransfers, "stress-transfers", 0, "Number of transfers the relayer stress case sends. Defaults to 200")
 
 	debugFlagSet.StringVar(&extraFlags.BlockDatabaseFile, "block-db", interchaintest.DefaultBlockDatabaseFilepath(), "Path to database sqlite file that tracks blocks and transactions.")
 }
@@ -0,0 +1,199 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	feetypes "github.com/cosmos/ibc-go/v6/modules/apps/29-fee/types"
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/relayer"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// channelUpgradeVotingPeriod is short enough for the channel upgrade proposal to pass during the test.
+const channelUpgradeVotingPeriod = 20 * time.Second
+
+// TestRelayerChannelUpgrade upgrades a transfer channel to add the fee middleware through a gov proposal,
+// and asserts that the upgrade handshake completes on both chains,
+// after flushing the packets sent before the upgrade started and while it was in progress.
+// Relayers with the relayer.ChannelUpgrade capability must complete the handshake while running.
+// For other relayers, the test relays the handshake itself with cosmos.RelayChannelUpgrade,
+// and the relayer must flush the packets in flight while the channel is flushing.
+//
+// Channel upgrades require chains with ibc-go v8.1 or later; the test is skipped for other chains.
+func TestRelayerChannelUpgrade(t *testing.T, ctx context.Context, cf interchaintest.ChainFactory, rf interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+	relaysUpgrades := len(missingCapabilities(rf, relayer.ChannelUpgrade)) == 0
+	if !relaysUpgrades {
+		requireCapabilities(t, rep, rf, relayer.FlushPackets, relayer.FlushAcknowledgements)
+	}
+
+	client, network := interchaintest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	c0, ok := chains[0].(*cosmos.CosmosChain)
+	if !ok {
+		t.Skipf("channel upgrade test requires cosmos chains, got %T", chains[0])
+	}
+	c1, ok := chains[1].(*cosmos.CosmosChain)
+	if !ok {
+		t.Skipf("channel upgrade test requires cosmos chains, got %T", chains[1])
+	}
+	c0.AddGenesisModifier(func(_ context.Context, cfg ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		return cosmos.ModifyGenesisVotingPeriod(channelUpgradeVotingPeriod)(cfg, genbz)
+	})
+
+	r := rf.Build(t, client, network)
+
+	const pathName = "p"
+	ic := interchaintest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path:              pathName,
+			CreateChannelOpts: ibc.DefaultChannelOpts(),
+		})
+
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	defer ic.Close()
+
+	for _, c := range []*cosmos.CosmosChain{c0, c1} {
+		supported, err := c.SupportsChannelUpgrades(ctx)
+		req.NoError(err)
+		if !supported {
+			t.Skipf("chain %s does not support channel upgrades", c.Config().ChainID)
+		}
+	}
+
+	channels, err := r.GetChannels(ctx, eRep, c0.Config().ChainID)
+	req.NoError(err)
+	req.Len(channels, 1)
+	channel := channels[0]
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "upgrade", userFaucetFund, c0, c1)
+	sender, receiver := users[0], users[1]
+
+	sendTransfer := func() ibc.Tx {
+		tx, err := c0.SendIBCTransfer(ctx, channel.ChannelID, sender.KeyName(), ibc.WalletAmount{
+			Address: receiver.FormattedAddress(),
+			Denom:   c0.Config().Denom,
+			Amount:  testCoinAmount,
+		}, ibc.TransferOptions{})
+		req.NoError(err)
+		req.NoError(tx.Validate())
+		return tx
+	}
+
+	// The relayer is not running yet, so this packet is still in flight when the upgrade starts.
+	beforeUpgrade := sendTransfer()
+
+	version := string(feetypes.ModuleCdc.MustMarshalJSON(&feetypes.Metadata{
+		FeeVersion: feetypes.Version,
+		AppVersion: channel.Version,
+	}))
+	height, err := c0.Height(ctx)
+	req.NoError(err)
+	propTx, err := c0.ChannelUpgradeProposal(ctx, sender.KeyName(), cosmos.ChannelUpgradeProposal{
+		Deposit:   fmt.Sprintf("%d%s", 10_000_000, c0.Config().Denom),
+		Title:     "Add fee middleware",
+		Summary:   "Upgrade " + channel.ChannelID + " to the fee middleware",
+		PortID:    channel.PortID,
+		ChannelID: channel.ChannelID,
+		Fields: cosmos.ChannelUpgradeFields{
+			Ordering:       chantypes.Order(chantypes.Order_value[channel.Ordering.String()]),
+			ConnectionHops: channel.ConnectionHops,
+			Version:        version,
+		},
+	})
+	req.NoError(err, "failed to submit channel upgrade proposal")
+	req.NoError(c0.VoteOnProposalAllValidators(ctx, propTx.ProposalID, cosmos.ProposalVoteYes))
+	_, err = cosmos.PollForProposalStatus(ctx, c0, height, height+pollHeightMax, propTx.ProposalID, cosmos.ProposalStatusPassed)
+	req.NoError(err, "channel upgrade proposal did not pass")
+
+	// The upgrade is initiated, but the counterparty has not accepted it yet, so the channel still accepts packets.
+	duringUpgrade := sendTransfer()
+
+	if relaysUpgrades {
+		req.NoError(r.StartRelayer(ctx, eRep, pathName))
+		defer func() {
+			if err := r.StopRelayer(ctx, eRep); err != nil {
+				t.Logf("failed to stop relayer: %v", err)
+			}
+		}()
+	} else {
+		// Both transfers are still in flight, so c0 must flush them before the upgrade completes.
+		req.NoError(cosmos.RelayChannelUpgrade(ctx, r, eRep, pathName,
+			cosmos.ChannelUpgradeEnd{Chain: c0, PortID: channel.PortID, ChannelID: channel.ChannelID, KeyName: sender.KeyName()},
+			cosmos.ChannelUpgradeEnd{Chain: c1, PortID: channel.Counterparty.PortID, ChannelID: channel.Counterparty.ChannelID, KeyName: receiver.KeyName()},
+		), "failed to relay channel upgrade")
+	}
+
+	// The channel ends are queried from the chains, since relayers predating channel upgrades leave out the upgrade sequence.
+	upgraded := func(c *cosmos.CosmosChain, portID, channelID string) testutil.BlockPoller[ibc.ChannelOutput] {
+		return testutil.BlockPoller[ibc.ChannelOutput]{
+			CurrentHeight: c.Height,
+			PollFunc: func(ctx context.Context, _ uint64) (ibc.ChannelOutput, error) {
+				ch, err := c.QueryChannelEnd(ctx, portID, channelID)
+				if err != nil {
+					return ibc.ChannelOutput{}, err
+				}
+				if ch.State != ibc.ChannelStateOpen || ch.Version != version {
+					return ibc.ChannelOutput{}, fmt.Errorf("channel %s on %s is %s with version %s, upgrade sequence %d",
+						channelID, c.Config().ChainID, ch.State, ch.Version, ch.UpgradeSequence)
+				}
+				return ch, nil
+			},
+		}
+	}
+
+	height, err = c0.Height(ctx)
+	req.NoError(err)
+	bp := upgraded(c0, channel.PortID, channel.ChannelID)
+	ch0, err := bp.DoPoll(ctx, height, height+pollHeightMax)
+	req.NoError(err, "channel upgrade not completed")
+	req.Equal(uint64(1), ch0.UpgradeSequence)
+
+	// The upgrade only completes after all packets sent before it were flushed.
+	pendingSend, pendingAck, err := c0.QueryPacketBacklog(ctx, c1, channel.PortID, channel.ChannelID)
+	req.NoError(err)
+	req.Zero(pendingSend, "packets not received before the channel upgrade completed")
+	req.Zero(pendingAck, "acknowledgements not relayed before the channel upgrade completed")
+
+	height, err = c0.Height(ctx)
+	req.NoError(err)
+	for _, tx := range []ibc.Tx{beforeUpgrade, duringUpgrade} {
+		_, err := testutil.PollForAck(ctx, c0, tx.Height, height+1, tx.Packet)
+		req.NoError(err, "transfer %s not acknowledged", tx.TxHash)
+	}
+
+	// The counterparty channel is upgraded too, without being closed.
+	bp = upgraded(c1, channel.Counterparty.PortID, channel.Counterparty.ChannelID)
+	ch1, err := bp.DoPoll(ctx, height, height+pollHeightMax)
+	req.NoError(err, "counterparty channel upgrade not completed")
+	req.Equal(uint64(1), ch1.UpgradeSequence)
+}
@@ -0,0 +1,118 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// clientRefreshTrustingPeriod is short enough that an idle client expires during the test
+	// unless the relayer refreshes it.
+	clientRefreshTrustingPeriod = 2 * time.Minute
+
+	// clientRefreshIdlePeriod is how long the relayer runs without relaying any packets.
+	clientRefreshIdlePeriod = 3 * time.Minute
+)
+
+// clientRefreshQuerier is a chain that can report the consensus state and status of its light clients.
+type clientRefreshQuerier interface {
+	testutil.ClientConsensusQuerier
+	QueryClientStatus(ctx context.Context, clientID string) (string, error)
+}
+
+// TestRelayerClientRefresh asserts that a running relayer keeps a client with a short trusting period
+// from expiring, even when there are no packets to relay.
+func TestRelayerClientRefresh(t *testing.T, ctx context.Context, cf interchaintest.ChainFactory, rf interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	client, network := interchaintest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	c0, c1 := chains[0], chains[1]
+
+	querier, ok := c0.(clientRefreshQuerier)
+	if !ok {
+		t.Skipf("chain type %T does not support querying client consensus state", c0)
+	}
+
+	r := rf.Build(t, client, network)
+
+	const pathName = "p"
+	ic := interchaintest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path:              pathName,
+			CreateClientOpts:  ibc.CreateClientOptions{TrustingPeriod: clientRefreshTrustingPeriod.String()},
+			CreateChannelOpts: ibc.DefaultChannelOpts(),
+		})
+
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	defer ic.Close()
+
+	clients, err := r.GetClients(ctx, eRep, c0.Config().ChainID)
+	req.NoError(err)
+
+	var clientID string
+	for _, c := range clients {
+		if c.ClientState.ChainID == c1.Config().ChainID {
+			clientID = c.ClientID
+			break
+		}
+	}
+	req.NotEmpty(clientID, "no client on %s tracking %s", c0.Config().ChainID, c1.Config().ChainID)
+
+	req.NoError(r.StartRelayer(ctx, eRep, pathName))
+	defer func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	}()
+
+	idleStart := time.Now()
+
+	// With no packets to relay, only the relayer's periodic client updates can keep the client alive
+	// past its trusting period. Require at least two updates so the client is refreshed more than once.
+	updates, err := testutil.WaitForClientRefresh(ctx, querier, clientID, 2, clientRefreshIdlePeriod)
+	req.NoError(err, "relayer did not refresh client %s with trusting period %s", clientID, clientRefreshTrustingPeriod)
+	for _, u := range updates {
+		t.Logf("client %s updated: %s", clientID, u)
+	}
+
+	// Keep idling for the full period, so the client's original consensus state is well past its trusting period.
+	select {
+	case <-ctx.Done():
+		req.NoError(ctx.Err())
+	case <-time.After(time.Until(idleStart.Add(clientRefreshIdlePeriod))):
+	}
+
+	status, err := querier.QueryClientStatus(ctx, clientID)
+	req.NoError(err)
+	req.Equal("Active", status, "client %s expired despite updates: %v", clientID, updates)
+}
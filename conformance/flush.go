@@ -121,4 +121,37 @@ func TestRelayerFlushing(t *testing.T, ctx context.Context, cf interchaintest.Ch
 		_, err = testutil.PollForAck(ctx, c0, beforeTransferHeight, afterFlushHeight+2, tx.Packet)
 		req.NoError(err)
 	})
+
+	t.Run("flush with client ahead", func(t *testing.T) {
+		rep.TrackTest(t)
+		requireCapabilities(t, rep, rf, relayer.FlushAcknowledgements)
+
+		eRep := rep.RelayerExecReporter(t)
+
+		req := require.New(rep.TestifyT(t))
+
+		beforeTransferHeight, err := c0.Height(ctx)
+		req.NoError(err)
+
+		tx, err := c0.SendIBCTransfer(ctx, c0ChannelID, interchaintest.FaucetAccountKeyName, ibc.WalletAmount{
+			Address: c1FaucetAddr,
+			Denom:   c0.Config().Denom,
+			Amount:  txAmount,
+		}, ibc.TransferOptions{})
+		req.NoError(err)
+		req.NoError(tx.Validate())
+
+		// The packet must be proven against a client height newer than its commitment.
+		req.NoError(testutil.RelayWithClientAhead(ctx, r, eRep, pathName, c0ChannelID, c0, 5))
+
+		req.NoError(testutil.WaitForBlocks(ctx, 3, c0, c1))
+
+		req.NoError(r.FlushAcknowledgements(ctx, eRep, pathName, c0ChannelID))
+
+		afterFlushHeight, err := c0.Height(ctx)
+		req.NoError(err)
+
+		_, err = testutil.PollForAck(ctx, c0, beforeTransferHeight, afterFlushHeight+2, tx.Packet)
+		req.NoError(err)
+	})
 }
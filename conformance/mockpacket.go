@@ -0,0 +1,129 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	ibcmock "github.com/cosmos/ibc-go/v6/testing/mock"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRelayerMockPacket sends an ICS-20 transfer to the ibc-go mock module of the first chain,
+// over a channel from its mock port to the transfer port of the second chain, see ibc.MockTransferChannelOpts.
+// It asserts that the relayer delivers the packet with a MsgRecvPacket and the acknowledgement with a MsgAcknowledgement:
+// the mock acknowledgement is written on the mock end and relayed to the sender,
+// who is refunded since the mock module fails packets other than its mock packet data.
+//
+// The mock module is only available on chains built from the ibc-go simapp, such as ibc-go-simd;
+// the test is skipped for other chains.
+func TestRelayerMockPacket(t *testing.T, ctx context.Context, cf interchaintest.ChainFactory, rf interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	client, network := interchaintest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	mockChain, ok := chains[0].(*cosmos.CosmosChain)
+	if !ok {
+		t.Skipf("mock packet test requires cosmos chains, got %T", chains[0])
+	}
+	transferChain, ok := chains[1].(*cosmos.CosmosChain)
+	if !ok {
+		t.Skipf("mock packet test requires cosmos chains, got %T", chains[1])
+	}
+
+	r := rf.Build(t, client, network)
+
+	const pathName = "mock"
+	ic := interchaintest.NewInterchain().
+		AddChain(mockChain).
+		AddChain(transferChain).
+		AddRelayer(r, "r").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  mockChain,
+			Chain2:  transferChain,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	eRep := rep.RelayerExecReporter(t)
+
+	// The path is linked here, to skip the test if the first chain has no mock module.
+	req.NoError(ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	defer ic.Close()
+
+	req.NoError(r.GeneratePath(ctx, eRep, mockChain.Config().ChainID, transferChain.Config().ChainID, pathName))
+	err = r.LinkPath(ctx, eRep, pathName, ibc.MockTransferChannelOpts(), ibc.DefaultClientOpts())
+	if err != nil && strings.Contains(err.Error(), "could not retrieve module from port-id") {
+		t.Skipf("chain %s has no mock module: %v", mockChain.Config().ChainID, err)
+	}
+	req.NoError(err, "failed to link path")
+
+	channels, err := r.GetChannels(ctx, eRep, transferChain.Config().ChainID)
+	req.NoError(err)
+	req.Len(channels, 1)
+	channel := channels[0]
+	req.Equal(ibc.MockPort, channel.Counterparty.PortID)
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "mock", userFaucetFund, transferChain, mockChain)
+	sender, receiver := users[0], users[1]
+	denom := transferChain.Config().Denom
+
+	tx, err := transferChain.SendIBCTransfer(ctx, channel.ChannelID, sender.KeyName(), ibc.WalletAmount{
+		Address: receiver.FormattedAddress(),
+		Denom:   denom,
+		Amount:  testCoinAmount,
+	}, ibc.TransferOptions{})
+	req.NoError(err)
+	req.NoError(tx.Validate())
+	req.Equal(ibc.MockPort, tx.Packet.DestPort)
+
+	// The transfer is escrowed, and the fees are paid, before the relayer delivers the packet.
+	sentBalance, err := transferChain.GetBalance(ctx, sender.FormattedAddress(), denom)
+	req.NoError(err)
+
+	req.NoError(r.StartRelayer(ctx, eRep, pathName))
+	defer func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("failed to stop relayer: %v", err)
+		}
+	}()
+
+	ack, err := testutil.PollForAck(ctx, transferChain, tx.Height, tx.Height+pollHeightMax, tx.Packet)
+	req.NoError(err, "mock acknowledgement not relayed")
+	wantAck := ibcmock.MockFailAcknowledgement.Acknowledgement()
+	req.Equal(string(wantAck), string(ack.Acknowledgement))
+
+	// The mock module wrote the acknowledgement on its end.
+	commitment, err := mockChain.QueryPacketAcknowledgementCommitment(ctx, ibc.MockPort, tx.Packet.DestChannel, tx.Packet.Sequence)
+	req.NoError(err)
+	req.Equal(chantypes.CommitAcknowledgement(wantAck), commitment)
+
+	// The acknowledgement cleared the packet commitment, and the transfer module refunded the escrow.
+	pending, err := transferChain.QueryPendingPackets(ctx, mockChain, channel.PortID, channel.ChannelID)
+	req.NoError(err)
+	req.True(pending.Empty(), "pending packets: %s", pending)
+
+	balance, err := transferChain.GetBalance(ctx, sender.FormattedAddress(), denom)
+	req.NoError(err)
+	req.Equal(sentBalance+testCoinAmount, balance, "transfer to the mock module not refunded")
+}
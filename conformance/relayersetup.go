@@ -5,8 +5,8 @@ import (
 	"fmt"
 	"testing"
 
-	conntypes "github.com/cosmos/ibc-go/v6/modules/core/03-connection/types"
 	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
 	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
 	"github.com/strangelove-ventures/interchaintest/v6/testutil"
@@ -102,7 +102,7 @@ func TestRelayerSetup(t *testing.T, ctx context.Context, cf interchaintest.Chain
 		conn0 := conns0[0]
 		req.NotEmpty(conn0.ID)
 		req.NotEmpty(conn0.ClientID)
-		req.Equal(conn0.State, conntypes.OPEN.String())
+		req.Equal(ibc.ConnectionStateOpen, conn0.State)
 
 		conns1, err := r.GetConnections(ctx, eRep, c1.Config().ChainID)
 		req.NoError(err)
@@ -111,13 +111,16 @@ func TestRelayerSetup(t *testing.T, ctx context.Context, cf interchaintest.Chain
 		conn1 := conns1[0]
 		req.NotEmpty(conn1.ID)
 		req.NotEmpty(conn1.ClientID)
-		req.Equal(conn1.State, conntypes.OPEN.String())
+		req.Equal(ibc.ConnectionStateOpen, conn1.State)
 
 		// Now validate counterparties.
 		req.Equal(conn0.Counterparty.ClientId, conn1.ClientID)
 		req.Equal(conn0.Counterparty.ConnectionId, conn1.ID)
 		req.Equal(conn1.Counterparty.ClientId, conn0.ClientID)
 		req.Equal(conn1.Counterparty.ConnectionId, conn0.ID)
+
+		requireChainConnections(ctx, req, c0, conns0)
+		requireChainConnections(ctx, req, c1, conns1)
 	})
 	if t.Failed() {
 		return
@@ -160,16 +163,57 @@ func TestRelayerSetup(t *testing.T, ctx context.Context, cf interchaintest.Chain
 
 		// Piecemeal assertions against each channel.
 		// Not asserting against ConnectionHops or ChannelID.
-		req.Equal(ch0.State, "STATE_OPEN")
-		req.Equal(ch0.Ordering, "ORDER_UNORDERED")
+		req.Equal(ibc.ChannelStateOpen, ch0.State)
+		req.Equal(ibc.ChannelOrderingUnordered, ch0.Ordering)
 		req.Equal(ch0.Counterparty, ibc.ChannelCounterparty{PortID: "transfer", ChannelID: ch1.ChannelID})
 		req.Equal(ch0.Version, "ics20-1")
 		req.Equal(ch0.PortID, "transfer")
 
-		req.Equal(ch1.State, "STATE_OPEN")
-		req.Equal(ch1.Ordering, "ORDER_UNORDERED")
+		req.Equal(ibc.ChannelStateOpen, ch1.State)
+		req.Equal(ibc.ChannelOrderingUnordered, ch1.Ordering)
 		req.Equal(ch1.Counterparty, ibc.ChannelCounterparty{PortID: "transfer", ChannelID: ch0.ChannelID})
 		req.Equal(ch1.Version, "ics20-1")
 		req.Equal(ch1.PortID, "transfer")
+
+		requireChainChannels(ctx, req, c0, channels0)
+		requireChainChannels(ctx, req, c1, channels1)
 	})
 }
+
+// requireChainConnections asserts that the connections the relayer reported for chain are those of the chain state,
+// if chain is a cosmos chain, whose state is queried directly.
+func requireChainConnections(ctx context.Context, req *require.Assertions, chain ibc.Chain, reported ibc.ConnectionOutputs) {
+	c, ok := chain.(*cosmos.CosmosChain)
+	if !ok {
+		return
+	}
+	conns, err := c.QueryConnections(ctx)
+	req.NoError(err, "failed to query connections of %s", c.Config().ChainID)
+	req.Len(conns, len(reported))
+	for i, conn := range conns {
+		req.Positive(conn.QueryHeight)
+		req.Equal(reported[i].ID, conn.ID)
+		req.Equal(reported[i].ClientID, conn.ClientID)
+		req.Equal(reported[i].State, conn.State)
+		req.Equal(reported[i].Counterparty.ClientId, conn.Counterparty.ClientId)
+		req.Equal(reported[i].Counterparty.ConnectionId, conn.Counterparty.ConnectionId)
+	}
+}
+
+// requireChainChannels asserts that the channels the relayer reported for chain are those of the chain state,
+// if chain is a cosmos chain, whose state is queried directly.
+func requireChainChannels(ctx context.Context, req *require.Assertions, chain ibc.Chain, reported []ibc.ChannelOutput) {
+	c, ok := chain.(*cosmos.CosmosChain)
+	if !ok {
+		return
+	}
+	channels, err := c.QueryChannels(ctx)
+	req.NoError(err, "failed to query channels of %s", c.Config().ChainID)
+	req.Len(channels, len(reported))
+	for i, ch := range channels {
+		req.Positive(ch.QueryHeight)
+		// Relayers do not report the query height.
+		ch.QueryHeight = 0
+		req.Equal(reported[i], ch)
+	}
+}
@@ -0,0 +1,280 @@
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultStressTransfers = 200
+	defaultStressSenders   = 4
+	defaultStressTimeout   = 5 * time.Minute
+
+	// stressBatchSize is the number of transfers a sender sends in a single transaction.
+	stressBatchSize = 25
+)
+
+var (
+	// errStressPacketsLost is returned when packets of the stress test are neither acknowledged nor pending.
+	errStressPacketsLost = errors.New("packets lost")
+
+	// errStressTooSlow is returned when packets of the stress test are still pending after the timeout.
+	errStressTooSlow = errors.New("relayer too slow")
+)
+
+// StressOptions configures TestRelayerStress. Zero values use the defaults.
+type StressOptions struct {
+	// Transfers is the total number of transfers sent. Defaults to 200.
+	Transfers int
+
+	// Senders is the number of users sending transfers concurrently. Defaults to 4.
+	Senders int
+
+	// Timeout is how long the relayer has to acknowledge all transfers after the first one is sent.
+	// It must be shorter than the ten minute timeout of the packets. Defaults to 5 minutes.
+	Timeout time.Duration
+}
+
+func (o StressOptions) withDefaults() StressOptions {
+	if o.Transfers <= 0 {
+		o.Transfers = defaultStressTransfers
+	}
+	if o.Senders <= 0 {
+		o.Senders = defaultStressSenders
+	}
+	if o.Senders > o.Transfers {
+		o.Senders = o.Transfers
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = defaultStressTimeout
+	}
+	return o
+}
+
+// TestRelayerStress sends a high volume of transfers from several senders over a single channel,
+// while the relayer is running, and asserts that the relayer acknowledges all of them in time.
+//
+// The senders are funded concurrently through the faucet's sequence-safe broadcaster,
+// and each sends its transfers in batches of several messages per transaction.
+// Acknowledgements are counted from the blocks of the sending chain as they are produced.
+// The throughput in packets per minute, the time to acknowledge all packets,
+// and the maximum backlog of pending packets are reported as metrics.
+func TestRelayerStress(t *testing.T, ctx context.Context, cf interchaintest.ChainFactory, rf interchaintest.RelayerFactory, rep *testreporter.Reporter, opts StressOptions) {
+	rep.TrackTest(t)
+
+	opts = opts.withDefaults()
+
+	client, network := interchaintest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	c0, ok := chains[0].(*cosmos.CosmosChain)
+	if !ok {
+		t.Skipf("stress test requires cosmos chains, got %T", chains[0])
+	}
+	c1, ok := chains[1].(*cosmos.CosmosChain)
+	if !ok {
+		t.Skipf("stress test requires cosmos chains, got %T", chains[1])
+	}
+
+	r := rf.Build(t, client, network)
+
+	const pathName = "p"
+	ic := interchaintest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path:              pathName,
+			CreateChannelOpts: ibc.DefaultChannelOpts(),
+		})
+
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	defer ic.Close()
+
+	channels, err := r.GetChannels(ctx, eRep, c0.Config().ChainID)
+	req.NoError(err)
+	req.Len(channels, 1)
+	channel := channels[0]
+
+	// Fund all senders at once; GetAndFundTestUsers returns one user per chain argument.
+	senderChains := make([]ibc.Chain, opts.Senders)
+	for i := range senderChains {
+		senderChains[i] = c0
+	}
+	senders := interchaintest.GetAndFundTestUsers(t, ctx, "stress", userFaucetFund, senderChains...)
+	receiver := interchaintest.GetAndFundTestUsers(t, ctx, "stress-receiver", 1, c1)[0]
+
+	req.NoError(r.StartRelayer(ctx, eRep, pathName))
+	defer func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("failed to stop relayer: %v", err)
+		}
+	}()
+
+	startHeight, err := c0.Height(ctx)
+	req.NoError(err)
+	start := time.Now()
+
+	// Split the transfers between the senders, each sending its share in batches.
+	sent := make([][]ibc.Tx, opts.Senders)
+	var eg errgroup.Group
+	for i := range senders {
+		i := i
+		user := senders[i].(*cosmos.CosmosWallet)
+		n := opts.Transfers / opts.Senders
+		if i < opts.Transfers%opts.Senders {
+			n++
+		}
+		eg.Go(func() error {
+			b := cosmos.NewBroadcaster(t, c0)
+			for n > 0 {
+				size := n
+				if size > stressBatchSize {
+					size = stressBatchSize
+				}
+				batch := make([]cosmos.IBCTransfer, size)
+				for j := range batch {
+					batch[j] = cosmos.IBCTransfer{
+						ChannelID: channel.ChannelID,
+						Amount: ibc.WalletAmount{
+							Address: receiver.FormattedAddress(),
+							Denom:   c0.Config().Denom,
+							Amount:  1,
+						},
+					}
+				}
+				txs, err := cosmos.BatchSendIBCTransfer(ctx, b, user, batch, ibc.TransferOptions{})
+				if err != nil {
+					return fmt.Errorf("sender %s: %w", user.KeyName(), err)
+				}
+				sent[i] = append(sent[i], txs...)
+				n -= len(batch)
+			}
+			return nil
+		})
+	}
+	sendErr := make(chan error, 1)
+	go func() { sendErr <- eg.Wait() }()
+
+	var (
+		sendDone   bool
+		acked      = make(map[uint64]bool)
+		timedOut   = make(map[uint64]bool)
+		pending    int
+		maxBacklog int
+		lastAck    time.Time
+	)
+	isChannelPacket := func(p ibc.Packet) bool {
+		return p.SourcePort == channel.PortID && p.SourceChannel == channel.ChannelID
+	}
+	deadline := start.Add(opts.Timeout)
+	for height := startHeight; ; {
+		if !sendDone {
+			select {
+			case err := <-sendErr:
+				req.NoError(err, "failed to send transfers")
+				sendDone = true
+			default:
+			}
+		}
+
+		// Query the backlog before reading the blocks, so that the acknowledgements
+		// of the packets that are no longer pending are in the blocks read below.
+		pendingSend, pendingAck, err := c0.QueryPacketBacklog(ctx, c1, channel.PortID, channel.ChannelID)
+		req.NoError(err, "failed to query packet backlog")
+		pending = pendingSend + pendingAck
+		if pending > maxBacklog {
+			maxBacklog = pending
+		}
+
+		// Read the acknowledgements and timeouts from every block since the last poll.
+		current, err := c0.Height(ctx)
+		req.NoError(err)
+		for ; height <= current; height++ {
+			acks, err := c0.Acknowledgements(ctx, height)
+			req.NoError(err, "failed to get acknowledgements at height %d", height)
+			for _, ack := range acks {
+				if isChannelPacket(ack.Packet) && !acked[ack.Packet.Sequence] {
+					acked[ack.Packet.Sequence] = true
+					lastAck = time.Now()
+				}
+			}
+			timeouts, err := c0.Timeouts(ctx, height)
+			req.NoError(err, "failed to get timeouts at height %d", height)
+			for _, timeout := range timeouts {
+				if isChannelPacket(timeout.Packet) {
+					timedOut[timeout.Packet.Sequence] = true
+				}
+			}
+		}
+
+		// Once all transfers are sent, stop when no packet is outstanding on the channel.
+		if sendDone && (pending == 0 || len(acked)+len(timedOut) >= opts.Transfers) {
+			break
+		}
+		if time.Now().After(deadline) {
+			break
+		}
+		req.NoError(testutil.WaitForBlocks(ctx, 1, c0))
+	}
+
+	rep.TrackMetric(t, "max backlog", float64(maxBacklog), "packets")
+	req.True(sendDone, "transfers not sent within %s", opts.Timeout)
+
+	var missing int
+	for _, txs := range sent {
+		for _, tx := range txs {
+			if !acked[tx.Packet.Sequence] && !timedOut[tx.Packet.Sequence] {
+				missing++
+			}
+		}
+	}
+	req.NoError(stressOutcome(opts, len(acked), len(timedOut), missing, pending))
+
+	elapsed := lastAck.Sub(start)
+	rep.TrackMetric(t, "time to full ack", elapsed.Seconds(), "s")
+	rep.TrackMetric(t, "throughput", float64(opts.Transfers)/elapsed.Minutes(), "packets/min")
+}
+
+// stressOutcome returns errStressPacketsLost if any transfer timed out, or is missing an acknowledgement
+// although no packet is pending on the channel, and errStressTooSlow if transfers are still pending.
+func stressOutcome(opts StressOptions, acked, timedOut, missing, pending int) error {
+	if timedOut > 0 {
+		return fmt.Errorf("%w: %d of %d packets timed out", errStressPacketsLost, timedOut, opts.Transfers)
+	}
+	if missing > 0 && pending == 0 {
+		return fmt.Errorf("%w: %d of %d packets were neither acknowledged nor pending", errStressPacketsLost, missing, opts.Transfers)
+	}
+	if missing > 0 {
+		return fmt.Errorf("%w: %d of %d packets acknowledged within %s, %d still pending", errStressTooSlow, acked, opts.Transfers, opts.Timeout, pending)
+	}
+	return nil
+}
@@ -98,14 +98,14 @@ var relayerTestCaseConfigs = [...]RelayerTestCaseConfig{
 		Name:                        "height timeout",
 		RequiredRelayerCapabilities: []relayer.Capability{relayer.HeightTimeout},
 		PreRelayerStart:             preRelayerStart_HeightTimeout,
-		Test:                        testPacketRelayFail,
+		Test:                        testPacketRelayTimeout(heightTimeoutTrigger),
 		TestLabels:                  []label.Test{label.Timeout, label.HeightTimeout},
 	},
 	{
 		Name:                        "timestamp timeout",
 		RequiredRelayerCapabilities: []relayer.Capability{relayer.TimestampTimeout},
 		PreRelayerStart:             preRelayerStart_TimestampTimeout,
-		Test:                        testPacketRelayFail,
+		Test:                        testPacketRelayTimeout(timestampTimeoutTrigger),
 		TestLabels:                  []label.Test{label.Timeout, label.TimestampTimeout},
 	},
 }
@@ -206,6 +206,15 @@ func sendIBCTransfersFromBothChainsWithTimeout(
 	}
 }
 
+// TestOptions configures the cases of TestWithOptions. The zero value runs every case with its defaults.
+type TestOptions struct {
+	// SkipStress skips the stress case, which sends hundreds of transfers and takes minutes per relayer.
+	SkipStress bool
+
+	// Stress configures the stress case, see TestRelayerStress.
+	Stress StressOptions
+}
+
 // Test is the stable API exposed by the conformance package.
 // This is intended to be used by Go unit tests.
 //
@@ -214,6 +223,11 @@ func sendIBCTransfersFromBothChainsWithTimeout(
 // If the subtest configuration does not meet your needs,
 // you can directly call one of the other exported Test functions, such as TestChainPair.
 func Test(t *testing.T, ctx context.Context, cfs []interchaintest.ChainFactory, rfs []interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	TestWithOptions(t, ctx, cfs, rfs, rep, TestOptions{})
+}
+
+// TestWithOptions runs the cases of Test, configured by opts.
+func TestWithOptions(t *testing.T, ctx context.Context, cfs []interchaintest.ChainFactory, rfs []interchaintest.RelayerFactory, rep *testreporter.Reporter, opts TestOptions) {
 	// Validate chain factory counts up front.
 	counts := make(map[int]bool)
 	for _, cf := range cfs {
@@ -269,6 +283,37 @@ func Test(t *testing.T, ctx context.Context, cfs []interchaintest.ChainFactory,
 
 								TestRelayerFlushing(t, ctx, cf, rf, rep)
 							})
+
+							t.Run("client refresh", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerClientRefresh(t, ctx, cf, rf, rep)
+							})
+
+							t.Run("stress", func(t *testing.T) {
+								rep.TrackTest(t)
+								if opts.SkipStress {
+									rep.TrackSkip(t, "stress case skipped by TestOptions.SkipStress")
+								}
+								rep.TrackParallel(t)
+
+								TestRelayerStress(t, ctx, cf, rf, rep, opts.Stress)
+							})
+
+							t.Run("channel upgrade", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerChannelUpgrade(t, ctx, cf, rf, rep)
+							})
+
+							t.Run("mock packet", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerMockPacket(t, ctx, cf, rf, rep)
+							})
 						})
 					}
 				})
@@ -360,6 +405,76 @@ func TestChainPair(
 			})
 		}
 	})
+
+	t.Run("no pending packets", func(t *testing.T) {
+		rep.TrackTest(t)
+		testNoPendingPackets(ctx, t, rep, relayerImpl, srcChain, dstChain, channels, pathNames...)
+	})
+}
+
+// testNoPendingPackets asserts that the relayer has nothing left to relay on any of the channels of srcChain.
+// In-flight packets are given a few blocks to settle before failing with the pending sequences.
+// The path of each channel is found by its connection when several paths are relayed.
+func testNoPendingPackets(
+	ctx context.Context,
+	t *testing.T,
+	rep *testreporter.Reporter,
+	r ibc.Relayer,
+	srcChain, dstChain ibc.Chain,
+	channels []ibc.ChannelOutput,
+	pathNames ...string,
+) {
+	req := require.New(rep.TestifyT(t))
+	eRep := rep.RelayerExecReporter(t)
+
+	pathOf := func(channel ibc.ChannelOutput) string {
+		switch len(pathNames) {
+		case 0:
+			return interchaintest.TestPathName
+		case 1:
+			return pathNames[0]
+		}
+		pg, ok := r.(relayer.PathGetter)
+		if !ok {
+			rep.TrackSkip(t, "relayer cannot attribute channel %s to one of the paths %v", channel.ChannelID, pathNames)
+		}
+		req.NotEmpty(channel.ConnectionHops, "channel %s has no connection", channel.ChannelID)
+		for _, pathName := range pathNames {
+			p, err := pg.GetPath(ctx, eRep, pathName)
+			req.NoError(err, "failed to get path %s", pathName)
+			if pathEndOn(p, srcChain.Config().ChainID).ConnectionID == channel.ConnectionHops[0] {
+				return pathName
+			}
+		}
+		req.FailNow("no path for channel", "channel %s on connection %s", channel.ChannelID, channel.ConnectionHops[0])
+		return ""
+	}
+
+	querier, _ := srcChain.(testutil.PendingPacketsQuerier)
+
+	const attempts = 5
+	for _, channel := range channels {
+		pathName := pathOf(channel)
+		var pending ibc.PendingPackets
+		for i := 0; i < attempts; i++ {
+			var err error
+			pending, err = testutil.GetPendingPackets(ctx, r, eRep, pathName, channel, querier, dstChain)
+			req.NoError(err, "failed to get pending packets on channel %s", channel.ChannelID)
+			if pending.Empty() {
+				break
+			}
+			req.NoError(testutil.WaitForBlocks(ctx, 2, srcChain, dstChain), "failed to wait for blocks")
+		}
+		req.True(pending.Empty(), "pending packets on channel %s of path %s: %s", channel.ChannelID, pathName, pending)
+	}
+}
+
+// pathEndOn returns the end of p on the chain with chainID.
+func pathEndOn(p relayer.Path, chainID string) relayer.PathEnd {
+	if p.Dst.ChainID == chainID {
+		return p.Dst
+	}
+	return p.Src
 }
 
 // PreRelayerStart methods for the RelayerTestCases
@@ -469,7 +584,30 @@ func testPacketRelaySuccess(
 	//[END] assert on destination to source transfer
 }
 
-// Ensure that a queued packet that should not be relayed is not relayed.
+// timeoutTrigger is the field of a packet that is expected to time it out.
+type timeoutTrigger string
+
+const (
+	heightTimeoutTrigger    timeoutTrigger = "height"
+	timestampTimeoutTrigger timeoutTrigger = "timestamp"
+)
+
+// Ensure that a queued packet that should not be relayed is not relayed,
+// but timed out by trigger and refunded on its sending chain.
+func testPacketRelayTimeout(trigger timeoutTrigger) func(context.Context, *testing.T, *RelayerTestCase, *testreporter.Reporter, ibc.Chain, ibc.Chain, []ibc.ChannelOutput) {
+	return func(
+		ctx context.Context,
+		t *testing.T,
+		testCase *RelayerTestCase,
+		rep *testreporter.Reporter,
+		srcChain ibc.Chain,
+		dstChain ibc.Chain,
+		channels []ibc.ChannelOutput,
+	) {
+		testPacketRelayFail(ctx, t, testCase, rep, srcChain, dstChain, channels, trigger)
+	}
+}
+
 func testPacketRelayFail(
 	ctx context.Context,
 	t *testing.T,
@@ -478,6 +616,7 @@ func testPacketRelayFail(
 	srcChain ibc.Chain,
 	dstChain ibc.Chain,
 	channels []ibc.ChannelOutput,
+	trigger timeoutTrigger,
 ) {
 	req := require.New(rep.TestifyT(t))
 
@@ -498,6 +637,7 @@ func testPacketRelayFail(
 		timeout, err := testutil.PollForTimeout(ctx, srcChain, srcTx.Height, srcTx.Height+pollHeightMax, srcTx.Packet)
 		req.NoError(err, "failed to get timeout packet on source chain")
 		req.NoError(timeout.Validate(), "invalid timeout packet on source chain")
+		assertTimeoutPacket(ctx, req, trigger, srcChain, dstChain, srcTx)
 
 		// Even though we poll for the timeout, there may be timing issues where balances are not fully reconciled yet.
 		// So we have a small buffer here.
@@ -529,6 +669,7 @@ func testPacketRelayFail(
 		timeout, err := testutil.PollForTimeout(ctx, dstChain, dstTx.Height, dstTx.Height+pollHeightMax, dstTx.Packet)
 		req.NoError(err, "failed to get timeout packet on destination chain")
 		req.NoError(timeout.Validate(), "invalid timeout packet on destination chain")
+		assertTimeoutPacket(ctx, req, trigger, dstChain, srcChain, dstTx)
 
 		// get ibc denom for dst denom on src chain
 		dstDenomTrace := transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom(channels[i].PortID, channels[i].ChannelID, dstDenom))
@@ -547,3 +688,51 @@ func testPacketRelayFail(
 	}
 	// [END] assert on destination to source transfer
 }
+
+// assertTimeoutPacket asserts that the packet of tx, sent from sender to receiver, was timed out on sender,
+// with a timeout_packet event, and that trigger is the field of the packet that timed it out.
+func assertTimeoutPacket(ctx context.Context, req *require.Assertions, trigger timeoutTrigger, sender, receiver ibc.Chain, tx ibc.Tx) {
+	chainID := sender.Config().ChainID
+	packet := tx.Packet
+
+	poller := testutil.BlockPoller[ibc.Packet]{
+		CurrentHeight: sender.Height,
+		PollFunc: func(ctx context.Context, height uint64) (ibc.Packet, error) {
+			packets, err := sender.(*cosmos.CosmosChain).TimeoutPacketEvents(ctx, height)
+			if err != nil {
+				return ibc.Packet{}, err
+			}
+			for _, p := range packets {
+				if p.Sequence == packet.Sequence && p.SourcePort == packet.SourcePort && p.SourceChannel == packet.SourceChannel {
+					return p, nil
+				}
+			}
+			return ibc.Packet{}, testutil.ErrNotFound
+		},
+	}
+	event, err := poller.DoPoll(ctx, tx.Height, tx.Height+pollHeightMax)
+	req.NoError(err, "failed to get timeout_packet event of packet %d on %s", packet.Sequence, chainID)
+	req.Equal(packet.TimeoutHeight, event.TimeoutHeight, "timeout height of timeout_packet event on %s", chainID)
+	req.Equal(packet.TimeoutTimestamp, event.TimeoutTimestamp, "timeout timestamp of timeout_packet event on %s", chainID)
+
+	// ibc-go times out a packet once either of its timeouts passed,
+	// so assert that the timeout other than trigger has not passed yet.
+	timeoutHeight, err := packet.ParsedTimeoutHeight()
+	req.NoError(err, "invalid timeout height of packet %d on %s", packet.Sequence, chainID)
+	switch trigger {
+	case heightTimeoutTrigger:
+		req.False(timeoutHeight.IsZero(), "packet %d on %s has no timeout height", packet.Sequence, chainID)
+		if packet.TimeoutTimestamp != 0 {
+			req.True(time.Now().Before(time.Unix(0, int64(packet.TimeoutTimestamp))),
+				"timeout timestamp of packet %d on %s has passed, so the packet may not have timed out by height", packet.Sequence, chainID)
+		}
+	case timestampTimeoutTrigger:
+		req.NotZero(packet.TimeoutTimestamp, "packet %d on %s has no timeout timestamp", packet.Sequence, chainID)
+		if !timeoutHeight.IsZero() {
+			receiverHeight, err := receiver.Height(ctx)
+			req.NoError(err, "failed to get height of %s", receiver.Config().ChainID)
+			req.True(ibc.HeightForChainID(receiver.Config().ChainID, receiverHeight).LT(timeoutHeight),
+				"timeout height of packet %d on %s has passed, so the packet may not have timed out by timestamp", packet.Sequence, chainID)
+		}
+	}
+}
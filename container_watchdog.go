@@ -0,0 +1,28 @@
+package interchaintest
+
+import (
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
+)
+
+// ContainerExit describes a chain node or relayer container that exited unexpectedly,
+// as reported to InterchainBuildOptions.OnContainerExit.
+type ContainerExit = dockerutil.ContainerExit
+
+// ContainerExitTestingT is a subset of testing.TB to fail a test from FailOnContainerExit.
+type ContainerExitTestingT interface {
+	Errorf(format string, args ...any)
+}
+
+// FailOnContainerExit returns a callback for InterchainBuildOptions.OnContainerExit
+// that fails t with the exit code and last log lines of the exited container.
+//
+// The test continues to run, as t.FailNow must not be called outside the test goroutine,
+// but is marked as failed as soon as the container exits.
+func FailOnContainerExit(t ContainerExitTestingT) func(ContainerExit) {
+	return func(exit ContainerExit) {
+		t.Errorf("Container %s exited unexpectedly with code %d at %s, last logs:\n%s",
+			exit.Name, exit.ExitCode, exit.Time.Format(time.RFC3339), exit.Logs)
+	}
+}
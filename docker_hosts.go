@@ -0,0 +1,96 @@
+package interchaintest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
+	"go.uber.org/zap"
+)
+
+// DockerHost is a docker daemon, other than that of InterchainBuildOptions.Client, on which chains run,
+// e.g. on a second machine for topologies too large for one. See InterchainBuildOptions.ChainDockerHosts.
+//
+// Chains on a docker host are reached by the test and by the relayers through the ports they publish
+// on the Address of the host, so the machine must accept connections on its published ports.
+// All nodes of a chain run on the same host; use ibc.ChainConfig.AdditionalPeers to peer with nodes elsewhere.
+type DockerHost struct {
+	// Client and NetworkID of the host, e.g. from DockerSetupHost.
+	Client    *client.Client
+	NetworkID string
+
+	// Address of the machine of the host as reachable from the test and the relayers, e.g. 10.0.0.2.
+	// Defaults to the host of the daemon address of Client, e.g. 10.0.0.2 for tcp://10.0.0.2:2376.
+	Address string
+}
+
+// hostAddressSetter is implemented by chains that can run on a DockerHost.
+type hostAddressSetter interface {
+	SetHostAddress(address string)
+}
+
+// address returns the address of the host, or an error if it cannot be determined.
+func (h DockerHost) address() (string, error) {
+	if h.Address != "" {
+		return h.Address, nil
+	}
+	if h.Client == nil {
+		return "", fmt.Errorf("docker host has no client")
+	}
+	if addr := dockerutil.DaemonAddress(h.Client); addr != "" {
+		return addr, nil
+	}
+	return "", fmt.Errorf("docker host %s is not reached over the network, its address must be set", h.Client.DaemonHost())
+}
+
+// configureDockerHosts sets the host address of the chains of the interchain on the given docker hosts.
+func (ic *Interchain) configureDockerHosts(hosts map[ibc.Chain]DockerHost) error {
+	for c, h := range hosts {
+		name, ok := ic.chains[c]
+		if !ok {
+			return fmt.Errorf("docker host given for chain %s, which is not in the interchain", c.Config().ChainID)
+		}
+		if h.Client == nil || h.NetworkID == "" {
+			return fmt.Errorf("docker host of chain %s must have a client and a network", name)
+		}
+		s, ok := c.(hostAddressSetter)
+		if !ok {
+			return fmt.Errorf("chain %s of type %T cannot run on another docker host", name, c)
+		}
+		addr, err := h.address()
+		if err != nil {
+			return fmt.Errorf("chain %s: %w", name, err)
+		}
+		s.SetHostAddress(addr)
+	}
+	return nil
+}
+
+// watchContainers watches the containers of the test on the docker daemon of opts.Client
+// and on the distinct docker hosts of the chains, and returns the function stopping all the watchdogs.
+func (ic *Interchain) watchContainers(opts InterchainBuildOptions) (stop func()) {
+	clients := []*client.Client{opts.Client}
+	for _, h := range opts.ChainDockerHosts {
+		seen := false
+		for _, cli := range clients {
+			seen = seen || cli == h.Client
+		}
+		if !seen {
+			clients = append(clients, h.Client)
+		}
+	}
+
+	stops := make([]func(), len(clients))
+	for i, cli := range clients {
+		stops[i] = dockerutil.WatchContainers(context.Background(), cli, opts.TestName, opts.OnContainerExit, func(err error) {
+			ic.log.Warn("Container watchdog stopped", zap.Error(err))
+		})
+	}
+	return func() {
+		for _, stop := range stops {
+			stop()
+		}
+	}
+}
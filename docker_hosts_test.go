@@ -0,0 +1,58 @@
+package interchaintest
+
+import (
+	"testing"
+
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+// hostChain is an ibc.Chain recording its host address.
+// Calling any other method panics.
+type hostChain struct {
+	chainIDChain
+
+	hostAddress string
+}
+
+func (c *hostChain) SetHostAddress(address string) { c.hostAddress = address }
+
+func TestInterchain_ConfigureDockerHosts(t *testing.T) {
+	newClient := func(host string) *client.Client {
+		cli, err := client.NewClientWithOpts(client.WithHost(host))
+		require.NoError(t, err)
+		return cli
+	}
+
+	a, b := &hostChain{chainIDChain: chainIDChain{id: "a"}}, &hostChain{chainIDChain: chainIDChain{id: "b"}}
+	ic := NewInterchain().AddChain(a).AddChain(b)
+	require.NoError(t, ic.configureDockerHosts(map[ibc.Chain]DockerHost{
+		a: {Client: newClient("tcp://10.0.0.2:2376"), NetworkID: "net"},
+		b: {Client: newClient("ssh://docker@bastion"), NetworkID: "net", Address: "10.0.0.3"},
+	}))
+	require.Equal(t, "10.0.0.2", a.hostAddress)
+	require.Equal(t, "10.0.0.3", b.hostAddress)
+
+	err := ic.configureDockerHosts(map[ibc.Chain]DockerHost{
+		a: {Client: newClient("unix:///var/run/docker.sock"), NetworkID: "net"},
+	})
+	require.ErrorContains(t, err, "its address must be set")
+
+	err = ic.configureDockerHosts(map[ibc.Chain]DockerHost{
+		a: {Client: newClient("tcp://10.0.0.2:2376")},
+	})
+	require.ErrorContains(t, err, "must have a client and a network")
+
+	err = ic.configureDockerHosts(map[ibc.Chain]DockerHost{
+		&hostChain{chainIDChain: chainIDChain{id: "c"}}: {Client: newClient("tcp://10.0.0.2:2376"), NetworkID: "net"},
+	})
+	require.ErrorContains(t, err, "chain c, which is not in the interchain")
+
+	d := chainIDChain{id: "d"}
+	ic.AddChain(d)
+	err = ic.configureDockerHosts(map[ibc.Chain]DockerHost{
+		d: {Client: newClient("tcp://10.0.0.2:2376"), NetworkID: "net"},
+	})
+	require.ErrorContains(t, err, "cannot run on another docker host")
+}
@@ -0,0 +1,31 @@
+package interchaintest
+
+import (
+	"context"
+
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
+)
+
+// DockerResource is a docker container, volume or network created by interchaintest, as listed by ListResources.
+// Every such resource is labeled with the name of its test, the test package, and its creation time.
+type DockerResource = dockerutil.Resource
+
+// DockerResourceKind is the kind of a DockerResource.
+type DockerResourceKind = dockerutil.ResourceKind
+
+const (
+	DockerContainer = dockerutil.ResourceContainer
+	DockerVolume    = dockerutil.ResourceVolume
+	DockerNetwork   = dockerutil.ResourceNetwork
+)
+
+// DockerResourceFilter selects the resources listed by ListResources. The zero value selects all of them.
+type DockerResourceFilter = dockerutil.ResourceFilter
+
+// ListResources returns the docker resources created by interchaintest on the docker daemon of cli
+// and selected by filter, sorted by creation time,
+// e.g. for a cleanup binary or TestMain to prune the leftovers of crashed test runs on a shared CI machine.
+func ListResources(ctx context.Context, cli *client.Client, filter DockerResourceFilter) ([]DockerResource, error) {
+	return dockerutil.ListResources(ctx, cli, filter)
+}
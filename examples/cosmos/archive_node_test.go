@@ -0,0 +1,110 @@
+package cosmos_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestArchiveNode queries a historical balance that the pruning validators no longer serve,
+// from an archive node added to the chain.
+func TestArchiveNode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	const pruningInterval = 10
+
+	// Aggressive pruning, keeping only the last two states.
+	appTomlOverrides := make(testutil.Toml)
+	appTomlOverrides["pruning"] = "custom"
+	appTomlOverrides["pruning-keep-recent"] = 2
+	appTomlOverrides["pruning-keep-every"] = 0
+	appTomlOverrides["pruning-interval"] = pruningInterval
+
+	nf := 0
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion, ChainConfig: ibc.ChainConfig{
+			ConfigFileOverrides: map[string]any{"config/app.toml": appTomlOverrides},
+		}, NumFullNodes: &nf},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000, gaia, gaia)
+	sender, recipient := users[0], users[1]
+
+	oldHeight, err := gaia.Height(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, gaia.SendFunds(ctx, sender.KeyName(), ibc.WalletAmount{
+		Address: recipient.FormattedAddress(),
+		Denom:   gaia.Config().Denom,
+		Amount:  1_000,
+	}))
+
+	// Let the validators prune the state at oldHeight.
+	require.NoError(t, testutil.WaitForBlocks(ctx, 3*pruningInterval, gaia))
+
+	balanceAt := func(n *cosmos.ChainNode, height int64) (int64, error) {
+		stdout, _, err := n.ExecQueryAtHeight(ctx, height, "bank", "balances", recipient.FormattedAddress(), "--denom", gaia.Config().Denom)
+		if err != nil {
+			return 0, err
+		}
+		var coin types.Coin
+		if err := json.Unmarshal(stdout, &coin); err != nil {
+			return 0, err
+		}
+		return coin.Amount.Int64(), nil
+	}
+
+	_, err = balanceAt(gaia.Validators[0], int64(oldHeight))
+	require.Error(t, err, "validator should have pruned height %d", oldHeight)
+
+	addCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+	archive, err := gaia.AddArchiveFullNode(addCtx)
+	require.NoError(t, err)
+
+	bal, err := balanceAt(archive, int64(oldHeight))
+	require.NoError(t, err)
+	require.Equal(t, int64(10_000_000), bal)
+
+	h, err := gaia.Height(ctx)
+	require.NoError(t, err)
+	bal, err = balanceAt(archive, int64(h))
+	require.NoError(t, err)
+	require.Equal(t, int64(10_001_000), bal)
+}
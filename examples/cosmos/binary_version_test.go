@@ -0,0 +1,56 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestBinaryVersion asserts that the version of the chain binary is probed before the chain starts,
+// so that tests can branch on the SDK version of the chain.
+func TestBinaryVersion(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	_, ok := gaia.BinaryVersion()
+	require.False(t, ok, "version probed before start")
+
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	v, ok := gaia.BinaryVersion()
+	require.True(t, ok)
+	require.Equal(t, gaiaVersion, v.Version)
+	require.Equal(t, "gaiad", v.ServerName)
+
+	// Gaia v7 is built with SDK v0.45, without gov v1.
+	require.NotEmpty(t, v.SDKVersion)
+	require.False(t, v.SDKAtLeast("v0.46.0"))
+	require.Len(t, v.Incompatibilities(gaiaVersion), 1, "image and binary versions must match")
+}
@@ -71,6 +71,11 @@ func CosmosChainUpgradeTest(t *testing.T, chainName, initialVersion, upgradeVers
 	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), userFunds, chain)
 	chainUser := users[0]
 
+	versionBefore, err := chain.AppVersion(ctx)
+	require.NoError(t, err, "error fetching app version before upgrade")
+	modulesBefore, err := chain.QueryModuleVersions(ctx)
+	require.NoError(t, err, "error fetching module versions before upgrade")
+
 	height, err := chain.Height(ctx)
 	require.NoError(t, err, "error fetching height before submit upgrade proposal")
 
@@ -131,6 +136,17 @@ func CosmosChainUpgradeTest(t *testing.T, chainName, initialVersion, upgradeVers
 	require.NoError(t, err, "error fetching height after upgrade")
 
 	require.GreaterOrEqual(t, height, haltHeight+blocksAfterUpgrade, "height did not increment enough after upgrade")
+
+	versionAfter, err := chain.AppVersion(ctx)
+	require.NoError(t, err, "error fetching app version after upgrade")
+	require.NotEqual(t, versionBefore, versionAfter, "app version did not change with the upgrade")
+
+	// The upgrade handler ran the migrations, which never lower a module's consensus version.
+	modulesAfter, err := chain.QueryModuleVersions(ctx)
+	require.NoError(t, err, "error fetching module versions after upgrade")
+	for name, before := range modulesBefore {
+		require.GreaterOrEqual(t, modulesAfter[name], before, "consensus version of module %s", name)
+	}
 }
 
 func modifyGenesisShortProposals(votingPeriod string, maxDepositPeriod string) func(ibc.ChainConfig, []byte) ([]byte, error) {
@@ -148,6 +164,18 @@ func modifyGenesisShortProposals(votingPeriod string, maxDepositPeriod string) f
 		if err := dyno.Set(g, chainConfig.Denom, "app_state", "gov", "deposit_params", "min_deposit", 0, "denom"); err != nil {
 			return nil, fmt.Errorf("failed to set voting period in genesis json: %w", err)
 		}
+		// Since SDK v0.47, gov reads its params from a single params object instead.
+		if _, err := dyno.Get(g, "app_state", "gov", "params"); err == nil {
+			if err := dyno.Set(g, votingPeriod, "app_state", "gov", "params", "voting_period"); err != nil {
+				return nil, fmt.Errorf("failed to set voting period in genesis json: %w", err)
+			}
+			if err := dyno.Set(g, maxDepositPeriod, "app_state", "gov", "params", "max_deposit_period"); err != nil {
+				return nil, fmt.Errorf("failed to set max deposit period in genesis json: %w", err)
+			}
+			if err := dyno.Set(g, chainConfig.Denom, "app_state", "gov", "params", "min_deposit", 0, "denom"); err != nil {
+				return nil, fmt.Errorf("failed to set min deposit denom in genesis json: %w", err)
+			}
+		}
 		out, err := json.Marshal(g)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
@@ -0,0 +1,172 @@
+package cosmos_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestCometMock runs gaia against CometMock, producing blocks at once with AdvanceBlocks
+// and fast-forwarding the block time with AdvanceTime.
+func TestCometMock(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	nf := 0
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion, NumFullNodes: &nf, ChainConfig: ibc.ChainConfig{
+			ConsensusEngine: &ibc.ConsensusEngineConfig{
+				Image: ibc.DockerImage{Repository: ibc.DefaultCometMockImage.Repository, Version: "v0.34.x", UidGid: "1025:1025"},
+				// gaia v7 is built with SDK v0.45, whose standalone mode serves ABCI only.
+				AppFlags:      []string{"--with-tendermint=false", "--transport=grpc", "--address=tcp://0.0.0.0:26658"},
+				BlockInterval: time.Second,
+			},
+		}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	require.NotNil(t, gaia.CometMock())
+
+	height, err := gaia.Height(ctx)
+	require.NoError(t, err)
+
+	// Far more blocks than the block interval produces in the time it takes.
+	start := time.Now()
+	require.NoError(t, gaia.AdvanceBlocks(ctx, 50))
+	require.Less(t, time.Since(start), 20*time.Second)
+
+	advanced, err := gaia.Height(ctx)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, advanced, height+50)
+
+	// Fast-forward the block time, e.g. past a timeout, without waiting.
+	before, err := gaia.Validators[0].Client.Status(ctx)
+	require.NoError(t, err)
+	require.NoError(t, gaia.AdvanceTime(ctx, time.Hour))
+	require.NoError(t, gaia.AdvanceBlock(ctx))
+	after, err := gaia.Validators[0].Client.Status(ctx)
+	require.NoError(t, err)
+	require.False(t, after.SyncInfo.LatestBlockTime.Before(before.SyncInfo.LatestBlockTime.Add(time.Hour)))
+
+	// Transactions are broadcast through the RPC server of CometMock.
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000, gaia, gaia)
+	sender, recipient := users[0], users[1]
+	denom := gaia.Config().Denom
+
+	require.NoError(t, gaia.SendFunds(ctx, sender.KeyName(), ibc.WalletAmount{
+		Address: recipient.FormattedAddress(),
+		Denom:   denom,
+		Amount:  1_000,
+	}))
+
+	// The app serves no gRPC in standalone mode on SDK v0.45, so query through the CLI.
+	stdout, _, err := gaia.Validators[0].ExecQuery(ctx, "bank", "balances", recipient.FormattedAddress())
+	require.NoError(t, err)
+	var res struct {
+		Balances sdk.Coins `json:"balances"`
+	}
+	require.NoError(t, json.Unmarshal(stdout, &res))
+	require.Equal(t, int64(10_001_000), res.Balances.AmountOf(denom).Int64())
+}
+
+// TestCometMockOnDemandBlocks runs gaia against CometMock without automatic block production,
+// where the chain only advances when the test produces blocks, e.g. through testutil.WaitForBlocks.
+func TestCometMockOnDemandBlocks(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	nf := 0
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion, NumFullNodes: &nf, ChainConfig: ibc.ChainConfig{
+			ConsensusEngine: &ibc.ConsensusEngineConfig{
+				Image:    ibc.DockerImage{Repository: ibc.DefaultCometMockImage.Repository, Version: "v0.34.x", UidGid: "1025:1025"},
+				AppFlags: []string{"--with-tendermint=false", "--transport=grpc", "--address=tcp://0.0.0.0:26658"},
+			},
+		}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	require.True(t, gaia.ProducesBlocksOnDemand())
+
+	// No blocks are produced on their own.
+	height, err := gaia.Height(ctx)
+	require.NoError(t, err)
+	time.Sleep(3 * time.Second)
+	idle, err := gaia.Height(ctx)
+	require.NoError(t, err)
+	require.Equal(t, height, idle)
+
+	// Waiting for blocks produces them.
+	require.NoError(t, testutil.WaitForBlocks(ctx, 5, gaia))
+	advanced, err := gaia.Height(ctx)
+	require.NoError(t, err)
+	require.Equal(t, height+5, advanced)
+
+	// Transactions are included and awaited without a block interval.
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000, gaia, gaia)
+	sender, recipient := users[0], users[1]
+	denom := gaia.Config().Denom
+
+	require.NoError(t, gaia.SendFunds(ctx, sender.KeyName(), ibc.WalletAmount{
+		Address: recipient.FormattedAddress(),
+		Denom:   denom,
+		Amount:  1_000,
+	}))
+
+	stdout, _, err := gaia.Validators[0].ExecQuery(ctx, "bank", "balances", recipient.FormattedAddress())
+	require.NoError(t, err)
+	var res struct {
+		Balances sdk.Coins `json:"balances"`
+	}
+	require.NoError(t, json.Unmarshal(stdout, &res))
+	require.Equal(t, int64(10_001_000), res.Balances.AmountOf(denom).Int64())
+}
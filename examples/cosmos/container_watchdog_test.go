@@ -0,0 +1,67 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestContainerWatchdog kills a validator container from outside the test,
+// asserting that the watchdog reports it quickly, and not a deliberately stopped full node.
+func TestContainerWatchdog(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	exits := make(chan interchaintest.ContainerExit, len(gaia.Validators)+len(gaia.FullNodes))
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+
+		OnContainerExit: func(exit interchaintest.ContainerExit) { exits <- exit },
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	require.NoError(t, gaia.FullNodes[0].StopContainer(ctx))
+
+	val := gaia.Validators[0]
+	require.NoError(t, client.ContainerKill(ctx, val.Name(), "SIGKILL"))
+
+	select {
+	case exit := <-exits:
+		require.Equal(t, val.Name(), exit.Name)
+		require.Equal(t, 137, exit.ExitCode)
+		require.NotEmpty(t, exit.Logs)
+	case <-time.After(5 * time.Second):
+		t.Fatal("watchdog did not report the killed validator")
+	}
+
+	select {
+	case exit := <-exits:
+		t.Fatalf("watchdog reported unexpected exit of %s", exit.Name)
+	case <-time.After(time.Second):
+	}
+}
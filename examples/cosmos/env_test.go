@@ -0,0 +1,82 @@
+package cosmos_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestChainEnv sets an environment variable switching gaiad to JSON logs, overridden on the second validator,
+// and asserts that the nodes log in their format, also after their containers are recreated.
+func TestChainEnv(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	nv := 2
+	nf := 0
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:    "gaia",
+			Version: gaiaVersion,
+			ChainConfig: ibc.ChainConfig{
+				// Flags of the SDK binaries can be set through variables prefixed with the binary's name.
+				Env: []string{"GAIAD_LOG_FORMAT=json"},
+				// The second validator logs plain text from its first start.
+				ValidatorEnv: [][]string{nil, {"GAIAD_LOG_FORMAT=plain"}},
+			},
+			NumValidators: &nv,
+			NumFullNodes:  &nf,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(chain)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	requireLogs := func() {
+		t.Helper()
+		for i, json := range []bool{true, false} {
+			logs, err := chain.Validators[i].ReadLogs(ctx, 20)
+			require.NoError(t, err)
+			lines := strings.Split(strings.TrimSpace(logs), "\n")
+			require.NotEmpty(t, lines)
+			for _, line := range lines {
+				require.Equal(t, json, strings.HasPrefix(line, "{"), "unexpected log format of validator %d: %q", i, line)
+			}
+		}
+	}
+
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, chain))
+	requireLogs()
+
+	// Containers are recreated as for an upgrade, with the same environment.
+	require.NoError(t, chain.StopAllNodes(ctx))
+	require.NoError(t, chain.StartAllNodes(ctx))
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, chain))
+	requireLogs()
+}
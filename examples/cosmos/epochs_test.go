@@ -0,0 +1,92 @@
+package cosmos_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestEpochs shortens the osmosis day epoch and asserts that each epoch boundary
+// emits the epoch_start begin-blocker event, and that a specific epoch can be awaited.
+func TestEpochs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	const epochDuration = 30 * time.Second
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "osmosis", Version: osmosisVersion, ChainConfig: ibc.ChainConfig{
+			ModifyGenesis: cosmos.ModifyGenesisEpochDuration("day", epochDuration),
+		}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	osmosis := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(osmosis)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	start, err := osmosis.QueryEpochInfo(ctx, "day")
+	require.NoError(t, err)
+	require.Equal(t, epochDuration, start.Duration)
+
+	// Blocks are about two seconds apart, so two epochs of 30 seconds begin within about 45 blocks.
+	height, err := osmosis.Height(ctx)
+	require.NoError(t, err)
+	info, err := cosmos.WaitForEpochs(ctx, osmosis, "day", 2, height+100)
+	require.NoError(t, err)
+	require.Equal(t, start.CurrentEpoch+2, info.CurrentEpoch)
+	require.False(t, info.CurrentEpochStartTime.Before(start.NextEpochStartTime()))
+
+	// The epoch started in the begin blocker of its start height.
+	h := info.CurrentEpochStartHeight
+	res, err := osmosis.Validators[0].Client.BlockResults(ctx, &h)
+	require.NoError(t, err)
+
+	var epochNumbers []string
+	for _, e := range res.BeginBlockEvents {
+		if e.Type != "epoch_start" {
+			continue
+		}
+		for _, attr := range e.Attributes {
+			if string(attr.Key) == "epoch_number" {
+				epochNumbers = append(epochNumbers, string(attr.Value))
+			}
+		}
+	}
+	require.Contains(t, epochNumbers, fmt.Sprint(info.CurrentEpoch))
+
+	// Waiting for an epoch by number blocks until it began, and returns at once for a past epoch.
+	height, err = osmosis.Height(ctx)
+	require.NoError(t, err)
+	next, err := osmosis.WaitForEpoch(ctx, "day", info.CurrentEpoch+1, height+30)
+	require.NoError(t, err)
+	require.Equal(t, info.CurrentEpoch+1, next.CurrentEpoch)
+	require.Greater(t, next.CurrentEpochStartHeight, info.CurrentEpochStartHeight)
+
+	past, err := osmosis.WaitForEpoch(ctx, "day", start.CurrentEpoch, height)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, past.CurrentEpoch, next.CurrentEpoch)
+}
@@ -0,0 +1,90 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestFaucetStress funds more users, with more tokens, than the default faucet balance allows,
+// concurrently from a faucet pre-seeded in genesis.
+func TestFaucetStress(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	const (
+		numUsers   = 50
+		userAmount = int64(10_000_000_000_000)
+
+		faucetBalance = int64(1_000_000_000_000_000)
+		extraDenom    = "ufoo"
+		extraBalance  = int64(1_000_000)
+	)
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion, ChainConfig: ibc.ChainConfig{
+			FaucetGenesisBalance: map[string]int64{
+				"uatom":    faucetBalance,
+				extraDenom: extraBalance,
+			},
+		}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	faucet := gaia.FaucetWallet()
+	require.NotNil(t, faucet)
+	require.Equal(t, cosmos.FaucetAccountKeyName, faucet.KeyName())
+	require.NotEmpty(t, faucet.Mnemonic())
+
+	bal, err := gaia.GetBalance(ctx, faucet.FormattedAddress(), extraDenom)
+	require.NoError(t, err)
+	require.Equal(t, extraBalance, bal)
+
+	// Funding runs concurrently per chain argument; a sequence mismatch on any funding transaction fails the test.
+	fundChains := make([]ibc.Chain, numUsers)
+	for i := range fundChains {
+		fundChains[i] = gaia
+	}
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "stress", userAmount, fundChains...)
+	require.Len(t, users, numUsers)
+
+	for _, u := range users {
+		bal, err := gaia.GetBalance(ctx, u.FormattedAddress(), gaia.Config().Denom)
+		require.NoError(t, err)
+		require.Equal(t, userAmount, bal)
+	}
+
+	bal, err = gaia.GetBalance(ctx, faucet.FormattedAddress(), gaia.Config().Denom)
+	require.NoError(t, err)
+	require.LessOrEqual(t, bal, faucetBalance-numUsers*userAmount)
+}
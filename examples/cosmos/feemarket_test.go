@@ -0,0 +1,134 @@
+package cosmos_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/icza/dyno"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestFeemarketBaseFee loads a chain with the feemarket module until its base fee rises
+// above the configured gas prices, and asserts that transactions of the helpers still land,
+// while a transaction paying the static gas prices is rejected.
+func TestFeemarketBaseFee(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:    "evmos",
+			Version: "v9.1.0",
+			ChainConfig: ibc.ChainConfig{
+				Type:           "cosmos",
+				ChainID:        "evmos_9000-1",
+				Images:         []ibc.DockerImage{{Repository: "ghcr.io/strangelove-ventures/heighliner/evmos", Version: "v9.1.0", UidGid: "1025:1025"}},
+				Bin:            "evmosd",
+				Bech32Prefix:   "evmos",
+				Denom:          "aevmos",
+				CoinType:       "60",
+				GasPrices:      "1aevmos",
+				GasAdjustment:  1.5,
+				TrustingPeriod: "504h",
+				// Pay twice the base fee, more than the default margin, to land transactions of the load test quickly.
+				BaseFeeMultiplier: 2,
+				ModifyGenesis:     modifyGenesisLowMaxGas(1_000_000),
+			},
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	evmos := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(evmos)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	initialBaseFee, err := evmos.QueryBaseFee(ctx)
+	require.NoError(t, err)
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000_000, evmos, evmos)
+	sender, recipient := users[0], users[1]
+
+	// Fill the blocks beyond the feemarket's gas target, half the block gas limit, to raise the base fee.
+	res, err := testutil.MeasureThroughput(ctx, evmos, 30*time.Second, testutil.ThroughputOptions{
+		Sender: cosmos.NewSequencedBroadcaster(evmos, sender),
+		Amount: ibc.WalletAmount{
+			Address: recipient.FormattedAddress(),
+			Denom:   evmos.Config().Denom,
+			Amount:  1,
+		},
+		Concurrency: 20,
+	})
+	require.NoError(t, err)
+	t.Log(res)
+
+	baseFee, err := evmos.QueryBaseFee(ctx)
+	require.NoError(t, err)
+	require.True(t, baseFee.GT(initialBaseFee), "base fee did not rise from %s, now %s", initialBaseFee, baseFee)
+
+	gasPrice, err := evmos.QueryGasPrice(ctx)
+	require.NoError(t, err)
+	require.True(t, gasPrice.GTE(sdk.NewDecFromInt(baseFee)), "gas price %s below base fee %s", gasPrice, baseFee)
+
+	// A control transaction paying exactly the static gas prices is rejected.
+	const gas = 200_000
+	staticPrices, err := sdk.ParseDecCoins(evmos.Config().GasPrices)
+	require.NoError(t, err)
+	staticFees, _ := staticPrices.MulDec(sdk.NewDec(gas)).TruncateDecimal()
+	send := banktypes.NewMsgSend(sdk.AccAddress(sender.Address()), sdk.AccAddress(recipient.Address()), sdk.NewCoins(sdk.NewInt64Coin(evmos.Config().Denom, 1)))
+	_, err = evmos.SendTxAndWait(ctx, sender, gas, staticFees, send)
+	require.ErrorContains(t, err, "insufficient fee")
+
+	// The configured gas prices are below the base fee, so the transaction must pay the base fee instead.
+	require.NoError(t, evmos.SendFunds(ctx, sender.KeyName(), ibc.WalletAmount{
+		Address: recipient.FormattedAddress(),
+		Denom:   evmos.Config().Denom,
+		Amount:  1,
+	}))
+}
+
+// modifyGenesisLowMaxGas sets the block gas limit to maxGas, so that a few transactions exceed the feemarket's gas target,
+// and starts the base fee at the minimum the configured gas prices would pay.
+func modifyGenesisLowMaxGas(maxGas int64) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(_ ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		g := make(map[string]any)
+		if err := json.Unmarshal(genbz, &g); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+		}
+		if err := dyno.Set(g, fmt.Sprint(maxGas), "consensus_params", "block", "max_gas"); err != nil {
+			return nil, fmt.Errorf("failed to set block max gas in genesis json: %w", err)
+		}
+		if err := dyno.Set(g, "1", "app_state", "feemarket", "params", "base_fee"); err != nil {
+			return nil, fmt.Errorf("failed to set base fee in genesis json: %w", err)
+		}
+		if err := dyno.Set(g, "0.000000000000000000", "app_state", "feemarket", "params", "min_gas_price"); err != nil {
+			return nil, fmt.Errorf("failed to set min gas price in genesis json: %w", err)
+		}
+		return json.Marshal(g)
+	}
+}
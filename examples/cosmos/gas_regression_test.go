@@ -0,0 +1,156 @@
+package cosmos_test
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// gasGoldenFile holds the gas used by standard operations, keyed by chain name and version, then message type.
+// The values of gaia are recorded by testdata/gasgen.
+var gasGoldenFile = filepath.Join("testdata", "gas_golden.json")
+
+// Set IBCTEST_UPDATE_GAS_GOLDEN to rewrite the golden file with the measured values.
+var updateGasGolden = os.Getenv("IBCTEST_UPDATE_GAS_GOLDEN") != ""
+
+// Set IBCTEST_GAS_TOLERANCE_PERCENT to change the allowed deviation from the golden values.
+const defaultGasTolerancePercent = 5.0
+
+type gasGolden map[string]map[string]int64
+
+// TestGasRegression records the gas used by MsgSend, MsgTransfer and MsgDelegate and asserts
+// it stays within a tolerance of the golden values for the chain image.
+func TestGasRegression(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	tolerance := defaultGasTolerancePercent
+	if v := os.Getenv("IBCTEST_GAS_TOLERANCE_PERCENT"); v != "" {
+		var err error
+		tolerance, err = strconv.ParseFloat(v, 64)
+		require.NoError(t, err, "invalid IBCTEST_GAS_TOLERANCE_PERCENT")
+	}
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion},
+		{Name: "osmosis", Version: osmosisVersion},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia, osmosis := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const ibcPath = "gaia-osmo"
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia).
+		AddChain(osmosis).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia,
+			Chain2:  osmosis,
+			Relayer: r,
+			Path:    ibcPath,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const fundAmount = int64(10_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", fundAmount, gaia, gaia, osmosis)
+	gaiaUser, gaiaUser2, osmosisUser := users[0], users[1], users[2]
+
+	channel, err := ibc.GetTransferChannel(ctx, r, eRep, gaia.Config().ChainID, osmosis.Config().ChainID)
+	require.NoError(t, err)
+
+	results := make(map[string]cosmos.TxResult)
+	measured := make(map[string]int64)
+	measure := func(msg string, op func(ctx context.Context) error) {
+		t.Helper()
+		res, err := cosmos.MeasureGas(ctx, op)
+		require.NoError(t, err)
+		require.Positive(t, res.GasUsed)
+		require.LessOrEqual(t, res.GasUsed, res.GasWanted)
+		require.NotEmpty(t, res.FeePaid)
+		results[msg] = res
+		measured[msg] = res.GasUsed
+	}
+
+	measure("MsgSend", func(ctx context.Context) error {
+		return gaia.SendFunds(ctx, gaiaUser.KeyName(), ibc.WalletAmount{
+			Address: gaiaUser2.FormattedAddress(),
+			Denom:   gaia.Config().Denom,
+			Amount:  1_000,
+		})
+	})
+
+	measure("MsgTransfer", func(ctx context.Context) error {
+		_, err := gaia.SendIBCTransfer(ctx, channel.ChannelID, gaiaUser.KeyName(), ibc.WalletAmount{
+			Address: osmosisUser.FormattedAddress(),
+			Denom:   gaia.Config().Denom,
+			Amount:  1_000,
+		}, ibc.TransferOptions{})
+		return err
+	})
+
+	valoper, err := gaia.Validators[0].ValidatorOperatorAddress(ctx)
+	require.NoError(t, err)
+	measure("MsgDelegate", func(ctx context.Context) error {
+		return gaia.StakingDelegate(ctx, gaiaUser.KeyName(), valoper, "1000"+gaia.Config().Denom)
+	})
+
+	key := gaia.Config().Name + ":" + gaiaVersion
+
+	golden := make(gasGolden)
+	bz, err := os.ReadFile(gasGoldenFile)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bz, &golden))
+
+	if updateGasGolden {
+		golden[key] = measured
+		bz, err := json.MarshalIndent(golden, "", "  ")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(gasGoldenFile, append(bz, '\n'), 0o644))
+		t.Logf("Updated golden gas values for %s: %v", key, measured)
+		return
+	}
+
+	want, ok := golden[key]
+	require.Truef(t, ok, "no golden gas values for %s in %s; run with IBCTEST_UPDATE_GAS_GOLDEN=1 to record them", key, gasGoldenFile)
+	require.Lenf(t, want, len(results), "golden gas values for %s must be those of the measured messages", key)
+	for msg, res := range results {
+		wantGas, ok := want[msg]
+		require.Truef(t, ok, "no golden gas value for %s on %s", msg, key)
+		margin := int64(math.Round(float64(wantGas) * tolerance / 100))
+		err := testutil.AssertGasWithin(res.Gas(), wantGas-margin, wantGas+margin)
+		require.NoErrorf(t, err, "%s on %s, golden value is %d", msg, key, wantGas)
+	}
+}
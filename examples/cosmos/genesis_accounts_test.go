@@ -0,0 +1,90 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/go-bip39"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestGenesisAccounts funds an account in genesis and asserts it can transact
+// from the first block, without any funding transaction.
+func TestGenesisAccounts(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	entropy, err := bip39.NewEntropy(256)
+	require.NoError(t, err)
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	require.NoError(t, err)
+
+	// Derive the address before the chain exists, so it can be funded in genesis.
+	derivedPriv, err := hd.Secp256k1.Derive()(mnemonic, "", hd.CreateHDPath(types.CoinType, 0, 0).String())
+	require.NoError(t, err)
+	addr, err := types.Bech32ifyAddressBytes("cosmos", hd.Secp256k1.Generate()(derivedPriv).PubKey().Address())
+	require.NoError(t, err)
+
+	const genesisAmount = int64(1_000_000_000)
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion, ChainConfig: ibc.ChainConfig{
+			ModifyGenesis: cosmos.ModifyGenesisAddAccounts(ibc.WalletAmount{
+				Address: addr,
+				Denom:   "uatom",
+				Amount:  genesisAmount,
+			}),
+		}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	bal, err := gaia.GetBalance(ctx, addr, gaia.Config().Denom)
+	require.NoError(t, err)
+	require.Equal(t, genesisAmount, bal)
+
+	const keyName = "genesis-user"
+	require.NoError(t, gaia.RecoverKey(ctx, keyName, mnemonic))
+
+	recipient := interchaintest.GetAndFundTestUsers(t, ctx, "recipient", 1, gaia)[0]
+
+	const amount = int64(1_000)
+	require.NoError(t, gaia.SendFunds(ctx, keyName, ibc.WalletAmount{
+		Address: recipient.FormattedAddress(),
+		Denom:   gaia.Config().Denom,
+		Amount:  amount,
+	}))
+
+	recipientBal, err := gaia.GetBalance(ctx, recipient.FormattedAddress(), gaia.Config().Denom)
+	require.NoError(t, err)
+	require.Equal(t, 1+amount, recipientBal)
+}
@@ -0,0 +1,77 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestCompareGenesis builds three gaia chains linked by a relayer, the last one with a shorter voting period,
+// and asserts that CompareGenesis only reports the voting period, not the validators, faucets or relayer wallets,
+// which differ between all chains.
+func TestCompareGenesis(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "gaia-1", Version: gaiaVersion},
+		{Name: "gaia", ChainName: "gaia-2", Version: gaiaVersion},
+		{Name: "gaia", ChainName: "gaia-3", Version: gaiaVersion, ChainConfig: ibc.ChainConfig{
+			ModifyGenesis: cosmos.ModifyGenesisVotingPeriod(20 * time.Second),
+		}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia1, gaia2, gaia3 := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain), chains[2].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia1).
+		AddChain(gaia2).
+		AddChain(gaia3).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{Chain1: gaia1, Chain2: gaia2, Relayer: r, Path: "gaia1-gaia2"}).
+		AddLink(interchaintest.InterchainLink{Chain1: gaia2, Chain2: gaia3, Relayer: r, Path: "gaia2-gaia3"})
+
+	rep := testreporter.NewNopReporter()
+	require.NoError(t, ic.Build(ctx, rep.RelayerExecReporter(t), interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// Every chain has its own faucet and relayer wallet in genesis.
+	require.NotEqual(t, gaia1.FaucetWallet().FormattedAddress(), gaia2.FaucetWallet().FormattedAddress())
+	relayerWallet1, ok := r.GetWallet(gaia1.Config().ChainID)
+	require.True(t, ok)
+	relayerWallet2, ok := r.GetWallet(gaia2.Config().ChainID)
+	require.True(t, ok)
+	require.NotEqual(t, relayerWallet1.FormattedAddress(), relayerWallet2.FormattedAddress())
+
+	diff, err := cosmos.CompareGenesis(ctx, gaia1, gaia2)
+	require.NoError(t, err)
+	require.Empty(t, diff)
+
+	diff, err = cosmos.CompareGenesis(ctx, gaia2, gaia3)
+	require.NoError(t, err)
+	require.Regexp(t, `^app_state\.gov\.voting_params\.voting_period: "[0-9]+s" != "20s"$`, diff)
+}
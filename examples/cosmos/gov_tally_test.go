@@ -0,0 +1,92 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestGovTally votes on a proposal with some of the validators,
+// which have equal stakes, and asserts the tally before the voting period ends.
+func TestGovTally(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// Long enough for the votes and the tally query to land within the voting period.
+	const tallyVotingPeriod = "90s"
+
+	nv, nf := 4, 0
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion, NumValidators: &nv, NumFullNodes: &nf, ChainConfig: ibc.ChainConfig{
+			ModifyGenesis: modifyGenesisShortProposals(tallyVotingPeriod, maxDepositPeriod),
+		}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(chain)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	user := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), 10_000_000_000, chain)[0]
+
+	height, err := chain.Height(ctx)
+	require.NoError(t, err)
+
+	prop, err := chain.TextProposal(ctx, user.KeyName(), cosmos.TextProposal{
+		Deposit:     "500000000" + chain.Config().Denom,
+		Title:       "Tally",
+		Description: "Proposal tallied during its voting period",
+	})
+	require.NoError(t, err)
+
+	// Two validators vote yes, one votes no and the last one does not vote.
+	// Validator nodes hold the key of their validator as "validator".
+	votes := []string{cosmos.ProposalVoteYes, cosmos.ProposalVoteYes, cosmos.ProposalVoteNo}
+	for i, vote := range votes {
+		require.NoError(t, chain.Validators[i].VoteOnProposal(ctx, "validator", prop.ProposalID, vote))
+	}
+
+	tally, err := chain.QueryProposalTally(ctx, prop.ProposalID)
+	require.NoError(t, err)
+
+	p, err := chain.QueryProposal(ctx, prop.ProposalID)
+	require.NoError(t, err)
+	require.Equal(t, cosmos.ProposalStatusVotingPeriod, p.Status, "the tally must be queried within the voting period")
+
+	yes, ok := sdk.NewIntFromString(tally.YesCount)
+	require.True(t, ok, "yes count %q", tally.YesCount)
+	no, ok := sdk.NewIntFromString(tally.NoCount)
+	require.True(t, ok, "no count %q", tally.NoCount)
+	require.True(t, no.IsPositive(), "no count %s", no)
+	require.Equal(t, no.MulRaw(2).String(), yes.String(), "the yes votes of two validators against the no vote of one")
+	require.Equal(t, "0", tally.AbstainCount)
+	require.Equal(t, "0", tally.NoWithVetoCount)
+
+	// The votes pass the proposal once the voting period ends, with the tally seen during it.
+	final, err := cosmos.PollForProposalStatus(ctx, chain, height, height+200, prop.ProposalID, cosmos.ProposalStatusPassed)
+	require.NoError(t, err, "proposal did not pass")
+	require.Equal(t, tally.YesCount, final.FinalTallyResult.Yes)
+	require.Equal(t, tally.NoCount, final.FinalTallyResult.No)
+}
@@ -0,0 +1,89 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestGovV1Proposal submits a MsgSoftwareUpgrade inside a gov v1 proposal and passes it,
+// both on a chain with gov v1 and on a chain of SDK v0.45, which receives the equivalent legacy proposal.
+func TestGovV1Proposal(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name, chainName, version string
+	}{
+		{name: "SDK v0.45", chainName: "gaia", version: gaiaVersion},
+		{name: "SDK v0.47", chainName: "juno", version: "v16.0.0"},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			testGovV1Proposal(t, tt.chainName, tt.version)
+		})
+	}
+}
+
+func testGovV1Proposal(t *testing.T, chainName, version string) {
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: chainName, Version: version, ChainConfig: ibc.ChainConfig{
+			ModifyGenesis: modifyGenesisShortProposals(votingPeriod, maxDepositPeriod),
+		}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(chain)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), 10_000_000_000, chain)
+	user := users[0]
+
+	height, err := chain.Height(ctx)
+	require.NoError(t, err)
+
+	authority, err := chain.GovModuleAddress()
+	require.NoError(t, err)
+	prop, err := chain.BuildProposal([]sdk.Msg{&upgradetypes.MsgSoftwareUpgrade{
+		Authority: authority,
+		Plan: upgradetypes.Plan{
+			Name:   "v2",
+			Height: int64(height + 100),
+		},
+	}}, "", "500000000"+chain.Config().Denom, "Upgrade", "Upgrade through a gov v1 proposal")
+	require.NoError(t, err)
+
+	tx, err := chain.SubmitProposal(ctx, user.KeyName(), prop)
+	require.NoError(t, err)
+	require.NotEmpty(t, tx.ProposalID)
+
+	require.NoError(t, chain.VoteOnProposalAllValidators(ctx, tx.ProposalID, cosmos.ProposalVoteYes))
+
+	_, err = cosmos.PollForProposalStatus(ctx, chain, height, height+20, tx.ProposalID, cosmos.ProposalStatusPassed)
+	require.NoError(t, err, "proposal did not pass")
+}
@@ -79,7 +79,7 @@ func TestUpdateLightClients(t *testing.T) {
 	require.NoError(t, err)
 
 	amountToSend := int64(553255) // Unique amount to make log searching easier.
-	dstAddress := osmoUser.(*cosmos.CosmosWallet).FormattedAddressWithPrefix(osmosis.Config().Bech32Prefix)
+	dstAddress := osmoUser.FormattedAddress()
 	transfer := ibc.WalletAmount{
 		Address: dstAddress,
 		Denom:   gaia.Config().Denom,
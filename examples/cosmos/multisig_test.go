@@ -0,0 +1,78 @@
+package cosmos_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestMultisig sends funds from a 2-of-3 multisig account,
+// asserting that two signatures are accepted and a single one is not.
+func TestMultisig(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const funds = int64(10_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", funds, gaia, gaia, gaia, gaia)
+	signers, recipient := users[:3], users[3]
+	keyNames := []string{signers[0].KeyName(), signers[1].KeyName(), signers[2].KeyName()}
+	denom := gaia.Config().Denom
+
+	multisig, err := gaia.CreateMultisig(ctx, keyNames, 2)
+	require.NoError(t, err)
+
+	const multisigFunds = funds / 2
+	require.NoError(t, gaia.SendFunds(ctx, keyNames[0], ibc.WalletAmount{
+		Address: multisig,
+		Denom:   denom,
+		Amount:  multisigFunds,
+	}))
+
+	const amount = int64(1_000)
+	send := []string{"bank", "send", multisig, recipient.FormattedAddress(), fmt.Sprintf("%d%s", amount, denom)}
+
+	_, err = gaia.ExecMultisigTx(ctx, multisig, keyNames[:1], send...)
+	require.Error(t, err, "a single signature must not meet the threshold")
+
+	_, err = gaia.ExecMultisigTx(ctx, multisig, []string{keyNames[0], keyNames[2]}, send...)
+	require.NoError(t, err)
+
+	bal, err := gaia.GetBalance(ctx, recipient.FormattedAddress(), denom)
+	require.NoError(t, err)
+	require.Equal(t, funds+amount, bal)
+
+	bal, err = gaia.GetBalance(ctx, multisig, denom)
+	require.NoError(t, err)
+	require.Less(t, bal, multisigFunds-amount, "the multisig pays the amount and fees")
+}
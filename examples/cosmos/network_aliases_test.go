@@ -0,0 +1,56 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestNetworkAliases asserts that a configured network alias resolves to the chain's RPC node
+// from other containers on the docker network.
+func TestNetworkAliases(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	const alias = "chain-a-rpc"
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion, ChainConfig: ibc.ChainConfig{
+			NetworkAliases: []string{alias},
+		}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// Query the chain through the alias from a validator container, which is not the aliased node.
+	stdout, stderr, err := gaia.Validators[0].Exec(ctx, []string{
+		gaia.Config().Bin, "status", "--node", "tcp://" + alias + ":26657",
+	}, nil)
+	require.NoError(t, err, "stderr: %s", stderr)
+	// The status is printed to stderr by some versions of the cosmos sdk.
+	require.Contains(t, string(stdout)+string(stderr), gaia.Config().ChainID)
+}
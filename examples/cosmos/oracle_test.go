@@ -0,0 +1,159 @@
+package cosmos_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestOraclePriceFeeder runs a price feeder for the validator of umee, voting every denom of the oracle accept list,
+// asserts that exchange rates are voted and that the validator misses no vote period while the feeder runs,
+// then stops the feeder and asserts that the validator misses the next votes.
+func TestOraclePriceFeeder(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	const (
+		votePeriod  = 2
+		slashWindow = 100
+	)
+	nv := 1
+	nf := 0
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			ChainName: "umee",
+			Version:   umeeVersion,
+			ChainConfig: ibc.ChainConfig{
+				Type:    "cosmos",
+				ChainID: "umee-1",
+				Images: []ibc.DockerImage{{
+					Repository: "ghcr.io/strangelove-ventures/heighliner/umee",
+					Version:    umeeVersion,
+					UidGid:     dockerutil.GetHeighlinerUserString(),
+				}},
+				Bin:            "umeed",
+				Bech32Prefix:   "umee",
+				Denom:          "uumee",
+				GasPrices:      "0.00uumee",
+				GasAdjustment:  1.3,
+				TrustingPeriod: "336h",
+				ModifyGenesis: func(cfg ibc.ChainConfig, genbz []byte) ([]byte, error) {
+					genbz, err := cosmos.ModifyGenesisOracleVotePeriod(votePeriod, slashWindow)(cfg, genbz)
+					if err != nil {
+						return nil, err
+					}
+					// The feeder votes the ATOM rate only, so it must be the only denom validators have to vote.
+					return cosmos.ModifyGenesisOracleAcceptList(atomOracleDenom)(cfg, genbz)
+				},
+			},
+			NumValidators: &nv,
+			NumFullNodes:  &nf,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	umee := chains[0].(*cosmos.CosmosChain)
+
+	require.NoError(t, umee.AddPriceFeeders(cosmos.PriceFeederConfig{
+		Image: ibc.DockerImage{Repository: "ghcr.io/umee-network/price-feeder-umee", Version: "v2.1.0"},
+		// The test keyring backend has no password.
+		Env:        []string{"PRICE_FEEDER_PASS="},
+		ConfigFile: priceFeederConfig,
+	}))
+
+	ic := interchaintest.NewInterchain().AddChain(umee)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	rates, err := umee.WaitForExchangeRates(ctx, 20*votePeriod)
+	require.NoError(t, err)
+	require.True(t, rates.AmountOf("ATOM").IsPositive(), "no ATOM exchange rate in %s", rates)
+
+	val := umee.Validators[0]
+	valoper, err := val.ValidatorOperatorAddress(ctx)
+	require.NoError(t, err)
+
+	// The validator votes every vote period while its feeder runs.
+	missed, err := umee.QueryOracleMissCounter(ctx, valoper)
+	require.NoError(t, err)
+	require.NoError(t, testutil.WaitForBlocks(ctx, 3*votePeriod, umee))
+	missedWhileRunning, err := umee.QueryOracleMissCounter(ctx, valoper)
+	require.NoError(t, err)
+	require.Equal(t, missed, missedWhileRunning, "validator missed votes while its feeder runs")
+
+	feeder := val.Sidecars.ByName(cosmos.PriceFeederProcessName)
+	require.NotNil(t, feeder)
+	require.NoError(t, feeder.StopContainer(ctx))
+
+	// The validator does not vote in the vote periods during which its feeder is stopped.
+	require.NoError(t, testutil.WaitForBlocks(ctx, 3*votePeriod, umee))
+	missedAfterStop, err := umee.QueryOracleMissCounter(ctx, valoper)
+	require.NoError(t, err)
+	require.Greater(t, missedAfterStop, missed)
+}
+
+// atomOracleDenom is the ATOM denom of the oracle accept list of umee.
+var atomOracleDenom = cosmos.OracleDenom{
+	BaseDenom:   "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2",
+	SymbolDenom: "ATOM",
+	Exponent:    6,
+}
+
+// priceFeederConfig is the config file of the umee price feeder, voting the ATOM rate from the mock provider.
+func priceFeederConfig(v cosmos.PriceFeederValidator) ([]byte, error) {
+	return []byte(fmt.Sprintf(`gas_adjustment = 1.5
+gas_prices = "0.00uumee"
+
+[server]
+listen_addr = "0.0.0.0:7171"
+read_timeout = "20s"
+verbose_cors = true
+write_timeout = "20s"
+
+[[currency_pairs]]
+base = "ATOM"
+providers = ["mock"]
+quote = "USD"
+
+[account]
+address = "%s"
+chain_id = "%s"
+validator = "%s"
+
+[keyring]
+backend = "test"
+dir = "%s"
+
+[rpc]
+grpc_endpoint = "%s"
+rpc_timeout = "100ms"
+tmrpc_endpoint = "http://%s"
+
+[telemetry]
+enabled = false
+`, v.AccountAddress, v.ChainID, v.ValidatorAddress, v.KeyringDir, v.GRPCAddress, strings.TrimPrefix(v.RPCAddress, "tcp://"))), nil
+}
@@ -0,0 +1,76 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestLinePeerTopology peers a validator and three full nodes in a line,
+// asserting that the blocks of the validator still reach the full node at the end of the line.
+func TestLinePeerTopology(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	nv, nf := 1, 3
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion, NumValidators: &nv, NumFullNodes: &nf},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	nodes := gaia.Nodes()
+	require.NoError(t, gaia.SetPeerTopology(ctx, cosmos.LineTopology(nodes)))
+
+	val, end := nodes[0], nodes[len(nodes)-1]
+	for _, n := range nodes {
+		peer, err := n.PeerAddress(ctx)
+		require.NoError(t, err)
+		require.NoError(t, cosmos.ValidatePeerAddress(peer))
+	}
+
+	require.NoError(t, testutil.WaitForBlocks(ctx, 3, val))
+	valHeight, err := val.Height(ctx)
+	require.NoError(t, err)
+
+	deadline := time.Now().Add(time.Minute)
+	for {
+		endHeight, err := end.Height(ctx)
+		require.NoError(t, err)
+		if endHeight >= valHeight {
+			break
+		}
+		require.True(t, time.Now().Before(deadline), "end node at height %d did not catch up with validator at height %d", endHeight, valHeight)
+		time.Sleep(time.Second)
+	}
+
+	// The end node has a single peer, the full node before it.
+	netInfo, err := end.Client.NetInfo(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, netInfo.NPeers)
+}
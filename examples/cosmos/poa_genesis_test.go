@@ -0,0 +1,84 @@
+package cosmos_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestPoAGenesis runs gaia with a proof-of-authority genesis, whose validators have fixed powers without gentxs,
+// and asserts that the validator without power does not sign blocks. x/poa chains build on x/staking,
+// so their genesis is set up as that of gaia.
+func TestPoAGenesis(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	nv := 3
+	nf := 0
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion, NumValidators: &nv, NumFullNodes: &nf, ChainConfig: ibc.ChainConfig{
+			PoA: &ibc.PoAConfig{ValidatorPowers: []int64{10, 5, 0}},
+		}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(chain)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	res, err := chain.Validators[0].Client.Validators(ctx, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, res.Validators, 2)
+
+	var total int64
+	for _, v := range res.Validators {
+		total += v.VotingPower
+	}
+	require.Equal(t, int64(15), total)
+
+	status, err := chain.Validators[2].Client.Status(ctx)
+	require.NoError(t, err)
+	unpowered := status.ValidatorInfo.Address
+
+	// The canonical commit of a block is that of the next block, so query the block before the latest.
+	height, err := chain.Height(ctx)
+	require.NoError(t, err)
+	h := int64(height) - 1
+	require.Positive(t, h)
+	commit, err := chain.Validators[0].Client.Commit(ctx, &h)
+	require.NoError(t, err)
+
+	var signed int
+	for _, sig := range commit.Commit.Signatures {
+		if bytes.Equal(sig.ValidatorAddress, unpowered) {
+			require.NotEqual(t, tmtypes.BlockIDFlagCommit, sig.BlockIDFlag, "validator without power signed block %d", h)
+		}
+		if sig.BlockIDFlag == tmtypes.BlockIDFlagCommit {
+			signed++
+		}
+	}
+	require.Equal(t, 2, signed, "validators with power must sign block %d", h)
+}
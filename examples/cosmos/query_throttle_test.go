@@ -0,0 +1,75 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/sync/errgroup"
+)
+
+// TestQueryThrottle polls the balance of a user from many goroutines against a chain with a query rate limit,
+// which keeps the queries from overwhelming its single node.
+func TestQueryThrottle(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	nv, nf := 1, 0
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion, NumValidators: &nv, NumFullNodes: &nf, ChainConfig: ibc.ChainConfig{
+			QueryRateLimit: 50,
+		}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	// Closing the interchain records the query stats of the chain to the reporter.
+	rep := testreporter.NewNopReporter()
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, rep.RelayerExecReporter(t), interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	user := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000, gaia)[0]
+	denom := gaia.Config().Denom
+
+	var eg errgroup.Group
+	for i := 0; i < 50; i++ {
+		eg.Go(func() error {
+			for j := 0; j < 20; j++ {
+				if _, err := gaia.GetBalance(ctx, user.FormattedAddress(), denom); err != nil {
+					return err
+				}
+				if _, err := gaia.Height(ctx); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	require.NoError(t, eg.Wait())
+
+	// 2000 queries at 50 queries per second.
+	stats := gaia.QueryStats()
+	require.NotZero(t, stats.Throttled)
+	t.Logf("Query stats: %+v", stats)
+}
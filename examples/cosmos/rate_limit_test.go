@@ -0,0 +1,107 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// rateLimitContract is the ibc-rate-limit contract of osmosis v15.0.0, from x/ibc-rate-limit/bytecode.
+const rateLimitContract = "rate_limiter.wasm"
+
+// TestContractRateLimit limits the transfers an osmosis chain receives with the ibc-rate-limit contract,
+// asserting that transfers exceeding the quota are rejected until the quota window resets.
+func TestContractRateLimit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	testRecvRateLimit(t, &interchaintest.ChainSpec{
+		Name:    "osmosis",
+		Version: "v15.0.0",
+		ChainConfig: ibc.ChainConfig{
+			ModifyGenesis: modifyGenesisShortProposals(votingPeriod, maxDepositPeriod),
+		},
+	}, func(chain *cosmos.CosmosChain, keyName string) cosmos.RateLimiter {
+		return &cosmos.ContractRateLimiter{
+			Chain:           chain,
+			ContractFile:    rateLimitContract,
+			ProposerKeyName: keyName,
+			Deposit:         "500000000" + chain.Config().Denom,
+			Window:          30 * time.Second,
+		}
+	})
+}
+
+// testRecvRateLimit asserts the receive quota of a rate limiter on a chain built from spec,
+// with transfers from a gaia chain.
+func testRecvRateLimit(t *testing.T, spec *interchaintest.ChainSpec, newLimiter func(chain *cosmos.CosmosChain, keyName string) cosmos.RateLimiter) {
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion},
+		spec,
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia, limited := chains[0], chains[1].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const pathName = "rate-limit"
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia).
+		AddChain(limited).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia,
+			Chain2:  limited,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000_000, gaia, limited)
+	gaiaUser, limitedUser := users[0], users[1]
+
+	channel, err := ibc.GetTransferChannel(ctx, r, eRep, gaia.Config().ChainID, limited.Config().ChainID)
+	require.NoError(t, err)
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	})
+
+	require.NoError(t, cosmos.AssertRecvRateLimit(ctx, newLimiter(limited, limitedUser.KeyName()), cosmos.RateLimitTransfer{
+		Src:           gaia,
+		SrcChannel:    *channel,
+		SenderKeyName: gaiaUser.KeyName(),
+		Receiver:      limitedUser.FormattedAddress(),
+		Denom:         gaia.Config().Denom,
+		Supply:        1_000_000,
+	}, 10))
+}
@@ -0,0 +1,72 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestSendTxAndWait sends transactions with explicit gas and fees,
+// and asserts that they are charged exactly those fees and report the requested gas.
+func TestSendTxAndWait(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000, gaia, gaia)
+	sender, recipient := users[0], users[1]
+	denom := gaia.Config().Denom
+
+	send := banktypes.NewMsgSend(sdk.AccAddress(sender.Address()), sdk.AccAddress(recipient.Address()), sdk.NewCoins(sdk.NewInt64Coin(denom, 1_000)))
+	fees := sdk.NewCoins(sdk.NewInt64Coin(denom, 5_000))
+
+	before, err := gaia.GetBalance(ctx, sender.FormattedAddress(), denom)
+	require.NoError(t, err)
+
+	res, err := gaia.SendTxAndWait(ctx, sender, 200_000, fees, send)
+	require.NoError(t, err)
+	require.Equal(t, int64(200_000), res.GasWanted)
+	require.Positive(t, res.GasUsed)
+
+	after, err := gaia.GetBalance(ctx, sender.FormattedAddress(), denom)
+	require.NoError(t, err)
+	require.Equal(t, before-1_000-5_000, after)
+
+	// Too little gas is not raised by simulation: the transaction is included and runs out of gas.
+	res, err = gaia.SendTxAndWait(ctx, sender, 50_000, fees, send)
+	require.Error(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, sdkerrors.ErrOutOfGas.ABCICode(), res.Code)
+	require.Equal(t, int64(50_000), res.GasWanted)
+}
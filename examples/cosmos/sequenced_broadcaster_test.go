@@ -0,0 +1,98 @@
+package cosmos_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/sync/errgroup"
+)
+
+// TestSequencedBroadcaster sends funds concurrently from a single wallet,
+// asserting every transaction is included with its own account sequence.
+func TestSequencedBroadcaster(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000_000, gaia, gaia)
+	sender, recipient := users[0], users[1]
+
+	const (
+		numSends = 20
+		amount   = int64(1_000)
+	)
+
+	b := cosmos.NewSequencedBroadcaster(gaia, sender)
+	var recorder cosmos.TxRecorder
+	recordCtx := cosmos.WithTxRecorder(ctx, &recorder)
+
+	var eg errgroup.Group
+	for i := 0; i < numSends; i++ {
+		eg.Go(func() error {
+			return b.SendFunds(recordCtx, ibc.WalletAmount{
+				Address: recipient.FormattedAddress(),
+				Denom:   gaia.Config().Denom,
+				Amount:  amount,
+			})
+		})
+	}
+	require.NoError(t, eg.Wait())
+
+	// Every transaction was committed, each with a distinct sequence.
+	results := recorder.Results()
+	require.Len(t, results, numSends)
+	hashes := make(map[string]bool, numSends)
+	for _, res := range results {
+		hashes[res.TxHash] = true
+	}
+	require.Len(t, hashes, numSends)
+	require.Equal(t, uint64(numSends), b.Sequence())
+
+	stdout, _, err := gaia.Validators[0].ExecQuery(ctx, "auth", "account", sender.FormattedAddress())
+	require.NoError(t, err)
+	var account struct {
+		Sequence string `json:"sequence"`
+	}
+	require.NoError(t, json.Unmarshal(stdout, &account))
+	require.Equal(t, fmt.Sprint(numSends), account.Sequence)
+
+	bal, err := gaia.GetBalance(ctx, recipient.FormattedAddress(), gaia.Config().Denom)
+	require.NoError(t, err)
+	require.Equal(t, 10_000_000_000+numSends*amount, bal)
+}
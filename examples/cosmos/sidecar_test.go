@@ -0,0 +1,102 @@
+package cosmos_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestSidecarProcess runs an HTTP server as a sidecar of the chain, and asserts that it is reachable,
+// that it can be restarted, and that the watchdog reports it when it crashes.
+func TestSidecarProcess(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	const (
+		processName = "health"
+		port        = "8080/tcp"
+	)
+	nv := 1
+	nf := 0
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:    "gaia",
+			Version: gaiaVersion,
+			ChainConfig: ibc.ChainConfig{
+				SidecarConfigs: []ibc.SidecarConfig{
+					{
+						ProcessName: processName,
+						Image:       ibc.DockerImage{Repository: "busybox", Version: "stable"},
+						StartCmd:    []string{"httpd", "-f", "-p", "8080", "-h", "/etc"},
+						Ports:       []string{port},
+					},
+				},
+			},
+			NumValidators: &nv,
+			NumFullNodes:  &nf,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(chain)
+
+	exits := make(chan interchaintest.ContainerExit, 1)
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+
+		OnContainerExit: func(exit interchaintest.ContainerExit) { exits <- exit },
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	sidecar := chain.Sidecars.ByName(processName)
+	require.NotNil(t, sidecar)
+
+	requireHealthy := func() {
+		t.Helper()
+		hostPorts, err := sidecar.GetHostPorts(ctx, port)
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			res, err := http.Get("http://" + hostPorts[0] + "/hostname")
+			if err != nil {
+				return false
+			}
+			_ = res.Body.Close()
+			return res.StatusCode == http.StatusOK
+		}, 10*time.Second, 100*time.Millisecond, "sidecar not reachable at %s", hostPorts[0])
+	}
+
+	requireHealthy()
+
+	require.NoError(t, sidecar.RestartContainer(ctx))
+	requireHealthy()
+
+	require.NoError(t, client.ContainerKill(ctx, sidecar.Name(), "SIGKILL"))
+	select {
+	case exit := <-exits:
+		require.Equal(t, sidecar.Name(), exit.Name)
+		require.Equal(t, 137, exit.ExitCode)
+	case <-time.After(5 * time.Second):
+		t.Fatal("watchdog did not report the killed sidecar")
+	}
+}
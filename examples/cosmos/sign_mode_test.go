@@ -0,0 +1,72 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestAminoSignMode signs transactions with amino JSON, as required by legacy chains,
+// through the CLI tx helpers, SendTxAndWait and the Broadcaster.
+func TestAminoSignMode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion, ChainConfig: ibc.ChainConfig{
+			SignMode: ibc.SignModeAminoJSON,
+		}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// Funding the users already sends amino-signed transactions from the faucet.
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000, gaia, gaia)
+	sender, recipient := users[0], users[1]
+	denom := gaia.Config().Denom
+
+	require.NoError(t, gaia.SendFunds(ctx, sender.KeyName(), ibc.WalletAmount{
+		Address: recipient.FormattedAddress(),
+		Denom:   denom,
+		Amount:  1_000,
+	}))
+
+	send := banktypes.NewMsgSend(sdk.AccAddress(sender.Address()), sdk.AccAddress(recipient.Address()), sdk.NewCoins(sdk.NewInt64Coin(denom, 1_000)))
+	_, err = gaia.SendTxAndWait(ctx, sender, 200_000, sdk.NewCoins(sdk.NewInt64Coin(denom, 5_000)), send)
+	require.NoError(t, err)
+
+	b := cosmos.NewBroadcaster(t, gaia)
+	res, err := cosmos.BroadcastTx(ctx, b, sender.(*cosmos.CosmosWallet), send)
+	require.NoError(t, err)
+	require.Zero(t, res.Code, res.RawLog)
+
+	balance, err := gaia.GetBalance(ctx, recipient.FormattedAddress(), denom)
+	require.NoError(t, err)
+	require.Equal(t, int64(10_000_000+3*1_000), balance)
+}
@@ -0,0 +1,129 @@
+package cosmos_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/icza/dyno"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestValidatorJailing downs a validator until it is jailed and slashed for downtime, then unjails it.
+func TestValidatorJailing(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	nv := 2
+	nf := 0
+	// The first validator alone keeps producing blocks while the second one is down.
+	selfDelegations := []int64{9_000_000_000_000, 1_000_000_000_000}
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:    "gaia",
+			Version: gaiaVersion,
+			ChainConfig: ibc.ChainConfig{
+				ValidatorSelfDelegations: selfDelegations,
+				ModifyGenesis:            modifyGenesisShortDowntime("10", "0.500000000000000000", "10s", "0.010000000000000000"),
+			},
+			NumValidators: &nv,
+			NumFullNodes:  &nf,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(chain)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const jailed = 1
+	v := chain.Validators[jailed]
+	valoper, err := v.ValidatorOperatorAddress(ctx)
+	require.NoError(t, err)
+	valcons, err := v.ValidatorConsAddress(ctx)
+	require.NoError(t, err)
+
+	before, err := chain.QueryValidator(ctx, valoper)
+	require.NoError(t, err)
+	require.False(t, before.Jailed)
+	require.Equal(t, types.NewInt(selfDelegations[jailed]), before.Tokens)
+
+	require.NoError(t, chain.JailValidator(ctx, jailed))
+
+	after, err := chain.QueryValidator(ctx, valoper)
+	require.NoError(t, err)
+	require.True(t, after.Jailed)
+
+	// The downtime slash fraction was applied to the validator's tokens.
+	params, err := chain.QuerySlashingParams(ctx)
+	require.NoError(t, err)
+	slashed := types.NewDecFromInt(before.Tokens).Mul(params.SlashFractionDowntime).TruncateInt()
+	require.Equal(t, before.Tokens.Sub(slashed), after.Tokens)
+
+	info, err := chain.QueryValidatorSigningInfo(ctx, valcons)
+	require.NoError(t, err)
+	require.False(t, info.Tombstoned)
+	require.False(t, info.JailedUntil.IsZero())
+
+	// Unjailing is only possible after the downtime jail duration.
+	time.Sleep(time.Until(info.JailedUntil))
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, chain))
+
+	wallet, err := v.ValidatorWallet(ctx)
+	require.NoError(t, err)
+	require.NoError(t, chain.Unjail(ctx, wallet))
+
+	unjailed, err := chain.QueryValidator(ctx, valoper)
+	require.NoError(t, err)
+	require.False(t, unjailed.Jailed)
+}
+
+func modifyGenesisShortDowntime(signedBlocksWindow, minSignedPerWindow, downtimeJailDuration, slashFractionDowntime string) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(chainConfig ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		g := make(map[string]interface{})
+		if err := json.Unmarshal(genbz, &g); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+		}
+		params := map[string]string{
+			"signed_blocks_window":    signedBlocksWindow,
+			"min_signed_per_window":   minSignedPerWindow,
+			"downtime_jail_duration":  downtimeJailDuration,
+			"slash_fraction_downtime": slashFractionDowntime,
+		}
+		for k, v := range params {
+			if err := dyno.Set(g, v, "app_state", "slashing", "params", k); err != nil {
+				return nil, fmt.Errorf("failed to set slashing %s in genesis json: %w", k, err)
+			}
+		}
+		out, err := json.Marshal(g)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+		}
+		return out, nil
+	}
+}
@@ -0,0 +1,256 @@
+// Command gasgen records the golden gas values of TestGasRegression for gaia v7.1.0 in ../gas_golden.json.
+// It delivers the transactions of the test, as built by the CLI with the gas prices of the gaia chain config,
+// to the gaia app in-process, with the genesis, IBC client, connection and channel that the test would find.
+// The gaia module cannot be a dependency of the interchaintest module. Run it with go mod tidy && go run . from this directory,
+// or rerun TestGasRegression against the image with IBCTEST_UPDATE_GAS_GOLDEN=1.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	crisistypes "github.com/cosmos/cosmos-sdk/x/crisis/types"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	transfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	connectiontypes "github.com/cosmos/ibc-go/v3/modules/core/03-connection/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v3/modules/core/23-commitment/types"
+	host "github.com/cosmos/ibc-go/v3/modules/core/24-host"
+	ibctm "github.com/cosmos/ibc-go/v3/modules/light-clients/07-tendermint/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	gaiaapp "github.com/cosmos/gaia/v7/app"
+	"github.com/cosmos/gaia/v7/app/helpers"
+)
+
+// goldenKey is the key of the golden values in the golden file, the chain name and version.
+const goldenKey = "gaia:v7.1.0"
+
+const (
+	chainID = "gaia-1"
+	denom   = "uatom"
+	gas     = 200_000
+	fee     = 2_000 // gas * 0.01uatom
+)
+
+// account is an account signing transactions.
+type account struct {
+	priv   cryptotypes.PrivKey
+	addr   sdk.AccAddress
+	accNum uint64
+	seq    uint64
+}
+
+func newAccount() *account {
+	priv := secp256k1.GenPrivKey()
+	return &account{priv: priv, addr: sdk.AccAddress(priv.PubKey().Address())}
+}
+
+var encCfg = gaiaapp.MakeEncodingConfig()
+
+// sign returns the signed transaction of msgs from a, with the gas limit and fees of the CLI.
+func sign(a *account, msgs ...sdk.Msg) []byte {
+	txCfg := encCfg.TxConfig
+	b := txCfg.NewTxBuilder()
+	must(b.SetMsgs(msgs...))
+	b.SetGasLimit(gas)
+	b.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin(denom, fee)))
+
+	mode := signing.SignMode_SIGN_MODE_DIRECT
+	must(b.SetSignatures(signing.SignatureV2{PubKey: a.priv.PubKey(), Data: &signing.SingleSignatureData{SignMode: mode}, Sequence: a.seq}))
+	sig, err := tx.SignWithPrivKey(mode, authsigning.SignerData{ChainID: chainID, AccountNumber: a.accNum, Sequence: a.seq}, b, a.priv, txCfg, a.seq)
+	must(err)
+	must(b.SetSignatures(sig))
+	bz, err := txCfg.TxEncoder()(b.GetTx())
+	must(err)
+	return bz
+}
+
+func must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+func main() {
+	app := gaiaapp.NewGaiaApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{}, gaiaapp.DefaultNodeHome, 0, encCfg, helpers.EmptyAppOptions{})
+	cdc := encCfg.Marshaler
+
+	vals := []*account{newAccount(), newAccount()}
+	user1, user2 := newAccount(), newAccount()
+
+	genesis := gaiaapp.NewDefaultGenesisState()
+
+	var authGen authtypes.GenesisState
+	cdc.MustUnmarshalJSON(genesis[authtypes.ModuleName], &authGen)
+	var bankGen banktypes.GenesisState
+	cdc.MustUnmarshalJSON(genesis[banktypes.ModuleName], &bankGen)
+	var accs authtypes.GenesisAccounts
+	for _, a := range append(vals, user1, user2) {
+		accs = append(accs, authtypes.NewBaseAccount(a.addr, nil, 0, 0))
+	}
+	packed, err := authtypes.PackAccounts(accs)
+	must(err)
+	authGen.Accounts = packed
+	genesis[authtypes.ModuleName] = cdc.MustMarshalJSON(&authGen)
+	for _, v := range vals {
+		bankGen.Balances = append(bankGen.Balances, banktypes.Balance{Address: v.addr.String(), Coins: sdk.NewCoins(sdk.NewInt64Coin(denom, 10_000_000_000_000))})
+	}
+	for _, u := range []*account{user1, user2} {
+		bankGen.Balances = append(bankGen.Balances, banktypes.Balance{Address: u.addr.String(), Coins: sdk.NewCoins(sdk.NewInt64Coin(denom, 10_000_000))})
+	}
+	genesis[banktypes.ModuleName] = cdc.MustMarshalJSON(&bankGen)
+
+	var stakingGen stakingtypes.GenesisState
+	cdc.MustUnmarshalJSON(genesis[stakingtypes.ModuleName], &stakingGen)
+	stakingGen.Params.BondDenom = denom
+	genesis[stakingtypes.ModuleName] = cdc.MustMarshalJSON(&stakingGen)
+	var mintGen minttypes.GenesisState
+	cdc.MustUnmarshalJSON(genesis[minttypes.ModuleName], &mintGen)
+	mintGen.Params.MintDenom = denom
+	genesis[minttypes.ModuleName] = cdc.MustMarshalJSON(&mintGen)
+	var crisisGen crisistypes.GenesisState
+	cdc.MustUnmarshalJSON(genesis[crisistypes.ModuleName], &crisisGen)
+	crisisGen.ConstantFee.Denom = denom
+	genesis[crisistypes.ModuleName] = cdc.MustMarshalJSON(&crisisGen)
+	var govGen govtypes.GenesisState
+	cdc.MustUnmarshalJSON(genesis[govtypes.ModuleName], &govGen)
+	govGen.DepositParams.MinDeposit = sdk.NewCoins(sdk.NewInt64Coin(denom, 10_000_000))
+	genesis[govtypes.ModuleName] = cdc.MustMarshalJSON(&govGen)
+
+	// The gentxs of the validators, as by gaiad gentx with the defaults of interchaintest.
+	var genTxs []sdk.Tx
+	var consKeys []cryptotypes.PrivKey
+	for i, v := range vals {
+		consKey := ed25519.GenPrivKey()
+		consKeys = append(consKeys, consKey)
+		msg, err := stakingtypes.NewMsgCreateValidator(sdk.ValAddress(v.addr), consKey.PubKey(), sdk.NewInt64Coin(denom, 5_000_000_000_000),
+			stakingtypes.NewDescription(fmt.Sprintf("gaia-1-val-%d", i), "", "", "", ""),
+			stakingtypes.NewCommissionRates(sdk.MustNewDecFromStr("0.1"), sdk.MustNewDecFromStr("0.2"), sdk.MustNewDecFromStr("0.01")),
+			sdk.OneInt())
+		must(err)
+		bz := sign(v, msg)
+		t, err := encCfg.TxConfig.TxDecoder()(bz)
+		must(err)
+		genTxs = append(genTxs, t)
+	}
+	genesis[genutiltypes.ModuleName] = cdc.MustMarshalJSON(genutiltypes.NewGenesisStateFromTx(encCfg.TxConfig.TxJSONEncoder(), genTxs))
+
+	stateBytes, err := json.Marshal(genesis)
+	must(err)
+	now := time.Now().UTC()
+	res := app.InitChain(abci.RequestInitChain{Time: now, ChainId: chainID, ConsensusParams: helpers.DefaultConsensusParams, AppStateBytes: stateBytes})
+	if len(res.Validators) != 2 {
+		panic(fmt.Sprintf("%d validators", len(res.Validators)))
+	}
+
+	height := int64(1)
+	votes := make([]abci.VoteInfo, len(consKeys))
+	for i, k := range consKeys {
+		votes[i] = abci.VoteInfo{Validator: abci.Validator{Address: k.PubKey().Address(), Power: 5_000_000}, SignedLastBlock: true}
+	}
+	block := func(setup func(ctx sdk.Context), txs ...[]byte) []abci.ResponseDeliverTx {
+		header := tmproto.Header{ChainID: chainID, Height: height, Time: now.Add(time.Duration(height) * 5 * time.Second), ProposerAddress: consKeys[int(height)%2].PubKey().Address()}
+		app.BeginBlock(abci.RequestBeginBlock{Header: header, LastCommitInfo: abci.LastCommitInfo{Votes: votes}})
+		if setup != nil {
+			setup(app.BaseApp.NewContext(false, header))
+		}
+		var results []abci.ResponseDeliverTx
+		for _, t := range txs {
+			r := app.DeliverTx(abci.RequestDeliverTx{Tx: t})
+			if r.Code != 0 {
+				panic(r.Log)
+			}
+			results = append(results, r)
+		}
+		app.EndBlock(abci.RequestEndBlock{Height: height})
+		app.Commit()
+		height++
+		return results
+	}
+
+	// Blocks before the chain is considered started, accumulating rewards.
+	for i := 0; i < 5; i++ {
+		block(nil)
+	}
+
+	// The client, connection and transfer channel to osmosis as created by the relayer.
+	block(func(ctx sdk.Context) {
+		const clientID, connID, channelID = "07-tendermint-0", "connection-0", "channel-0"
+		latest := clienttypes.NewHeight(1, 20)
+		cs := ibctm.NewClientState("osmosis-1", ibctm.DefaultTrustLevel, 14*24*time.Hour*2/3, 14*24*time.Hour, 10*time.Minute,
+			latest, commitmenttypes.GetSDKSpecs(), []string{"upgrade", "upgradedIBCState"}, false, false)
+		app.IBCKeeper.ClientKeeper.SetClientState(ctx, clientID, cs)
+		app.IBCKeeper.ClientKeeper.SetClientConsensusState(ctx, clientID, latest,
+			ibctm.NewConsensusState(ctx.BlockTime(), commitmenttypes.NewMerkleRoot(make([]byte, 32)), make([]byte, 32)))
+		app.IBCKeeper.ClientKeeper.SetNextClientSequence(ctx, 1)
+		app.IBCKeeper.ConnectionKeeper.SetConnection(ctx, connID, connectiontypes.NewConnectionEnd(connectiontypes.OPEN, clientID,
+			connectiontypes.NewCounterparty(clientID, connID, commitmenttypes.NewMerklePrefix([]byte("ibc"))),
+			connectiontypes.ExportedVersionsToProto(connectiontypes.GetCompatibleVersions()), 0))
+		app.IBCKeeper.ChannelKeeper.SetChannel(ctx, transfertypes.PortID, channelID, channeltypes.NewChannel(channeltypes.OPEN, channeltypes.UNORDERED,
+			channeltypes.NewCounterparty(transfertypes.PortID, channelID), []string{connID}, transfertypes.Version))
+		app.IBCKeeper.ChannelKeeper.SetNextSequenceSend(ctx, transfertypes.PortID, channelID, 1)
+		app.IBCKeeper.ChannelKeeper.SetNextSequenceRecv(ctx, transfertypes.PortID, channelID, 1)
+		app.IBCKeeper.ChannelKeeper.SetNextSequenceAck(ctx, transfertypes.PortID, channelID, 1)
+		capPath := host.ChannelCapabilityPath(transfertypes.PortID, channelID)
+		chanCap, err := app.ScopedIBCKeeper.NewCapability(ctx, capPath)
+		must(err)
+		must(app.ScopedTransferKeeper.ClaimCapability(ctx, chanCap, capPath))
+	})
+
+	qctx := app.BaseApp.NewContext(true, tmproto.Header{})
+	for _, a := range []*account{user1, user2} {
+		a.accNum = app.AccountKeeper.GetAccount(qctx, a.addr).GetAccountNumber()
+	}
+
+	measured := make(map[string]int64)
+	deliver := func(name string, a *account, msg sdk.Msg) {
+		r := block(nil, sign(a, msg))
+		a.seq++
+		measured[name] = r[0].GasUsed
+	}
+
+	coin := sdk.NewInt64Coin(denom, 1_000)
+	deliver("MsgSend", user1, banktypes.NewMsgSend(user1.addr, user2.addr, sdk.NewCoins(coin)))
+
+	osmoAddr, err := bech32.ConvertAndEncode("osmo", newAccount().addr)
+	must(err)
+	ctx := app.BaseApp.NewContext(true, tmproto.Header{Height: height, Time: now.Add(time.Duration(height) * 5 * time.Second)})
+	// The default relative timeouts of the transfer command.
+	timeoutHeight := clienttypes.NewHeight(1, 20+1000)
+	timeoutTimestamp := uint64(ctx.BlockTime().Add(-5*time.Second).UnixNano()) + uint64(10*time.Minute)
+	deliver("MsgTransfer", user1, transfertypes.NewMsgTransfer(transfertypes.PortID, "channel-0", coin, user1.addr.String(), osmoAddr, timeoutHeight, timeoutTimestamp))
+
+	// The delegation of the test is the first one of the user, to the first validator.
+	deliver("MsgDelegate", user1, stakingtypes.NewMsgDelegate(user1.addr, sdk.ValAddress(vals[0].addr), coin))
+
+	goldenFile := filepath.Join("..", "gas_golden.json")
+	golden := make(map[string]map[string]int64)
+	bz, err := os.ReadFile(goldenFile)
+	must(err)
+	must(json.Unmarshal(bz, &golden))
+	golden[goldenKey] = measured
+	bz, err = json.MarshalIndent(golden, "", "  ")
+	must(err)
+	must(os.WriteFile(goldenFile, append(bz, '\n'), 0o644))
+}
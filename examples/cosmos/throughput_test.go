@@ -0,0 +1,64 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestThroughput loads a chain with concurrent bank sends from a single wallet and reports its throughput.
+func TestThroughput(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000_000, gaia, gaia)
+	sender, recipient := users[0], users[1]
+
+	res, err := testutil.MeasureThroughput(ctx, gaia, 30*time.Second, testutil.ThroughputOptions{
+		Sender: cosmos.NewSequencedBroadcaster(gaia, sender),
+		Amount: ibc.WalletAmount{
+			Address: recipient.FormattedAddress(),
+			Denom:   gaia.Config().Denom,
+			Amount:  1,
+		},
+		Concurrency: 20,
+	})
+	require.NoError(t, err)
+	t.Log(res)
+
+	require.Positive(t, res.Txs)
+	require.Positive(t, res.AvgBlockGasUsed)
+	require.Less(t, res.Failed, res.Submitted)
+}
@@ -0,0 +1,112 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestTransferParams disables receiving ICS-20 transfers on a chain through governance,
+// and asserts that incoming transfers are then acknowledged with an error and refunded.
+func TestTransferParams(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion, ChainConfig: ibc.ChainConfig{
+			ModifyGenesis: modifyGenesisShortProposals(votingPeriod, maxDepositPeriod),
+		}},
+		{Name: "osmosis", Version: osmosisVersion},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia, osmosis := chains[0].(*cosmos.CosmosChain), chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const pathName = "gaia-osmo"
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia).
+		AddChain(osmosis).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia,
+			Chain2:  osmosis,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000_000, gaia, osmosis)
+	gaiaUser, osmosisUser := users[0], users[1]
+
+	sendEnabled, receiveEnabled, err := gaia.QueryTransferParams(ctx)
+	require.NoError(t, err)
+	require.True(t, sendEnabled)
+	require.True(t, receiveEnabled)
+
+	height, err := gaia.Height(ctx)
+	require.NoError(t, err)
+
+	propTx, err := gaia.TransferParamsProposal(ctx, gaiaUser.KeyName(), "500000000"+gaia.Config().Denom, true, false)
+	require.NoError(t, err)
+
+	require.NoError(t, gaia.VoteOnProposalAllValidators(ctx, propTx.ProposalID, cosmos.ProposalVoteYes))
+
+	_, err = cosmos.PollForProposalStatus(ctx, gaia, height, height+haltHeightDelta, propTx.ProposalID, cosmos.ProposalStatusPassed)
+	require.NoError(t, err, "proposal status did not change to passed in expected number of blocks")
+
+	sendEnabled, receiveEnabled, err = gaia.QueryTransferParams(ctx)
+	require.NoError(t, err)
+	require.True(t, sendEnabled)
+	require.False(t, receiveEnabled)
+
+	osmosisChannel, err := ibc.GetTransferChannel(ctx, r, eRep, osmosis.Config().ChainID, gaia.Config().ChainID)
+	require.NoError(t, err)
+
+	before, err := osmosis.GetBalance(ctx, osmosisUser.FormattedAddress(), osmosis.Config().Denom)
+	require.NoError(t, err)
+	tx, err := osmosis.SendIBCTransfer(ctx, osmosisChannel.ChannelID, osmosisUser.KeyName(), ibc.WalletAmount{
+		Address: gaiaUser.FormattedAddress(),
+		Denom:   osmosis.Config().Denom,
+		Amount:  1_000_000,
+	}, ibc.TransferOptions{})
+	require.NoError(t, err)
+	require.NoError(t, tx.Validate())
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	})
+
+	ackErr, err := testutil.AssertTransferFailure(ctx, osmosis, tx, before)
+	require.NoError(t, err)
+	require.Contains(t, ackErr.Msg, "fungible token transfers to this chain are disabled")
+}
@@ -0,0 +1,94 @@
+package cosmos_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestValidatorCommission asserts that genesis validators are created with the configured commission
+// and minimum self-delegation, and that the chain enforces them.
+func TestValidatorCommission(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	nv := 1
+	nf := 0
+	const (
+		selfDelegation    = 5_000_000_000_000
+		minSelfDelegation = 1_000_000_000_000
+	)
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:    "gaia",
+			Version: gaiaVersion,
+			ChainConfig: ibc.ChainConfig{
+				ValidatorSelfDelegations: []int64{selfDelegation},
+				CommissionRate:           "0.05",
+				CommissionMaxRate:        "0.10",
+				CommissionMaxChangeRate:  "0.01",
+				MinSelfDelegation:        minSelfDelegation,
+			},
+			NumValidators: &nv,
+			NumFullNodes:  &nf,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(chain)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	v := chain.Validators[0]
+	valoper, err := v.ValidatorOperatorAddress(ctx)
+	require.NoError(t, err)
+
+	val, err := chain.QueryValidator(ctx, valoper)
+	require.NoError(t, err)
+	require.Equal(t, types.MustNewDecFromStr("0.05"), val.Commission.Rate)
+	require.Equal(t, types.MustNewDecFromStr("0.10"), val.Commission.MaxRate)
+	require.Equal(t, types.MustNewDecFromStr("0.01"), val.Commission.MaxChangeRate)
+	require.Equal(t, types.NewInt(minSelfDelegation), val.MinSelfDelegation)
+
+	wallet, err := v.ValidatorWallet(ctx)
+	require.NoError(t, err)
+
+	// The minimum self-delegation can only be raised, and not above the validator's self-delegation.
+	_, err = v.ExecTx(ctx, wallet.KeyName(),
+		"staking", "edit-validator", "--min-self-delegation", strconv.Itoa(minSelfDelegation/2),
+	)
+	require.Error(t, err, "decreasing the minimum self-delegation must be rejected")
+
+	_, err = v.ExecTx(ctx, wallet.KeyName(),
+		"staking", "edit-validator", "--min-self-delegation", strconv.Itoa(2*selfDelegation),
+	)
+	require.Error(t, err, "a minimum self-delegation above the self-delegation must be rejected")
+
+	val, err = chain.QueryValidator(ctx, valoper)
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt(minSelfDelegation), val.MinSelfDelegation)
+}
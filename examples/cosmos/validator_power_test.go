@@ -0,0 +1,75 @@
+package cosmos_test
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestValidatorPowerDistribution asserts that genesis validators are bonded with the configured self-delegations.
+func TestValidatorPowerDistribution(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	nv := 2
+	nf := 0
+	selfDelegations := []int64{6_700_000_000_000, 3_300_000_000_000}
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:    "gaia",
+			Version: gaiaVersion,
+			ChainConfig: ibc.ChainConfig{
+				ValidatorSelfDelegations: selfDelegations,
+			},
+			NumValidators: &nv,
+			NumFullNodes:  &nf,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(chain)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	stdout, _, err := chain.Validators[0].ExecQuery(ctx, "staking", "validators")
+	require.NoError(t, err)
+
+	var res struct {
+		Validators []struct {
+			Tokens string `json:"tokens"`
+		} `json:"validators"`
+	}
+	require.NoError(t, json.Unmarshal(stdout, &res))
+	require.Len(t, res.Validators, len(selfDelegations))
+
+	var tokens []string
+	for _, v := range res.Validators {
+		tokens = append(tokens, v.Tokens)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(tokens)))
+	require.Equal(t, []string{"6700000000000", "3300000000000"}, tokens)
+}
@@ -3,4 +3,5 @@ package cosmos_test
 const (
 	gaiaVersion    = "v7.1.0"
 	osmosisVersion = "v12.2.0"
+	umeeVersion    = "v3.3.0"
 )
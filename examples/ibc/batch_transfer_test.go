@@ -0,0 +1,181 @@
+package ibc_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"testing"
+
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	"github.com/icza/dyno"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestBatchSendIBCTransfer sends three denoms in a single transaction with one MsgTransfer each,
+// and asserts that each packet is acknowledged and delivers the voucher of its own amount.
+func TestBatchSendIBCTransfer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	extraDenoms := []string{"ufoo", "ubar"}
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.0", ChainConfig: ibc.ChainConfig{
+			ModifyGenesis: modifyGenesisExtraDenoms(extraDenoms...),
+		}},
+		{Name: "osmosis", Version: "v11.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia, osmosis := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const pathName = "gaia-osmo"
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia).
+		AddChain(osmosis).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia,
+			Chain2:  osmosis,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const fundAmount = int64(10_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", fundAmount, gaia, osmosis)
+	gaiaUser, osmosisUser := users[0], users[1]
+
+	// Only the faucet holds the extra denoms, so fund the user with them.
+	for _, denom := range extraDenoms {
+		require.NoError(t, gaia.SendFunds(ctx, interchaintest.FaucetAccountKeyName, ibc.WalletAmount{
+			Address: gaiaUser.FormattedAddress(),
+			Denom:   denom,
+			Amount:  fundAmount,
+		}))
+	}
+
+	gaiaChannel, err := ibc.GetTransferChannel(ctx, r, eRep, gaia.Config().ChainID, osmosis.Config().ChainID)
+	require.NoError(t, err)
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	})
+
+	// Distinct amounts, so that each packet is easy to map back to its transfer.
+	var transfers []cosmos.IBCTransfer
+	for i, denom := range append([]string{gaia.Config().Denom}, extraDenoms...) {
+		transfers = append(transfers, cosmos.IBCTransfer{
+			ChannelID: gaiaChannel.ChannelID,
+			Amount: ibc.WalletAmount{
+				Address: osmosisUser.FormattedAddress(),
+				Denom:   denom,
+				Amount:  int64(1_000 * (i + 1)),
+			},
+		})
+	}
+
+	b := cosmos.NewBroadcaster(t, gaia.(*cosmos.CosmosChain))
+	txs, err := cosmos.BatchSendIBCTransfer(ctx, b, gaiaUser.(*cosmos.CosmosWallet), transfers, ibc.TransferOptions{})
+	require.NoError(t, err)
+	require.Len(t, txs, len(transfers))
+
+	for i, tx := range txs {
+		require.NoError(t, tx.Validate())
+		require.Equal(t, txs[0].TxHash, tx.TxHash, "all transfers must be in one tx")
+
+		// The packet carries the amount of its own transfer.
+		var data transfertypes.FungibleTokenPacketData
+		require.NoError(t, json.Unmarshal(tx.Packet.Data, &data))
+		require.Equal(t, transfers[i].Amount.Denom, data.Denom)
+		require.Equal(t, fmt.Sprint(transfers[i].Amount.Amount), data.Amount)
+	}
+
+	afterSendHeight, err := gaia.Height(ctx)
+	require.NoError(t, err)
+
+	for i, tx := range txs {
+		_, err := testutil.PollForAck(ctx, gaia, txs[0].Height, afterSendHeight+30, tx.Packet)
+		require.NoError(t, err, "no acknowledgement for packet %d (%s)", tx.Packet.Sequence, transfers[i].Amount.Denom)
+
+		voucher := transfertypes.ParseDenomTrace(
+			transfertypes.GetPrefixedDenom(tx.Packet.DestPort, tx.Packet.DestChannel, transfers[i].Amount.Denom),
+		).IBCDenom()
+		bal, err := osmosis.GetBalance(ctx, osmosisUser.FormattedAddress(), voucher)
+		require.NoError(t, err)
+		require.Equal(t, transfers[i].Amount.Amount, bal, "voucher balance for %s", transfers[i].Amount.Denom)
+	}
+}
+
+// modifyGenesisExtraDenoms gives every genesis account a balance of each of the denoms,
+// in addition to the chain's native denom.
+func modifyGenesisExtraDenoms(denoms ...string) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(_ ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		g := make(map[string]any)
+		if err := json.Unmarshal(genbz, &g); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+		}
+
+		balances, err := dyno.GetSlice(g, "app_state", "bank", "balances")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get genesis balances: %w", err)
+		}
+		for i := range balances {
+			coins, err := dyno.GetSlice(balances[i], "coins")
+			if err != nil {
+				return nil, fmt.Errorf("failed to get coins of genesis balance %d: %w", i, err)
+			}
+			for _, denom := range denoms {
+				coins = append(coins, map[string]any{"denom": denom, "amount": "1000000000000"})
+			}
+			// Genesis coins must be sorted by denom.
+			sort.Slice(coins, func(a, b int) bool {
+				return fmt.Sprint(coins[a].(map[string]any)["denom"]) < fmt.Sprint(coins[b].(map[string]any)["denom"])
+			})
+			if err := dyno.Set(balances[i], coins, "coins"); err != nil {
+				return nil, fmt.Errorf("failed to set coins of genesis balance %d: %w", i, err)
+			}
+		}
+
+		// Let the chain compute the total supply, including the extra denoms.
+		if err := dyno.Set(g, []any{}, "app_state", "bank", "supply"); err != nil {
+			return nil, fmt.Errorf("failed to reset genesis supply: %w", err)
+		}
+
+		out, err := json.Marshal(g)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+		}
+		return out, nil
+	}
+}
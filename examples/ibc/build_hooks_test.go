@@ -0,0 +1,92 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestBuildHookContractChannel instantiates the ibc-reflect contracts once the chains are started,
+// from an AfterChainStart hook, so that Build opens the channel of the link between their ports.
+func TestBuildHookContractChannel(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "juno", ChainName: "juno-1", Version: "v14.1.0"},
+		{Name: "juno", ChainName: "juno-2", Version: "v14.1.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	juno1, juno2 := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const pathName = "juno-juno"
+	ic := interchaintest.NewInterchain().
+		AddChain(juno1).
+		AddChain(juno2).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  juno1,
+			Chain2:  juno2,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	var contract1, contract2 string
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+
+		Hooks: interchaintest.BuildHooks{
+			AfterChainStart: func(ctx context.Context, _ []ibc.Chain) error {
+				users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), 10_000_000, juno1, juno2)
+				contract1, contract2 = instantiateReflectContracts(ctx, t, juno1, juno2, users[0].KeyName(), users[1].KeyName())
+
+				return ic.SetLinkChannelOpts(r, pathName, ibc.CreateChannelOptions{
+					SourcePortName: cosmos.WasmPortID(contract1),
+					DestPortName:   cosmos.WasmPortID(contract2),
+					Order:          ibc.Ordered,
+					Version:        reflectVersion,
+				})
+			},
+		},
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	channels, err := r.GetChannels(ctx, eRep, juno1.Config().ChainID)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	require.Equal(t, cosmos.WasmPortID(contract1), channels[0].PortID)
+	require.Equal(t, cosmos.WasmPortID(contract2), channels[0].Counterparty.PortID)
+	require.Equal(t, reflectVersion, channels[0].Version)
+
+	// The channel connect callback of ibc_reflect_send registers the account of the channel,
+	// whose remote address is only set once the relayer relays the packet asking for it.
+	h, err := juno1.Height(ctx)
+	require.NoError(t, err)
+	_, err = cosmos.PollForContractState(ctx, juno1, h, h+10, contract1, accountQuery(channels[0].ChannelID), func(reflectSendAccount) bool {
+		return true
+	})
+	require.NoError(t, err)
+}
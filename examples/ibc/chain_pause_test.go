@@ -0,0 +1,105 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestChainPause pauses the destination chain of a transfer past the transfer's timestamp timeout,
+// and asserts that the relayer times out the packet once the chain resumes.
+func TestChainPause(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.0"},
+		{Name: "osmosis", Version: "v11.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia, osmosis := chains[0], chains[1].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const pathName = "gaia-osmo"
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia).
+		AddChain(osmosis).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia,
+			Chain2:  osmosis,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000, gaia, osmosis)
+	gaiaUser, osmosisUser := users[0], users[1]
+
+	gaiaChannel, err := ibc.GetTransferChannel(ctx, r, eRep, gaia.Config().ChainID, osmosis.Config().ChainID)
+	require.NoError(t, err)
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	})
+
+	require.NoError(t, osmosis.PauseAllNodes(ctx))
+
+	// Height does not wait for the paused chain to answer.
+	_, err = osmosis.Height(ctx)
+	require.ErrorIs(t, err, cosmos.ErrNodePaused)
+
+	const timeout = 20 * time.Second
+	tx, err := gaia.SendIBCTransfer(ctx, gaiaChannel.ChannelID, gaiaUser.KeyName(), ibc.WalletAmount{
+		Address: osmosisUser.FormattedAddress(),
+		Denom:   gaia.Config().Denom,
+		Amount:  1_000_000,
+	}, ibc.TransferOptions{Timeout: &ibc.IBCTimeout{NanoSeconds: uint64(timeout)}})
+	require.NoError(t, err)
+	require.NoError(t, tx.Validate())
+
+	// The packet cannot be received while the chain is paused, and times out in the meantime.
+	time.Sleep(timeout + 10*time.Second)
+	require.NoError(t, osmosis.ResumeAllNodes(ctx))
+
+	height, err := osmosis.Height(ctx)
+	require.NoError(t, err)
+	require.NoError(t, testutil.WaitForBlocks(ctx, 1, osmosis))
+	after, err := osmosis.Height(ctx)
+	require.NoError(t, err)
+	require.Greater(t, after, height, "chain did not resume producing blocks")
+
+	_, err = testutil.PollForTimeout(ctx, gaia, tx.Height, tx.Height+50, tx.Packet)
+	require.NoError(t, err)
+}
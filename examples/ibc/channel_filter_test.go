@@ -0,0 +1,130 @@
+package ibc_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestRelayerChannelFilter asserts that packets on channels excluded by the path's channel filter are not relayed,
+// and that filter changes take effect after the relayer is restarted.
+func TestRelayerChannelFilter(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "gaia-1", Version: "v7.0.0"},
+		{Name: "gaia", ChainName: "gaia-2", Version: "v7.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain1, chain2 := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const pathName = "gaia-gaia"
+	ic := interchaintest.NewInterchain().
+		AddChain(chain1).
+		AddChain(chain2).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  chain1,
+			Chain2:  chain2,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// Open a second transfer channel on the same connection.
+	require.NoError(t, r.CreateChannel(ctx, eRep, pathName, ibc.DefaultChannelOpts()))
+
+	channels, err := r.GetChannels(ctx, eRep, chain1.Config().ChainID)
+	require.NoError(t, err)
+	require.Len(t, channels, 2)
+	sort.Slice(channels, func(i, j int) bool { return channels[i].ChannelID < channels[j].ChannelID })
+	relayedChan, filteredChan := channels[0], channels[1]
+
+	// Only relay the first channel.
+	require.NoError(t, r.UpdatePath(ctx, eRep, pathName, ibc.PathUpdateOptions{
+		ChannelFilter: &ibc.ChannelFilter{
+			Rule:        ibc.ChannelFilterAllowlist,
+			ChannelList: []string{relayedChan.ChannelID},
+		},
+	}))
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("an error occurred while stopping the relayer: %s", err)
+		}
+	})
+
+	const fundAmount = int64(10_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", fundAmount, chain1, chain2)
+	user1, user2 := users[0], users[1]
+
+	const amountToSend = int64(1_000)
+	transfer := ibc.WalletAmount{
+		Address: user2.FormattedAddress(),
+		Denom:   chain1.Config().Denom,
+		Amount:  amountToSend,
+	}
+	for _, ch := range channels {
+		_, err := chain1.SendIBCTransfer(ctx, ch.ChannelID, user1.KeyName(), transfer, ibc.TransferOptions{})
+		require.NoError(t, err)
+	}
+
+	ibcDenom := func(ch ibc.ChannelOutput) string {
+		prefixed := transfertypes.GetPrefixedDenom(ch.Counterparty.PortID, ch.Counterparty.ChannelID, chain1.Config().Denom)
+		return transfertypes.ParseDenomTrace(prefixed).IBCDenom()
+	}
+
+	_, err = testutil.WaitForBalance(ctx, chain2, user2.FormattedAddress(), ibcDenom(relayedChan), amountToSend, time.Minute)
+	require.NoError(t, err)
+
+	// The transfer on the filtered channel remains pending.
+	require.NoError(t, testutil.WaitForBlocks(ctx, 10, chain1, chain2))
+	bal, err := chain2.GetBalance(ctx, user2.FormattedAddress(), ibcDenom(filteredChan))
+	require.NoError(t, err)
+	require.Zero(t, bal)
+
+	// Allow the second channel. The running relayer only picks up the change after a restart.
+	require.NoError(t, r.UpdatePath(ctx, eRep, pathName, ibc.PathUpdateOptions{
+		ChannelFilter: &ibc.ChannelFilter{
+			Rule:        ibc.ChannelFilterAllowlist,
+			ChannelList: []string{relayedChan.ChannelID, filteredChan.ChannelID},
+		},
+	}))
+	require.NoError(t, r.StopRelayer(ctx, eRep))
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+
+	_, err = testutil.WaitForBalance(ctx, chain2, user2.FormattedAddress(), ibcDenom(filteredChan), amountToSend, time.Minute)
+	require.NoError(t, err)
+}
@@ -0,0 +1,41 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/conformance"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/relayer"
+	"github.com/strangelove-ventures/interchaintest/v6/relayer/rly"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestChannelUpgrade runs the channel upgrade conformance case on ibc-go simapp chains of v8.1,
+// the first release with channel upgrades, so that the case does not skip.
+// The relayer does not complete upgrade handshakes, see rly.Capabilities,
+// so the case relays the handshake with cosmos.RelayChannelUpgrade while the relayer flushes
+// the packets sent before and during the upgrade.
+func TestChannelUpgrade(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "ibc-go-simd", ChainName: "simd-1", Version: "v8.1.0"},
+		{Name: "ibc-go-simd", ChainName: "simd-2", Version: "v8.1.0"},
+	})
+
+	// Relayers before v2.5 do not support chains of ibc-go v8.
+	rf := interchaintest.NewBuiltinRelayerFactory(
+		ibc.CosmosRly,
+		zaptest.NewLogger(t),
+		relayer.CustomDockerImage(rly.DefaultContainerImage, "v2.5.0", rly.RlyDefaultUidGid),
+	)
+
+	conformance.TestRelayerChannelUpgrade(t, context.Background(), cf, rf, testreporter.NewNopReporter())
+}
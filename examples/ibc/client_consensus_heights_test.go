@@ -0,0 +1,99 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestClientConsensusHeights relays a transfer without running the relayer,
+// and asserts that the relayer installed a consensus state of the sending chain
+// past the height of the transfer on the receiving chain.
+func TestClientConsensusHeights(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.0"},
+		{Name: "osmosis", Version: "v11.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia, osmosis := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const pathName = "gaia-osmo"
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia).
+		AddChain(osmosis).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia,
+			Chain2:  osmosis,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	clients, err := r.GetClients(ctx, eRep, gaia.Config().ChainID)
+	require.NoError(t, err)
+	require.Len(t, clients, 1)
+	clientID := clients[0].ClientID
+
+	heights, err := gaia.QueryClientConsensusHeights(ctx, clientID)
+	require.NoError(t, err)
+	require.NotEmpty(t, heights)
+	latest, err := gaia.QueryClientLatestHeight(ctx, clientID)
+	require.NoError(t, err)
+	require.Equal(t, latest, heights[len(heights)-1].RevisionHeight)
+
+	channels, err := r.GetChannels(ctx, eRep, osmosis.Config().ChainID)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), 10_000_000, gaia, osmosis)
+	gaiaUser, osmoUser := users[0], users[1]
+
+	tx, err := osmosis.SendIBCTransfer(ctx, channels[0].ChannelID, osmoUser.KeyName(), ibc.WalletAmount{
+		Address: gaiaUser.FormattedAddress(),
+		Denom:   osmosis.Config().Denom,
+		Amount:  1_000,
+	}, ibc.TransferOptions{})
+	require.NoError(t, err)
+
+	// The relayer is not running, so the packet is only relayed by the flush,
+	// which updates the client to a height past the packet commitment to prove it.
+	require.NoError(t, r.FlushPackets(ctx, eRep, pathName, channels[0].ChannelID))
+
+	heights, err = gaia.QueryClientConsensusHeights(ctx, clientID)
+	require.NoError(t, err)
+	require.Greater(t, heights[len(heights)-1].RevisionHeight, tx.Height)
+	for i := 1; i < len(heights); i++ {
+		require.True(t, heights[i-1].LT(heights[i]), "consensus heights not in ascending order: %v", heights)
+	}
+}
@@ -0,0 +1,78 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestClientExpiry creates clients with a trusting period of a minute
+// and asserts that they expire within the test while the relayer is not running.
+func TestClientExpiry(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.0"},
+		{Name: "osmosis", Version: "v11.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia, osmosis := chains[0].(*cosmos.CosmosChain), chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const trustingPeriod = time.Minute
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia).
+		AddChain(osmosis).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:           gaia,
+			Chain2:           osmosis,
+			Relayer:          r,
+			Path:             "gaia-osmo",
+			CreateClientOpts: testutil.ShortTrustingPeriodClientOpts(trustingPeriod),
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	clients, err := r.GetClients(ctx, eRep, gaia.Config().ChainID)
+	require.NoError(t, err)
+	require.Len(t, clients, 1)
+	clientID := clients[0].ClientID
+
+	status, err := gaia.QueryClientStatus(ctx, clientID)
+	require.NoError(t, err)
+	require.Equal(t, "Active", status)
+
+	// The relayer was never started, so nothing refreshes the client.
+	waitCtx, cancel := context.WithTimeout(ctx, 5*trustingPeriod)
+	defer cancel()
+	require.NoError(t, testutil.WaitForClientExpiry(waitCtx, gaia, clientID))
+}
@@ -0,0 +1,95 @@
+package ibc_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestConsensusParamsMaxGas lowers the block max gas in genesis so that storing a contract is rejected,
+// then raises it through governance so that the same transaction succeeds.
+func TestConsensusParamsMaxGas(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	const (
+		lowMaxGas = 1_000_000
+		storeGas  = "5000000"
+	)
+
+	// x/consensus, which updates the consensus params at runtime, requires SDK v0.47.
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "juno", Version: "v16.0.0", ChainConfig: ibc.ChainConfig{
+			ModifyGenesis: func(cfg ibc.ChainConfig, genbz []byte) ([]byte, error) {
+				genbz, err := cosmos.ModifyGenesisVotingPeriod(20*time.Second)(cfg, genbz)
+				if err != nil {
+					return nil, err
+				}
+				return cosmos.ModifyGenesisConsensusParams(func(p *cosmos.ConsensusParams) {
+					p.Block.MaxGas = lowMaxGas
+				})(cfg, genbz)
+			},
+		}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	juno := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(juno)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	params, err := juno.QueryConsensusParams(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(lowMaxGas), params.Block.MaxGas)
+
+	user := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000_000, juno)[0]
+
+	// The ante handler rejects transactions wanting more gas than a block allows.
+	_, err = juno.StoreContract(ctx, user.KeyName(), reflectContract, "--gas", storeGas)
+	require.ErrorContains(t, err, fmt.Sprintf("code %d", sdkerrors.ErrInvalidGasLimit.ABCICode()))
+
+	prop, err := juno.BuildConsensusParamsProposal(ctx, func(p *cosmos.ConsensusParams) {
+		p.Block.MaxGas = 10 * lowMaxGas
+	}, "", "500000000"+juno.Config().Denom, "Raise max gas", "Raise the block max gas")
+	require.NoError(t, err)
+
+	height, err := juno.Height(ctx)
+	require.NoError(t, err)
+
+	tx, err := juno.SubmitProposal(ctx, user.KeyName(), prop)
+	require.NoError(t, err)
+	require.NoError(t, juno.VoteOnProposalAllValidators(ctx, tx.ProposalID, cosmos.ProposalVoteYes))
+	_, err = cosmos.PollForProposalStatus(ctx, juno, height, height+30, tx.ProposalID, cosmos.ProposalStatusPassed)
+	require.NoError(t, err, "proposal did not pass")
+
+	params, err = juno.QueryConsensusParams(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(10*lowMaxGas), params.Block.MaxGas)
+
+	codeID, err := juno.StoreContract(ctx, user.KeyName(), reflectContract, "--gas", storeGas)
+	require.NoError(t, err)
+	require.NotEmpty(t, codeID)
+}
@@ -0,0 +1,63 @@
+package ibc_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/stretchr/testify/require"
+)
+
+// reflectContract is the reflect contract of CosmWasm v1.2.0, vendored from the testdata of wasmd v0.31.0,
+// which executes the messages of its owner.
+var reflectContract = filepath.Join("testdata", "reflect.wasm")
+
+// The ibc-reflect contracts of CosmWasm v1.2.0, also vendored from the testdata of wasmd v0.31.0.
+// ibc_reflect_send asks its counterparty for a remote account when a channel is connected,
+// which ibc_reflect instantiates from the reflect contract.
+var (
+	reflectSendContract = filepath.Join("testdata", "ibc_reflect_send.wasm")
+	ibcReflectContract  = filepath.Join("testdata", "ibc_reflect.wasm")
+)
+
+const reflectVersion = "ibc-reflect-v1"
+
+// reflectSendAccount is the response of ibc_reflect_send to an account query.
+type reflectSendAccount struct {
+	Data struct {
+		RemoteAddr string `json:"remote_addr"`
+	} `json:"data"`
+}
+
+// ibcReflectAccount is the response of ibc_reflect to an account query.
+type ibcReflectAccount struct {
+	Data struct {
+		Account string `json:"account"`
+	} `json:"data"`
+}
+
+func accountQuery(channelID string) any {
+	return map[string]any{"account": map[string]any{"channel_id": channelID}}
+}
+
+// instantiateReflectContracts instantiates ibc_reflect_send on chainA and ibc_reflect on chainB,
+// returning their addresses.
+func instantiateReflectContracts(ctx context.Context, t *testing.T, chainA, chainB *cosmos.CosmosChain, keyNameA, keyNameB string) (string, string) {
+	t.Helper()
+
+	sendCodeID, err := chainA.StoreContract(ctx, keyNameA, reflectSendContract)
+	require.NoError(t, err)
+	contractA, err := chainA.InstantiateContract(ctx, keyNameA, sendCodeID, "{}", true)
+	require.NoError(t, err)
+
+	reflectCodeID, err := chainB.StoreContract(ctx, keyNameB, reflectContract)
+	require.NoError(t, err)
+	ibcReflectCodeID, err := chainB.StoreContract(ctx, keyNameB, ibcReflectContract)
+	require.NoError(t, err)
+	contractB, err := chainB.InstantiateContract(ctx, keyNameB, ibcReflectCodeID, fmt.Sprintf(`{"reflect_code_id":%s}`, reflectCodeID), true)
+	require.NoError(t, err)
+
+	return contractA, contractB
+}
@@ -0,0 +1,97 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestGenesisChannel creates the transfer channel of a link in the genesis of both chains,
+// and relays a transfer over it without any handshake.
+func TestGenesisChannel(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.0"},
+		{Name: "osmosis", Version: "v11.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia, osmosis := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const pathName = "gaia-osmo"
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia).
+		AddChain(osmosis).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:         gaia,
+			Chain2:         osmosis,
+			Relayer:        r,
+			Path:           pathName,
+			GenesisChannel: true,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// The channel is open from genesis on both chains.
+	for _, c := range []ibc.Chain{gaia, osmosis} {
+		channels, err := r.GetChannels(ctx, eRep, c.Config().ChainID)
+		require.NoError(t, err)
+		require.Len(t, channels, 1)
+		require.Equal(t, cosmos.GenesisLinkChannelID, channels[0].ChannelID)
+		require.Equal(t, ibc.ChannelStateOpen, channels[0].State)
+	}
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		_ = r.StopRelayer(ctx, eRep)
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000, gaia, osmosis)
+	gaiaUser, osmosisUser := users[0], users[1]
+
+	tx, err := gaia.SendIBCTransfer(ctx, cosmos.GenesisLinkChannelID, gaiaUser.KeyName(), ibc.WalletAmount{
+		Address: osmosisUser.FormattedAddress(),
+		Denom:   gaia.Config().Denom,
+		Amount:  1_000,
+	}, ibc.TransferOptions{})
+	require.NoError(t, err)
+
+	_, err = testutil.PollForAck(ctx, gaia, tx.Height, tx.Height+30, tx.Packet)
+	require.NoError(t, err, "transfer %s not relayed", tx.TxHash)
+
+	voucher := transfertypes.ParseDenomTrace(
+		transfertypes.GetPrefixedDenom(tx.Packet.DestPort, tx.Packet.DestChannel, gaia.Config().Denom),
+	).IBCDenom()
+	balance, err := osmosis.GetBalance(ctx, osmosisUser.FormattedAddress(), voucher)
+	require.NoError(t, err)
+	require.Equal(t, int64(1_000), balance)
+}
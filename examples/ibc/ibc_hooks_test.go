@@ -0,0 +1,164 @@
+package ibc_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// hooksCounterContract is the counter contract of the ibc-hooks tests of osmosis v15.0.0
+// (tests/ibc-hooks/bytecode/counter.wasm), which counts the calls and funds received from each sender.
+// The first call of a sender creates its counter at zero and each later call increments it.
+var hooksCounterContract = filepath.Join("testdata", "ibc_hooks_counter.wasm")
+
+// TestIBCHooks transfers tokens with a wasm hook memo to a counter contract,
+// asserting that the contract received the funds and executed the message,
+// and that the sender is refunded if the contract call fails.
+func TestIBCHooks(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "osmosis", ChainName: "osmosis-1", Version: "v15.0.0"},
+		{Name: "osmosis", ChainName: "osmosis-2", Version: "v15.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	osmo1, osmo2 := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const pathName = "osmo-osmo"
+	ic := interchaintest.NewInterchain().
+		AddChain(osmo1).
+		AddChain(osmo2).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  osmo1,
+			Chain2:  osmo2,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000, osmo1, osmo2)
+	sender, deployer := users[0], users[1]
+
+	codeID, err := osmo2.StoreContract(ctx, deployer.KeyName(), hooksCounterContract)
+	require.NoError(t, err)
+	contract, err := osmo2.InstantiateContract(ctx, deployer.KeyName(), codeID, `{"count":0}`, true)
+	require.NoError(t, err)
+
+	channel, err := ibc.GetTransferChannel(ctx, r, eRep, osmo1.Config().ChainID, osmo2.Config().ChainID)
+	require.NoError(t, err)
+
+	const amount = 1_000
+	transfer := ibc.WalletAmount{
+		Address: contract,
+		Denom:   osmo1.Config().Denom,
+		Amount:  amount,
+	}
+
+	before, err := osmo1.GetBalance(ctx, sender.FormattedAddress(), osmo1.Config().Denom)
+	require.NoError(t, err)
+	// The contract rejects unknown messages, so the transfer is refunded.
+	tx, err := osmo1.SendIBCHookTransfer(ctx, channel.ChannelID, sender.KeyName(), transfer, map[string]any{"unknown": struct{}{}}, ibc.TransferOptions{})
+	require.NoError(t, err)
+	require.NoError(t, tx.Validate())
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	})
+
+	_, err = testutil.AssertTransferFailure(ctx, osmo1, tx, before)
+	require.NoError(t, err)
+
+	hookSender, err := cosmos.IBCHookSender(channel.Counterparty.ChannelID, sender.FormattedAddress(), osmo2.Config().Bech32Prefix)
+	require.NoError(t, err)
+	ibcDenom := transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom(channel.Counterparty.PortID, channel.Counterparty.ChannelID, osmo1.Config().Denom)).IBCDenom()
+
+	type countResponse struct {
+		Data struct {
+			Count int64 `json:"count"`
+		} `json:"data"`
+	}
+	type fundsResponse struct {
+		Data struct {
+			TotalFunds []struct {
+				Denom  string `json:"denom"`
+				Amount string `json:"amount"`
+			} `json:"total_funds"`
+		} `json:"data"`
+	}
+
+	// callHook calls the increment hook with a transfer, and waits for the contract to track the funds received in total.
+	callHook := func(total int64) {
+		t.Helper()
+
+		tx, err := osmo1.SendIBCHookTransfer(ctx, channel.ChannelID, sender.KeyName(), transfer, map[string]any{"increment": struct{}{}}, ibc.TransferOptions{})
+		require.NoError(t, err)
+		require.NoError(t, tx.Validate())
+
+		h, err := osmo2.Height(ctx)
+		require.NoError(t, err)
+		funds, err := cosmos.PollForContractState(ctx, osmo2, h, h+20, contract,
+			map[string]any{"get_total_funds": map[string]any{"addr": hookSender}},
+			func(res fundsResponse) bool {
+				return len(res.Data.TotalFunds) == 1 && res.Data.TotalFunds[0].Amount == fmt.Sprint(total)
+			},
+		)
+		require.NoError(t, err)
+		require.Equal(t, ibcDenom, funds.Data.TotalFunds[0].Denom)
+	}
+
+	getCount := func() int64 {
+		t.Helper()
+
+		var res countResponse
+		require.NoError(t, osmo2.QueryContract(ctx, contract, map[string]any{"get_count": map[string]any{"addr": hookSender}}, &res))
+		return res.Data.Count
+	}
+
+	// The first call creates the counter of the hook sender and tracks the received funds.
+	callHook(amount)
+	require.Zero(t, getCount())
+
+	// The second one increments it.
+	callHook(2 * amount)
+	require.EqualValues(t, 1, getCount())
+
+	bal, err := osmo2.GetBalance(ctx, contract, ibcDenom)
+	require.NoError(t, err)
+	require.EqualValues(t, 2*amount, bal)
+}
@@ -0,0 +1,144 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	icatypes "github.com/cosmos/ibc-go/v6/modules/apps/27-interchain-accounts/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/relayer"
+	"github.com/strangelove-ventures/interchaintest/v6/relayer/rly"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestICAEncodings registers an interchain account for each encoding of ICS-27 transactions,
+// negotiated in the version of the account's channel, and executes a bank send built with cosmos.BuildICATx
+// through the native ICS-27 controller. Hosts support proto3json since ibc-go v8.1.
+// The intertx module of the other ICA examples takes a single message and serializes it itself,
+// so it cannot send the payload of BuildICATx.
+func TestICAEncodings(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	client, network := interchaintest.DockerSetup(t)
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "ibc-go-simd", ChainName: "controller", Version: "v8.1.0"},
+		{Name: "ibc-go-simd", ChainName: "host", Version: "v8.1.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	controller, host := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	// Relayers before v2.5 do not support chains of ibc-go v8.
+	r := interchaintest.NewBuiltinRelayerFactory(
+		ibc.CosmosRly,
+		zaptest.NewLogger(t),
+		relayer.CustomDockerImage(rly.DefaultContainerImage, "v2.5.0", rly.RlyDefaultUidGid),
+	).Build(t, client, network)
+
+	const pathName = "ica-path"
+	ic := interchaintest.NewInterchain().
+		AddChain(controller).
+		AddChain(host).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  controller,
+			Chain2:  host,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("an error occurred while stopping the relayer: %s", err)
+		}
+	})
+
+	connections, err := controller.QueryConnections(ctx)
+	require.NoError(t, err)
+	require.Len(t, connections, 1)
+	connectionID, hostConnectionID := connections[0].ID, connections[0].Counterparty.ConnectionId
+
+	hostUser := interchaintest.GetAndFundTestUsers(t, ctx, t.Name()+"-host", 10_000_000_000, host)[0]
+	controllerNode := controller.Validators[0]
+
+	for _, encoding := range []string{cosmos.ICAEncodingProtobuf, cosmos.ICAEncodingProto3JSON} {
+		encoding := encoding
+		t.Run(encoding, func(t *testing.T) {
+			// Every owner has its own interchain account and channel.
+			owner := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), 10_000_000_000, controller)[0]
+
+			version, err := cosmos.ICAControllerVersion(connectionID, hostConnectionID, encoding)
+			require.NoError(t, err)
+			_, err = controllerNode.RegisterICAControllerWithVersion(ctx, owner.KeyName(), connectionID, version)
+			require.NoError(t, err)
+
+			var icaAddr string
+			for i := 0; i < 30 && icaAddr == ""; i++ {
+				require.NoError(t, testutil.WaitForBlocks(ctx, 1, controller))
+				icaAddr, _ = controllerNode.QueryICAController(ctx, connectionID, owner.FormattedAddress())
+			}
+			require.NotEmpty(t, icaAddr, "interchain account not registered")
+
+			// The channel of the account was opened with the requested encoding.
+			portID, err := icatypes.NewControllerPortID(owner.FormattedAddress())
+			require.NoError(t, err)
+			channels, err := controller.QueryChannels(ctx)
+			require.NoError(t, err)
+			var metadata icatypes.Metadata
+			for _, ch := range channels {
+				if ch.PortID == portID && ch.State == ibc.ChannelStateOpen {
+					require.NoError(t, icatypes.ModuleCdc.UnmarshalJSON([]byte(ch.Version), &metadata))
+				}
+			}
+			require.Equal(t, encoding, metadata.Encoding, "no open channel on port %s with the encoding", portID)
+			require.Equal(t, icaAddr, metadata.Address)
+
+			const icaFunds, sendAmount = 1_000_000, 1_000
+			require.NoError(t, host.SendFunds(ctx, hostUser.KeyName(), ibc.WalletAmount{
+				Address: icaAddr,
+				Denom:   host.Config().Denom,
+				Amount:  icaFunds,
+			}))
+
+			_, err = controllerNode.SendICATx(ctx, owner.KeyName(), connectionID, []sdk.Msg{&banktypes.MsgSend{
+				FromAddress: icaAddr,
+				ToAddress:   hostUser.FormattedAddress(),
+				Amount:      sdk.NewCoins(sdk.NewInt64Coin(host.Config().Denom, sendAmount)),
+			}}, encoding)
+			require.NoError(t, err)
+
+			// The host decoded and executed the bank send.
+			_, err = testutil.WaitForBalance(ctx, host, icaAddr, host.Config().Denom, icaFunds-sendAmount, time.Minute)
+			require.NoError(t, err)
+		})
+	}
+}
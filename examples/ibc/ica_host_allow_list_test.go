@@ -0,0 +1,182 @@
+package ibc_test
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/acks"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/relayer"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestICAHostAllowList restricts the interchain accounts host to MsgSend through governance,
+// then asserts that a MsgSend sent to a funded interchain account is executed,
+// while a MsgDelegate is acknowledged with an error.
+func TestICAHostAllowList(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	client, network := interchaintest.DockerSetup(t)
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	ctx := context.Background()
+
+	icad := []ibc.DockerImage{{Repository: "ghcr.io/cosmos/ibc-go-icad", Version: "v0.3.5"}}
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "icad", ChainConfig: ibc.ChainConfig{Images: icad}},
+		{Name: "icad", ChainConfig: ibc.ChainConfig{
+			Images:        icad,
+			ModifyGenesis: cosmos.ModifyGenesisVotingPeriod(20 * time.Second),
+		}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	controller, host := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	r := interchaintest.NewBuiltinRelayerFactory(
+		ibc.CosmosRly,
+		zaptest.NewLogger(t),
+		relayer.ProcessorEvents(100),
+	).Build(t, client, network)
+
+	const pathName = "ica-path"
+	ic := interchaintest.NewInterchain().
+		AddChain(controller).
+		AddChain(host).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  controller,
+			Chain2:  host,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("an error occurred while stopping the relayer: %s", err)
+		}
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), 10_000_000_000, controller, host)
+	controllerUser, hostUser := users[0], users[1]
+
+	// Restrict the host to bank sends.
+	const msgSend = "/cosmos.bank.v1beta1.MsgSend"
+	prop, err := host.ICAHostAllowListProposal(ctx, hostUser.KeyName(), "10000000"+host.Config().Denom, msgSend)
+	require.NoError(t, err)
+	require.NoError(t, host.VoteOnProposalAllValidators(ctx, prop.ProposalID, cosmos.ProposalVoteYes))
+	_, err = cosmos.PollForProposalStatus(ctx, host, prop.Height, prop.Height+30, prop.ProposalID, cosmos.ProposalStatusPassed)
+	require.NoError(t, err, "proposal did not pass")
+
+	allowed, err := host.QueryICAHostParams(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{msgSend}, allowed)
+
+	// Register the interchain account of the controller user on the host.
+	connections, err := r.GetConnections(ctx, eRep, controller.Config().ChainID)
+	require.NoError(t, err)
+	require.NotEmpty(t, connections)
+	connectionID := connections[0].ID
+
+	controllerNode := controller.Validators[0]
+	_, err = controllerNode.RegisterICA(ctx, controllerUser.KeyName(), connectionID)
+	require.NoError(t, err)
+
+	var icaAddr string
+	for i := 0; i < 30 && icaAddr == ""; i++ {
+		require.NoError(t, testutil.WaitForBlocks(ctx, 1, controller))
+		icaAddr, _ = controllerNode.QueryICA(ctx, connectionID, controllerUser.FormattedAddress())
+	}
+	require.NotEmpty(t, icaAddr, "interchain account not registered")
+
+	// Fund the interchain account, so only the allow list can make its messages fail.
+	const icaFunds = 1_000_000
+	require.NoError(t, host.SendFunds(ctx, hostUser.KeyName(), ibc.WalletAmount{
+		Address: icaAddr,
+		Denom:   host.Config().Denom,
+		Amount:  icaFunds,
+	}))
+
+	// submit sends msg to the interchain account and returns the acknowledgement of its execution by the host.
+	submit := func(msg sdk.Msg) acks.Ack {
+		t.Helper()
+		bz, err := cosmos.DefaultEncoding().Codec.MarshalInterfaceJSON(msg)
+		require.NoError(t, err)
+
+		txHash, err := controllerNode.ExecTx(ctx, controllerUser.KeyName(), "intertx", "submit", string(bz), "--connection-id", connectionID)
+		require.NoError(t, err)
+		hash, err := hex.DecodeString(txHash)
+		require.NoError(t, err)
+		submitTx, err := controllerNode.Client.Tx(ctx, hash, false)
+		require.NoError(t, err)
+
+		var packetData []byte
+		for _, event := range submitTx.TxResult.Events {
+			for _, attr := range event.Attributes {
+				if event.Type == "send_packet" && string(attr.Key) == "packet_data" {
+					packetData = attr.Value
+				}
+			}
+		}
+		require.NotEmpty(t, packetData)
+
+		// Blocks with intertx msgs cannot be decoded, but those never contain acknowledgements.
+		icaAck, err := testutil.PollForAckOfPacketData(ctx, controller, uint64(submitTx.Height), uint64(submitTx.Height)+30, packetData)
+		require.NoError(t, err, "no acknowledgement of the interchain account packet")
+		ack, err := acks.Parse(icaAck.Acknowledgement)
+		require.NoError(t, err)
+		return ack
+	}
+
+	// Sending from the interchain account is allowed.
+	const sendAmount = 1_000
+	ack := submit(&banktypes.MsgSend{
+		FromAddress: icaAddr,
+		ToAddress:   hostUser.FormattedAddress(),
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin(host.Config().Denom, sendAmount)),
+	})
+	require.True(t, ack.Success(), "allowed message was not executed by the host: %s", ack.Error)
+	bal, err := host.GetBalance(ctx, icaAddr, host.Config().Denom)
+	require.NoError(t, err)
+	require.Equal(t, int64(icaFunds-sendAmount), bal)
+
+	// Delegating from the interchain account is not allowed.
+	valoper, err := host.Validators[0].ValidatorOperatorAddress(ctx)
+	require.NoError(t, err)
+	ack = submit(&stakingtypes.MsgDelegate{
+		DelegatorAddress: icaAddr,
+		ValidatorAddress: valoper,
+		Amount:           sdk.NewInt64Coin(host.Config().Denom, sendAmount),
+	})
+	require.False(t, ack.Success(), "disallowed message was executed by the host")
+	bal, err = host.GetBalance(ctx, icaAddr, host.Config().Denom)
+	require.NoError(t, err)
+	require.Equal(t, int64(icaFunds-sendAmount), bal, "disallowed delegation changed the balance of the interchain account")
+}
@@ -2,14 +2,17 @@ package ibc
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
-	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/acks"
 	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
 	"github.com/strangelove-ventures/interchaintest/v6/relayer"
@@ -60,7 +63,7 @@ func TestInterchainAccounts(t *testing.T) {
 	r := interchaintest.NewBuiltinRelayerFactory(
 		ibc.CosmosRly,
 		zaptest.NewLogger(t),
-		relayer.RelayerOptionExtraStartFlags{Flags: []string{"-p", "events", "-b", "100"}},
+		relayer.ProcessorEvents(100),
 	).Build(t, client, network)
 
 	// Build the network; spin up the chains and configure the relayer
@@ -96,12 +99,29 @@ func TestInterchainAccounts(t *testing.T) {
 	require.NoError(t, err)
 
 	// Create new clients
-	err = r.CreateClients(ctx, eRep, pathName, ibc.CreateClientOptions{TrustingPeriod: "330h"})
+	clientOpts, err := interchaintest.ClientOptsFromUnbonding(ctx, chain1, chain2)
+	require.NoError(t, err)
+	err = r.CreateClients(ctx, eRep, pathName, clientOpts)
 	require.NoError(t, err)
 
 	err = testutil.WaitForBlocks(ctx, 5, chain1, chain2)
 	require.NoError(t, err)
 
+	// Each chain has exactly one client of the other, with the requested trusting period.
+	wantTrustingPeriod, err := time.ParseDuration(clientOpts.TrustingPeriod)
+	require.NoError(t, err)
+	for _, c := range [][2]ibc.Chain{{chain1, chain2}, {chain2, chain1}} {
+		clients, err := r.GetClients(ctx, eRep, c[0].Config().ChainID)
+		require.NoError(t, err)
+		byChainID := clients.ByChainID()
+		require.Len(t, byChainID, 1)
+		require.Len(t, byChainID[c[1].Config().ChainID], 1)
+
+		trustingPeriod, err := byChainID[c[1].Config().ChainID][0].ClientState.TrustingPeriodDuration()
+		require.NoError(t, err)
+		require.Equal(t, wantTrustingPeriod, trustingPeriod)
+	}
+
 	// Create a new connection
 	err = r.CreateConnections(ctx, eRep, pathName)
 	require.NoError(t, err)
@@ -115,7 +135,7 @@ func TestInterchainAccounts(t *testing.T) {
 	require.Equal(t, 1, len(connections))
 
 	// Register a new interchain account on chain2, on behalf of the user acc on chain1
-	chain1Addr := chain1User.(*cosmos.CosmosWallet).FormattedAddressWithPrefix(chain1.Config().Bech32Prefix)
+	chain1Addr := chain1User.FormattedAddress()
 
 	registerICA := []string{
 		chain1.Config().Bin, "tx", "intertx", "register",
@@ -160,13 +180,14 @@ func TestInterchainAccounts(t *testing.T) {
 	icaAddr := parseInterchainAccountField(stdout)
 	require.NotEmpty(t, icaAddr)
 
-	// Get initial account balances
-	chain2Addr := chain2User.(*cosmos.CosmosWallet).FormattedAddressWithPrefix(chain2.Config().Bech32Prefix)
+	// Get initial account balances in all denoms, so that unexpected fees or denoms are caught
+	chain2Addr := chain2User.FormattedAddress()
+	host := chain2.(*cosmos.CosmosChain)
 
-	chain2OrigBal, err := chain2.GetBalance(ctx, chain2Addr, chain2.Config().Denom)
+	chain2OrigBals, err := host.AllBalances(ctx, chain2Addr)
 	require.NoError(t, err)
 
-	icaOrigBal, err := chain2.GetBalance(ctx, icaAddr, chain2.Config().Denom)
+	icaOrigBals, err := host.AllBalances(ctx, icaAddr)
 	require.NoError(t, err)
 
 	// Send funds to ICA from user account on chain2
@@ -183,25 +204,21 @@ func TestInterchainAccounts(t *testing.T) {
 	err = testutil.WaitForBlocks(ctx, 5, chain2)
 	require.NoError(t, err)
 
-	chain2Bal, err := chain2.GetBalance(ctx, chain2Addr, chain2.Config().Denom)
+	transferCoin := sdk.NewInt64Coin(chain2.Config().Denom, transferAmount)
+
+	chain2Bals, err := host.AllBalances(ctx, chain2Addr)
 	require.NoError(t, err)
-	require.Equal(t, chain2OrigBal-transferAmount, chain2Bal)
+	require.Equal(t, chain2OrigBals.Sub(transferCoin).String(), chain2Bals.String())
 
-	icaBal, err := chain2.GetBalance(ctx, icaAddr, chain2.Config().Denom)
+	icaBals, err := host.AllBalances(ctx, icaAddr)
 	require.NoError(t, err)
-	require.Equal(t, icaOrigBal+transferAmount, icaBal)
+	require.Equal(t, icaOrigBals.Add(transferCoin).String(), icaBals.String())
 
 	// Build bank transfer msg
-	rawMsg, err := json.Marshal(map[string]any{
-		"@type":        "/cosmos.bank.v1beta1.MsgSend",
-		"from_address": icaAddr,
-		"to_address":   chain2Addr,
-		"amount": []map[string]any{
-			{
-				"denom":  chain2.Config().Denom,
-				"amount": strconv.Itoa(transferAmount),
-			},
-		},
+	rawMsg, err := cosmos.DefaultEncoding().Codec.MarshalInterfaceJSON(&banktypes.MsgSend{
+		FromAddress: icaAddr,
+		ToAddress:   chain2Addr,
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin(chain2.Config().Denom, transferAmount)),
 	})
 	require.NoError(t, err)
 
@@ -214,9 +231,10 @@ func TestInterchainAccounts(t *testing.T) {
 		"--home", chain1.HomeDir(),
 		"--node", chain1.GetRPCAddress(),
 		"--keyring-backend", keyring.BackendTest,
+		"--output", "json",
 		"-y",
 	}
-	_, _, err = chain1.Exec(ctx, sendICATransfer, nil)
+	submitStdout, _, err := chain1.Exec(ctx, sendICATransfer, nil)
 	require.NoError(t, err)
 
 	// Wait for tx to be relayed
@@ -224,14 +242,91 @@ func TestInterchainAccounts(t *testing.T) {
 	require.NoError(t, err)
 
 	// Assert that the funds have been received by the user account on chain2
-	chain2Bal, err = chain2.GetBalance(ctx, chain2Addr, chain2.Config().Denom)
+	chain2Bals, err = host.AllBalances(ctx, chain2Addr)
+	require.NoError(t, err)
+	require.Equal(t, chain2OrigBals.String(), chain2Bals.String())
+
+	// Assert that the ICA packet carried exactly the bank transfer msg
+	var submitResp struct {
+		TxHash string `json:"txhash"`
+	}
+	require.NoError(t, json.Unmarshal(submitStdout, &submitResp))
+	submitHash, err := hex.DecodeString(submitResp.TxHash)
+	require.NoError(t, err)
+
+	submitTx, err := chain1.(*cosmos.CosmosChain).Validators[0].Client.Tx(ctx, submitHash, false)
+	require.NoError(t, err)
+
+	var icaPacketData []byte
+	for _, event := range submitTx.TxResult.Events {
+		if event.Type != "send_packet" {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if string(attr.Key) == "packet_data" {
+				icaPacketData = attr.Value
+			}
+		}
+	}
+	require.NotEmpty(t, icaPacketData)
+
+	icaPacket, err := ibc.DecodeICAPacket(icaPacketData)
+	require.NoError(t, err)
+	require.Equal(t, ibc.ICAEncodingProtobuf, icaPacket.Encoding)
+	require.Len(t, icaPacket.Msgs, 1)
+	require.Equal(t, &banktypes.MsgSend{
+		FromAddress: icaAddr,
+		ToAddress:   chain2Addr,
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin(chain2.Config().Denom, transferAmount)),
+	}, icaPacket.Msgs[0])
+
+	// Assert that the host acknowledged the successful execution of the bank transfer msg
+	afterRelayHeight, err := chain1.Height(ctx)
+	require.NoError(t, err)
+
+	// Blocks with intertx msgs cannot be decoded, but those never contain acknowledgements.
+	icaAck, err := testutil.PollForAckOfPacketData(ctx, chain1, uint64(submitTx.Height), afterRelayHeight, icaPacketData)
+	require.NoError(t, err, "no acknowledgement of the interchain account packet")
+
+	ack, err := acks.Parse(icaAck.Acknowledgement)
+	require.NoError(t, err)
+	require.True(t, ack.Success(), ack.Error)
+
+	decodedAck, err := ibc.DecodeICAAcknowledgement(icaAck.Acknowledgement)
 	require.NoError(t, err)
-	require.Equal(t, chain2OrigBal, chain2Bal)
+	require.Equal(t, 1, len(decodedAck.MsgData)+len(decodedAck.MsgResponses))
 
 	// Assert that the funds have been removed from the ICA on chain2
-	icaBal, err = chain2.GetBalance(ctx, icaAddr, chain2.Config().Denom)
+	icaBals, err = host.AllBalances(ctx, icaAddr)
+	require.NoError(t, err)
+	require.Equal(t, icaOrigBals.String(), icaBals.String())
+
+	// Vote on a proposal of chain2 with the ICA, from the user account on chain1.
+	// Another account submits the proposal not to change the balances of chain2User.
+	proposer := interchaintest.GetAndFundTestUsers(t, ctx, t.Name()+"-proposer", userFunds, chain2)[0]
+	prop, err := host.TextProposal(ctx, proposer.KeyName(), cosmos.TextProposal{
+		Deposit:     "10000000" + chain2.Config().Denom,
+		Title:       "ICA vote",
+		Description: "Proposal voted on by an interchain account",
+	})
 	require.NoError(t, err)
-	require.Equal(t, icaOrigBal, icaBal)
+
+	voteHeight, err := chain2.Height(ctx)
+	require.NoError(t, err)
+	_, err = chain1.(*cosmos.CosmosChain).Validators[0].SendICAGovVote(ctx, connections[0].ID, chain1Addr, icaAddr, prop.ProposalID, cosmos.ProposalVoteYes)
+	require.NoError(t, err)
+
+	// Assert that the vote is recorded on chain2 under the ICA address once relayed
+	vote, err := cosmos.PollForVote(ctx, host, voteHeight, voteHeight+20, prop.ProposalID, icaAddr, cosmos.ProposalVoteYes)
+	require.NoError(t, err)
+	require.Equal(t, icaAddr, vote.Voter)
+
+	// Assert that the host received the bank transfer and vote packets of the ordered ICA channel in order
+	hostChans, err := r.GetChannels(ctx, eRep, chain2.Config().ChainID)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(hostChans))
+	require.Equal(t, ibc.ChannelOrderingOrdered, hostChans[0].Ordering)
+	require.NoError(t, testutil.AssertOrderedDelivery(ctx, host, hostChans[0].ChannelID, []uint64{1, 2}))
 
 	// Stop the relayer and wait for the process to terminate
 	err = r.StopRelayer(ctx, eRep)
@@ -248,58 +343,68 @@ func TestInterchainAccounts(t *testing.T) {
 	// Wait for approximately one minute to allow packet timeout threshold to be hit
 	time.Sleep(70 * time.Second)
 
-	// Restart the relayer and wait for NextSeqRecv proof to be delivered and packet timed out
+	// Restart the relayer and wait for NextSeqRecv proof to be delivered and packet timed out,
+	// which closes the controller end, then for the relayer to close the host end
+	restartHeight, err := chain1.Height(ctx)
+	require.NoError(t, err)
 	err = r.StartRelayer(ctx, eRep, pathName)
 	require.NoError(t, err)
 
-	err = testutil.WaitForBlocks(ctx, 15, chain1, chain2)
+	controllerEnd := hostChans[0].Counterparty
+	_, err = testutil.PollForChannelState(ctx, chain1.(*cosmos.CosmosChain), restartHeight, restartHeight+30,
+		controllerEnd.PortID, controllerEnd.ChannelID, ibc.ChannelStateClosed)
+	require.NoError(t, err, "controller channel not closed by the packet timeout")
+
+	hostHeight, err := chain2.Height(ctx)
 	require.NoError(t, err)
+	_, err = testutil.PollForChannelState(ctx, host, hostHeight, hostHeight+30,
+		hostChans[0].PortID, hostChans[0].ChannelID, ibc.ChannelStateClosed)
+	require.NoError(t, err, "host channel not closed")
 
 	// Assert that the packet timed out and that the acc balances are correct
-	chain2Bal, err = chain2.GetBalance(ctx, chain2Addr, chain2.Config().Denom)
+	chain2Bals, err = host.AllBalances(ctx, chain2Addr)
 	require.NoError(t, err)
-	require.Equal(t, chain2OrigBal, chain2Bal)
+	require.Equal(t, chain2OrigBals.String(), chain2Bals.String())
 
-	icaBal, err = chain2.GetBalance(ctx, icaAddr, chain2.Config().Denom)
+	icaBals, err = host.AllBalances(ctx, icaAddr)
 	require.NoError(t, err)
-	require.Equal(t, icaOrigBal, icaBal)
+	require.Equal(t, icaOrigBals.String(), icaBals.String())
 
 	// Assert that the channel ends are both closed
 	chain1Chans, err := r.GetChannels(ctx, eRep, chain1.Config().ChainID)
 	require.NoError(t, err)
 	require.Equal(t, 1, len(chain1Chans))
-	require.Equal(t, "STATE_CLOSED", chain1Chans[0].State)
+	require.Equal(t, ibc.ChannelStateClosed, chain1Chans[0].State)
 
 	chain2Chans, err := r.GetChannels(ctx, eRep, chain2.Config().ChainID)
 	require.NoError(t, err)
 	require.Equal(t, 1, len(chain2Chans))
-	require.Equal(t, "STATE_CLOSED", chain2Chans[0].State)
+	require.Equal(t, ibc.ChannelStateClosed, chain2Chans[0].State)
 
-	// Attempt to open another channel for the same ICA
-	_, _, err = chain1.Exec(ctx, registerICA, nil)
+	// The timed out packet of the closed channel is not pending anymore
+	pending, err := testutil.GetPendingPackets(ctx, r, eRep, pathName, chain1Chans[0], chain1.(*cosmos.CosmosChain), chain2)
 	require.NoError(t, err)
+	require.True(t, pending.Empty(), "pending packets on closed channel %s: %s", chain1Chans[0].ChannelID, pending)
 
-	// Wait for channel handshake to finish
-	err = testutil.WaitForBlocks(ctx, 15, chain1, chain2)
+	// Open another channel for the same ICA, asserting the same ICA is in use
+	newChannelID, err := cosmos.ReopenICAChannel(ctx, chain1.(*cosmos.CosmosChain), r, eRep, pathName, connections[0].ID, chain1User.KeyName())
 	require.NoError(t, err)
-
-	// Assert that a new channel has been opened and the same ICA is in use
-	stdout, _, err = chain1.Exec(ctx, queryICA, nil)
-	require.NoError(t, err)
-
-	newICA := parseInterchainAccountField(stdout)
-	require.NotEmpty(t, newICA)
-	require.Equal(t, icaAddr, newICA)
+	require.NotEqual(t, chain1Chans[0].ChannelID, newChannelID)
 
 	chain1Chans, err = r.GetChannels(ctx, eRep, chain1.Config().ChainID)
 	require.NoError(t, err)
 	require.Equal(t, 2, len(chain1Chans))
-	require.Equal(t, "STATE_OPEN", chain1Chans[1].State)
+	require.Equal(t, newChannelID, chain1Chans[1].ChannelID)
+	require.Equal(t, ibc.ChannelStateOpen, chain1Chans[1].State)
+
+	// The handshake completes on chain2 after the channel is open on chain1
+	err = testutil.WaitForBlocks(ctx, 2, chain2)
+	require.NoError(t, err)
 
 	chain2Chans, err = r.GetChannels(ctx, eRep, chain2.Config().ChainID)
 	require.NoError(t, err)
 	require.Equal(t, 2, len(chain2Chans))
-	require.Equal(t, "STATE_OPEN", chain2Chans[1].State)
+	require.Equal(t, ibc.ChannelStateOpen, chain2Chans[1].State)
 }
 
 // parseInterchainAccountField takes a slice of bytes which should be returned when querying for an ICA via
@@ -0,0 +1,117 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestInternalGasPrices asserts that faucet funding uses the zero-fee internal gas prices,
+// so that a user's balance change after an IBC transfer is exactly the amount plus the fees of that transfer.
+func TestInternalGasPrices(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.0", ChainConfig: ibc.ChainConfig{
+			GasPrices:         "0.01uatom",
+			InternalGasPrices: "0uatom",
+			// Allow zero-fee transactions on the node, while user transactions still pay fees.
+			ConfigFileOverrides: map[string]any{
+				"config/app.toml": testutil.Toml{"minimum-gas-prices": "0uatom"},
+			},
+		}},
+		// The node rejects zero fees here, so internal transactions must fall back to the regular gas prices.
+		{Name: "osmosis", Version: "v11.0.0", ChainConfig: ibc.ChainConfig{
+			InternalGasPrices: "0uosmo",
+		}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia, osmosis := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const ibcPath = "gaia-osmo"
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia).
+		AddChain(osmosis).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia,
+			Chain2:  osmosis,
+			Relayer: r,
+			Path:    ibcPath,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	faucetAddrBytes, err := gaia.GetAddress(ctx, interchaintest.FaucetAccountKeyName)
+	require.NoError(t, err)
+	faucetAddr, err := types.Bech32ifyAddressBytes(gaia.Config().Bech32Prefix, faucetAddrBytes)
+	require.NoError(t, err)
+
+	faucetBalInitial, err := gaia.GetBalance(ctx, faucetAddr, gaia.Config().Denom)
+	require.NoError(t, err)
+
+	const fundAmount = int64(10_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", fundAmount, gaia, osmosis)
+	gaiaUser, osmosisUser := users[0], users[1]
+
+	// The faucet paid no fees for funding the user.
+	faucetBal, err := gaia.GetBalance(ctx, faucetAddr, gaia.Config().Denom)
+	require.NoError(t, err)
+	require.Equal(t, faucetBalInitial-fundAmount, faucetBal)
+
+	// Funding on osmosis succeeded through the fallback.
+	osmosisUserBal, err := osmosis.GetBalance(ctx, osmosisUser.FormattedAddress(), osmosis.Config().Denom)
+	require.NoError(t, err)
+	require.Equal(t, fundAmount, osmosisUserBal)
+
+	gaiaUserBalInitial, err := gaia.GetBalance(ctx, gaiaUser.FormattedAddress(), gaia.Config().Denom)
+	require.NoError(t, err)
+	require.Equal(t, fundAmount, gaiaUserBalInitial)
+
+	gaiaChannel, err := ibc.GetTransferChannel(ctx, r, eRep, gaia.Config().ChainID, osmosis.Config().ChainID)
+	require.NoError(t, err)
+
+	const amountToSend = int64(1_000_000)
+	tx, err := gaia.SendIBCTransfer(ctx, gaiaChannel.ChannelID, gaiaUser.KeyName(), ibc.WalletAmount{
+		Address: osmosisUser.FormattedAddress(),
+		Denom:   gaia.Config().Denom,
+		Amount:  amountToSend,
+	}, ibc.TransferOptions{})
+	require.NoError(t, err)
+	require.NoError(t, tx.Validate())
+
+	fees := gaia.GetGasFeesInNativeDenom(tx.GasSpent)
+	require.Positive(t, fees)
+
+	gaiaUserBal, err := gaia.GetBalance(ctx, gaiaUser.FormattedAddress(), gaia.Config().Denom)
+	require.NoError(t, err)
+	require.Equal(t, gaiaUserBalInitial-(amountToSend+fees), gaiaUserBal)
+}
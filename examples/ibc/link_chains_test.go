@@ -0,0 +1,78 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestLinkChains builds two chains in separate Interchains, as independent test fixtures would,
+// and links them afterwards with LinkChains.
+func TestLinkChains(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.0"},
+		{Name: "osmosis", Version: "v11.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia, osmosis := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	for _, c := range []ibc.Chain{gaia, osmosis} {
+		ic := interchaintest.NewInterchain().AddChain(c)
+		require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+			TestName:  t.Name(),
+			Client:    client,
+			NetworkID: network,
+		}))
+		t.Cleanup(func() {
+			_ = ic.Close()
+		})
+	}
+
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	const pathName = "gaia-osmo"
+	require.NoError(t, interchaintest.LinkChains(ctx, eRep, r, gaia, osmosis, pathName))
+
+	channel, err := ibc.GetTransferChannel(ctx, r, eRep, gaia.Config().ChainID, osmosis.Config().ChainID)
+	require.NoError(t, err)
+	require.Equal(t, ibc.ChannelStateOpen, channel.State)
+
+	// The relayer wallets are funded, so the relayer can relay a transfer.
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000, gaia, osmosis)
+	gaiaUser, osmosisUser := users[0], users[1]
+
+	tx, err := gaia.SendIBCTransfer(ctx, channel.ChannelID, gaiaUser.KeyName(), ibc.WalletAmount{
+		Address: osmosisUser.FormattedAddress(),
+		Denom:   gaia.Config().Denom,
+		Amount:  1_000,
+	}, ibc.TransferOptions{})
+	require.NoError(t, err)
+	require.NoError(t, r.FlushPackets(ctx, eRep, pathName, channel.ChannelID))
+	require.NoError(t, r.FlushAcknowledgements(ctx, eRep, pathName, channel.ChannelID))
+
+	voucher := transfertypes.ParseDenomTrace(
+		transfertypes.GetPrefixedDenom(tx.Packet.DestPort, tx.Packet.DestChannel, gaia.Config().Denom),
+	).IBCDenom()
+	balance, err := osmosis.GetBalance(ctx, osmosisUser.FormattedAddress(), voucher)
+	require.NoError(t, err)
+	require.Equal(t, int64(1_000), balance, "transfer %s not relayed", tx.TxHash)
+}
@@ -0,0 +1,81 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestForkFreezesClient forks a chain by making its validators double-sign a conflicting header,
+// and asserts that the light client of the chain on its counterparty is frozen by the misbehaviour.
+func TestForkFreezesClient(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "gaia-1", Version: "v7.0.0"},
+		{Name: "gaia", ChainName: "gaia-2", Version: "v7.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	forked, host := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(forked).
+		AddChain(host).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  forked,
+			Chain2:  host,
+			Relayer: r,
+			Path:    "fork",
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	clients, err := r.GetClients(ctx, eRep, host.Config().ChainID)
+	require.NoError(t, err)
+	require.Len(t, clients, 1)
+	require.Equal(t, forked.Config().ChainID, clients[0].ClientState.ChainID)
+	clientID := clients[0].ClientID
+
+	status, err := host.QueryClientStatus(ctx, clientID)
+	require.NoError(t, err)
+	require.Equal(t, "Active", status)
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), 10_000_000, host)
+
+	// The misbehaviour must be past the latest consensus state of the client.
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, forked))
+	require.NoError(t, testutil.InduceFork(ctx, forked, host, clientID, users[0]))
+
+	status, err = host.QueryClientStatus(ctx, clientID)
+	require.NoError(t, err)
+	require.Equal(t, "Frozen", status)
+}
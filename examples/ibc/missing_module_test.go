@@ -0,0 +1,129 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestMissingTransferModule asserts that linking a transfer path fails
+// when the counterparty chain has no transfer module state, so its transfer port is not bound,
+// with the error of the chain rejecting the channel handshake on that port.
+func TestMissingTransferModule(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "gaia-1", Version: "v7.0.0"},
+		{Name: "gaia", ChainName: "gaia-2", Version: "v7.0.0", ChainConfig: ibc.ChainConfig{
+			ModifyGenesis: cosmos.ModifyGenesisRemoveModules("transfer"),
+		}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain1, chain2 := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain1).
+		AddChain(chain2).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  chain1,
+			Chain2:  chain2,
+			Relayer: r,
+			Path:    "gaia-gaia",
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	err = ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	})
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+	require.ErrorContains(t, err, "failed to link path gaia-gaia")
+	// The relayer output in the error has the rejection of MsgChannelOpenTry by chain2,
+	// which has no module bound to the transfer port.
+	require.ErrorContains(t, err, "could not retrieve module from port-id")
+}
+
+// TestDisabledTransfers asserts that IBC transfers fail clearly
+// when ICS-20 transfers are disabled on the sending chain.
+func TestDisabledTransfers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "gaia-1", Version: "v7.0.0", ChainConfig: ibc.ChainConfig{
+			ModifyGenesis: cosmos.ModifyGenesisDisableTransfer(),
+		}},
+		{Name: "gaia", ChainName: "gaia-2", Version: "v7.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain1, chain2 := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain1).
+		AddChain(chain2).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  chain1,
+			Chain2:  chain2,
+			Relayer: r,
+			Path:    "gaia-gaia",
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000, chain1, chain2)
+	user1, user2 := users[0], users[1]
+
+	channel, err := ibc.GetTransferChannel(ctx, r, eRep, chain1.Config().ChainID, chain2.Config().ChainID)
+	require.NoError(t, err)
+
+	_, err = chain1.SendIBCTransfer(ctx, channel.ChannelID, user1.KeyName(), ibc.WalletAmount{
+		Address: user2.FormattedAddress(),
+		Denom:   chain1.Config().Denom,
+		Amount:  1_000,
+	}, ibc.TransferOptions{})
+	require.ErrorContains(t, err, "fungible token transfers from this chain are disabled")
+}
@@ -0,0 +1,223 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	ibcmock "github.com/cosmos/ibc-go/v6/testing/mock"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/conformance"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/relayer"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestMockModuleChannel links two ibc-go simapp chains with a channel between their mock modules,
+// which is independent of the ICS-20 transfer application, and closes it.
+// Unlike the transfer module, the mock module lets users close its channels.
+// The mock module cannot send packets, so no packets flow over the channel, see TestMockModulePacket.
+func TestMockModuleChannel(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "ibc-go-simd", ChainName: "simd-1", Version: "v6.0.0"},
+		{Name: "ibc-go-simd", ChainName: "simd-2", Version: "v6.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain1, chain2 := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const pathName = "mock-path"
+	ic := interchaintest.NewInterchain().
+		AddChain(chain1).
+		AddChain(chain2).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:            chain1,
+			Chain2:            chain2,
+			Relayer:           r,
+			Path:              pathName,
+			CreateChannelOpts: ibc.MockChannelOpts(),
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	channels, err := r.GetChannels(ctx, eRep, chain1.Config().ChainID)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+
+	ch := channels[0]
+	require.Equal(t, ibc.ChannelStateOpen, ch.State)
+	require.Equal(t, ibc.MockPort, ch.PortID)
+	require.Equal(t, ibc.MockPort, ch.Counterparty.PortID)
+	require.Equal(t, ibc.MockVersion, ch.Version)
+
+	// Both chains bound the mock port, the counterparty end is open too.
+	counterparty := requireMockChannel(t, ctx, chain2, ch.Counterparty.ChannelID)
+	require.Equal(t, ibc.ChannelStateOpen, counterparty.State)
+	require.Equal(t, ibc.ChannelCounterparty{PortID: ibc.MockPort, ChannelID: ch.ChannelID}, counterparty.Counterparty)
+
+	// The relayer closes the channel with MsgChannelCloseInit on chain1 and MsgChannelCloseConfirm on chain2,
+	// which the mock module accepts.
+	closer, ok := r.(relayer.ChannelCloser)
+	require.True(t, ok, "relayer %T cannot close channels", r)
+	require.NoError(t, closer.CloseChannel(ctx, eRep, pathName, ch.ChannelID, ibc.MockPort))
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, chain1, chain2))
+
+	require.Equal(t, ibc.ChannelStateClosed, requireMockChannel(t, ctx, chain1, ch.ChannelID).State)
+	require.Equal(t, ibc.ChannelStateClosed, requireMockChannel(t, ctx, chain2, ch.Counterparty.ChannelID).State)
+}
+
+// TestMockModulePacket sends an ICS-20 transfer from the transfer port of chain2 to the mock module of chain1,
+// and relays the packet with a MsgRecvPacket and its acknowledgement with a MsgAcknowledgement, built by cosmos.RelayPacket.
+// The mock module acknowledges packets other than its mock packet data with an error acknowledgement,
+// so the transfer is refunded.
+func TestMockModulePacket(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "ibc-go-simd", ChainName: "simd-1", Version: "v6.0.0"},
+		{Name: "ibc-go-simd", ChainName: "simd-2", Version: "v6.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain1, chain2 := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const pathName = "mock-transfer-path"
+	ic := interchaintest.NewInterchain().
+		AddChain(chain1).
+		AddChain(chain2).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:            chain1,
+			Chain2:            chain2,
+			Relayer:           r,
+			Path:              pathName,
+			CreateChannelOpts: ibc.MockTransferChannelOpts(),
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// The relayer is not started: the test relays the packet itself.
+	channels, err := chain2.QueryChannels(ctx)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	ch := channels[0]
+	require.Equal(t, "transfer", ch.PortID)
+	require.Equal(t, ibc.MockPort, ch.Counterparty.PortID)
+
+	// relayer2 signs the MsgAcknowledgement, so that the balance of user2 only changes by the refund.
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), 10_000_000_000, chain1, chain2, chain2)
+	user1, user2, relayer2 := users[0], users[1], users[2]
+	denom := chain2.Config().Denom
+
+	const amount = 1_000
+	tx, err := chain2.SendIBCTransfer(ctx, ch.ChannelID, user2.KeyName(), ibc.WalletAmount{
+		Address: user1.FormattedAddress(),
+		Denom:   denom,
+		Amount:  amount,
+	}, ibc.TransferOptions{})
+	require.NoError(t, err)
+	require.NoError(t, tx.Validate())
+
+	sentBalance, err := chain2.GetBalance(ctx, user2.FormattedAddress(), denom)
+	require.NoError(t, err)
+
+	ack, err := cosmos.RelayPacket(ctx, r, eRep, pathName,
+		cosmos.PacketRelayEnd{Chain: chain2, KeyName: relayer2.KeyName()},
+		cosmos.PacketRelayEnd{Chain: chain1, KeyName: user1.KeyName()},
+		tx,
+	)
+	require.NoError(t, err)
+	require.Equal(t, string(ibcmock.MockFailAcknowledgement.Acknowledgement()), string(ack))
+
+	commitment, err := chain1.QueryPacketAcknowledgementCommitment(ctx, ibc.MockPort, ch.Counterparty.ChannelID, tx.Packet.Sequence)
+	require.NoError(t, err)
+	require.Equal(t, chantypes.CommitAcknowledgement(ack), commitment)
+
+	pending, err := chain2.QueryPendingPackets(ctx, chain1, ch.PortID, ch.ChannelID)
+	require.NoError(t, err)
+	require.True(t, pending.Empty(), "pending packets: %s", pending)
+
+	balance, err := chain2.GetBalance(ctx, user2.FormattedAddress(), denom)
+	require.NoError(t, err)
+	require.Equal(t, sentBalance+amount, balance, "transfer to the mock module not refunded")
+}
+
+// TestMockModuleConformance runs the mock packet conformance case of the relayer against ibc-go simapp chains.
+func TestMockModuleConformance(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "ibc-go-simd", ChainName: "simd-1", Version: "v6.0.0"},
+		{Name: "ibc-go-simd", ChainName: "simd-2", Version: "v6.0.0"},
+	})
+	rf := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t))
+
+	conformance.TestRelayerMockPacket(t, context.Background(), cf, rf, testreporter.NewNopReporter())
+}
+
+// requireMockChannel returns the end of the channel with channelID on the mock port of chain, from the chain state.
+func requireMockChannel(t *testing.T, ctx context.Context, chain *cosmos.CosmosChain, channelID string) ibc.ChannelOutput {
+	t.Helper()
+
+	channels, err := chain.QueryChannels(ctx)
+	require.NoError(t, err)
+	for _, ch := range channels {
+		if ch.PortID == ibc.MockPort && ch.ChannelID == channelID {
+			require.Equal(t, ibc.MockVersion, ch.Version)
+			return ch
+		}
+	}
+	require.FailNow(t, "channel not found", "no channel %s on port %s of %s", channelID, ibc.MockPort, chain.Config().ChainID)
+	return ibc.ChannelOutput{}
+}
@@ -0,0 +1,96 @@
+package ibc_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestMultipleDockerHosts runs osmosis on the remote docker daemon of INTERCHAINTEST_REMOTE_DOCKER_HOST,
+// e.g. tcp://10.0.0.2:2376, and gaia and the relayer on the local one, and transfers between them.
+// The remote machine must accept connections on the ports published by its containers.
+func TestMultipleDockerHosts(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	remoteHost := os.Getenv("INTERCHAINTEST_REMOTE_DOCKER_HOST")
+	if remoteHost == "" {
+		t.Skip("INTERCHAINTEST_REMOTE_DOCKER_HOST not set")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.0"},
+		{Name: "osmosis", Version: "v11.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia, osmosis := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	remote := interchaintest.DockerSetupHost(t, remoteHost)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const pathName = "gaia-osmo"
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia).
+		AddChain(osmosis).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia,
+			Chain2:  osmosis,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+
+		ChainDockerHosts: map[ibc.Chain]interchaintest.DockerHost{osmosis: remote},
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), 10_000_000, gaia, osmosis)
+	gaiaUser, osmoUser := users[0], users[1]
+
+	channels, err := r.GetChannels(ctx, eRep, gaia.Config().ChainID)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	channel := channels[0]
+
+	const amount = 1_000
+	_, err = gaia.SendIBCTransfer(ctx, channel.ChannelID, gaiaUser.KeyName(), ibc.WalletAmount{
+		Address: osmoUser.FormattedAddress(),
+		Denom:   gaia.Config().Denom,
+		Amount:  amount,
+	}, ibc.TransferOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, r.FlushPackets(ctx, eRep, pathName, channel.ChannelID))
+	require.NoError(t, r.FlushAcknowledgements(ctx, eRep, pathName, channel.Counterparty.ChannelID))
+
+	ibcDenom := transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom(
+		channel.Counterparty.PortID, channel.Counterparty.ChannelID, gaia.Config().Denom,
+	)).IBCDenom()
+	bal, err := osmosis.GetBalance(ctx, osmoUser.FormattedAddress(), ibcDenom)
+	require.NoError(t, err)
+	require.Equal(t, int64(amount), bal)
+}
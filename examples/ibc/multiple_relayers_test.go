@@ -0,0 +1,180 @@
+package ibc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/relayer"
+	"github.com/strangelove-ventures/interchaintest/v6/relayer/rly"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestMultipleRelayers builds a three-chain network where each path is served by a different relayer,
+// and routes a transfer across both paths.
+//
+// The relayers are two differently configured relayer images;
+// Build treats every relayer independently, so relayers of different implementations combine the same way.
+func TestMultipleRelayers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "gaia-a", Version: "v7.0.0", ChainConfig: ibc.ChainConfig{ChainID: "chain-a"}},
+		{Name: "gaia", ChainName: "gaia-b", Version: "v7.0.0", ChainConfig: ibc.ChainConfig{ChainID: "chain-b"}},
+		{Name: "gaia", ChainName: "gaia-c", Version: "v7.0.0", ChainConfig: ibc.ChainConfig{ChainID: "chain-c"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chainA, chainB, chainC := chains[0], chains[1], chains[2]
+
+	client, network := interchaintest.DockerSetup(t)
+
+	rAB := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+	rBC := interchaintest.NewBuiltinRelayerFactory(
+		ibc.CosmosRly,
+		zaptest.NewLogger(t),
+		relayer.CustomDockerImage(rly.DefaultContainerImage, "v2.3.1", rly.RlyDefaultUidGid),
+	).Build(t, client, network)
+
+	const (
+		pathAB = "ab"
+		pathBC = "bc"
+
+		relayerAB = "relayer-ab"
+		relayerBC = "relayer-bc"
+	)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chainA).
+		AddChain(chainB).
+		AddChain(chainC).
+		AddRelayer(rAB, relayerAB).
+		AddRelayer(rBC, relayerBC).
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  chainA,
+			Chain2:  chainB,
+			Relayer: rAB,
+			Path:    pathAB,
+		}).
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  chainB,
+			Chain2:  chainC,
+			Relayer: rBC,
+			Path:    pathBC,
+		})
+
+	reportBuf := new(bytes.Buffer)
+	rep := testreporter.NewReporter(nopCloser{Writer: reportBuf})
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	abRep, bcRep := eRep.ForRelayer(relayerAB), eRep.ForRelayer(relayerBC)
+
+	require.NoError(t, rAB.StartRelayer(ctx, abRep, pathAB))
+	require.NoError(t, rBC.StartRelayer(ctx, bcRep, pathBC))
+	stopped := false
+	t.Cleanup(func() {
+		if !stopped {
+			_ = rAB.StopRelayer(ctx, abRep)
+			_ = rBC.StopRelayer(ctx, bcRep)
+		}
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000, chainA, chainB, chainC)
+	userA, userB, userC := users[0], users[1], users[2]
+
+	abChan, err := ibc.GetTransferChannel(ctx, rAB, abRep, chainA.Config().ChainID, chainB.Config().ChainID)
+	require.NoError(t, err)
+	bcChan, err := ibc.GetTransferChannel(ctx, rBC, bcRep, chainB.Config().ChainID, chainC.Config().ChainID)
+	require.NoError(t, err)
+
+	const amount = int64(1_000_000)
+
+	// First hop, relayed by relayerAB.
+	tx, err := chainA.SendIBCTransfer(ctx, abChan.ChannelID, userA.KeyName(), ibc.WalletAmount{
+		Address: userB.FormattedAddress(),
+		Denom:   chainA.Config().Denom,
+		Amount:  amount,
+	}, ibc.TransferOptions{})
+	require.NoError(t, err)
+	require.NoError(t, tx.Validate())
+
+	_, err = testutil.PollForAck(ctx, chainA, tx.Height, tx.Height+20, tx.Packet)
+	require.NoError(t, err)
+
+	denomOnB := transfertypes.GetPrefixedDenom(abChan.Counterparty.PortID, abChan.Counterparty.ChannelID, chainA.Config().Denom)
+	ibcDenomOnB := transfertypes.ParseDenomTrace(denomOnB).IBCDenom()
+
+	// Second hop, relayed by relayerBC.
+	tx, err = chainB.SendIBCTransfer(ctx, bcChan.ChannelID, userB.KeyName(), ibc.WalletAmount{
+		Address: userC.FormattedAddress(),
+		Denom:   ibcDenomOnB,
+		Amount:  amount,
+	}, ibc.TransferOptions{})
+	require.NoError(t, err)
+	require.NoError(t, tx.Validate())
+
+	_, err = testutil.PollForAck(ctx, chainB, tx.Height, tx.Height+20, tx.Packet)
+	require.NoError(t, err)
+
+	denomOnC := transfertypes.GetPrefixedDenom(bcChan.Counterparty.PortID, bcChan.Counterparty.ChannelID, denomOnB)
+	bal, err := chainC.GetBalance(ctx, userC.FormattedAddress(), transfertypes.ParseDenomTrace(denomOnC).IBCDenom())
+	require.NoError(t, err)
+	require.Equal(t, amount, bal)
+
+	// Stop the relayers before closing the reporter, so that no command is tracked after it closes.
+	stopped = true
+	require.NoError(t, rAB.StopRelayer(ctx, abRep))
+	require.NoError(t, rBC.StopRelayer(ctx, bcRep))
+	require.NoError(t, rep.Close())
+
+	// Every relayer command is attributed to the relayer that ran it.
+	seen := make(map[string]bool)
+	dec := json.NewDecoder(reportBuf)
+	for {
+		var wm testreporter.WrappedMessage
+		err := dec.Decode(&wm)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if exec, ok := wm.Message.(testreporter.RelayerExecMessage); ok {
+			require.NotEmpty(t, exec.Relayer, "unattributed relayer command %v", exec.Command)
+			seen[exec.Relayer] = true
+		}
+	}
+	require.True(t, seen[relayerAB])
+	require.True(t, seen[relayerBC])
+}
+
+// nopCloser wraps an io.Writer to provide a Close method that always returns nil.
+type nopCloser struct {
+	io.Writer
+}
+
+func (n nopCloser) Close() error {
+	return nil
+}
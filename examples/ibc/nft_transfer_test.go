@@ -0,0 +1,131 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestNFTTransfer mints an NFT and sends it over an ICS-721 channel,
+// asserting that the receiver owns it under the voucher class on the destination chain.
+func TestNFTTransfer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	irisConfig := func(chainID string) ibc.ChainConfig {
+		return ibc.ChainConfig{
+			Type:    "cosmos",
+			Name:    chainID,
+			ChainID: chainID,
+			Images: []ibc.DockerImage{{
+				Repository: "ghcr.io/strangelove-ventures/heighliner/irisnet",
+				Version:    "v2.0.0",
+				UidGid:     dockerutil.GetHeighlinerUserString(),
+			}},
+			Bin:            "iris",
+			Bech32Prefix:   "iaa",
+			Denom:          "uiris",
+			GasPrices:      "0.01uiris",
+			TrustingPeriod: "300h",
+			GasAdjustment:  1.3,
+		}
+	}
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{ChainName: "iris-a", ChainConfig: irisConfig("iris-a")},
+		{ChainName: "iris-b", ChainConfig: irisConfig("iris-b")},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chainA, chainB := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const pathName = "nft-path"
+	ic := interchaintest.NewInterchain().
+		AddChain(chainA).
+		AddChain(chainB).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  chainA,
+			Chain2:  chainB,
+			Relayer: r,
+			Path:    pathName,
+			CreateChannelOpts: ibc.CreateChannelOptions{
+				SourcePortName: cosmos.NFTTransferPort,
+				DestPortName:   cosmos.NFTTransferPort,
+				Order:          ibc.Unordered,
+				Version:        "ics721-1",
+			},
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		_ = r.StopRelayer(ctx, eRep)
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000_000, chainA, chainB)
+	userA, userB := users[0], users[1]
+
+	const (
+		classID = "kitties"
+		tokenID = "kitty1"
+	)
+	require.NoError(t, chainA.IssueNFTClass(ctx, userA.KeyName(), classID, "Kitties"))
+	require.NoError(t, chainA.MintNFT(ctx, userA.KeyName(), classID, tokenID, "https://example.com/kitty1.json", userA.FormattedAddress()))
+
+	owner, err := chainA.QueryNFTOwner(ctx, classID, tokenID)
+	require.NoError(t, err)
+	require.Equal(t, userA.FormattedAddress(), owner)
+
+	channels, err := r.GetChannels(ctx, eRep, chainA.Config().ChainID)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	channel := channels[0]
+	require.Equal(t, cosmos.NFTTransferPort, channel.PortID)
+
+	tx, err := chainA.SendNFTTransfer(ctx, channel.ChannelID, userA.KeyName(), classID, tokenID, userB.FormattedAddress(), ibc.TransferOptions{})
+	require.NoError(t, err)
+
+	ack, err := testutil.PollForAck(ctx, chainA, tx.Height, tx.Height+20, tx.Packet)
+	require.NoError(t, err)
+	require.NoError(t, ack.Validate())
+
+	// The token is escrowed on the source chain.
+	owner, err = chainA.QueryNFTOwner(ctx, classID, tokenID)
+	require.NoError(t, err)
+	require.NotEqual(t, userA.FormattedAddress(), owner)
+
+	// And arrives on the destination as a voucher class.
+	voucherClassID := ibc.NFTVoucherClassID(channel.Counterparty.PortID, channel.Counterparty.ChannelID, classID)
+	nfts, err := chainB.QueryNFTs(ctx, userB.FormattedAddress(), voucherClassID)
+	require.NoError(t, err)
+	require.Equal(t, []cosmos.NFT{{ClassID: voucherClassID, ID: tokenID, URI: "https://example.com/kitty1.json"}}, nfts)
+}
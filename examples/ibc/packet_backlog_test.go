@@ -0,0 +1,100 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestPacketBacklog sends transfers without a running relayer,
+// and asserts that the packet backlog of the channel moves from unreceived to unacknowledged packets,
+// and drains to zero as the relayer flushes the packets and then their acknowledgements.
+func TestPacketBacklog(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.0"},
+		{Name: "osmosis", Version: "v11.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia, osmosis := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const pathName = "gaia-osmo"
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia).
+		AddChain(osmosis).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia,
+			Chain2:  osmosis,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000, gaia, osmosis)
+	gaiaUser, osmosisUser := users[0], users[1]
+
+	gaiaChannel, err := ibc.GetTransferChannel(ctx, r, eRep, gaia.Config().ChainID, osmosis.Config().ChainID)
+	require.NoError(t, err)
+
+	assertBacklog := func(wantSend, wantAck int) {
+		t.Helper()
+		pendingSend, pendingAck, err := gaia.QueryPacketBacklog(ctx, osmosis, gaiaChannel.PortID, gaiaChannel.ChannelID)
+		require.NoError(t, err)
+		require.Equal(t, wantSend, pendingSend, "unreceived packets")
+		require.Equal(t, wantAck, pendingAck, "unacknowledged packets")
+	}
+
+	assertBacklog(0, 0)
+
+	const numTransfers = 3
+	for i := 0; i < numTransfers; i++ {
+		tx, err := gaia.SendIBCTransfer(ctx, gaiaChannel.ChannelID, gaiaUser.KeyName(), ibc.WalletAmount{
+			Address: osmosisUser.FormattedAddress(),
+			Denom:   gaia.Config().Denom,
+			Amount:  1_000,
+		}, ibc.TransferOptions{})
+		require.NoError(t, err)
+		require.NoError(t, tx.Validate())
+	}
+
+	assertBacklog(numTransfers, 0)
+
+	require.NoError(t, r.FlushPackets(ctx, eRep, pathName, gaiaChannel.ChannelID))
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, osmosis))
+	assertBacklog(0, numTransfers)
+
+	require.NoError(t, r.FlushAcknowledgements(ctx, eRep, pathName, gaiaChannel.ChannelID))
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, gaia))
+	assertBacklog(0, 0)
+}
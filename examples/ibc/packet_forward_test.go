@@ -198,7 +198,7 @@ func TestPacketForwardMiddleware(t *testing.T) {
 		require.NoError(t, err)
 		_, err = testutil.PollForAck(ctx, chainA, chainAHeight, chainAHeight+30, transferTx.Packet)
 		require.NoError(t, err)
-		err = testutil.WaitForBlocks(ctx, 1, chainA)
+		_, err = testutil.WaitForBalance(ctx, chainD, userD.FormattedAddress(), thirdHopIBCDenom, transferAmount, time.Minute)
 		require.NoError(t, err)
 
 		chainABalance, err := chainA.GetBalance(ctx, userA.FormattedAddress(), chainA.Config().Denom)
@@ -272,7 +272,7 @@ func TestPacketForwardMiddleware(t *testing.T) {
 		require.NoError(t, err)
 		_, err = testutil.PollForAck(ctx, chainD, chainDHeight, chainDHeight+30, transferTx.Packet)
 		require.NoError(t, err)
-		err = testutil.WaitForBlocks(ctx, 1, chainA)
+		_, err = testutil.WaitForBalance(ctx, chainA, userA.FormattedAddress(), chainA.Config().Denom, userFunds, time.Minute)
 		require.NoError(t, err)
 
 		// assert balances for user controlled wallets
@@ -335,7 +335,7 @@ func TestPacketForwardMiddleware(t *testing.T) {
 		require.NoError(t, err)
 		_, err = testutil.PollForAck(ctx, chainA, chainAHeight, chainAHeight+25, transferTx.Packet)
 		require.NoError(t, err)
-		err = testutil.WaitForBlocks(ctx, 1, chainA)
+		_, err = testutil.WaitForBalance(ctx, chainA, userA.FormattedAddress(), chainA.Config().Denom, userFunds, time.Minute)
 		require.NoError(t, err)
 
 		// assert balances for user controlled wallets
@@ -392,7 +392,7 @@ func TestPacketForwardMiddleware(t *testing.T) {
 		require.NoError(t, err)
 		_, err = testutil.PollForAck(ctx, chainA, chainAHeight, chainAHeight+25, transferTx.Packet)
 		require.NoError(t, err)
-		err = testutil.WaitForBlocks(ctx, 1, chainA)
+		_, err = testutil.WaitForBalance(ctx, chainA, userA.FormattedAddress(), chainA.Config().Denom, userFunds, time.Minute)
 		require.NoError(t, err)
 
 		// assert balances for user controlled wallets
@@ -461,7 +461,7 @@ func TestPacketForwardMiddleware(t *testing.T) {
 		require.NoError(t, err)
 		_, err = testutil.PollForAck(ctx, chainA, chainAHeight, chainAHeight+30, transferTx.Packet)
 		require.NoError(t, err)
-		err = testutil.WaitForBlocks(ctx, 1, chainA)
+		_, err = testutil.WaitForBalance(ctx, chainA, userA.FormattedAddress(), chainA.Config().Denom, userFunds, time.Minute)
 		require.NoError(t, err)
 
 		// assert balances for user controlled wallets
@@ -527,7 +527,7 @@ func TestPacketForwardMiddleware(t *testing.T) {
 		require.NoError(t, err)
 		_, err = testutil.PollForAck(ctx, chainB, chainBHeight, chainBHeight+10, transferTx.Packet)
 		require.NoError(t, err)
-		err = testutil.WaitForBlocks(ctx, 1, chainB)
+		_, err = testutil.WaitForBalance(ctx, chainA, userA.FormattedAddress(), baIBCDenom, transferAmount, time.Minute)
 		require.NoError(t, err)
 
 		// assert balance for user controlled wallet
@@ -581,7 +581,7 @@ func TestPacketForwardMiddleware(t *testing.T) {
 		require.NoError(t, err)
 		_, err = testutil.PollForAck(ctx, chainA, chainAHeight, chainAHeight+30, transferTx.Packet)
 		require.NoError(t, err)
-		err = testutil.WaitForBlocks(ctx, 1, chainA)
+		_, err = testutil.WaitForBalance(ctx, chainA, userA.FormattedAddress(), baIBCDenom, transferAmount, time.Minute)
 		require.NoError(t, err)
 
 		// assert balances for user controlled wallets
@@ -650,7 +650,7 @@ func TestPacketForwardMiddleware(t *testing.T) {
 		require.NoError(t, err)
 		_, err = testutil.PollForAck(ctx, chainA, chainAHeight, chainAHeight+30, transferTx.Packet)
 		require.NoError(t, err)
-		err = testutil.WaitForBlocks(ctx, 1, chainA)
+		_, err = testutil.WaitForBalance(ctx, chainA, userA.FormattedAddress(), chainA.Config().Denom, userABalance, time.Minute)
 		require.NoError(t, err)
 
 		chainABalance, err := chainA.GetBalance(ctx, userA.FormattedAddress(), chainA.Config().Denom)
@@ -0,0 +1,124 @@
+package ibc_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/relayer"
+	"github.com/strangelove-ventures/interchaintest/v6/relayer/rly"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestPreseededRelayer links two chains with the relayer keys and path checked in under testdata,
+// and asserts that the relayer uses them. The path file has the IDs of clients,
+// which are created before the path is linked, so the relayer links them instead of creating clients.
+func TestPreseededRelayer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	const configDir = "testdata/preseeded-relayer"
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "gaia-a", Version: "v7.0.0", ChainConfig: ibc.ChainConfig{ChainID: "chain-a"}},
+		{Name: "gaia", ChainName: "gaia-b", Version: "v7.0.0", ChainConfig: ibc.ChainConfig{ChainID: "chain-b"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chainA, chainB := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(
+		ibc.CosmosRly, zaptest.NewLogger(t), relayer.PreseededConfigDir(configDir),
+	).Build(t, client, network)
+
+	const pathName = "ab"
+	ic := interchaintest.NewInterchain().
+		AddChain(chainA).
+		AddChain(chainB).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  chainA,
+			Chain2:  chainB,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	preseeded, err := relayer.LoadPreseededConfig(configDir)
+	require.NoError(t, err)
+	path := preseeded.Paths[pathName]
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+		Hooks: interchaintest.BuildHooks{
+			// Create the clients of the path file on another path, as an earlier run would have.
+			AfterRelayerConfigured: func(ctx context.Context, _ []ibc.Relayer) error {
+				home := r.(*rly.CosmosRelayer).HomeDir()
+				for _, cmd := range [][]string{
+					{"rly", "paths", "new", chainA.Config().ChainID, chainB.Config().ChainID, "clients", "--home", home},
+					{"rly", "tx", "clients", "clients", "--home", home},
+				} {
+					if res := r.Exec(ctx, eRep, cmd, nil); res.Err != nil {
+						return res.Err
+					}
+				}
+				return nil
+			},
+		},
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	for _, c := range []ibc.Chain{chainA, chainB} {
+		chainID := c.Config().ChainID
+		mnemonic, err := os.ReadFile(filepath.Join(configDir, "keys", chainID))
+		require.NoError(t, err)
+
+		wallet, ok := r.GetWallet(chainID)
+		require.True(t, ok)
+		require.Equal(t, strings.TrimSpace(string(mnemonic)), wallet.Mnemonic())
+
+		// The preseeded key was funded in genesis.
+		bal, err := c.GetBalance(ctx, wallet.FormattedAddress(), c.Config().Denom)
+		require.NoError(t, err)
+		require.Positive(t, bal)
+	}
+
+	channels, err := r.GetChannels(ctx, eRep, chainA.Config().ChainID)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+
+	// The connection of the path is on the clients of the path file, and no other clients were created.
+	for c, clientID := range map[ibc.Chain]string{chainA: path.SrcClientID, chainB: path.DstClientID} {
+		chainID := c.Config().ChainID
+		require.NotEmpty(t, clientID, chainID)
+
+		clients, err := r.GetClients(ctx, eRep, chainID)
+		require.NoError(t, err)
+		require.Len(t, clients, 1, chainID)
+		require.Equal(t, clientID, clients[0].ClientID)
+
+		connections, err := r.GetConnections(ctx, eRep, chainID)
+		require.NoError(t, err)
+		require.Len(t, connections, 1, chainID)
+		require.Equal(t, clientID, connections[0].ClientID)
+	}
+}
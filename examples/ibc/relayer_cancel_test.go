@@ -0,0 +1,92 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/relayer"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestRelayerCancel asserts that relayer operations return promptly with a context error
+// once their context is cancelled, or once their operation timeout passes.
+func TestRelayerCancel(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.0"},
+		{Name: "osmosis", Version: "v11.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia, osmosis := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(
+		ibc.CosmosRly,
+		zaptest.NewLogger(t),
+		relayer.OperationTimeout(2*time.Minute),
+	).Build(t, client, network)
+
+	const pathName = "gaia-osmo"
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia).
+		AddChain(osmosis).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia,
+			Chain2:  osmosis,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	require.NoError(t, r.GeneratePath(ctx, eRep, gaia.Config().ChainID, osmosis.Config().ChainID, pathName))
+
+	t.Run("cancelled context", func(t *testing.T) {
+		cctx, cancel := context.WithCancel(ctx)
+		time.AfterFunc(time.Second, cancel)
+
+		start := time.Now()
+		err := r.CreateClients(cctx, eRep, pathName, ibc.DefaultClientOpts())
+		require.Less(t, time.Since(start), 30*time.Second, "CreateClients did not return promptly")
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("operation timeout", func(t *testing.T) {
+		impatient := interchaintest.NewBuiltinRelayerFactory(
+			ibc.CosmosRly,
+			zaptest.NewLogger(t),
+			relayer.OperationTimeout(time.Second),
+		).Build(t, client, network)
+
+		start := time.Now()
+		res := impatient.Exec(ctx, eRep, []string{"sleep", "100"}, nil)
+		require.Less(t, time.Since(start), 30*time.Second, "Exec did not return promptly")
+		require.ErrorIs(t, res.Err, context.DeadlineExceeded)
+		require.ErrorContains(t, res.Err, "exceeded timeout of 1s")
+	})
+}
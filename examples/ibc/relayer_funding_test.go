@@ -0,0 +1,160 @@
+package ibc_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestRelayerFunding gives the relayer a small budget on one chain,
+// asserts relaying stops once the budget is spent, and recovers after topping up the relayer wallet.
+func TestRelayerFunding(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "gaia-a", Version: "v7.0.0", ChainConfig: ibc.ChainConfig{ChainID: "chain-a"}},
+		{Name: "gaia", ChainName: "gaia-b", Version: "v7.0.0", ChainConfig: ibc.ChainConfig{ChainID: "chain-b"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chainA, chainB := chains[0], chains[1].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	// Enough to create the path, and little more.
+	const budget = int64(10_000_000)
+
+	const pathName = "ab"
+	ic := interchaintest.NewInterchain().
+		AddChain(chainA).
+		AddChain(chainB).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  chainA,
+			Chain2:  chainB,
+			Relayer: r,
+			Path:    pathName,
+			RelayerFunds: map[ibc.Chain]interchaintest.RelayerFunding{
+				chainB: {Denom: chainB.Config().Denom, Amount: budget},
+			},
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000_000, chainA, chainB)
+	userA, userB := users[0], users[1]
+
+	// Path creation was paid out of the budget.
+	relayerWallet, ok := r.GetWallet(chainB.Config().ChainID)
+	require.True(t, ok)
+	relayerAddr := relayerWallet.FormattedAddress()
+	bal, err := chainB.GetBalance(ctx, relayerAddr, chainB.Config().Denom)
+	require.NoError(t, err)
+	require.Less(t, bal, budget)
+
+	// Spend the rest of the budget with the relayer key, leaving less than one relayer transaction's fee.
+	const drainKey = "relayer-wallet"
+	require.NoError(t, chainB.RecoverKey(ctx, drainKey, relayerWallet.Mnemonic()))
+	drain := func(amount int64) {
+		require.NoError(t, chainB.SendFunds(ctx, drainKey, ibc.WalletAmount{
+			Address: userB.FormattedAddress(),
+			Denom:   chainB.Config().Denom,
+			Amount:  amount,
+		}))
+	}
+	// The first send measures the fee of a send, which determines how much the second one can transfer.
+	drain(1)
+	afterFirst, err := chainB.GetBalance(ctx, relayerAddr, chainB.Config().Denom)
+	require.NoError(t, err)
+	fee := bal - 1 - afterFirst
+	const leftover = int64(500)
+	drain(afterFirst - fee - leftover)
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+	stopped := false
+	t.Cleanup(func() {
+		if !stopped {
+			_ = r.StopRelayer(ctx, eRep)
+		}
+	})
+
+	channel, err := ibc.GetTransferChannel(ctx, r, eRep, chainA.Config().ChainID, chainB.Config().ChainID)
+	require.NoError(t, err)
+
+	tx, err := chainA.SendIBCTransfer(ctx, channel.ChannelID, userA.KeyName(), ibc.WalletAmount{
+		Address: userB.FormattedAddress(),
+		Denom:   chainA.Config().Denom,
+		Amount:  1_000,
+	}, ibc.TransferOptions{})
+	require.NoError(t, err)
+	require.NoError(t, tx.Validate())
+
+	prefixed := transfertypes.GetPrefixedDenom(channel.Counterparty.PortID, channel.Counterparty.ChannelID, chainA.Config().Denom)
+	ibcDenom := transfertypes.ParseDenomTrace(prefixed).IBCDenom()
+
+	// The relayer cannot pay for the packet's delivery to chain B.
+	require.NoError(t, testutil.WaitForBlocks(ctx, 10, chainA, chainB))
+	bal, err = chainB.GetBalance(ctx, userB.FormattedAddress(), ibcDenom)
+	require.NoError(t, err)
+	require.Zero(t, bal)
+	logs := new(relayerLogs)
+	stopped = true
+	require.NoError(t, r.StopRelayer(ctx, logs))
+	require.Contains(t, logs.String(), "insufficient funds")
+
+	// Top up the relayer wallet; relaying resumes where it stopped.
+	require.NoError(t, chainB.SendFunds(ctx, userB.KeyName(), ibc.WalletAmount{
+		Address: relayerAddr,
+		Denom:   chainB.Config().Denom,
+		Amount:  budget,
+	}))
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+	stopped = false
+
+	_, err = testutil.WaitForBalance(ctx, chainB, userB.FormattedAddress(), ibcDenom, 1_000, time.Minute)
+	require.NoError(t, err)
+	h, err := chainA.Height(ctx)
+	require.NoError(t, err)
+	ack, err := testutil.PollForAck(ctx, chainA, tx.Height, h+30, tx.Packet)
+	require.NoError(t, err)
+	require.NoError(t, ack.Validate())
+}
+
+// relayerLogs is an ibc.RelayerExecReporter collecting the output of relayer commands.
+type relayerLogs struct {
+	strings.Builder
+}
+
+func (l *relayerLogs) TrackRelayerExec(_ string, _ []string, stdout, stderr string, _ int, _, _ time.Time, _ error) {
+	l.WriteString(stdout)
+	l.WriteString(stderr)
+}
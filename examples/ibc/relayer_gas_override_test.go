@@ -0,0 +1,106 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/relayer"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestRelayerGasOverride asserts that a relayer whose gas price on a chain is below the minimum gas price of the chain
+// does not relay packets to it, and that it relays them once its gas price is corrected and it is restarted.
+func TestRelayerGasOverride(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.0"},
+		{Name: "osmosis", Version: "v11.0.0", ChainConfig: ibc.ChainConfig{
+			GasPrices: "0.0025uosmo",
+			ConfigFileOverrides: map[string]any{
+				"config/app.toml": testutil.Toml{"minimum-gas-prices": "0.0025uosmo"},
+			},
+		}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia, osmosis := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const ibcPath = "gaia-osmo"
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia).
+		AddChain(osmosis).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia,
+			Chain2:  osmosis,
+			Relayer: r,
+			Path:    ibcPath,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	gr, ok := r.(relayer.GasOverrideRelayer)
+	require.True(t, ok, "relayer does not support gas overrides")
+
+	// The transactions of the relayer on osmosis are now rejected for insufficient fees.
+	require.NoError(t, gr.SetChainGasOverride(ctx, eRep, osmosis.Config().ChainID, "0.0001uosmo", 0))
+	require.NoError(t, r.StartRelayer(ctx, eRep, ibcPath))
+	t.Cleanup(func() {
+		_ = r.StopRelayer(ctx, eRep)
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000, gaia, osmosis)
+	gaiaUser, osmosisUser := users[0], users[1]
+
+	gaiaChannel, err := ibc.GetTransferChannel(ctx, r, eRep, gaia.Config().ChainID, osmosis.Config().ChainID)
+	require.NoError(t, err)
+
+	tx, err := gaia.SendIBCTransfer(ctx, gaiaChannel.ChannelID, gaiaUser.KeyName(), ibc.WalletAmount{
+		Address: osmosisUser.FormattedAddress(),
+		Denom:   gaia.Config().Denom,
+		Amount:  1_000_000,
+	}, ibc.TransferOptions{})
+	require.NoError(t, err)
+	require.NoError(t, tx.Validate())
+
+	require.NoError(t, testutil.WaitForBlocks(ctx, 10, gaia, osmosis))
+	pending, err := r.GetPendingPackets(ctx, eRep, ibcPath, gaiaChannel.ChannelID)
+	require.NoError(t, err)
+	require.Contains(t, pending.SrcPackets, tx.Packet.Sequence, "packet relayed with a gas price below the minimum")
+
+	// Correct the gas price and restart the relayer, which then relays the pending packet.
+	require.NoError(t, r.StopRelayer(ctx, eRep))
+	require.NoError(t, gr.SetChainGasOverride(ctx, eRep, osmosis.Config().ChainID, "0.0025uosmo", 0))
+	require.NoError(t, r.StartRelayer(ctx, eRep, ibcPath))
+
+	h, err := gaia.Height(ctx)
+	require.NoError(t, err)
+	_, err = testutil.PollForAck(ctx, gaia, h, h+30, tx.Packet)
+	require.NoError(t, err)
+}
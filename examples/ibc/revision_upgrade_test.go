@@ -0,0 +1,211 @@
+package ibc_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/relayer/rly"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestRevisionUpgradeTimeoutHeights upgrades gaia from revision 1 to 2, halting it at the height of an ibc-upgrade,
+// upgrading its client on osmosis and restarting it with a new chain ID, and asserts that transfers sent after
+// the upgrade time out at heights of the current revision of their destination chain, and are received.
+func TestRevisionUpgradeTimeoutHeights(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	const (
+		chainID         = "revchain-1"
+		upgradedChainID = "revchain-2"
+		haltHeightDelta = 10
+		pathName        = "gaia-osmo"
+	)
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.0", ChainConfig: ibc.ChainConfig{
+			ChainID:       chainID,
+			ModifyGenesis: cosmos.ModifyGenesisVotingPeriod(10 * time.Second),
+		}},
+		{Name: "osmosis", Version: "v11.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia, osmosis := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia).
+		AddChain(osmosis).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia,
+			Chain2:  osmosis,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000_000, gaia, osmosis)
+	gaiaUser, osmosisUser := users[0], users[1]
+
+	channel, err := ibc.GetTransferChannel(ctx, r, eRep, chainID, osmosis.Config().ChainID)
+	require.NoError(t, err)
+	conns, err := r.GetConnections(ctx, eRep, chainID)
+	require.NoError(t, err)
+	var conn *ibc.ConnectionOutput
+	for _, c := range conns {
+		if c.ID == channel.ConnectionHops[0] {
+			conn = c
+		}
+	}
+	require.NotNil(t, conn, "no connection %s", channel.ConnectionHops[0])
+	gaiaClientID := conn.Counterparty.ClientId
+
+	clientHeight, err := osmosis.QueryClientHeight(ctx, gaiaClientID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), clientHeight.RevisionNumber)
+
+	h, err := gaia.IBCHeight(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), h.RevisionNumber)
+
+	// Halt gaia at the height of an ibc-upgrade, which schedules the upgrade of its client on osmosis to revision 2.
+	haltHeight := h.RevisionHeight + haltHeightDelta
+	prop, err := gaia.IBCUpgradeProposal(ctx, gaiaUser.KeyName(), cosmos.IBCUpgradeProposal{
+		Deposit:         "500000000" + gaia.Config().Denom,
+		Title:           "Revision upgrade",
+		Name:            "revision-2",
+		Description:     "Restart with chain ID " + upgradedChainID,
+		Height:          haltHeight,
+		UpgradedChainID: upgradedChainID,
+	})
+	require.NoError(t, err)
+	require.NoError(t, gaia.VoteOnProposalAllValidators(ctx, prop.ProposalID, cosmos.ProposalVoteYes))
+	_, err = cosmos.PollForProposalStatus(ctx, gaia, prop.Height, haltHeight, prop.ProposalID, cosmos.ProposalStatusPassed)
+	require.NoError(t, err, "upgrade proposal did not pass")
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 45*time.Second)
+	defer cancel()
+	// This times out once the chain halts at the upgrade height.
+	_ = testutil.WaitForBlocks(timeoutCtx, haltHeightDelta+1, gaia)
+	height, err := gaia.Height(ctx)
+	require.NoError(t, err)
+	require.Equal(t, haltHeight, height, "chain did not halt at upgrade height")
+
+	// The halted nodes still serve the proofs of the upgraded client state, by which the relayer upgrades the client.
+	res := r.Exec(ctx, eRep, []string{
+		"rly", "tx", "upgrade-clients", pathName, osmosis.Config().ChainID,
+		"--height", fmt.Sprint(haltHeight),
+		"--home", r.(*rly.CosmosRelayer).HomeDir(),
+	}, nil)
+	require.NoError(t, res.Err)
+
+	clientHeight, err = osmosis.QueryClientHeight(ctx, gaiaClientID)
+	require.NoError(t, err)
+	require.Equal(t, ibc.NewHeight(2, haltHeight-1), clientHeight)
+
+	require.NoError(t, gaia.StopAllNodes(ctx))
+	require.NoError(t, gaia.UpgradeChainID(ctx, upgradedChainID))
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, gaia))
+
+	upgraded, err := gaia.IBCHeight(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), upgraded.RevisionNumber)
+
+	// Relay between the upgraded chain and osmosis over the clients and connection of the previous revision.
+	wallet, ok := r.GetWallet(chainID)
+	require.True(t, ok)
+	require.NoError(t, r.AddChainConfiguration(ctx, eRep, gaia.Config(), wallet.KeyName(), gaia.GetRPCAddress(), gaia.GetGRPCAddress()))
+	require.NoError(t, r.RestoreKey(ctx, eRep, upgradedChainID, wallet.KeyName(), gaia.Config().CoinType, wallet.Mnemonic()))
+	const upgradedPathName = "gaia2-osmo"
+	require.NoError(t, r.GeneratePath(ctx, eRep, upgradedChainID, osmosis.Config().ChainID, upgradedPathName))
+	require.NoError(t, r.UpdatePath(ctx, eRep, upgradedPathName, ibc.PathUpdateOptions{
+		SrcClientID: &conn.ClientID,
+		SrcConnID:   &conn.ID,
+		DstClientID: &conn.Counterparty.ClientId,
+		DstConnID:   &conn.Counterparty.ConnectionId,
+	}))
+	require.NoError(t, r.StartRelayer(ctx, eRep, upgradedPathName))
+	t.Cleanup(func() {
+		_ = r.StopRelayer(ctx, eRep)
+	})
+
+	gaiaVoucher := transfertypes.ParseDenomTrace(
+		transfertypes.GetPrefixedDenom(channel.PortID, channel.ChannelID, osmosis.Config().Denom),
+	).IBCDenom()
+	sendToGaia := func(timeout *ibc.IBCTimeout, expected int64) ibc.Height {
+		tx, err := osmosis.SendIBCTransfer(ctx, channel.Counterparty.ChannelID, osmosisUser.KeyName(), ibc.WalletAmount{
+			Address: gaiaUser.FormattedAddress(),
+			Denom:   osmosis.Config().Denom,
+			Amount:  1_000,
+		}, ibc.TransferOptions{Timeout: timeout})
+		require.NoError(t, err)
+		// A timeout height of revision 1 would be past, and the packet would time out instead.
+		_, err = testutil.WaitForBalance(ctx, gaia, gaiaUser.FormattedAddress(), gaiaVoucher, expected, 2*time.Minute)
+		require.NoError(t, err, "transfer to the upgraded chain was not received")
+		got, err := tx.Packet.ParsedTimeoutHeight()
+		require.NoError(t, err)
+		return got
+	}
+
+	// Relative timeouts of transfers to the upgraded chain are in its revision, past its current height.
+	got := sendToGaia(&ibc.IBCTimeout{Height: 100}, 1_000)
+	require.Equal(t, uint64(2), got.RevisionNumber)
+	require.True(t, upgraded.LT(got), "timeout height %s must be past the height %s of the upgraded chain", got, upgraded)
+
+	clientHeight, err = osmosis.QueryClientHeight(ctx, gaiaClientID)
+	require.NoError(t, err)
+	timeoutHeight := clientHeight.Add(100)
+	got = sendToGaia(&ibc.IBCTimeout{AbsoluteHeight: timeoutHeight}, 2_000)
+	require.Equal(t, timeoutHeight, got)
+
+	// Relative timeouts of transfers from the upgraded chain are in the revision of osmosis.
+	tx, err := gaia.SendIBCTransfer(ctx, channel.ChannelID, gaiaUser.KeyName(), ibc.WalletAmount{
+		Address: osmosisUser.FormattedAddress(),
+		Denom:   gaia.Config().Denom,
+		Amount:  1_000,
+	}, ibc.TransferOptions{Timeout: &ibc.IBCTimeout{Height: 100}})
+	require.NoError(t, err)
+	got, err = tx.Packet.ParsedTimeoutHeight()
+	require.NoError(t, err)
+	require.Equal(t, ibc.ChainIDRevision(osmosis.Config().ChainID), got.RevisionNumber)
+
+	osmosisHeight, err := osmosis.IBCHeight(ctx)
+	require.NoError(t, err)
+	require.True(t, osmosisHeight.LT(got), "timeout height %s must be past the height %s of osmosis", got, osmosisHeight)
+
+	osmosisVoucher := transfertypes.ParseDenomTrace(
+		transfertypes.GetPrefixedDenom(channel.Counterparty.PortID, channel.Counterparty.ChannelID, gaia.Config().Denom),
+	).IBCDenom()
+	_, err = testutil.WaitForBalance(ctx, osmosis, osmosisUser.FormattedAddress(), osmosisVoucher, 1_000, 2*time.Minute)
+	require.NoError(t, err, "transfer from the upgraded chain was not received")
+}
@@ -0,0 +1,89 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/conformance"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestRollupTransfer runs a rollkit rollup with its sequencer, a full node and a mock DA layer,
+// and runs the transfer conformance tests between the rollup and gaia.
+func TestRollupTransfer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	nv := 1
+	nf := 1
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			ChainName: "gm",
+			ChainConfig: ibc.ChainConfig{
+				Type:    "cosmos",
+				ChainID: "gm-1",
+				Images: []ibc.DockerImage{{
+					Repository: "ghcr.io/rollkit/gm",
+					Version:    "v0.3.0",
+					UidGid:     "1025:1025",
+				}},
+				Bin:            "gmd",
+				Bech32Prefix:   "gm",
+				Denom:          "stake",
+				GasPrices:      "0.00stake",
+				GasAdjustment:  1.3,
+				TrustingPeriod: "336h",
+				Rollup: &ibc.RollupConfig{
+					StartFlags: []string{"--rollkit.block_time", "1s"},
+				},
+			},
+			NumValidators: &nv,
+			NumFullNodes:  &nf,
+		},
+		{Name: "gaia", Version: "v7.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gm, gaia := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	rf := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t))
+	r := rf.Build(t, client, network)
+
+	const pathName = "gm-gaia"
+	ic := interchaintest.NewInterchain().
+		AddChain(gm).
+		AddChain(gaia).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gm,
+			Chain2:  gaia,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// The rollup takes part in the transfer conformance tests as any cosmos chain, relaying in both directions.
+	conformance.TestChainPair(t, ctx, client, network, gm, gaia, rf, rep, r, pathName)
+}
@@ -0,0 +1,93 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestRoundTripConservation transfers atoms from gaia to osmosis and back,
+// and asserts that the vouchers on osmosis are burned and the sender's balance is conserved.
+func TestRoundTripConservation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.0"},
+		{Name: "osmosis", Version: "v11.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia, osmosis := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const pathName = "gaia-osmo"
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia).
+		AddChain(osmosis).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia,
+			Chain2:  osmosis,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	})
+
+	// The return transfer is signed on osmosis with the key of the gaia user, which pays its fees there.
+	user := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000, gaia)[0]
+	require.NoError(t, osmosis.RecoverKey(ctx, user.KeyName(), user.Mnemonic()))
+	osmosisAddr, err := types.Bech32ifyAddressBytes(osmosis.Config().Bech32Prefix, user.Address())
+	require.NoError(t, err)
+	require.NoError(t, osmosis.SendFunds(ctx, interchaintest.FaucetAccountKeyName, ibc.WalletAmount{
+		Address: osmosisAddr,
+		Denom:   osmosis.Config().Denom,
+		Amount:  10_000_000,
+	}))
+
+	channel, err := ibc.GetTransferChannel(ctx, r, eRep, gaia.Config().ChainID, osmosis.Config().ChainID)
+	require.NoError(t, err)
+
+	require.NoError(t, testutil.AssertRoundTripConservation(ctx, gaia, osmosis, channel.ChannelID, channel.Counterparty.ChannelID, user, 1_000_000))
+
+	// No other transfers were sent over the channel, so no vouchers of the atoms are left on osmosis.
+	voucher := transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom(channel.Counterparty.PortID, channel.Counterparty.ChannelID, gaia.Config().Denom)).IBCDenom()
+	supply, err := osmosis.GetSupply(ctx, voucher)
+	require.NoError(t, err)
+	require.Zero(t, supply)
+}
@@ -0,0 +1,80 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestExportTopology links two chains and asserts that the exported topology
+// wires the transfer channel ends of both chains to each other.
+func TestExportTopology(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.0"},
+		{Name: "osmosis", Version: "v11.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia, osmosis := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia).
+		AddChain(osmosis).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia,
+			Chain2:  osmosis,
+			Relayer: r,
+			Path:    "gaia-osmo",
+		})
+
+	rep := testreporter.NewNopReporter()
+	require.NoError(t, ic.Build(ctx, rep.RelayerExecReporter(t), interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+	interchaintest.LogTopologyOnFailure(ctx, t, ic)
+
+	topology, err := ic.ExportTopology(ctx)
+	require.NoError(t, err)
+
+	gaiaState, ok := topology.Chain(gaia.Config().ChainID)
+	require.True(t, ok)
+	osmosisState, ok := topology.Chain(osmosis.Config().ChainID)
+	require.True(t, ok)
+
+	require.Len(t, gaiaState.Channels, 1)
+	require.Len(t, osmosisState.Channels, 1)
+	gaiaChannel, osmosisChannel := gaiaState.Channels[0], osmosisState.Channels[0]
+
+	require.Equal(t, ibc.ChannelStateOpen, gaiaChannel.State)
+	require.Equal(t, osmosis.Config().ChainID, gaiaChannel.CounterpartyChainID)
+	require.Equal(t, osmosisChannel.ChannelID, gaiaChannel.CounterpartyChannelID)
+	require.Equal(t, gaia.Config().ChainID, osmosisChannel.CounterpartyChainID)
+	require.Equal(t, gaiaChannel.ChannelID, osmosisChannel.CounterpartyChannelID)
+
+	require.Len(t, gaiaState.Connections, 1)
+	require.Len(t, osmosisState.Connections, 1)
+	require.Equal(t, osmosisState.Connections[0].ID, gaiaState.Connections[0].CounterpartyConnectionID)
+}
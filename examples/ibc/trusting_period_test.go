@@ -0,0 +1,94 @@
+package ibc_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/icza/dyno"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestTrustingPeriodFromUnbonding asserts that clients created without an explicit trusting period
+// get one shorter than a chain's unbonding time, even when the unbonding time was shortened in genesis.
+func TestTrustingPeriodFromUnbonding(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	const unbondingTime = 5 * time.Minute
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "gaia-1", Version: "v7.0.0", ChainConfig: ibc.ChainConfig{
+			ModifyGenesis: modifyGenesisUnbondingTime(unbondingTime),
+		}},
+		{Name: "gaia", ChainName: "gaia-2", Version: "v7.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain1, chain2 := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain1).
+		AddChain(chain2).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  chain1,
+			Chain2:  chain2,
+			Relayer: r,
+			Path:    "gaia-gaia",
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// The client on chain2 tracks chain1, whose unbonding time was shortened.
+	clients, err := r.GetClients(ctx, eRep, chain2.Config().ChainID)
+	require.NoError(t, err)
+	require.Len(t, clients, 1)
+
+	trustingPeriod, err := time.ParseDuration(clients[0].ClientState.TrustingPeriod)
+	require.NoError(t, err)
+	require.Positive(t, trustingPeriod)
+	require.Less(t, trustingPeriod, unbondingTime)
+}
+
+func modifyGenesisUnbondingTime(unbondingTime time.Duration) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(_ ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		g := make(map[string]interface{})
+		if err := json.Unmarshal(genbz, &g); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+		}
+		if err := dyno.Set(g, fmt.Sprintf("%.0fs", unbondingTime.Seconds()), "app_state", "staking", "params", "unbonding_time"); err != nil {
+			return nil, fmt.Errorf("failed to set unbonding time in genesis json: %w", err)
+		}
+		out, err := json.Marshal(g)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+		}
+		return out, nil
+	}
+}
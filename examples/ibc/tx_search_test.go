@@ -0,0 +1,126 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestTxSearch tags transfers with memos and finds them again through the tx search of the chain,
+// as subtests sharing an Interchain can assert their own transfers without balance bookkeeping.
+func TestTxSearch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v8.0.0-rc3", ChainConfig: ibc.ChainConfig{ChainID: "gaia-a", GasPrices: "0.0uatom"}},
+		{Name: "gaia", Version: "v8.0.0-rc3", ChainConfig: ibc.ChainConfig{ChainID: "gaia-b", GasPrices: "0.0uatom"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chainA, chainB := chains[0].(*cosmos.CosmosChain), chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chainA).
+		AddChain(chainB).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  chainA,
+			Chain2:  chainB,
+			Relayer: r,
+			Path:    "a-b",
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000, chainA, chainB)
+	userA, userB := users[0], users[1]
+
+	abChan, err := ibc.GetTransferChannel(ctx, r, eRep, chainA.Config().ChainID, chainB.Config().ChainID)
+	require.NoError(t, err)
+
+	transfer := ibc.WalletAmount{
+		Address: userB.FormattedAddress(),
+		Denom:   chainA.Config().Denom,
+		Amount:  1_000,
+	}
+	var sent []ibc.Tx
+	for _, memo := range []string{"subtest-1", "subtest-2", "subtest-1"} {
+		tx, err := chainA.SendIBCTransfer(ctx, abChan.ChannelID, userA.KeyName(), transfer, ibc.TransferOptions{Memo: memo})
+		require.NoError(t, err)
+		sent = append(sent, tx)
+	}
+
+	packets, err := chainA.FindPacketsByMemo(ctx, "subtest-1")
+	require.NoError(t, err)
+	require.Len(t, packets, 2)
+	require.Equal(t, sent[0].Packet.Sequence, packets[0].Sequence)
+	require.Equal(t, sent[2].Packet.Sequence, packets[1].Sequence)
+
+	// A prefix of a memo does not match.
+	packets, err = chainA.FindPacketsByMemo(ctx, "subtest")
+	require.NoError(t, err)
+	require.Empty(t, packets)
+
+	// The sender's funding transaction is not signed by the sender, so only the transfers are found.
+	txs, err := chainA.FindTxsBySender(ctx, userA.FormattedAddress())
+	require.NoError(t, err)
+	require.Len(t, txs, len(sent))
+	for i, tx := range txs {
+		require.Equal(t, sent[i].TxHash, tx.TxHash)
+		require.Equal(t, int64(sent[i].Height), tx.Height)
+		require.Zero(t, tx.Code)
+	}
+
+	// Pages are concatenated to the same results.
+	var paged []cosmos.TxSearchResult
+	for page := 1; page <= len(sent); page++ {
+		res, err := chainA.TxSearch(ctx, "message.sender='"+userA.FormattedAddress()+"'", page, 1)
+		require.NoError(t, err)
+		paged = append(paged, res...)
+	}
+	require.Equal(t, txs, paged)
+
+	// The send_packet events of the transfers are found by block range.
+	channelQuery := "send_packet.packet_src_channel='" + abChan.ChannelID + "'"
+	inRange, err := chainA.TxSearchRange(ctx, channelQuery, int64(sent[0].Height), int64(sent[len(sent)-1].Height))
+	require.NoError(t, err)
+	require.Equal(t, len(sent), cosmos.CountEvents(inRange, "send_packet"))
+
+	// Blocks after the last transfer have none.
+	after, err := chainA.TxSearchRange(ctx, channelQuery, int64(sent[len(sent)-1].Height)+1, 0)
+	require.NoError(t, err)
+	require.Empty(t, after)
+
+	// Nor do blocks before the first.
+	if sent[0].Height > 1 {
+		before, err := chainA.TxSearchRange(ctx, channelQuery, 1, int64(sent[0].Height)-1)
+		require.NoError(t, err)
+		require.Empty(t, before)
+	}
+}
@@ -0,0 +1,98 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestWasmContractChannel opens a channel between ibc_reflect_send on one juno chain and ibc_reflect on another,
+// and asserts that the remote account ibc_reflect created for the channel is acknowledged to ibc_reflect_send.
+func TestWasmContractChannel(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "juno", ChainName: "juno-1", Version: "v14.1.0"},
+		{Name: "juno", ChainName: "juno-2", Version: "v14.1.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	juno1, juno2 := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const pathName = "juno-juno"
+	ic := interchaintest.NewInterchain().
+		AddChain(juno1).
+		AddChain(juno2).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  juno1,
+			Chain2:  juno2,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), 10_000_000, juno1, juno2)
+	user1, user2 := users[0], users[1]
+
+	contract1, contract2 := instantiateReflectContracts(ctx, t, juno1, juno2, user1.KeyName(), user2.KeyName())
+
+	ch1, ch2, err := cosmos.CreateWasmChannel(ctx, r, eRep, pathName, juno1, juno2, contract1, contract2, reflectVersion, ibc.Ordered)
+	require.NoError(t, err)
+	require.Equal(t, cosmos.WasmPortID(contract1), ch1.PortID)
+	require.Equal(t, cosmos.WasmPortID(contract2), ch2.PortID)
+	require.Equal(t, reflectVersion, ch1.Version)
+	require.Equal(t, ch2.ChannelID, ch1.Counterparty.ChannelID)
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("an error occurred while stopping the relayer: %s", err)
+		}
+	})
+
+	// The channel connect callback of ibc_reflect_send sends the packet asking for the remote account,
+	// which ibc_reflect on juno-2 creates when receiving it.
+	h, err := juno2.Height(ctx)
+	require.NoError(t, err)
+	remote, err := cosmos.PollForContractState(ctx, juno2, h, h+20, contract2, accountQuery(ch2.ChannelID), func(res ibcReflectAccount) bool {
+		return res.Data.Account != ""
+	})
+	require.NoError(t, err)
+
+	// And its acknowledgement reports the account to ibc_reflect_send on juno-1.
+	h, err = juno1.Height(ctx)
+	require.NoError(t, err)
+	local, err := cosmos.PollForContractState(ctx, juno1, h, h+20, contract1, accountQuery(ch1.ChannelID), func(res reflectSendAccount) bool {
+		return res.Data.RemoteAddr != ""
+	})
+	require.NoError(t, err)
+	require.Equal(t, remote.Data.Account, local.Data.RemoteAddr)
+}
@@ -104,7 +104,7 @@ func TestSubstrateToCosmosIBC(t *testing.T) {
 	r := interchaintest.NewBuiltinRelayerFactory(
 		ibc.CosmosRly,
 		zaptest.NewLogger(t),
-		relayer.StartupFlags("-b", "100"),
+		relayer.ProcessorEvents(100),
 		// These two fields are used to pass in a custom Docker image built locally
 		//relayer.ImagePull(false),
 		relayer.CustomDockerImage("ghcr.io/composablefi/relayer", "sub-create-client", "100:1000"),
@@ -0,0 +1,119 @@
+package interchaintest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// genesisChannelExchange passes the genesis state of the two chains of a link with InterchainLink.GenesisChannel
+// between their concurrent starts, so each chain creates its client of the other in its genesis.
+type genesisChannelExchange struct {
+	// ends[i] receives the genesis state of the i-th chain of the link.
+	ends [2]chan cosmos.GenesisLinkEnd
+
+	// If zero, the trusting period is derived from the unbonding periods of both chains.
+	trustingPeriod time.Duration
+}
+
+func newGenesisChannelExchange(trustingPeriod time.Duration) *genesisChannelExchange {
+	return &genesisChannelExchange{
+		ends:           [2]chan cosmos.GenesisLinkEnd{make(chan cosmos.GenesisLinkEnd, 1), make(chan cosmos.GenesisLinkEnd, 1)},
+		trustingPeriod: trustingPeriod,
+	}
+}
+
+// modifier returns the genesis modifier of the i-th chain of the link,
+// which waits for the genesis of the other chain before creating the link in its own genesis.
+func (ex *genesisChannelExchange) modifier(i int) cosmos.GenesisModifier {
+	return func(ctx context.Context, cfg ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		own, err := cosmos.GenesisLinkEndFromGenesis(cfg, genbz)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read genesis state of %s for genesis channel: %w", cfg.ChainID, err)
+		}
+		ex.ends[i] <- own
+
+		var counterparty cosmos.GenesisLinkEnd
+		select {
+		case counterparty = <-ex.ends[1-i]:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for genesis of the counterparty of %s: %w", cfg.ChainID, ctx.Err())
+		}
+
+		trustingPeriod := ex.trustingPeriod
+		if trustingPeriod == 0 {
+			trustingPeriod = genesisTrustingPeriod(own.UnbondingPeriod, counterparty.UnbondingPeriod)
+		}
+		return cosmos.ModifyGenesisLink(counterparty, trustingPeriod)(cfg, genbz)
+	}
+}
+
+// genesisTrustingPeriod returns the trusting period that ClientOptsFromUnbonding
+// derives from chains with the given unbonding periods.
+func genesisTrustingPeriod(unbonding1, unbonding2 time.Duration) time.Duration {
+	minUnbonding := unbonding1
+	if unbonding2 < minUnbonding {
+		minUnbonding = unbonding2
+	}
+	return time.Duration(float64(minUnbonding) * trustingPeriodRatio).Truncate(time.Second)
+}
+
+// validateGenesisChannel panics if link cannot have its channel created in genesis,
+// given the links already added to ic.
+func (ic *Interchain) validateGenesisChannel(link InterchainLink) {
+	for _, c := range []ibc.Chain{link.Chain1, link.Chain2} {
+		if _, ok := c.(*cosmos.CosmosChain); !ok {
+			panic(fmt.Errorf("genesis channel requires cosmos chains, but chain %s is %T", c.Config().ChainID, c))
+		}
+		for _, l := range ic.links {
+			if l.genesisChannel && (l.chains[0] == c || l.chains[1] == c) {
+				panic(fmt.Errorf("chain %s already has a genesis channel", c.Config().ChainID))
+			}
+		}
+	}
+	if link.CreateChannelOpts != (ibc.CreateChannelOptions{}) && link.CreateChannelOpts != ibc.DefaultChannelOpts() {
+		panic(fmt.Errorf("genesis channel only supports the default channel options"))
+	}
+	if link.CreateClientOpts.SrcClientID != "" {
+		panic(fmt.Errorf("genesis channel cannot reuse existing clients"))
+	}
+}
+
+// addGenesisChannels adds the genesis modifiers creating the channels of the links with genesisChannel set
+// to their chains, before the chains start.
+func (ic *Interchain) addGenesisChannels() error {
+	for _, link := range ic.links {
+		if !link.genesisChannel {
+			continue
+		}
+		var trustingPeriod time.Duration
+		if link.createClientOpts.TrustingPeriod != "" {
+			var err error
+			trustingPeriod, err = time.ParseDuration(link.createClientOpts.TrustingPeriod)
+			if err != nil {
+				return fmt.Errorf("invalid trusting period of genesis channel: %w", err)
+			}
+		}
+		ex := newGenesisChannelExchange(trustingPeriod)
+		for i, c := range link.chains {
+			c.(*cosmos.CosmosChain).AddGenesisModifier(ex.modifier(i))
+		}
+	}
+	return nil
+}
+
+// genesisChannelPathUpdate returns the update of a relayer path to the client and connection
+// created in the genesis of both chains of a link with genesisChannel set.
+func genesisChannelPathUpdate() ibc.PathUpdateOptions {
+	opts := ibc.CreateClientOptions{
+		SrcClientID:     cosmos.GenesisLinkClientID,
+		DstClientID:     cosmos.GenesisLinkClientID,
+		SrcConnectionID: cosmos.GenesisLinkConnectionID,
+		DstConnectionID: cosmos.GenesisLinkConnectionID,
+	}
+	upd, _ := opts.ExistingPathUpdate()
+	return upd
+}
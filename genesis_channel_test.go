@@ -0,0 +1,29 @@
+package interchaintest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenesisTrustingPeriod(t *testing.T) {
+	require.Equal(t, 17*time.Hour, genesisTrustingPeriod(20*time.Hour, 21*24*time.Hour))
+	require.Equal(t, 17*time.Hour, genesisTrustingPeriod(21*24*time.Hour, 20*time.Hour))
+}
+
+func TestInterchain_AddLink_GenesisChannel(t *testing.T) {
+	a, b := chainIDChain{id: "a"}, chainIDChain{id: "b"}
+	r := &topologyRelayer{}
+	ic := NewInterchain().AddChain(a).AddChain(b).AddRelayer(r, "r")
+
+	require.PanicsWithError(t, "genesis channel requires cosmos chains, but chain a is interchaintest.chainIDChain", func() {
+		ic.AddLink(InterchainLink{Chain1: a, Chain2: b, Relayer: r, Path: "p", GenesisChannel: true})
+	})
+	require.Empty(t, ic.links)
+
+	// Links without a genesis channel are not validated for it.
+	ic.AddLink(InterchainLink{Chain1: a, Chain2: b, Relayer: r, Path: "p", CreateChannelOpts: ibc.CreateChannelOptions{Version: "v2"}})
+	require.Len(t, ic.links, 1)
+}
@@ -0,0 +1,49 @@
+package ibc
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+// ConvertBech32Prefix returns the bech32 address addr re-encoded with newPrefix,
+// e.g. to render an address on one chain with the prefix of another chain.
+// An error is returned if addr is not a valid bech32 address, including its checksum,
+// as is the case for penumbra and polkadot addresses.
+func ConvertBech32Prefix(addr, newPrefix string) (string, error) {
+	if err := validateBech32Prefix(newPrefix); err != nil {
+		return "", err
+	}
+
+	_, bz, err := bech32.DecodeAndConvert(addr)
+	if err != nil {
+		return "", fmt.Errorf("address %q is not a valid bech32 address: %w", addr, err)
+	}
+
+	converted, err := bech32.ConvertAndEncode(newPrefix, bz)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode address %q with prefix %q: %w", addr, newPrefix, err)
+	}
+	return converted, nil
+}
+
+// WalletAddressWithPrefix returns the address of w rendered with prefix,
+// e.g. a user's address on another chain for use as the receiver of a transfer.
+// See ConvertBech32Prefix.
+func WalletAddressWithPrefix(w Wallet, prefix string) (string, error) {
+	return ConvertBech32Prefix(w.FormattedAddress(), prefix)
+}
+
+// validateBech32Prefix returns an error if prefix cannot be used as the human-readable part of a bech32 address.
+func validateBech32Prefix(prefix string) error {
+	if prefix == "" {
+		return fmt.Errorf("bech32 prefix must not be empty")
+	}
+	for _, c := range prefix {
+		// Uppercase prefixes are valid bech32, but the encoder only produces lowercase addresses.
+		if c < 33 || c > 126 || (c >= 'A' && c <= 'Z') {
+			return fmt.Errorf("invalid character %q in bech32 prefix %q", c, prefix)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package ibc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockWallet struct {
+	Wallet
+
+	addr string
+}
+
+func (w mockWallet) FormattedAddress() string {
+	return w.addr
+}
+
+func TestConvertBech32Prefix(t *testing.T) {
+	const (
+		cosmosAddr = "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu"
+		osmoAddr   = "osmo1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5helwsw"
+	)
+
+	t.Run("happy path", func(t *testing.T) {
+		got, err := ConvertBech32Prefix(cosmosAddr, "osmo")
+		require.NoError(t, err)
+		require.Equal(t, osmoAddr, got)
+
+		got, err = ConvertBech32Prefix(got, "cosmos")
+		require.NoError(t, err)
+		require.Equal(t, cosmosAddr, got)
+	})
+
+	t.Run("uppercase input", func(t *testing.T) {
+		got, err := ConvertBech32Prefix(strings.ToUpper(cosmosAddr), "osmo")
+		require.NoError(t, err)
+		require.Equal(t, osmoAddr, got)
+	})
+
+	t.Run("mixed case input", func(t *testing.T) {
+		_, err := ConvertBech32Prefix("Cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu", "osmo")
+		require.ErrorContains(t, err, "not a valid bech32 address")
+	})
+
+	t.Run("bad checksum", func(t *testing.T) {
+		_, err := ConvertBech32Prefix(cosmosAddr[:len(cosmosAddr)-1]+"q", "osmo")
+		require.ErrorContains(t, err, "not a valid bech32 address")
+	})
+
+	t.Run("non bech32 input", func(t *testing.T) {
+		for _, addr := range []string{
+			"",
+			// Polkadot SS58 address.
+			"5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+			"0x71C7656EC7ab88b098defB751B7401B5f6d8976F",
+		} {
+			_, err := ConvertBech32Prefix(addr, "osmo")
+			require.ErrorContains(t, err, "not a valid bech32 address", addr)
+		}
+	})
+
+	t.Run("invalid prefix", func(t *testing.T) {
+		for _, prefix := range []string{"", "OSMO", "os mo", "osmo\x7f"} {
+			_, err := ConvertBech32Prefix(cosmosAddr, prefix)
+			require.ErrorContains(t, err, "prefix", prefix)
+		}
+	})
+
+	t.Run("wallet", func(t *testing.T) {
+		got, err := WalletAddressWithPrefix(mockWallet{addr: cosmosAddr}, "osmo")
+		require.NoError(t, err)
+		require.Equal(t, osmoAddr, got)
+	})
+}
@@ -0,0 +1,96 @@
+package ibc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultCometMockImage is the image of CometMock used by a ConsensusEngineConfig without an Image.
+var DefaultCometMockImage = DockerImage{
+	Repository: "ghcr.io/informalsystems/cometmock",
+	Version:    "v0.37.x",
+	UidGid:     "1025:1025",
+}
+
+// CometMockProcessName is the sidecar process name of the CometMock consensus engine of a chain.
+const CometMockProcessName = "cometmock"
+
+// CometMockPort is the container port of the RPC server of CometMock, which replaces that of the nodes.
+const CometMockPort = "22331/tcp"
+
+// CometMockABCIPort is the container port on which the chain nodes serve ABCI to CometMock.
+const CometMockABCIPort = "26658/tcp"
+
+// ConsensusEngineConfig runs the nodes of a chain without their consensus engine, against CometMock,
+// a mock consensus engine that signs blocks with the keys of the validators and produces them instantly.
+// The test controls block production, e.g. with (*cosmos.CosmosChain).AdvanceBlocks,
+// and the RPC server of CometMock serves the chain in place of those of the nodes.
+//
+// The chain binary must serve gRPC when started without its consensus engine, as do binaries of SDK v0.47 and newer.
+type ConsensusEngineConfig struct {
+	// Image of CometMock, defaults to DefaultCometMockImage.
+	// Its user must be able to read the validator keys, i.e. have the UidGid of the chain image.
+	Image DockerImage `yaml:"image"`
+	// Interval of the automatic block production.
+	// If zero, blocks are produced only when the test advances the chain and, by default, for each transaction.
+	// testutil.WaitForBlocks then produces the blocks it waits for, but helpers polling the chain up to a height,
+	// such as testutil.PollForAck or testutil.BlockPoller, wait for blocks the test must advance concurrently,
+	// and otherwise block until their context is done.
+	BlockInterval time.Duration `yaml:"block-interval"`
+	// Flags of the start command of the nodes running the app without its consensus engine, serving ABCI over gRPC
+	// on CometMockABCIPort. Defaults to --with-comet=false --transport=grpc --address=tcp://0.0.0.0:26658,
+	// binaries of SDK v0.46 and older need --with-tendermint=false instead.
+	AppFlags []string `yaml:"app-flags"`
+	// Additional flags of CometMock, e.g. --auto-tx=false not to produce a block for each transaction.
+	ExtraFlags []string `yaml:"extra-flags"`
+}
+
+// Clone returns a deep copy of c.
+func (c ConsensusEngineConfig) Clone() ConsensusEngineConfig {
+	x := c
+	if c.AppFlags != nil {
+		x.AppFlags = append([]string(nil), c.AppFlags...)
+	}
+	if c.ExtraFlags != nil {
+		x.ExtraFlags = append([]string(nil), c.ExtraFlags...)
+	}
+	return x
+}
+
+// NodeFlags returns the flags of the start command of the chain nodes, see AppFlags.
+func (c ConsensusEngineConfig) NodeFlags() []string {
+	if c.AppFlags != nil {
+		return append([]string(nil), c.AppFlags...)
+	}
+	port := strings.TrimSuffix(CometMockABCIPort, "/tcp")
+	return []string{"--with-comet=false", "--transport=grpc", "--address=tcp://0.0.0.0:" + port}
+}
+
+// CometMockSidecar returns the sidecar config of CometMock connecting to the apps of the validators at appAddresses,
+// e.g. host:26658, with genesisFile and the validator home directories homeDirs in the container of CometMock.
+func (c ConsensusEngineConfig) CometMockSidecar(appAddresses []string, genesisFile string, homeDirs []string) SidecarConfig {
+	image := c.Image
+	if image.Repository == "" {
+		image = DefaultCometMockImage
+	}
+	// A negative block time disables the automatic block production of CometMock.
+	blockTime := int64(-1)
+	if c.BlockInterval > 0 {
+		blockTime = c.BlockInterval.Milliseconds()
+	}
+	cmd := append([]string{"cometmock", fmt.Sprintf("--block-time=%d", blockTime)}, c.ExtraFlags...)
+	cmd = append(cmd,
+		strings.Join(appAddresses, ","),
+		genesisFile,
+		"tcp://0.0.0.0:"+strings.TrimSuffix(CometMockPort, "/tcp"),
+		strings.Join(homeDirs, ","),
+		"grpc",
+	)
+	return SidecarConfig{
+		ProcessName: CometMockProcessName,
+		Image:       image,
+		StartCmd:    cmd,
+		Ports:       []string{CometMockPort},
+	}
+}
@@ -0,0 +1,44 @@
+package ibc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsensusEngineConfig_NodeFlags(t *testing.T) {
+	var c ConsensusEngineConfig
+	require.Equal(t, []string{"--with-comet=false", "--transport=grpc", "--address=tcp://0.0.0.0:26658"}, c.NodeFlags())
+
+	c.AppFlags = []string{"--with-tendermint=false", "--transport=grpc", "--address=tcp://0.0.0.0:26658"}
+	require.Equal(t, c.AppFlags, c.NodeFlags())
+}
+
+func TestConsensusEngineConfig_CometMockSidecar(t *testing.T) {
+	addrs := []string{"val-0:26658", "val-1:26658"}
+	homes := []string{"/cometmock/val-0", "/cometmock/val-1"}
+	genesis := "/cometmock/val-0/config/genesis.json"
+
+	s := ConsensusEngineConfig{}.CometMockSidecar(addrs, genesis, homes)
+	require.NoError(t, s.Validate())
+	require.Equal(t, CometMockProcessName, s.ProcessName)
+	require.Equal(t, DefaultCometMockImage, s.Image)
+	require.Equal(t, []string{CometMockPort}, s.Ports)
+	require.Equal(t, []string{
+		"cometmock", "--block-time=-1",
+		"val-0:26658,val-1:26658", genesis, "tcp://0.0.0.0:22331", "/cometmock/val-0,/cometmock/val-1", "grpc",
+	}, s.StartCmd)
+
+	c := ConsensusEngineConfig{
+		Image:         DockerImage{Repository: "local-cometmock", Version: "dev"},
+		BlockInterval: 500 * time.Millisecond,
+		ExtraFlags:    []string{"--auto-tx=false"},
+	}
+	s = c.CometMockSidecar(addrs[:1], genesis, homes[:1])
+	require.Equal(t, c.Image, s.Image)
+	require.Equal(t, []string{
+		"cometmock", "--block-time=500", "--auto-tx=false",
+		"val-0:26658", genesis, "tcp://0.0.0.0:22331", "/cometmock/val-0", "grpc",
+	}, s.StartCmd)
+}
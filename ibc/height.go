@@ -0,0 +1,94 @@
+package ibc
+
+import (
+	"fmt"
+
+	clienttypes "github.com/cosmos/ibc-go/v6/modules/core/02-client/types"
+	ibcexported "github.com/cosmos/ibc-go/v6/modules/core/exported"
+)
+
+// Height is a height of a chain as IBC knows it: a block height within a revision of the chain.
+// The revision is that of the chain ID, e.g. 2 for mychain-2, and is bumped by upgrades that reset the block height.
+// Heights of different revisions are ordered by revision first, so a height of revision 2 is past any of revision 1.
+type Height struct {
+	RevisionNumber uint64
+	RevisionHeight uint64
+}
+
+// NewHeight returns the height at block height within revision.
+func NewHeight(revision, height uint64) Height {
+	return Height{RevisionNumber: revision, RevisionHeight: height}
+}
+
+// ChainIDRevision returns the revision number of chainID, e.g. 2 for mychain-2,
+// or 0 if chainID is not of the form {chain-name}-{revision}.
+func ChainIDRevision(chainID string) uint64 {
+	return clienttypes.ParseChainID(chainID)
+}
+
+// HeightForChainID returns the block height of the chain with chainID as an IBC height, in the revision of chainID.
+func HeightForChainID(chainID string, height uint64) Height {
+	return NewHeight(ChainIDRevision(chainID), height)
+}
+
+// ParseHeight parses a height in the form {revision}-{height}, e.g. 1-100, as in packet events.
+func ParseHeight(s string) (Height, error) {
+	h, err := clienttypes.ParseHeight(s)
+	if err != nil {
+		return Height{}, fmt.Errorf("invalid height %q: %w", s, err)
+	}
+	return HeightFromIBC(h), nil
+}
+
+// HeightFromIBC converts a height of ibc-go, e.g. the latest height of a client state.
+func HeightFromIBC(h ibcexported.Height) Height {
+	return NewHeight(h.GetRevisionNumber(), h.GetRevisionHeight())
+}
+
+// ClientHeight converts h to a height of ibc-go, e.g. for the timeout height of a MsgTransfer.
+func (h Height) ClientHeight() clienttypes.Height {
+	return clienttypes.NewHeight(h.RevisionNumber, h.RevisionHeight)
+}
+
+// String returns h in the form {revision}-{height}, as ParseHeight parses.
+func (h Height) String() string {
+	return fmt.Sprintf("%d-%d", h.RevisionNumber, h.RevisionHeight)
+}
+
+// IsZero reports whether h is the zero height, which disables timeout heights.
+func (h Height) IsZero() bool {
+	return h.RevisionNumber == 0 && h.RevisionHeight == 0
+}
+
+// Add returns the height blocks past h, in the same revision.
+func (h Height) Add(blocks uint64) Height {
+	return NewHeight(h.RevisionNumber, h.RevisionHeight+blocks)
+}
+
+// Compare returns -1, 0 or 1 if h is before, equal to or past other.
+func (h Height) Compare(other Height) int {
+	switch {
+	case h.RevisionNumber < other.RevisionNumber:
+		return -1
+	case h.RevisionNumber > other.RevisionNumber:
+		return 1
+	case h.RevisionHeight < other.RevisionHeight:
+		return -1
+	case h.RevisionHeight > other.RevisionHeight:
+		return 1
+	}
+	return 0
+}
+
+// LT reports whether h is before other.
+func (h Height) LT(other Height) bool { return h.Compare(other) < 0 }
+
+// LTE reports whether h is before or equal to other.
+func (h Height) LTE(other Height) bool { return h.Compare(other) <= 0 }
+
+// GT reports whether h is past other.
+func (h Height) GT(other Height) bool { return h.Compare(other) > 0 }
+
+// GTE reports whether h is past or equal to other, e.g. whether a packet with timeout height other
+// times out on a chain at height h.
+func (h Height) GTE(other Height) bool { return h.Compare(other) >= 0 }
@@ -0,0 +1,63 @@
+package ibc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainIDRevision(t *testing.T) {
+	for chainID, want := range map[string]uint64{
+		"cosmoshub-4":   4,
+		"mychain-2":     2,
+		"evmos_9001-2":  2,
+		"gaia":          0,
+		"osmosis-test":  0,
+		"juno-1-rc":     0,
+		"chain-0001-10": 10,
+	} {
+		require.Equal(t, want, ChainIDRevision(chainID), chainID)
+	}
+	require.Equal(t, NewHeight(2, 100), HeightForChainID("mychain-2", 100))
+}
+
+func TestParseHeight(t *testing.T) {
+	h, err := ParseHeight("2-100")
+	require.NoError(t, err)
+	require.Equal(t, NewHeight(2, 100), h)
+	require.Equal(t, "2-100", h.String())
+
+	_, err = ParseHeight("100")
+	require.Error(t, err)
+
+	h, err = Packet{TimeoutHeight: "0-0"}.ParsedTimeoutHeight()
+	require.NoError(t, err)
+	require.True(t, h.IsZero())
+
+	h, err = Packet{}.ParsedTimeoutHeight()
+	require.NoError(t, err)
+	require.True(t, h.IsZero())
+
+	h, err = Packet{TimeoutHeight: "1-42"}.ParsedTimeoutHeight()
+	require.NoError(t, err)
+	require.Equal(t, NewHeight(1, 42), h)
+	require.Equal(t, h, HeightFromIBC(h.ClientHeight()))
+}
+
+func TestHeight_Compare(t *testing.T) {
+	// A height of a later revision is past any height of an earlier one, whatever its block height.
+	before, after := NewHeight(1, 1000), NewHeight(2, 5)
+	require.True(t, before.LT(after))
+	require.True(t, before.LTE(after))
+	require.True(t, after.GT(before))
+	require.True(t, after.GTE(before))
+	require.False(t, after.LT(before))
+
+	require.Equal(t, 0, after.Compare(NewHeight(2, 5)))
+	require.True(t, after.GTE(NewHeight(2, 5)))
+	require.True(t, after.LTE(NewHeight(2, 5)))
+	require.False(t, after.GT(NewHeight(2, 5)))
+
+	require.True(t, after.LT(after.Add(1)))
+	require.Equal(t, NewHeight(2, 15), after.Add(10))
+}
@@ -0,0 +1,64 @@
+package ibc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// WasmHook is the contract call that a chain with the ibc-hooks middleware executes
+// when it receives an ICS-20 transfer with a wasm hook memo.
+type WasmHook struct {
+	Contract string          `json:"contract"`
+	Msg      json.RawMessage `json:"msg"`
+}
+
+// WasmHookMemo returns the transfer memo executing msg on contract upon receipt of the transfer,
+// i.e. {"wasm":{"contract":...,"msg":{...}}}.
+// msg must marshal to a JSON object, such as {"increment":{}}.
+// The receiver of the transfer must be the contract as well.
+func WasmHookMemo(contract string, msg any) (string, error) {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		return "", fmt.Errorf("marshal wasm hook msg: %w", err)
+	}
+	memo, err := json.Marshal(struct {
+		Wasm WasmHook `json:"wasm"`
+	}{WasmHook{Contract: contract, Msg: bz}})
+	if err != nil {
+		return "", fmt.Errorf("marshal wasm hook memo: %w", err)
+	}
+	if _, err := ParseWasmHookMemo(string(memo)); err != nil {
+		return "", err
+	}
+	return string(memo), nil
+}
+
+// ParseWasmHookMemo returns the contract call of a wasm hook memo,
+// returning an error if the memo does not have the shape expected by ibc-hooks.
+func ParseWasmHookMemo(memo string) (WasmHook, error) {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(memo), &m); err != nil {
+		return WasmHook{}, fmt.Errorf("wasm hook memo is not a JSON object: %w", err)
+	}
+	raw, ok := m["wasm"]
+	if !ok {
+		return WasmHook{}, errors.New(`wasm hook memo has no "wasm" key`)
+	}
+
+	var hook WasmHook
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&hook); err != nil {
+		return WasmHook{}, fmt.Errorf(`invalid "wasm" object of wasm hook memo: %w`, err)
+	}
+	if hook.Contract == "" {
+		return WasmHook{}, errors.New("wasm hook memo has no contract")
+	}
+	var msg map[string]json.RawMessage
+	if err := json.Unmarshal(hook.Msg, &msg); err != nil || msg == nil {
+		return WasmHook{}, fmt.Errorf("wasm hook msg %s is not a JSON object", hook.Msg)
+	}
+	return hook, nil
+}
@@ -0,0 +1,46 @@
+package ibc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWasmHookMemo(t *testing.T) {
+	memo, err := WasmHookMemo("osmo1contract", map[string]any{"increment": struct{}{}})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"wasm":{"contract":"osmo1contract","msg":{"increment":{}}}}`, memo)
+
+	hook, err := ParseWasmHookMemo(memo)
+	require.NoError(t, err)
+	require.Equal(t, "osmo1contract", hook.Contract)
+	require.JSONEq(t, `{"increment":{}}`, string(hook.Msg))
+
+	_, err = WasmHookMemo("", map[string]any{"increment": struct{}{}})
+	require.Error(t, err)
+
+	_, err = WasmHookMemo("osmo1contract", "increment")
+	require.Error(t, err)
+}
+
+func TestParseWasmHookMemo(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		memo string
+	}{
+		{"not json", `wasm`},
+		{"not an object", `["wasm"]`},
+		{"no wasm key", `{"forward":{}}`},
+		{"null wasm", `{"wasm":null}`},
+		{"unknown field", `{"wasm":{"contract":"osmo1contract","msg":{},"funds":[]}}`},
+		{"no contract", `{"wasm":{"msg":{}}}`},
+		{"no msg", `{"wasm":{"contract":"osmo1contract"}}`},
+		{"null msg", `{"wasm":{"contract":"osmo1contract","msg":null}}`},
+		{"string msg", `{"wasm":{"contract":"osmo1contract","msg":"{}"}}`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseWasmHookMemo(tt.memo)
+			require.Error(t, err)
+		})
+	}
+}
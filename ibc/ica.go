@@ -0,0 +1,138 @@
+package ibc
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/std"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	icatypes "github.com/cosmos/ibc-go/v6/modules/apps/27-interchain-accounts/types"
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+)
+
+// icaCodec knows the message types commonly executed by interchain accounts.
+var icaCodec = func() *codec.ProtoCodec {
+	registry := codectypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(registry)
+	banktypes.RegisterInterfaces(registry)
+	distrtypes.RegisterInterfaces(registry)
+	govv1.RegisterInterfaces(registry)
+	govv1beta1.RegisterInterfaces(registry)
+	stakingtypes.RegisterInterfaces(registry)
+	transfertypes.RegisterInterfaces(registry)
+	return codec.NewProtoCodec(registry)
+}()
+
+const (
+	// ICAEncodingProtobuf is the ICS-27 channel version encoding for protobuf encoded transactions.
+	ICAEncodingProtobuf = icatypes.EncodingProtobuf
+	// ICAEncodingProto3JSON is the ICS-27 channel version encoding for proto3 JSON encoded transactions.
+	ICAEncodingProto3JSON = "proto3json"
+)
+
+// ICAPacketData is the decoded data of an ICS-27 interchain account packet.
+type ICAPacketData struct {
+	Type icatypes.Type
+	// Encoding of the transaction, either ICAEncodingProtobuf or ICAEncodingProto3JSON.
+	Encoding string
+	Memo     string
+	Msgs     []sdk.Msg
+}
+
+// ICAAcknowledgement is the decoded acknowledgement of an ICS-27 interchain account packet.
+type ICAAcknowledgement struct {
+	// MsgData are the results of the executed messages, populated by hosts before Cosmos SDK v0.46.
+	MsgData []*sdk.MsgData
+	// MsgResponses are the results of the executed messages, populated by hosts since Cosmos SDK v0.46.
+	MsgResponses []*codectypes.Any
+	// Error is set instead of the results if the host failed to execute the transaction.
+	Error string
+}
+
+// Success returns true if the host executed the transaction.
+func (ack ICAAcknowledgement) Success() bool {
+	return ack.Error == ""
+}
+
+// DecodeICAPacket decodes the raw data of an ICS-27 interchain account packet,
+// e.g. Packet.Data of an acknowledged ICA packet.
+// Both protobuf and proto3json encoded transactions are supported.
+// Only messages of the bank, distribution, gov, staking and transfer modules can be decoded.
+func DecodeICAPacket(data []byte) (ICAPacketData, error) {
+	var packetData icatypes.InterchainAccountPacketData
+	if err := icatypes.ModuleCdc.UnmarshalJSON(data, &packetData); err != nil {
+		return ICAPacketData{}, fmt.Errorf("unmarshal interchain account packet data: %w", err)
+	}
+	decoded := ICAPacketData{
+		Type: packetData.Type,
+		Memo: packetData.Memo,
+	}
+	if packetData.Type != icatypes.EXECUTE_TX {
+		return decoded, fmt.Errorf("unsupported interchain account packet type %s", packetData.Type)
+	}
+
+	// A proto3json encoded transaction is a JSON object, which is never valid for the protobuf encoding.
+	if bytes.HasPrefix(bytes.TrimSpace(packetData.Data), []byte("{")) {
+		decoded.Encoding = ICAEncodingProto3JSON
+
+		var cosmosTx icatypes.CosmosTx
+		if err := icaCodec.UnmarshalJSON(packetData.Data, &cosmosTx); err != nil {
+			return decoded, fmt.Errorf("unmarshal cosmos tx json: %w", err)
+		}
+		decoded.Msgs = make([]sdk.Msg, len(cosmosTx.Messages))
+		for i, a := range cosmosTx.Messages {
+			if err := icaCodec.UnpackAny(a, &decoded.Msgs[i]); err != nil {
+				return decoded, fmt.Errorf("unpack message %d: %w", i, err)
+			}
+		}
+		return decoded, nil
+	}
+
+	decoded.Encoding = ICAEncodingProtobuf
+	msgs, err := icatypes.DeserializeCosmosTx(icaCodec, packetData.Data)
+	if err != nil {
+		return decoded, fmt.Errorf("deserialize cosmos tx: %w", err)
+	}
+	decoded.Msgs = msgs
+	return decoded, nil
+}
+
+// DecodeICAPacketData decodes the messages carried by an ICS-27 interchain account packet.
+// See DecodeICAPacket.
+func DecodeICAPacketData(data []byte) ([]sdk.Msg, error) {
+	decoded, err := DecodeICAPacket(data)
+	if err != nil {
+		return nil, err
+	}
+	return decoded.Msgs, nil
+}
+
+// DecodeICAAcknowledgement decodes the raw acknowledgement of an ICS-27 interchain account packet,
+// e.g. PacketAcknowledgement.Acknowledgement.
+func DecodeICAAcknowledgement(ack []byte) (ICAAcknowledgement, error) {
+	var channelAck chantypes.Acknowledgement
+	if err := icaCodec.UnmarshalJSON(ack, &channelAck); err != nil {
+		return ICAAcknowledgement{}, fmt.Errorf("unmarshal acknowledgement: %w", err)
+	}
+
+	if !channelAck.Success() {
+		return ICAAcknowledgement{Error: channelAck.GetError()}, nil
+	}
+
+	var txMsgData sdk.TxMsgData
+	if err := icaCodec.Unmarshal(channelAck.GetResult(), &txMsgData); err != nil {
+		return ICAAcknowledgement{}, fmt.Errorf("unmarshal acknowledgement result: %w", err)
+	}
+	return ICAAcknowledgement{
+		MsgData:      txMsgData.Data,
+		MsgResponses: txMsgData.MsgResponses,
+	}, nil
+}
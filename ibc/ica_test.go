@@ -0,0 +1,110 @@
+package ibc
+
+import (
+	"errors"
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	icatypes "github.com/cosmos/ibc-go/v6/modules/apps/27-interchain-accounts/types"
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeICAPacketData(t *testing.T) {
+	send := &banktypes.MsgSend{
+		FromAddress: "cosmos1ica",
+		ToAddress:   "cosmos1user",
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin("uatom", 10_000)),
+	}
+
+	packetData := func(data []byte) []byte {
+		return icatypes.InterchainAccountPacketData{
+			Type: icatypes.EXECUTE_TX,
+			Data: data,
+		}.GetBytes()
+	}
+
+	t.Run("protobuf", func(t *testing.T) {
+		data, err := icatypes.SerializeCosmosTx(icaCodec, []proto.Message{send})
+		require.NoError(t, err)
+
+		msgs, err := DecodeICAPacketData(packetData(data))
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		require.Equal(t, send, msgs[0])
+
+		decoded, err := DecodeICAPacket(packetData(data))
+		require.NoError(t, err)
+		require.Equal(t, icatypes.EXECUTE_TX, decoded.Type)
+		require.Equal(t, ICAEncodingProtobuf, decoded.Encoding)
+		require.Equal(t, []sdk.Msg{send}, decoded.Msgs)
+	})
+
+	t.Run("proto3json", func(t *testing.T) {
+		a, err := codectypes.NewAnyWithValue(send)
+		require.NoError(t, err)
+		data, err := icaCodec.MarshalJSON(&icatypes.CosmosTx{Messages: []*codectypes.Any{a}})
+		require.NoError(t, err)
+
+		msgs, err := DecodeICAPacketData(packetData(data))
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		require.Equal(t, send, msgs[0])
+
+		decoded, err := DecodeICAPacket(packetData(data))
+		require.NoError(t, err)
+		require.Equal(t, ICAEncodingProto3JSON, decoded.Encoding)
+	})
+
+	t.Run("memo", func(t *testing.T) {
+		data, err := icatypes.SerializeCosmosTx(icaCodec, []proto.Message{send})
+		require.NoError(t, err)
+
+		decoded, err := DecodeICAPacket(icatypes.InterchainAccountPacketData{
+			Type: icatypes.EXECUTE_TX,
+			Data: data,
+			Memo: "memo",
+		}.GetBytes())
+		require.NoError(t, err)
+		require.Equal(t, "memo", decoded.Memo)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := DecodeICAPacketData([]byte("not json"))
+		require.Error(t, err)
+
+		_, err = DecodeICAPacketData(packetData([]byte("garbage")))
+		require.Error(t, err)
+	})
+}
+
+func TestDecodeICAAcknowledgement(t *testing.T) {
+	t.Run("result", func(t *testing.T) {
+		resp, err := codectypes.NewAnyWithValue(&banktypes.MsgSendResponse{})
+		require.NoError(t, err)
+		result, err := proto.Marshal(&sdk.TxMsgData{MsgResponses: []*codectypes.Any{resp}})
+		require.NoError(t, err)
+
+		ack, err := DecodeICAAcknowledgement(chantypes.NewResultAcknowledgement(result).Acknowledgement())
+		require.NoError(t, err)
+		require.True(t, ack.Success())
+		require.Len(t, ack.MsgResponses, 1)
+		require.Equal(t, "/cosmos.bank.v1beta1.MsgSendResponse", ack.MsgResponses[0].TypeUrl)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ack, err := DecodeICAAcknowledgement(chantypes.NewErrorAcknowledgement(errors.New("boom")).Acknowledgement())
+		require.NoError(t, err)
+		require.False(t, ack.Success())
+		require.NotEmpty(t, ack.Error)
+		require.Empty(t, ack.MsgResponses)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := DecodeICAAcknowledgement([]byte("not json"))
+		require.Error(t, err)
+	})
+}
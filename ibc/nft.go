@@ -0,0 +1,22 @@
+package ibc
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+)
+
+// NFTClassTrace returns the class path of an ICS-721 voucher class received through the given destination port and channel.
+// classID is the class path on the sending chain: the base class ID if the class is native to the sender,
+// or its full trace, such as "nft-transfer/channel-0/class", if the sender holds a voucher class itself.
+func NFTClassTrace(destPort, destChannel, classID string) string {
+	return destPort + "/" + destChannel + "/" + classID
+}
+
+// NFTVoucherClassID returns the class ID under which an ICS-721 voucher class is stored on the receiving chain,
+// i.e. "ibc/" followed by the uppercase hex SHA-256 hash of the class trace.
+// See NFTClassTrace for the arguments.
+func NFTVoucherClassID(destPort, destChannel, classID string) string {
+	hash := sha256.Sum256([]byte(NFTClassTrace(destPort, destChannel, classID)))
+	return "ibc/" + strings.ToUpper(fmt.Sprintf("%x", hash))
+}
@@ -0,0 +1,22 @@
+package ibc
+
+import (
+	"testing"
+
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNFTVoucherClassID(t *testing.T) {
+	require.Equal(t, "nft-transfer/channel-3/kitties", NFTClassTrace("nft-transfer", "channel-3", "kitties"))
+
+	// Voucher class IDs are derived the same way as ICS-20 voucher denoms.
+	want := transfertypes.ParseDenomTrace("nft-transfer/channel-3/kitties").IBCDenom()
+	require.Equal(t, want, NFTVoucherClassID("nft-transfer", "channel-3", "kitties"))
+
+	// A voucher class sent onward keeps its full trace.
+	require.Equal(t,
+		"nft-transfer/channel-1/nft-transfer/channel-3/kitties",
+		NFTClassTrace("nft-transfer", "channel-1", NFTClassTrace("nft-transfer", "channel-3", "kitties")),
+	)
+}
@@ -60,6 +60,15 @@ func (packet Packet) Equal(other Packet) bool {
 	return reflect.DeepEqual(packet, other)
 }
 
+// ParsedTimeoutHeight returns the timeout height of the packet, with its revision,
+// or the zero height if the packet has no timeout height.
+func (packet Packet) ParsedTimeoutHeight() (Height, error) {
+	if packet.TimeoutHeight == "" {
+		return Height{}, nil
+	}
+	return ParseHeight(packet.TimeoutHeight)
+}
+
 // PacketAcknowledgement signals the packet was processed and accepted by the counterparty chain.
 // See: https://github.com/cosmos/ibc/blob/52a9094a5bc8c5275e25c19d0b2d9e6fd80ba31c/spec/core/ics-004-channel-and-packet-semantics/README.md#writing-acknowledgements
 type PacketAcknowledgement struct {
@@ -0,0 +1,55 @@
+package ibc
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PoAConfig runs a chain with a proof-of-authority genesis, e.g. a chain built with x/poa or another permissioned
+// validator set: the genesis validators are set in genesis with fixed voting powers, without staking gentxs.
+// The validators with power are also bonded in the staking genesis with the tokens of their powers,
+// as SDK chains, including x/poa chains, which build on x/staking, require the genesis validators to match it.
+type PoAConfig struct {
+	// Voting powers of the genesis validators, one per validator. If empty, every validator has a power of 1.
+	// Validators with no power are left out of the genesis validators and run as non-validating nodes,
+	// but at least one validator must have power.
+	ValidatorPowers []int64 `yaml:"validator-powers"`
+}
+
+// Clone returns a deep copy of c.
+func (c PoAConfig) Clone() PoAConfig {
+	x := c
+	if c.ValidatorPowers != nil {
+		x.ValidatorPowers = append([]int64(nil), c.ValidatorPowers...)
+	}
+	return x
+}
+
+// Powers returns the voting powers of the numValidators genesis validators,
+// or an error if ValidatorPowers does not give every validator a non-negative power, with at least one positive.
+func (c PoAConfig) Powers(numValidators int) ([]int64, error) {
+	if len(c.ValidatorPowers) == 0 {
+		if numValidators == 0 {
+			return nil, errors.New("proof-of-authority chain must have at least one validator")
+		}
+		powers := make([]int64, numValidators)
+		for i := range powers {
+			powers[i] = 1
+		}
+		return powers, nil
+	}
+	if len(c.ValidatorPowers) != numValidators {
+		return nil, fmt.Errorf("got %d validator powers for %d validators", len(c.ValidatorPowers), numValidators)
+	}
+	var bonded bool
+	for i, p := range c.ValidatorPowers {
+		if p < 0 {
+			return nil, fmt.Errorf("power of validator %d must not be negative, got %d", i, p)
+		}
+		bonded = bonded || p > 0
+	}
+	if !bonded {
+		return nil, errors.New("at least one validator must have nonzero power")
+	}
+	return append([]int64(nil), c.ValidatorPowers...), nil
+}
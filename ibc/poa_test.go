@@ -0,0 +1,34 @@
+package ibc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoAConfig_Powers(t *testing.T) {
+	powers, err := PoAConfig{}.Powers(3)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 1, 1}, powers)
+
+	powers, err = PoAConfig{ValidatorPowers: []int64{10, 0, 5}}.Powers(3)
+	require.NoError(t, err)
+	require.Equal(t, []int64{10, 0, 5}, powers)
+
+	for _, tc := range []struct {
+		name          string
+		cfg           PoAConfig
+		numValidators int
+		wantErr       string
+	}{
+		{"no validators", PoAConfig{}, 0, "at least one validator"},
+		{"count mismatch", PoAConfig{ValidatorPowers: []int64{1, 1}}, 3, "got 2 validator powers for 3 validators"},
+		{"negative", PoAConfig{ValidatorPowers: []int64{1, -1}}, 2, "must not be negative"},
+		{"no power", PoAConfig{ValidatorPowers: []int64{0, 0}}, 2, "nonzero power"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.cfg.Powers(tc.numValidators)
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
@@ -41,8 +41,9 @@ type Relayer interface {
 	// setup channels, connections, and clients
 	LinkPath(ctx context.Context, rep RelayerExecReporter, pathName string, channelOpts CreateChannelOptions, clientOptions CreateClientOptions) error
 
-	// update path channel filter
-	UpdatePath(ctx context.Context, rep RelayerExecReporter, pathName string, filter ChannelFilter) error
+	// UpdatePath updates the channel filter and client or connection IDs of a path.
+	// A running relayer may only pick up the changes after it is restarted.
+	UpdatePath(ctx context.Context, rep RelayerExecReporter, pathName string, opts PathUpdateOptions) error
 
 	// update clients, such as after new genesis
 	UpdateClients(ctx context.Context, rep RelayerExecReporter, pathName string) error
@@ -70,6 +71,11 @@ type Relayer interface {
 	// FlushAcknowledgements flushes any outstanding acknowledgements and then returns.
 	FlushAcknowledgements(ctx context.Context, rep RelayerExecReporter, pathName string, channelID string) error
 
+	// GetPendingPackets returns the packet and acknowledgement sequences on the channel
+	// with the given ID on the path's source chain that have not been relayed, in both directions.
+	// Channels in the CLOSED state are reported like any other channel.
+	GetPendingPackets(ctx context.Context, rep RelayerExecReporter, pathName string, channelID string) (PendingPackets, error)
+
 	// CreateClients performs the client handshake steps necessary for creating a light client
 	// on src that tracks the state of dst, and a light client on dst that tracks the state of src.
 	CreateClients(ctx context.Context, rep RelayerExecReporter, pathName string, opts CreateClientOptions) error
@@ -216,6 +222,36 @@ func DefaultChannelOpts() CreateChannelOptions {
 	}
 }
 
+// Port and version of the ibc-go mock module, which acknowledges every packet it receives.
+// The mock module is available in images built from the ibc-go simapp, such as ibc-go-simd.
+const (
+	MockPort    = "mock"
+	MockVersion = "mock-version"
+)
+
+// MockChannelOpts returns the options for creating an unordered channel between the mock modules of both chains.
+func MockChannelOpts() CreateChannelOptions {
+	return CreateChannelOptions{
+		SourcePortName: MockPort,
+		DestPortName:   MockPort,
+		Order:          Unordered,
+		Version:        MockVersion,
+	}
+}
+
+// MockTransferChannelOpts returns the options for creating an unordered channel from the mock module of the source chain
+// to the ICS-20 transfer module of the destination chain. The mock module accepts the transfer version,
+// so that transfers from the destination chain deliver packets to the mock module, which cannot send packets itself.
+// The mock module acknowledges such packets, that lack its mock packet data, with an error acknowledgement.
+func MockTransferChannelOpts() CreateChannelOptions {
+	return CreateChannelOptions{
+		SourcePortName: MockPort,
+		DestPortName:   "transfer",
+		Order:          Unordered,
+		Version:        "ics20-1",
+	}
+}
+
 // Validate will check that the specified CreateChannelOptions are valid.
 func (opts CreateChannelOptions) Validate() error {
 	switch {
@@ -263,6 +299,14 @@ func (o Order) Validate() error {
 // CreateClientOptions contains the configuration for creating a client.
 type CreateClientOptions struct {
 	TrustingPeriod string
+
+	// If set, LinkPath reuses the existing clients with these IDs on the source and destination chains
+	// instead of creating new clients. Both must be set.
+	SrcClientID, DstClientID string
+
+	// If set, LinkPath also reuses the existing open connection between the reused clients with these IDs,
+	// skipping the connection handshake. Both must be set.
+	SrcConnectionID, DstConnectionID string
 }
 
 // DefaultClientOpts returns the default settings for creating clients.
@@ -278,9 +322,53 @@ func (opts CreateClientOptions) Validate() error {
 	if err != nil {
 		return err
 	}
+
+	if (opts.SrcClientID == "") != (opts.DstClientID == "") {
+		return fmt.Errorf("existing clients require both source and destination client IDs")
+	}
+	if opts.SrcClientID != "" {
+		if err := host.ClientIdentifierValidator(opts.SrcClientID); err != nil {
+			return err
+		}
+		if err := host.ClientIdentifierValidator(opts.DstClientID); err != nil {
+			return err
+		}
+	}
+
+	if (opts.SrcConnectionID == "") != (opts.DstConnectionID == "") {
+		return fmt.Errorf("existing connections require both source and destination connection IDs")
+	}
+	if opts.SrcConnectionID != "" {
+		if opts.SrcClientID == "" {
+			return fmt.Errorf("existing connections require the IDs of their existing clients")
+		}
+		if err := host.ConnectionIdentifierValidator(opts.SrcConnectionID); err != nil {
+			return err
+		}
+		if err := host.ConnectionIdentifierValidator(opts.DstConnectionID); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// ExistingPathUpdate returns the PathUpdateOptions setting the existing clients and connections of opts
+// on a relayer path, and false if opts does not reuse existing clients.
+func (opts CreateClientOptions) ExistingPathUpdate() (PathUpdateOptions, bool) {
+	if opts.SrcClientID == "" {
+		return PathUpdateOptions{}, false
+	}
+	upd := PathUpdateOptions{
+		SrcClientID: &opts.SrcClientID,
+		DstClientID: &opts.DstClientID,
+	}
+	if opts.SrcConnectionID != "" {
+		upd.SrcConnID = &opts.SrcConnectionID
+		upd.DstConnID = &opts.DstConnectionID
+	}
+	return upd, true
+}
+
 // ExecReporter is the interface of a narrow type returned by testreporter.RelayerExecReporter.
 // This avoids a direct dependency on the testreporter package,
 // and it avoids the relayer needing to be aware of a *testing.T.
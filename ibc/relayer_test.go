@@ -2,6 +2,7 @@ package ibc
 
 import (
 	"testing"
+	"time"
 
 	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
 	"github.com/stretchr/testify/require"
@@ -12,6 +13,10 @@ func TestChannelOptsConfigured(t *testing.T) {
 	opts := DefaultChannelOpts()
 	require.NoError(t, opts.Validate())
 
+	// Test the mock module channel opts
+	opts = MockChannelOpts()
+	require.NoError(t, opts.Validate())
+
 	// Test empty struct channel opts
 	opts = CreateChannelOptions{}
 	require.Error(t, opts.Validate())
@@ -34,3 +39,56 @@ func TestChannelOptsConfigured(t *testing.T) {
 	}
 	require.Error(t, opts.Validate())
 }
+
+func TestClientOptsExistingClients(t *testing.T) {
+	opts := DefaultClientOpts()
+	require.NoError(t, opts.Validate())
+	_, ok := opts.ExistingPathUpdate()
+	require.False(t, ok)
+
+	opts.SrcClientID, opts.DstClientID = "07-tendermint-0", "07-tendermint-1"
+	require.NoError(t, opts.Validate())
+	upd, ok := opts.ExistingPathUpdate()
+	require.True(t, ok)
+	require.Equal(t, "07-tendermint-0", *upd.SrcClientID)
+	require.Equal(t, "07-tendermint-1", *upd.DstClientID)
+	require.Nil(t, upd.SrcConnID)
+	require.Nil(t, upd.DstConnID)
+
+	opts.SrcConnectionID, opts.DstConnectionID = "connection-0", "connection-2"
+	require.NoError(t, opts.Validate())
+	upd, ok = opts.ExistingPathUpdate()
+	require.True(t, ok)
+	require.Equal(t, "connection-0", *upd.SrcConnID)
+	require.Equal(t, "connection-2", *upd.DstConnID)
+
+	// Test partial or invalid existing clients and connections
+	for _, invalid := range []CreateClientOptions{
+		{TrustingPeriod: "0", SrcClientID: "07-tendermint-0"},
+		{TrustingPeriod: "0", SrcClientID: "07-tendermint-0", DstClientID: "x"},
+		{TrustingPeriod: "0", SrcClientID: "07-tendermint-0", DstClientID: "07-tendermint-1", DstConnectionID: "connection-0"},
+		{TrustingPeriod: "0", SrcConnectionID: "connection-0", DstConnectionID: "connection-1"},
+	} {
+		require.Error(t, invalid.Validate(), invalid)
+	}
+}
+
+func TestClientOutputsByChainID(t *testing.T) {
+	clients := ClientOutputs{
+		{ClientID: "07-tendermint-0", ClientState: ClientState{ChainID: "gaia-1", TrustingPeriod: "1209600s"}},
+		{ClientID: "07-tendermint-1", ClientState: ClientState{ChainID: "osmosis-1", TrustingPeriod: "864000s"}},
+		{ClientID: "07-tendermint-2", ClientState: ClientState{ChainID: "gaia-1", TrustingPeriod: "1209600s"}},
+	}
+
+	byChainID := clients.ByChainID()
+	require.Len(t, byChainID, 2)
+	require.Equal(t, ClientOutputs{clients[0], clients[2]}, byChainID["gaia-1"])
+	require.Equal(t, ClientOutputs{clients[1]}, byChainID["osmosis-1"])
+
+	trustingPeriod, err := byChainID["osmosis-1"][0].ClientState.TrustingPeriodDuration()
+	require.NoError(t, err)
+	require.Equal(t, 240*time.Hour, trustingPeriod)
+
+	_, err = ClientState{ChainID: "gaia-1", TrustingPeriod: "two weeks"}.TrustingPeriodDuration()
+	require.ErrorContains(t, err, "invalid trusting period of client of gaia-1")
+}
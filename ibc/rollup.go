@@ -0,0 +1,74 @@
+package ibc
+
+// DefaultMockDAImage is the mock data availability layer started for a rollup without a DAAddress.
+var DefaultMockDAImage = DockerImage{
+	Repository: "ghcr.io/rollkit/local-da",
+	Version:    "v0.2.1",
+}
+
+// MockDAProcessName is the sidecar process name of the mock data availability layer of a rollup.
+const MockDAProcessName = "mock-da"
+
+// MockDAPort is the container port of the mock data availability layer.
+const MockDAPort = "7980/tcp"
+
+// RollupConfig runs a chain as a rollup, e.g. built with rollkit, instead of a CometBFT validator set:
+// a single sequencer node produces the blocks and posts them to a data availability (DA) layer,
+// and the other nodes are full nodes.
+type RollupConfig struct {
+	// Address of the DA layer, e.g. http://celestia-da:26658.
+	// If empty, a mock DA layer is started as a sidecar of the chain with MockDAImage.
+	DAAddress string `yaml:"da-address"`
+	// Image of the mock DA layer, defaults to DefaultMockDAImage. Unused with a DAAddress.
+	MockDAImage DockerImage `yaml:"mock-da-image"`
+	// Flag of the start command setting the DA address. Defaults to --rollkit.da_address.
+	DAAddressFlag string `yaml:"da-address-flag"`
+	// Flags of the start command of the sequencer only. Defaults to --rollkit.aggregator.
+	SequencerFlags []string `yaml:"sequencer-flags"`
+	// Flags of the start command of every node, e.g. --rollkit.block_time=1s.
+	StartFlags []string `yaml:"start-flags"`
+}
+
+// Clone returns a deep copy of c.
+func (c RollupConfig) Clone() RollupConfig {
+	x := c
+	if c.SequencerFlags != nil {
+		x.SequencerFlags = append([]string(nil), c.SequencerFlags...)
+	}
+	if c.StartFlags != nil {
+		x.StartFlags = append([]string(nil), c.StartFlags...)
+	}
+	return x
+}
+
+// MockDASidecar returns the sidecar config of the mock DA layer of the rollup.
+func (c RollupConfig) MockDASidecar() SidecarConfig {
+	image := c.MockDAImage
+	if image.Repository == "" {
+		image = DefaultMockDAImage
+	}
+	return SidecarConfig{
+		ProcessName: MockDAProcessName,
+		Image:       image,
+		StartCmd:    []string{"local-da", "-listen-all"},
+		Ports:       []string{MockDAPort},
+		PreStart:    true,
+	}
+}
+
+// NodeFlags returns the flags of the start command of a rollup node with the DA layer at daAddress.
+func (c RollupConfig) NodeFlags(daAddress string, sequencer bool) []string {
+	daFlag := c.DAAddressFlag
+	if daFlag == "" {
+		daFlag = "--rollkit.da_address"
+	}
+	flags := []string{daFlag, daAddress}
+	if sequencer {
+		if c.SequencerFlags != nil {
+			flags = append(flags, c.SequencerFlags...)
+		} else {
+			flags = append(flags, "--rollkit.aggregator")
+		}
+	}
+	return append(flags, c.StartFlags...)
+}
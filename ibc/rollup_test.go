@@ -0,0 +1,33 @@
+package ibc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollupConfig_NodeFlags(t *testing.T) {
+	var c RollupConfig
+	require.Equal(t, []string{"--rollkit.da_address", "http://da:7980", "--rollkit.aggregator"}, c.NodeFlags("http://da:7980", true))
+	require.Equal(t, []string{"--rollkit.da_address", "http://da:7980"}, c.NodeFlags("http://da:7980", false))
+
+	c = RollupConfig{
+		DAAddressFlag:  "--da.address",
+		SequencerFlags: []string{"--node.sequencer"},
+		StartFlags:     []string{"--block-time=1s"},
+	}
+	require.Equal(t, []string{"--da.address", "http://da:7980", "--node.sequencer", "--block-time=1s"}, c.NodeFlags("http://da:7980", true))
+	require.Equal(t, []string{"--da.address", "http://da:7980", "--block-time=1s"}, c.NodeFlags("http://da:7980", false))
+}
+
+func TestRollupConfig_MockDASidecar(t *testing.T) {
+	s := RollupConfig{}.MockDASidecar()
+	require.NoError(t, s.Validate())
+	require.Equal(t, MockDAProcessName, s.ProcessName)
+	require.Equal(t, DefaultMockDAImage, s.Image)
+	require.Equal(t, []string{MockDAPort}, s.Ports)
+	require.True(t, s.PreStart)
+
+	image := DockerImage{Repository: "local-da", Version: "dev"}
+	require.Equal(t, image, RollupConfig{MockDAImage: image}.MockDASidecar().Image)
+}
@@ -0,0 +1,54 @@
+package ibc
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SidecarConfig describes an auxiliary process of a chain, run in its own container on the chain's docker network.
+type SidecarConfig struct {
+	// Name of the process, unique among the sidecars of the chain, e.g. price-feeder.
+	ProcessName string `yaml:"process-name"`
+	// Docker image of the process.
+	Image DockerImage `yaml:"image"`
+	// Command starting the process in the image.
+	StartCmd []string `yaml:"start-cmd"`
+	// Environment variables of the process, in the form KEY=VALUE.
+	Env []string `yaml:"env"`
+	// Container ports the process listens on, e.g. 8080/tcp, published on the docker host.
+	Ports []string `yaml:"ports"`
+	// Start the process before the chain nodes, e.g. for a remote signer the nodes depend on.
+	// Otherwise, it starts once the chain produces blocks.
+	PreStart bool `yaml:"pre-start"`
+	// Run one process per validator instead of one for the chain.
+	ValidatorProcess bool `yaml:"validator-process"`
+	// Mount the volume of the validator at its home directory in the process container,
+	// e.g. to read the validator's keys or configuration. Requires ValidatorProcess.
+	ShareNodeVolume bool `yaml:"share-node-volume"`
+}
+
+// Clone returns a deep copy of c.
+func (c SidecarConfig) Clone() SidecarConfig {
+	x := c
+	x.StartCmd = append([]string(nil), c.StartCmd...)
+	x.Env = append([]string(nil), c.Env...)
+	x.Ports = append([]string(nil), c.Ports...)
+	return x
+}
+
+// Validate returns an error if c cannot be run as a sidecar.
+func (c SidecarConfig) Validate() error {
+	if c.ProcessName == "" {
+		return errors.New("sidecar process name cannot be empty")
+	}
+	if c.Image.Repository == "" {
+		return fmt.Errorf("sidecar %s: image repository cannot be empty", c.ProcessName)
+	}
+	if len(c.StartCmd) == 0 {
+		return fmt.Errorf("sidecar %s: start command cannot be empty", c.ProcessName)
+	}
+	if c.ShareNodeVolume && !c.ValidatorProcess {
+		return fmt.Errorf("sidecar %s: sharing the node volume requires a validator process", c.ProcessName)
+	}
+	return nil
+}
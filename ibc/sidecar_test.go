@@ -0,0 +1,45 @@
+package ibc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSidecarConfig_Validate(t *testing.T) {
+	valid := SidecarConfig{
+		ProcessName: "price-feeder",
+		Image:       DockerImage{Repository: "busybox", Version: "stable"},
+		StartCmd:    []string{"httpd", "-f"},
+	}
+	require.NoError(t, valid.Validate())
+
+	shared := valid
+	shared.ValidatorProcess = true
+	shared.ShareNodeVolume = true
+	require.NoError(t, shared.Validate())
+
+	for _, tc := range []struct {
+		name        string
+		modify      func(*SidecarConfig)
+		errContains string
+	}{
+		{name: "no name", modify: func(c *SidecarConfig) { c.ProcessName = "" }, errContains: "name cannot be empty"},
+		{name: "no image", modify: func(c *SidecarConfig) { c.Image = DockerImage{} }, errContains: "image repository cannot be empty"},
+		{name: "no command", modify: func(c *SidecarConfig) { c.StartCmd = nil }, errContains: "start command cannot be empty"},
+		{name: "chain volume", modify: func(c *SidecarConfig) { c.ShareNodeVolume = true }, errContains: "requires a validator process"},
+	} {
+		c := valid.Clone()
+		tc.modify(&c)
+		require.ErrorContains(t, c.Validate(), tc.errContains, tc.name)
+	}
+}
+
+func TestSidecarConfig_Clone(t *testing.T) {
+	c := SidecarConfig{StartCmd: []string{"httpd"}, Env: []string{"A=1"}, Ports: []string{"8080/tcp"}}
+	x := c.Clone()
+	x.StartCmd[0] = "sleep"
+	x.Env[0] = "A=2"
+	x.Ports[0] = "9090/tcp"
+	require.Equal(t, SidecarConfig{StartCmd: []string{"httpd"}, Env: []string{"A=1"}, Ports: []string{"8080/tcp"}}, c)
+}
@@ -0,0 +1,198 @@
+package ibc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ChannelState is the state of a channel end.
+// Its values are the names of the ibc-go proto enum, e.g. "STATE_OPEN";
+// the other spellings of relayers, such as "OPEN" or "Open", are normalized to them.
+type ChannelState string
+
+const (
+	ChannelStateUninitialized ChannelState = "STATE_UNINITIALIZED_UNSPECIFIED"
+	ChannelStateInit          ChannelState = "STATE_INIT"
+	ChannelStateTryOpen       ChannelState = "STATE_TRYOPEN"
+	ChannelStateOpen          ChannelState = "STATE_OPEN"
+	ChannelStateClosed        ChannelState = "STATE_CLOSED"
+
+	// ChannelStateFlushing and ChannelStateFlushComplete are the states of a channel being upgraded,
+	// on chains with ibc-go v8.1 and later.
+	ChannelStateFlushing      ChannelState = "STATE_FLUSHING"
+	ChannelStateFlushComplete ChannelState = "STATE_FLUSHCOMPLETE"
+)
+
+// channelStates are the channel states in the order of their proto enum values.
+var channelStates = []ChannelState{
+	ChannelStateUninitialized, ChannelStateInit, ChannelStateTryOpen, ChannelStateOpen, ChannelStateClosed,
+	ChannelStateFlushing, ChannelStateFlushComplete,
+}
+
+// ParseChannelState returns the channel state spelled s, in any of the spellings of chains and relayers.
+func ParseChannelState(s string) (ChannelState, error) {
+	v, err := parseEnum(s, "STATE_", channelStates)
+	if err != nil {
+		return "", fmt.Errorf("invalid channel state: %w", err)
+	}
+	return v, nil
+}
+
+// ChannelStateFromProto returns the channel state of the ibc-go proto enum value v, e.g. of chantypes.State.
+func ChannelStateFromProto(v int32) (ChannelState, error) {
+	if v < 0 || int(v) >= len(channelStates) {
+		return "", fmt.Errorf("invalid channel state %d", v)
+	}
+	return channelStates[v], nil
+}
+
+func (s ChannelState) String() string {
+	return string(s)
+}
+
+// UnmarshalJSON normalizes the channel state from a string in any spelling, or from its proto enum value.
+func (s *ChannelState) UnmarshalJSON(bz []byte) error {
+	return unmarshalEnum(bz, s, ParseChannelState, ChannelStateFromProto)
+}
+
+// ConnectionState is the state of a connection end.
+// Like ChannelState, its values are the names of the ibc-go proto enum, e.g. "STATE_OPEN".
+type ConnectionState string
+
+const (
+	ConnectionStateUninitialized ConnectionState = "STATE_UNINITIALIZED_UNSPECIFIED"
+	ConnectionStateInit          ConnectionState = "STATE_INIT"
+	ConnectionStateTryOpen       ConnectionState = "STATE_TRYOPEN"
+	ConnectionStateOpen          ConnectionState = "STATE_OPEN"
+)
+
+// connectionStates are the connection states in the order of their proto enum values.
+var connectionStates = []ConnectionState{
+	ConnectionStateUninitialized, ConnectionStateInit, ConnectionStateTryOpen, ConnectionStateOpen,
+}
+
+// ParseConnectionState returns the connection state spelled s, in any of the spellings of chains and relayers.
+func ParseConnectionState(s string) (ConnectionState, error) {
+	v, err := parseEnum(s, "STATE_", connectionStates)
+	if err != nil {
+		return "", fmt.Errorf("invalid connection state: %w", err)
+	}
+	return v, nil
+}
+
+// ConnectionStateFromProto returns the connection state of the ibc-go proto enum value v, e.g. of conntypes.State.
+func ConnectionStateFromProto(v int32) (ConnectionState, error) {
+	if v < 0 || int(v) >= len(connectionStates) {
+		return "", fmt.Errorf("invalid connection state %d", v)
+	}
+	return connectionStates[v], nil
+}
+
+func (s ConnectionState) String() string {
+	return string(s)
+}
+
+// UnmarshalJSON normalizes the connection state from a string in any spelling, or from its proto enum value.
+func (s *ConnectionState) UnmarshalJSON(bz []byte) error {
+	return unmarshalEnum(bz, s, ParseConnectionState, ConnectionStateFromProto)
+}
+
+// ChannelOrdering is the ordering of an existing channel.
+// Like ChannelState, its values are the names of the ibc-go proto enum, e.g. "ORDER_UNORDERED".
+// See Order for the ordering of a channel to create.
+type ChannelOrdering string
+
+const (
+	ChannelOrderingNone      ChannelOrdering = "ORDER_NONE_UNSPECIFIED"
+	ChannelOrderingUnordered ChannelOrdering = "ORDER_UNORDERED"
+	ChannelOrderingOrdered   ChannelOrdering = "ORDER_ORDERED"
+)
+
+// channelOrderings are the channel orderings in the order of their proto enum values.
+var channelOrderings = []ChannelOrdering{ChannelOrderingNone, ChannelOrderingUnordered, ChannelOrderingOrdered}
+
+// ParseChannelOrdering returns the channel ordering spelled s, in any of the spellings of chains and relayers.
+func ParseChannelOrdering(s string) (ChannelOrdering, error) {
+	v, err := parseEnum(s, "ORDER_", channelOrderings)
+	if err != nil {
+		return "", fmt.Errorf("invalid channel ordering: %w", err)
+	}
+	return v, nil
+}
+
+// ChannelOrderingFromProto returns the channel ordering of the ibc-go proto enum value v, e.g. of chantypes.Order.
+func ChannelOrderingFromProto(v int32) (ChannelOrdering, error) {
+	if v < 0 || int(v) >= len(channelOrderings) {
+		return "", fmt.Errorf("invalid channel ordering %d", v)
+	}
+	return channelOrderings[v], nil
+}
+
+func (o ChannelOrdering) String() string {
+	return string(o)
+}
+
+// Order returns the Order to create a channel with ordering o.
+func (o ChannelOrdering) Order() Order {
+	switch o {
+	case ChannelOrderingOrdered:
+		return Ordered
+	case ChannelOrderingUnordered:
+		return Unordered
+	default:
+		return Invalid
+	}
+}
+
+// UnmarshalJSON normalizes the channel ordering from a string in any spelling, or from its proto enum value.
+func (o *ChannelOrdering) UnmarshalJSON(bz []byte) error {
+	return unmarshalEnum(bz, o, ParseChannelOrdering, ChannelOrderingFromProto)
+}
+
+// parseEnum returns the value of values spelled s, ignoring case, underscores, the prefix,
+// and the suffix of unspecified values, so that e.g. "STATE_TRYOPEN", "TRYOPEN", and "TryOpen" are equal.
+func parseEnum[T ~string](s, prefix string, values []T) (T, error) {
+	normalize := func(s string) string {
+		s = strings.ToUpper(strings.TrimSpace(s))
+		s = strings.TrimPrefix(s, prefix)
+		s = strings.TrimSuffix(s, "_UNSPECIFIED")
+		return strings.ReplaceAll(s, "_", "")
+	}
+	n := normalize(s)
+	for _, v := range values {
+		if normalize(string(v)) == n {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("unknown value %q", s)
+}
+
+// unmarshalEnum sets v from the JSON string bz with parse, or from the JSON number bz with fromProto.
+// An empty string leaves v unset.
+func unmarshalEnum[T ~string](bz []byte, v *T, parse func(string) (T, error), fromProto func(int32) (T, error)) error {
+	var n int32
+	if err := json.Unmarshal(bz, &n); err == nil {
+		parsed, err := fromProto(n)
+		if err != nil {
+			return err
+		}
+		*v = parsed
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(bz, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*v = ""
+		return nil
+	}
+	parsed, err := parse(s)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
@@ -0,0 +1,121 @@
+package ibc
+
+import (
+	"encoding/json"
+	"testing"
+
+	conntypes "github.com/cosmos/ibc-go/v6/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v6/modules/core/23-commitment/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelOutput_UnmarshalJSON(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		output   string
+		state    ChannelState
+		ordering ChannelOrdering
+	}{
+		{
+			name:     "rly",
+			output:   `{"state":"STATE_OPEN","ordering":"ORDER_UNORDERED","counterparty":{"port_id":"transfer","channel_id":"channel-1"},"connection_hops":["connection-0"],"version":"ics20-1","port_id":"transfer","channel_id":"channel-0"}`,
+			state:    ChannelStateOpen,
+			ordering: ChannelOrderingUnordered,
+		},
+		{
+			name:     "rly upgrading",
+			output:   `{"state":"STATE_FLUSHCOMPLETE","ordering":"ORDER_ORDERED","counterparty":{"port_id":"transfer","channel_id":"channel-1"},"connection_hops":["connection-0"],"version":"ics20-1","port_id":"transfer","channel_id":"channel-0","upgrade_sequence":"1"}`,
+			state:    ChannelStateFlushComplete,
+			ordering: ChannelOrderingOrdered,
+		},
+		{
+			name:     "hermes",
+			output:   `{"connection_hops":["connection-0"],"counterparty":{"channel_id":"channel-1","port_id":"transfer"},"ordering":"Unordered","state":"TryOpen","version":"ics20-1","port_id":"transfer","channel_id":"channel-0"}`,
+			state:    ChannelStateTryOpen,
+			ordering: ChannelOrderingUnordered,
+		},
+		{
+			name:     "unprefixed",
+			output:   `{"state":"CLOSED","ordering":"ORDERED","port_id":"transfer","channel_id":"channel-0"}`,
+			state:    ChannelStateClosed,
+			ordering: ChannelOrderingOrdered,
+		},
+		{
+			name:     "proto enum values",
+			output:   `{"state":1,"ordering":0,"port_id":"transfer","channel_id":"channel-0"}`,
+			state:    ChannelStateInit,
+			ordering: ChannelOrderingNone,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var ch ChannelOutput
+			require.NoError(t, json.Unmarshal([]byte(tc.output), &ch))
+			require.Equal(t, tc.state, ch.State)
+			require.Equal(t, tc.ordering, ch.Ordering)
+			require.Equal(t, "channel-0", ch.ChannelID)
+		})
+	}
+
+	var ch ChannelOutput
+	require.ErrorContains(t, json.Unmarshal([]byte(`{"state":"STATE_HALF_OPEN"}`), &ch), `invalid channel state: unknown value "STATE_HALF_OPEN"`)
+}
+
+func TestConnectionOutput_UnmarshalJSON(t *testing.T) {
+	for output, want := range map[string]ConnectionState{
+		// rly
+		`{"id":"connection-0","client_id":"07-tendermint-0","state":"STATE_OPEN","delay_period":"0"}`: ConnectionStateOpen,
+		// hermes
+		`{"id":"connection-0","client_id":"07-tendermint-0","state":"Uninitialized"}`: ConnectionStateUninitialized,
+		`{"id":"connection-0","client_id":"07-tendermint-0","state":"TRYOPEN"}`:       ConnectionStateTryOpen,
+		`{"id":"connection-0","client_id":"07-tendermint-0","state":1}`:               ConnectionStateInit,
+	} {
+		var conn ConnectionOutput
+		require.NoError(t, json.Unmarshal([]byte(output), &conn), output)
+		require.Equal(t, want, conn.State, output)
+	}
+
+	var conn ConnectionOutput
+	require.ErrorContains(t, json.Unmarshal([]byte(`{"state":"CLOSED"}`), &conn), "invalid connection state")
+	require.ErrorContains(t, json.Unmarshal([]byte(`{"state":4}`), &conn), "invalid connection state 4")
+}
+
+func TestChannelOutputFromProto(t *testing.T) {
+	ch := chantypes.NewIdentifiedChannel("transfer", "channel-0", chantypes.NewChannel(
+		chantypes.OPEN, chantypes.UNORDERED, chantypes.NewCounterparty("transfer", "channel-1"), []string{"connection-0"}, "ics20-1",
+	))
+	out, err := ChannelOutputFromProto(ch, 42)
+	require.NoError(t, err)
+	require.Equal(t, ChannelOutput{
+		State:          ChannelStateOpen,
+		Ordering:       ChannelOrderingUnordered,
+		Counterparty:   ChannelCounterparty{PortID: "transfer", ChannelID: "channel-1"},
+		ConnectionHops: []string{"connection-0"},
+		Version:        "ics20-1",
+		PortID:         "transfer",
+		ChannelID:      "channel-0",
+		QueryHeight:    42,
+	}, out)
+	require.Equal(t, Unordered, out.Ordering.Order())
+
+	// Channel upgrade states are not in the proto enum of this ibc-go version.
+	ch.State = chantypes.State(5)
+	out, err = ChannelOutputFromProto(ch, 42)
+	require.NoError(t, err)
+	require.Equal(t, ChannelStateFlushing, out.State)
+}
+
+func TestConnectionOutputFromProto(t *testing.T) {
+	conn := conntypes.NewIdentifiedConnection("connection-0", conntypes.NewConnectionEnd(
+		conntypes.TRYOPEN, "07-tendermint-0", conntypes.NewCounterparty("07-tendermint-1", "connection-1", commitmenttypes.NewMerklePrefix([]byte("ibc"))),
+		conntypes.ExportedVersionsToProto(conntypes.GetCompatibleVersions()), 10,
+	))
+	out, err := ConnectionOutputFromProto(conn, 7)
+	require.NoError(t, err)
+	require.Equal(t, "connection-0", out.ID)
+	require.Equal(t, "07-tendermint-0", out.ClientID)
+	require.Equal(t, ConnectionStateTryOpen, out.State)
+	require.Equal(t, "connection-1", out.Counterparty.ConnectionId)
+	require.Equal(t, "10", out.DelayPeriod)
+	require.Equal(t, uint64(7), out.QueryHeight)
+}
@@ -1,11 +1,14 @@
 package ibc
 
 import (
+	"fmt"
 	"reflect"
 	"strconv"
+	"time"
 
 	simappparams "github.com/cosmos/cosmos-sdk/simapp/params"
 	ibcexported "github.com/cosmos/ibc-go/v6/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
 )
 
 // ChainConfig defines the chain parameters requires to run an interchaintest testnet for a chain.
@@ -28,12 +31,88 @@ type ChainConfig struct {
 	CoinType string `default:"118" yaml:"coin-type"`
 	// Minimum gas prices for sending transactions, in native currency denom.
 	GasPrices string `yaml:"gas-prices"`
+	// Gas prices for internal plumbing transactions, such as funding users from the faucet
+	// or validator governance votes, e.g. 0uatom for zero fees.
+	// If empty, GasPrices is used. If the node rejects these gas prices as insufficient,
+	// the transaction is retried with GasPrices.
+	InternalGasPrices string `yaml:"internal-gas-prices"`
 	// Adjustment multiplier for gas fees.
 	GasAdjustment float64 `yaml:"gas-adjustment"`
+	// Multiplier of the current gas price of chains with a fee market module, e.g. the EIP-1559 base fee,
+	// that transactions pay at least per gas, covering increases of the base fee until they are included.
+	// If zero, 1.5 is used; it must not be negative. Used for cosmos chains only.
+	BaseFeeMultiplier float64 `yaml:"base-fee-multiplier"`
+	// Mode transactions are signed with by the tx helpers and the relayer, SignModeDirect or SignModeAminoJSON,
+	// e.g. amino-json for legacy chains without support for SIGN_MODE_DIRECT. If empty, SignModeDirect is used.
+	SignMode string `yaml:"sign-mode"`
+	// Queries per second the query helpers of the chain send to its nodes, shared by all tests using the chain,
+	// not to overwhelm small test chains polled by many goroutines, e.g. 50. If zero or negative, queries are not throttled.
+	// Used for cosmos chains only.
+	QueryRateLimit float64 `yaml:"query-rate-limit"`
 	// Trusting period of the chain.
 	TrustingPeriod string `yaml:"trusting-period"`
 	// Do not use docker host mount.
 	NoHostMount bool `yaml:"no-host-mount"`
+	// Self-delegation amounts of the genesis validators in native currency denom, one per validator,
+	// e.g. to create an unequal voting power distribution. If empty, all validators get equal power.
+	ValidatorSelfDelegations []int64 `yaml:"validator-self-delegations"`
+	// Commission rate of the genesis validators, as a decimal fraction, e.g. 0.05.
+	// If empty, the chain binary's gentx default is used. Used for cosmos chains only.
+	CommissionRate string `yaml:"commission-rate"`
+	// Maximum commission rate the genesis validators can ever charge, as a decimal fraction, e.g. 0.20.
+	// If empty, the chain binary's gentx default is used. Used for cosmos chains only.
+	CommissionMaxRate string `yaml:"commission-max-rate"`
+	// Maximum daily increase of the commission rate of the genesis validators, as a decimal fraction, e.g. 0.01.
+	// If empty, the chain binary's gentx default is used. Used for cosmos chains only.
+	CommissionMaxChangeRate string `yaml:"commission-max-change-rate"`
+	// Minimum self-delegation of the genesis validators in native currency denom,
+	// below which a validator is jailed when unbonding from itself.
+	// If zero, the chain binary's gentx default is used. Used for cosmos chains only.
+	MinSelfDelegation int64 `yaml:"min-self-delegation"`
+	// Additional persistent peers of every node, in the form <node-id>@<host>:<port>,
+	// e.g. to connect the chain to an external node or to another chain with the same ID.
+	AdditionalPeers []string `yaml:"additional-peers"`
+	// Additional hostnames of the chain on the docker network, e.g. chain-a-rpc for tooling expecting a fixed name.
+	// They are set as network aliases of the node serving the chain's RPC and gRPC addresses when the chain starts.
+	// Used for cosmos chains only.
+	NetworkAliases []string `yaml:"network-aliases"`
+	// Genesis balance of the faucet account, from which test users are funded, as amounts per denom,
+	// e.g. to fund many users or contracts in stress tests. If empty, the faucet gets 100T units of Denom.
+	FaucetGenesisBalance map[string]int64 `yaml:"faucet-genesis-balance"`
+	// Non-nil runs the chain nodes with libfaketime, offsetting their clocks by the duration,
+	// e.g. to test timestamp timeouts or client clock drift against a chain whose clock is ahead.
+	// The offset can be changed while the chain is running, see (*cosmos.CosmosChain).SetClockOffset.
+	// The chain image must include libfaketime, and the node binary must read the time through libc,
+	// which Go binaries do not unless built to do so. Start fails otherwise, as do later offset changes.
+	// Used for cosmos chains only.
+	ClockOffset *time.Duration `yaml:"clock-offset"`
+	// Environment variables of every node, in the form KEY=VALUE, e.g. to change the log format
+	// or enable experimental features of the chain binary.
+	// They apply to the node containers, including those recreated for upgrades, and to commands run with Exec.
+	// Used for cosmos chains only.
+	Env []string `yaml:"env"`
+	// Environment variables of single validators, one list per validator index, set after Env on the nodes,
+	// overriding its variables of the same key. Validators without a list get the chain's Env only.
+	// Used for cosmos chains only.
+	ValidatorEnv [][]string `yaml:"validator-env"`
+	// Environment variables of single full nodes, one list per full node index, as ValidatorEnv.
+	// Used for cosmos chains only.
+	FullNodeEnv [][]string `yaml:"full-node-env"`
+	// Auxiliary processes run in their own containers alongside the chain, e.g. a price feeder or a signer daemon.
+	// Used for cosmos chains only.
+	SidecarConfigs []SidecarConfig `yaml:"sidecar-processes"`
+	// Non-nil runs the chain as a rollup with a single sequencer, the only validator,
+	// without gentxs nor a staking validator set. See RollupConfig.
+	// Used for cosmos chains only.
+	Rollup *RollupConfig `yaml:"rollup"`
+	// Non-nil runs the chain with a proof-of-authority genesis, whose validators have fixed voting powers
+	// instead of staking gentxs and self-delegations. See PoAConfig.
+	// Used for cosmos chains only.
+	PoA *PoAConfig `yaml:"poa"`
+	// Non-nil runs the chain against CometMock, a mock consensus engine producing blocks as instructed by the test,
+	// instead of CometBFT. See ConsensusEngineConfig.
+	// Used for cosmos chains only.
+	ConsensusEngine *ConsensusEngineConfig `yaml:"consensus-engine"`
 	// When provided, genesis file contents will be altered before sharing for genesis.
 	ModifyGenesis func(ChainConfig, []byte) ([]byte, error)
 	// Override config parameters for files at filepath.
@@ -47,6 +126,56 @@ func (c ChainConfig) Clone() ChainConfig {
 	images := make([]DockerImage, len(c.Images))
 	copy(images, c.Images)
 	x.Images = images
+	if c.ValidatorSelfDelegations != nil {
+		x.ValidatorSelfDelegations = append([]int64(nil), c.ValidatorSelfDelegations...)
+	}
+	if c.AdditionalPeers != nil {
+		x.AdditionalPeers = append([]string(nil), c.AdditionalPeers...)
+	}
+	if c.NetworkAliases != nil {
+		x.NetworkAliases = append([]string(nil), c.NetworkAliases...)
+	}
+	if c.Env != nil {
+		x.Env = append([]string(nil), c.Env...)
+	}
+	x.ValidatorEnv = cloneEnvs(c.ValidatorEnv)
+	x.FullNodeEnv = cloneEnvs(c.FullNodeEnv)
+	if c.SidecarConfigs != nil {
+		x.SidecarConfigs = make([]SidecarConfig, len(c.SidecarConfigs))
+		for i, sc := range c.SidecarConfigs {
+			x.SidecarConfigs[i] = sc.Clone()
+		}
+	}
+	if c.Rollup != nil {
+		rollup := c.Rollup.Clone()
+		x.Rollup = &rollup
+	}
+	if c.PoA != nil {
+		poa := c.PoA.Clone()
+		x.PoA = &poa
+	}
+	if c.ConsensusEngine != nil {
+		engine := c.ConsensusEngine.Clone()
+		x.ConsensusEngine = &engine
+	}
+	if c.FaucetGenesisBalance != nil {
+		x.FaucetGenesisBalance = make(map[string]int64, len(c.FaucetGenesisBalance))
+		for denom, amount := range c.FaucetGenesisBalance {
+			x.FaucetGenesisBalance[denom] = amount
+		}
+	}
+	return x
+}
+
+// cloneEnvs returns a deep copy of the per-node environments envs.
+func cloneEnvs(envs [][]string) [][]string {
+	if envs == nil {
+		return nil
+	}
+	x := make([][]string, len(envs))
+	for i, env := range envs {
+		x[i] = append([]string(nil), env...)
+	}
 	return x
 }
 
@@ -110,6 +239,22 @@ func (c ChainConfig) MergeChainSpecConfig(other ChainConfig) ChainConfig {
 		c.GasPrices = other.GasPrices
 	}
 
+	if other.InternalGasPrices != "" {
+		c.InternalGasPrices = other.InternalGasPrices
+	}
+
+	if other.BaseFeeMultiplier != 0 {
+		c.BaseFeeMultiplier = other.BaseFeeMultiplier
+	}
+
+	if other.SignMode != "" {
+		c.SignMode = other.SignMode
+	}
+
+	if other.QueryRateLimit != 0 {
+		c.QueryRateLimit = other.QueryRateLimit
+	}
+
 	if other.GasAdjustment > 0 && c.GasAdjustment == 0 {
 		c.GasAdjustment = other.GasAdjustment
 	}
@@ -120,6 +265,80 @@ func (c ChainConfig) MergeChainSpecConfig(other ChainConfig) ChainConfig {
 
 	// Skip NoHostMount so that false can be distinguished.
 
+	if len(other.ValidatorSelfDelegations) > 0 {
+		c.ValidatorSelfDelegations = append([]int64(nil), other.ValidatorSelfDelegations...)
+	}
+
+	if other.CommissionRate != "" {
+		c.CommissionRate = other.CommissionRate
+	}
+
+	if other.CommissionMaxRate != "" {
+		c.CommissionMaxRate = other.CommissionMaxRate
+	}
+
+	if other.CommissionMaxChangeRate != "" {
+		c.CommissionMaxChangeRate = other.CommissionMaxChangeRate
+	}
+
+	if other.MinSelfDelegation > 0 {
+		c.MinSelfDelegation = other.MinSelfDelegation
+	}
+
+	if len(other.AdditionalPeers) > 0 {
+		c.AdditionalPeers = append([]string(nil), other.AdditionalPeers...)
+	}
+
+	if len(other.NetworkAliases) > 0 {
+		c.NetworkAliases = append([]string(nil), other.NetworkAliases...)
+	}
+
+	if len(other.FaucetGenesisBalance) > 0 {
+		c.FaucetGenesisBalance = make(map[string]int64, len(other.FaucetGenesisBalance))
+		for denom, amount := range other.FaucetGenesisBalance {
+			c.FaucetGenesisBalance[denom] = amount
+		}
+	}
+
+	if other.ClockOffset != nil {
+		offset := *other.ClockOffset
+		c.ClockOffset = &offset
+	}
+
+	if len(other.Env) > 0 {
+		c.Env = append([]string(nil), other.Env...)
+	}
+
+	if len(other.ValidatorEnv) > 0 {
+		c.ValidatorEnv = cloneEnvs(other.ValidatorEnv)
+	}
+
+	if len(other.FullNodeEnv) > 0 {
+		c.FullNodeEnv = cloneEnvs(other.FullNodeEnv)
+	}
+
+	if len(other.SidecarConfigs) > 0 {
+		c.SidecarConfigs = make([]SidecarConfig, len(other.SidecarConfigs))
+		for i, sc := range other.SidecarConfigs {
+			c.SidecarConfigs[i] = sc.Clone()
+		}
+	}
+
+	if other.Rollup != nil {
+		rollup := other.Rollup.Clone()
+		c.Rollup = &rollup
+	}
+
+	if other.PoA != nil {
+		poa := other.PoA.Clone()
+		c.PoA = &poa
+	}
+
+	if other.ConsensusEngine != nil {
+		engine := other.ConsensusEngine.Clone()
+		c.ConsensusEngine = &engine
+	}
+
 	if other.ModifyGenesis != nil {
 		c.ModifyGenesis = other.ModifyGenesis
 	}
@@ -150,6 +369,12 @@ func (c ChainConfig) IsFullyConfigured() bool {
 		c.TrustingPeriod != ""
 }
 
+// Sign modes of ChainConfig, as named by the --sign-mode flag of cosmos chain binaries.
+const (
+	SignModeDirect    = "direct"
+	SignModeAminoJSON = "amino-json"
+)
+
 type DockerImage struct {
 	Repository string `yaml:"repository"`
 	Version    string `yaml:"version"`
@@ -171,9 +396,16 @@ type WalletAmount struct {
 	Amount  int64
 }
 
+// IBCTimeout is the timeout of an IBC transfer. The first of NanoSeconds, AbsoluteHeight and Height that is set applies.
 type IBCTimeout struct {
+	// NanoSeconds after it is sent that the packet times out.
 	NanoSeconds uint64
-	Height      uint64
+	// Height is the number of blocks of the counterparty chain, past its latest height known to the client
+	// of the channel, after which the packet times out. The timeout height is in the revision of that latest height.
+	Height uint64
+	// AbsoluteHeight is the height of the counterparty chain at which the packet times out,
+	// e.g. HeightForChainID(counterpartyChainID, h) for a height h of the current revision of the counterparty.
+	AbsoluteHeight Height
 }
 
 type ChannelCounterparty struct {
@@ -181,14 +413,68 @@ type ChannelCounterparty struct {
 	ChannelID string `json:"channel_id"`
 }
 
+// ChannelOutput represents a channel end, as reported by a relayer or queried from a chain.
 type ChannelOutput struct {
-	State          string              `json:"state"`
-	Ordering       string              `json:"ordering"`
+	State          ChannelState        `json:"state"`
+	Ordering       ChannelOrdering     `json:"ordering"`
 	Counterparty   ChannelCounterparty `json:"counterparty"`
 	ConnectionHops []string            `json:"connection_hops"`
 	Version        string              `json:"version"`
 	PortID         string              `json:"port_id"`
 	ChannelID      string              `json:"channel_id"`
+
+	// UpgradeSequence is the number of upgrades started on the channel, on chains with ibc-go v8.1 and later.
+	// While an upgrade is in progress, State is ChannelStateFlushing or ChannelStateFlushComplete until the channel is open again.
+	UpgradeSequence uint64 `json:"upgrade_sequence,string,omitempty"`
+
+	// QueryHeight is the height of the chain at which the channel was queried.
+	// It is only set for channels queried from a chain, relayers do not report it.
+	QueryHeight uint64 `json:"-"`
+}
+
+// ChannelOutputFromProto returns the channel end ch of the chain state at queryHeight.
+func ChannelOutputFromProto(ch chantypes.IdentifiedChannel, queryHeight uint64) (ChannelOutput, error) {
+	state, err := ChannelStateFromProto(int32(ch.State))
+	if err != nil {
+		return ChannelOutput{}, err
+	}
+	ordering, err := ChannelOrderingFromProto(int32(ch.Ordering))
+	if err != nil {
+		return ChannelOutput{}, err
+	}
+	return ChannelOutput{
+		State:    state,
+		Ordering: ordering,
+		Counterparty: ChannelCounterparty{
+			PortID:    ch.Counterparty.PortId,
+			ChannelID: ch.Counterparty.ChannelId,
+		},
+		ConnectionHops: ch.ConnectionHops,
+		Version:        ch.Version,
+		PortID:         ch.PortId,
+		ChannelID:      ch.ChannelId,
+		QueryHeight:    queryHeight,
+	}, nil
+}
+
+// PendingPackets contains the sequences on a channel that a relayer has not relayed yet.
+// Src and Dst refer to the source and destination chains of the relayer path.
+type PendingPackets struct {
+	// Packets committed on the chain that have not been received by the counterparty.
+	SrcPackets []uint64
+	DstPackets []uint64
+	// Acknowledgements written on the chain that have not been relayed back to the sender.
+	SrcAcks []uint64
+	DstAcks []uint64
+}
+
+// Empty reports whether there is nothing left to relay.
+func (p PendingPackets) Empty() bool {
+	return len(p.SrcPackets) == 0 && len(p.DstPackets) == 0 && len(p.SrcAcks) == 0 && len(p.DstAcks) == 0
+}
+
+func (p PendingPackets) String() string {
+	return fmt.Sprintf("src packets: %v, dst packets: %v, src acks: %v, dst acks: %v", p.SrcPackets, p.DstPackets, p.SrcAcks, p.DstAcks)
 }
 
 // ConnectionOutput represents the IBC connection information queried from a chain's state for a particular connection.
@@ -196,9 +482,31 @@ type ConnectionOutput struct {
 	ID           string                    `json:"id,omitempty" yaml:"id"`
 	ClientID     string                    `json:"client_id,omitempty" yaml:"client_id"`
 	Versions     []*ibcexported.Version    `json:"versions,omitempty" yaml:"versions"`
-	State        string                    `json:"state,omitempty" yaml:"state"`
+	State        ConnectionState           `json:"state,omitempty" yaml:"state"`
 	Counterparty *ibcexported.Counterparty `json:"counterparty" yaml:"counterparty"`
 	DelayPeriod  string                    `json:"delay_period,omitempty" yaml:"delay_period"`
+
+	// QueryHeight is the height of the chain at which the connection was queried.
+	// It is only set for connections queried from a chain, relayers do not report it.
+	QueryHeight uint64 `json:"-" yaml:"-"`
+}
+
+// ConnectionOutputFromProto returns the connection end conn of the chain state at queryHeight.
+func ConnectionOutputFromProto(conn ibcexported.IdentifiedConnection, queryHeight uint64) (*ConnectionOutput, error) {
+	state, err := ConnectionStateFromProto(int32(conn.State))
+	if err != nil {
+		return nil, err
+	}
+	counterparty := conn.Counterparty
+	return &ConnectionOutput{
+		ID:           conn.Id,
+		ClientID:     conn.ClientId,
+		Versions:     conn.Versions,
+		State:        state,
+		Counterparty: &counterparty,
+		DelayPeriod:  strconv.FormatUint(conn.DelayPeriod, 10),
+		QueryHeight:  queryHeight,
+	}, nil
 }
 
 type ConnectionOutputs []*ConnectionOutput
@@ -209,11 +517,31 @@ type ClientOutput struct {
 }
 
 type ClientState struct {
-	ChainID string `json:"chain_id"`
+	ChainID        string `json:"chain_id"`
+	TrustingPeriod string `json:"trusting_period"`
+}
+
+// TrustingPeriodDuration parses TrustingPeriod, e.g. "1209600s" as reported by the relayer.
+func (s ClientState) TrustingPeriodDuration() (time.Duration, error) {
+	d, err := time.ParseDuration(s.TrustingPeriod)
+	if err != nil {
+		return 0, fmt.Errorf("invalid trusting period of client of %s: %w", s.ChainID, err)
+	}
+	return d, nil
 }
 
 type ClientOutputs []*ClientOutput
 
+// ByChainID groups the clients of a chain by the chain ID of the counterparty chain each client tracks,
+// e.g. to assert that a single client was created per counterparty.
+func (o ClientOutputs) ByChainID() map[string]ClientOutputs {
+	m := make(map[string]ClientOutputs)
+	for _, c := range o {
+		m[c.ClientState.ChainID] = append(m[c.ClientState.ChainID], c)
+	}
+	return m
+}
+
 type Wallet interface {
 	KeyName() string
 	FormattedAddress() string
@@ -234,3 +562,21 @@ type ChannelFilter struct {
 	Rule        string
 	ChannelList []string
 }
+
+// Channel filter rules.
+const (
+	// ChannelFilterAllowlist relays only the channels in the filter's ChannelList.
+	ChannelFilterAllowlist = "allowlist"
+	// ChannelFilterDenylist relays all channels except the ones in the filter's ChannelList.
+	ChannelFilterDenylist = "denylist"
+)
+
+// PathUpdateOptions contains the fields of a relayer path to update.
+// Nil fields are left unchanged.
+type PathUpdateOptions struct {
+	ChannelFilter *ChannelFilter
+	SrcClientID   *string
+	SrcConnID     *string
+	DstClientID   *string
+	DstConnID     *string
+}
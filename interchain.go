@@ -3,9 +3,14 @@ package interchaintest
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
+	"github.com/cosmos/cosmos-sdk/types"
 	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/relayer"
 	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -28,6 +33,9 @@ type Interchain struct {
 	// Set to true after Build is called once.
 	built bool
 
+	// Set to true once Build starts creating the clients, connections and channels of the links.
+	linked bool
+
 	// Map of relayer-chain pairs to address and mnemonic, set during Build().
 	// Not yet exposed through any exported API.
 	relayerWallets map[relayerChain]ibc.Wallet
@@ -35,21 +43,37 @@ type Interchain struct {
 	// Map of chain to additional genesis wallets to include at chain start.
 	AdditionalGenesisWallets map[ibc.Chain][]ibc.WalletAmount
 
+	// Map of relayer-chain pairs to the genesis funding of the relayer wallet,
+	// set through InterchainLink.RelayerFunds.
+	relayerFunds map[relayerChain]RelayerFunding
+
 	// Set during Build and cleaned up in the Close method.
 	cs *chainSet
+
+	// Set during Build if InterchainBuildOptions.OnContainerExit is set, and called in the Close method.
+	stopWatchdog func()
+
+	// Set during Build from InterchainBuildOptions.ChainDockerHosts.
+	dockerHosts map[ibc.Chain]DockerHost
+
+	// Reporter passed to Build, which the Close method records the query stats of the chains to.
+	rep *testreporter.RelayerExecReporter
 }
 
 type interchainLink struct {
 	chains [2]ibc.Chain
 	// If set, these options will be used when creating the client in the path link step.
-	// If a zero value initialization is used, e.g. CreateClientOptions{},
-	// then the default values will be used via ibc.DefaultClientOpts.
+	// If the trusting period is empty, e.g. with a zero value CreateClientOptions{},
+	// then the trusting period is derived with ClientOptsFromUnbonding.
 	createClientOpts ibc.CreateClientOptions
 
 	// If set, these options will be used when creating the channel in the path link step.
 	// If a zero value initialization is used, e.g. CreateChannelOptions{},
 	// then the default values will be used via ibc.DefaultChannelOpts.
 	createChannelOpts ibc.CreateChannelOptions
+
+	// If set, the clients, connection, and channel of the link are created in the genesis of the chains.
+	genesisChannel bool
 }
 
 // NewInterchain returns a new Interchain.
@@ -144,16 +168,40 @@ type InterchainLink struct {
 	Path string
 
 	// If set, these options will be used when creating the client in the path link step.
-	// If a zero value initialization is used, e.g. CreateClientOptions{},
-	// then the default values will be used via ibc.DefaultClientOpts.
+	// If the trusting period is empty, e.g. with a zero value CreateClientOptions{},
+	// then the trusting period is derived with ClientOptsFromUnbonding.
 	CreateClientOpts ibc.CreateClientOptions
 
 	// If set, these options will be used when creating the channel in the path link step.
 	// If a zero value initialization is used, e.g. CreateChannelOptions{},
 	// then the default values will be used via ibc.DefaultChannelOpts.
 	CreateChannelOpts ibc.CreateChannelOptions
+
+	// If set, overrides the genesis funding of the relayer wallet on Chain1 or Chain2.
+	// Chains without an entry use DefaultRelayerFunding for the chain's denom.
+	// Links sharing a relayer and a chain must not specify different funding.
+	RelayerFunds map[ibc.Chain]RelayerFunding
+
+	// If set, the client, open connection, and open transfer channel of the link are created in the genesis
+	// of both chains instead of through handshakes, so the channel is open from the first block.
+	// Build then only points the relayer path at them, with the IDs of cosmos.ModifyGenesisLink.
+	// Both chains must be cosmos chains without another genesis channel,
+	// and CreateChannelOpts must be empty or ibc.DefaultChannelOpts.
+	GenesisChannel bool
 }
 
+// RelayerFunding is the genesis balance of a relayer wallet on a chain.
+// Chains needing a non-staking fee denom, or a larger budget for high gas prices,
+// can set it through InterchainLink.RelayerFunds.
+type RelayerFunding struct {
+	Denom  string
+	Amount int64
+}
+
+// DefaultRelayerFunding is the amount of the chain's denom given to every relayer wallet
+// without a RelayerFunds entry.
+const DefaultRelayerFunding = int64(1_000_000_000_000)
+
 // AddLink adds the given link to the Interchain.
 // If any validation fails, AddLink panics.
 func (ic *Interchain) AddLink(link InterchainLink) *Interchain {
@@ -182,10 +230,36 @@ func (ic *Interchain) AddLink(link InterchainLink) *Interchain {
 		panic(fmt.Errorf("relayer %q already has a path named %q", key.Relayer, key.Path))
 	}
 
+	for c, funds := range link.RelayerFunds {
+		if c != link.Chain1 && c != link.Chain2 {
+			panic(fmt.Errorf("relayer funds for chain %s, which is not part of the link", c.Config().ChainID))
+		}
+		if err := types.ValidateDenom(funds.Denom); err != nil {
+			panic(fmt.Errorf("invalid relayer funds denom for chain %s: %w", c.Config().ChainID, err))
+		}
+		if funds.Amount <= 0 {
+			panic(fmt.Errorf("relayer funds amount for chain %s must be positive, got %d", c.Config().ChainID, funds.Amount))
+		}
+
+		rc := relayerChain{R: link.Relayer, C: c}
+		if existing, ok := ic.relayerFunds[rc]; ok && existing != funds {
+			panic(fmt.Errorf("conflicting relayer funds for relayer %q on chain %s: %v and %v", ic.relayers[link.Relayer], c.Config().ChainID, existing, funds))
+		}
+		if ic.relayerFunds == nil {
+			ic.relayerFunds = make(map[relayerChain]RelayerFunding)
+		}
+		ic.relayerFunds[rc] = funds
+	}
+
+	if link.GenesisChannel {
+		ic.validateGenesisChannel(link)
+	}
+
 	ic.links[key] = interchainLink{
 		chains:            [2]ibc.Chain{link.Chain1, link.Chain2},
 		createChannelOpts: link.CreateChannelOpts,
 		createClientOpts:  link.CreateClientOpts,
+		genesisChannel:    link.GenesisChannel,
 	}
 	return ic
 }
@@ -197,6 +271,10 @@ type InterchainBuildOptions struct {
 	Client    *client.Client
 	NetworkID string
 
+	// Optional docker hosts of chains that do not run on Client, e.g. to split a large topology across machines.
+	// The relayers, on Client, reach these chains through their host addresses, see DockerHost.
+	ChainDockerHosts map[ibc.Chain]DockerHost
+
 	// If set, ic.Build does not create paths or links in the relayer,
 	// but it does still configure keys and wallets for declared relayer-chain links.
 	// This is useful for tests that need lower-level access to configuring relayers.
@@ -208,8 +286,46 @@ type InterchainBuildOptions struct {
 
 	// If set, saves block history to a sqlite3 database to aid debugging.
 	BlockDatabaseFile string
+
+	// How long to wait for every node of every chain to pass its readiness probes
+	// before Build proceeds to configure relayers.
+	// Defaults to DefaultReadinessTimeout if zero.
+	ReadinessTimeout time.Duration
+
+	// If set, Build watches the chain node and relayer containers of the test until Close,
+	// and calls OnContainerExit when any of them exits unexpectedly, i.e. other than
+	// from deliberate stops such as StopContainer or StopRelayer.
+	// Use FailOnContainerExit to fail the test on such an exit.
+	OnContainerExit func(ContainerExit)
+
+	// Optional functions called at phases of Build, see BuildHooks.
+	Hooks BuildHooks
+}
+
+// BuildHooks are optional functions that Interchain.Build calls at phases of the build,
+// for customizations that would otherwise require reimplementing Build.
+// An error returned by a hook aborts the build with that error.
+// The chains are in the order of their chain IDs and the relayers in the order of their names.
+type BuildHooks struct {
+	// BeforeStart is called once the chains are initialized, with their images pulled and,
+	// for chains with docker nodes, the volumes of their node homes created,
+	// before their genesis is built and they are started, e.g. to write extra files into node homes.
+	BeforeStart func(ctx context.Context, chains []ibc.Chain) error
+
+	// AfterChainStart is called once all chains are started and ready, and the faucets funded,
+	// before the relayers are configured, e.g. to store and instantiate contracts
+	// whose ports the channels of the links are created on, see Interchain.SetLinkChannelOpts.
+	AfterChainStart func(ctx context.Context, chains []ibc.Chain) error
+
+	// AfterRelayerConfigured is called once the relayers have their keys and wallets and the paths
+	// of the links are generated, before the clients, connections and channels of the links are created.
+	// The relayers are not started. With SkipPathCreation, it is called once the keys are configured.
+	AfterRelayerConfigured func(ctx context.Context, relayers []ibc.Relayer) error
 }
 
+// DefaultReadinessTimeout is the default value of InterchainBuildOptions.ReadinessTimeout.
+const DefaultReadinessTimeout = 2 * time.Minute
+
 // Build starts all the chains and configures the relayers associated with the Interchain.
 // It is the caller's responsibility to directly call StartRelayer on the relayer implementations.
 //
@@ -219,6 +335,7 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		panic(fmt.Errorf("Interchain.Build called more than once"))
 	}
 	ic.built = true
+	ic.rep = rep
 
 	chains := make([]ibc.Chain, 0, len(ic.chains))
 	for chain := range ic.chains {
@@ -226,11 +343,24 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 	}
 	ic.cs = newChainSet(ic.log, chains)
 
+	if err := ic.configureDockerHosts(opts.ChainDockerHosts); err != nil {
+		return err
+	}
+	ic.dockerHosts = opts.ChainDockerHosts
+
+	if opts.OnContainerExit != nil {
+		ic.stopWatchdog = ic.watchContainers(opts)
+	}
+
 	// Initialize the chains (pull docker images, etc.).
-	if err := ic.cs.Initialize(ctx, opts.TestName, opts.Client, opts.NetworkID); err != nil {
+	if err := ic.cs.Initialize(ctx, opts.TestName, opts.Client, opts.NetworkID, opts.ChainDockerHosts); err != nil {
 		return fmt.Errorf("failed to initialize chains: %w", err)
 	}
 
+	if err := ic.validatePreseededRelayers(); err != nil {
+		return err
+	}
+
 	err := ic.generateRelayerWallets(ctx) // Build the relayer wallet mapping.
 	if err != nil {
 		return err
@@ -242,14 +372,38 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		return err
 	}
 
+	if err := ic.addGenesisChannels(); err != nil {
+		return err
+	}
+
+	if h := opts.Hooks.BeforeStart; h != nil {
+		if err := h(ctx, ic.sortedChains()); err != nil {
+			return fmt.Errorf("BeforeStart hook: %w", err)
+		}
+	}
+
 	if err := ic.cs.Start(ctx, opts.TestName, walletAmounts); err != nil {
 		return fmt.Errorf("failed to start chains: %w", err)
 	}
 
+	readinessTimeout := opts.ReadinessTimeout
+	if readinessTimeout == 0 {
+		readinessTimeout = DefaultReadinessTimeout
+	}
+	if err := ic.cs.WaitReady(ctx, readinessTimeout); err != nil {
+		return fmt.Errorf("chains not ready: %w", err)
+	}
+
 	if err := ic.cs.TrackBlocks(ctx, opts.TestName, opts.BlockDatabaseFile, opts.GitSha); err != nil {
 		return fmt.Errorf("failed to track blocks: %w", err)
 	}
 
+	if h := opts.Hooks.AfterChainStart; h != nil {
+		if err := h(ctx, ic.sortedChains()); err != nil {
+			return fmt.Errorf("AfterChainStart hook: %w", err)
+		}
+	}
+
 	if err := ic.configureRelayerKeys(ctx, rep); err != nil {
 		// Error already wrapped with appropriate detail.
 		return err
@@ -258,7 +412,7 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 	// Some tests may want to configure the relayer from a lower level,
 	// but still have wallets configured.
 	if opts.SkipPathCreation {
-		return nil
+		return ic.afterRelayerConfigured(ctx, opts.Hooks)
 	}
 
 	// For every relayer link, teach the relayer about the link and create the link.
@@ -268,7 +422,7 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		c0 := link.chains[0]
 		c1 := link.chains[1]
 
-		if err := rp.Relayer.GeneratePath(ctx, rep, c0.Config().ChainID, c1.Config().ChainID, rp.Path); err != nil {
+		if err := rp.Relayer.GeneratePath(ctx, ic.relayerExecReporter(rep, rp.Relayer), c0.Config().ChainID, c1.Config().ChainID, rp.Path); err != nil {
 			return fmt.Errorf(
 				"failed to generate path %s on relayer %s between chains %s and %s: %w",
 				rp.Path, rp.Relayer, ic.chains[c0], ic.chains[c1], err,
@@ -276,6 +430,11 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		}
 	}
 
+	if err := ic.afterRelayerConfigured(ctx, opts.Hooks); err != nil {
+		return err
+	}
+	ic.linked = true
+
 	// Now link the paths in parallel
 	// Creates clients, connections, and channels for each link/path.
 	var eg errgroup.Group
@@ -285,10 +444,24 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		c0 := link.chains[0]
 		c1 := link.chains[1]
 		eg.Go(func() error {
-			// If the user specifies a zero value CreateClientOptions struct then we fall back to the default
-			// client options.
-			if link.createClientOpts == (ibc.CreateClientOptions{}) {
-				link.createClientOpts = ibc.DefaultClientOpts()
+			if link.genesisChannel {
+				if err := rp.Relayer.UpdatePath(ctx, ic.relayerExecReporter(rep, rp.Relayer), rp.Path, genesisChannelPathUpdate()); err != nil {
+					return fmt.Errorf(
+						"failed to set genesis clients of path %s on relayer %s between chains %s and %s: %w",
+						rp.Path, rp.Relayer, ic.chains[c0], ic.chains[c1], err,
+					)
+				}
+				return nil
+			}
+
+			// If the user specifies no trusting period, e.g. with a zero value CreateClientOptions struct,
+			// then we fall back to a trusting period derived from the chains' unbonding times, or the default client options.
+			if link.createClientOpts.TrustingPeriod == "" {
+				opts, err := ClientOptsFromUnbonding(ctx, c0, c1)
+				if err != nil {
+					return err
+				}
+				link.createClientOpts.TrustingPeriod = opts.TrustingPeriod
 			}
 
 			// Check that the client creation options are valid and fully specified.
@@ -307,7 +480,7 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 				return err
 			}
 
-			if err := rp.Relayer.LinkPath(ctx, rep, rp.Path, link.createChannelOpts, link.createClientOpts); err != nil {
+			if err := rp.Relayer.LinkPath(ctx, ic.relayerExecReporter(rep, rp.Relayer), rp.Path, link.createChannelOpts, link.createClientOpts); err != nil {
 				return fmt.Errorf(
 					"failed to link path %s on relayer %s between chains %s and %s: %w",
 					rp.Path, rp.Relayer, ic.chains[c0], ic.chains[c1], err,
@@ -320,6 +493,49 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 	return eg.Wait()
 }
 
+// afterRelayerConfigured calls the AfterRelayerConfigured hook of hooks, if set.
+func (ic *Interchain) afterRelayerConfigured(ctx context.Context, hooks BuildHooks) error {
+	if hooks.AfterRelayerConfigured == nil {
+		return nil
+	}
+	relayers := make([]ibc.Relayer, 0, len(ic.relayers))
+	for r := range ic.relayers {
+		relayers = append(relayers, r)
+	}
+	sort.Slice(relayers, func(i, j int) bool { return ic.relayers[relayers[i]] < ic.relayers[relayers[j]] })
+	if err := hooks.AfterRelayerConfigured(ctx, relayers); err != nil {
+		return fmt.Errorf("AfterRelayerConfigured hook: %w", err)
+	}
+	return nil
+}
+
+// sortedChains returns the chains of the Interchain in the order of their chain IDs.
+func (ic *Interchain) sortedChains() []ibc.Chain {
+	chains := make([]ibc.Chain, 0, len(ic.chains))
+	for c := range ic.chains {
+		chains = append(chains, c)
+	}
+	sort.Slice(chains, func(i, j int) bool { return ic.chains[chains[i]] < ic.chains[chains[j]] })
+	return chains
+}
+
+// SetLinkChannelOpts replaces the options of the channel that Build creates for the link of relayer named path,
+// e.g. from a BuildHooks.AfterChainStart hook, to open the channel on the port of a contract instantiated there.
+// It returns an error if there is no such link or once Build started creating the links.
+func (ic *Interchain) SetLinkChannelOpts(relayer ibc.Relayer, path string, opts ibc.CreateChannelOptions) error {
+	if ic.linked {
+		return fmt.Errorf("links already created, cannot set channel options of path %q", path)
+	}
+	key := relayerPath{Relayer: relayer, Path: path}
+	link, ok := ic.links[key]
+	if !ok {
+		return fmt.Errorf("relayer %q has no path named %q", ic.relayers[relayer], path)
+	}
+	link.createChannelOpts = opts
+	ic.links[key] = link
+	return nil
+}
+
 // WithLog sets the logger on the interchain object.
 // Usually the default nop logger is fine, but sometimes it can be helpful
 // to see more verbose logs, typically by passing zaptest.NewLogger(t).
@@ -328,12 +544,52 @@ func (ic *Interchain) WithLog(log *zap.Logger) *Interchain {
 	return ic
 }
 
+// DefaultFaucetGenesisBalance is the amount of the chain's denom given to the faucet wallet
+// when the chain config has no FaucetGenesisBalance.
+const DefaultFaucetGenesisBalance = int64(100_000_000_000_000)
+
+// faucetGenesisAmounts returns the genesis balance of the faucet at address, one amount per denom.
+func faucetGenesisAmounts(cfg ibc.ChainConfig, address string) []ibc.WalletAmount {
+	if len(cfg.FaucetGenesisBalance) == 0 {
+		return []ibc.WalletAmount{{Address: address, Denom: cfg.Denom, Amount: DefaultFaucetGenesisBalance}}
+	}
+
+	denoms := make([]string, 0, len(cfg.FaucetGenesisBalance))
+	for denom := range cfg.FaucetGenesisBalance {
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
+
+	amounts := make([]ibc.WalletAmount, len(denoms))
+	for i, denom := range denoms {
+		amounts[i] = ibc.WalletAmount{Address: address, Denom: denom, Amount: cfg.FaucetGenesisBalance[denom]}
+	}
+	return amounts
+}
+
 // Close cleans up any resources created during Build,
 // and returns any relevant errors.
 func (ic *Interchain) Close() error {
+	if ic.stopWatchdog != nil {
+		ic.stopWatchdog()
+	}
+	ic.trackQueryStats()
 	return ic.cs.Close()
 }
 
+// trackQueryStats records the query stats of the cosmos chains to the reporter passed to Build, if any,
+// see cosmos.CosmosChain.TrackQueryStats.
+func (ic *Interchain) trackQueryStats() {
+	if ic.rep == nil {
+		return
+	}
+	for _, c := range ic.sortedChains() {
+		if cc, ok := c.(*cosmos.CosmosChain); ok {
+			cc.TrackQueryStats(ic.rep)
+		}
+	}
+}
+
 func (ic *Interchain) genesisWalletAmounts(ctx context.Context) (map[ibc.Chain][]ibc.WalletAmount, error) {
 	// Faucet addresses are created separately because they need to be explicitly added to the chains.
 	faucetAddresses, err := ic.cs.CreateCommonAccount(ctx, FaucetAccountKeyName)
@@ -347,13 +603,7 @@ func (ic *Interchain) genesisWalletAmounts(ctx context.Context) (map[ibc.Chain][
 	// Add faucet for each chain first.
 	for c := range ic.chains {
 		// The values are nil at this point, so it is safe to directly assign the slice.
-		walletAmounts[c] = []ibc.WalletAmount{
-			{
-				Address: faucetAddresses[c],
-				Denom:   c.Config().Denom,
-				Amount:  100_000_000_000_000, // Faucet wallet gets 100T units of denom.
-			},
-		}
+		walletAmounts[c] = faucetGenesisAmounts(c.Config(), faucetAddresses[c])
 
 		if ic.AdditionalGenesisWallets != nil {
 			walletAmounts[c] = append(walletAmounts[c], ic.AdditionalGenesisWallets[c]...)
@@ -363,10 +613,14 @@ func (ic *Interchain) genesisWalletAmounts(ctx context.Context) (map[ibc.Chain][
 	// Then add all defined relayer wallets.
 	for rc, wallet := range ic.relayerWallets {
 		c := rc.C
+		funds, ok := ic.relayerFunds[rc]
+		if !ok {
+			funds = RelayerFunding{Denom: c.Config().Denom, Amount: DefaultRelayerFunding}
+		}
 		walletAmounts[c] = append(walletAmounts[c], ibc.WalletAmount{
 			Address: wallet.FormattedAddress(),
-			Denom:   c.Config().Denom,
-			Amount:  1_000_000_000_000, // Every wallet gets 1t units of denom.
+			Denom:   funds.Denom,
+			Amount:  funds.Amount,
 		})
 	}
 
@@ -385,7 +639,15 @@ func (ic *Interchain) generateRelayerWallets(ctx context.Context) error {
 		for _, c := range chains {
 			// Just an ephemeral unique name, only for the local use of the keyring.
 			accountName := ic.relayers[r] + "-" + ic.chains[c]
-			newWallet, err := c.BuildRelayerWallet(ctx, accountName)
+			var (
+				newWallet ibc.Wallet
+				err       error
+			)
+			if preseeded, ok := preseededConfig(r); ok {
+				newWallet, err = c.BuildWallet(ctx, accountName, preseeded.Mnemonics[c.Config().ChainID])
+			} else {
+				newWallet, err = c.BuildRelayerWallet(ctx, accountName)
+			}
 			if err != nil {
 				return err
 			}
@@ -396,40 +658,97 @@ func (ic *Interchain) generateRelayerWallets(ctx context.Context) error {
 	return nil
 }
 
+// preseededRelayer is a relayer that may use preseeded keys and paths, see relayer.PreseededConfigDir.
+type preseededRelayer interface {
+	Preseeded() (relayer.PreseededConfig, bool)
+}
+
+// preseededConfig returns the preseeded config of r, if it has one.
+func preseededConfig(r ibc.Relayer) (relayer.PreseededConfig, bool) {
+	pr, ok := r.(preseededRelayer)
+	if !ok {
+		return relayer.PreseededConfig{}, false
+	}
+	return pr.Preseeded()
+}
+
+// validatePreseededRelayers checks that the preseeded config of every relayer that has one
+// has the keys and paths of exactly the chains and paths the relayer links.
+func (ic *Interchain) validatePreseededRelayers() error {
+	for r, chains := range ic.relayerChains() {
+		preseeded, ok := preseededConfig(r)
+		if !ok {
+			continue
+		}
+
+		chainIDs := make([]string, len(chains))
+		for i, c := range chains {
+			chainIDs[i] = c.Config().ChainID
+		}
+		paths := make(map[string][2]string)
+		for rp, link := range ic.links {
+			if rp.Relayer == r {
+				paths[rp.Path] = [2]string{link.chains[0].Config().ChainID, link.chains[1].Config().ChainID}
+			}
+		}
+
+		if err := preseeded.Validate(chainIDs, paths); err != nil {
+			return fmt.Errorf("relayer %s: %w", ic.relayers[r], err)
+		}
+	}
+	return nil
+}
+
 // configureRelayerKeys adds the chain configuration for each relayer
 // and adds the preconfigured key to the relayer for each relayer-chain.
+// Each relayer is configured concurrently, as relayers of different types share no state.
 func (ic *Interchain) configureRelayerKeys(ctx context.Context, rep *testreporter.RelayerExecReporter) error {
-	// Possible optimization: each relayer could be configured concurrently.
-	// But we are only testing with a single relayer so far, so we don't need this yet.
+	eg, egCtx := errgroup.WithContext(ctx)
 
 	for r, chains := range ic.relayerChains() {
-		for _, c := range chains {
-			rpcAddr, grpcAddr := c.GetRPCAddress(), c.GetGRPCAddress()
-			if !r.UseDockerNetwork() {
-				rpcAddr, grpcAddr = c.GetHostRPCAddress(), c.GetHostGRPCAddress()
+		r := r
+		chains := chains
+		rRep := ic.relayerExecReporter(rep, r)
+		eg.Go(func() error {
+			for _, c := range chains {
+				rpcAddr, grpcAddr := c.GetRPCAddress(), c.GetGRPCAddress()
+				// Chains on other docker hosts are not on the docker network of the relayer.
+				if _, remote := ic.dockerHosts[c]; remote || !r.UseDockerNetwork() {
+					rpcAddr, grpcAddr = c.GetHostRPCAddress(), c.GetHostGRPCAddress()
+				}
+
+				chainName := ic.chains[c]
+				if err := r.AddChainConfiguration(egCtx,
+					rRep,
+					c.Config(), chainName,
+					rpcAddr, grpcAddr,
+				); err != nil {
+					return fmt.Errorf("failed to configure relayer %s for chain %s: %w", ic.relayers[r], chainName, err)
+				}
+
+				if err := r.RestoreKey(egCtx,
+					rRep,
+					c.Config().ChainID, chainName,
+					c.Config().CoinType,
+					ic.relayerWallets[relayerChain{R: r, C: c}].Mnemonic(),
+				); err != nil {
+					return fmt.Errorf("failed to restore key to relayer %s for chain %s: %w", ic.relayers[r], chainName, err)
+				}
 			}
+			return nil
+		})
+	}
 
-			chainName := ic.chains[c]
-			if err := r.AddChainConfiguration(ctx,
-				rep,
-				c.Config(), chainName,
-				rpcAddr, grpcAddr,
-			); err != nil {
-				return fmt.Errorf("failed to configure relayer %s for chain %s: %w", ic.relayers[r], chainName, err)
-			}
+	return eg.Wait()
+}
 
-			if err := r.RestoreKey(ctx,
-				rep,
-				c.Config().ChainID, chainName,
-				c.Config().CoinType,
-				ic.relayerWallets[relayerChain{R: r, C: c}].Mnemonic(),
-			); err != nil {
-				return fmt.Errorf("failed to restore key to relayer %s for chain %s: %w", ic.relayers[r], chainName, err)
-			}
-		}
+// relayerExecReporter returns a reporter attributing commands to the name of r in the Interchain.
+// A nil rep is returned unchanged.
+func (ic *Interchain) relayerExecReporter(rep *testreporter.RelayerExecReporter, r ibc.Relayer) *testreporter.RelayerExecReporter {
+	if rep == nil {
+		return nil
 	}
-
-	return nil
+	return rep.ForRelayer(ic.relayers[r])
 }
 
 // relayerChain is a tuple of a Relayer and a Chain.
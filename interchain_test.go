@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
@@ -74,6 +75,86 @@ func TestInterchain_DuplicateChain(t *testing.T) {
 	_ = ic.Close()
 }
 
+func TestInterchain_Readiness(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+	numVals, numFullNodes := 2, 1
+	eRep := testreporter.NewNopReporter().RelayerExecReporter(t)
+
+	t.Run("ready after build", func(t *testing.T) {
+		t.Parallel()
+
+		client, network := interchaintest.DockerSetup(t)
+
+		cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+			{Name: "gaia", Version: "v7.0.1", NumValidators: &numVals, NumFullNodes: &numFullNodes, ChainConfig: ibc.ChainConfig{
+				ConfigFileOverrides: map[string]any{
+					"config/app.toml": testutil.Toml{"api": testutil.Toml{"enable": true}},
+				},
+			}},
+		})
+
+		chains, err := cf.Chains(t.Name())
+		require.NoError(t, err)
+		gaia := chains[0].(*cosmos.CosmosChain)
+
+		ic := interchaintest.NewInterchain().AddChain(gaia)
+
+		require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+			TestName:  t.Name(),
+			Client:    client,
+			NetworkID: network,
+		}))
+		t.Cleanup(func() {
+			_ = ic.Close()
+		})
+
+		// Every node is immediately usable, without waiting for further blocks.
+		for _, n := range gaia.Nodes() {
+			h, err := n.Height(ctx)
+			require.NoError(t, err)
+			require.GreaterOrEqual(t, h, uint64(2))
+		}
+	})
+
+	t.Run("timeout identifies node and probe", func(t *testing.T) {
+		t.Parallel()
+
+		client, network := interchaintest.DockerSetup(t)
+
+		cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+			{Name: "gaia", Version: "v7.0.1", NumValidators: &numVals, NumFullNodes: &numFullNodes},
+		})
+
+		chains, err := cf.Chains(t.Name())
+		require.NoError(t, err)
+
+		ic := interchaintest.NewInterchain().AddChain(chains[0])
+		t.Cleanup(func() {
+			_ = ic.Close()
+		})
+
+		err = ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+			TestName:  t.Name(),
+			Client:    client,
+			NetworkID: network,
+
+			// Too short for any probe to succeed.
+			ReadinessTimeout: time.Nanosecond,
+		})
+		require.Error(t, err)
+		require.ErrorContains(t, err, "chain gaia")
+		require.ErrorContains(t, err, "not ready after")
+		require.ErrorContains(t, err, "rpc probe")
+		require.ErrorContains(t, err, "recent logs")
+	})
+}
+
 func TestInterchain_GetRelayerWallets(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping in short mode")
@@ -280,6 +361,9 @@ func TestCosmosChain_BroadcastTx(t *testing.T) {
 
 	sendAmount := int64(10000)
 
+	dstAddr, err := ibc.WalletAddressWithPrefix(testUser, gaia1.Config().Bech32Prefix)
+	require.NoError(t, err)
+
 	t.Run("relayer starts", func(t *testing.T) {
 		require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
 	})
@@ -293,7 +377,7 @@ func TestCosmosChain_BroadcastTx(t *testing.T) {
 			"channel-0",
 			transferAmount,
 			testUser.FormattedAddress(),
-			testUser.(*cosmos.CosmosWallet).FormattedAddressWithPrefix(gaia1.Config().Bech32Prefix),
+			dstAddr,
 			clienttypes.NewHeight(1, 1000),
 			0,
 			"",
@@ -309,7 +393,7 @@ func TestCosmosChain_BroadcastTx(t *testing.T) {
 		srcDenomTrace := transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom("transfer", "channel-0", gaia0.Config().Denom))
 		dstIbcDenom := srcDenomTrace.IBCDenom()
 
-		dstFinalBalance, err := gaia1.GetBalance(ctx, testUser.(*cosmos.CosmosWallet).FormattedAddressWithPrefix(gaia1.Config().Bech32Prefix), dstIbcDenom)
+		dstFinalBalance, err := gaia1.GetBalance(ctx, dstAddr, dstIbcDenom)
 		require.NoError(t, err, "failed to get balance from dest chain")
 		require.Equal(t, sendAmount, dstFinalBalance)
 	})
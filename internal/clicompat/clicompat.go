@@ -0,0 +1,373 @@
+// Package clicompat decodes the JSON output of the CLI queries of Cosmos SDK chain binaries,
+// whose formats differ across SDK versions, into types independent of the SDK version.
+package clicompat
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"golang.org/x/mod/semver"
+)
+
+// Format is the JSON output format of the CLI of a chain binary built with an SDK version.
+type Format struct {
+	// Name is the SDK version of the format, e.g. v0.47, or "detect" for a format detected from each output.
+	Name string
+
+	// Proposals and tallies are gov v1 types, with proposal messages and tally counts, since SDK v0.46.
+	govV1 bool
+	// Proposals have a title and summary of their own, since SDK v0.47.
+	govV1Title bool
+	// Query results are wrapped in their response messages, e.g. {"proposal": {...}}, since SDK v0.50.
+	wrapped bool
+	// The format of each output is detected from its fields, for binaries of unknown SDK versions.
+	detect bool
+}
+
+// The formats by SDK version, each that of the binaries of its version and later up to the next one.
+var (
+	V045   = Format{Name: "v0.45"}
+	V046   = Format{Name: "v0.46", govV1: true}
+	V047   = Format{Name: "v0.47", govV1: true, govV1Title: true}
+	V050   = Format{Name: "v0.50", govV1: true, govV1Title: true, wrapped: true}
+	Detect = Format{Name: "detect", detect: true}
+)
+
+// ForSDKVersion returns the format of the binaries built with sdkVersion, e.g. v0.46.11,
+// or Detect if the SDK version is unknown, i.e. empty or not a semantic version.
+func ForSDKVersion(sdkVersion string) Format {
+	v := sdkVersion
+	if !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	if !semver.IsValid(v) {
+		return Detect
+	}
+	for _, f := range []Format{V050, V047, V046} {
+		if semver.Compare(v, f.Name) >= 0 {
+			return f
+		}
+	}
+	return V045
+}
+
+// Coin is an amount of a denom, as in the total deposit of a proposal.
+type Coin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+// Tally is the tally of the votes on a proposal.
+type Tally struct {
+	Yes, Abstain, No, NoWithVeto string
+}
+
+// Proposal is a governance proposal, of any gov version.
+type Proposal struct {
+	ID     string
+	Status string // e.g. PROPOSAL_STATUS_PASSED
+
+	// ContentType is the type URL of the legacy content of the proposal,
+	// or that of its first message if it has no legacy content.
+	ContentType string
+	// Title and Description of the legacy content, or the title and summary of the proposal.
+	Title, Description string
+
+	FinalTallyResult Tally
+	SubmitTime       string
+	DepositEndTime   string
+	TotalDeposit     []Coin
+	VotingStartTime  string
+	VotingEndTime    string
+}
+
+// TxResponse is the response of a broadcast transaction or of a transaction query.
+type TxResponse struct {
+	TxHash    string
+	Height    int64
+	Code      uint32
+	Codespace string
+	RawLog    string
+}
+
+// scalar is a JSON string, number or null, as integers are encoded as either depending on the SDK version.
+type scalar string
+
+func (s *scalar) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	switch {
+	case bytes.Equal(b, []byte("null")):
+		*s = ""
+		return nil
+	case len(b) > 0 && b[0] == '"':
+		var str string
+		if err := json.Unmarshal(b, &str); err != nil {
+			return err
+		}
+		*s = scalar(str)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return fmt.Errorf("expected string or number, got %s", b)
+	}
+	*s = scalar(n.String())
+	return nil
+}
+
+// uint parses s as an unsigned integer, 0 if empty, as zero fields are omitted by some SDK versions.
+func (s scalar) uint(name string) (uint64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(string(s), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", name, s, err)
+	}
+	return n, nil
+}
+
+// status returns the proposal status s, which is either the name of the status or its number.
+func (s scalar) status() string {
+	if n, err := strconv.ParseInt(string(s), 10, 32); err == nil {
+		if name, ok := govv1.ProposalStatus_name[int32(n)]; ok {
+			return name
+		}
+	}
+	return string(s)
+}
+
+// anyJSON is a message or proposal content of any type, encoded with its type URL in proto JSON,
+// or as {"type": ..., "value": ...} in the amino JSON of the autocli queries of SDK v0.50.
+type anyJSON struct {
+	Type        string
+	Title       string
+	Description string
+	// Content is the legacy content of a MsgExecLegacyContent.
+	Content *anyJSON
+}
+
+func (a *anyJSON) UnmarshalJSON(b []byte) error {
+	var amino struct {
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(b, &amino); err != nil {
+		return err
+	}
+	var j struct {
+		Type        string   `json:"@type"`
+		Title       string   `json:"title"`
+		Description string   `json:"description"`
+		Content     *anyJSON `json:"content"`
+	}
+	if amino.Type != "" && len(amino.Value) > 0 {
+		if err := json.Unmarshal(amino.Value, &j); err != nil {
+			return err
+		}
+		j.Type = amino.Type
+	} else if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	*a = anyJSON{Type: j.Type, Title: j.Title, Description: j.Description, Content: j.Content}
+	return nil
+}
+
+type tallyV1beta1JSON struct {
+	Yes        scalar `json:"yes"`
+	Abstain    scalar `json:"abstain"`
+	No         scalar `json:"no"`
+	NoWithVeto scalar `json:"no_with_veto"`
+}
+
+type tallyV1JSON struct {
+	YesCount        scalar `json:"yes_count"`
+	AbstainCount    scalar `json:"abstain_count"`
+	NoCount         scalar `json:"no_count"`
+	NoWithVetoCount scalar `json:"no_with_veto_count"`
+}
+
+// proposalTimesJSON are the fields of proposals common to all gov versions.
+type proposalTimesJSON struct {
+	Status          scalar `json:"status"`
+	SubmitTime      string `json:"submit_time"`
+	DepositEndTime  string `json:"deposit_end_time"`
+	TotalDeposit    []Coin `json:"total_deposit"`
+	VotingStartTime string `json:"voting_start_time"`
+	VotingEndTime   string `json:"voting_end_time"`
+}
+
+func (j proposalTimesJSON) proposal(id scalar) (Proposal, error) {
+	if id == "" {
+		return Proposal{}, errors.New("missing proposal id")
+	}
+	return Proposal{
+		ID:              string(id),
+		Status:          j.Status.status(),
+		SubmitTime:      j.SubmitTime,
+		DepositEndTime:  j.DepositEndTime,
+		TotalDeposit:    j.TotalDeposit,
+		VotingStartTime: j.VotingStartTime,
+		VotingEndTime:   j.VotingEndTime,
+	}, nil
+}
+
+type proposalV1beta1JSON struct {
+	proposalTimesJSON
+	ProposalID       scalar           `json:"proposal_id"`
+	Content          anyJSON          `json:"content"`
+	FinalTallyResult tallyV1beta1JSON `json:"final_tally_result"`
+}
+
+type proposalV1JSON struct {
+	proposalTimesJSON
+	ID               scalar      `json:"id"`
+	Messages         []anyJSON   `json:"messages"`
+	FinalTallyResult tallyV1JSON `json:"final_tally_result"`
+	Title            string      `json:"title"`
+	Summary          string      `json:"summary"`
+}
+
+// unwrap returns the field of the response message out, if the format wraps query results, or out itself.
+func (f Format) unwrap(out []byte, field string) ([]byte, error) {
+	wrapped := f.wrapped
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(out, &fields); err != nil {
+		return nil, fmt.Errorf("malformed output: %w", err)
+	}
+	if f.detect {
+		_, wrapped = fields[field]
+	}
+	if !wrapped {
+		return out, nil
+	}
+	inner, ok := fields[field]
+	if !ok {
+		return nil, fmt.Errorf("output has no %s field", field)
+	}
+	return inner, nil
+}
+
+// isGovV1 reports whether the proposal or tally out is of gov v1, which has any of the fields v1Fields.
+func (f Format) isGovV1(out []byte, v1Fields ...string) bool {
+	if !f.detect {
+		return f.govV1
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(out, &fields); err != nil {
+		return false
+	}
+	for _, name := range v1Fields {
+		if _, ok := fields[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Proposal decodes the output of the gov proposal query.
+func (f Format) Proposal(out []byte) (Proposal, error) {
+	out, err := f.unwrap(out, "proposal")
+	if err != nil {
+		return Proposal{}, fmt.Errorf("decode %s proposal: %w", f.Name, err)
+	}
+
+	if !f.isGovV1(out, "id", "messages") {
+		var j proposalV1beta1JSON
+		if err := json.Unmarshal(out, &j); err != nil {
+			return Proposal{}, fmt.Errorf("decode %s proposal: %w", f.Name, err)
+		}
+		p, err := j.proposal(j.ProposalID)
+		if err != nil {
+			return Proposal{}, fmt.Errorf("decode %s proposal: %w", f.Name, err)
+		}
+		p.ContentType, p.Title, p.Description = j.Content.Type, j.Content.Title, j.Content.Description
+		p.FinalTallyResult = j.FinalTallyResult.tally()
+		return p, nil
+	}
+
+	var j proposalV1JSON
+	if err := json.Unmarshal(out, &j); err != nil {
+		return Proposal{}, fmt.Errorf("decode %s proposal: %w", f.Name, err)
+	}
+	p, err := j.proposal(j.ID)
+	if err != nil {
+		return Proposal{}, fmt.Errorf("decode %s proposal: %w", f.Name, err)
+	}
+	if len(j.Messages) > 0 {
+		m := j.Messages[0]
+		p.ContentType = m.Type
+		if m.Content != nil {
+			p.ContentType, p.Title, p.Description = m.Content.Type, m.Content.Title, m.Content.Description
+		}
+	}
+	// Proposals without legacy content have a title and summary of their own.
+	if p.Title == "" && p.Description == "" && (f.govV1Title || f.detect) {
+		p.Title, p.Description = j.Title, j.Summary
+	}
+	p.FinalTallyResult = j.FinalTallyResult.tally()
+	return p, nil
+}
+
+func (j tallyV1beta1JSON) tally() Tally {
+	return Tally{Yes: string(j.Yes), Abstain: string(j.Abstain), No: string(j.No), NoWithVeto: string(j.NoWithVeto)}
+}
+
+func (j tallyV1JSON) tally() Tally {
+	return Tally{Yes: string(j.YesCount), Abstain: string(j.AbstainCount), No: string(j.NoCount), NoWithVeto: string(j.NoWithVetoCount)}
+}
+
+// Tally decodes the output of the gov tally query.
+func (f Format) Tally(out []byte) (Tally, error) {
+	out, err := f.unwrap(out, "tally")
+	if err != nil {
+		return Tally{}, fmt.Errorf("decode %s tally: %w", f.Name, err)
+	}
+	if f.isGovV1(out, "yes_count", "abstain_count", "no_count", "no_with_veto_count") {
+		var j tallyV1JSON
+		if err := json.Unmarshal(out, &j); err != nil {
+			return Tally{}, fmt.Errorf("decode %s tally: %w", f.Name, err)
+		}
+		return j.tally(), nil
+	}
+	var j tallyV1beta1JSON
+	if err := json.Unmarshal(out, &j); err != nil {
+		return Tally{}, fmt.Errorf("decode %s tally: %w", f.Name, err)
+	}
+	return j.tally(), nil
+}
+
+// TxResponse decodes the output of a transaction broadcast, or of the tx query.
+// Its format is the same in all SDK versions, but for integers being strings or numbers and zero fields omitted.
+func (f Format) TxResponse(out []byte) (TxResponse, error) {
+	var j struct {
+		TxHash    string `json:"txhash"`
+		Height    scalar `json:"height"`
+		Code      scalar `json:"code"`
+		Codespace string `json:"codespace"`
+		RawLog    string `json:"raw_log"`
+	}
+	if err := json.Unmarshal(out, &j); err != nil {
+		return TxResponse{}, fmt.Errorf("decode %s tx response: %w", f.Name, err)
+	}
+	height, err := j.Height.uint("height")
+	if err != nil {
+		return TxResponse{}, fmt.Errorf("decode %s tx response: %w", f.Name, err)
+	}
+	code, err := j.Code.uint("code")
+	if err != nil {
+		return TxResponse{}, fmt.Errorf("decode %s tx response: %w", f.Name, err)
+	}
+	return TxResponse{
+		TxHash:    j.TxHash,
+		Height:    int64(height),
+		Code:      uint32(code),
+		Codespace: j.Codespace,
+		RawLog:    j.RawLog,
+	}, nil
+}
@@ -0,0 +1,208 @@
+package clicompat
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func fixture(t *testing.T, version, name string) []byte {
+	t.Helper()
+	out, err := os.ReadFile(filepath.Join("testdata", version, name+".json"))
+	require.NoError(t, err)
+	return out
+}
+
+func TestForSDKVersion(t *testing.T) {
+	for _, tc := range []struct {
+		sdkVersion string
+		want       Format
+	}{
+		{"v0.45.11", V045},
+		{"v0.45.16-ics", V045},
+		{"0.46.2", V046},
+		{"v0.46.13", V046},
+		{"v0.47.0-rc1", V046},
+		{"v0.47.5", V047},
+		{"v0.50.1", V050},
+		{"v0.53.0", V050},
+		{"", Detect},
+		{"main", Detect},
+	} {
+		require.Equal(t, tc.want, ForSDKVersion(tc.sdkVersion), tc.sdkVersion)
+	}
+}
+
+// proposalTimes returns the times of the proposal of the fixture of version, which vary with each capture.
+func proposalTimes(t *testing.T, version string) Proposal {
+	t.Helper()
+	var j struct {
+		Proposal *proposalTimesJSON `json:"proposal"`
+		proposalTimesJSON
+	}
+	require.NoError(t, json.Unmarshal(fixture(t, version, "proposal"), &j))
+	if j.Proposal != nil {
+		j.proposalTimesJSON = *j.Proposal
+	}
+	return Proposal{
+		SubmitTime:      j.SubmitTime,
+		DepositEndTime:  j.DepositEndTime,
+		VotingStartTime: j.VotingStartTime,
+		VotingEndTime:   j.VotingEndTime,
+	}
+}
+
+func TestProposal(t *testing.T) {
+	textProposal := Proposal{
+		ID:               "1",
+		Status:           "PROPOSAL_STATUS_PASSED",
+		ContentType:      "/cosmos.gov.v1beta1.TextProposal",
+		Title:            "Signal",
+		Description:      "Signal support for the upgrade",
+		FinalTallyResult: Tally{Yes: "5000000000", Abstain: "0", No: "1000000", NoWithVeto: "0"},
+		TotalDeposit:     []Coin{{Denom: "stake", Amount: "10000000"}},
+	}
+	communitySpend := func(contentType string) Proposal {
+		return Proposal{
+			ID:               "1",
+			Status:           "PROPOSAL_STATUS_PASSED",
+			ContentType:      contentType,
+			Title:            "Community spend",
+			Description:      "Send 1000stake from the community pool",
+			FinalTallyResult: Tally{Yes: "5000000000", Abstain: "0", No: "1000000", NoWithVeto: "0"},
+			TotalDeposit:     []Coin{{Denom: "stake", Amount: "10000000"}},
+		}
+	}
+
+	for _, tc := range []struct {
+		version string
+		format  Format
+		want    Proposal
+	}{
+		{"v0.45", V045, textProposal},
+		{"v0.46", V046, textProposal},
+		{"v0.47", V047, communitySpend("/cosmos.bank.v1beta1.MsgSend")},
+		{"v0.50", V050, communitySpend("cosmos-sdk/MsgSend")},
+	} {
+		t.Run(tc.version, func(t *testing.T) {
+			out := fixture(t, tc.version, "proposal")
+			want := tc.want
+			times := proposalTimes(t, tc.version)
+			require.NotEmpty(t, times.SubmitTime)
+			want.SubmitTime, want.DepositEndTime = times.SubmitTime, times.DepositEndTime
+			want.VotingStartTime, want.VotingEndTime = times.VotingStartTime, times.VotingEndTime
+
+			got, err := tc.format.Proposal(out)
+			require.NoError(t, err)
+			require.Equal(t, want, got)
+
+			got, err = Detect.Proposal(out)
+			require.NoError(t, err)
+			require.Equal(t, want, got)
+		})
+	}
+
+	// The status is also decoded from its number.
+	got, err := V050.Proposal([]byte(`{"proposal":{"id":"2","status":3}}`))
+	require.NoError(t, err)
+	require.Equal(t, "PROPOSAL_STATUS_PASSED", got.Status)
+}
+
+func TestProposal_Mismatch(t *testing.T) {
+	_, err := V046.Proposal(fixture(t, "v0.45", "proposal"))
+	require.ErrorContains(t, err, "missing proposal id")
+
+	_, err = V050.Proposal(fixture(t, "v0.47", "proposal"))
+	require.ErrorContains(t, err, "no proposal field")
+
+	_, err = Detect.Proposal([]byte("Error: proposal 1 doesn't exist"))
+	require.ErrorContains(t, err, "malformed output")
+}
+
+func TestTally(t *testing.T) {
+	want := Tally{Yes: "5000000000", Abstain: "0", No: "1000000", NoWithVeto: "0"}
+	for _, tc := range []struct {
+		version string
+		format  Format
+	}{
+		{"v0.45", V045},
+		{"v0.46", V046},
+		{"v0.47", V047},
+		{"v0.50", V050},
+	} {
+		t.Run(tc.version, func(t *testing.T) {
+			out := fixture(t, tc.version, "tally")
+
+			got, err := tc.format.Tally(out)
+			require.NoError(t, err)
+			require.Equal(t, want, got)
+
+			got, err = Detect.Tally(out)
+			require.NoError(t, err)
+			require.Equal(t, want, got)
+		})
+	}
+}
+
+func TestTxResponse(t *testing.T) {
+	for _, tc := range []struct {
+		version string
+		format  Format
+		// Hashes of the broadcast transactions, the first of which is queried.
+		hash, failedHash string
+		height           int64
+		rawLog           string
+		failedRawLog     string
+	}{
+		{
+			"v0.45", V045,
+			"6483C9B444C0EA1A86B3D834B5055B7306C6C21A48D72E4852270E311C2B8854",
+			"3D01F46CCF79073C987F8838220BD7E2474A22963D0743341B8D1DB518060A3A",
+			7, "[]", "9000000stake is smaller than 1000000000stake: insufficient funds: insufficient funds",
+		},
+		{
+			"v0.46", V046,
+			"2A5B9E186D2B9B71351B3BF7FC414105521BC8345486320980DB826B6197541A",
+			"73526D5992B8A866B8FF0FDDB3E6F4B9C2AB5F9D97378F1123B99004B78D2498",
+			7, "[]", "9000000stake is smaller than 1000000000stake: insufficient funds: insufficient funds",
+		},
+		{
+			"v0.47", V047,
+			"F72757F8AEAAB2AA58EC78B5FEE140CDE32F92B53D84F543DF0521FF7C525A60",
+			"7D1089202BDED0DAA0A9AD060E077F88556DB61817EF7E44A2984B4C6E0D53F0",
+			9, "[]", "spendable balance 8000000stake is smaller than 1000000000stake: insufficient funds: insufficient funds",
+		},
+		{
+			"v0.50", V050,
+			"3AB1E98CE43597AEA6E58A2A94E7463B8E950E1CAA65FD6846890FAAC26D5613",
+			"4657B5364E5C5BF084B105084CD2D62A546AB04E6FCA8AC0EFB4D6C9EF2CC241",
+			9, "", "spendable balance 8000000stake is smaller than 1000000000stake: insufficient funds: insufficient funds",
+		},
+	} {
+		t.Run(tc.version, func(t *testing.T) {
+			got, err := tc.format.TxResponse(fixture(t, tc.version, "tx"))
+			require.NoError(t, err)
+			require.Equal(t, TxResponse{TxHash: tc.hash, RawLog: tc.rawLog}, got)
+
+			got, err = tc.format.TxResponse(fixture(t, tc.version, "tx_insufficient_funds"))
+			require.NoError(t, err)
+			require.Equal(t, TxResponse{TxHash: tc.failedHash, Code: 5, Codespace: "sdk", RawLog: tc.failedRawLog}, got)
+
+			got, err = tc.format.TxResponse(fixture(t, tc.version, "tx_query"))
+			require.NoError(t, err)
+			require.Equal(t, tc.hash, got.TxHash)
+			require.Equal(t, tc.height, got.Height)
+			require.Zero(t, got.Code)
+		})
+	}
+
+	got, err := Detect.TxResponse([]byte(`{"height":42,"txhash":"ABC","code":11,"codespace":"sdk"}`))
+	require.NoError(t, err)
+	require.Equal(t, TxResponse{TxHash: "ABC", Height: 42, Code: 11, Codespace: "sdk"}, got)
+
+	_, err = Detect.TxResponse([]byte(`{"txhash":"ABC","code":"out of gas"}`))
+	require.ErrorContains(t, err, "invalid code")
+}
@@ -0,0 +1,147 @@
+#!/usr/bin/env bash
+# capture.sh writes the fixtures of testdata: the JSON output of the CLI commands decoded by clicompat,
+# captured from a single validator chain run with the simd binary of each SDK version.
+# The simd of SDK v0.45 and v0.46 is that of the SDK, those of v0.47 and v0.50 are the simapps of
+# ibc-go v7 and v8, built with those SDK versions. Each is built from the module in the directory of its version.
+#
+# Run it from this directory with ./capture.sh, or ./capture.sh v0.47 for a single version.
+set -euo pipefail
+
+declare -A simd_pkgs=(
+	[v0.45]=github.com/cosmos/cosmos-sdk/simapp/simd
+	[v0.46]=github.com/cosmos/cosmos-sdk/simapp/simd
+	[v0.47]=github.com/cosmos/ibc-go/v7/testing/simapp/simd
+	[v0.50]=github.com/cosmos/ibc-go/v8/testing/simapp/simd
+)
+
+if [ $# -eq 0 ]; then
+	for v in v0.45 v0.46 v0.47 v0.50; do
+		"$0" "$v"
+	done
+	exit
+fi
+
+version=$1
+simd=$(mktemp -d)/simd
+(cd "$version" && GOFLAGS=-mod=mod go build -o "$simd" "${simd_pkgs[$version]}")
+out=$(realpath "../$version")
+home=$(mktemp -d)
+trap 'kill $(jobs -p) 2>/dev/null && wait; rm -rf "$home" "$(dirname "$simd")"' EXIT
+
+chain=clicompat-1
+node=tcp://127.0.0.1:36657
+keyring=(--keyring-backend test --home "$home")
+tx=("${keyring[@]}" --chain-id "$chain" --node "$node" --gas 400000 --output json -y)
+query=(--node "$node" --output json)
+
+case $version in
+v0.45 | v0.46 | v0.47) genesis=() ;;
+*) genesis=(genesis) ;;
+esac
+case $version in
+v0.45 | v0.46) tx+=(--broadcast-mode sync) ;;
+esac
+
+"$simd" init clicompat --chain-id "$chain" --home "$home" >/dev/null 2>&1
+"$simd" keys add val "${keyring[@]}" >/dev/null 2>&1
+"$simd" keys add user "${keyring[@]}" >/dev/null 2>&1
+val=$("$simd" keys show val -a "${keyring[@]}")
+user=$("$simd" keys show user -a "${keyring[@]}")
+# The gov module account, which sends the community spend of the proposals of gov v1.
+gov=cosmos10d07y265gmmuvt4z0w9aw880jnsr700j6zn9kn
+
+"$simd" "${genesis[@]}" add-genesis-account "$val" 10000000000stake --home "$home"
+"$simd" "${genesis[@]}" add-genesis-account "$user" 10000000stake --home "$home"
+"$simd" "${genesis[@]}" gentx val 5000000000stake --chain-id "$chain" "${keyring[@]}" >/dev/null 2>&1
+"$simd" "${genesis[@]}" collect-gentxs --home "$home" >/dev/null 2>&1
+
+python3 - "$home/config/genesis.json" "$version" <<'EOF'
+import json, sys
+path, version = sys.argv[1:]
+g = json.load(open(path))
+gov = g["app_state"]["gov"]
+if version == "v0.45" or version == "v0.46":
+    gov["voting_params"]["voting_period"] = "20s"
+    gov["deposit_params"]["min_deposit"] = [{"denom": "stake", "amount": "10000000"}]
+else:
+    gov["params"]["voting_period"] = "20s"
+    gov["params"]["min_deposit"] = [{"denom": "stake", "amount": "10000000"}]
+    if "expedited_voting_period" in gov["params"]:
+        gov["params"]["expedited_voting_period"] = "10s"
+json.dump(g, open(path, "w"))
+EOF
+
+"$simd" start --home "$home" --minimum-gas-prices 0stake \
+	--rpc.laddr "$node" --p2p.laddr tcp://127.0.0.1:36656 \
+	--grpc.address 127.0.0.1:39090 \
+	>"$home/node.log" 2>&1 &
+
+wait_height() {
+	for _ in $(seq 60); do
+		h=$("$simd" status --node "$node" 2>&1 | python3 -c 'import json,sys
+d=json.load(sys.stdin); print(int((d.get("SyncInfo") or d.get("sync_info"))["latest_block_height"]))' 2>/dev/null || echo 0)
+		[ "$h" -ge "$1" ] && return
+		sleep 1
+	done
+	echo "chain did not reach height $1" >&2
+	grep -m5 -i "panic\|error" "$home/node.log" >&2
+	exit 1
+}
+
+# next_block waits for the block after the current one, in which the last broadcast transaction is included.
+next_block() {
+	h=$("$simd" status --node "$node" 2>&1 | python3 -c 'import json,sys
+d=json.load(sys.stdin); print(int((d.get("SyncInfo") or d.get("sync_info"))["latest_block_height"]))')
+	wait_height $((h + 2))
+}
+
+wait_height 2
+
+"$simd" tx staking delegate "$("$simd" keys show val --bech val -a "${keyring[@]}")" 1000000stake --from user "${tx[@]}" >/dev/null
+next_block
+
+case $version in
+v0.45)
+	"$simd" tx gov submit-proposal --type Text --title "Signal" --description "Signal support for the upgrade" \
+		--deposit 10000000stake --from val "${tx[@]}" >/dev/null
+	;;
+v0.46)
+	"$simd" tx gov submit-legacy-proposal --type Text --title "Signal" --description "Signal support for the upgrade" \
+		--deposit 10000000stake --from val "${tx[@]}" >/dev/null
+	;;
+*)
+	"$simd" tx bank send user "$gov" 1000000stake --from user "${tx[@]}" >/dev/null
+	next_block
+	cat >"$home/proposal.json" <<EOF
+{
+  "messages": [{"@type": "/cosmos.bank.v1beta1.MsgSend", "from_address": "$gov", "to_address": "$user", "amount": [{"denom": "stake", "amount": "1000"}]}],
+  "metadata": "ipfs://CID",
+  "deposit": "10000000stake",
+  "title": "Community spend",
+  "summary": "Send 1000stake from the community pool"
+}
+EOF
+	"$simd" tx gov submit-proposal "$home/proposal.json" --from val "${tx[@]}" >/dev/null
+	;;
+esac
+next_block
+
+"$simd" tx gov vote 1 yes --from val "${tx[@]}" >"$out/tx.json"
+"$simd" tx gov vote 1 no --from user "${tx[@]}" >/dev/null
+next_block
+hash=$(python3 -c 'import json,sys; print(json.load(open(sys.argv[1]))["txhash"])' "$out/tx.json")
+"$simd" query tx "$hash" "${query[@]}" >"$out/tx_query.json"
+"$simd" query gov tally 1 "${query[@]}" >"$out/tally.json"
+
+# The check of the transaction fails, as the user has not enough funds to pay the fees.
+"$simd" tx bank send user "$val" 1stake --fees 1000000000stake --from user "${tx[@]}" >"$out/tx_insufficient_funds.json" || true
+
+for _ in $(seq 60); do
+	status=$("$simd" query gov proposal 1 "${query[@]}" | python3 -c 'import json,sys
+d=json.load(sys.stdin); print(d.get("proposal", d)["status"])')
+	case $status in
+	PROPOSAL_STATUS_PASSED | 3) break ;;
+	esac
+	sleep 1
+done
+"$simd" query gov proposal 1 "${query[@]}" >"$out/proposal.json"
@@ -0,0 +1,13 @@
+module gen
+
+go 1.18
+
+require github.com/cosmos/cosmos-sdk v0.45.16
+
+replace (
+	github.com/99designs/keyring => github.com/cosmos/keyring v1.2.0
+	github.com/gogo/protobuf => github.com/regen-network/protobuf v1.3.3-alpha.regen.1
+	github.com/jhump/protoreflect => github.com/jhump/protoreflect v1.9.0
+	github.com/tendermint/tendermint => github.com/cometbft/cometbft v0.34.27
+	google.golang.org/grpc => google.golang.org/grpc v1.33.2
+)
@@ -0,0 +1,7 @@
+module gen
+
+go 1.21
+
+require github.com/cosmos/ibc-go/v8 v8.5.2
+
+replace github.com/syndtr/goleveldb => github.com/syndtr/goleveldb v1.0.1-0.20210819022825-2ae1ddf74ef7
@@ -48,7 +48,7 @@ func (r *FileRetriever) SingleFileContent(ctx context.Context, volumeName, relPa
 			// Use root user to avoid permission issues when reading files from the volume.
 			User: GetRootUserString(),
 
-			Labels: map[string]string{CleanupLabel: r.testName},
+			Labels: ResourceLabels(r.testName),
 		},
 		&container.HostConfig{
 			Binds:      []string{volumeName + ":" + mountPath},
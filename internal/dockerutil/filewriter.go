@@ -57,7 +57,7 @@ func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string,
 			// Use root user to avoid permission issues when reading files from the volume.
 			User: GetRootUserString(),
 
-			Labels: map[string]string{CleanupLabel: w.testName},
+			Labels: ResourceLabels(w.testName),
 		},
 		&container.HostConfig{
 			Binds:      []string{volumeName + ":" + mountPath},
@@ -169,7 +169,7 @@ func (image *Image) createContainer(ctx context.Context, containerName, hostName
 			Hostname: hostName,
 			User:     opts.User,
 
-			Labels: map[string]string{CleanupLabel: image.testName},
+			Labels: ResourceLabels(image.testName),
 		},
 		&container.HostConfig{
 			Binds:           opts.Binds,
@@ -228,6 +228,7 @@ func (image *Image) Start(ctx context.Context, cmd []string, opts ContainerOptio
 		log:         logger,
 		image:       image,
 		containerID: cID,
+		startedAt:   time.Now(),
 	}, nil
 }
 
@@ -243,25 +244,27 @@ type Container struct {
 	log         *zap.Logger
 	image       *Image
 	containerID string
+	startedAt   time.Time
 }
 
 // Wait blocks until the container exits. Calling wait is not suitable for daemons and servers.
 // A non-zero status code returns an error.
 //
 // Wait implicitly calls Stop.
+// If ctx is done before the container exits, the container is killed and removed,
+// and the error wraps the context's error.
 // If logTail is non-zero, the stdout and stderr logs will be truncated at the end to that number of lines.
 func (c *Container) Wait(ctx context.Context, logTail uint64) ContainerExecResult {
 	waitCh, errCh := c.image.client.ContainerWait(ctx, c.containerID, container.WaitConditionNotRunning)
 	var exitCode int
 	select {
 	case <-ctx.Done():
-		return ContainerExecResult{
-			Err:      ctx.Err(),
-			ExitCode: 1,
-			Stdout:   nil,
-			Stderr:   nil,
-		}
+		return c.abort(ctx.Err(), logTail)
 	case err := <-errCh:
+		if ctx.Err() != nil {
+			// The wait was interrupted by ctx rather than failing.
+			return c.abort(ctx.Err(), logTail)
+		}
 		return ContainerExecResult{
 			Err:      err,
 			ExitCode: 1,
@@ -280,32 +283,7 @@ func (c *Container) Wait(ctx context.Context, logTail uint64) ContainerExecResul
 		}
 	}
 
-	var (
-		stdoutBuf = new(bytes.Buffer)
-		stderrBuf = new(bytes.Buffer)
-	)
-
-	logOpts := types.ContainerLogsOptions{
-		ShowStdout: true,
-		ShowStderr: true,
-	}
-	if logTail != 0 {
-		logOpts.Tail = strconv.FormatUint(logTail, 10)
-	}
-
-	rc, err := c.image.client.ContainerLogs(ctx, c.containerID, logOpts)
-	if err != nil {
-		return ContainerExecResult{
-			Err:      err,
-			ExitCode: exitCode,
-			Stdout:   nil,
-			Stderr:   nil,
-		}
-	}
-	defer func() { _ = rc.Close() }()
-
-	// Logs are multiplexed into one stream; see docs for ContainerLogs.
-	_, err = stdcopy.StdCopy(stdoutBuf, stderrBuf, rc)
+	stdout, stderr, err := c.logs(ctx, logTail)
 	if err != nil {
 		return ContainerExecResult{
 			Err:      err,
@@ -314,7 +292,6 @@ func (c *Container) Wait(ctx context.Context, logTail uint64) ContainerExecResul
 			Stderr:   nil,
 		}
 	}
-	_ = rc.Close()
 
 	err = c.Stop(10 * time.Second)
 	if err != nil {
@@ -322,7 +299,7 @@ func (c *Container) Wait(ctx context.Context, logTail uint64) ContainerExecResul
 	}
 
 	if exitCode != 0 {
-		out := strings.Join([]string{stdoutBuf.String(), stderrBuf.String()}, " ")
+		out := strings.Join([]string{string(stdout), string(stderr)}, " ")
 		return ContainerExecResult{
 			Err:      fmt.Errorf("exit code %d: %s", exitCode, out),
 			ExitCode: exitCode,
@@ -334,8 +311,65 @@ func (c *Container) Wait(ctx context.Context, logTail uint64) ContainerExecResul
 	return ContainerExecResult{
 		Err:      nil,
 		ExitCode: exitCode,
-		Stdout:   stdoutBuf.Bytes(),
-		Stderr:   stderrBuf.Bytes(),
+		Stdout:   stdout,
+		Stderr:   stderr,
+	}
+}
+
+// logs returns the stdout and stderr logs of the container,
+// truncated at the end to logTail lines if logTail is non-zero.
+func (c *Container) logs(ctx context.Context, logTail uint64) (stdout, stderr []byte, err error) {
+	logOpts := types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+	}
+	if logTail != 0 {
+		logOpts.Tail = strconv.FormatUint(logTail, 10)
+	}
+
+	rc, err := c.image.client.ContainerLogs(ctx, c.containerID, logOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = rc.Close() }()
+
+	var (
+		stdoutBuf = new(bytes.Buffer)
+		stderrBuf = new(bytes.Buffer)
+	)
+	// Logs are multiplexed into one stream; see docs for ContainerLogs.
+	if _, err := stdcopy.StdCopy(stdoutBuf, stderrBuf, rc); err != nil {
+		return nil, nil, err
+	}
+	return stdoutBuf.Bytes(), stderrBuf.Bytes(), nil
+}
+
+// abort kills and removes the container after its wait was interrupted by cause, typically a context error,
+// so that the command does not keep running after the caller's deadline.
+// The result wraps cause, and includes how long the container ran and its output so far.
+func (c *Container) abort(cause error, logTail uint64) ContainerExecResult {
+	ran := time.Since(c.startedAt).Round(time.Millisecond)
+
+	// ctx is already done, so use a fresh context to clean up.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := c.image.client.ContainerKill(ctx, c.containerID, "SIGKILL"); err != nil && !errdefs.IsNotFound(err) && !errdefs.IsConflict(err) {
+		c.log.Error("Failed to kill container", zap.Error(err), zap.String("container_id", c.containerID))
+	}
+	stdout, stderr, err := c.logs(ctx, logTail)
+	if err != nil {
+		c.log.Error("Failed to retrieve logs of killed container", zap.Error(err), zap.String("container_id", c.containerID))
+	}
+	if err := c.Stop(10 * time.Second); err != nil {
+		c.log.Error("Failed to stop and remove container", zap.Error(err), zap.String("container_id", c.containerID))
+	}
+
+	return ContainerExecResult{
+		Err:      fmt.Errorf("container %s killed after running for %s: %w; partial stdout: %q", c.Name, ran, cause, stdout),
+		ExitCode: -1,
+		Stdout:   stdout,
+		Stderr:   stderr,
 	}
 }
 
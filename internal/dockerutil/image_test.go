@@ -161,6 +161,29 @@ func TestContainer(t *testing.T) {
 		require.NoError(t, c.Stop(5*time.Second))
 	})
 
+	t.Run("wait cancelled", func(t *testing.T) {
+		c, err := image.Start(ctx, []string{"sh", "-c", "echo -n partial; sleep 100"}, ContainerOptions{})
+		require.NoError(t, err)
+
+		cctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+		defer cancel()
+
+		start := time.Now()
+		res := c.Wait(cctx, 0)
+		require.Less(t, time.Since(start), 30*time.Second, "wait did not return promptly")
+
+		require.ErrorIs(t, res.Err, context.DeadlineExceeded)
+		require.ErrorContains(t, res.Err, "partial")
+		require.Equal(t, "partial", string(res.Stdout))
+
+		containers, err := image.client.ContainerList(ctx, types.ContainerListOptions{
+			All:     true,
+			Filters: filters.NewArgs(filters.Arg("name", c.Name)),
+		})
+		require.NoError(t, err)
+		require.Empty(t, containers, "container was not removed")
+	})
+
 	t.Run("stop long running container", func(t *testing.T) {
 		c, err := image.Start(ctx, []string{"sleep", "100"}, ContainerOptions{})
 		require.NoError(t, err)
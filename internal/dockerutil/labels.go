@@ -0,0 +1,38 @@
+package dockerutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// TestPackageLabel is the test binary of the test that created a docker resource, e.g. ibc for ibc.test.
+	TestPackageLabel = LabelPrefix + "test-package"
+
+	// CreatedAtLabel is the time, in RFC 3339 format, at which a docker resource was created.
+	CreatedAtLabel = LabelPrefix + "created-at"
+)
+
+// TestPackage is the value of TestPackageLabel for the resources created by this process.
+// It is the name of the test binary without its .test suffix, which go test names after the package under test.
+var TestPackage = strings.TrimSuffix(filepath.Base(os.Args[0]), ".test")
+
+// ResourceLabels returns the labels of a docker resource created for the test testName:
+// CleanupLabel, targeted by DockerSetup when it cleans up, TestPackageLabel and CreatedAtLabel.
+func ResourceLabels(testName string) map[string]string {
+	return map[string]string{
+		CleanupLabel:     testName,
+		TestPackageLabel: TestPackage,
+		CreatedAtLabel:   time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+// NodeResourceLabels returns the ResourceLabels of testName with the NodeOwnerLabel nodeOwner,
+// for the volume or long-running container of a node.
+func NodeResourceLabels(testName, nodeOwner string) map[string]string {
+	labels := ResourceLabels(testName)
+	labels[NodeOwnerLabel] = nodeOwner
+	return labels
+}
@@ -0,0 +1,150 @@
+package dockerutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+)
+
+// ResourceKind is the kind of a docker resource listed by ListResources.
+type ResourceKind string
+
+const (
+	ResourceContainer ResourceKind = "container"
+	ResourceVolume    ResourceKind = "volume"
+	ResourceNetwork   ResourceKind = "network"
+)
+
+// Resource is a docker resource created by interchaintest, as listed by ListResources.
+type Resource struct {
+	Kind ResourceKind
+	ID   string
+	Name string
+
+	// Values of CleanupLabel, TestPackageLabel and NodeOwnerLabel;
+	// TestPackage is empty for resources created by versions without the label.
+	TestName    string
+	TestPackage string
+	NodeOwner   string
+
+	// CreatedAt is the value of CreatedAtLabel, or the creation time reported by docker without the label.
+	CreatedAt time.Time
+
+	// State of a container, e.g. running or exited. Empty for volumes and networks.
+	State string
+}
+
+// String returns a one-line description of r, e.g. for logs.
+func (r Resource) String() string {
+	s := fmt.Sprintf("%s %s (test %q", r.Kind, r.Name, r.TestName)
+	if r.TestPackage != "" {
+		s += fmt.Sprintf(", package %s", r.TestPackage)
+	}
+	if !r.CreatedAt.IsZero() {
+		s += fmt.Sprintf(", created %s", r.CreatedAt.Format(time.RFC3339))
+	}
+	if r.State != "" {
+		s += ", " + r.State
+	}
+	return s + ")"
+}
+
+// ResourceFilter selects the resources listed by ListResources. The zero value selects all of them.
+type ResourceFilter struct {
+	// Only resources of the test with this exact name, if set.
+	TestName string
+	// Only resources of the test package, if set, see TestPackageLabel.
+	TestPackage string
+	// Only resources created before this time, if set, e.g. to prune leftovers of earlier CI runs.
+	CreatedBefore time.Time
+}
+
+func (f ResourceFilter) args() filters.Args {
+	args := filters.NewArgs()
+	if f.TestName != "" {
+		args.Add("label", CleanupLabel+"="+f.TestName)
+	} else {
+		args.Add("label", CleanupLabel)
+	}
+	if f.TestPackage != "" {
+		args.Add("label", TestPackageLabel+"="+f.TestPackage)
+	}
+	return args
+}
+
+func (f ResourceFilter) match(r Resource) bool {
+	return f.CreatedBefore.IsZero() || r.CreatedAt.Before(f.CreatedBefore)
+}
+
+// newResource returns the resource with the given labels; created is used without a CreatedAtLabel.
+func newResource(kind ResourceKind, id, name string, labels map[string]string, created time.Time) Resource {
+	if t, err := time.Parse(time.RFC3339, labels[CreatedAtLabel]); err == nil {
+		created = t
+	}
+	return Resource{
+		Kind:        kind,
+		ID:          id,
+		Name:        name,
+		TestName:    labels[CleanupLabel],
+		TestPackage: labels[TestPackageLabel],
+		NodeOwner:   labels[NodeOwnerLabel],
+		CreatedAt:   created,
+	}
+}
+
+// ListResources returns the containers, volumes and networks created by interchaintest on the docker daemon of cli
+// and selected by filter, sorted by creation time.
+func ListResources(ctx context.Context, cli *client.Client, filter ResourceFilter) ([]Resource, error) {
+	var rs []Resource
+
+	cs, err := cli.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: filter.args()})
+	if err != nil {
+		return nil, fmt.Errorf("list containers: %w", err)
+	}
+	for _, c := range cs {
+		name := c.ID
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		r := newResource(ResourceContainer, c.ID, name, c.Labels, time.Unix(c.Created, 0))
+		r.State = c.State
+		rs = append(rs, r)
+	}
+
+	vs, err := cli.VolumeList(ctx, filter.args())
+	if err != nil {
+		return nil, fmt.Errorf("list volumes: %w", err)
+	}
+	for _, v := range vs.Volumes {
+		created, _ := time.Parse(time.RFC3339, v.CreatedAt)
+		rs = append(rs, newResource(ResourceVolume, v.Name, v.Name, v.Labels, created))
+	}
+
+	ns, err := cli.NetworkList(ctx, types.NetworkListOptions{Filters: filter.args()})
+	if err != nil {
+		return nil, fmt.Errorf("list networks: %w", err)
+	}
+	for _, n := range ns {
+		rs = append(rs, newResource(ResourceNetwork, n.ID, n.Name, n.Labels, n.Created))
+	}
+
+	return filterResources(rs, filter), nil
+}
+
+// filterResources returns the resources of rs matching filter, sorted by creation time.
+func filterResources(rs []Resource, filter ResourceFilter) []Resource {
+	var out []Resource
+	for _, r := range rs {
+		if filter.match(r) {
+			out = append(out, r)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out
+}
@@ -0,0 +1,54 @@
+package dockerutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceLabels(t *testing.T) {
+	before := time.Now().UTC().Truncate(time.Second)
+	labels := NodeResourceLabels("TestFoo", "chain-1-val-0-TestFoo")
+	require.Equal(t, "TestFoo", labels[CleanupLabel])
+	require.Equal(t, "chain-1-val-0-TestFoo", labels[NodeOwnerLabel])
+	require.Equal(t, TestPackage, labels[TestPackageLabel])
+	require.Equal(t, "dockerutil", TestPackage)
+
+	r := newResource(ResourceVolume, "v1", "v1", labels, time.Time{})
+	require.Equal(t, "TestFoo", r.TestName)
+	require.Equal(t, "dockerutil", r.TestPackage)
+	require.Equal(t, "chain-1-val-0-TestFoo", r.NodeOwner)
+	require.False(t, r.CreatedAt.Before(before))
+
+	_, ok := ResourceLabels("TestFoo")[NodeOwnerLabel]
+	require.False(t, ok)
+}
+
+func TestNewResource_NoCreatedAtLabel(t *testing.T) {
+	created := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	r := newResource(ResourceNetwork, "n1", "interchaintest-abc", map[string]string{CleanupLabel: "TestFoo"}, created)
+	require.Equal(t, created, r.CreatedAt)
+	require.Empty(t, r.TestPackage)
+	require.Equal(t, `network interchaintest-abc (test "TestFoo", created 2022-10-01T12:00:00Z)`, r.String())
+}
+
+func TestFilterResources(t *testing.T) {
+	t0 := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	rs := []Resource{
+		{Kind: ResourceVolume, Name: "v", CreatedAt: t0.Add(2 * time.Hour)},
+		{Kind: ResourceContainer, Name: "c", CreatedAt: t0, State: "exited"},
+		{Kind: ResourceNetwork, Name: "n", CreatedAt: t0.Add(time.Hour)},
+	}
+
+	names := func(rs []Resource) []string {
+		var out []string
+		for _, r := range rs {
+			out = append(out, r.Name)
+		}
+		return out
+	}
+	require.Equal(t, []string{"c", "n", "v"}, names(filterResources(rs, ResourceFilter{})))
+	require.Equal(t, []string{"c", "n"}, names(filterResources(rs, ResourceFilter{CreatedBefore: t0.Add(90 * time.Minute)})))
+	require.Empty(t, filterResources(rs, ResourceFilter{CreatedBefore: t0}))
+}
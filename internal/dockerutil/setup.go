@@ -43,7 +43,8 @@ const (
 	// LabelPrefix is the reverse DNS format "namespace" for interchaintest Docker labels.
 	LabelPrefix = "ventures.strangelove.interchaintest."
 
-	// NodeOwnerLabel indicates the logical node owning a particular object (probably a volume).
+	// NodeOwnerLabel indicates the logical node owning a particular object,
+	// such as a node's volume or its long-running container.
 	NodeOwnerLabel = LabelPrefix + "node-owner"
 )
 
@@ -62,8 +63,21 @@ var KeepVolumesOnFailure = os.Getenv("IBCTEST_SKIP_FAILURE_CLEANUP") != ""
 // If any part of the setup fails, DockerSetup panics because the test cannot continue.
 func DockerSetup(t DockerSetupTestingT) (*client.Client, string) {
 	t.Helper()
+	return dockerSetup(t, client.FromEnv)
+}
+
+// DockerSetupHost is like DockerSetup for the docker daemon at host, e.g. tcp://10.0.0.2:2376,
+// instead of the daemon of the DOCKER_HOST environment variable.
+// The other docker environment variables, such as the TLS settings, still apply.
+func DockerSetupHost(t DockerSetupTestingT, host string) (*client.Client, string) {
+	t.Helper()
+	return dockerSetup(t, client.FromEnv, client.WithHost(host))
+}
 
-	cli, err := client.NewClientWithOpts(client.FromEnv)
+func dockerSetup(t DockerSetupTestingT, opts ...client.Opt) (*client.Client, string) {
+	t.Helper()
+
+	cli, err := client.NewClientWithOpts(opts...)
 	if err != nil {
 		panic(fmt.Errorf("failed to create docker client: %v", err))
 	}
@@ -72,14 +86,15 @@ func DockerSetup(t DockerSetupTestingT) (*client.Client, string) {
 	t.Cleanup(dockerCleanup(t, cli))
 
 	// Also eagerly clean up any leftover resources from a previous test run,
-	// e.g. if the test was interrupted.
+	// e.g. if the test was interrupted, after logging them.
+	logLeftoverResources(t, cli)
 	dockerCleanup(t, cli)()
 
 	name := fmt.Sprintf("interchaintest-%s", RandLowerCaseLetterString(8))
 	network, err := cli.NetworkCreate(context.TODO(), name, types.NetworkCreate{
 		CheckDuplicate: true,
 
-		Labels: map[string]string{CleanupLabel: t.Name()},
+		Labels: ResourceLabels(t.Name()),
 	})
 	if err != nil {
 		panic(fmt.Errorf("failed to create docker network: %v", err))
@@ -88,6 +103,23 @@ func DockerSetup(t DockerSetupTestingT) (*client.Client, string) {
 	return cli, network.ID
 }
 
+// logLeftoverResources logs the resources of a previous run of t, about to be pruned by DockerSetup.
+func logLeftoverResources(t DockerSetupTestingT, cli *client.Client) {
+	rs, err := ListResources(context.TODO(), cli, ResourceFilter{TestName: t.Name()})
+	if err != nil {
+		t.Logf("Failed to list leftover docker resources: %v", err)
+		return
+	}
+	if len(rs) == 0 {
+		return
+	}
+	lines := make([]string, len(rs))
+	for i, r := range rs {
+		lines[i] = "  " + r.String()
+	}
+	t.Logf("Pruning %d docker resources left over from a previous run of %s:\n%s", len(rs), t.Name(), strings.Join(lines, "\n"))
+}
+
 // dockerCleanup will clean up Docker containers, networks, and the other various config files generated in testing
 func dockerCleanup(t DockerSetupTestingT, cli *client.Client) func() {
 	return func() {
@@ -108,7 +140,7 @@ func dockerCleanup(t DockerSetupTestingT, cli *client.Client) func() {
 		for _, c := range cs {
 			stopTimeout := 10 * time.Second
 			deadline := time.Now().Add(stopTimeout)
-			if err := cli.ContainerStop(ctx, c.ID, &stopTimeout); isLoggableStopError(err) {
+			if err := StopContainer(ctx, cli, c.ID, stopTimeout); isLoggableStopError(err) {
 				t.Logf("Failed to stop container %s during docker cleanup: %v", c.ID, err)
 			}
 
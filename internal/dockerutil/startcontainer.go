@@ -13,7 +13,9 @@ import (
 // StartContainer attempts to start the container with the given ID.
 // If the request times out, it retries a certain number of times before failing.
 // Any other failure modes stop immediately.
+// Any expected stop of the container from ExpectContainerStop is cleared.
 func StartContainer(ctx context.Context, cli *client.Client, id string) error {
+	consumeExpectedStop(id)
 	return retry.Do(
 		func() error {
 			retryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
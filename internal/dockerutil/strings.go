@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"net/url"
 	"os"
 	"regexp"
 	"runtime"
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
 )
 
@@ -32,6 +34,34 @@ func GetHostPort(cont types.ContainerJSON, portID string) string {
 	return net.JoinHostPort(ip, m[0].HostPort)
 }
 
+// HostPortOn returns hostPort, as returned by GetHostPort, with its host replaced by address,
+// e.g. to reach a port published by a remote docker daemon on the address of its machine.
+// hostPort is returned unchanged if address or hostPort is empty.
+func HostPortOn(hostPort, address string) string {
+	if address == "" || hostPort == "" {
+		return hostPort
+	}
+	_, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return hostPort
+	}
+	return net.JoinHostPort(address, port)
+}
+
+// DaemonAddress returns the host of the address of the docker daemon of cli, e.g. 10.0.0.2 for tcp://10.0.0.2:2376,
+// or an empty string if the daemon is reached through a unix socket or a named pipe.
+func DaemonAddress(cli *client.Client) string {
+	u, err := url.Parse(cli.DaemonHost())
+	if err != nil {
+		return ""
+	}
+	switch u.Scheme {
+	case "unix", "npipe":
+		return ""
+	}
+	return u.Hostname()
+}
+
 // Ensure that the global RNG is seeded when this package is imported.
 // Otherwise, each importer would need to seed explicitly on their own.
 //
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
 	"github.com/stretchr/testify/require"
 )
@@ -86,3 +87,22 @@ func TestSanitizeContainerName(t *testing.T) {
 		require.Equal(t, tt.Want, SanitizeContainerName(tt.Name), tt)
 	}
 }
+
+func TestHostPortOn(t *testing.T) {
+	require.Equal(t, "10.0.0.2:26657", HostPortOn("localhost:26657", "10.0.0.2"))
+	require.Equal(t, "[fd00::2]:26657", HostPortOn("localhost:26657", "fd00::2"))
+	require.Equal(t, "localhost:26657", HostPortOn("localhost:26657", ""))
+	require.Equal(t, "", HostPortOn("", "10.0.0.2"))
+}
+
+func TestDaemonAddress(t *testing.T) {
+	for host, want := range map[string]string{
+		"tcp://10.0.0.2:2376":         "10.0.0.2",
+		"ssh://user@docker-2.example": "docker-2.example",
+		"unix:///var/run/docker.sock": "",
+	} {
+		cli, err := client.NewClientWithOpts(client.WithHost(host))
+		require.NoError(t, err, host)
+		require.Equal(t, want, DaemonAddress(cli), host)
+	}
+}
@@ -55,7 +55,7 @@ func SetVolumeOwner(ctx context.Context, opts VolumeOwnerOptions) error {
 			// Root user so we have permissions to set ownership and mode.
 			User: GetRootUserString(),
 
-			Labels: map[string]string{CleanupLabel: opts.TestName},
+			Labels: ResourceLabels(opts.TestName),
 		},
 		&container.HostConfig{
 			Binds:      []string{opts.VolumeName + ":" + mountPath},
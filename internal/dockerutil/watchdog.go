@@ -0,0 +1,139 @@
+package dockerutil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// watchdogLogTail is the number of log lines attached to a ContainerExit.
+const watchdogLogTail = "50"
+
+// expectedStops tracks the IDs of containers that are being stopped deliberately,
+// so that a watchdog does not report their exit.
+var expectedStops = struct {
+	mu  sync.Mutex
+	ids map[string]struct{}
+}{
+	ids: make(map[string]struct{}),
+}
+
+// ExpectContainerStop marks the next exit of the container with the given ID as deliberate,
+// so it is not reported by WatchContainers.
+// The mark is cleared when the container exits or is started again with StartContainer.
+func ExpectContainerStop(id string) {
+	expectedStops.mu.Lock()
+	defer expectedStops.mu.Unlock()
+	expectedStops.ids[id] = struct{}{}
+}
+
+// consumeExpectedStop reports whether the exit of the container with the given ID was expected,
+// clearing the mark.
+func consumeExpectedStop(id string) bool {
+	expectedStops.mu.Lock()
+	defer expectedStops.mu.Unlock()
+	_, ok := expectedStops.ids[id]
+	delete(expectedStops.ids, id)
+	return ok
+}
+
+// StopContainer stops the container with the given ID, waiting up to timeout for it to exit before killing it.
+// The exit is not reported by WatchContainers.
+func StopContainer(ctx context.Context, cli *client.Client, id string, timeout time.Duration) error {
+	ExpectContainerStop(id)
+	return cli.ContainerStop(ctx, id, &timeout)
+}
+
+// ContainerExit describes a container that exited unexpectedly.
+type ContainerExit struct {
+	ID   string
+	Name string
+
+	ExitCode int
+	Time     time.Time
+
+	// The last lines of the container's combined stdout and stderr.
+	Logs string
+}
+
+// WatchContainers calls onExit for every long-running container of the test testName,
+// i.e. every container labeled with NodeOwnerLabel, that exits other than from StopContainer
+// or a call to ExpectContainerStop.
+//
+// It watches until ctx is done or the returned stop function is called,
+// and calls onErr, if not nil, if the subscription fails before then.
+func WatchContainers(ctx context.Context, cli *client.Client, testName string, onExit func(ContainerExit), onErr func(error)) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	msgs, errs := cli.Events(ctx, types.EventsOptions{
+		Filters: filters.NewArgs(
+			filters.Arg("type", events.ContainerEventType),
+			filters.Arg("event", "die"),
+			filters.Arg("label", CleanupLabel+"="+testName),
+			filters.Arg("label", NodeOwnerLabel),
+		),
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case msg := <-msgs:
+				if consumeExpectedStop(msg.Actor.ID) {
+					continue
+				}
+				onExit(containerExit(ctx, cli, msg))
+			case err := <-errs:
+				if onErr != nil && ctx.Err() == nil && !errors.Is(err, context.Canceled) {
+					onErr(err)
+				}
+				return
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// containerExit builds the ContainerExit of a die event, with the container's last log lines.
+// Failing to retrieve the logs is reported in place of the logs.
+func containerExit(ctx context.Context, cli *client.Client, msg events.Message) ContainerExit {
+	exit := ContainerExit{
+		ID:   msg.Actor.ID,
+		Name: msg.Actor.Attributes["name"],
+		Time: time.Unix(0, msg.TimeNano),
+	}
+	exit.ExitCode, _ = strconv.Atoi(msg.Actor.Attributes["exitCode"])
+
+	rc, err := cli.ContainerLogs(ctx, msg.Actor.ID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       watchdogLogTail,
+	})
+	if err != nil {
+		exit.Logs = "failed to retrieve container logs: " + err.Error()
+		return exit
+	}
+	defer rc.Close()
+
+	buf := new(bytes.Buffer)
+	if _, err := stdcopy.StdCopy(buf, buf, rc); err != nil {
+		exit.Logs = "failed to read container logs: " + err.Error()
+		return exit
+	}
+	exit.Logs = strings.TrimSpace(buf.String())
+	return exit
+}
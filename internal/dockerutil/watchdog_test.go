@@ -0,0 +1,76 @@
+package dockerutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchContainers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	t.Parallel()
+
+	ctx := context.Background()
+	cli, _ := DockerSetup(t)
+	require.NoError(t, ensureBusybox(ctx, cli))
+
+	exits := make(chan ContainerExit, 2)
+	stop := WatchContainers(ctx, cli, t.Name(), func(exit ContainerExit) { exits <- exit }, func(err error) {
+		t.Errorf("watchdog failed: %v", err)
+	})
+	defer stop()
+
+	stopped := startWatchedContainer(ctx, t, cli, "stopped")
+	require.NoError(t, StopContainer(ctx, cli, stopped, 5*time.Second))
+
+	killed := startWatchedContainer(ctx, t, cli, "killed")
+	require.NoError(t, cli.ContainerKill(ctx, killed, "SIGKILL"))
+
+	select {
+	case exit := <-exits:
+		require.Equal(t, killed, exit.ID)
+		require.Equal(t, 137, exit.ExitCode)
+		require.Contains(t, exit.Logs, "watched container killed")
+	case <-time.After(10 * time.Second):
+		t.Fatal("watchdog did not report the killed container")
+	}
+
+	select {
+	case exit := <-exits:
+		t.Fatalf("watchdog reported unexpected exit of %s", exit.Name)
+	case <-time.After(time.Second):
+	}
+}
+
+// startWatchedContainer starts a long-running busybox container for the test, labeled for WatchContainers.
+func startWatchedContainer(ctx context.Context, t *testing.T, cli *client.Client, name string) string {
+	t.Helper()
+
+	cc, err := cli.ContainerCreate(
+		ctx,
+		&container.Config{
+			Image: busyboxRef,
+
+			Entrypoint: []string{"sh", "-c"},
+			Cmd:        []string{"echo 'watched container " + name + "' && sleep 600"},
+
+			Labels: map[string]string{
+				CleanupLabel:   t.Name(),
+				NodeOwnerLabel: name,
+			},
+		},
+		nil,
+		nil, // No networking necessary.
+		nil,
+		SanitizeContainerName(t.Name()+"-"+name),
+	)
+	require.NoError(t, err)
+	require.NoError(t, StartContainer(ctx, cli, cc.ID))
+	return cc.ID
+}
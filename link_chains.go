@@ -0,0 +1,69 @@
+package interchaintest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+)
+
+// LinkChains establishes an IBC path named pathName between two running chains with the relayer r,
+// e.g. to link chains of separately built Interchains, each set up by its own test fixture.
+//
+// LinkChains configures r for both chains, funds a new relayer key on each chain with DefaultRelayerFunding
+// from the chain's faucet, and creates the clients, connection, and an ICS-20 transfer channel of the path,
+// with a trusting period derived from the chains' unbonding times.
+// r must not be configured for either chain yet, i.e. it should not be a relayer of the chains' Interchains.
+func LinkChains(ctx context.Context, rep *testreporter.RelayerExecReporter, r ibc.Relayer, chainA, chainB ibc.Chain, pathName string) error {
+	// Relayers may not support concurrent configuration, see configureRelayerKeys.
+	for _, c := range []ibc.Chain{chainA, chainB} {
+		if err := configureRelayerChain(ctx, rep, r, c); err != nil {
+			return err
+		}
+	}
+
+	chainIDA, chainIDB := chainA.Config().ChainID, chainB.Config().ChainID
+	if err := r.GeneratePath(ctx, rep, chainIDA, chainIDB, pathName); err != nil {
+		return fmt.Errorf("failed to generate path %s between chains %s and %s: %w", pathName, chainIDA, chainIDB, err)
+	}
+
+	clientOpts, err := ClientOptsFromUnbonding(ctx, chainA, chainB)
+	if err != nil {
+		return err
+	}
+	if err := r.LinkPath(ctx, rep, pathName, ibc.DefaultChannelOpts(), clientOpts); err != nil {
+		return fmt.Errorf("failed to link path %s between chains %s and %s: %w", pathName, chainIDA, chainIDB, err)
+	}
+	return nil
+}
+
+// configureRelayerChain adds the configuration of the running chain c to r,
+// with a new relayer key funded from the chain's faucet.
+func configureRelayerChain(ctx context.Context, rep *testreporter.RelayerExecReporter, r ibc.Relayer, c ibc.Chain) error {
+	cfg := c.Config()
+
+	rpcAddr, grpcAddr := c.GetRPCAddress(), c.GetGRPCAddress()
+	if !r.UseDockerNetwork() {
+		rpcAddr, grpcAddr = c.GetHostRPCAddress(), c.GetHostGRPCAddress()
+	}
+
+	// Chain IDs are unique among the chains of a relayer, unlike chain names across Interchains.
+	keyName := cfg.ChainID
+	if err := r.AddChainConfiguration(ctx, rep, cfg, keyName, rpcAddr, grpcAddr); err != nil {
+		return fmt.Errorf("failed to configure relayer for chain %s: %w", cfg.ChainID, err)
+	}
+
+	wallet, err := r.AddKey(ctx, rep, cfg.ChainID, keyName, cfg.CoinType)
+	if err != nil {
+		return fmt.Errorf("failed to add relayer key for chain %s: %w", cfg.ChainID, err)
+	}
+	if err := sendFaucetFunds(ctx, c, ibc.WalletAmount{
+		Address: wallet.FormattedAddress(),
+		Denom:   cfg.Denom,
+		Amount:  DefaultRelayerFunding,
+	}); err != nil {
+		return fmt.Errorf("failed to fund relayer wallet on chain %s: %w", cfg.ChainID, err)
+	}
+	return nil
+}
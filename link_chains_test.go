@@ -0,0 +1,100 @@
+package interchaintest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+// linkChain is a running ibc.Chain recording the funds sent from it.
+// Calling any other method panics.
+type linkChain struct {
+	ibc.Chain
+
+	id    string
+	sends []ibc.WalletAmount
+}
+
+func (c *linkChain) Config() ibc.ChainConfig {
+	return ibc.ChainConfig{ChainID: c.id, Denom: "u" + c.id, CoinType: "118"}
+}
+
+func (c *linkChain) GetRPCAddress() string      { return "http://" + c.id + ":26657" }
+func (c *linkChain) GetGRPCAddress() string     { return c.id + ":9090" }
+func (c *linkChain) GetHostRPCAddress() string  { return "http://127.0.0.1:26657" }
+func (c *linkChain) GetHostGRPCAddress() string { return "127.0.0.1:9090" }
+
+func (c *linkChain) SendFunds(_ context.Context, keyName string, amount ibc.WalletAmount) error {
+	if keyName != FaucetAccountKeyName {
+		panic("unexpected key " + keyName)
+	}
+	c.sends = append(c.sends, amount)
+	return nil
+}
+
+// linkRelayer is an ibc.Relayer recording the calls of LinkChains.
+// Calling any other method panics.
+type linkRelayer struct {
+	ibc.Relayer
+
+	calls     []string
+	addKeyErr error
+}
+
+func (r *linkRelayer) UseDockerNetwork() bool { return true }
+
+func (r *linkRelayer) AddChainConfiguration(_ context.Context, _ ibc.RelayerExecReporter, cfg ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) error {
+	r.calls = append(r.calls, "configure "+cfg.ChainID+" "+keyName+" "+rpcAddr+" "+grpcAddr)
+	return nil
+}
+
+func (r *linkRelayer) AddKey(_ context.Context, _ ibc.RelayerExecReporter, chainID, keyName, _ string) (ibc.Wallet, error) {
+	if r.addKeyErr != nil {
+		return nil, r.addKeyErr
+	}
+	r.calls = append(r.calls, "add key "+chainID+" "+keyName)
+	return sweepWallet{name: "relayer-" + chainID}, nil
+}
+
+func (r *linkRelayer) GeneratePath(_ context.Context, _ ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string) error {
+	r.calls = append(r.calls, "generate path "+pathName+" "+srcChainID+" "+dstChainID)
+	return nil
+}
+
+func (r *linkRelayer) LinkPath(_ context.Context, _ ibc.RelayerExecReporter, pathName string, channelOpts ibc.CreateChannelOptions, clientOpts ibc.CreateClientOptions) error {
+	if channelOpts != ibc.DefaultChannelOpts() || clientOpts != ibc.DefaultClientOpts() {
+		panic("unexpected link options")
+	}
+	r.calls = append(r.calls, "link path "+pathName)
+	return nil
+}
+
+func TestLinkChains(t *testing.T) {
+	a, b := &linkChain{id: "a"}, &linkChain{id: "b"}
+	r := &linkRelayer{}
+
+	require.NoError(t, LinkChains(context.Background(), nil, r, a, b, "a-b"))
+
+	require.Equal(t, []string{
+		"configure a a http://a:26657 a:9090",
+		"add key a a",
+		"configure b b http://b:26657 b:9090",
+		"add key b b",
+		"generate path a-b a b",
+		"link path a-b",
+	}, r.calls)
+
+	require.Equal(t, []ibc.WalletAmount{{Address: "relayer-a-addr", Denom: "ua", Amount: DefaultRelayerFunding}}, a.sends)
+	require.Equal(t, []ibc.WalletAmount{{Address: "relayer-b-addr", Denom: "ub", Amount: DefaultRelayerFunding}}, b.sends)
+}
+
+func TestLinkChains_Error(t *testing.T) {
+	r := &linkRelayer{addKeyErr: errors.New("key exists")}
+
+	err := LinkChains(context.Background(), nil, r, &linkChain{id: "a"}, &linkChain{id: "b"}, "a-b")
+	require.ErrorContains(t, err, "failed to add relayer key for chain a: key exists")
+	require.Equal(t, []string{"configure a a http://a:26657 a:9090"}, r.calls, "path must not be created")
+}
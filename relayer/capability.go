@@ -18,6 +18,9 @@ const (
 	// Whether the relayer supports a one-off flush packets or flush acknowledgements command.
 	FlushPackets
 	FlushAcknowledgements
+
+	// Whether the running relayer completes the handshakes of channel upgrades started on either chain.
+	ChannelUpgrade
 )
 
 // FullCapabilities returns a mapping of all known relayer features to true,
@@ -31,5 +34,7 @@ func FullCapabilities() map[Capability]bool {
 
 		FlushPackets:          true,
 		FlushAcknowledgements: true,
+
+		ChannelUpgrade: true,
 	}
 }
@@ -12,11 +12,12 @@ func _() {
 	_ = x[HeightTimeout-1]
 	_ = x[FlushPackets-2]
 	_ = x[FlushAcknowledgements-3]
+	_ = x[ChannelUpgrade-4]
 }
 
-const _Capability_name = "TimestampTimeoutHeightTimeoutFlushPacketsFlushAcknowledgements"
+const _Capability_name = "TimestampTimeoutHeightTimeoutFlushPacketsFlushAcknowledgementsChannelUpgrade"
 
-var _Capability_index = [...]uint8{0, 16, 29, 41, 62}
+var _Capability_index = [...]uint8{0, 16, 29, 41, 62, 76}
 
 func (i Capability) String() string {
 	if i < 0 || i >= Capability(len(_Capability_index)-1) {
@@ -0,0 +1,36 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// ChannelCloseCommander is implemented by a RelayerCommander whose relayer can close channels, as required by CloseChannel.
+type ChannelCloseCommander interface {
+	// CloseChannel is the command closing the channel with channelID and portID on the source chain of pathName,
+	// with MsgChannelCloseInit on the source chain and MsgChannelCloseConfirm on the destination chain.
+	CloseChannel(pathName, channelID, portID, homeDir string) []string
+}
+
+// ChannelCloser is implemented by a relayer that can close a channel, such as DockerRelayer.
+// Only applications that allow it can have their channels closed, e.g. the ibc-go mock module but not ICS-20 transfer.
+type ChannelCloser interface {
+	ibc.Relayer
+
+	// CloseChannel closes the channel with channelID and portID on the source chain of pathName, and its counterparty end.
+	CloseChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID, portID string) error
+}
+
+var _ ChannelCloser = (*DockerRelayer)(nil)
+
+// CloseChannel closes the channel, which requires the relayer implementation to support it, see ChannelCloseCommander.
+func (r *DockerRelayer) CloseChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID, portID string) error {
+	cc, ok := r.c.(ChannelCloseCommander)
+	if !ok {
+		return fmt.Errorf("relayer %s does not support closing channels", r.c.Name())
+	}
+	res := r.Exec(ctx, rep, cc.CloseChannel(pathName, channelID, portID, r.HomeDir()), nil)
+	return res.Err
+}
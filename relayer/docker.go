@@ -15,6 +15,7 @@ import (
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
 	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
 	"go.uber.org/zap"
@@ -36,12 +37,28 @@ type DockerRelayer struct {
 
 	customImage *ibc.DockerImage
 	pullImage   bool
+	metrics     bool
+
+	// The timeout of each operation run through Exec, if non-zero.
+	opTimeout time.Duration
+
+	// The keys and paths to use instead of generating them, if non-nil.
+	preseeded *PreseededConfig
+
+	// The host address of the metrics endpoint, set by StartRelayer when metrics are enabled.
+	hostMetricsAddr string
 
 	// The ID of the container created by StartRelayer.
 	containerID string
 
 	// wallets contains a mapping of chainID to relayer wallet
 	wallets map[string]ibc.Wallet
+
+	// The gas overrides of the relayer config by chain ID.
+	gasOverrides map[string]RelayerOptionChainGas
+
+	// The chain configurations added by AddChainConfiguration by chain ID, to add them again with new gas overrides.
+	chainConfigs map[string]chainConfiguration
 }
 
 var _ ibc.Relayer = (*DockerRelayer)(nil)
@@ -62,14 +79,44 @@ func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli
 		testName: testName,
 
 		wallets: map[string]ibc.Wallet{},
+
+		gasOverrides: map[string]RelayerOptionChainGas{},
+		chainConfigs: map[string]chainConfiguration{},
 	}
 
+	var processor *RelayerOptionProcessor
 	for _, opt := range options {
 		switch o := opt.(type) {
+		case RelayerOptionProcessor:
+			if processor != nil {
+				return nil, fmt.Errorf("relayer processor options %s and %s cannot be combined", processor.Processor, o.Processor)
+			}
+			if o.BlockHistory < 0 {
+				return nil, fmt.Errorf("relayer block history must not be negative, got %d", o.BlockHistory)
+			}
+			processor = &o
 		case RelayerOptionDockerImage:
 			r.customImage = &o.DockerImage
 		case RelayerOptionImagePull:
 			r.pullImage = o.Pull
+		case RelayerOptionMetrics:
+			if _, ok := c.(MetricsCommander); !ok {
+				return nil, fmt.Errorf("relayer %s does not support metrics", c.Name())
+			}
+			r.metrics = true
+		case RelayerOptionOperationTimeout:
+			r.opTimeout = o.Timeout
+		case RelayerOptionPreseededConfig:
+			if _, ok := c.(PathFileCommander); !ok {
+				return nil, fmt.Errorf("relayer %s does not support preseeded paths", c.Name())
+			}
+			preseeded, err := LoadPreseededConfig(o.Dir)
+			if err != nil {
+				return nil, err
+			}
+			r.preseeded = &preseeded
+		case RelayerOptionChainGas:
+			r.gasOverrides[o.ChainID] = o
 		}
 	}
 
@@ -81,7 +128,7 @@ func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli
 	v, err := cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
 		// Have to leave Driver unspecified for Docker Desktop compatibility.
 
-		Labels: map[string]string{dockerutil.CleanupLabel: testName},
+		Labels: dockerutil.ResourceLabels(testName),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("creating volume: %w", err)
@@ -123,6 +170,21 @@ func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli
 }
 
 func (r *DockerRelayer) AddChainConfiguration(ctx context.Context, rep ibc.RelayerExecReporter, chainConfig ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) error {
+	cc := chainConfiguration{cfg: chainConfig, keyName: keyName, rpcAddr: rpcAddr, grpcAddr: grpcAddr}
+	if err := r.addChainConfiguration(ctx, rep, cc); err != nil {
+		return err
+	}
+	r.chainConfigs[chainConfig.ChainID] = cc
+	return nil
+}
+
+func (r *DockerRelayer) addChainConfiguration(ctx context.Context, rep ibc.RelayerExecReporter, cc chainConfiguration) error {
+	chainConfig := cc.cfg
+	if o, ok := r.gasOverrides[chainConfig.ChainID]; ok {
+		chainConfig = o.apply(chainConfig)
+	}
+	keyName, rpcAddr, grpcAddr := cc.keyName, cc.rpcAddr, cc.grpcAddr
+
 	// For rly this file is json, but the file extension should not matter.
 	// Using .config to avoid implying any particular format.
 	chainConfigFile := chainConfig.ChainID + ".config"
@@ -206,14 +268,73 @@ func (r *DockerRelayer) FlushPackets(ctx context.Context, rep ibc.RelayerExecRep
 	return res.Err
 }
 
+func (r *DockerRelayer) GetPendingPackets(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) (ibc.PendingPackets, error) {
+	var pending ibc.PendingPackets
+
+	res := r.Exec(ctx, rep, r.c.UnrelayedPackets(pathName, channelID, r.HomeDir()), nil)
+	if res.Err != nil {
+		return pending, res.Err
+	}
+	src, dst, err := r.c.ParseUnrelayedSequencesOutput(string(res.Stdout), string(res.Stderr))
+	if err != nil {
+		return pending, fmt.Errorf("failed to parse unrelayed packets: %w", err)
+	}
+	pending.SrcPackets, pending.DstPackets = src, dst
+
+	res = r.Exec(ctx, rep, r.c.UnrelayedAcknowledgements(pathName, channelID, r.HomeDir()), nil)
+	if res.Err != nil {
+		return pending, res.Err
+	}
+	src, dst, err = r.c.ParseUnrelayedSequencesOutput(string(res.Stdout), string(res.Stderr))
+	if err != nil {
+		return pending, fmt.Errorf("failed to parse unrelayed acknowledgements: %w", err)
+	}
+	pending.SrcAcks, pending.DstAcks = src, dst
+
+	return pending, nil
+}
+
+// GeneratePath generates the path pathName between the chains,
+// or adds it from the path file of the preseeded config, if the relayer has one.
 func (r *DockerRelayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string) error {
+	if r.preseeded != nil {
+		return r.addPreseededPath(ctx, rep, srcChainID, dstChainID, pathName)
+	}
 	cmd := r.c.GeneratePath(srcChainID, dstChainID, pathName, r.HomeDir())
 	res := r.Exec(ctx, rep, cmd, nil)
 	return res.Err
 }
 
-func (r *DockerRelayer) UpdatePath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, filter ibc.ChannelFilter) error {
-	cmd := r.c.UpdatePath(pathName, r.HomeDir(), filter)
+// Preseeded returns the preseeded config of the relayer, if it was constructed with PreseededConfigDir.
+func (r *DockerRelayer) Preseeded() (PreseededConfig, bool) {
+	if r.preseeded == nil {
+		return PreseededConfig{}, false
+	}
+	return *r.preseeded, true
+}
+
+func (r *DockerRelayer) addPreseededPath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string) error {
+	p, ok := r.preseeded.Paths[pathName]
+	if !ok {
+		return fmt.Errorf("no preseeded path %s", pathName)
+	}
+	if p.SrcChainID != srcChainID || p.DstChainID != dstChainID {
+		return fmt.Errorf("preseeded path %s links %s to %s, expected %s to %s", pathName, p.SrcChainID, p.DstChainID, srcChainID, dstChainID)
+	}
+
+	pathFile := path.Join("preseeded", pathName+".json")
+	fw := dockerutil.NewFileWriter(r.log, r.client, r.testName)
+	if err := fw.WriteFile(ctx, r.volumeName, pathFile, p.File); err != nil {
+		return fmt.Errorf("failed to write preseeded path %s: %w", pathName, err)
+	}
+
+	cmd := r.c.(PathFileCommander).AddPathFile(srcChainID, dstChainID, pathName, path.Join(r.HomeDir(), pathFile), r.HomeDir())
+	res := r.Exec(ctx, rep, cmd, nil)
+	return res.Err
+}
+
+func (r *DockerRelayer) UpdatePath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.PathUpdateOptions) error {
+	cmd := r.c.UpdatePath(pathName, r.HomeDir(), opts)
 	res := r.Exec(ctx, rep, cmd, nil)
 	return res.Err
 }
@@ -253,13 +374,31 @@ func (r *DockerRelayer) GetClients(ctx context.Context, rep ibc.RelayerExecRepor
 	return r.c.ParseGetClientsOutput(string(res.Stdout), string(res.Stderr))
 }
 
+// LinkPath creates clients, a connection and a channel on the path.
+// Existing clients and connections set in clientOpts are written to the path first,
+// so that the relayer reuses them instead of running their handshakes.
 func (r *DockerRelayer) LinkPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, channelOpts ibc.CreateChannelOptions, clientOpts ibc.CreateClientOptions) error {
+	if upd, ok := clientOpts.ExistingPathUpdate(); ok {
+		if err := r.UpdatePath(ctx, rep, pathName, upd); err != nil {
+			return fmt.Errorf("failed to set existing clients of path %s: %w", pathName, err)
+		}
+	}
+
 	cmd := r.c.LinkPath(pathName, r.HomeDir(), channelOpts, clientOpts)
 	res := r.Exec(ctx, rep, cmd, nil)
 	return res.Err
 }
 
+// Exec runs cmd in a one-off relayer container.
+// The container is killed once ctx is done, or once the operation timeout set with OperationTimeout passes.
 func (r *DockerRelayer) Exec(ctx context.Context, rep ibc.RelayerExecReporter, cmd []string, env []string) ibc.RelayerExecResult {
+	parentCtx := ctx
+	if r.opTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.opTimeout)
+		defer cancel()
+	}
+
 	job := dockerutil.NewImage(r.log, r.client, r.networkID, r.testName, r.containerImage().Repository, r.containerImage().Version)
 	opts := dockerutil.ContainerOptions{
 		Env:   env,
@@ -268,6 +407,9 @@ func (r *DockerRelayer) Exec(ctx context.Context, rep ibc.RelayerExecReporter, c
 
 	startedAt := time.Now()
 	res := job.Run(ctx, cmd, opts)
+	if res.Err != nil && parentCtx.Err() == nil && ctx.Err() != nil {
+		res.Err = fmt.Errorf("%s operation exceeded timeout of %s: %w", r.Name(), r.opTimeout, res.Err)
+	}
 
 	defer func() {
 		rep.TrackRelayerExec(
@@ -322,6 +464,7 @@ func (r *DockerRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecRepo
 	if err := r.stopContainer(ctx); err != nil {
 		return err
 	}
+	r.hostMetricsAddr = ""
 
 	stdoutBuf := new(bytes.Buffer)
 	stderrBuf := new(bytes.Buffer)
@@ -420,6 +563,12 @@ func (r *DockerRelayer) createNodeContainer(ctx context.Context, pathNames ...st
 		zap.String("command", strings.Join(cmd, " ")),
 		zap.String("container", containerName),
 	)
+
+	var exposedPorts nat.PortSet
+	if r.metrics {
+		exposedPorts = nat.PortSet{nat.Port(r.c.(MetricsCommander).MetricsPort()): {}}
+	}
+
 	cc, err := r.client.ContainerCreate(
 		ctx,
 		&container.Config{
@@ -431,11 +580,14 @@ func (r *DockerRelayer) createNodeContainer(ctx context.Context, pathNames ...st
 			Hostname: r.HostName(joinedPaths),
 			User:     r.c.DockerUser(),
 
-			Labels: map[string]string{dockerutil.CleanupLabel: r.testName},
+			Labels: dockerutil.NodeResourceLabels(r.testName, r.Name()),
+
+			ExposedPorts: exposedPorts,
 		},
 		&container.HostConfig{
-			Binds:      r.Bind(),
-			AutoRemove: false,
+			Binds:           r.Bind(),
+			PublishAllPorts: r.metrics,
+			AutoRemove:      false,
 		},
 		&network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
@@ -450,12 +602,24 @@ func (r *DockerRelayer) createNodeContainer(ctx context.Context, pathNames ...st
 	}
 
 	r.containerID = cc.ID
-	return dockerutil.StartContainer(ctx, r.client, r.containerID)
+	if err := dockerutil.StartContainer(ctx, r.client, r.containerID); err != nil {
+		return err
+	}
+
+	if r.metrics {
+		c, err := r.client.ContainerInspect(ctx, r.containerID)
+		if err != nil {
+			return fmt.Errorf("inspecting relayer container: %w", err)
+		}
+		r.hostMetricsAddr = dockerutil.GetHostPort(c, r.c.(MetricsCommander).MetricsPort())
+	}
+
+	return nil
 }
 
 func (r *DockerRelayer) stopContainer(ctx context.Context) error {
 	timeout := 30 * time.Second
-	return r.client.ContainerStop(ctx, r.containerID, &timeout)
+	return dockerutil.StopContainer(ctx, r.client, r.containerID, timeout)
 }
 
 func (r *DockerRelayer) Name() string {
@@ -513,6 +677,10 @@ type RelayerCommander interface {
 	// to produce the client output values.
 	ParseGetClientsOutput(stdout, stderr string) (ibc.ClientOutputs, error)
 
+	// ParseUnrelayedSequencesOutput processes the output of UnrelayedPackets or UnrelayedAcknowledgements
+	// to produce the pending sequences on the source and destination chains.
+	ParseUnrelayedSequencesOutput(stdout, stderr string) (src, dst []uint64, err error)
+
 	// Init is the command to run on the first call to AddChainConfiguration.
 	// If the returned command is nil or empty, nothing will be executed.
 	Init(homeDir string) []string
@@ -527,7 +695,7 @@ type RelayerCommander interface {
 	FlushAcknowledgements(pathName, channelID, homeDir string) []string
 	FlushPackets(pathName, channelID, homeDir string) []string
 	GeneratePath(srcChainID, dstChainID, pathName, homeDir string) []string
-	UpdatePath(pathName, homeDir string, filter ibc.ChannelFilter) []string
+	UpdatePath(pathName, homeDir string, opts ibc.PathUpdateOptions) []string
 	GetChannels(chainID, homeDir string) []string
 	GetConnections(chainID, homeDir string) []string
 	GetClients(chainID, homeDir string) []string
@@ -535,5 +703,7 @@ type RelayerCommander interface {
 	RestoreKey(chainID, keyName, coinType, mnemonic, homeDir string) []string
 	StartRelayer(homeDir string, pathNames ...string) []string
 	UpdateClients(pathName, homeDir string) []string
+	UnrelayedPackets(pathName, channelID, homeDir string) []string
+	UnrelayedAcknowledgements(pathName, channelID, homeDir string) []string
 	CreateWallet(keyName, address, mnemonic string) ibc.Wallet
 }
@@ -0,0 +1,74 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// ChainRemoveCommander is implemented by a RelayerCommander whose relayer can remove a chain from its config,
+// as required by SetChainGasOverride to add the chain again with other gas settings.
+type ChainRemoveCommander interface {
+	// RemoveChainConfiguration is the command removing the chain with the chain ID from the relayer config.
+	// It must keep the relayer keys and the paths of the chain.
+	RemoveChainConfiguration(chainID, homeDir string) []string
+}
+
+// GasOverrideRelayer is implemented by a relayer whose gas settings of a chain can be changed once it is configured,
+// such as DockerRelayer.
+type GasOverrideRelayer interface {
+	ibc.Relayer
+
+	// SetChainGasOverride replaces the gas prices and gas adjustment of the relayer on the chain chainID.
+	SetChainGasOverride(ctx context.Context, rep ibc.RelayerExecReporter, chainID, gasPrices string, gasAdjustment float64) error
+}
+
+var _ GasOverrideRelayer = (*DockerRelayer)(nil)
+
+// chainConfiguration is the configuration of a chain added with AddChainConfiguration.
+type chainConfiguration struct {
+	cfg                        ibc.ChainConfig
+	keyName, rpcAddr, grpcAddr string
+}
+
+// apply returns cfg with the gas prices and gas adjustment of o, where they are set.
+func (o RelayerOptionChainGas) apply(cfg ibc.ChainConfig) ibc.ChainConfig {
+	if o.GasPrices != "" {
+		cfg.GasPrices = o.GasPrices
+	}
+	if o.GasAdjustment != 0 {
+		cfg.GasAdjustment = o.GasAdjustment
+	}
+	return cfg
+}
+
+// SetChainGasOverride replaces the gas override of the chain chainID, as set with the ChainGasOverride option.
+// If the chain is already configured, its configuration is removed from the relayer and added again with the override,
+// which requires the relayer implementation to support it, see ChainRemoveCommander.
+// A started relayer must be restarted with StopRelayer and StartRelayer to use the new gas settings.
+func (r *DockerRelayer) SetChainGasOverride(ctx context.Context, rep ibc.RelayerExecReporter, chainID, gasPrices string, gasAdjustment float64) error {
+	r.gasOverrides[chainID] = RelayerOptionChainGas{
+		ChainID:       chainID,
+		GasPrices:     gasPrices,
+		GasAdjustment: gasAdjustment,
+	}
+
+	cc, ok := r.chainConfigs[chainID]
+	if !ok {
+		return nil
+	}
+	rc, ok := r.c.(ChainRemoveCommander)
+	if !ok {
+		return fmt.Errorf("relayer %s does not support removing chains", r.c.Name())
+	}
+
+	// Removing the chain only edits the config on disk, so this should complete immediately.
+	removeCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	if res := r.Exec(removeCtx, rep, rc.RemoveChainConfiguration(chainID, r.HomeDir()), nil); res.Err != nil {
+		return fmt.Errorf("failed to remove chain %s: %w", chainID, res.Err)
+	}
+	return r.addChainConfiguration(ctx, rep, cc)
+}
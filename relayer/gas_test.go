@@ -0,0 +1,28 @@
+package relayer
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayerOptionChainGas_apply(t *testing.T) {
+	cfg := ibc.ChainConfig{
+		ChainID:       "gaia-1",
+		GasPrices:     "0.01uatom",
+		GasAdjustment: 1.3,
+	}
+
+	got := ChainGasOverride("gaia-1", "0.0001uatom", 2).(RelayerOptionChainGas).apply(cfg)
+	require.Equal(t, "0.0001uatom", got.GasPrices)
+	require.Equal(t, 2.0, got.GasAdjustment)
+	require.Equal(t, "0.01uatom", cfg.GasPrices, "chain config must not be modified")
+
+	got = ChainGasOverride("gaia-1", "", 0).(RelayerOptionChainGas).apply(cfg)
+	require.Equal(t, cfg, got)
+
+	got = ChainGasOverride("gaia-1", "", 1.5).(RelayerOptionChainGas).apply(cfg)
+	require.Equal(t, "0.01uatom", got.GasPrices)
+	require.Equal(t, 1.5, got.GasAdjustment)
+}
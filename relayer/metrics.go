@@ -0,0 +1,85 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// MetricsCommander is implemented by a RelayerCommander whose relayer can serve Prometheus metrics.
+// The commander is responsible for enabling the metrics server in its StartRelayer command
+// when constructed with the EnableMetrics option.
+type MetricsCommander interface {
+	// MetricsPort is the container port serving metrics, in the "port/tcp" format.
+	MetricsPort() string
+
+	// MetricsPath is the HTTP path of the metrics endpoint, e.g. "/metrics".
+	MetricsPath() string
+}
+
+// ErrMetricsNotEnabled is returned by Metrics when the relayer was not
+// constructed with the EnableMetrics option, or when the relayer has not been started.
+var ErrMetricsNotEnabled = errors.New("relayer metrics not enabled or relayer not started")
+
+// Metrics scrapes the Prometheus endpoint of the running relayer.
+// The returned map is keyed by metric name, and each value is the sum of the metric across all label sets,
+// e.g. the total number of packets relayed on every path.
+// Histograms and summaries are reported through their _sum and _count series.
+func (r *DockerRelayer) Metrics(ctx context.Context) (map[string]float64, error) {
+	if r.hostMetricsAddr == "" {
+		return nil, ErrMetricsNotEnabled
+	}
+
+	mc := r.c.(MetricsCommander)
+	url := "http://" + r.hostMetricsAddr + mc.MetricsPath()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("scraping relayer metrics: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scraping relayer metrics: unexpected status %s", res.Status)
+	}
+
+	return parseMetrics(res.Body)
+}
+
+// parseMetrics parses metrics in the Prometheus text exposition format.
+func parseMetrics(r io.Reader) (map[string]float64, error) {
+	var p expfmt.TextParser
+	families, err := p.TextToMetricFamilies(r)
+	if err != nil {
+		return nil, fmt.Errorf("parsing relayer metrics: %w", err)
+	}
+
+	out := make(map[string]float64, len(families))
+	for name, mf := range families {
+		for _, m := range mf.Metric {
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				out[name] += m.GetCounter().GetValue()
+			case dto.MetricType_GAUGE:
+				out[name] += m.GetGauge().GetValue()
+			case dto.MetricType_UNTYPED:
+				out[name] += m.GetUntyped().GetValue()
+			case dto.MetricType_HISTOGRAM:
+				out[name+"_sum"] += m.GetHistogram().GetSampleSum()
+				out[name+"_count"] += float64(m.GetHistogram().GetSampleCount())
+			case dto.MetricType_SUMMARY:
+				out[name+"_sum"] += m.GetSummary().GetSampleSum()
+				out[name+"_count"] += float64(m.GetSummary().GetSampleCount())
+			}
+		}
+	}
+	return out, nil
+}
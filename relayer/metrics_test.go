@@ -0,0 +1,38 @@
+package relayer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMetrics(t *testing.T) {
+	const in = `# HELP cosmos_relayer_observed_packets The total number of observed packets
+# TYPE cosmos_relayer_observed_packets counter
+cosmos_relayer_observed_packets{chain="gaia-1",channel="channel-0",path="gaia-osmo",port="transfer",type="MsgTransfer"} 3
+cosmos_relayer_observed_packets{chain="osmosis-1",channel="channel-0",path="gaia-osmo",port="transfer",type="MsgAcknowledgement"} 2
+# HELP cosmos_relayer_chain_latest_height The current height of the chain
+# TYPE cosmos_relayer_chain_latest_height gauge
+cosmos_relayer_chain_latest_height{chain="gaia-1"} 42
+# HELP cosmos_relayer_tx_duration Time spent broadcasting transactions
+# TYPE cosmos_relayer_tx_duration histogram
+cosmos_relayer_tx_duration_bucket{le="1"} 1
+cosmos_relayer_tx_duration_bucket{le="+Inf"} 2
+cosmos_relayer_tx_duration_sum 2.5
+cosmos_relayer_tx_duration_count 2
+`
+
+	m, err := parseMetrics(strings.NewReader(in))
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]float64{
+		"cosmos_relayer_observed_packets":    5,
+		"cosmos_relayer_chain_latest_height": 42,
+		"cosmos_relayer_tx_duration_sum":     2.5,
+		"cosmos_relayer_tx_duration_count":   2,
+	}, m)
+
+	_, err = parseMetrics(strings.NewReader("not a metric line at all {"))
+	require.Error(t, err)
+}
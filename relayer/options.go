@@ -1,6 +1,8 @@
 package relayer
 
 import (
+	"time"
+
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
 )
 
@@ -55,3 +57,99 @@ func StartupFlags(flags ...string) RelayerOption {
 }
 
 func (opt RelayerOptionExtraStartFlags) relayerOption() {}
+
+// Names of the processors of RelayerOptionProcessor.
+const (
+	ProcessorNameEvents = "events"
+	ProcessorNameLegacy = "legacy"
+)
+
+type RelayerOptionProcessor struct {
+	// Processor is ProcessorNameEvents or ProcessorNameLegacy.
+	Processor string
+	// BlockHistory is the number of past blocks the events processor queries on start,
+	// or zero for the default of the relayer.
+	BlockHistory int
+}
+
+// ProcessorEvents starts the relayer with its event processor, querying blockHistory past blocks on start,
+// or the default of the relayer if blockHistory is zero.
+// Relayers without a choice of processor ignore it. It cannot be combined with ProcessorLegacy.
+func ProcessorEvents(blockHistory int) RelayerOption {
+	return RelayerOptionProcessor{
+		Processor:    ProcessorNameEvents,
+		BlockHistory: blockHistory,
+	}
+}
+
+// ProcessorLegacy starts the relayer with its legacy processor.
+// Relayers without a choice of processor ignore it. It cannot be combined with ProcessorEvents.
+func ProcessorLegacy() RelayerOption {
+	return RelayerOptionProcessor{
+		Processor: ProcessorNameLegacy,
+	}
+}
+
+func (opt RelayerOptionProcessor) relayerOption() {}
+
+type RelayerOptionMetrics struct{}
+
+// EnableMetrics serves the relayer's Prometheus metrics while it is started,
+// so that they can be scraped through (*DockerRelayer).Metrics.
+// The relayer implementation must support metrics.
+func EnableMetrics() RelayerOption {
+	return RelayerOptionMetrics{}
+}
+
+func (opt RelayerOptionMetrics) relayerOption() {}
+
+type RelayerOptionOperationTimeout struct {
+	Timeout time.Duration
+}
+
+// OperationTimeout limits each relayer operation, such as creating clients or relaying packets,
+// to timeout, e.g. 2 minutes, so that a hung relayer fails the operation rather than the whole test.
+// It does not limit the relayer started by StartRelayer.
+// A timeout of zero, the default, does not limit operations beyond the deadline of their context.
+func OperationTimeout(timeout time.Duration) RelayerOption {
+	return RelayerOptionOperationTimeout{
+		Timeout: timeout,
+	}
+}
+
+func (opt RelayerOptionOperationTimeout) relayerOption() {}
+
+type RelayerOptionPreseededConfig struct {
+	Dir string
+}
+
+// PreseededConfigDir loads the relayer keys and paths from the directory dir, see PreseededConfig for its layout,
+// so that Interchain.Build funds and restores the checked-in keys and adds the checked-in paths,
+// instead of generating them. The relayer implementation must support path files, see PathFileCommander.
+func PreseededConfigDir(dir string) RelayerOption {
+	return RelayerOptionPreseededConfig{
+		Dir: dir,
+	}
+}
+
+func (opt RelayerOptionPreseededConfig) relayerOption() {}
+
+type RelayerOptionChainGas struct {
+	ChainID       string
+	GasPrices     string
+	GasAdjustment float64
+}
+
+// ChainGasOverride sets the gas prices, e.g. "0.01uatom", and the gas adjustment the relayer uses on the chain chainID,
+// instead of those of the chain config. It only changes the relayer config, not the chain nor other relayers.
+// An empty gasPrices or a zero gasAdjustment keeps the value of the chain config.
+// See also (*DockerRelayer).SetChainGasOverride to change them once the relayer is configured.
+func ChainGasOverride(chainID, gasPrices string, gasAdjustment float64) RelayerOption {
+	return RelayerOptionChainGas{
+		ChainID:       chainID,
+		GasPrices:     gasPrices,
+		GasAdjustment: gasAdjustment,
+	}
+}
+
+func (opt RelayerOptionChainGas) relayerOption() {}
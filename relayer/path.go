@@ -0,0 +1,58 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// PathCommander is implemented by a RelayerCommander whose relayer can show the ends of a configured path,
+// as required by GetPath.
+type PathCommander interface {
+	// ShowPath is the command printing the path pathName.
+	ShowPath(pathName, homeDir string) []string
+
+	// ParseShowPathOutput processes the output of ShowPath.
+	ParseShowPathOutput(stdout, stderr string) (Path, error)
+}
+
+// Path is a path configured in a relayer, between its source and destination ends.
+type Path struct {
+	Src, Dst PathEnd
+}
+
+// PathEnd is an end of a Path. The client and connection IDs are empty until they are created.
+type PathEnd struct {
+	ChainID      string
+	ClientID     string
+	ConnectionID string
+}
+
+// PathGetter is implemented by a relayer that can report the ends of its paths, such as DockerRelayer,
+// e.g. to find the path of a channel by its connection.
+type PathGetter interface {
+	ibc.Relayer
+
+	// GetPath returns the path pathName of the relayer.
+	GetPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) (Path, error)
+}
+
+var _ PathGetter = (*DockerRelayer)(nil)
+
+// GetPath returns the path pathName, which requires the relayer implementation to support it, see PathCommander.
+func (r *DockerRelayer) GetPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) (Path, error) {
+	pc, ok := r.c.(PathCommander)
+	if !ok {
+		return Path{}, fmt.Errorf("relayer %s does not support showing paths", r.c.Name())
+	}
+	res := r.Exec(ctx, rep, pc.ShowPath(pathName, r.HomeDir()), nil)
+	if res.Err != nil {
+		return Path{}, res.Err
+	}
+	p, err := pc.ParseShowPathOutput(string(res.Stdout), string(res.Stderr))
+	if err != nil {
+		return Path{}, fmt.Errorf("failed to parse path %s: %w", pathName, err)
+	}
+	return p, nil
+}
@@ -0,0 +1,151 @@
+package relayer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PathFileCommander is implemented by a RelayerCommander whose relayer can add a path from a path file,
+// as required by the PreseededConfigDir option.
+type PathFileCommander interface {
+	// AddPathFile is the command adding the path pathName between the chains from the file at containerFilePath.
+	AddPathFile(srcChainID, dstChainID, pathName, containerFilePath, homeDir string) []string
+}
+
+// PreseededConfig is a relayer configuration loaded from a directory with LoadPreseededConfig,
+// with the keys and paths of the relayer to use instead of generating them.
+//
+// The directory contains:
+//   - keys/<chain-id>: the mnemonic of the relayer key on the chain with the chain ID.
+//   - paths/<path-name>.json: a path file in the format of the relayer,
+//     with the chain IDs of the path in the "src" and "dst" objects, as in the path files of rly.
+//     The objects may also have the IDs of existing clients, which the relayer then links instead of creating clients.
+type PreseededConfig struct {
+	// Mnemonics of the relayer keys by chain ID.
+	Mnemonics map[string]string
+
+	// Paths by path name.
+	Paths map[string]PreseededPath
+}
+
+// PreseededPath is a path of a PreseededConfig.
+type PreseededPath struct {
+	SrcChainID string
+	DstChainID string
+
+	// The client IDs of the path file, if it has any.
+	SrcClientID string
+	DstClientID string
+
+	// The content of the path file.
+	File []byte
+}
+
+// LoadPreseededConfig loads the relayer keys and paths of the directory dir, see PreseededConfig for its layout.
+func LoadPreseededConfig(dir string) (PreseededConfig, error) {
+	c := PreseededConfig{
+		Mnemonics: make(map[string]string),
+		Paths:     make(map[string]PreseededPath),
+	}
+
+	keys, err := os.ReadDir(filepath.Join(dir, "keys"))
+	if err != nil {
+		return c, fmt.Errorf("read preseeded keys: %w", err)
+	}
+	for _, k := range keys {
+		if k.IsDir() {
+			continue
+		}
+		bz, err := os.ReadFile(filepath.Join(dir, "keys", k.Name()))
+		if err != nil {
+			return c, fmt.Errorf("read preseeded key: %w", err)
+		}
+		mnemonic := strings.TrimSpace(string(bz))
+		if mnemonic == "" {
+			return c, fmt.Errorf("preseeded key for chain %s is empty", k.Name())
+		}
+		c.Mnemonics[k.Name()] = mnemonic
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "paths", "*.json"))
+	if err != nil {
+		return c, err
+	}
+	for _, p := range paths {
+		bz, err := os.ReadFile(p)
+		if err != nil {
+			return c, fmt.Errorf("read preseeded path: %w", err)
+		}
+		var file struct {
+			Src struct {
+				ChainID  string `json:"chain-id"`
+				ClientID string `json:"client-id"`
+			} `json:"src"`
+			Dst struct {
+				ChainID  string `json:"chain-id"`
+				ClientID string `json:"client-id"`
+			} `json:"dst"`
+		}
+		if err := json.Unmarshal(bz, &file); err != nil {
+			return c, fmt.Errorf("parse preseeded path %s: %w", p, err)
+		}
+		if file.Src.ChainID == "" || file.Dst.ChainID == "" {
+			return c, fmt.Errorf("preseeded path %s must have the chain IDs of src and dst", p)
+		}
+		c.Paths[strings.TrimSuffix(filepath.Base(p), ".json")] = PreseededPath{
+			SrcChainID:  file.Src.ChainID,
+			DstChainID:  file.Dst.ChainID,
+			SrcClientID: file.Src.ClientID,
+			DstClientID: file.Dst.ClientID,
+			File:        bz,
+		}
+	}
+
+	return c, nil
+}
+
+// Validate returns an error unless c has exactly a key for every chain of chainIDs,
+// and exactly the paths of paths, given as path names to the source and destination chain IDs.
+func (c PreseededConfig) Validate(chainIDs []string, paths map[string][2]string) error {
+	var errs []string
+
+	linked := make(map[string]bool, len(chainIDs))
+	for _, id := range chainIDs {
+		linked[id] = true
+		if _, ok := c.Mnemonics[id]; !ok {
+			errs = append(errs, fmt.Sprintf("no preseeded key for chain %s", id))
+		}
+	}
+	for id := range c.Mnemonics {
+		if !linked[id] {
+			errs = append(errs, fmt.Sprintf("preseeded key for chain %s, which is not linked", id))
+		}
+	}
+
+	for name, ids := range paths {
+		p, ok := c.Paths[name]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("no preseeded path %s", name))
+			continue
+		}
+		if p.SrcChainID != ids[0] || p.DstChainID != ids[1] {
+			errs = append(errs, fmt.Sprintf("preseeded path %s links %s to %s, expected %s to %s", name, p.SrcChainID, p.DstChainID, ids[0], ids[1]))
+		}
+	}
+	for name := range c.Paths {
+		if _, ok := paths[name]; !ok {
+			errs = append(errs, fmt.Sprintf("preseeded path %s, which is not linked", name))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Strings(errs)
+	return errors.New("preseeded relayer config does not match the links: " + strings.Join(errs, "; "))
+}
@@ -0,0 +1,52 @@
+package relayer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPreseededConfig(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "keys"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "paths"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "keys", "chain-a"), []byte("word word word\n"), 0o600))
+	const path = `{"src":{"chain-id":"chain-a","client-id":"07-tendermint-0"},"dst":{"chain-id":"chain-b","client-id":"07-tendermint-1"}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "paths", "ab.json"), []byte(path), 0o600))
+
+	c, err := LoadPreseededConfig(dir)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"chain-a": "word word word"}, c.Mnemonics)
+	require.Equal(t, map[string]PreseededPath{
+		"ab": {SrcChainID: "chain-a", DstChainID: "chain-b", SrcClientID: "07-tendermint-0", DstClientID: "07-tendermint-1", File: []byte(path)},
+	}, c.Paths)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "paths", "bad.json"), []byte(`{"src":{}}`), 0o600))
+	_, err = LoadPreseededConfig(dir)
+	require.ErrorContains(t, err, "must have the chain IDs of src and dst")
+
+	_, err = LoadPreseededConfig(filepath.Join(dir, "missing"))
+	require.ErrorContains(t, err, "read preseeded keys")
+}
+
+func TestPreseededConfig_Validate(t *testing.T) {
+	c := PreseededConfig{
+		Mnemonics: map[string]string{"chain-a": "a", "chain-b": "b"},
+		Paths: map[string]PreseededPath{
+			"ab": {SrcChainID: "chain-a", DstChainID: "chain-b"},
+		},
+	}
+
+	require.NoError(t, c.Validate([]string{"chain-a", "chain-b"}, map[string][2]string{"ab": {"chain-a", "chain-b"}}))
+
+	err := c.Validate([]string{"chain-a", "chain-c"}, map[string][2]string{"ab": {"chain-a", "chain-c"}})
+	require.ErrorContains(t, err, "no preseeded key for chain chain-c")
+	require.ErrorContains(t, err, "preseeded key for chain chain-b, which is not linked")
+	require.ErrorContains(t, err, "preseeded path ab links chain-a to chain-b, expected chain-a to chain-c")
+
+	err = c.Validate([]string{"chain-a", "chain-b"}, map[string][2]string{"ba": {"chain-b", "chain-a"}})
+	require.ErrorContains(t, err, "no preseeded path ba")
+	require.ErrorContains(t, err, "preseeded path ab, which is not linked")
+}
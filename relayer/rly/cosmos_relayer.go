@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
@@ -23,13 +24,7 @@ type CosmosRelayer struct {
 }
 
 func NewCosmosRelayer(log *zap.Logger, testName string, cli *client.Client, networkID string, options ...relayer.RelayerOption) *CosmosRelayer {
-	c := commander{log: log}
-	for _, opt := range options {
-		switch o := opt.(type) {
-		case relayer.RelayerOptionExtraStartFlags:
-			c.extraStartFlags = o.Flags
-		}
-	}
+	c := newCommander(log, options...)
 	dr, err := relayer.NewDockerRelayer(context.TODO(), log, testName, cli, networkID, c, options...)
 	if err != nil {
 		panic(err) // TODO: return
@@ -72,8 +67,11 @@ const (
 // Note, this API may change if the rly package eventually needs
 // to distinguish between multiple rly versions.
 func Capabilities() map[relayer.Capability]bool {
-	// RC1 matches the full set of capabilities as of writing.
-	return relayer.FullCapabilities()
+	caps := relayer.FullCapabilities()
+	// The default relayer version predates channel upgrades,
+	// whose handshake cosmos.RelayChannelUpgrade relays for it.
+	caps[relayer.ChannelUpgrade] = false
+	return caps
 }
 
 func ChainConfigToCosmosRelayerChainConfig(chainConfig ibc.ChainConfig, keyName, rpcAddr, gprcAddr string) CosmosRelayerChainConfig {
@@ -81,6 +79,10 @@ func ChainConfigToCosmosRelayerChainConfig(chainConfig ibc.ChainConfig, keyName,
 	if chainType == "polkadot" || chainType == "parachain" || chainType == "relaychain" {
 		chainType = "substrate"
 	}
+	signMode := chainConfig.SignMode
+	if signMode == "" {
+		signMode = ibc.SignModeDirect
+	}
 	return CosmosRelayerChainConfig{
 		Type: chainType,
 		Value: CosmosRelayerChainConfigValue{
@@ -95,7 +97,7 @@ func ChainConfigToCosmosRelayerChainConfig(chainConfig ibc.ChainConfig, keyName,
 			Debug:          true,
 			Timeout:        "10s",
 			OutputFormat:   "json",
-			SignMode:       "direct",
+			SignMode:       signMode,
 		},
 	}
 }
@@ -104,6 +106,35 @@ func ChainConfigToCosmosRelayerChainConfig(chainConfig ibc.ChainConfig, keyName,
 type commander struct {
 	log             *zap.Logger
 	extraStartFlags []string
+	metrics         bool
+	processor       *relayer.RelayerOptionProcessor
+}
+
+func newCommander(log *zap.Logger, options ...relayer.RelayerOption) commander {
+	c := commander{log: log}
+	for _, opt := range options {
+		switch o := opt.(type) {
+		case relayer.RelayerOptionExtraStartFlags:
+			c.extraStartFlags = o.Flags
+		case relayer.RelayerOptionMetrics:
+			c.metrics = true
+		case relayer.RelayerOptionProcessor:
+			c.processor = &o
+		}
+	}
+	return c
+}
+
+// metricsPort is the container port of the relayer's debug server, which serves the metrics endpoint.
+// The metrics endpoint requires rly v2.2.0 or newer.
+const metricsPort = "5183/tcp"
+
+func (commander) MetricsPort() string {
+	return metricsPort
+}
+
+func (commander) MetricsPath() string {
+	return "/relayer/metrics"
 }
 
 func (commander) Name() string {
@@ -121,6 +152,13 @@ func (commander) AddChainConfiguration(containerFilePath, homeDir string) []stri
 	}
 }
 
+func (commander) RemoveChainConfiguration(chainID, homeDir string) []string {
+	return []string{
+		"rly", "chains", "delete", chainID,
+		"--home", homeDir,
+	}
+}
+
 func (commander) AddKey(chainID, keyName, coinType, homeDir string) []string {
 	return []string{
 		"rly", "keys", "add", chainID, keyName,
@@ -140,6 +178,13 @@ func (commander) CreateChannel(pathName string, opts ibc.CreateChannelOptions, h
 	}
 }
 
+func (commander) CloseChannel(pathName, channelID, portID, homeDir string) []string {
+	return []string{
+		"rly", "tx", "channel-close", pathName, channelID, portID,
+		"--home", homeDir,
+	}
+}
+
 func (commander) CreateClients(pathName string, opts ibc.CreateClientOptions, homeDir string) []string {
 	return []string{
 		"rly", "tx", "clients", pathName, "--client-tp", opts.TrustingPeriod,
@@ -183,13 +228,70 @@ func (commander) GeneratePath(srcChainID, dstChainID, pathName, homeDir string)
 	}
 }
 
-func (commander) UpdatePath(pathName, homeDir string, filter ibc.ChannelFilter) []string {
+func (commander) AddPathFile(srcChainID, dstChainID, pathName, containerFilePath, homeDir string) []string {
+	return []string{
+		"rly", "paths", "add", srcChainID, dstChainID, pathName,
+		"--file", containerFilePath,
+		"--home", homeDir,
+	}
+}
+
+func (commander) ShowPath(pathName, homeDir string) []string {
 	return []string{
+		"rly", "paths", "show", pathName, "--json",
+		"--home", homeDir,
+	}
+}
+
+// rlyPathEnd is an end of a path in the output of paths show.
+type rlyPathEnd struct {
+	ChainID      string `json:"chain-id"`
+	ClientID     string `json:"client-id"`
+	ConnectionID string `json:"connection-id"`
+}
+
+func (e rlyPathEnd) pathEnd() relayer.PathEnd {
+	return relayer.PathEnd{ChainID: e.ChainID, ClientID: e.ClientID, ConnectionID: e.ConnectionID}
+}
+
+func (commander) ParseShowPathOutput(stdout, stderr string) (relayer.Path, error) {
+	// The path is under "chains", next to its status.
+	var out struct {
+		Chains struct {
+			Src rlyPathEnd `json:"src"`
+			Dst rlyPathEnd `json:"dst"`
+		} `json:"chains"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &out); err != nil {
+		return relayer.Path{}, err
+	}
+	return relayer.Path{Src: out.Chains.Src.pathEnd(), Dst: out.Chains.Dst.pathEnd()}, nil
+}
+
+func (commander) UpdatePath(pathName, homeDir string, opts ibc.PathUpdateOptions) []string {
+	command := []string{
 		"rly", "paths", "update", pathName,
 		"--home", homeDir,
-		"--filter-rule", filter.Rule,
-		"--filter-channels", strings.Join(filter.ChannelList, ","),
 	}
+	if opts.ChannelFilter != nil {
+		command = append(command,
+			"--filter-rule", opts.ChannelFilter.Rule,
+			"--filter-channels", strings.Join(opts.ChannelFilter.ChannelList, ","),
+		)
+	}
+	if opts.SrcClientID != nil {
+		command = append(command, "--src-client-id", *opts.SrcClientID)
+	}
+	if opts.SrcConnID != nil {
+		command = append(command, "--src-connection-id", *opts.SrcConnID)
+	}
+	if opts.DstClientID != nil {
+		command = append(command, "--dst-client-id", *opts.DstClientID)
+	}
+	if opts.DstConnID != nil {
+		command = append(command, "--dst-connection-id", *opts.DstConnID)
+	}
+	return command
 }
 
 func (commander) GetChannels(chainID, homeDir string) []string {
@@ -238,6 +340,16 @@ func (c commander) StartRelayer(homeDir string, pathNames ...string) []string {
 		"rly", "start", "--debug",
 		"--home", homeDir,
 	}
+	if c.metrics {
+		cmd = append(cmd, "--debug-addr", "0.0.0.0:"+strings.TrimSuffix(metricsPort, "/tcp"))
+	}
+	if p := c.processor; p != nil {
+		cmd = append(cmd, "--processor", p.Processor)
+		// Only the events processor has a block history.
+		if p.Processor == relayer.ProcessorNameEvents && p.BlockHistory > 0 {
+			cmd = append(cmd, "--block-history", strconv.Itoa(p.BlockHistory))
+		}
+	}
 	cmd = append(cmd, c.extraStartFlags...)
 	cmd = append(cmd, pathNames...)
 	return cmd
@@ -250,6 +362,20 @@ func (commander) UpdateClients(pathName, homeDir string) []string {
 	}
 }
 
+func (commander) UnrelayedPackets(pathName, channelID, homeDir string) []string {
+	return []string{
+		"rly", "q", "unrelayed-packets", pathName, channelID,
+		"--home", homeDir,
+	}
+}
+
+func (commander) UnrelayedAcknowledgements(pathName, channelID, homeDir string) []string {
+	return []string{
+		"rly", "q", "unrelayed-acks", pathName, channelID,
+		"--home", homeDir,
+	}
+}
+
 func (commander) ConfigContent(ctx context.Context, cfg ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) ([]byte, error) {
 	cosmosRelayerChainConfig := ChainConfigToCosmosRelayerChainConfig(cfg, keyName, rpcAddr, grpcAddr)
 	jsonBytes, err := json.Marshal(cosmosRelayerChainConfig)
@@ -341,6 +467,20 @@ func (c commander) ParseGetClientsOutput(stdout, stderr string) (ibc.ClientOutpu
 	return clients, nil
 }
 
+// unrelayedSequences is the output of the unrelayed-packets and unrelayed-acks queries.
+type unrelayedSequences struct {
+	Src []uint64 `json:"src"`
+	Dst []uint64 `json:"dst"`
+}
+
+func (commander) ParseUnrelayedSequencesOutput(stdout, stderr string) ([]uint64, []uint64, error) {
+	var seqs unrelayedSequences
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &seqs); err != nil {
+		return nil, nil, err
+	}
+	return seqs.Src, seqs.Dst, nil
+}
+
 func (commander) Init(homeDir string) []string {
 	return []string{
 		"rly", "config", "init",
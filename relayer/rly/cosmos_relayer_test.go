@@ -0,0 +1,60 @@
+package rly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/relayer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestCommander_StartRelayerProcessor(t *testing.T) {
+	log := zaptest.NewLogger(t)
+
+	c := newCommander(log, relayer.ProcessorEvents(100))
+	require.Equal(t, []string{
+		"rly", "start", "--debug", "--home", "/home/relayer",
+		"--processor", "events", "--block-history", "100",
+		"ibc-path",
+	}, c.StartRelayer("/home/relayer", "ibc-path"))
+
+	c = newCommander(log, relayer.ProcessorEvents(0), relayer.StartupFlags("--flush-interval", "1m"))
+	require.Equal(t, []string{
+		"rly", "start", "--debug", "--home", "/home/relayer",
+		"--processor", "events", "--flush-interval", "1m",
+	}, c.StartRelayer("/home/relayer"))
+
+	c = newCommander(log, relayer.ProcessorLegacy())
+	require.Equal(t, []string{
+		"rly", "start", "--debug", "--home", "/home/relayer",
+		"--processor", "legacy",
+	}, c.StartRelayer("/home/relayer"))
+
+	c = newCommander(log)
+	require.Equal(t, []string{"rly", "start", "--debug", "--home", "/home/relayer"}, c.StartRelayer("/home/relayer"))
+}
+
+func TestProcessorOptionsCannotBeCombined(t *testing.T) {
+	log := zaptest.NewLogger(t)
+	opts := []relayer.RelayerOption{relayer.ProcessorEvents(100), relayer.ProcessorLegacy()}
+
+	_, err := relayer.NewDockerRelayer(context.Background(), log, t.Name(), nil, "", newCommander(log, opts...), opts...)
+	require.ErrorContains(t, err, "relayer processor options events and legacy cannot be combined")
+}
+
+func TestCommander_ParseShowPathOutput(t *testing.T) {
+	const stdout = `{"chains":{"src":{"chain-id":"chain-a","client-id":"07-tendermint-0","connection-id":"connection-0"},` +
+		`"dst":{"chain-id":"chain-b","client-id":"07-tendermint-1","connection-id":"connection-2"},` +
+		`"src-channel-filter":{"rule":"","channel-list":[]}},"status":{"chains":true,"clients":true,"connection":true}}`
+
+	p, err := commander{}.ParseShowPathOutput(stdout+"\n", "")
+	require.NoError(t, err)
+	require.Equal(t, relayer.Path{
+		Src: relayer.PathEnd{ChainID: "chain-a", ClientID: "07-tendermint-0", ConnectionID: "connection-0"},
+		Dst: relayer.PathEnd{ChainID: "chain-b", ClientID: "07-tendermint-1", ConnectionID: "connection-2"},
+	}, p)
+
+	_, err = commander{}.ParseShowPathOutput("not json", "")
+	require.Error(t, err)
+}
@@ -15,7 +15,8 @@ import (
 )
 
 const (
-	testPathName = "test-path"
+	// TestPathName is the name of the path StartChainPair creates between the chains.
+	TestPathName = "test-path"
 
 	FaucetAccountKeyName = "faucet"
 )
@@ -38,6 +39,16 @@ func DockerSetup(t *testing.T) (*client.Client, string) {
 	return dockerutil.DockerSetup(t)
 }
 
+// DockerSetupHost is like DockerSetup for the docker daemon at host, e.g. tcp://10.0.0.2:2376,
+// to run chains on it through InterchainBuildOptions.ChainDockerHosts.
+//
+// If any part of the setup fails, t.Fatal is called.
+func DockerSetupHost(t *testing.T, host string) DockerHost {
+	t.Helper()
+	cli, network := dockerutil.DockerSetupHost(t, host)
+	return DockerHost{Client: cli, NetworkID: network}
+}
+
 // startup both chains
 // creates wallets in the relayer for src and dst chain
 // funds relayer src and dst wallets on respective chain in genesis
@@ -63,7 +74,7 @@ func StartChainPair(
 			Chain1:  srcChain,
 			Chain2:  dstChain,
 			Relayer: relayerImpl,
-			Path:    testPathName,
+			Path:    TestPathName,
 		})
 
 	blockSqlite := DefaultBlockDatabaseFilepath()
@@ -124,7 +135,7 @@ func StopStartRelayerWithPreStartFuncs(
 	wg.Wait()
 
 	if len(pathNames) == 0 {
-		if err := relayerImpl.StartRelayer(ctx, eRep, testPathName); err != nil {
+		if err := relayerImpl.StartRelayer(ctx, eRep, TestPathName); err != nil {
 			return nil, fmt.Errorf("failed to start relayer: %w", err)
 		}
 	} else {
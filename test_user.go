@@ -3,15 +3,39 @@ package interchaintest
 import (
 	"context"
 	"fmt"
+	"os"
 	"testing"
+	"time"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
 	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v6/testutil"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
 	"golang.org/x/sync/errgroup"
 )
 
+// sweepTestUsersOnCleanup determines whether GetAndFundTestUsers sweeps the users' balances back to the faucet
+// when the test completes.
+var sweepTestUsersOnCleanup = os.Getenv("IBCTEST_SWEEP_TEST_USERS") != ""
+
+// SweepTestUsersOnCleanup sets whether GetAndFundTestUsers registers a cleanup that returns the remaining
+// native balances of the test users to the faucet with SweepUsers,
+// which keeps the faucet of long-lived shared networks from slowly draining.
+//
+// The value is false by default, but can be initialized to true by setting the
+// environment variable IBCTEST_SWEEP_TEST_USERS to a non-empty value.
+func SweepTestUsersOnCleanup(b bool) {
+	sweepTestUsersOnCleanup = b
+}
+
+// SweepingTestUsersOnCleanup reports the current value of SweepTestUsersOnCleanup.
+// This function is only intended for tests.
+func SweepingTestUsersOnCleanup() bool {
+	return sweepTestUsersOnCleanup
+}
+
 // GetAndFundTestUserWithMnemonic restores a user using the given mnemonic
 // and funds it with the native chain denom.
 // The caller should wait for some blocks to complete before the funds will be accessible.
@@ -28,7 +52,7 @@ func GetAndFundTestUserWithMnemonic(
 		return nil, fmt.Errorf("failed to get source user wallet: %w", err)
 	}
 
-	err = chain.SendFunds(ctx, FaucetAccountKeyName, ibc.WalletAmount{
+	err = sendFaucetFunds(ctx, chain, ibc.WalletAmount{
 		Address: user.FormattedAddress(),
 		Amount:  amount,
 		Denom:   chainCfg.Denom,
@@ -64,6 +88,19 @@ func GetAndFundTestUsers(
 	}
 	require.NoError(t, eg.Wait())
 
+	if sweepTestUsersOnCleanup {
+		t.Cleanup(func() {
+			// The test's context may already be canceled.
+			ctx, cancel := context.WithTimeout(context.Background(), sweepTimeout)
+			defer cancel()
+			for i, chain := range chains {
+				if err := sweepToFaucet(ctx, chain, users[i]); err != nil {
+					t.Logf("Failed to sweep test user %s back to faucet: %v", users[i].FormattedAddress(), err)
+				}
+			}
+		})
+	}
+
 	// TODO(nix 05-17-2022): Map with generics once using go 1.18
 	chainHeights := make([]testutil.ChainHeighter, len(chains))
 	for i := range chains {
@@ -71,3 +108,85 @@ func GetAndFundTestUsers(
 	}
 	return users
 }
+
+// sweepTimeout bounds the sweep of test users on cleanup.
+const sweepTimeout = 2 * time.Minute
+
+// sweepGas is the gas limit that SendFunds transactions pay for when the gas is not estimated,
+// i.e. the default gas limit of the Cosmos SDK CLI.
+const sweepGas = 200_000
+
+// SweepUsers sends the remaining balance of the chain's native denom of each user back to faucetAddr,
+// minus the fee of the send, concurrently for all users.
+//
+// Users whose balance cannot cover more than the fee, such as empty accounts
+// or accounts that only hold other denoms, are skipped; other denoms are not swept.
+// The fee is estimated with GetGasFeesInNativeDenom for the default gas limit of the Cosmos SDK CLI.
+func SweepUsers(ctx context.Context, chain ibc.Chain, users []ibc.Wallet, faucetAddr string) error {
+	denom := chain.Config().Denom
+	fee := chain.GetGasFeesInNativeDenom(sweepGas)
+
+	errs := make([]error, len(users))
+	var eg errgroup.Group
+	for i, user := range users {
+		i, user := i, user
+		eg.Go(func() error {
+			bal, err := chain.GetBalance(ctx, user.FormattedAddress(), denom)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to get balance of %s: %w", user.FormattedAddress(), err)
+				return nil
+			}
+			amount, ok := sweepAmount(bal, fee)
+			if !ok {
+				return nil
+			}
+			if err := chain.SendFunds(ctx, user.KeyName(), ibc.WalletAmount{
+				Address: faucetAddr,
+				Denom:   denom,
+				Amount:  amount,
+			}); err != nil {
+				errs[i] = fmt.Errorf("failed to sweep %d%s from %s: %w", amount, denom, user.FormattedAddress(), err)
+			}
+			return nil
+		})
+	}
+	_ = eg.Wait()
+	return multierr.Combine(errs...)
+}
+
+// sweepAmount returns the amount of balance that is left to sweep after paying fee,
+// and false if the balance cannot cover more than the fee.
+func sweepAmount(balance, fee int64) (int64, bool) {
+	if balance <= fee {
+		return 0, false
+	}
+	return balance - fee, true
+}
+
+// sweepToFaucet sweeps user on chain back to the chain's faucet account.
+func sweepToFaucet(ctx context.Context, chain ibc.Chain, user ibc.Wallet) error {
+	addr, err := chain.GetAddress(ctx, FaucetAccountKeyName)
+	if err != nil {
+		return fmt.Errorf("failed to get faucet address: %w", err)
+	}
+	faucetAddr, err := sdk.Bech32ifyAddressBytes(chain.Config().Bech32Prefix, addr)
+	if err != nil {
+		return fmt.Errorf("failed to format faucet address: %w", err)
+	}
+	return SweepUsers(ctx, chain, []ibc.Wallet{user}, faucetAddr)
+}
+
+// internalFundsSender is implemented by chains that can pay fees for test plumbing
+// transactions with separate internal gas prices, e.g. cosmos.CosmosChain.
+type internalFundsSender interface {
+	SendFundsInternal(ctx context.Context, keyName string, amount ibc.WalletAmount) error
+}
+
+// sendFaucetFunds sends amount from the faucet account,
+// using the chain's internal gas prices when the chain supports them.
+func sendFaucetFunds(ctx context.Context, chain ibc.Chain, amount ibc.WalletAmount) error {
+	if s, ok := chain.(internalFundsSender); ok {
+		return s.SendFundsInternal(ctx, FaucetAccountKeyName, amount)
+	}
+	return chain.SendFunds(ctx, FaucetAccountKeyName, amount)
+}
@@ -0,0 +1,111 @@
+package interchaintest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+// sweepChain is an ibc.Chain with fixed native balances and a fixed fee per transaction,
+// recording the sends of SweepUsers.
+// Calling any other method panics.
+type sweepChain struct {
+	ibc.Chain
+
+	fee      int64
+	balances map[string]int64
+	failSend map[string]bool
+
+	mu    sync.Mutex
+	sends map[string]ibc.WalletAmount
+}
+
+func (c *sweepChain) Config() ibc.ChainConfig {
+	return ibc.ChainConfig{Denom: "uatom"}
+}
+
+func (c *sweepChain) GetGasFeesInNativeDenom(gasPaid int64) int64 {
+	if gasPaid != sweepGas {
+		panic("unexpected gas")
+	}
+	return c.fee
+}
+
+func (c *sweepChain) GetBalance(_ context.Context, address, denom string) (int64, error) {
+	if denom != "uatom" {
+		panic("unexpected denom " + denom)
+	}
+	return c.balances[address], nil
+}
+
+func (c *sweepChain) SendFunds(_ context.Context, keyName string, amount ibc.WalletAmount) error {
+	if c.failSend[keyName] {
+		return errors.New("send failed")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sends[keyName] = amount
+	return nil
+}
+
+type sweepWallet struct {
+	ibc.Wallet
+	name string
+}
+
+func (w sweepWallet) KeyName() string          { return w.name }
+func (w sweepWallet) FormattedAddress() string { return w.name + "-addr" }
+
+func TestSweepUsers(t *testing.T) {
+	ctx := context.Background()
+
+	chain := &sweepChain{
+		fee: 2000,
+		balances: map[string]int64{
+			"rich-addr":        10_000,
+			"fee-exact-addr":   2000,
+			"below-fee-addr":   1999,
+			"above-fee-addr":   2001,
+			"other-denom-addr": 0,
+		},
+		sends: make(map[string]ibc.WalletAmount),
+	}
+	users := []ibc.Wallet{
+		sweepWallet{name: "rich"},
+		sweepWallet{name: "fee-exact"},
+		sweepWallet{name: "below-fee"},
+		sweepWallet{name: "above-fee"},
+		sweepWallet{name: "other-denom"},
+		sweepWallet{name: "empty"},
+	}
+
+	require.NoError(t, SweepUsers(ctx, chain, users, "faucet-addr"))
+	require.Equal(t, map[string]ibc.WalletAmount{
+		"rich":      {Address: "faucet-addr", Denom: "uatom", Amount: 8000},
+		"above-fee": {Address: "faucet-addr", Denom: "uatom", Amount: 1},
+	}, chain.sends)
+}
+
+func TestSweepUsers_SendErrors(t *testing.T) {
+	chain := &sweepChain{
+		fee: 10,
+		balances: map[string]int64{
+			"ok-addr":     100,
+			"failed-addr": 100,
+		},
+		failSend: map[string]bool{"failed": true},
+		sends:    make(map[string]ibc.WalletAmount),
+	}
+
+	err := SweepUsers(context.Background(), chain, []ibc.Wallet{sweepWallet{name: "ok"}, sweepWallet{name: "failed"}}, "faucet-addr")
+	require.ErrorContains(t, err, "failed to sweep 90uatom from failed-addr: send failed")
+
+	// Other users are still swept.
+	require.Equal(t, map[string]ibc.WalletAmount{
+		"ok": {Address: "faucet-addr", Denom: "uatom", Amount: 90},
+	}, chain.sends)
+}
@@ -73,4 +73,23 @@
 //
 // If you use a plain require.NoError(t, err) call,
 // the report will note that the test failed, but the report will not include the error line.
+//
+// To debug nondeterministic relayer failures, use a RelayerExecRecorder in place of a RelayerExecReporter.
+// It additionally records every relayer command with its timing,
+// which can be written out as a script replaying the exact sequence of commands.
+//
+//	func TestRelayer(t *testing.T) {
+//	  eRep := reporter.RelayerExecRecorder(t)
+//	  t.Cleanup(func() {
+//	    if t.Failed() {
+//	      f, _ := os.Create("/tmp/relayer.sh")
+//	      _ = eRep.WriteScript(f)
+//	      _ = f.Close()
+//	    }
+//	  })
+//	  // Pass eRep wherever an ibc.RelayerExecReporter is needed,
+//	  // and eRep.RelayerExecReporter to Interchain.Build,
+//	  // which records the commands of all relayers configured and linked during the build.
+//	  require.NoError(t, ic.Build(ctx, eRep.RelayerExecReporter, opts))
+//	}
 package testreporter
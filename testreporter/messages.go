@@ -127,6 +127,10 @@ func (m TestSkipMessage) typ() string {
 type RelayerExecMessage struct {
 	Name string // Test name, but "Name" for consistency.
 
+	// Name of the relayer in the Interchain that executed the command,
+	// when the reporter was obtained through ForRelayer.
+	Relayer string `json:",omitempty"`
+
 	StartedAt, FinishedAt time.Time
 
 	ContainerName string `json:",omitempty"`
@@ -144,6 +148,33 @@ func (m RelayerExecMessage) typ() string {
 	return "RelayerExec"
 }
 
+// ArtifactDirMessage is tracked when a per-test artifact directory is created,
+// so that the report indicates where a test's artifacts were written.
+type ArtifactDirMessage struct {
+	Name string
+	When time.Time
+	Path string
+}
+
+func (m ArtifactDirMessage) typ() string {
+	return "ArtifactDir"
+}
+
+// MetricMessage is a named measurement taken by a test, such as the throughput of a relayer,
+// to compare performance across runs.
+type MetricMessage struct {
+	Name string
+	When time.Time
+
+	Metric string
+	Value  float64
+	Unit   string `json:",omitempty"`
+}
+
+func (m MetricMessage) typ() string {
+	return "Metric"
+}
+
 // WrappedMessage wraps a Message with an outer Type field
 // so that decoders can determine the underlying message's type.
 type WrappedMessage struct {
@@ -210,6 +241,14 @@ func (m *WrappedMessage) UnmarshalJSON(b []byte) error {
 		x := RelayerExecMessage{}
 		err = json.Unmarshal(raw, &x)
 		msg = x
+	case "ArtifactDir":
+		x := ArtifactDirMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
+	case "Metric":
+		x := MetricMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
 	default:
 		return fmt.Errorf("unknown message type %q", outer.Type)
 	}
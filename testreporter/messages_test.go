@@ -36,6 +36,7 @@ func TestWrappedMessage_RoundTrip(t *testing.T) {
 		{
 			Message: testreporter.RelayerExecMessage{
 				Name:          "foo",
+				Relayer:       "relayer",
 				StartedAt:     time.Now(),
 				FinishedAt:    time.Now().Add(time.Second),
 				ContainerName: "relayer-exec-123",
@@ -45,6 +46,8 @@ func TestWrappedMessage_RoundTrip(t *testing.T) {
 				Error:         "",
 			},
 		},
+		{Message: testreporter.ArtifactDirMessage{Name: "foo", When: time.Now(), Path: "/tmp/artifacts/foo"}},
+		{Message: testreporter.MetricMessage{Name: "foo", When: time.Now(), Metric: "throughput", Value: 12.5, Unit: "packets/min"}},
 	}
 
 	for _, tc := range tcs {
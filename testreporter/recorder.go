@@ -0,0 +1,97 @@
+package testreporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RelayerExecRecorder satisfies the ibc.RelayerExecReporter interface,
+// additionally keeping every tracked relayer command in memory, in order.
+// This is useful to debug nondeterministic relayer failures,
+// by replaying the exact sequence of commands with WriteScript.
+// Instances of RelayerExecRecorder must be retrieved through (*Reporter).RelayerExecRecorder.
+//
+// The embedded RelayerExecReporter records into the RelayerExecRecorder, as do the reporters derived from it
+// with ForRelayer, so pass it where a *RelayerExecReporter is needed, e.g. to Interchain.Build.
+type RelayerExecRecorder struct {
+	*RelayerExecReporter
+
+	mu    sync.Mutex
+	execs []RelayerExecMessage
+}
+
+// RelayerExecRecorder returns a RelayerExecRecorder associated with t.
+// All tracked commands are reported as with RelayerExecReporter.
+func (r *Reporter) RelayerExecRecorder(t T) *RelayerExecRecorder {
+	rec := &RelayerExecRecorder{RelayerExecReporter: r.RelayerExecReporter(t)}
+	rec.rec = rec
+	return rec
+}
+
+// record keeps a copy of msg, tracked by a reporter recording into r.
+func (r *RelayerExecRecorder) record(msg RelayerExecMessage) {
+	msg.Command = append([]string(nil), msg.Command...)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.execs = append(r.execs, msg)
+}
+
+// Execs returns the recorded relayer commands in the order they were tracked.
+func (r *RelayerExecRecorder) Execs() []RelayerExecMessage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RelayerExecMessage(nil), r.execs...)
+}
+
+// WriteScript writes the recorded relayer commands to w as a bash script.
+// Each command is preceded by a comment with its original timing, container and exit code,
+// and by a sleep reproducing the delay since the previous command started.
+// The commands are written as executed, so the script is intended to be run
+// inside a container with the relayer's home directory, e.g. via docker exec.
+func (r *RelayerExecRecorder) WriteScript(w io.Writer) error {
+	execs := r.Execs()
+
+	var sb strings.Builder
+	sb.WriteString("#!/usr/bin/env bash\n")
+	fmt.Fprintf(&sb, "# %d relayer commands recorded for %s\n", len(execs), r.testName)
+	sb.WriteString("set -x\n")
+
+	for i, e := range execs {
+		sb.WriteString("\n")
+		if i > 0 {
+			if gap := e.StartedAt.Sub(execs[i-1].StartedAt); gap > 0 {
+				fmt.Fprintf(&sb, "sleep %.3f\n", gap.Seconds())
+			}
+		}
+		fmt.Fprintf(&sb, "# started %s, took %s, container %q, exit code %d\n",
+			e.StartedAt.UTC().Format(time.RFC3339Nano), e.FinishedAt.Sub(e.StartedAt), e.ContainerName, e.ExitCode,
+		)
+		if e.Error != "" {
+			fmt.Fprintf(&sb, "# error: %s\n", strings.ReplaceAll(e.Error, "\n", " "))
+		}
+
+		quoted := make([]string, len(e.Command))
+		for j, arg := range e.Command {
+			quoted[j] = shellQuote(arg)
+		}
+		sb.WriteString(strings.Join(quoted, " "))
+		sb.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// shellQuote quotes s for use as a single argument in a POSIX shell.
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:=@,+", r))
+	}) == -1 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
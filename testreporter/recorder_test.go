@@ -0,0 +1,89 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/internal/mocktesting"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayerExecRecorder(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+
+	r.TrackTest(mt)
+
+	rec := r.RelayerExecRecorder(mt)
+
+	firstStartedAt := time.Now()
+	rec.TrackRelayerExec(
+		"my_container",
+		[]string{"rly", "tx", "link", "my path"},
+		"stdout", "stderr",
+		0,
+		firstStartedAt, firstStartedAt.Add(time.Second),
+		nil,
+	)
+
+	secondStartedAt := firstStartedAt.Add(1500 * time.Millisecond)
+	rec.TrackRelayerExec(
+		"my_container",
+		[]string{"rly", "q", "channels", "it's"},
+		"", "boom",
+		1,
+		secondStartedAt, secondStartedAt.Add(time.Second),
+		errors.New("exit code 1"),
+	)
+
+	mt.RunCleanups()
+
+	require.NoError(t, r.Close())
+
+	// Recorded commands are still reported.
+	msgs := ReporterMessages(t, buf)
+	require.Len(t, msgs, 6)
+	require.IsType(t, testreporter.RelayerExecMessage{}, msgs[2])
+	require.IsType(t, testreporter.RelayerExecMessage{}, msgs[3])
+
+	execs := rec.Execs()
+	require.Len(t, execs, 2)
+	require.Equal(t, []string{"rly", "tx", "link", "my path"}, execs[0].Command)
+	require.Equal(t, "exit code 1", execs[1].Error)
+
+	script := new(strings.Builder)
+	require.NoError(t, rec.WriteScript(script))
+
+	require.True(t, strings.HasPrefix(script.String(), "#!/usr/bin/env bash\n"))
+	require.Contains(t, script.String(), "rly tx link 'my path'\n")
+	require.Contains(t, script.String(), "sleep 1.500\n")
+	require.Contains(t, script.String(), "# error: exit code 1\n")
+	require.Contains(t, script.String(), `rly q channels 'it'\''s'`+"\n")
+}
+
+func TestRelayerExecRecorder_ForRelayer(t *testing.T) {
+	t.Parallel()
+
+	r := testreporter.NewNopReporter()
+	rec := r.RelayerExecRecorder(mocktesting.NewT("my_test"))
+
+	now := time.Now()
+	rec.ForRelayer("rly-a").TrackRelayerExec("a", []string{"rly", "a"}, "", "", 0, now, now, nil)
+	rec.ForRelayer("rly-b").ForRelayer("rly-c").TrackRelayerExec("c", []string{"rly", "c"}, "", "", 0, now, now, nil)
+	rec.TrackRelayerExec("d", []string{"rly", "d"}, "", "", 0, now, now, nil)
+
+	execs := rec.Execs()
+	require.Len(t, execs, 3)
+	for i, want := range []string{"rly-a", "rly-c", ""} {
+		require.Equal(t, "my_test", execs[i].Name)
+		require.Equal(t, want, execs[i].Relayer)
+	}
+}
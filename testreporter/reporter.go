@@ -150,6 +150,26 @@ func (r *Reporter) TrackSkip(t T, format string, args ...any) {
 	t.Skip(msg)
 }
 
+// TrackArtifactDir records the path of the artifact directory belonging to t.
+func (r *Reporter) TrackArtifactDir(t T, dir string) {
+	r.in <- ArtifactDirMessage{
+		Name: t.Name(),
+		When: time.Now(),
+		Path: dir,
+	}
+}
+
+// TrackMetric records the measurement value of metric taken by t, in unit, e.g. "packets/min".
+func (r *Reporter) TrackMetric(t T, metric string, value float64, unit string) {
+	r.in <- MetricMessage{
+		Name:   t.Name(),
+		When:   time.Now(),
+		Metric: metric,
+		Value:  value,
+		Unit:   unit,
+	}
+}
+
 // RelayerExecReporter returns a RelayerExecReporter associated with t.
 func (r *Reporter) RelayerExecReporter(t T) *RelayerExecReporter {
 	return &RelayerExecReporter{r: r, testName: t.Name()}
@@ -160,6 +180,33 @@ func (r *Reporter) RelayerExecReporter(t T) *RelayerExecReporter {
 type RelayerExecReporter struct {
 	r        *Reporter
 	testName string
+
+	// relayerName is set through ForRelayer.
+	relayerName string
+
+	// rec additionally records tracked commands, if the reporter belongs to a RelayerExecRecorder.
+	rec *RelayerExecRecorder
+}
+
+// ForRelayer returns a RelayerExecReporter that attributes tracked commands to the named relayer,
+// so that the commands of multiple relayers in a single test can be told apart in the report.
+// Commands tracked by the returned reporter are still recorded by the RelayerExecRecorder of r, if any.
+func (r *RelayerExecReporter) ForRelayer(name string) *RelayerExecReporter {
+	// Copy r so that the reporter for the relayer keeps every sink of r.
+	rr := *r
+	rr.relayerName = name
+	return &rr
+}
+
+// TrackMetric records the measurement value of metric taken by the test of r, in unit, see Reporter.TrackMetric.
+func (r *RelayerExecReporter) TrackMetric(metric string, value float64, unit string) {
+	r.r.in <- MetricMessage{
+		Name:   r.testName,
+		When:   time.Now(),
+		Metric: metric,
+		Value:  value,
+		Unit:   unit,
+	}
 }
 
 // TrackRelayerExec tracks the execution of an individual relayer command.
@@ -175,8 +222,9 @@ func (r *RelayerExecReporter) TrackRelayerExec(
 	if err != nil {
 		errMsg = err.Error()
 	}
-	r.r.in <- RelayerExecMessage{
+	msg := RelayerExecMessage{
 		Name:          r.testName,
+		Relayer:       r.relayerName,
 		StartedAt:     startedAt,
 		FinishedAt:    finishedAt,
 		ContainerName: containerName,
@@ -186,6 +234,11 @@ func (r *RelayerExecReporter) TrackRelayerExec(
 		ExitCode:      exitCode,
 		Error:         errMsg,
 	}
+	r.r.in <- msg
+
+	if r.rec != nil {
+		r.rec.record(msg)
+	}
 }
 
 // TestifyT returns a TestifyReporter which will track logged errors in test.
@@ -261,6 +261,77 @@ func TestReporter_RelayerExec(t *testing.T) {
 	require.Empty(t, diff)
 }
 
+func TestReporter_RelayerExecForRelayer(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+
+	eRep := r.RelayerExecReporter(mt)
+	now := time.Now()
+	eRep.ForRelayer("rly-a").TrackRelayerExec("a", []string{"rly", "a"}, "", "", 0, now, now, nil)
+	eRep.ForRelayer("rly-b").TrackRelayerExec("b", []string{"rly", "b"}, "", "", 0, now, now, nil)
+	eRep.TrackRelayerExec("c", []string{"rly", "c"}, "", "", 0, now, now, nil)
+
+	require.NoError(t, r.Close())
+
+	msgs := ReporterMessages(t, buf)
+	require.Len(t, msgs, 5) // Begin suite, 3 execs, finish suite.
+
+	for i, want := range []string{"rly-a", "rly-b", ""} {
+		m := msgs[i+1].(testreporter.RelayerExecMessage)
+		require.Equal(t, "my_test", m.Name)
+		require.Equal(t, want, m.Relayer)
+	}
+}
+
+func TestReporter_TrackMetric(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+	beforeTrack := time.Now()
+	r.TrackMetric(mt, "throughput", 42.5, "packets/min")
+	afterTrack := time.Now()
+
+	require.NoError(t, r.Close())
+
+	msgs := ReporterMessages(t, buf)
+	require.Len(t, msgs, 3) // Begin suite, metric, finish suite.
+
+	m := msgs[1].(testreporter.MetricMessage)
+	require.Equal(t, "my_test", m.Name)
+	require.Equal(t, "throughput", m.Metric)
+	require.Equal(t, 42.5, m.Value)
+	require.Equal(t, "packets/min", m.Unit)
+	requireTimeInRange(t, m.When, beforeTrack, afterTrack)
+}
+
+func TestReporter_RelayerExecTrackMetric(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+	r.RelayerExecReporter(mt).TrackMetric("throttled queries", 3, "queries")
+
+	require.NoError(t, r.Close())
+
+	msgs := ReporterMessages(t, buf)
+	require.Len(t, msgs, 3) // Begin suite, metric, finish suite.
+
+	m := msgs[1].(testreporter.MetricMessage)
+	require.Equal(t, "my_test", m.Name)
+	require.Equal(t, "throttled queries", m.Metric)
+	require.Equal(t, float64(3), m.Value)
+	require.Equal(t, "queries", m.Unit)
+}
+
 // requireTimeInRange is a helper to assert that a time occurs between a given start and end.
 func requireTimeInRange(t *testing.T, actual, notBefore, notAfter time.Time) {
 	t.Helper()
@@ -0,0 +1,30 @@
+package testutil
+
+import "fmt"
+
+// TxGas is the gas of a committed transaction, e.g. from (cosmos.TxResult).Gas.
+type TxGas struct {
+	// Gas requested by the transaction, which bounds the gas it may use.
+	GasWanted int64
+	// Gas actually consumed by the transaction.
+	GasUsed int64
+}
+
+// AssertGasWithin returns an error unless the gas used by the transaction is within [min, max],
+// e.g. to detect that a chain upgrade changed the gas cost of a message.
+//
+// The gas used is asserted rather than the gas wanted, which only reflects how the transaction was simulated
+// and adjusted. The gas used must still not exceed the gas wanted, since no committed transaction does,
+// so a violation points to a caller passing the wrong values.
+func AssertGasWithin(tx TxGas, min, max int64) error {
+	if min > max {
+		return fmt.Errorf("invalid gas budget: min %d is greater than max %d", min, max)
+	}
+	if tx.GasUsed > tx.GasWanted {
+		return fmt.Errorf("gas used %d exceeds gas wanted %d", tx.GasUsed, tx.GasWanted)
+	}
+	if tx.GasUsed < min || tx.GasUsed > max {
+		return fmt.Errorf("gas used %d is outside of budget [%d, %d] (gas wanted %d)", tx.GasUsed, min, max, tx.GasWanted)
+	}
+	return nil
+}
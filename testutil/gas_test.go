@@ -0,0 +1,24 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertGasWithin(t *testing.T) {
+	require.NoError(t, AssertGasWithin(TxGas{GasWanted: 120_000, GasUsed: 80_000}, 70_000, 90_000))
+	require.NoError(t, AssertGasWithin(TxGas{GasWanted: 80_000, GasUsed: 80_000}, 80_000, 80_000))
+
+	require.EqualError(t, AssertGasWithin(TxGas{GasWanted: 120_000, GasUsed: 95_000}, 70_000, 90_000),
+		"gas used 95000 is outside of budget [70000, 90000] (gas wanted 120000)")
+	require.EqualError(t, AssertGasWithin(TxGas{GasWanted: 120_000, GasUsed: 60_000}, 70_000, 90_000),
+		"gas used 60000 is outside of budget [70000, 90000] (gas wanted 120000)")
+
+	// A high gas wanted does not fail the budget, but gas used above it is invalid.
+	require.NoError(t, AssertGasWithin(TxGas{GasWanted: 1_000_000, GasUsed: 80_000}, 70_000, 90_000))
+	require.EqualError(t, AssertGasWithin(TxGas{GasWanted: 70_000, GasUsed: 80_000}, 70_000, 90_000),
+		"gas used 80000 exceeds gas wanted 70000")
+
+	require.ErrorContains(t, AssertGasWithin(TxGas{GasWanted: 1, GasUsed: 1}, 2, 1), "invalid gas budget")
+}
@@ -0,0 +1,41 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+
+	clienttypes "github.com/cosmos/ibc-go/v6/modules/core/02-client/types"
+	ibcexported "github.com/cosmos/ibc-go/v6/modules/core/exported"
+	ibctm "github.com/cosmos/ibc-go/v6/modules/light-clients/07-tendermint"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// Forker is a chain whose validators can be made to double-sign a header conflicting with one of its blocks.
+type Forker interface {
+	ForkMisbehaviour(ctx context.Context, clientID string, trustedHeight clienttypes.Height) (*ibctm.Misbehaviour, error)
+}
+
+// MisbehaviourSubmitter is a chain hosting a light client of a Forker, which it freezes on misbehaviour.
+type MisbehaviourSubmitter interface {
+	QueryClientState(ctx context.Context, clientID string) (ibcexported.ClientState, error)
+	SubmitMisbehaviour(ctx context.Context, wallet ibc.Wallet, misbehaviour *ibctm.Misbehaviour) error
+}
+
+// InduceFork forks chain, making its validators sign a header conflicting with one of its blocks,
+// and submits the misbehaviour to its light client with clientID on host, signed by wallet,
+// so that the client is frozen. Assert it with the Frozen status of the client, see ClientStatusQuerier.
+// The misbehaviour is verified against the latest consensus state of the client.
+func InduceFork(ctx context.Context, chain Forker, host MisbehaviourSubmitter, clientID string, wallet ibc.Wallet) error {
+	clientState, err := host.QueryClientState(ctx, clientID)
+	if err != nil {
+		return err
+	}
+	latest := clientState.GetLatestHeight()
+	trustedHeight := clienttypes.NewHeight(latest.GetRevisionNumber(), latest.GetRevisionHeight())
+
+	misbehaviour, err := chain.ForkMisbehaviour(ctx, clientID, trustedHeight)
+	if err != nil {
+		return fmt.Errorf("fork chain of client %s: %w", clientID, err)
+	}
+	return host.SubmitMisbehaviour(ctx, wallet, misbehaviour)
+}
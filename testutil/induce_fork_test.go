@@ -0,0 +1,73 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	clienttypes "github.com/cosmos/ibc-go/v6/modules/core/02-client/types"
+	ibcexported "github.com/cosmos/ibc-go/v6/modules/core/exported"
+	ibctm "github.com/cosmos/ibc-go/v6/modules/light-clients/07-tendermint"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+type mockForker struct {
+	Err error
+
+	clientID      string
+	trustedHeight clienttypes.Height
+}
+
+func (m *mockForker) ForkMisbehaviour(ctx context.Context, clientID string, trustedHeight clienttypes.Height) (*ibctm.Misbehaviour, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.clientID, m.trustedHeight = clientID, trustedHeight
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return &ibctm.Misbehaviour{ClientId: clientID}, nil
+}
+
+type mockMisbehaviourSubmitter struct {
+	LatestHeight clienttypes.Height
+
+	submitted *ibctm.Misbehaviour
+	signer    ibc.Wallet
+}
+
+func (m *mockMisbehaviourSubmitter) QueryClientState(ctx context.Context, clientID string) (ibcexported.ClientState, error) {
+	return &ibctm.ClientState{LatestHeight: m.LatestHeight}, nil
+}
+
+func (m *mockMisbehaviourSubmitter) SubmitMisbehaviour(ctx context.Context, wallet ibc.Wallet, misbehaviour *ibctm.Misbehaviour) error {
+	m.submitted, m.signer = misbehaviour, wallet
+	return nil
+}
+
+func TestInduceFork(t *testing.T) {
+	ctx := context.Background()
+	latest := clienttypes.NewHeight(1, 42)
+
+	t.Run("happy path", func(t *testing.T) {
+		chain := mockForker{}
+		host := mockMisbehaviourSubmitter{LatestHeight: latest}
+		wallet := mockWallet{}
+
+		require.NoError(t, InduceFork(ctx, &chain, &host, "07-tendermint-0", wallet))
+		require.Equal(t, "07-tendermint-0", chain.clientID)
+		require.Equal(t, latest, chain.trustedHeight)
+		require.Equal(t, "07-tendermint-0", host.submitted.ClientId)
+		require.Equal(t, wallet, host.signer)
+	})
+
+	t.Run("fork error", func(t *testing.T) {
+		chain := mockForker{Err: errors.New("boom")}
+		host := mockMisbehaviourSubmitter{LatestHeight: latest}
+
+		err := InduceFork(ctx, &chain, &host, "07-tendermint-0", mockWallet{})
+		require.ErrorIs(t, err, chain.Err)
+		require.Nil(t, host.submitted)
+	})
+}
@@ -0,0 +1,40 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// NodeRestarter is a chain that can restart the node relayers connect to.
+type NodeRestarter interface {
+	ChainAcker
+	RestartFullNode(ctx context.Context) error
+}
+
+// AssertRelayerSurvivesNodeRestart sends a packet with send, restarts the full node of chain while the packet
+// is in flight, then waits up to maxBlocks blocks for the packet to be acknowledged on chain.
+// The packet must be sent from chain, and a relayer must already be relaying its channel.
+// Returns an error if the packet is not acknowledged after the restart.
+func AssertRelayerSurvivesNodeRestart(
+	ctx context.Context,
+	chain NodeRestarter,
+	send func(ctx context.Context) (ibc.Tx, error),
+	maxBlocks uint64,
+) (ibc.PacketAcknowledgement, error) {
+	var zero ibc.PacketAcknowledgement
+
+	tx, err := send(ctx)
+	if err != nil {
+		return zero, fmt.Errorf("send packet: %w", err)
+	}
+	if err := chain.RestartFullNode(ctx); err != nil {
+		return zero, fmt.Errorf("restart node: %w", err)
+	}
+	ack, err := PollForAck(ctx, chain, tx.Height, tx.Height+maxBlocks, tx.Packet)
+	if err != nil {
+		return zero, fmt.Errorf("packet not acknowledged after node restart: %w", err)
+	}
+	return ack, nil
+}
@@ -0,0 +1,77 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRestarter struct {
+	mockChain
+
+	RestartErr   error
+	RestartCount int
+}
+
+func (m *mockRestarter) RestartFullNode(ctx context.Context) error {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.RestartCount++
+	return m.RestartErr
+}
+
+func TestAssertRelayerSurvivesNodeRestart(t *testing.T) {
+	ctx := context.Background()
+	packet := ibc.Packet{Sequence: 7, SourceChannel: "channel-0"}
+
+	t.Run("happy path", func(t *testing.T) {
+		chain := mockRestarter{mockChain: mockChain{
+			CurrentHeight: 10,
+			FoundAcks:     []ibc.PacketAcknowledgement{{Packet: packet}},
+		}}
+		send := func(ctx context.Context) (ibc.Tx, error) {
+			require.Zero(t, chain.RestartCount, "packet must be sent before the restart")
+			return ibc.Tx{Height: 10, Packet: packet}, nil
+		}
+
+		got, err := AssertRelayerSurvivesNodeRestart(ctx, &chain, send, 5)
+		require.NoError(t, err)
+		require.Equal(t, packet, got.Packet)
+		require.Equal(t, 1, chain.RestartCount)
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		var chain mockRestarter
+		send := func(ctx context.Context) (ibc.Tx, error) {
+			return ibc.Tx{}, errors.New("send go boom")
+		}
+
+		_, err := AssertRelayerSurvivesNodeRestart(ctx, &chain, send, 5)
+		require.ErrorContains(t, err, "send go boom")
+		require.Zero(t, chain.RestartCount)
+	})
+
+	t.Run("restart error", func(t *testing.T) {
+		chain := mockRestarter{RestartErr: errors.New("restart go boom")}
+		send := func(ctx context.Context) (ibc.Tx, error) {
+			return ibc.Tx{Height: 1, Packet: packet}, nil
+		}
+
+		_, err := AssertRelayerSurvivesNodeRestart(ctx, &chain, send, 5)
+		require.ErrorContains(t, err, "restart go boom")
+	})
+
+	t.Run("not acknowledged", func(t *testing.T) {
+		chain := mockRestarter{mockChain: mockChain{CurrentHeight: 10}}
+		send := func(ctx context.Context) (ibc.Tx, error) {
+			return ibc.Tx{Height: 10, Packet: packet}, nil
+		}
+
+		_, err := AssertRelayerSurvivesNodeRestart(ctx, &chain, send, 2)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -0,0 +1,69 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// PacketReceiver is a chain that can find the packets it received on a channel.
+type PacketReceiver interface {
+	// ReceivedPackets returns the packets received on the channel end dstChannelID of the chain,
+	// in the order the chain received them.
+	ReceivedPackets(ctx context.Context, dstChannelID string) ([]ibc.Packet, error)
+}
+
+// AssertOrderedDelivery asserts that dstChain received the packets with sentSequences, sent in ascending order
+// on the counterparty of its channel end dstChannelID, exactly once each and in ascending sequence order,
+// as an ordered channel requires. This catches relayers delivering packets of ordered channels out of order,
+// which balance checks miss.
+//
+// Packets received on the channel with other sequences are ignored, e.g. those of earlier phases of a test.
+// AssertOrderedDelivery does not wait for the packets, so they must have been relayed before it is called,
+// e.g. with PollForAck on the source chain.
+func AssertOrderedDelivery(ctx context.Context, dstChain PacketReceiver, dstChannelID string, sentSequences []uint64) error {
+	if len(sentSequences) == 0 {
+		return errors.New("no sent sequences")
+	}
+	sent := make(map[uint64]bool, len(sentSequences))
+	for i, seq := range sentSequences {
+		if i > 0 && seq <= sentSequences[i-1] {
+			return fmt.Errorf("sent sequences must be ascending, got %d after %d", seq, sentSequences[i-1])
+		}
+		sent[seq] = true
+	}
+
+	packets, err := dstChain.ReceivedPackets(ctx, dstChannelID)
+	if err != nil {
+		return fmt.Errorf("failed to find packets received on %s: %w", dstChannelID, err)
+	}
+
+	received := make(map[uint64]bool, len(sentSequences))
+	var last uint64
+	for _, p := range packets {
+		if !sent[p.Sequence] {
+			continue
+		}
+		if received[p.Sequence] {
+			return fmt.Errorf("packet %d was received more than once on %s", p.Sequence, dstChannelID)
+		}
+		if p.Sequence < last {
+			return fmt.Errorf("packet %d was received after packet %d on %s", p.Sequence, last, dstChannelID)
+		}
+		received[p.Sequence] = true
+		last = p.Sequence
+	}
+
+	var missing []uint64
+	for _, seq := range sentSequences {
+		if !received[seq] {
+			missing = append(missing, seq)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("packets %v were not received on %s", missing, dstChannelID)
+	}
+	return nil
+}
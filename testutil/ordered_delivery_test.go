@@ -0,0 +1,84 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPacketReceiver struct {
+	Sequences []uint64
+	Err       error
+
+	GotChannelID string
+}
+
+func (m *mockPacketReceiver) ReceivedPackets(ctx context.Context, dstChannelID string) ([]ibc.Packet, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotChannelID = dstChannelID
+	packets := make([]ibc.Packet, len(m.Sequences))
+	for i, seq := range m.Sequences {
+		packets[i] = ibc.Packet{Sequence: seq, DestChannel: dstChannelID}
+	}
+	return packets, m.Err
+}
+
+func TestAssertOrderedDelivery(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("happy path", func(t *testing.T) {
+		// Packets of earlier phases of a test are ignored.
+		chain := mockPacketReceiver{Sequences: []uint64{1, 2, 3, 4, 5}}
+
+		require.NoError(t, AssertOrderedDelivery(ctx, &chain, "channel-1", []uint64{3, 4, 5}))
+		require.Equal(t, "channel-1", chain.GotChannelID)
+	})
+
+	t.Run("out of order", func(t *testing.T) {
+		chain := mockPacketReceiver{Sequences: []uint64{1, 3, 2}}
+
+		err := AssertOrderedDelivery(ctx, &chain, "channel-1", []uint64{1, 2, 3})
+		require.Error(t, err)
+		require.EqualError(t, err, "packet 2 was received after packet 3 on channel-1")
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		chain := mockPacketReceiver{Sequences: []uint64{1, 2, 2, 3}}
+
+		err := AssertOrderedDelivery(ctx, &chain, "channel-1", []uint64{1, 2, 3})
+		require.Error(t, err)
+		require.EqualError(t, err, "packet 2 was received more than once on channel-1")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		chain := mockPacketReceiver{Sequences: []uint64{1, 3}}
+
+		err := AssertOrderedDelivery(ctx, &chain, "channel-1", []uint64{1, 2, 3, 4})
+		require.Error(t, err)
+		require.EqualError(t, err, "packets [2 4] were not received on channel-1")
+	})
+
+	t.Run("invalid sent sequences", func(t *testing.T) {
+		var chain mockPacketReceiver
+
+		err := AssertOrderedDelivery(ctx, &chain, "channel-1", nil)
+		require.Error(t, err)
+
+		err = AssertOrderedDelivery(ctx, &chain, "channel-1", []uint64{2, 1})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "ascending")
+	})
+
+	t.Run("receiver error", func(t *testing.T) {
+		chain := mockPacketReceiver{Err: errors.New("boom")}
+
+		err := AssertOrderedDelivery(ctx, &chain, "channel-1", []uint64{1})
+		require.Error(t, err)
+		require.ErrorIs(t, err, chain.Err)
+	})
+}
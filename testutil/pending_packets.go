@@ -0,0 +1,44 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// PendingPacketsGetter is a relayer that can report the unrelayed packets and acknowledgements of a channel.
+type PendingPacketsGetter interface {
+	GetPendingPackets(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, channelID string) (ibc.PendingPackets, error)
+}
+
+// PendingPacketsQuerier is a chain that can query the unrelayed packets and acknowledgements of one of its channels
+// from the packet commitments on both ends, such as a CosmosChain.
+type PendingPacketsQuerier interface {
+	QueryPendingPackets(ctx context.Context, counterparty ibc.Chain, portID, channelID string) (ibc.PendingPackets, error)
+}
+
+// GetPendingPackets returns the pending packets of r on channel, on the path pathName.
+// The channel may be in any state, including CLOSED.
+// If the relayer fails and chain, the chain of channel, is not nil, the packets are queried from chain
+// and its counterparty instead; the error then includes both failures.
+func GetPendingPackets(
+	ctx context.Context,
+	r PendingPacketsGetter,
+	rep ibc.RelayerExecReporter,
+	pathName string,
+	channel ibc.ChannelOutput,
+	chain PendingPacketsQuerier,
+	counterparty ibc.Chain,
+) (ibc.PendingPackets, error) {
+	pending, err := r.GetPendingPackets(ctx, rep, pathName, channel.ChannelID)
+	if err == nil || chain == nil {
+		return pending, err
+	}
+
+	pending, qErr := chain.QueryPendingPackets(ctx, counterparty, channel.PortID, channel.ChannelID)
+	if qErr != nil {
+		return pending, fmt.Errorf("relayer: %w; chain query: %v", err, qErr)
+	}
+	return pending, nil
+}
@@ -0,0 +1,75 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPendingPacketsGetter struct {
+	Pending ibc.PendingPackets
+	Err     error
+
+	Channels []string
+}
+
+func (m *mockPendingPacketsGetter) GetPendingPackets(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, channelID string) (ibc.PendingPackets, error) {
+	m.Channels = append(m.Channels, pathName+" "+channelID)
+	return m.Pending, m.Err
+}
+
+type mockPendingPacketsQuerier struct {
+	Pending ibc.PendingPackets
+	Err     error
+
+	Channels []string
+}
+
+func (m *mockPendingPacketsQuerier) QueryPendingPackets(ctx context.Context, counterparty ibc.Chain, portID, channelID string) (ibc.PendingPackets, error) {
+	m.Channels = append(m.Channels, portID+"/"+channelID)
+	return m.Pending, m.Err
+}
+
+func TestGetPendingPackets(t *testing.T) {
+	ctx := context.Background()
+	channel := ibc.ChannelOutput{PortID: "icacontroller-owner", ChannelID: "channel-1", State: ibc.ChannelStateClosed}
+
+	t.Run("relayer", func(t *testing.T) {
+		r := &mockPendingPacketsGetter{Pending: ibc.PendingPackets{SrcPackets: []uint64{1}}}
+		chain := &mockPendingPacketsQuerier{}
+
+		pending, err := GetPendingPackets(ctx, r, nil, "p", channel, chain, nil)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{1}, pending.SrcPackets)
+		require.Equal(t, []string{"p channel-1"}, r.Channels)
+		require.Empty(t, chain.Channels)
+	})
+
+	t.Run("chain fallback", func(t *testing.T) {
+		r := &mockPendingPacketsGetter{Err: errors.New("channel not found")}
+		chain := &mockPendingPacketsQuerier{Pending: ibc.PendingPackets{DstAcks: []uint64{2}}}
+
+		pending, err := GetPendingPackets(ctx, r, nil, "p", channel, chain, nil)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{2}, pending.DstAcks)
+		require.Equal(t, []string{"icacontroller-owner/channel-1"}, chain.Channels)
+	})
+
+	t.Run("no fallback", func(t *testing.T) {
+		r := &mockPendingPacketsGetter{Err: errors.New("channel not found")}
+
+		_, err := GetPendingPackets(ctx, r, nil, "p", channel, nil, nil)
+		require.EqualError(t, err, "channel not found")
+	})
+
+	t.Run("both fail", func(t *testing.T) {
+		r := &mockPendingPacketsGetter{Err: errors.New("channel not found")}
+		chain := &mockPendingPacketsQuerier{Err: errors.New("connection refused")}
+
+		_, err := GetPendingPackets(ctx, r, nil, "p", channel, chain, nil)
+		require.EqualError(t, err, "relayer: channel not found; chain query: connection refused")
+	})
+}
@@ -1,6 +1,7 @@
 package testutil
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -86,6 +87,47 @@ func PollForAck(ctx context.Context, chain ChainAcker, startHeight, maxHeight ui
 	return found, nil
 }
 
+// PollForAckOfPacketData attempts to find an acknowledgement of a packet with the given data, like PollForAck,
+// for packets only known by their data, e.g. from the send_packet event of an interchain account transaction.
+// Heights whose acknowledgements cannot be queried, e.g. with messages the chain's codec cannot decode, are skipped.
+// If no acknowledgement is found, the returned error wraps ErrNotFound and reports the errors of the skipped heights.
+func PollForAckOfPacketData(ctx context.Context, chain ChainAcker, startHeight, maxHeight uint64, data []byte) (ibc.PacketAcknowledgement, error) {
+	var (
+		zero    ibc.PacketAcknowledgement
+		skipped []string
+		pollErr error
+	)
+	poll := func(ctx context.Context, height uint64) (ibc.PacketAcknowledgement, error) {
+		acks, err := chain.Acknowledgements(ctx, height)
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("height %d: %v", height, err))
+			pollErr = err
+			return zero, err
+		}
+		for _, ack := range acks {
+			if bytes.Equal(ack.Packet.Data, data) {
+				return ack, nil
+			}
+		}
+		pollErr = ErrNotFound
+		return zero, ErrNotFound
+	}
+
+	poller := BlockPoller[ibc.PacketAcknowledgement]{CurrentHeight: chain.Height, PollFunc: poll}
+	found, err := poller.DoPoll(ctx, startHeight, maxHeight)
+	if err == nil {
+		return found, nil
+	}
+	if err != pollErr {
+		// The height of the chain could not be queried.
+		return zero, err
+	}
+	if len(skipped) > 0 {
+		return zero, fmt.Errorf("%w, skipped heights whose acknowledgements could not be queried: %s", ErrNotFound, strings.Join(skipped, "; "))
+	}
+	return zero, ErrNotFound
+}
+
 // ChainTimeouter is a chain that can get its timeouts at a specified height
 type ChainTimeouter interface {
 	ChainHeighter
@@ -153,3 +195,28 @@ func (pe *packetPollError) Format(s fmt.State, verb rune) {
 	final := fmt.Sprintf("%s\n- target packet:\n%s\n- searched:\n%+v", pe.error, target, searched)
 	fmt.Fprint(s, final)
 }
+
+// ChannelEndQuerier is a chain that can query an end of one of its channels, such as a CosmosChain.
+type ChannelEndQuerier interface {
+	ChainHeighter
+	QueryChannelEnd(ctx context.Context, portID, channelID string) (ibc.ChannelOutput, error)
+}
+
+// PollForChannelState polls the channel end with portID and channelID on chain until it is in state,
+// e.g. closed after the timeout of a packet on an ordered channel.
+// Otherwise, works identically to PollForAck; the error reports the last state of the channel end.
+func PollForChannelState(ctx context.Context, chain ChannelEndQuerier, startHeight, maxHeight uint64, portID, channelID string, state ibc.ChannelState) (ibc.ChannelOutput, error) {
+	poll := func(ctx context.Context, height uint64) (ibc.ChannelOutput, error) {
+		ch, err := chain.QueryChannelEnd(ctx, portID, channelID)
+		if err != nil {
+			return ibc.ChannelOutput{}, err
+		}
+		if ch.State != state {
+			return ibc.ChannelOutput{}, fmt.Errorf("channel %s/%s is %s, not %s", portID, channelID, ch.State, state)
+		}
+		return ch, nil
+	}
+
+	poller := BlockPoller[ibc.ChannelOutput]{CurrentHeight: chain.Height, PollFunc: poll}
+	return poller.DoPoll(ctx, startHeight, maxHeight)
+}
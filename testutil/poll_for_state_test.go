@@ -19,9 +19,15 @@ type mockChain struct {
 
 	FoundAcks []ibc.PacketAcknowledgement
 	AckErr    error
+	// AckErrAt overrides AckErr at the given heights.
+	AckErrAt map[uint64]error
 
 	FoundTimeouts []ibc.PacketTimeout
 	TimeoutErr    error
+
+	// ChannelStates are the states of the channel end, one per query, repeating the last one.
+	ChannelStates []ibc.ChannelState
+	ChannelErr    error
 }
 
 func (m *mockChain) Height(ctx context.Context) (uint64, error) {
@@ -38,6 +44,9 @@ func (m *mockChain) Acknowledgements(ctx context.Context, height uint64) ([]ibc.
 		panic("nil context")
 	}
 	m.GotHeights = append(m.GotHeights, height)
+	if err, ok := m.AckErrAt[height]; ok {
+		return nil, err
+	}
 	return m.FoundAcks, m.AckErr
 }
 
@@ -49,6 +58,20 @@ func (m *mockChain) Timeouts(ctx context.Context, height uint64) ([]ibc.PacketTi
 	return m.FoundTimeouts, m.TimeoutErr
 }
 
+func (m *mockChain) QueryChannelEnd(ctx context.Context, portID, channelID string) (ibc.ChannelOutput, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	if m.ChannelErr != nil {
+		return ibc.ChannelOutput{}, m.ChannelErr
+	}
+	state := m.ChannelStates[len(m.ChannelStates)-1]
+	if len(m.ChannelStates) > 1 {
+		m.ChannelStates = m.ChannelStates[1:]
+	}
+	return ibc.ChannelOutput{State: state, PortID: portID, ChannelID: channelID}, nil
+}
+
 func TestPollForAck(t *testing.T) {
 	ctx := context.Background()
 
@@ -109,6 +132,63 @@ func TestPollForAck(t *testing.T) {
 	})
 }
 
+func TestPollForAckOfPacketData(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("happy path", func(t *testing.T) {
+		chain := mockChain{
+			CurrentHeight: 1,
+			FoundAcks: []ibc.PacketAcknowledgement{
+				{Packet: ibc.Packet{Sequence: 44, Data: []byte("other")}},
+				{Packet: ibc.Packet{Sequence: 33, Data: []byte("found")}},
+			},
+			AckErrAt: map[uint64]error{3: errors.New("undecodable tx")},
+		}
+		got, err := PollForAckOfPacketData(ctx, &chain, 3, 5, []byte("found"))
+
+		require.NoError(t, err)
+		require.EqualValues(t, 33, got.Packet.Sequence)
+		require.Equal(t, []uint64{3, 4}, chain.GotHeights)
+	})
+
+	t.Run("height error", func(t *testing.T) {
+		chain := mockChain{HeightErr: errors.New("height go boom")}
+		_, err := PollForAckOfPacketData(ctx, &chain, 3, 5, nil)
+
+		require.EqualError(t, err, "height go boom")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		chain := mockChain{CurrentHeight: 1, FoundAcks: []ibc.PacketAcknowledgement{
+			{Packet: ibc.Packet{Sequence: 10, Data: []byte("other")}},
+		}}
+		_, err := PollForAckOfPacketData(ctx, &chain, 1, 3, []byte("missing"))
+
+		require.EqualError(t, err, "not found")
+		require.Equal(t, []uint64{1, 2, 3}, chain.GotHeights)
+	})
+
+	t.Run("skipped heights reported", func(t *testing.T) {
+		chain := mockChain{
+			CurrentHeight: 1,
+			AckErrAt:      map[uint64]error{2: errors.New("undecodable tx")},
+		}
+		_, err := PollForAckOfPacketData(ctx, &chain, 1, 3, []byte("missing"))
+
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Contains(t, err.Error(), "height 2: undecodable tx")
+		require.Equal(t, []uint64{1, 2, 3}, chain.GotHeights)
+	})
+
+	t.Run("query errors reported", func(t *testing.T) {
+		chain := mockChain{CurrentHeight: 1, AckErr: errors.New("ack go boom")}
+		_, err := PollForAckOfPacketData(ctx, &chain, 1, 2, nil)
+
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Contains(t, err.Error(), "height 1: ack go boom; height 2: ack go boom")
+	})
+}
+
 func TestPollForTimeout(t *testing.T) {
 	ctx := context.Background()
 
@@ -160,3 +240,29 @@ func TestPollForTimeout(t *testing.T) {
 		})
 	})
 }
+
+func TestPollForChannelState(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("happy path", func(t *testing.T) {
+		chain := mockChain{CurrentHeight: 1, ChannelStates: []ibc.ChannelState{ibc.ChannelStateOpen, ibc.ChannelStateOpen, ibc.ChannelStateClosed}}
+		got, err := PollForChannelState(ctx, &chain, 1, 5, "icahost", "channel-0", ibc.ChannelStateClosed)
+
+		require.NoError(t, err)
+		require.Equal(t, ibc.ChannelOutput{State: ibc.ChannelStateClosed, PortID: "icahost", ChannelID: "channel-0"}, got)
+	})
+
+	t.Run("never reached", func(t *testing.T) {
+		chain := mockChain{CurrentHeight: 1, ChannelStates: []ibc.ChannelState{ibc.ChannelStateOpen}}
+		_, err := PollForChannelState(ctx, &chain, 1, 3, "icahost", "channel-0", ibc.ChannelStateClosed)
+
+		require.EqualError(t, err, fmt.Sprintf("channel icahost/channel-0 is %s, not %s", ibc.ChannelStateOpen, ibc.ChannelStateClosed))
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		chain := mockChain{CurrentHeight: 1, ChannelErr: errors.New("channel go boom")}
+		_, err := PollForChannelState(ctx, &chain, 1, 3, "icahost", "channel-0", ibc.ChannelStateClosed)
+
+		require.EqualError(t, err, "channel go boom")
+	})
+}
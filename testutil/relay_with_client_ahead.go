@@ -0,0 +1,34 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// ClientAheadRelayer is the subset of ibc.Relayer needed by RelayWithClientAhead.
+type ClientAheadRelayer interface {
+	UpdateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error
+	FlushPackets(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, channelID string) error
+}
+
+// RelayWithClientAhead relays the packets pending on channelID of pathName, after advancing the source chain
+// extraBlocks and updating the clients on pathName. The relayer then proves the packet commitments
+// against a client height newer than the height at which the packets were sent,
+// which exercises a known class of proof height bugs.
+//
+// The relayer must not be running, otherwise it may relay the packets before the client is ahead.
+// Acknowledgements are not relayed; use the relayer's FlushAcknowledgements afterwards.
+func RelayWithClientAhead(ctx context.Context, r ClientAheadRelayer, rep ibc.RelayerExecReporter, pathName, channelID string, src ChainHeighter, extraBlocks int) error {
+	if err := WaitForBlocks(ctx, extraBlocks, src); err != nil {
+		return fmt.Errorf("failed to advance source chain %d blocks: %w", extraBlocks, err)
+	}
+	if err := r.UpdateClients(ctx, rep, pathName); err != nil {
+		return fmt.Errorf("failed to update clients on path %s: %w", pathName, err)
+	}
+	if err := r.FlushPackets(ctx, rep, pathName, channelID); err != nil {
+		return fmt.Errorf("failed to flush packets on path %s channel %s: %w", pathName, channelID, err)
+	}
+	return nil
+}
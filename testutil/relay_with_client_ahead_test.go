@@ -0,0 +1,47 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+type mockClientAheadRelayer struct {
+	UpdateErr error
+
+	Calls []string
+}
+
+func (m *mockClientAheadRelayer) UpdateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
+	m.Calls = append(m.Calls, "update "+pathName)
+	return m.UpdateErr
+}
+
+func (m *mockClientAheadRelayer) FlushPackets(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, channelID string) error {
+	m.Calls = append(m.Calls, "flush "+pathName+" "+channelID)
+	return nil
+}
+
+func TestRelayWithClientAhead(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		chain := &mockChain{}
+		r := &mockClientAheadRelayer{}
+
+		require.NoError(t, RelayWithClientAhead(context.Background(), r, nil, "p", "channel-0", chain, 5))
+
+		require.GreaterOrEqual(t, chain.CurrentHeight, 5)
+		require.Equal(t, []string{"update p", "flush p channel-0"}, r.Calls)
+	})
+
+	t.Run("update error", func(t *testing.T) {
+		chain := &mockChain{}
+		r := &mockClientAheadRelayer{UpdateErr: errors.New("boom")}
+
+		err := RelayWithClientAhead(context.Background(), r, nil, "p", "channel-0", chain, 1)
+		require.ErrorContains(t, err, "boom")
+		require.Equal(t, []string{"update p"}, r.Calls)
+	})
+}
@@ -0,0 +1,107 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	"github.com/strangelove-ventures/interchaintest/v6/acks"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// roundTripMaxBlocks is how many blocks AssertRoundTripConservation polls for each acknowledgement.
+const roundTripMaxBlocks = 30
+
+// RoundTripChain is a chain that can send ICS-20 transfers and get its acknowledgements, balances and supply.
+type RoundTripChain interface {
+	ChainAckBalancer
+	Config() ibc.ChainConfig
+	SendIBCTransfer(ctx context.Context, channelID, keyName string, amount ibc.WalletAmount, options ibc.TransferOptions) (ibc.Tx, error)
+	GetGasFeesInNativeDenom(gasPaid int64) int64
+	GetSupply(ctx context.Context, denom string) (int64, error)
+}
+
+// AssertRoundTripConservation transfers amount of chainA's native denom from wallet on chainA to chainB
+// over channelAB, then transfers the received vouchers back over channelBA, the counterparty of channelAB.
+// It asserts that the vouchers are burned on return, so that their supply on chainB returns to its value
+// before the transfer, i.e. zero unless other transfers are in flight, and that wallet's native balance
+// on chainA returns to its starting value minus the fees of the first transfer.
+//
+// The key of wallet must also exist on chainB under the same name, with funds for the fees of the return transfer,
+// e.g. recovered from the wallet's mnemonic. A relayer must already be relaying the channel.
+func AssertRoundTripConservation(ctx context.Context, chainA, chainB RoundTripChain, channelAB, channelBA string, wallet ibc.Wallet, amount int64) error {
+	denom := chainA.Config().Denom
+	addrA := wallet.FormattedAddress()
+	addrB, err := types.Bech32ifyAddressBytes(chainB.Config().Bech32Prefix, wallet.Address())
+	if err != nil {
+		return fmt.Errorf("failed to format address of %s on %s: %w", wallet.KeyName(), chainB.Config().ChainID, err)
+	}
+	voucher := transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom(transfertypes.PortID, channelBA, denom)).IBCDenom()
+
+	startBalance, err := chainA.GetBalance(ctx, addrA, denom)
+	if err != nil {
+		return fmt.Errorf("failed to get balance of %s: %w", addrA, err)
+	}
+	startSupply, err := chainB.GetSupply(ctx, voucher)
+	if err != nil {
+		return fmt.Errorf("failed to get supply of %s: %w", voucher, err)
+	}
+
+	out, err := roundTripTransfer(ctx, chainA, channelAB, wallet.KeyName(), ibc.WalletAmount{Address: addrB, Denom: denom, Amount: amount})
+	if err != nil {
+		return fmt.Errorf("transfer to %s: %w", chainB.Config().ChainID, err)
+	}
+	if out.Packet.DestChannel != channelBA {
+		return fmt.Errorf("transfer over %s was received on %s, expected %s", channelAB, out.Packet.DestChannel, channelBA)
+	}
+
+	supply, err := chainB.GetSupply(ctx, voucher)
+	if err != nil {
+		return fmt.Errorf("failed to get supply of %s: %w", voucher, err)
+	}
+	if minted := supply - startSupply; minted != amount {
+		return fmt.Errorf("%d%s minted on %s, expected %d", minted, voucher, chainB.Config().ChainID, amount)
+	}
+
+	if _, err := roundTripTransfer(ctx, chainB, channelBA, wallet.KeyName(), ibc.WalletAmount{Address: addrA, Denom: voucher, Amount: amount}); err != nil {
+		return fmt.Errorf("transfer back to %s: %w", chainA.Config().ChainID, err)
+	}
+
+	supply, err = chainB.GetSupply(ctx, voucher)
+	if err != nil {
+		return fmt.Errorf("failed to get supply of %s: %w", voucher, err)
+	}
+	if supply != startSupply {
+		return fmt.Errorf("supply of %s on %s is %d after the round trip, expected %d", voucher, chainB.Config().ChainID, supply, startSupply)
+	}
+
+	balance, err := chainA.GetBalance(ctx, addrA, denom)
+	if err != nil {
+		return fmt.Errorf("failed to get balance of %s: %w", addrA, err)
+	}
+	if want := startBalance - chainA.GetGasFeesInNativeDenom(out.GasSpent); balance != want {
+		return fmt.Errorf("balance of %s is %d%s after the round trip, expected %d%s", addrA, balance, denom, want, denom)
+	}
+	return nil
+}
+
+// roundTripTransfer sends the transfer from chain and waits for its successful acknowledgement.
+func roundTripTransfer(ctx context.Context, chain RoundTripChain, channelID, keyName string, amount ibc.WalletAmount) (ibc.Tx, error) {
+	tx, err := chain.SendIBCTransfer(ctx, channelID, keyName, amount, ibc.TransferOptions{})
+	if err != nil {
+		return tx, err
+	}
+	ack, err := PollForAck(ctx, chain, tx.Height, tx.Height+roundTripMaxBlocks, tx.Packet)
+	if err != nil {
+		return tx, fmt.Errorf("no acknowledgement of packet %d: %w", tx.Packet.Sequence, err)
+	}
+	channelAck, err := acks.Parse(ack.Acknowledgement)
+	if err != nil {
+		return tx, err
+	}
+	if !channelAck.Success() {
+		return tx, fmt.Errorf("packet %d acknowledged with error: %s", tx.Packet.Sequence, channelAck.Error.Msg)
+	}
+	return tx, nil
+}
@@ -0,0 +1,100 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRoundTripChain returns Balances and Supplies in order,
+// and acknowledges each sent transfer with Ack.
+type mockRoundTripChain struct {
+	mockBalanceChain
+
+	Cfg      ibc.ChainConfig
+	Tx       ibc.Tx
+	Ack      []byte
+	Supplies []int64
+
+	GotTransfers []ibc.WalletAmount
+}
+
+func (m *mockRoundTripChain) Config() ibc.ChainConfig { return m.Cfg }
+
+func (m *mockRoundTripChain) SendIBCTransfer(ctx context.Context, channelID, keyName string, amount ibc.WalletAmount, options ibc.TransferOptions) (ibc.Tx, error) {
+	m.GotTransfers = append(m.GotTransfers, amount)
+	m.FoundAcks = []ibc.PacketAcknowledgement{{Packet: m.Tx.Packet, Acknowledgement: m.Ack}}
+	return m.Tx, nil
+}
+
+func (m *mockRoundTripChain) GetGasFeesInNativeDenom(gasPaid int64) int64 { return gasPaid / 10 }
+
+func (m *mockRoundTripChain) GetSupply(ctx context.Context, denom string) (int64, error) {
+	supply := m.Supplies[0]
+	m.Supplies = m.Supplies[1:]
+	return supply, nil
+}
+
+type mockWallet struct{}
+
+func (mockWallet) KeyName() string          { return "user" }
+func (mockWallet) FormattedAddress() string { return "cosmos1user" }
+func (mockWallet) Mnemonic() string         { return "" }
+func (mockWallet) Address() []byte          { return []byte("01234567890123456789") }
+
+func TestAssertRoundTripConservation(t *testing.T) {
+	ctx := context.Background()
+
+	voucher := transfertypes.ParseDenomTrace("transfer/channel-1/uatom").IBCDenom()
+	chains := func(endBalance, endSupply int64, returnAck string) (*mockRoundTripChain, *mockRoundTripChain) {
+		a := &mockRoundTripChain{
+			mockBalanceChain: mockBalanceChain{Balances: []int64{1000, endBalance}},
+			Cfg:              ibc.ChainConfig{ChainID: "a", Denom: "uatom", Bech32Prefix: "cosmos"},
+			Tx:               ibc.Tx{Height: 1, GasSpent: 50, Packet: ibc.Packet{Sequence: 1, SourceChannel: "channel-0", DestChannel: "channel-1"}},
+			Ack:              []byte(`{"result":"AQ=="}`),
+		}
+		b := &mockRoundTripChain{
+			Cfg:      ibc.ChainConfig{ChainID: "b", Denom: "uosmo", Bech32Prefix: "osmo"},
+			Tx:       ibc.Tx{Height: 1, GasSpent: 50, Packet: ibc.Packet{Sequence: 1, SourceChannel: "channel-1", DestChannel: "channel-0"}},
+			Ack:      []byte(returnAck),
+			Supplies: []int64{0, 100, endSupply},
+		}
+		return a, b
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		a, b := chains(995, 0, `{"result":"AQ=="}`)
+		require.NoError(t, AssertRoundTripConservation(ctx, a, b, "channel-0", "channel-1", mockWallet{}, 100))
+
+		require.Equal(t, "uatom", a.GotTransfers[0].Denom)
+		require.Regexp(t, "^osmo1", a.GotTransfers[0].Address)
+		require.Equal(t, []ibc.WalletAmount{{Address: "cosmos1user", Denom: voucher, Amount: 100}}, b.GotTransfers)
+	})
+
+	t.Run("balance not conserved", func(t *testing.T) {
+		a, b := chains(900, 0, `{"result":"AQ=="}`)
+		err := AssertRoundTripConservation(ctx, a, b, "channel-0", "channel-1", mockWallet{}, 100)
+		require.ErrorContains(t, err, "balance of cosmos1user is 900uatom after the round trip, expected 995uatom")
+	})
+
+	t.Run("vouchers not burned", func(t *testing.T) {
+		a, b := chains(995, 100, `{"result":"AQ=="}`)
+		err := AssertRoundTripConservation(ctx, a, b, "channel-0", "channel-1", mockWallet{}, 100)
+		require.ErrorContains(t, err, "is 100 after the round trip, expected 0")
+	})
+
+	t.Run("return transfer failed", func(t *testing.T) {
+		a, b := chains(995, 0, `{"error":"boom"}`)
+		err := AssertRoundTripConservation(ctx, a, b, "channel-0", "channel-1", mockWallet{}, 100)
+		require.ErrorContains(t, err, "transfer back to a: packet 1 acknowledged with error: boom")
+	})
+
+	t.Run("wrong counterparty channel", func(t *testing.T) {
+		a, b := chains(995, 0, `{"result":"AQ=="}`)
+		err := AssertRoundTripConservation(ctx, a, b, "channel-0", "channel-2", mockWallet{}, 100)
+		require.ErrorContains(t, err, "was received on channel-1, expected channel-2")
+	})
+}
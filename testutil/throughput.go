@@ -0,0 +1,157 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// defaultThroughputConcurrency is how many load transactions MeasureThroughput keeps in flight by default.
+const defaultThroughputConcurrency = 10
+
+// ThroughputChain is a chain that can report the transactions and gas usage of its blocks.
+type ThroughputChain interface {
+	ChainHeighter
+	// BlockTxStats returns the number of transactions in the block at height and the total gas they used.
+	BlockTxStats(ctx context.Context, height uint64) (numTxs int, gasUsed int64, err error)
+}
+
+// FundsSender sends funds from a single account, managing its account sequence
+// so that it can send concurrently, such as a cosmos.SequencedBroadcaster.
+type FundsSender interface {
+	// SendFunds sends amount to its address and returns once the transaction is included.
+	SendFunds(ctx context.Context, amount ibc.WalletAmount) error
+}
+
+// ThroughputOptions configures the load generated by MeasureThroughput.
+type ThroughputOptions struct {
+	// Sender sends each transaction of the load, a bank send of Amount,
+	// e.g. 1uatom to a recipient, unless SendTx is set.
+	Sender FundsSender
+	Amount ibc.WalletAmount
+
+	// SendTx submits a single transaction of a custom load, such as a contract execution,
+	// and returns once it is included. SendTx is called concurrently,
+	// so it must manage the account sequences of its signers, e.g. through a cosmos.SequencedBroadcaster.
+	SendTx func(ctx context.Context) error
+
+	// Concurrency is the maximum number of SendTx calls in flight. Defaults to 10.
+	Concurrency int
+}
+
+// Throughput is the chain performance measured by MeasureThroughput.
+type Throughput struct {
+	Duration time.Duration
+
+	// Blocks produced during the measurement, i.e. heights StartHeight+1 through EndHeight.
+	StartHeight, EndHeight uint64
+
+	// Transactions included in the measured blocks, including transactions not sent by the load.
+	Txs          int
+	TxsPerSecond float64
+
+	// Average gas used per measured block.
+	AvgBlockGasUsed float64
+
+	// Load transactions submitted while measuring, and how many of them failed.
+	Submitted, Failed int
+}
+
+// String formats the throughput for test logs.
+func (t Throughput) String() string {
+	return fmt.Sprintf("%.2f tx/s (%d txs in %d blocks over %s), avg block gas used %.0f, %d/%d load txs failed",
+		t.TxsPerSecond, t.Txs, t.EndHeight-t.StartHeight, t.Duration, t.AvgBlockGasUsed, t.Failed, t.Submitted)
+}
+
+// MeasureThroughput submits a steady stream of transactions for the given duration,
+// the bank sends of opts.Sender or the transactions of opts.SendTx, keeping at most opts.Concurrency of them in flight,
+// and reports the transactions per second and average block gas usage of the blocks produced meanwhile.
+//
+// Failed load transactions are counted rather than returned as errors,
+// since rejections such as full mempools are expected when stressing a chain.
+func MeasureThroughput(ctx context.Context, chain ThroughputChain, duration time.Duration, opts ThroughputOptions) (Throughput, error) {
+	sendTx := opts.SendTx
+	if sendTx == nil {
+		if opts.Sender == nil {
+			return Throughput{}, errors.New("Sender or SendTx is required to measure throughput")
+		}
+		sendTx = func(ctx context.Context) error {
+			return opts.Sender.SendFunds(ctx, opts.Amount)
+		}
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultThroughputConcurrency
+	}
+
+	startHeight, err := chain.Height(ctx)
+	if err != nil {
+		return Throughput{}, fmt.Errorf("failed to get start height: %w", err)
+	}
+
+	loadCtx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	var (
+		submitted, failed int64
+		wg                sync.WaitGroup
+	)
+	start := time.Now()
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for loadCtx.Err() == nil {
+				err := sendTx(loadCtx)
+				if loadCtx.Err() != nil {
+					// The transaction was interrupted by the end of the measurement.
+					return
+				}
+				atomic.AddInt64(&submitted, 1)
+				if err != nil {
+					atomic.AddInt64(&failed, 1)
+				}
+			}
+		}()
+	}
+
+	<-loadCtx.Done()
+	elapsed := time.Since(start)
+	if err := ctx.Err(); err != nil {
+		wg.Wait()
+		return Throughput{}, err
+	}
+
+	endHeight, err := chain.Height(ctx)
+	wg.Wait()
+	if err != nil {
+		return Throughput{}, fmt.Errorf("failed to get end height: %w", err)
+	}
+
+	res := Throughput{
+		Duration:    elapsed,
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+		Submitted:   int(submitted),
+		Failed:      int(failed),
+	}
+	var gasUsed int64
+	for h := startHeight + 1; h <= endHeight; h++ {
+		n, gas, err := chain.BlockTxStats(ctx, h)
+		if err != nil {
+			return Throughput{}, fmt.Errorf("failed to get stats of block %d: %w", h, err)
+		}
+		res.Txs += n
+		gasUsed += gas
+	}
+	res.TxsPerSecond = float64(res.Txs) / elapsed.Seconds()
+	if blocks := endHeight - startHeight; blocks > 0 {
+		res.AvgBlockGasUsed = float64(gasUsed) / float64(blocks)
+	}
+	return res, nil
+}
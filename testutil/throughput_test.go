@@ -0,0 +1,124 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+type mockThroughputChain struct {
+	CurHeight int64
+}
+
+func (m *mockThroughputChain) Height(ctx context.Context) (uint64, error) {
+	return uint64(atomic.AddInt64(&m.CurHeight, 10)), nil
+}
+
+func (m *mockThroughputChain) BlockTxStats(ctx context.Context, height uint64) (int, int64, error) {
+	if height%2 == 0 {
+		return 3, 300, nil
+	}
+	return 1, 100, nil
+}
+
+// mockFundsSender records the amounts sent.
+type mockFundsSender struct {
+	mu   sync.Mutex
+	sent []ibc.WalletAmount
+}
+
+func (m *mockFundsSender) SendFunds(ctx context.Context, amount ibc.WalletAmount) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, amount)
+	time.Sleep(time.Millisecond)
+	return nil
+}
+
+func TestMeasureThroughput(t *testing.T) {
+	t.Parallel()
+
+	t.Run("happy path", func(t *testing.T) {
+		chain := mockThroughputChain{CurHeight: 0}
+
+		var inFlight, maxInFlight, calls int64
+		sendTx := func(ctx context.Context) error {
+			n := atomic.AddInt64(&inFlight, 1)
+			defer atomic.AddInt64(&inFlight, -1)
+			for {
+				max := atomic.LoadInt64(&maxInFlight)
+				if n <= max || atomic.CompareAndSwapInt64(&maxInFlight, max, n) {
+					break
+				}
+			}
+			if atomic.AddInt64(&calls, 1)%5 == 0 {
+				return errors.New("mempool is full")
+			}
+			time.Sleep(time.Millisecond)
+			return nil
+		}
+
+		res, err := MeasureThroughput(context.Background(), &chain, 50*time.Millisecond, ThroughputOptions{
+			SendTx:      sendTx,
+			Concurrency: 3,
+		})
+		require.NoError(t, err)
+
+		require.EqualValues(t, 10, res.StartHeight)
+		require.EqualValues(t, 20, res.EndHeight)
+		require.Equal(t, 20, res.Txs)
+		require.Equal(t, 200.0, res.AvgBlockGasUsed)
+		require.GreaterOrEqual(t, res.Duration, 50*time.Millisecond)
+		require.InDelta(t, float64(res.Txs)/res.Duration.Seconds(), res.TxsPerSecond, 1e-9)
+
+		require.LessOrEqual(t, maxInFlight, int64(3))
+		require.Positive(t, res.Submitted)
+		require.Positive(t, res.Failed)
+		require.Less(t, res.Failed, res.Submitted)
+	})
+
+	t.Run("bank sends", func(t *testing.T) {
+		chain := mockThroughputChain{}
+		sender := &mockFundsSender{}
+		amount := ibc.WalletAmount{Address: "cosmos1recipient", Denom: "uatom", Amount: 1}
+
+		res, err := MeasureThroughput(context.Background(), &chain, 20*time.Millisecond, ThroughputOptions{
+			Sender: sender,
+			Amount: amount,
+		})
+		require.NoError(t, err)
+
+		require.Positive(t, res.Submitted)
+		require.Zero(t, res.Failed)
+		sender.mu.Lock()
+		defer sender.mu.Unlock()
+		require.GreaterOrEqual(t, len(sender.sent), res.Submitted)
+		for _, a := range sender.sent {
+			require.Equal(t, amount, a)
+		}
+	})
+
+	t.Run("missing load", func(t *testing.T) {
+		chain := mockThroughputChain{}
+
+		_, err := MeasureThroughput(context.Background(), &chain, time.Millisecond, ThroughputOptions{})
+		require.Error(t, err)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		chain := mockThroughputChain{}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := MeasureThroughput(ctx, &chain, time.Minute, ThroughputOptions{
+			SendTx: func(ctx context.Context) error { return nil },
+		})
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
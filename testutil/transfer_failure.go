@@ -0,0 +1,71 @@
+package testutil
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	"github.com/strangelove-ventures/interchaintest/v6/acks"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// transferFailureMaxBlocks is how many blocks AssertTransferFailure polls for the acknowledgement.
+const transferFailureMaxBlocks = 30
+
+// ChainAckBalancer is a chain that can get its acknowledgements and account balances.
+type ChainAckBalancer interface {
+	ChainAcker
+	GetBalance(ctx context.Context, address string, denom string) (int64, error)
+}
+
+// AssertTransferFailure asserts that the packet of the ICS-20 transfer tx sent from srcChain is acknowledged
+// with an error, e.g. because of an invalid receiver, and that the sender is refunded.
+// It returns the error from the acknowledgement for additional assertions, e.g. with the matchers of package acks.
+//
+// The refund is verified against before, the balance of the sender in the denom of the transfer
+// before it was sent: after the acknowledgement, the sender must have lost less than the amount of the transfer,
+// which allows for the fees of the transfer if they are paid in the same denom.
+// The acknowledgement may be relayed before AssertTransferFailure is called.
+func AssertTransferFailure(ctx context.Context, srcChain ChainAckBalancer, tx ibc.Tx, before int64) (acks.Error, error) {
+	packet := tx.Packet
+	var data transfertypes.FungibleTokenPacketData
+	if err := json.Unmarshal(packet.Data, &data); err != nil {
+		return acks.Error{}, fmt.Errorf("packet %d is not a transfer packet: %w", packet.Sequence, err)
+	}
+	amount, err := strconv.ParseInt(data.Amount, 10, 64)
+	if err != nil {
+		return acks.Error{}, fmt.Errorf("invalid amount %q of packet %d: %w", data.Amount, packet.Sequence, err)
+	}
+	// The sender holds the denom of the trace, either the native denom or an ibc/ voucher.
+	denom := transfertypes.ParseDenomTrace(data.Denom).IBCDenom()
+
+	h, err := srcChain.Height(ctx)
+	if err != nil {
+		return acks.Error{}, err
+	}
+	ack, err := PollForAck(ctx, srcChain, tx.Height+1, h+transferFailureMaxBlocks, packet)
+	if err != nil {
+		return acks.Error{}, fmt.Errorf("no acknowledgement after height %d: %w", tx.Height, err)
+	}
+
+	channelAck, err := acks.Parse(ack.Acknowledgement)
+	if err != nil {
+		return acks.Error{}, err
+	}
+	if channelAck.Success() {
+		return acks.Error{}, errors.New("packet was acknowledged successfully, expected an error acknowledgement")
+	}
+
+	after, err := srcChain.GetBalance(ctx, data.Sender, denom)
+	if err != nil {
+		return *channelAck.Error, fmt.Errorf("failed to get balance of sender %s: %w", data.Sender, err)
+	}
+	if lost := before - after; lost < 0 || lost >= amount {
+		return *channelAck.Error, fmt.Errorf("sender %s was not refunded: balance went from %d%s to %d%s, transfer of %d%s", data.Sender, before, denom, after, denom, amount, denom)
+	}
+
+	return *channelAck.Error, nil
+}
@@ -0,0 +1,109 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+// mockBalanceChain returns Balances in order from GetBalance.
+type mockBalanceChain struct {
+	mockChain
+
+	Balances []int64
+
+	GotDenoms []string
+}
+
+func (m *mockBalanceChain) GetBalance(ctx context.Context, address string, denom string) (int64, error) {
+	m.GotDenoms = append(m.GotDenoms, denom)
+	bal := m.Balances[0]
+	m.Balances = m.Balances[1:]
+	return bal, nil
+}
+
+func TestAssertTransferFailure(t *testing.T) {
+	ctx := context.Background()
+
+	transferPacket := func(denom string) ibc.Packet {
+		return ibc.Packet{
+			Sequence:      1,
+			SourceChannel: "channel-0",
+			Data: transfertypes.NewFungibleTokenPacketData(
+				denom, "100", "cosmos1sender", "osmo1truncated", "",
+			).GetBytes(),
+		}
+	}
+	transferTx := func(packet ibc.Packet) ibc.Tx {
+		return ibc.Tx{Height: 5, Packet: packet}
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		packet := transferPacket("uatom")
+		chain := mockBalanceChain{
+			mockChain: mockChain{FoundAcks: []ibc.PacketAcknowledgement{
+				{Packet: packet, Acknowledgement: []byte(`{"error":"decoding bech32 failed"}`)},
+			}},
+			// The sender paid 10uatom of fees.
+			Balances: []int64{990},
+		}
+
+		ackErr, err := AssertTransferFailure(ctx, &chain, transferTx(packet), 1000)
+		require.NoError(t, err)
+		require.Equal(t, "decoding bech32 failed", ackErr.Msg)
+		require.Equal(t, []string{"uatom"}, chain.GotDenoms)
+		// The acknowledgement is searched from the block after the transfer.
+		require.Equal(t, uint64(6), chain.GotHeights[0])
+	})
+
+	t.Run("voucher denom", func(t *testing.T) {
+		packet := transferPacket("transfer/channel-1/uosmo")
+		chain := mockBalanceChain{
+			mockChain: mockChain{FoundAcks: []ibc.PacketAcknowledgement{
+				{Packet: packet, Acknowledgement: []byte(`{"error":"boom"}`)},
+			}},
+			Balances: []int64{100},
+		}
+
+		_, err := AssertTransferFailure(ctx, &chain, transferTx(packet), 100)
+		require.NoError(t, err)
+		require.Equal(t, transfertypes.ParseDenomTrace("transfer/channel-1/uosmo").IBCDenom(), chain.GotDenoms[0])
+	})
+
+	t.Run("success ack", func(t *testing.T) {
+		packet := transferPacket("uatom")
+		chain := mockBalanceChain{
+			mockChain: mockChain{FoundAcks: []ibc.PacketAcknowledgement{
+				{Packet: packet, Acknowledgement: []byte(`{"result":"AQ=="}`)},
+			}},
+			Balances: []int64{900},
+		}
+
+		_, err := AssertTransferFailure(ctx, &chain, transferTx(packet), 1000)
+		require.ErrorContains(t, err, "acknowledged successfully")
+	})
+
+	t.Run("not refunded", func(t *testing.T) {
+		packet := transferPacket("uatom")
+		chain := mockBalanceChain{
+			mockChain: mockChain{FoundAcks: []ibc.PacketAcknowledgement{
+				{Packet: packet, Acknowledgement: []byte(`{"error":"boom"}`)},
+			}},
+			Balances: []int64{900},
+		}
+
+		ackErr, err := AssertTransferFailure(ctx, &chain, transferTx(packet), 1000)
+		require.ErrorContains(t, err, "balance went from 1000uatom to 900uatom, transfer of 100uatom")
+		require.Equal(t, "boom", ackErr.Msg)
+	})
+
+	t.Run("not a transfer packet", func(t *testing.T) {
+		chain := mockBalanceChain{}
+
+		_, err := AssertTransferFailure(ctx, &chain, transferTx(ibc.Packet{Data: []byte("garbage")}), 0)
+		require.ErrorContains(t, err, "not a transfer packet")
+	})
+}
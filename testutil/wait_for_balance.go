@@ -0,0 +1,43 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// balancePollInterval is how often WaitForBalance queries the balance.
+var balancePollInterval = time.Second
+
+// BalanceGetter is a chain that can report the balance of an address, such as an ibc.Chain.
+type BalanceGetter interface {
+	GetBalance(ctx context.Context, address string, denom string) (int64, error)
+}
+
+// WaitForBalance blocks until the balance of address in denom on chain equals expected, e.g. once a relayer
+// delivered an IBC transfer, which is more robust than waiting a fixed number of blocks as relaying latency varies.
+// If the balance does not equal expected within timeout, an error is returned with the last observed balance.
+func WaitForBalance(ctx context.Context, chain BalanceGetter, address, denom string, expected int64, timeout time.Duration) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var lastBalance int64
+	for {
+		bal, err := chain.GetBalance(ctx, address, denom)
+		if err == nil {
+			if bal == expected {
+				return bal, nil
+			}
+			lastBalance = bal
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return lastBalance, fmt.Errorf("balance of %s is not %d%s: %w (last error: %v)", address, expected, denom, ctx.Err(), err)
+			}
+			return lastBalance, fmt.Errorf("balance of %s is not %d%s, last balance %d%s: %w", address, expected, denom, lastBalance, denom, ctx.Err())
+		case <-time.After(balancePollInterval):
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockBalanceGetter struct {
+	Balance int64
+	Err     error
+}
+
+func (m *mockBalanceGetter) GetBalance(ctx context.Context, address string, denom string) (int64, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	return atomic.AddInt64(&m.Balance, 100), m.Err
+}
+
+func TestWaitForBalance(t *testing.T) {
+	interval := balancePollInterval
+	balancePollInterval = time.Millisecond
+	t.Cleanup(func() { balancePollInterval = interval })
+
+	t.Run("happy path", func(t *testing.T) {
+		chain := mockBalanceGetter{Balance: 0}
+
+		bal, err := WaitForBalance(context.Background(), &chain, "cosmos1abc", "uatom", 500, time.Minute)
+		require.NoError(t, err)
+		require.EqualValues(t, 500, bal)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		chain := mockBalanceGetter{Balance: 50}
+
+		bal, err := WaitForBalance(context.Background(), &chain, "cosmos1abc", "uatom", 500, 20*time.Millisecond)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Contains(t, err.Error(), "balance of cosmos1abc is not 500uatom, last balance")
+		require.Positive(t, bal)
+		require.NotEqual(t, int64(500), bal)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		chain := mockBalanceGetter{Err: errors.New("boom")}
+
+		_, err := WaitForBalance(context.Background(), &chain, "cosmos1abc", "uatom", 500, 20*time.Millisecond)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Contains(t, err.Error(), "boom")
+	})
+}
@@ -12,7 +12,18 @@ type ChainHeighter interface {
 	Height(ctx context.Context) (uint64, error)
 }
 
+// BlockProducer is a chain that may produce blocks only on demand of the test,
+// e.g. a chain running against CometMock without a block interval.
+type BlockProducer interface {
+	ChainHeighter
+	// ProducesBlocksOnDemand reports whether the chain produces blocks only when AdvanceBlocks is called.
+	ProducesBlocksOnDemand() bool
+	// AdvanceBlocks produces n blocks.
+	AdvanceBlocks(ctx context.Context, n int) error
+}
+
 // WaitForBlocks blocks until all chains reach a block height delta equal to or greater than the delta argument.
+// The blocks of BlockProducer chains producing blocks on demand are produced instead.
 // If a ChainHeighter does not monotonically increase the height, this function may block program execution indefinitely.
 func WaitForBlocks(ctx context.Context, delta int, chains ...ChainHeighter) error {
 	if len(chains) == 0 {
@@ -22,6 +33,12 @@ func WaitForBlocks(ctx context.Context, delta int, chains ...ChainHeighter) erro
 	for i := range chains {
 		chain := chains[i]
 		eg.Go(func() error {
+			if p, ok := chain.(BlockProducer); ok && p.ProducesBlocksOnDemand() {
+				if delta < 1 {
+					return nil
+				}
+				return p.AdvanceBlocks(egCtx, delta)
+			}
 			h := &height{Chain: chain}
 			return h.WaitForDelta(egCtx, delta)
 		})
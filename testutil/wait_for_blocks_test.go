@@ -35,6 +35,22 @@ func (m *mockChainHeighterFixed) Height(ctx context.Context) (uint64, error) {
 	return uint64(m.CurHeight), m.Err
 }
 
+// mockBlockProducer only produces blocks when advanced if OnDemand is set.
+type mockBlockProducer struct {
+	mockChainHeighterFixed
+	OnDemand bool
+}
+
+func (m *mockBlockProducer) ProducesBlocksOnDemand() bool { return m.OnDemand }
+
+func (m *mockBlockProducer) AdvanceBlocks(ctx context.Context, n int) error {
+	if ctx == nil {
+		panic("nil context")
+	}
+	atomic.AddInt64(&m.CurHeight, int64(n))
+	return m.Err
+}
+
 func TestWaitForBlocks(t *testing.T) {
 	t.Parallel()
 
@@ -80,6 +96,32 @@ func TestWaitForBlocks(t *testing.T) {
 		// Because 0 is always invalid height, we do not start testing for the delta until height > 0.
 		require.EqualValues(t, 2, chain.CurHeight)
 	})
+
+	t.Run("blocks produced on demand", func(t *testing.T) {
+		var (
+			producer = mockBlockProducer{mockChainHeighterFixed: mockChainHeighterFixed{CurHeight: 10}, OnDemand: true}
+			chain    = mockChainHeighter{CurHeight: 5}
+		)
+
+		err := WaitForBlocks(context.Background(), 3, &producer, &chain)
+
+		require.NoError(t, err)
+		require.EqualValues(t, 13, producer.CurHeight)
+
+		require.NoError(t, WaitForBlocks(context.Background(), 0, &producer))
+		require.EqualValues(t, 13, producer.CurHeight)
+	})
+
+	t.Run("blocks produced automatically", func(t *testing.T) {
+		producer := mockBlockProducer{mockChainHeighterFixed: mockChainHeighterFixed{CurHeight: 10}}
+		producer.Err = errors.New("height go boom")
+
+		// The height of a chain producing its own blocks is queried, as for any other chain.
+		err := WaitForBlocks(context.Background(), 1, &producer)
+
+		require.EqualError(t, err, "height go boom")
+		require.EqualValues(t, 10, producer.CurHeight)
+	})
 }
 
 func TestWaitForInSync(t *testing.T) {
@@ -0,0 +1,45 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// clientStatusExpired is the status of a light client whose trusting period passed since its latest consensus state.
+const clientStatusExpired = "Expired"
+
+// ClientStatusQuerier is a chain that can report the status of one of its IBC light clients, e.g. Active or Expired.
+type ClientStatusQuerier interface {
+	QueryClientStatus(ctx context.Context, clientID string) (string, error)
+}
+
+// ShortTrustingPeriodClientOpts returns client options with the given trusting period, such as 30 seconds,
+// so that the created clients expire within the test once they are no longer updated.
+// Wait for the expiry with WaitForClientExpiry; clients stay active while a started relayer refreshes them.
+func ShortTrustingPeriodClientOpts(trustingPeriod time.Duration) ibc.CreateClientOptions {
+	return ibc.CreateClientOptions{TrustingPeriod: trustingPeriod.String()}
+}
+
+// WaitForClientExpiry blocks until the status of the light client with clientID on chain is Expired,
+// e.g. after creating it with ShortTrustingPeriodClientOpts.
+// If ctx is done first, the error includes the last observed status.
+func WaitForClientExpiry(ctx context.Context, chain ClientStatusQuerier, clientID string) error {
+	for {
+		status, err := chain.QueryClientStatus(ctx, clientID)
+		if err == nil && status == clientStatusExpired {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return fmt.Errorf("client %s not expired: %w (last error: %v)", clientID, ctx.Err(), err)
+			}
+			return fmt.Errorf("client %s not expired, status %s: %w", clientID, status, ctx.Err())
+		case <-time.After(clientUpdatePollInterval):
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// mockClientStatusQuerier reports the client as Active for the first ActiveQueries queries, then as Expired.
+type mockClientStatusQuerier struct {
+	ActiveQueries int64
+	Err           error
+
+	queries int64
+}
+
+func (m *mockClientStatusQuerier) QueryClientStatus(ctx context.Context, clientID string) (string, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	if m.Err != nil {
+		return "", m.Err
+	}
+	if atomic.AddInt64(&m.queries, 1) <= m.ActiveQueries {
+		return "Active", nil
+	}
+	return "Expired", nil
+}
+
+func TestShortTrustingPeriodClientOpts(t *testing.T) {
+	opts := ShortTrustingPeriodClientOpts(30 * time.Second)
+	require.Equal(t, "30s", opts.TrustingPeriod)
+	require.NoError(t, opts.Validate())
+}
+
+func TestWaitForClientExpiry(t *testing.T) {
+	clientUpdatePollInterval = time.Millisecond
+
+	t.Run("happy path", func(t *testing.T) {
+		chain := mockClientStatusQuerier{ActiveQueries: 3}
+
+		require.NoError(t, WaitForClientExpiry(context.Background(), &chain, "07-tendermint-0"))
+		require.EqualValues(t, 4, chain.queries)
+	})
+
+	t.Run("already expired", func(t *testing.T) {
+		chain := mockClientStatusQuerier{}
+
+		require.NoError(t, WaitForClientExpiry(context.Background(), &chain, "07-tendermint-0"))
+		require.EqualValues(t, 1, chain.queries)
+	})
+
+	t.Run("still active", func(t *testing.T) {
+		chain := mockClientStatusQuerier{ActiveQueries: 1 << 30}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		err := WaitForClientExpiry(ctx, &chain, "07-tendermint-0")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Contains(t, err.Error(), "status Active")
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		chain := mockClientStatusQuerier{Err: errors.New("boom")}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		err := WaitForClientExpiry(ctx, &chain, "07-tendermint-0")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Contains(t, err.Error(), "boom")
+	})
+}
@@ -0,0 +1,85 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ClientConsensusQuerier is a chain that can report the latest consensus state
+// tracked by one of its IBC light clients.
+type ClientConsensusQuerier interface {
+	ClientHeightQuerier
+	QueryClientConsensusTimestamp(ctx context.Context, clientID string) (time.Time, error)
+}
+
+// ClientUpdate is an observed update of a light client's latest consensus state.
+type ClientUpdate struct {
+	Height    uint64
+	Timestamp time.Time
+}
+
+func (u ClientUpdate) String() string {
+	return fmt.Sprintf("height %d at %s", u.Height, u.Timestamp.UTC().Format(time.RFC3339))
+}
+
+// WaitForClientRefresh blocks until the latest consensus state of the light client with clientID on chain
+// has been updated at least minUpdates times, returning the observed updates.
+// This is useful to assert that a relayer refreshes clients before they expire, even when no packets are relayed.
+// If fewer updates are observed within the given duration, the error lists the observed updates.
+func WaitForClientRefresh(ctx context.Context, chain ClientConsensusQuerier, clientID string, minUpdates int, within time.Duration) ([]ClientUpdate, error) {
+	ctx, cancel := context.WithTimeout(ctx, within)
+	defer cancel()
+
+	latest := func() (ClientUpdate, error) {
+		h, err := chain.QueryClientLatestHeight(ctx, clientID)
+		if err != nil {
+			return ClientUpdate{}, err
+		}
+		ts, err := chain.QueryClientConsensusTimestamp(ctx, clientID)
+		if err != nil {
+			return ClientUpdate{}, err
+		}
+		return ClientUpdate{Height: h, Timestamp: ts}, nil
+	}
+
+	initial, err := latest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query client %s: %w", clientID, err)
+	}
+
+	var (
+		updates []ClientUpdate
+		last    = initial
+	)
+	for len(updates) < minUpdates {
+		select {
+		case <-ctx.Done():
+			return updates, fmt.Errorf(
+				"client %s updated %d times within %s, expected at least %d: initial %s, observed updates: [%s]: %w",
+				clientID, len(updates), within, minUpdates, initial, formatClientUpdates(updates), ctx.Err(),
+			)
+		case <-time.After(clientUpdatePollInterval):
+		}
+
+		u, err := latest()
+		if err != nil {
+			// Tolerate transient query errors; the deadline bounds the wait.
+			continue
+		}
+		if u.Height > last.Height {
+			updates = append(updates, u)
+			last = u
+		}
+	}
+	return updates, nil
+}
+
+func formatClientUpdates(updates []ClientUpdate) string {
+	s := make([]string, len(updates))
+	for i, u := range updates {
+		s[i] = u.String()
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,55 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// mockClientRefresher reports a new consensus height every UpdateEvery queries.
+type mockClientRefresher struct {
+	UpdateEvery int
+
+	calls  int
+	height uint64
+}
+
+func (m *mockClientRefresher) QueryClientLatestHeight(ctx context.Context, clientID string) (uint64, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.calls++
+	if m.UpdateEvery > 0 && m.calls%m.UpdateEvery == 0 {
+		m.height++
+	}
+	return m.height, nil
+}
+
+func (m *mockClientRefresher) QueryClientConsensusTimestamp(ctx context.Context, clientID string) (time.Time, error) {
+	return time.Unix(int64(m.height), 0), nil
+}
+
+func TestWaitForClientRefresh(t *testing.T) {
+	clientUpdatePollInterval = time.Millisecond
+
+	t.Run("happy path", func(t *testing.T) {
+		chain := mockClientRefresher{UpdateEvery: 3}
+
+		updates, err := WaitForClientRefresh(context.Background(), &chain, "07-tendermint-0", 2, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, updates, 2)
+		require.Less(t, updates[0].Height, updates[1].Height)
+		require.True(t, updates[0].Timestamp.Before(updates[1].Timestamp))
+	})
+
+	t.Run("not refreshed", func(t *testing.T) {
+		chain := mockClientRefresher{}
+
+		updates, err := WaitForClientRefresh(context.Background(), &chain, "07-tendermint-0", 1, 20*time.Millisecond)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Contains(t, err.Error(), "updated 0 times")
+		require.Empty(t, updates)
+	})
+}
@@ -0,0 +1,45 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// clientUpdatePollInterval is how often WaitForClientUpdate queries the client's latest height.
+var clientUpdatePollInterval = time.Second
+
+// ClientHeightQuerier is a chain that can report the latest height
+// of the consensus state tracked by one of its IBC light clients.
+type ClientHeightQuerier interface {
+	QueryClientLatestHeight(ctx context.Context, clientID string) (uint64, error)
+}
+
+// WaitForClientUpdate blocks until the latest consensus height of the light client with clientID on chain
+// advances past sinceHeight, returning the new height.
+// This is useful to observe a relayer keeping a client alive with MsgUpdateClient.
+// If the client is not updated within timeout, an error is returned.
+func WaitForClientUpdate(ctx context.Context, chain ClientHeightQuerier, clientID string, sinceHeight uint64, timeout time.Duration) (uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var lastHeight uint64
+	for {
+		h, err := chain.QueryClientLatestHeight(ctx, clientID)
+		if err == nil {
+			if h > sinceHeight {
+				return h, nil
+			}
+			lastHeight = h
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return 0, fmt.Errorf("client %s not updated past height %d: %w (last error: %v)", clientID, sinceHeight, ctx.Err(), err)
+			}
+			return 0, fmt.Errorf("client %s not updated past height %d, latest height %d: %w", clientID, sinceHeight, lastHeight, ctx.Err())
+		case <-time.After(clientUpdatePollInterval):
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockClientHeightQuerier struct {
+	CurHeight int64
+	Err       error
+}
+
+func (m *mockClientHeightQuerier) QueryClientLatestHeight(ctx context.Context, clientID string) (uint64, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	return uint64(atomic.AddInt64(&m.CurHeight, 1)), m.Err
+}
+
+func TestWaitForClientUpdate(t *testing.T) {
+	clientUpdatePollInterval = time.Millisecond
+
+	t.Run("happy path", func(t *testing.T) {
+		chain := mockClientHeightQuerier{CurHeight: 10}
+
+		h, err := WaitForClientUpdate(context.Background(), &chain, "07-tendermint-0", 15, time.Minute)
+		require.NoError(t, err)
+		require.EqualValues(t, 16, h)
+	})
+
+	t.Run("already updated", func(t *testing.T) {
+		chain := mockClientHeightQuerier{CurHeight: 20}
+
+		h, err := WaitForClientUpdate(context.Background(), &chain, "07-tendermint-0", 15, time.Minute)
+		require.NoError(t, err)
+		require.EqualValues(t, 21, h)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		chain := mockClientHeightQuerier{CurHeight: 10, Err: errors.New("boom")}
+
+		_, err := WaitForClientUpdate(context.Background(), &chain, "07-tendermint-0", 15, 20*time.Millisecond)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Contains(t, err.Error(), "boom")
+	})
+}
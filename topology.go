@@ -0,0 +1,232 @@
+package interchaintest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"golang.org/x/sync/errgroup"
+)
+
+// Topology is the IBC wiring of the chains of an Interchain:
+// every chain with its clients, connections, and channels, as reported by the relayers.
+// Each connection and channel refers to the chain at its other end, forming a graph of the chains.
+type Topology struct {
+	// Chains sorted by chain ID.
+	Chains []TopologyChain
+}
+
+// TopologyChain is the IBC state of a single chain.
+// Clients, connections, and channels are sorted by their IDs.
+type TopologyChain struct {
+	ChainID string
+
+	Clients     []TopologyClient
+	Connections []TopologyConnection
+	Channels    []TopologyChannel
+}
+
+// TopologyClient is a light client of a counterparty chain.
+type TopologyClient struct {
+	ID                  string
+	CounterpartyChainID string
+}
+
+// TopologyConnection is a connection end, with the client it uses on its own chain.
+type TopologyConnection struct {
+	ID       string
+	ClientID string
+	State    ibc.ConnectionState
+
+	// CounterpartyChainID is the chain ID tracked by the connection's client.
+	CounterpartyChainID      string
+	CounterpartyClientID     string
+	CounterpartyConnectionID string
+}
+
+// TopologyChannel is a channel end, with the connection it uses on its own chain.
+type TopologyChannel struct {
+	PortID       string
+	ChannelID    string
+	ConnectionID string
+	State        ibc.ChannelState
+	Ordering     ibc.ChannelOrdering
+	Version      string
+
+	// CounterpartyChainID is the chain ID tracked by the client of the channel's connection.
+	CounterpartyChainID   string
+	CounterpartyPortID    string
+	CounterpartyChannelID string
+}
+
+// Chain returns the IBC state of the chain with chainID.
+func (t Topology) Chain(chainID string) (TopologyChain, bool) {
+	for _, c := range t.Chains {
+		if c.ChainID == chainID {
+			return c, true
+		}
+	}
+	return TopologyChain{}, false
+}
+
+// String returns a human-readable dump of the topology, with one line per client, connection, and channel.
+func (t Topology) String() string {
+	var b strings.Builder
+	for _, c := range t.Chains {
+		fmt.Fprintf(&b, "%s\n", c.ChainID)
+		for _, cl := range c.Clients {
+			fmt.Fprintf(&b, "  client %s -> %s\n", cl.ID, cl.CounterpartyChainID)
+		}
+		for _, conn := range c.Connections {
+			fmt.Fprintf(&b, "  connection %s (%s) %s -> %s %s (%s)\n",
+				conn.ID, conn.ClientID, conn.State, conn.CounterpartyChainID, conn.CounterpartyConnectionID, conn.CounterpartyClientID)
+		}
+		for _, ch := range c.Channels {
+			fmt.Fprintf(&b, "  channel %s/%s (%s) %s %s %s -> %s %s/%s\n",
+				ch.PortID, ch.ChannelID, ch.ConnectionID, ch.State, ch.Ordering, ch.Version,
+				ch.CounterpartyChainID, ch.CounterpartyPortID, ch.CounterpartyChannelID)
+		}
+	}
+	return b.String()
+}
+
+// ExportTopology queries the clients, connections, and channels of every chain of the Interchain
+// through a relayer configured for the chain.
+// Chains without a relayer are included without any IBC state.
+//
+// ExportTopology must be called after Build.
+func (ic *Interchain) ExportTopology(ctx context.Context) (Topology, error) {
+	if !ic.built {
+		return Topology{}, fmt.Errorf("ExportTopology called before Build")
+	}
+
+	// Any relayer configured for a chain can query it.
+	chainRelayers := make(map[ibc.Chain]ibc.Relayer, len(ic.chains))
+	for r, chains := range ic.relayerChains() {
+		for _, c := range chains {
+			chainRelayers[c] = r
+		}
+	}
+
+	chains := make([]ibc.Chain, 0, len(ic.chains))
+	for c := range ic.chains {
+		chains = append(chains, c)
+	}
+	sort.Slice(chains, func(i, j int) bool {
+		return ic.chains[chains[i]] < ic.chains[chains[j]]
+	})
+
+	topology := Topology{Chains: make([]TopologyChain, len(chains))}
+	var eg errgroup.Group
+	for i, c := range chains {
+		tc := &topology.Chains[i]
+		tc.ChainID = ic.chains[c]
+		r, ok := chainRelayers[c]
+		if !ok {
+			continue
+		}
+		eg.Go(func() error {
+			return queryTopologyChain(ctx, r, tc)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return Topology{}, err
+	}
+	return topology, nil
+}
+
+// queryTopologyChain fills the clients, connections, and channels of tc from r.
+func queryTopologyChain(ctx context.Context, r ibc.Relayer, tc *TopologyChain) error {
+	rep := ibc.NopRelayerExecReporter{}
+
+	clients, err := r.GetClients(ctx, rep, tc.ChainID)
+	if err != nil {
+		return fmt.Errorf("failed to get clients of %s: %w", tc.ChainID, err)
+	}
+	conns, err := r.GetConnections(ctx, rep, tc.ChainID)
+	if err != nil {
+		return fmt.Errorf("failed to get connections of %s: %w", tc.ChainID, err)
+	}
+	channels, err := r.GetChannels(ctx, rep, tc.ChainID)
+	if err != nil {
+		return fmt.Errorf("failed to get channels of %s: %w", tc.ChainID, err)
+	}
+
+	clientChains := make(map[string]string, len(clients))
+	for _, c := range clients {
+		clientChains[c.ClientID] = c.ClientState.ChainID
+		tc.Clients = append(tc.Clients, TopologyClient{
+			ID:                  c.ClientID,
+			CounterpartyChainID: c.ClientState.ChainID,
+		})
+	}
+
+	connChains := make(map[string]string, len(conns))
+	for _, c := range conns {
+		conn := TopologyConnection{
+			ID:                  c.ID,
+			ClientID:            c.ClientID,
+			State:               c.State,
+			CounterpartyChainID: clientChains[c.ClientID],
+		}
+		if c.Counterparty != nil {
+			conn.CounterpartyClientID = c.Counterparty.ClientId
+			conn.CounterpartyConnectionID = c.Counterparty.ConnectionId
+		}
+		connChains[c.ID] = conn.CounterpartyChainID
+		tc.Connections = append(tc.Connections, conn)
+	}
+
+	for _, c := range channels {
+		ch := TopologyChannel{
+			PortID:                c.PortID,
+			ChannelID:             c.ChannelID,
+			State:                 c.State,
+			Ordering:              c.Ordering,
+			Version:               c.Version,
+			CounterpartyPortID:    c.Counterparty.PortID,
+			CounterpartyChannelID: c.Counterparty.ChannelID,
+		}
+		if len(c.ConnectionHops) > 0 {
+			ch.ConnectionID = c.ConnectionHops[0]
+			ch.CounterpartyChainID = connChains[ch.ConnectionID]
+		}
+		tc.Channels = append(tc.Channels, ch)
+	}
+
+	sort.Slice(tc.Clients, func(i, j int) bool { return tc.Clients[i].ID < tc.Clients[j].ID })
+	sort.Slice(tc.Connections, func(i, j int) bool { return tc.Connections[i].ID < tc.Connections[j].ID })
+	sort.Slice(tc.Channels, func(i, j int) bool {
+		a, b := tc.Channels[i], tc.Channels[j]
+		if a.ChannelID != b.ChannelID {
+			return a.ChannelID < b.ChannelID
+		}
+		return a.PortID < b.PortID
+	})
+	return nil
+}
+
+// TopologyTestingT is a subset of testing.TB to log the topology of a failed test from LogTopologyOnFailure.
+type TopologyTestingT interface {
+	Cleanup(func())
+	Failed() bool
+	Logf(format string, args ...any)
+}
+
+// LogTopologyOnFailure registers a cleanup that logs the topology of ic if t failed, to aid debugging.
+// As cleanups run in reverse order, call it after registering the cleanup that closes ic.
+func LogTopologyOnFailure(ctx context.Context, t TopologyTestingT, ic *Interchain) {
+	t.Cleanup(func() {
+		if !t.Failed() {
+			return
+		}
+		topology, err := ic.ExportTopology(ctx)
+		if err != nil {
+			t.Logf("Failed to export IBC topology: %v", err)
+			return
+		}
+		t.Logf("IBC topology:\n%s", topology)
+	})
+}
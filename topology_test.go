@@ -0,0 +1,136 @@
+package interchaintest
+
+import (
+	"context"
+	"testing"
+
+	conntypes "github.com/cosmos/ibc-go/v6/modules/core/03-connection/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func openConnection(id, clientID, counterpartyID, counterpartyClientID string) *ibc.ConnectionOutput {
+	return &ibc.ConnectionOutput{
+		ID:       id,
+		ClientID: clientID,
+		State:    ibc.ConnectionStateOpen,
+		Counterparty: &conntypes.Counterparty{
+			ClientId:     counterpartyClientID,
+			ConnectionId: counterpartyID,
+		},
+	}
+}
+
+// chainIDChain is an ibc.Chain reporting only its chain ID, which is also its name.
+type chainIDChain struct {
+	ibc.Chain
+	id string
+}
+
+func (c chainIDChain) Config() ibc.ChainConfig {
+	return ibc.ChainConfig{ChainID: c.id, Name: c.id}
+}
+
+// topologyRelayer is an ibc.Relayer reporting fixed clients, connections, and channels per chain ID.
+// Calling any other method panics.
+type topologyRelayer struct {
+	ibc.Relayer
+
+	clients  map[string]ibc.ClientOutputs
+	conns    map[string]ibc.ConnectionOutputs
+	channels map[string][]ibc.ChannelOutput
+}
+
+func (r *topologyRelayer) GetClients(_ context.Context, _ ibc.RelayerExecReporter, chainID string) (ibc.ClientOutputs, error) {
+	return r.clients[chainID], nil
+}
+
+func (r *topologyRelayer) GetConnections(_ context.Context, _ ibc.RelayerExecReporter, chainID string) (ibc.ConnectionOutputs, error) {
+	return r.conns[chainID], nil
+}
+
+func (r *topologyRelayer) GetChannels(_ context.Context, _ ibc.RelayerExecReporter, chainID string) ([]ibc.ChannelOutput, error) {
+	return r.channels[chainID], nil
+}
+
+func TestInterchain_ExportTopology(t *testing.T) {
+	a, b, c := chainIDChain{id: "a"}, chainIDChain{id: "b"}, chainIDChain{id: "c"}
+	r := &topologyRelayer{
+		clients: map[string]ibc.ClientOutputs{
+			"a": {{ClientID: "07-tendermint-0", ClientState: ibc.ClientState{ChainID: "b"}}},
+			"b": {
+				{ClientID: "07-tendermint-1", ClientState: ibc.ClientState{ChainID: "a"}},
+				{ClientID: "07-tendermint-0", ClientState: ibc.ClientState{ChainID: "x"}},
+			},
+		},
+		conns: map[string]ibc.ConnectionOutputs{
+			"a": {openConnection("connection-0", "07-tendermint-0", "connection-1", "07-tendermint-1")},
+			"b": {
+				openConnection("connection-1", "07-tendermint-1", "connection-0", "07-tendermint-0"),
+				{ID: "connection-0", ClientID: "07-tendermint-0", State: ibc.ConnectionStateInit, Counterparty: &conntypes.Counterparty{ClientId: "07-tendermint-9"}},
+			},
+		},
+		channels: map[string][]ibc.ChannelOutput{
+			"a": {{
+				State: ibc.ChannelStateOpen, Ordering: ibc.ChannelOrderingUnordered, Version: "ics20-1",
+				PortID: "transfer", ChannelID: "channel-0", ConnectionHops: []string{"connection-0"},
+				Counterparty: ibc.ChannelCounterparty{PortID: "transfer", ChannelID: "channel-3"},
+			}},
+		},
+	}
+
+	ic := NewInterchain().AddChain(a).AddChain(b).AddChain(c).AddRelayer(r, "r").AddLink(InterchainLink{
+		Chain1: b, Chain2: a, Relayer: r, Path: "p",
+	})
+
+	_, err := ic.ExportTopology(context.Background())
+	require.Error(t, err, "topology must not be exported before Build")
+
+	ic.built = true
+	topology, err := ic.ExportTopology(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, Topology{Chains: []TopologyChain{
+		{
+			ChainID: "a",
+			Clients: []TopologyClient{{ID: "07-tendermint-0", CounterpartyChainID: "b"}},
+			Connections: []TopologyConnection{{
+				ID: "connection-0", ClientID: "07-tendermint-0", State: ibc.ConnectionStateOpen,
+				CounterpartyChainID: "b", CounterpartyClientID: "07-tendermint-1", CounterpartyConnectionID: "connection-1",
+			}},
+			Channels: []TopologyChannel{{
+				PortID: "transfer", ChannelID: "channel-0", ConnectionID: "connection-0",
+				State: ibc.ChannelStateOpen, Ordering: ibc.ChannelOrderingUnordered, Version: "ics20-1",
+				CounterpartyChainID: "b", CounterpartyPortID: "transfer", CounterpartyChannelID: "channel-3",
+			}},
+		},
+		{
+			ChainID: "b",
+			Clients: []TopologyClient{
+				{ID: "07-tendermint-0", CounterpartyChainID: "x"},
+				{ID: "07-tendermint-1", CounterpartyChainID: "a"},
+			},
+			Connections: []TopologyConnection{
+				{ID: "connection-0", ClientID: "07-tendermint-0", State: ibc.ConnectionStateInit, CounterpartyChainID: "x", CounterpartyClientID: "07-tendermint-9"},
+				{
+					ID: "connection-1", ClientID: "07-tendermint-1", State: ibc.ConnectionStateOpen,
+					CounterpartyChainID: "a", CounterpartyClientID: "07-tendermint-0", CounterpartyConnectionID: "connection-0",
+				},
+			},
+		},
+		// Chains without a relayer have no IBC state.
+		{ChainID: "c"},
+	}}, topology)
+
+	require.Equal(t, `a
+  client 07-tendermint-0 -> b
+  connection connection-0 (07-tendermint-0) STATE_OPEN -> b connection-1 (07-tendermint-1)
+  channel transfer/channel-0 (connection-0) STATE_OPEN ORDER_UNORDERED ics20-1 -> b transfer/channel-3
+b
+  client 07-tendermint-0 -> x
+  client 07-tendermint-1 -> a
+  connection connection-0 (07-tendermint-0) STATE_INIT -> x  (07-tendermint-9)
+  connection connection-1 (07-tendermint-1) STATE_OPEN -> a connection-0 (07-tendermint-0)
+c
+`, topology.String())
+}
@@ -0,0 +1,45 @@
+package interchaintest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// trustingPeriodRatio is the fraction of the unbonding time used as the client trusting period.
+// The trusting period must be shorter than the unbonding time of the tracked chain.
+const trustingPeriodRatio = 0.85
+
+// unbondingTimeQuerier is a chain that reports its staking unbonding time.
+type unbondingTimeQuerier interface {
+	QueryUnbondingTime(ctx context.Context) (time.Duration, error)
+}
+
+// ClientOptsFromUnbonding returns client options with a trusting period of 85% of the shortest
+// unbonding time among the chains, so clients stay valid on chains whose unbonding time
+// was shortened, e.g. via ModifyGenesis.
+// If none of the chains report an unbonding time, ibc.DefaultClientOpts is returned.
+func ClientOptsFromUnbonding(ctx context.Context, chains ...ibc.Chain) (ibc.CreateClientOptions, error) {
+	var minUnbonding time.Duration
+	for _, c := range chains {
+		q, ok := c.(unbondingTimeQuerier)
+		if !ok {
+			continue
+		}
+		unbonding, err := q.QueryUnbondingTime(ctx)
+		if err != nil {
+			return ibc.CreateClientOptions{}, fmt.Errorf("failed to query unbonding time of %s: %w", c.Config().ChainID, err)
+		}
+		if minUnbonding == 0 || unbonding < minUnbonding {
+			minUnbonding = unbonding
+		}
+	}
+	if minUnbonding == 0 {
+		return ibc.DefaultClientOpts(), nil
+	}
+
+	trustingPeriod := time.Duration(float64(minUnbonding) * trustingPeriodRatio).Truncate(time.Second)
+	return ibc.CreateClientOptions{TrustingPeriod: trustingPeriod.String()}, nil
+}
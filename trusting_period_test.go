@@ -0,0 +1,41 @@
+package interchaintest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+type unbondingChain struct {
+	ibc.Chain
+	unbonding time.Duration
+}
+
+func (c unbondingChain) QueryUnbondingTime(context.Context) (time.Duration, error) {
+	return c.unbonding, nil
+}
+
+func TestClientOptsFromUnbonding(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("shortest unbonding time", func(t *testing.T) {
+		opts, err := interchaintest.ClientOptsFromUnbonding(ctx,
+			unbondingChain{unbonding: 21 * 24 * time.Hour},
+			unbondingChain{unbonding: 5 * time.Minute},
+		)
+		require.NoError(t, err)
+		require.NoError(t, opts.Validate())
+		require.Equal(t, "4m15s", opts.TrustingPeriod)
+	})
+
+	t.Run("no unbonding time", func(t *testing.T) {
+		var c0, c1 ibc.Chain
+		opts, err := interchaintest.ClientOptsFromUnbonding(ctx, c0, c1)
+		require.NoError(t, err)
+		require.Equal(t, ibc.DefaultClientOpts(), opts)
+	})
+}